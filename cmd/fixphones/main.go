@@ -0,0 +1,61 @@
+// Command fixphones reports the telephones of sellers, carriers and
+// warehouses that are not stored in E.164 and, with -apply, rewrites them
+// along with their display format. Telephones that can not be parsed are
+// only reported and must be fixed by hand.
+//
+//	go run ./cmd/fixphones          # dry run
+//	go run ./cmd/fixphones -apply
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/config"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/telephones"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func main() {
+	apply := flag.Bool("apply", false, "rewrite the telephones that can be parsed")
+
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	conn, err := cfg.DB.Open()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	service := telephones.NewService(telephones.NewMariaDbRepository(conn))
+	report, err := service.Fix(context.Background(), *apply)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	action := "would fix"
+	if report.Applied {
+		action = "fixed"
+	}
+
+	for _, change := range report.Fixed {
+		fmt.Printf("%s %s %d: %q -> %s (%s)\n", action, change.Table, change.Id, change.Telephone, change.E164, change.TelephoneDisplay)
+	}
+
+	for _, change := range report.Invalid {
+		fmt.Printf("invalid %s %d: %q: %s\n", change.Table, change.Id, change.Telephone, change.Error)
+	}
+
+	fmt.Printf("%d telephones checked, %d %s, %d invalid\n", report.Checked, len(report.Fixed), action, len(report.Invalid))
+
+	if len(report.Invalid) > 0 {
+		os.Exit(1)
+	}
+}
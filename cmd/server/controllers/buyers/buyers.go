@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -16,10 +17,11 @@ type BuyerController struct {
 }
 
 type reqBuyers struct {
-	Id           int    `json:"id"`
-	CardNumberId string `json:"card_number_id"`
-	FirstName    string `json:"first_name"`
-	LastName     string `json:"last_name"`
+	Id           int     `json:"id"`
+	CardNumberId string  `json:"card_number_id"`
+	FirstName    string  `json:"first_name"`
+	LastName     string  `json:"last_name"`
+	LocalityId   *string `json:"locality_id"`
 }
 
 func NewBuyer(s buyers.Service) *BuyerController {
@@ -55,7 +57,7 @@ func (s *BuyerController) Create() gin.HandlerFunc {
 			return
 		}
 
-		buyer, resp := s.service.Create(requestData.CardNumberId, requestData.FirstName, requestData.LastName)
+		buyer, resp := s.service.Create(c.Request.Context(), requestData.CardNumberId, requestData.FirstName, requestData.LastName, requestData.LocalityId)
 
 		if resp.Err != nil {
 			c.JSON(resp.Code, gin.H{
@@ -80,7 +82,7 @@ func (s *BuyerController) GetOne() gin.HandlerFunc {
 			return
 		}
 
-		buyer, resp := s.service.GetOne(parsedId)
+		buyer, resp := s.service.GetOne(c.Request.Context(), parsedId)
 
 		if resp.Err != nil {
 			c.JSON(
@@ -99,7 +101,13 @@ func (s *BuyerController) GetOne() gin.HandlerFunc {
 
 func (s *BuyerController) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		BuyersList, resp := s.service.GetAll()
+		opts, err := listing.Parse(c.Request.URL.Query(), buyers.ListFields)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, web.DecodeError(err.Error()))
+			return
+		}
+
+		BuyersList, page, resp := s.service.GetAll(c.Request.Context(), opts)
 
 		if resp.Err != nil {
 			c.JSON(
@@ -111,7 +119,7 @@ func (s *BuyerController) GetAll() gin.HandlerFunc {
 
 		c.JSON(
 			http.StatusOK,
-			web.NewResponse(BuyersList),
+			web.NewPageResponse(BuyersList, page),
 		)
 	}
 }
@@ -127,7 +135,7 @@ func (s *BuyerController) Delete() gin.HandlerFunc {
 			return
 		}
 
-		resp := s.service.Delete(parsedId)
+		resp := s.service.Delete(c.Request.Context(), parsedId)
 		if resp.Err != nil {
 			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
 			return
@@ -172,7 +180,7 @@ func (s *BuyerController) Update() gin.HandlerFunc {
 			}
 		}
 
-		buyer, resp := s.service.Update(parsedId, requestData)
+		buyer, resp := s.service.Update(c.Request.Context(), parsedId, requestData)
 
 		if resp.Err != nil {
 			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
@@ -197,10 +205,10 @@ func (s *BuyerController) GetReportPurchaseOrders() gin.HandlerFunc {
 				c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
 				return
 			}
-			reportPurchaseOrders, resp = s.service.GetReportPurchaseOrders(parsedId)
+			reportPurchaseOrders, resp = s.service.GetReportPurchaseOrders(c.Request.Context(), parsedId)
 
 		} else {
-			reportPurchaseOrders, resp = s.service.GetReportPurchaseOrders(0)
+			reportPurchaseOrders, resp = s.service.GetReportPurchaseOrders(c.Request.Context(), 0)
 		}
 
 		if resp.Err != nil {
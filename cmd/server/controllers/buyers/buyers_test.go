@@ -11,6 +11,7 @@ import (
 	controller "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/buyers"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -91,7 +92,7 @@ var (
 func TestGetBuyer(t *testing.T) {
 	t.Run("Get all buyers", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("GetAll").Return(fakeBuyers, web.ResponseCode{})
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(fakeBuyers, listing.Page{}, web.ResponseCode{})
 
 		r := routerBuyers()
 		r.GET(defaultURL, buyerController.GetAll())
@@ -113,7 +114,7 @@ func TestGetBuyer(t *testing.T) {
 
 	t.Run("Error case", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("GetAll").Return(nil, web.ResponseCode{
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(nil, listing.Page{}, web.ResponseCode{
 			Code: http.StatusInternalServerError,
 			Err:  errServer,
 		})
@@ -138,7 +139,7 @@ func TestGetBuyer(t *testing.T) {
 func TestGetOne(t *testing.T) {
 	t.Run("Success case", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(fakeBuyers[0], web.ResponseCode{})
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(fakeBuyers[0], web.ResponseCode{})
 
 		r := routerBuyers()
 		r.GET(idRequest, buyerController.GetOne())
@@ -159,7 +160,7 @@ func TestGetOne(t *testing.T) {
 
 	t.Run("Not exist case", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(buyers.Buyer{}, web.ResponseCode{
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, web.ResponseCode{
 			Code: http.StatusNotFound,
 			Err:  errBuyerNotFound,
 		})
@@ -183,7 +184,7 @@ func TestGetOne(t *testing.T) {
 
 	t.Run("Fail when ID is not a number", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(buyers.Buyer{}, web.ResponseCode{})
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, web.ResponseCode{})
 
 		r := routerBuyers()
 		r.GET(idRequest, buyerController.GetOne())
@@ -206,7 +207,7 @@ func TestGetOne(t *testing.T) {
 func TestDeleteBuyer(t *testing.T) {
 	t.Run("Success case if exists", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(web.ResponseCode{
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(web.ResponseCode{
 			Code: http.StatusNoContent,
 		})
 
@@ -225,7 +226,7 @@ func TestDeleteBuyer(t *testing.T) {
 
 	t.Run("Error case if not exists", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(web.ResponseCode{
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(web.ResponseCode{
 			Code: http.StatusNotFound,
 			Err:  errBuyerNotFound,
 		})
@@ -249,7 +250,7 @@ func TestDeleteBuyer(t *testing.T) {
 
 	t.Run("Fail when ID is not a number", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(buyers.Buyer{}, web.ResponseCode{})
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, web.ResponseCode{})
 
 		r := routerBuyers()
 		r.DELETE(idRequest, buyerController.Delete())
@@ -272,7 +273,7 @@ func TestDeleteBuyer(t *testing.T) {
 func TestUpdateBuyer(t *testing.T) {
 	t.Run("Sucessfully case", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(fakeBuyers[0], web.ResponseCode{})
 
 		parsedFakeBuyer, err := json.Marshal(fakeBuyers[0])
@@ -299,7 +300,7 @@ func TestUpdateBuyer(t *testing.T) {
 
 	t.Run("Not found case", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(buyers.Buyer{}, web.ResponseCode{
 				Code: http.StatusNotFound,
 				Err:  errBuyerNotFound,
@@ -328,7 +329,7 @@ func TestUpdateBuyer(t *testing.T) {
 
 	t.Run("Id must be a number", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(buyers.Buyer{}, web.ResponseCode{})
 
 		parsedFakeBuyer, err := json.Marshal(fakeBuyers[0])
@@ -354,7 +355,7 @@ func TestUpdateBuyer(t *testing.T) {
 
 	t.Run("Invalid request data", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(buyers.Buyer{}, web.ResponseCode{})
 
 		r := routerBuyers()
@@ -377,7 +378,7 @@ func TestUpdateBuyer(t *testing.T) {
 
 	t.Run("Body needed", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(buyers.Buyer{}, web.ResponseCode{})
 
 		router := routerBuyers()
@@ -400,7 +401,7 @@ func TestUpdateBuyer(t *testing.T) {
 
 	t.Run("CardNumberId can't be empty", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(buyers.Buyer{}, web.ResponseCode{})
 
 		router := routerBuyers()
@@ -423,7 +424,7 @@ func TestUpdateBuyer(t *testing.T) {
 
 	t.Run("Syntax error on body", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(buyers.Buyer{}, web.ResponseCode{})
 
 		router := routerBuyers()
@@ -450,9 +451,11 @@ func TestCreateBuyer(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
+			mock.Anything,
 		).
 			Return(fakeBuyers[0], web.ResponseCode{
 				Code: http.StatusCreated,
@@ -479,13 +482,45 @@ func TestCreateBuyer(t *testing.T) {
 		assert.Equal(t, fakeBuyers[0], bodyResponse.Data)
 	})
 
+	t.Run("Create with locality_id", func(t *testing.T) {
+		localityId := "6700"
+		expectedBuyer := fakeBuyers[0]
+		expectedBuyer.LocalityId = &localityId
+
+		mockedService, buyerController := newBuyerController()
+		mockedService.On("Create", mock.Anything, "12345", "Fulano", "Beltrano", &localityId).
+			Return(expectedBuyer, web.ResponseCode{
+				Code: http.StatusCreated,
+			})
+
+		r := routerBuyers()
+		r.POST(defaultURL, buyerController.Create())
+
+		body := []byte(`{"card_number_id": "12345", "first_name": "Fulano", "last_name": "Beltrano", "locality_id": "6700"}`)
+		req, err := http.NewRequest(http.MethodPost, defaultURL, bytes.NewBuffer(body))
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var bodyResponse ObjectResponse
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, expectedBuyer, bodyResponse.Data)
+	})
+
 	t.Run("invalid request input", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
+			mock.Anything,
 		).
 			Return(buyers.Buyer{}, web.ResponseCode{})
 
@@ -511,6 +546,7 @@ func TestCreateBuyer(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -537,12 +573,14 @@ func TestCreateBuyer(t *testing.T) {
 
 	t.Run("Conflict Card Number Id", func(t *testing.T) {
 		mockedService, buyerController := newBuyerController()
-		mockedService.On("GetAll").Return(fakeBuyers, nil)
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(fakeBuyers, listing.Page{}, nil)
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
+			mock.Anything,
 		).Return(buyers.Buyer{}, web.ResponseCode{
 			Code: http.StatusConflict,
 			Err:  errCardNumberIdExists,
@@ -571,6 +609,7 @@ func TestGetReportPurchaseOrders(t *testing.T) {
 		mockedService, buyersController := newBuyerController()
 		mockedService.On(
 			"GetReportPurchaseOrders",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).
 			Return(
@@ -2,22 +2,45 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/carriers"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 type CarryController struct {
 	service carriers.Service
 }
 
+// reqCarries accepts either the structured_address or, for older clients,
+// the free-text address.
 type reqCarries struct {
-	Cid         string `json:"cid" binding:"required"`
-	CompanyName string `json:"company_name" binding:"required"`
-	Address     string `json:"address" binding:"required"`
-	Telephone   string `json:"telephone" binding:"required"`
-	LocalityId  string `json:"locality_id" binding:"required"`
+	Cid               string           `json:"cid" binding:"required"`
+	CompanyName       string           `json:"company_name" binding:"required"`
+	Address           string           `json:"address"`
+	StructuredAddress *address.Address `json:"structured_address"`
+	Telephone         string           `json:"telephone" binding:"required"`
+	LocalityId        string           `json:"locality_id" binding:"required"`
+}
+
+type reqCarriesUpdate struct {
+	Cid               string           `json:"cid"`
+	CompanyName       string           `json:"company_name"`
+	Address           string           `json:"address"`
+	StructuredAddress *address.Address `json:"structured_address"`
+	Telephone         string           `json:"telephone"`
+	LocalityId        string           `json:"locality_id"`
+}
+
+type reqServiceArea struct {
+	LocalityId   *string `json:"locality_id"`
+	ProvinceName *string `json:"province_name"`
+	CountryName  *string `json:"country_name"`
+	LeadTimeDays *int    `json:"lead_time_days"`
 }
 
 func NewCarry(s carriers.Service) *CarryController {
@@ -28,7 +51,22 @@ func NewCarry(s carriers.Service) *CarryController {
 
 func NewCarryHandler(r *gin.Engine, cs carriers.Service) {
 	carryController := NewCarry(cs)
-	r.POST("/api/v1/carries/", carryController.Create())
+	// "/api/v1/carries" is the original misspelled path, kept as an alias.
+	for _, path := range []string{"/api/v1/carriers", "/api/v1/carries"} {
+		carryGroup := r.Group(path)
+		{
+			carryGroup.GET("/", carryController.GetAll())
+			carryGroup.GET("/:id", carryController.GetOne())
+			carryGroup.GET("/cid/:cid", carryController.GetByCid())
+			carryGroup.GET("/deliveries", carryController.GetDeliveries())
+			carryGroup.GET("/:id/serviceAreas", carryController.GetServiceAreas())
+			carryGroup.POST("/:id/serviceAreas", carryController.CreateServiceArea())
+			carryGroup.DELETE("/:id/serviceAreas/:areaId", carryController.DeleteServiceArea())
+			carryGroup.POST("/", carryController.Create())
+			carryGroup.PATCH("/:id", carryController.Update())
+			carryGroup.DELETE("/:id", carryController.Delete())
+		}
+	}
 }
 
 func (s *CarryController) Create() gin.HandlerFunc {
@@ -40,6 +78,14 @@ func (s *CarryController) Create() gin.HandlerFunc {
 			return
 		}
 
+		addr := address.FromLegacy(requestData.Address)
+		if requestData.StructuredAddress != nil {
+			addr = *requestData.StructuredAddress
+		} else if addr.Street == "" {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("invalid request input"))
+			return
+		}
+
 		if len(requestData.Cid) > 255 {
 			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("CID too long: max 255 characters"))
 			return
@@ -61,9 +107,10 @@ func (s *CarryController) Create() gin.HandlerFunc {
 		}
 
 		carry, resp := s.service.Create(
+			c.Request.Context(),
 			requestData.Cid,
 			requestData.CompanyName,
-			requestData.Address,
+			addr,
 			requestData.Telephone,
 			requestData.LocalityId,
 		)
@@ -81,3 +128,225 @@ func (s *CarryController) Create() gin.HandlerFunc {
 		)
 	}
 }
+
+func (s *CarryController) GetOne() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+		parsedId, err := strconv.Atoi(id)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		carry, resp := s.service.GetOne(c.Request.Context(), parsedId)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(carry))
+	}
+}
+
+func (s *CarryController) GetByCid() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		carry, resp := s.service.GetByCid(c.Request.Context(), c.Param("cid"))
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(carry))
+	}
+}
+
+func (s *CarryController) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		opts, err := listing.Parse(c.Request.URL.Query(), carriers.ListFields)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, web.DecodeError(err.Error()))
+			return
+		}
+
+		carries, page, resp := s.service.GetAll(c.Request.Context(), opts)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewPageResponse(carries, page))
+	}
+}
+
+func (s *CarryController) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var requestValidatorType reqCarriesUpdate
+		var requestData map[string]interface{}
+
+		id := c.Param("id")
+		parsedId, err := strconv.Atoi(id)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		if err := c.ShouldBindBodyWith(&requestData, binding.JSON); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, web.DecodeError("invalid request data"))
+			return
+		}
+
+		if len(requestData) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, web.DecodeError("invalid request data - body needed"))
+			return
+		}
+
+		if err := c.ShouldBindBodyWith(&requestValidatorType, binding.JSON); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, web.DecodeError("invalid type of data"))
+			return
+		}
+
+		if value, ok := requestData["cid"].(string); ok {
+			if value == "" {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("CID must not be empty"))
+				return
+			}
+			if len(value) > 255 {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("CID too long: max 255 characters"))
+				return
+			}
+		}
+
+		if value, ok := requestData["company_name"].(string); ok && len(value) > 255 {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("company_name too long: max 255 characters"))
+			return
+		}
+
+		if value, ok := requestData["address"].(string); ok && len(value) > 255 {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("address too long: max 255 characters"))
+			return
+		}
+
+		if value, ok := requestData["telephone"].(string); ok && len(value) > 20 {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("telephone too long: max 20 characters"))
+			return
+		}
+
+		carry, resp := s.service.Update(c.Request.Context(), parsedId, requestData)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(carry))
+	}
+}
+
+func (s *CarryController) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+		parsedId, err := strconv.Atoi(id)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		resp := s.service.Delete(c.Request.Context(), parsedId)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse("carry with id "+id+" was deleted"))
+	}
+}
+
+func (s *CarryController) GetServiceAreas() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parsedId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		areas, resp := s.service.GetServiceAreas(c.Request.Context(), parsedId)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(areas))
+	}
+}
+
+func (s *CarryController) CreateServiceArea() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var requestData reqServiceArea
+
+		parsedId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		if err := c.ShouldBindJSON(&requestData); err != nil {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("invalid request input"))
+			return
+		}
+
+		area, resp := s.service.CreateServiceArea(c.Request.Context(), parsedId, carriers.ServiceArea{
+			LocalityId:   requestData.LocalityId,
+			ProvinceName: requestData.ProvinceName,
+			CountryName:  requestData.CountryName,
+			LeadTimeDays: requestData.LeadTimeDays,
+		})
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(area))
+	}
+}
+
+func (s *CarryController) DeleteServiceArea() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parsedId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		areaId := c.Param("areaId")
+		parsedAreaId, err := strconv.Atoi(areaId)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("areaId must be a number"))
+			return
+		}
+
+		resp := s.service.DeleteServiceArea(c.Request.Context(), parsedId, parsedAreaId)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse("service area with id "+areaId+" was deleted"))
+	}
+}
+
+func (s *CarryController) GetDeliveries() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		from, to := c.Query("from"), c.Query("to")
+		if from == "" || to == "" {
+			c.JSON(http.StatusBadRequest, web.DecodeError("from and to locality ids are required"))
+			return
+		}
+
+		deliveries, resp := s.service.GetDeliveries(c.Request.Context(), from, to)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(deliveries))
+	}
+}
@@ -12,6 +12,8 @@ import (
 	controllers "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/carriers"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/carriers"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/carriers/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/repetition"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
@@ -38,7 +40,8 @@ func newCarryController() (*mocks.Service, *controllers.CarryController) {
 }
 
 const (
-	defaultURL = "/api/v1/carries"
+	defaultURL  = "/api/v1/carries"
+	carriersURL = "/api/v1/carriers"
 )
 
 var (
@@ -66,9 +69,10 @@ func TestCreateBuyer(t *testing.T) {
 		mockedService, carryController := newCarryController()
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 		).
@@ -101,9 +105,10 @@ func TestCreateBuyer(t *testing.T) {
 		mockedService, carryController := newCarryController()
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 		).
@@ -131,9 +136,10 @@ func TestCreateBuyer(t *testing.T) {
 		mockedService, carryController := newCarryController()
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 		).
@@ -167,9 +173,10 @@ func TestCreateBuyer(t *testing.T) {
 		mockedService, carryController := newCarryController()
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 		).
@@ -203,9 +210,10 @@ func TestCreateBuyer(t *testing.T) {
 		mockedService, carryController := newCarryController()
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 		).
@@ -239,9 +247,10 @@ func TestCreateBuyer(t *testing.T) {
 		mockedService, carryController := newCarryController()
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 		).
@@ -271,4 +280,428 @@ func TestCreateBuyer(t *testing.T) {
 
 		assert.Equal(t, errTelephone.Error(), bodyResponse.Error)
 	})
+
+	t.Run("Create with structured_address", func(t *testing.T) {
+		mockedService, carryController := newCarryController()
+		structured := address.Address{Street: "Av. Paulista", Number: "1000", Cep: "01310-100", State: "SP"}
+		mockedService.On("Create", mock.Anything, "11222333000181", "some name", structured, "4567-4567", "456").
+			Return(carriers.Carry{Id: 1, StructuredAddress: structured}, web.NewCodeResponse(http.StatusCreated, nil)).Once()
+
+		r := gin.Default()
+		r.POST(defaultURL, carryController.Create())
+
+		body := `{"cid":"11222333000181","company_name":"some name","telephone":"4567-4567","locality_id":"456",` +
+			`"structured_address":{"street":"Av. Paulista","number":"1000","cep":"01310-100","state":"SP"}}`
+		req, err := http.NewRequest(http.MethodPost, defaultURL, bytes.NewBufferString(body))
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockedService.AssertExpectations(t)
+	})
+}
+
+func TestGetOneCarry(t *testing.T) {
+	fakeCarry := carriers.Carry{Id: 1, Cid: "CID#1", CompanyName: "some name"}
+
+	t.Run("Successfully on GetOne", func(t *testing.T) {
+		mockedService, carryController := newCarryController()
+		mockedService.On("GetOne", mock.Anything, 1).Return(fakeCarry, web.ResponseCode{Code: http.StatusOK})
+
+		r := gin.Default()
+		r.GET(carriersURL+"/:id", carryController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, carriersURL+"/1", nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var bodyResponse ObjectResponse
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, fakeCarry, bodyResponse.Data)
+	})
+
+	t.Run("Invalid id on GetOne", func(t *testing.T) {
+		_, carryController := newCarryController()
+
+		r := gin.Default()
+		r.GET(carriersURL+"/:id", carryController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, carriersURL+"/abc", nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Not found on GetOne", func(t *testing.T) {
+		mockedService, carryController := newCarryController()
+		mockedService.On("GetOne", mock.Anything, 1).Return(carriers.Carry{}, web.ResponseCode{
+			Code: http.StatusNotFound,
+			Err:  carriers.GetErrCarryNotFound(1),
+		})
+
+		r := gin.Default()
+		r.GET(carriersURL+"/:id", carryController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, carriersURL+"/1", nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var bodyResponse ObjectErrorResponse
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, carriers.GetErrCarryNotFound(1).Error(), bodyResponse.Error)
+	})
+}
+
+func TestGetByCidCarry(t *testing.T) {
+	t.Run("Successfully on GetByCid", func(t *testing.T) {
+		fakeCarry := carriers.Carry{Id: 1, Cid: "CID1"}
+		mockedService, carryController := newCarryController()
+		mockedService.On("GetByCid", mock.Anything, "CID1").Return(fakeCarry, web.ResponseCode{Code: http.StatusOK})
+
+		r := gin.Default()
+		r.GET(carriersURL+"/cid/:cid", carryController.GetByCid())
+
+		req, err := http.NewRequest(http.MethodGet, carriersURL+"/cid/CID1", nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var bodyResponse ObjectResponse
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, fakeCarry, bodyResponse.Data)
+	})
+}
+
+func TestGetAllCarries(t *testing.T) {
+	t.Run("Successfully on GetAll filtered by locality", func(t *testing.T) {
+		fakeCarries := []carriers.Carry{{Id: 1, LocalityId: "456"}}
+		mockedService, carryController := newCarryController()
+		opts := listing.Options{Limit: listing.DefaultLimit, Sort: "id", Order: listing.SortAsc, Filters: []listing.Filter{{Field: "locality_id", Value: "456"}}}
+		mockedService.On("GetAll", mock.Anything, opts).Return(fakeCarries, listing.Page{}, web.ResponseCode{Code: http.StatusOK})
+
+		r := gin.Default()
+		r.GET(carriersURL, carryController.GetAll())
+
+		req, err := http.NewRequest(http.MethodGet, carriersURL+"?locality_id=456", nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var bodyResponse ObjectResponseArr
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, fakeCarries, bodyResponse.Data)
+	})
+
+	t.Run("Error on GetAll", func(t *testing.T) {
+		mockedService, carryController := newCarryController()
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return([]carriers.Carry{}, listing.Page{}, web.ResponseCode{
+			Code: http.StatusInternalServerError,
+			Err:  errors.New("couldn't get carries"),
+		})
+
+		r := gin.Default()
+		r.GET(carriersURL, carryController.GetAll())
+
+		req, err := http.NewRequest(http.MethodGet, carriersURL, nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("Error on GetAll with an unknown filter", func(t *testing.T) {
+		mockedService, carryController := newCarryController()
+
+		r := gin.Default()
+		r.GET(carriersURL, carryController.GetAll())
+
+		req, err := http.NewRequest(http.MethodGet, carriersURL+"?address=corrientes", nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Empty(t, mockedService.Calls)
+	})
+}
+
+func TestUpdateCarry(t *testing.T) {
+	t.Run("Successfully on Update", func(t *testing.T) {
+		fakeCarry := carriers.Carry{Id: 1, Cid: "CID#2"}
+		mockedService, carryController := newCarryController()
+		mockedService.On("Update", mock.Anything, 1, map[string]interface{}{"cid": "CID#2"}).
+			Return(fakeCarry, web.ResponseCode{Code: http.StatusOK})
+
+		r := gin.Default()
+		r.PATCH(carriersURL+"/:id", carryController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, carriersURL+"/1", bytes.NewBuffer([]byte(`{"cid": "CID#2"}`)))
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var bodyResponse ObjectResponse
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, fakeCarry, bodyResponse.Data)
+	})
+
+	t.Run("Empty body on Update", func(t *testing.T) {
+		_, carryController := newCarryController()
+
+		r := gin.Default()
+		r.PATCH(carriersURL+"/:id", carryController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, carriersURL+"/1", bytes.NewBuffer([]byte(`{}`)))
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid type on Update", func(t *testing.T) {
+		_, carryController := newCarryController()
+
+		r := gin.Default()
+		r.PATCH(carriersURL+"/:id", carryController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, carriersURL+"/1", bytes.NewBuffer([]byte(`{"cid": 2}`)))
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Telephone too long on Update", func(t *testing.T) {
+		_, carryController := newCarryController()
+
+		r := gin.Default()
+		r.PATCH(carriersURL+"/:id", carryController.Update())
+
+		body := fmt.Sprintf(`{"telephone": "%s"}`, repetition.RepetitionCharacters(21))
+		req, err := http.NewRequest(http.MethodPatch, carriersURL+"/1", bytes.NewBuffer([]byte(body)))
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		var bodyResponse ObjectErrorResponse
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, errTelephone.Error(), bodyResponse.Error)
+	})
+
+	t.Run("Conflict on Update", func(t *testing.T) {
+		mockedService, carryController := newCarryController()
+		mockedService.On("Update", mock.Anything, 1, map[string]interface{}{"cid": "CID#2"}).
+			Return(carriers.Carry{}, web.ResponseCode{
+				Code: http.StatusConflict,
+				Err:  errors.New("CID already exists"),
+			})
+
+		r := gin.Default()
+		r.PATCH(carriersURL+"/:id", carryController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, carriersURL+"/1", bytes.NewBuffer([]byte(`{"cid": "CID#2"}`)))
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestDeleteCarry(t *testing.T) {
+	t.Run("Successfully on Delete", func(t *testing.T) {
+		mockedService, carryController := newCarryController()
+		mockedService.On("Delete", mock.Anything, 1).Return(web.ResponseCode{Code: http.StatusNoContent})
+
+		r := gin.Default()
+		r.DELETE(carriersURL+"/:id", carryController.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, carriersURL+"/1", nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Conflict on Delete", func(t *testing.T) {
+		mockedService, carryController := newCarryController()
+		mockedService.On("Delete", mock.Anything, 1).Return(web.ResponseCode{
+			Code: http.StatusConflict,
+			Err:  errors.New("carry is used by shipments and cannot be removed"),
+		})
+
+		r := gin.Default()
+		r.DELETE(carriersURL+"/:id", carryController.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, carriersURL+"/1", nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestCarryRoutesAlias(t *testing.T) {
+	mockedService := new(mocks.Service)
+	mockedService.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, web.ResponseCode{Code: http.StatusOK})
+
+	r := gin.Default()
+	controllers.NewCarryHandler(r, mockedService)
+
+	for _, url := range []string{carriersURL + "/1", defaultURL + "/1"} {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
+
+func TestServiceAreasCarry(t *testing.T) {
+	localityId := "6"
+
+	t.Run("Successfully on Create", func(t *testing.T) {
+		mockedService, carryController := newCarryController()
+		mockedService.On("CreateServiceArea", mock.Anything, 1, carriers.ServiceArea{LocalityId: &localityId}).
+			Return(carriers.ServiceArea{Id: 1, CarrierId: 1, LocalityId: &localityId}, web.ResponseCode{Code: http.StatusCreated})
+
+		r := gin.Default()
+		r.POST(carriersURL+"/:id/serviceAreas", carryController.CreateServiceArea())
+
+		req, err := http.NewRequest(http.MethodPost, carriersURL+"/1/serviceAreas", bytes.NewBufferString(`{"locality_id": "6"}`))
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Invalid input on Create", func(t *testing.T) {
+		_, carryController := newCarryController()
+
+		r := gin.Default()
+		r.POST(carriersURL+"/:id/serviceAreas", carryController.CreateServiceArea())
+
+		req, err := http.NewRequest(http.MethodPost, carriersURL+"/1/serviceAreas", bytes.NewBufferString(`{"lead_time_days": "2"}`))
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("Invalid id on Delete", func(t *testing.T) {
+		_, carryController := newCarryController()
+
+		r := gin.Default()
+		r.DELETE(carriersURL+"/:id/serviceAreas/:areaId", carryController.DeleteServiceArea())
+
+		req, err := http.NewRequest(http.MethodDelete, carriersURL+"/1/serviceAreas/a", nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Error on GetServiceAreas", func(t *testing.T) {
+		mockedService, carryController := newCarryController()
+		mockedService.On("GetServiceAreas", mock.Anything, 1).Return([]carriers.ServiceArea{}, web.ResponseCode{
+			Code: http.StatusNotFound,
+			Err:  carriers.GetErrCarryNotFound(1),
+		})
+
+		r := gin.Default()
+		r.GET(carriersURL+"/:id/serviceAreas", carryController.GetServiceAreas())
+
+		req, err := http.NewRequest(http.MethodGet, carriersURL+"/1/serviceAreas", nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestGetDeliveriesCarry(t *testing.T) {
+	t.Run("Successfully on GetDeliveries", func(t *testing.T) {
+		mockedService := new(mocks.Service)
+		mockedService.On("GetDeliveries", mock.Anything, "2", "6").Return([]carriers.Delivery{{Carry: carriers.Carry{Id: 1}}}, web.ResponseCode{Code: http.StatusOK})
+
+		r := gin.Default()
+		controllers.NewCarryHandler(r, mockedService)
+
+		req, err := http.NewRequest(http.MethodGet, carriersURL+"/deliveries?from=2&to=6", nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockedService.AssertNumberOfCalls(t, "GetDeliveries", 1)
+	})
+
+	t.Run("Missing locality on GetDeliveries", func(t *testing.T) {
+		_, carryController := newCarryController()
+
+		r := gin.Default()
+		r.GET(carriersURL+"/deliveries", carryController.GetDeliveries())
+
+		req, err := http.NewRequest(http.MethodGet, carriersURL+"/deliveries?from=2", nil)
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/employees"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -74,6 +75,7 @@ func (s *EmployeeController) Create() gin.HandlerFunc {
 		}
 
 		employee, resp := s.service.Create(
+			c.Request.Context(),
 			requestData.CardNumberId,
 			requestData.FirstName,
 			requestData.LastName, requestData.WarehouseId,
@@ -103,7 +105,7 @@ func (s *EmployeeController) GetOne() gin.HandlerFunc {
 			return
 		}
 
-		employee, resp := s.service.GetOne(parsedId)
+		employee, resp := s.service.GetOne(c.Request.Context(), parsedId)
 
 		if resp.Err != nil {
 			c.JSON(
@@ -122,7 +124,13 @@ func (s *EmployeeController) GetOne() gin.HandlerFunc {
 
 func (s *EmployeeController) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		employeesList, resp := s.service.GetAll()
+		opts, err := listing.Parse(c.Request.URL.Query(), employees.ListFields)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, web.DecodeError(err.Error()))
+			return
+		}
+
+		employeesList, page, resp := s.service.GetAll(c.Request.Context(), opts)
 
 		if resp.Err != nil {
 			c.JSON(
@@ -134,7 +142,7 @@ func (s *EmployeeController) GetAll() gin.HandlerFunc {
 
 		c.JSON(
 			http.StatusOK,
-			web.NewResponse(employeesList),
+			web.NewPageResponse(employeesList, page),
 		)
 	}
 }
@@ -150,7 +158,7 @@ func (s *EmployeeController) Delete() gin.HandlerFunc {
 			return
 		}
 
-		resp := s.service.Delete(parsedId)
+		resp := s.service.Delete(c.Request.Context(), parsedId)
 		if resp.Err != nil {
 			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
 			return
@@ -220,7 +228,7 @@ func (s *EmployeeController) Update() gin.HandlerFunc {
 			}
 		}
 
-		employee, resp := s.service.Update(parsedId, requestData)
+		employee, resp := s.service.Update(c.Request.Context(), parsedId, requestData)
 
 		if resp.Err != nil {
 			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
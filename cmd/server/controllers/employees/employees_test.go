@@ -11,6 +11,7 @@ import (
 	controllers "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/employees"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/employees"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/employees/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -75,6 +76,7 @@ func TestCreateEmployee(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -109,6 +111,7 @@ func TestCreateEmployee(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -138,6 +141,7 @@ func TestCreateEmployee(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -166,6 +170,7 @@ func TestCreateEmployee(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -199,6 +204,7 @@ func TestCreateEmployee(t *testing.T) {
 
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -229,6 +235,7 @@ func TestCreateEmployee(t *testing.T) {
 
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -259,6 +266,7 @@ func TestCreateEmployee(t *testing.T) {
 
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -289,6 +297,7 @@ func TestCreateEmployee(t *testing.T) {
 
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -317,7 +326,7 @@ func TestCreateEmployee(t *testing.T) {
 func TestGetAll(t *testing.T) {
 	t.Run("Get all employees", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("GetAll").Return(fakeEmployee, web.ResponseCode{})
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(fakeEmployee, listing.Page{}, web.ResponseCode{})
 
 		r := gin.Default()
 		r.GET(defaultURL, employeeController.GetAll())
@@ -339,7 +348,7 @@ func TestGetAll(t *testing.T) {
 
 	t.Run("Error case", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("GetAll").Return(nil, web.ResponseCode{
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(nil, listing.Page{}, web.ResponseCode{
 			Code: http.StatusInternalServerError,
 			Err:  errServer,
 		})
@@ -364,7 +373,7 @@ func TestGetAll(t *testing.T) {
 func TestGetOne(t *testing.T) {
 	t.Run("Success case", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(fakeEmployee[0], web.ResponseCode{})
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(fakeEmployee[0], web.ResponseCode{})
 
 		r := gin.Default()
 		r.GET(idRequest, employeeController.GetOne())
@@ -385,7 +394,7 @@ func TestGetOne(t *testing.T) {
 
 	t.Run("Error case on create", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(employees.Employee{}, web.ResponseCode{
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(employees.Employee{}, web.ResponseCode{
 			Code: http.StatusInternalServerError,
 			Err:  errEmployeeNotFound,
 		})
@@ -408,7 +417,7 @@ func TestGetOne(t *testing.T) {
 
 	t.Run("Fail when ID is not a number", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(employees.Employee{}, web.ResponseCode{})
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(employees.Employee{}, web.ResponseCode{})
 
 		r := gin.Default()
 		r.GET(idRequest, employeeController.GetOne())
@@ -431,7 +440,7 @@ func TestGetOne(t *testing.T) {
 func TestDeleteSeller(t *testing.T) {
 	t.Run("Success case if exists", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(web.ResponseCode{
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(web.ResponseCode{
 			Code: http.StatusNoContent,
 		})
 
@@ -450,7 +459,7 @@ func TestDeleteSeller(t *testing.T) {
 
 	t.Run("Error case if not exists", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(web.ResponseCode{
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(web.ResponseCode{
 			Code: http.StatusNotFound,
 			Err:  errEmployeeNotFound,
 		})
@@ -474,7 +483,7 @@ func TestDeleteSeller(t *testing.T) {
 
 	t.Run("Fail when ID is not a number", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(employees.Employee{}, web.ResponseCode{})
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(employees.Employee{}, web.ResponseCode{})
 
 		r := gin.Default()
 		r.DELETE(idRequest, employeeController.Delete())
@@ -497,7 +506,7 @@ func TestDeleteSeller(t *testing.T) {
 func TestUpdateEmployee(t *testing.T) {
 	t.Run("Sucessfully case", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(fakeEmployee[0], web.ResponseCode{})
 
 		parsedFakeEmployee, err := json.Marshal(fakeEmployee[0])
@@ -524,7 +533,7 @@ func TestUpdateEmployee(t *testing.T) {
 
 	t.Run("Not found case", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(employees.Employee{}, web.ResponseCode{
 				Code: http.StatusNotFound,
 				Err:  errEmployeeNotFound,
@@ -553,7 +562,7 @@ func TestUpdateEmployee(t *testing.T) {
 
 	t.Run("Id must be a number", func(t *testing.T) {
 		mockedService, sellerController := newEmployeeController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(employees.Employee{}, web.ResponseCode{})
 
 		parsedFakeEmployee, err := json.Marshal(fakeEmployee[0])
@@ -579,7 +588,7 @@ func TestUpdateEmployee(t *testing.T) {
 
 	t.Run("Invalid request data", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(employees.Employee{}, web.ResponseCode{})
 
 		r := gin.Default()
@@ -602,7 +611,7 @@ func TestUpdateEmployee(t *testing.T) {
 
 	t.Run("Body needed", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(employees.Employee{}, web.ResponseCode{})
 
 		router := gin.Default()
@@ -625,7 +634,7 @@ func TestUpdateEmployee(t *testing.T) {
 
 	t.Run("card_number_id not be empty", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(employees.Employee{}, web.ResponseCode{})
 
 		router := gin.Default()
@@ -648,7 +657,7 @@ func TestUpdateEmployee(t *testing.T) {
 
 	t.Run("Syntax error on body", func(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(employees.Employee{}, web.ResponseCode{})
 
 		router := gin.Default()
@@ -673,7 +682,7 @@ func TestUpdateEmployee(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
 		expectedError := errors.New("card_number_id too long: max 45 characters")
 
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(employees.Employee{}, web.ResponseCode{})
 
 		router := gin.Default()
@@ -698,7 +707,7 @@ func TestUpdateEmployee(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
 		expectedError := errors.New("first_name too long: max 45 characters")
 
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(employees.Employee{}, web.ResponseCode{})
 
 		router := gin.Default()
@@ -723,7 +732,7 @@ func TestUpdateEmployee(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
 		expectedError := errors.New("last_name too long: max 45 characters")
 
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(employees.Employee{}, web.ResponseCode{})
 
 		router := gin.Default()
@@ -748,7 +757,7 @@ func TestUpdateEmployee(t *testing.T) {
 		mockedService, employeeController := newEmployeeController()
 		expectedError := errors.New("warehouse_id must be greather than 0")
 
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(employees.Employee{}, web.ResponseCode{})
 
 		router := gin.Default()
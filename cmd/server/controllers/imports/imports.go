@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/imports"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize limits the size of an uploaded import file to 10MB.
+const maxImportSize = 10 << 20
+
+type ImportController struct {
+	service imports.Service
+}
+
+func NewImport(s imports.Service) *ImportController {
+	return &ImportController{
+		service: s,
+	}
+}
+
+func NewImportHandler(r *gin.Engine, is imports.Service) {
+	importController := NewImport(is)
+	importGroup := r.Group("/api/v1/imports")
+	{
+		importGroup.POST("/:entity", importController.Import())
+	}
+}
+
+func (s *ImportController) Import() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		dryRun := false
+		if value, ok := c.GetQuery("dry_run"); ok {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, web.DecodeError("dry_run must be a boolean"))
+				return
+			}
+			dryRun = parsed
+		}
+
+		format := c.Query("format")
+		if format == "" {
+			format = formatFromContentType(c.ContentType())
+		}
+
+		mode := c.DefaultQuery("mode", imports.ModeAtomic)
+		body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+		result, resp := s.service.Import(c.Request.Context(), c.Param("entity"), format, mode, dryRun, body)
+		if resp.Err != nil {
+			if result.Total > 0 {
+				c.JSON(resp.Code, web.DecodeErrorWithDetails(resp.Err.Error(), result))
+				return
+			}
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(result))
+	}
+}
+
+func formatFromContentType(contentType string) string {
+	switch {
+	case strings.Contains(contentType, "jsonl"), strings.Contains(contentType, "ndjson"):
+		return imports.FormatJSONL
+	}
+	return imports.FormatCSV
+}
@@ -0,0 +1,162 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	controllers "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/imports"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/imports"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/imports/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type ObjectResponse struct {
+	Data imports.Result
+}
+
+type ObjectErrorResponse struct {
+	Error   string         `json:"error"`
+	Details imports.Result `json:"details"`
+}
+
+const importURL = "/api/v1/imports/:entity"
+
+const buyersCSV = "card_number_id,first_name,last_name\n402,Ana,Souza\n"
+
+var fakeResult = imports.Result{
+	Entity:   "buyers",
+	Mode:     imports.ModeAtomic,
+	Total:    1,
+	Valid:    1,
+	Imported: 1,
+	Rows:     []imports.RowResult{{Line: 2, Status: imports.StatusImported, Id: 1}},
+}
+
+func routerImports() *gin.Engine {
+	return gin.Default()
+}
+
+func newImportController() (*mocks.Service, *controllers.ImportController) {
+	mockedService := new(mocks.Service)
+	importController := controllers.NewImport(mockedService)
+	return mockedService, importController
+}
+
+func TestImport(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		mockedService, importController := newImportController()
+		mockedService.On("Import", mock.Anything, "buyers", imports.FormatCSV, imports.ModeAtomic, false, mock.Anything).
+			Return(fakeResult, web.NewCodeResponse(http.StatusCreated, nil))
+
+		r := routerImports()
+		r.POST(importURL, importController.Import())
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/imports/buyers", strings.NewReader(buyersCSV))
+		assert.Nil(t, err)
+		req.Header.Set("Content-Type", "text/csv")
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var bodyResponse ObjectResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, fakeResult, bodyResponse.Data)
+	})
+
+	t.Run("Infer jsonl format and forward query options", func(t *testing.T) {
+		mockedService, importController := newImportController()
+		mockedService.On("Import", mock.Anything, "sellers", imports.FormatJSONL, imports.ModeBestEffort, true, mock.Anything).
+			Return(imports.Result{Entity: "sellers", DryRun: true}, web.NewCodeResponse(http.StatusOK, nil))
+
+		r := routerImports()
+		r.POST(importURL, importController.Import())
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/imports/sellers?mode=best_effort&dry_run=true", strings.NewReader(`{"cid": 1}`))
+		assert.Nil(t, err)
+		req.Header.Set("Content-Type", "application/x-ndjson")
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockedService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid dry_run", func(t *testing.T) {
+		_, importController := newImportController()
+
+		r := routerImports()
+		r.POST(importURL, importController.Import())
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/imports/buyers?dry_run=maybe", strings.NewReader(buyersCSV))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var bodyResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "dry_run must be a boolean", bodyResponse.Error)
+	})
+
+	t.Run("Atomic import with invalid rows", func(t *testing.T) {
+		mockedService, importController := newImportController()
+		failedResult := imports.Result{
+			Entity: "buyers",
+			Mode:   imports.ModeAtomic,
+			Total:  1,
+			Failed: 1,
+			Rows:   []imports.RowResult{{Line: 2, Status: imports.StatusFailed, Error: "card_number_id must be informed"}},
+		}
+		mockedService.On("Import", mock.Anything, "buyers", imports.FormatCSV, imports.ModeAtomic, false, mock.Anything).
+			Return(failedResult, web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("import aborted: some rows are invalid, no row was imported")))
+
+		r := routerImports()
+		r.POST(importURL, importController.Import())
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/imports/buyers?format=csv", strings.NewReader(buyersCSV))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var bodyResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, "import aborted: some rows are invalid, no row was imported", bodyResponse.Error)
+		assert.Equal(t, failedResult, bodyResponse.Details)
+	})
+
+	t.Run("Unknown entity", func(t *testing.T) {
+		mockedService, importController := newImportController()
+		mockedService.On("Import", mock.Anything, "warehouses", imports.FormatCSV, imports.ModeAtomic, false, mock.Anything).
+			Return(imports.Result{}, web.NewCodeResponse(http.StatusNotFound, errors.New("unknown import entity warehouses")))
+
+		r := routerImports()
+		r.POST(importURL, importController.Import())
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/imports/warehouses", strings.NewReader(buyersCSV))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var bodyResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "unknown import entity warehouses", bodyResponse.Error)
+	})
+}
@@ -78,6 +78,7 @@ func TestGetReportInbound(t *testing.T) {
 		mockedService, inboundController := newInboundController()
 		mockedService.On(
 			"GetReportInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 		).
 			Return(
@@ -108,6 +109,7 @@ func TestGetReportInbound(t *testing.T) {
 		mockedService, inboundController := newInboundController()
 		mockedService.On(
 			"GetReportInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 		).
 			Return(
@@ -139,6 +141,7 @@ func TestCreateInbound(t *testing.T) {
 	t.Run("Successfully on create locality", func(t *testing.T) {
 		mockedService, inboundController := newInboundController()
 		mockedService.On("CreateInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
@@ -248,9 +251,54 @@ func TestCreateInbound(t *testing.T) {
 		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 	})
 
+	t.Run("Inconsistent inbound order", func(t *testing.T) {
+		mockedService, inboundController := newInboundController()
+		violations := []inboundInternal.RuleViolation{
+			{Rule: inboundInternal.RuleEmployeeWarehouse, Message: "employee with id 1 belongs to warehouse 2, not to warehouse 1"},
+			{Rule: inboundInternal.RuleSectionWarehouse, Message: "product_batch 1 is stored in section 1 of warehouse 2, not of warehouse 1"},
+		}
+		mockedService.On("CreateInboundOrders",
+			mock.Anything,
+			mock.AnythingOfType("string"),
+			mock.AnythingOfType("string"),
+			mock.AnythingOfType("int"),
+			mock.AnythingOfType("int"),
+			mock.AnythingOfType("int"),
+		).Return(inboundInternal.InboundOrder{}, web.ResponseCode{
+			Code: http.StatusUnprocessableEntity,
+			Err:  inboundInternal.ConsistencyError{Violations: violations},
+		})
+
+		parsedFakeInbound, err := json.Marshal(fakeInbounds[0])
+		assert.NoError(t, err)
+
+		r := routerInbounds()
+		r.POST(inboundDefaultURL, inboundController.CreateInboundOrders())
+
+		req, err := http.NewRequest(
+			http.MethodPost,
+			inboundDefaultURL,
+			bytes.NewBuffer(parsedFakeInbound),
+		)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		var body struct {
+			Error   string                          `json:"error"`
+			Details []inboundInternal.RuleViolation `json:"details"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, violations, body.Details)
+	})
+
 	t.Run("Fails on create locality", func(t *testing.T) {
 		mockedService, inboundController := newInboundController()
 		mockedService.On("CreateInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
@@ -279,3 +327,89 @@ func TestCreateInbound(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 }
+
+func TestGetReportProductivity(t *testing.T) {
+	const defaultProductivityURL = "/api/v1/employees/reportProductivity"
+
+	t.Run("Test get productivity report", func(t *testing.T) {
+		mockedService, inboundController := newInboundController()
+		mockedService.On(
+			"GetReportProductivity",
+			mock.Anything,
+			inboundInternal.ReportProductivityFilter{
+				StartDate:   "2022-01-01",
+				EndDate:     "2022-01-31",
+				WarehouseId: 1,
+				GroupBy:     "week",
+			},
+		).
+			Return(
+				[]inboundInternal.ReportProductivity{{Id: 1, WarehouseId: 1, WarehouseRank: 1}},
+				web.ResponseCode{Code: http.StatusOK},
+			)
+
+		r := routerInbounds()
+		r.GET(defaultProductivityURL, inboundController.GetReportProductivity())
+
+		req, err := http.NewRequest(
+			http.MethodGet,
+			defaultProductivityURL+"?start_date=2022-01-01&end_date=2022-01-31&warehouse_id=1&group_by=week",
+			nil,
+		)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Test invalid query params", func(t *testing.T) {
+		cases := map[string]int{
+			"?start_date=01-01-2022":                     http.StatusBadRequest,
+			"?end_date=2022-13-01":                       http.StatusBadRequest,
+			"?start_date=2022-02-01&end_date=2022-01-01": http.StatusBadRequest,
+			"?group_by=month":                            http.StatusBadRequest,
+			"?warehouse_id=abc":                          http.StatusBadRequest,
+		}
+
+		for query, expectedCode := range cases {
+			_, inboundController := newInboundController()
+
+			r := routerInbounds()
+			r.GET(defaultProductivityURL, inboundController.GetReportProductivity())
+
+			req, err := http.NewRequest(http.MethodGet, defaultProductivityURL+query, nil)
+			assert.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, expectedCode, w.Code, query)
+		}
+	})
+
+	t.Run("Test warehouse not found", func(t *testing.T) {
+		mockedService, inboundController := newInboundController()
+		mockedService.On(
+			"GetReportProductivity",
+			mock.Anything,
+			mock.Anything,
+		).
+			Return(
+				[]inboundInternal.ReportProductivity{},
+				web.ResponseCode{Code: http.StatusNotFound, Err: errors.New("warehouse with id 9 not found")},
+			)
+
+		r := routerInbounds()
+		r.GET(defaultProductivityURL, inboundController.GetReportProductivity())
+
+		req, err := http.NewRequest(http.MethodGet, defaultProductivityURL+"?warehouse_id=9", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
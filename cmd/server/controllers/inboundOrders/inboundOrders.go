@@ -1,7 +1,9 @@
 package inboundorders
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	inboundorders "github.com/emidioreb/mercado-fresco-lerigophers/internal/inboundOrders"
@@ -32,6 +34,7 @@ func NewInboundHandler(r *gin.Engine, is inboundorders.Service) {
 	inboundGroup := r.Group("/api/v1")
 	{
 		inboundGroup.GET("/employees/reportInboundOrders", controllerInbound.GetReportInboundOrders())
+		inboundGroup.GET("/employees/reportProductivity", controllerInbound.GetReportProductivity())
 		inboundGroup.POST("/inboundOrders", controllerInbound.CreateInboundOrders())
 	}
 }
@@ -59,6 +62,7 @@ func (s *InboundOrdersController) CreateInboundOrders() gin.HandlerFunc {
 		}
 
 		inboundOrders, resp := s.service.CreateInboundOrders(
+			c.Request.Context(),
 			requestData.OrderNumber,
 			requestData.OrderDate,
 			requestData.EmployeeId,
@@ -66,6 +70,12 @@ func (s *InboundOrdersController) CreateInboundOrders() gin.HandlerFunc {
 			requestData.WarehouseId,
 		)
 
+		var consistencyErr inboundorders.ConsistencyError
+		if errors.As(resp.Err, &consistencyErr) {
+			c.JSON(resp.Code, web.DecodeErrorWithDetails(consistencyErr.Error(), consistencyErr.Violations))
+			return
+		}
+
 		if resp.Err != nil {
 			c.JSON(resp.Code, gin.H{
 				"error": resp.Err.Error(),
@@ -83,7 +93,7 @@ func (s *InboundOrdersController) CreateInboundOrders() gin.HandlerFunc {
 func (s *InboundOrdersController) GetReportInboundOrders() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Query("id")
-		reportInbounds, resp := s.service.GetReportInboundOrders(id)
+		reportInbounds, resp := s.service.GetReportInboundOrders(c.Request.Context(), id)
 		if resp.Err != nil {
 			c.JSON(
 				http.StatusInternalServerError,
@@ -99,3 +109,62 @@ func (s *InboundOrdersController) GetReportInboundOrders() gin.HandlerFunc {
 	}
 
 }
+
+func (s *InboundOrdersController) GetReportProductivity() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const layout = "2006-01-02"
+
+		filter := inboundorders.ReportProductivityFilter{
+			StartDate: c.Query("start_date"),
+			EndDate:   c.Query("end_date"),
+			GroupBy:   c.Query("group_by"),
+		}
+
+		if filter.StartDate != "" {
+			if _, err := time.Parse(layout, filter.StartDate); err != nil {
+				c.JSON(http.StatusBadRequest, web.DecodeError("start_date format incorrect, model: YYYY-MM-DD"))
+				return
+			}
+		}
+
+		if filter.EndDate != "" {
+			if _, err := time.Parse(layout, filter.EndDate); err != nil {
+				c.JSON(http.StatusBadRequest, web.DecodeError("end_date format incorrect, model: YYYY-MM-DD"))
+				return
+			}
+		}
+
+		if filter.StartDate != "" && filter.EndDate != "" && filter.StartDate > filter.EndDate {
+			c.JSON(http.StatusBadRequest, web.DecodeError("start_date must be before end_date"))
+			return
+		}
+
+		if filter.GroupBy != "" && filter.GroupBy != inboundorders.GroupByDay && filter.GroupBy != inboundorders.GroupByWeek {
+			c.JSON(http.StatusBadRequest, web.DecodeError("group_by must be day or week"))
+			return
+		}
+
+		if warehouseId := c.Query("warehouse_id"); warehouseId != "" {
+			parsedWarehouseId, err := strconv.Atoi(warehouseId)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, web.DecodeError("warehouse_id must be a number"))
+				return
+			}
+			filter.WarehouseId = parsedWarehouseId
+		}
+
+		report, resp := s.service.GetReportProductivity(c.Request.Context(), filter)
+		if resp.Err != nil {
+			c.JSON(
+				resp.Code,
+				web.DecodeError(resp.Err.Error()),
+			)
+			return
+		}
+
+		c.JSON(
+			http.StatusOK,
+			web.NewResponse(report),
+		)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/localities"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 type LocalityController struct {
@@ -13,10 +14,20 @@ type LocalityController struct {
 }
 
 type reqLocality struct {
-	Id           string `json:"id" binding:"required"`
-	LocalityName string `json:"locality_name" binding:"required"`
-	ProvinceName string `json:"province_name" binding:"required"`
-	CountryName  string `json:"country_name" binding:"required"`
+	Id           string   `json:"id" binding:"required"`
+	LocalityName string   `json:"locality_name" binding:"required"`
+	ProvinceName string   `json:"province_name" binding:"required"`
+	CountryName  string   `json:"country_name" binding:"required"`
+	Latitude     *float64 `json:"latitude"`
+	Longitude    *float64 `json:"longitude"`
+}
+
+type reqLocalityUpdate struct {
+	LocalityName string   `json:"locality_name"`
+	ProvinceName string   `json:"province_name"`
+	CountryName  string   `json:"country_name"`
+	Latitude     *float64 `json:"latitude"`
+	Longitude    *float64 `json:"longitude"`
 }
 
 func NewLocality(s localities.Service) *LocalityController {
@@ -27,11 +38,21 @@ func NewLocality(s localities.Service) *LocalityController {
 
 func NewLocalityHandle(r *gin.Engine, ls localities.Service) {
 	localitiesController := NewLocality(ls)
-	localityGroup := r.Group("/localities")
-	{
-		localityGroup.POST("/", localitiesController.CreateLocality())
-		localityGroup.GET("/reportSellers", localitiesController.GetReportSellers())
-		localityGroup.GET("/reportCarries", localitiesController.GetReportCarriers())
+	// "/localities" is the original path outside /api/v1, kept as an alias.
+	for _, path := range []string{"/api/v1/localities", "/localities"} {
+		localityGroup := r.Group(path)
+		{
+			localityGroup.POST("/", localitiesController.CreateLocality())
+			localityGroup.GET("/reportSellers", localitiesController.GetReportSellers())
+			localityGroup.GET("/reportCarries", localitiesController.GetReportCarriers())
+			localityGroup.GET("/reportCoverage", localitiesController.GetCoverageReport())
+			localityGroup.GET("/countries", localitiesController.GetCountries())
+			localityGroup.GET("/countries/:country/provinces", localitiesController.GetProvinces())
+			localityGroup.GET("/countries/:country/provinces/:province/localities", localitiesController.GetLocalities())
+			localityGroup.GET("/:id", localitiesController.GetOne())
+			localityGroup.PATCH("/:id", localitiesController.Update())
+			localityGroup.DELETE("/:id", localitiesController.Delete())
+		}
 	}
 }
 
@@ -64,11 +85,19 @@ func (s *LocalityController) CreateLocality() gin.HandlerFunc {
 			return
 		}
 
+		if msg := validateCoordinates(requestData.Latitude, requestData.Longitude); msg != "" {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError(msg))
+			return
+		}
+
 		seller, resp := s.service.CreateLocality(
+			c.Request.Context(),
 			requestData.Id,
 			requestData.LocalityName,
 			requestData.ProvinceName,
 			requestData.CountryName,
+			requestData.Latitude,
+			requestData.Longitude,
 		)
 
 		if resp.Err != nil {
@@ -93,9 +122,9 @@ func (s *LocalityController) GetReportSellers() gin.HandlerFunc {
 		)
 		id := c.Query("id")
 		if id != "" {
-			reportSellers, resp = s.service.GetReportOneSeller(id)
+			reportSellers, resp = s.service.GetReportOneSeller(c.Request.Context(), id)
 		} else {
-			reportSellers, resp = s.service.GetAllReportSellers()
+			reportSellers, resp = s.service.GetAllReportSellers(c.Request.Context())
 		}
 
 		if resp.Err != nil {
@@ -116,7 +145,7 @@ func (s *LocalityController) GetReportSellers() gin.HandlerFunc {
 func (s *LocalityController) GetReportCarriers() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Query("id")
-		reportCarries, resp := s.service.GetReportCarriers(id)
+		reportCarries, resp := s.service.GetReportCarriers(c.Request.Context(), id)
 		if resp.Err != nil {
 			c.JSON(
 				http.StatusNotFound,
@@ -131,3 +160,159 @@ func (s *LocalityController) GetReportCarriers() gin.HandlerFunc {
 		)
 	}
 }
+
+func (s *LocalityController) GetOne() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locality, resp := s.service.GetOne(c.Request.Context(), c.Param("id"))
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(locality))
+	}
+}
+
+func (s *LocalityController) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var requestValidatorType reqLocalityUpdate
+		var requestData map[string]interface{}
+
+		if err := c.ShouldBindBodyWith(&requestData, binding.JSON); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, web.DecodeError("invalid request data"))
+			return
+		}
+
+		if len(requestData) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, web.DecodeError("invalid request data - body needed"))
+			return
+		}
+
+		if err := c.ShouldBindBodyWith(&requestValidatorType, binding.JSON); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, web.DecodeError("invalid type of data"))
+			return
+		}
+
+		for _, field := range []string{"locality_name", "province_name", "country_name"} {
+			value, ok := requestData[field].(string)
+			if !ok {
+				continue
+			}
+
+			if value == "" {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError(field+" must not be empty"))
+				return
+			}
+
+			if len(value) > 255 {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError(field+" too long: max 255 characters"))
+				return
+			}
+		}
+
+		_, hasLatitude := requestData["latitude"]
+		_, hasLongitude := requestData["longitude"]
+		if hasLatitude || hasLongitude {
+			if msg := validateCoordinates(requestValidatorType.Latitude, requestValidatorType.Longitude); msg != "" {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError(msg))
+				return
+			}
+		}
+
+		locality, resp := s.service.Update(c.Request.Context(), c.Param("id"), requestData)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(locality))
+	}
+}
+
+func (s *LocalityController) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		resp := s.service.Delete(c.Request.Context(), id)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse("locality with id "+id+" was deleted"))
+	}
+}
+
+func (s *LocalityController) GetCountries() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		countries, resp := s.service.GetCountries(c.Request.Context())
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(countries))
+	}
+}
+
+func (s *LocalityController) GetProvinces() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provinces, resp := s.service.GetProvinces(c.Request.Context(), c.Param("country"))
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(provinces))
+	}
+}
+
+func (s *LocalityController) GetLocalities() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		localitiesList, resp := s.service.GetLocalities(c.Request.Context(), c.Param("country"), c.Param("province"))
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(localitiesList))
+	}
+}
+
+func (s *LocalityController) GetCoverageReport() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report, resp := s.service.GetCoverageReport(c.Request.Context(), localities.CoverageFilters{
+			LocalityId:   c.Query("id"),
+			ProvinceName: c.Query("province_name"),
+			CountryName:  c.Query("country_name"),
+		})
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(report))
+	}
+}
+
+// validateCoordinates returns an error message unless latitude and longitude
+// are both unset or both valid decimal degrees.
+func validateCoordinates(latitude, longitude *float64) string {
+	if latitude == nil && longitude == nil {
+		return ""
+	}
+
+	if latitude == nil || longitude == nil {
+		return "latitude and longitude must be informed together"
+	}
+
+	if *latitude < -90 || *latitude > 90 {
+		return "latitude must be between -90 and 90"
+	}
+
+	if *longitude < -180 || *longitude > 180 {
+		return "longitude must be between -180 and 180"
+	}
+
+	return ""
+}
@@ -98,10 +98,13 @@ func TestCreateLocality(t *testing.T) {
 	t.Run("Successfully on create locality", func(t *testing.T) {
 		mockedService, localityController := newLocalitiesController()
 		mockedService.On("CreateLocality",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
+			mock.AnythingOfType("*float64"),
+			mock.AnythingOfType("*float64"),
 		).Return(fakeLocalities[0], web.ResponseCode{
 			Code: http.StatusCreated,
 		})
@@ -128,10 +131,13 @@ func TestCreateLocality(t *testing.T) {
 	t.Run("Fail on create locality", func(t *testing.T) {
 		mockedService, localityController := newLocalitiesController()
 		mockedService.On("CreateLocality",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
+			mock.AnythingOfType("*float64"),
+			mock.AnythingOfType("*float64"),
 		).Return(
 			localities.Locality{},
 			web.ResponseCode{
@@ -303,6 +309,7 @@ func TestGetReportSellers(t *testing.T) {
 		mockedService, localityController := newLocalitiesController()
 		mockedService.On(
 			"GetReportOneSeller",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 		).
 			Return(
@@ -331,7 +338,7 @@ func TestGetReportSellers(t *testing.T) {
 
 	t.Run("Test get report all", func(t *testing.T) {
 		mockedService, localityController := newLocalitiesController()
-		mockedService.On("GetAllReportSellers").
+		mockedService.On("GetAllReportSellers", mock.Anything).
 			Return(
 				fakeSellerReports,
 				web.ResponseCode{Code: http.StatusOK},
@@ -358,7 +365,7 @@ func TestGetReportSellers(t *testing.T) {
 
 	t.Run("Fail when get report", func(t *testing.T) {
 		mockedService, localityController := newLocalitiesController()
-		mockedService.On("GetAllReportSellers").
+		mockedService.On("GetAllReportSellers", mock.Anything).
 			Return(
 				[]localities.ReportSellers{},
 				web.ResponseCode{
@@ -392,6 +399,7 @@ func TestGetReportCarriers(t *testing.T) {
 		mockedService, localityController := newLocalitiesController()
 		mockedService.On(
 			"GetReportCarriers",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 		).
 			Return(
@@ -422,6 +430,7 @@ func TestGetReportCarriers(t *testing.T) {
 		mockedService, localityController := newLocalitiesController()
 		mockedService.On(
 			"GetReportCarriers",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 		).
 			Return(
@@ -456,3 +465,392 @@ func TestGetReportCarriers(t *testing.T) {
 		assert.Equal(t, "locality with id 1 not found", objectRespo.Error)
 	})
 }
+
+type ObjectResponseCountries struct {
+	Data []localities.Country
+}
+
+type ObjectResponseProvinces struct {
+	Data []localities.Province
+}
+
+type ObjectResponseLocalityDetails struct {
+	Data []localities.LocalityDetail
+}
+
+func TestGetOneLocality(t *testing.T) {
+	t.Run("Successfully on get locality", func(t *testing.T) {
+		mockedService, localityController := newLocalitiesController()
+		mockedService.On("GetOne", mock.Anything, "65760000").Return(fakeLocalities[0], web.ResponseCode{Code: http.StatusOK})
+
+		r := routerSellers()
+		r.GET(localitiesDefaultURL+":id", localityController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, localitiesDefaultURL+"65760000", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var bodyResponse ObjectResponseLocality
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, fakeLocalities[0], bodyResponse.Data)
+	})
+
+	t.Run("Not found on get locality", func(t *testing.T) {
+		mockedService, localityController := newLocalitiesController()
+		mockedService.On("GetOne", mock.Anything, "1").Return(localities.Locality{}, web.ResponseCode{
+			Code: http.StatusNotFound,
+			Err:  localities.GetErrLocalityNotFound("1"),
+		})
+
+		r := routerSellers()
+		r.GET(localitiesDefaultURL+":id", localityController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, localitiesDefaultURL+"1", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestUpdateLocality(t *testing.T) {
+	t.Run("Successfully on update locality", func(t *testing.T) {
+		mockedService, localityController := newLocalitiesController()
+		mockedService.On("Update", mock.Anything, "1", map[string]interface{}{"locality_name": "Osasco"}).
+			Return(localities.Locality{Id: "1", LocalityName: "Osasco"}, web.ResponseCode{Code: http.StatusOK})
+
+		r := routerSellers()
+		r.PATCH(localitiesDefaultURL+":id", localityController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, localitiesDefaultURL+"1",
+			bytes.NewBuffer([]byte(`{"locality_name": "Osasco"}`)))
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var bodyResponse ObjectResponseLocality
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, "Osasco", bodyResponse.Data.LocalityName)
+	})
+
+	t.Run("Empty body on update locality", func(t *testing.T) {
+		_, localityController := newLocalitiesController()
+
+		r := routerSellers()
+		r.PATCH(localitiesDefaultURL+":id", localityController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, localitiesDefaultURL+"1", bytes.NewBuffer([]byte(`{}`)))
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid type on update locality", func(t *testing.T) {
+		_, localityController := newLocalitiesController()
+
+		r := routerSellers()
+		r.PATCH(localitiesDefaultURL+":id", localityController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, localitiesDefaultURL+"1",
+			bytes.NewBuffer([]byte(`{"province_name": 10}`)))
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Empty field on update locality", func(t *testing.T) {
+		_, localityController := newLocalitiesController()
+
+		r := routerSellers()
+		r.PATCH(localitiesDefaultURL+":id", localityController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, localitiesDefaultURL+"1",
+			bytes.NewBuffer([]byte(`{"country_name": ""}`)))
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		var bodyResponse ObjectErrorResponse
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, "country_name must not be empty", bodyResponse.Error)
+	})
+}
+
+func TestDeleteLocality(t *testing.T) {
+	t.Run("Successfully on delete locality", func(t *testing.T) {
+		mockedService, localityController := newLocalitiesController()
+		mockedService.On("Delete", mock.Anything, "1").Return(web.ResponseCode{Code: http.StatusNoContent})
+
+		r := routerSellers()
+		r.DELETE(localitiesDefaultURL+":id", localityController.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, localitiesDefaultURL+"1", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Conflict on delete locality", func(t *testing.T) {
+		mockedService, localityController := newLocalitiesController()
+		mockedService.On("Delete", mock.Anything, "1").Return(web.ResponseCode{
+			Code: http.StatusConflict,
+			Err:  errors.New("locality with id 1 is used by 2 sellers"),
+		})
+
+		r := routerSellers()
+		r.DELETE(localitiesDefaultURL+":id", localityController.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, localitiesDefaultURL+"1", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		var bodyResponse ObjectErrorResponse
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, "locality with id 1 is used by 2 sellers", bodyResponse.Error)
+	})
+}
+
+func TestBrowseLocalities(t *testing.T) {
+	t.Run("Successfully on get countries", func(t *testing.T) {
+		fakeCountries := []localities.Country{{
+			CountryName:     "BR",
+			ProvincesCount:  4,
+			LocalitiesCount: 6,
+			LocationCounts:  localities.LocationCounts{SellersCount: 6, CarriersCount: 2},
+		}}
+
+		mockedService := new(mocks.Service)
+		mockedService.On("GetCountries", mock.Anything).Return(fakeCountries, web.ResponseCode{Code: http.StatusOK})
+
+		r := routerSellers()
+		controllers.NewLocalityHandle(r, mockedService)
+
+		req, err := http.NewRequest(http.MethodGet, localitiesDefaultURL+"countries", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var bodyResponse ObjectResponseCountries
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, fakeCountries, bodyResponse.Data)
+	})
+
+	t.Run("Successfully on get provinces", func(t *testing.T) {
+		fakeProvinces := []localities.Province{{CountryName: "BR", ProvinceName: "MA", LocalitiesCount: 3}}
+
+		mockedService := new(mocks.Service)
+		mockedService.On("GetProvinces", mock.Anything, "BR").Return(fakeProvinces, web.ResponseCode{Code: http.StatusOK})
+
+		r := routerSellers()
+		controllers.NewLocalityHandle(r, mockedService)
+
+		req, err := http.NewRequest(http.MethodGet, localitiesDefaultURL+"countries/BR/provinces", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var bodyResponse ObjectResponseProvinces
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, fakeProvinces, bodyResponse.Data)
+	})
+
+	t.Run("Successfully on get localities of a province", func(t *testing.T) {
+		fakeDetails := []localities.LocalityDetail{{
+			Locality:       fakeLocalities[0],
+			LocationCounts: localities.LocationCounts{SellersCount: 1},
+		}}
+
+		mockedService := new(mocks.Service)
+		mockedService.On("GetLocalities", mock.Anything, "BR", "MA").Return(fakeDetails, web.ResponseCode{Code: http.StatusOK})
+
+		r := routerSellers()
+		controllers.NewLocalityHandle(r, mockedService)
+
+		req, err := http.NewRequest(http.MethodGet, localitiesDefaultURL+"countries/BR/provinces/MA/localities", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var bodyResponse ObjectResponseLocalityDetails
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, fakeDetails, bodyResponse.Data)
+	})
+
+	t.Run("Unknown country on get provinces through the old alias", func(t *testing.T) {
+		mockedService := new(mocks.Service)
+		mockedService.On("GetProvinces", mock.Anything, "AR").Return([]localities.Province{}, web.ResponseCode{
+			Code: http.StatusNotFound,
+			Err:  errors.New("country AR not found"),
+		})
+
+		r := routerSellers()
+		controllers.NewLocalityHandle(r, mockedService)
+
+		req, err := http.NewRequest(http.MethodGet, "/localities/countries/AR/provinces", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+type ObjectResponseCoverage struct {
+	Data []localities.LocalityCoverage
+}
+
+func TestGetCoverageReport(t *testing.T) {
+	t.Run("Successfully on get coverage report with filters", func(t *testing.T) {
+		fakeCoverage := []localities.LocalityCoverage{{
+			Locality:               fakeLocalities[0],
+			LocationCounts:         localities.LocationCounts{SellersCount: 1},
+			WithoutCarrierCoverage: true,
+		}}
+
+		mockedService, localityController := newLocalitiesController()
+		mockedService.On("GetCoverageReport", mock.Anything, localities.CoverageFilters{ProvinceName: "MA", CountryName: "BR"}).
+			Return(fakeCoverage, web.ResponseCode{Code: http.StatusOK})
+
+		r := routerSellers()
+		r.GET(localitiesDefaultURL+"reportCoverage", localityController.GetCoverageReport())
+
+		req, err := http.NewRequest(http.MethodGet,
+			localitiesDefaultURL+"reportCoverage?province_name=MA&country_name=BR", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var bodyResponse ObjectResponseCoverage
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, fakeCoverage, bodyResponse.Data)
+	})
+
+	t.Run("Locality not found on coverage report", func(t *testing.T) {
+		mockedService, localityController := newLocalitiesController()
+		mockedService.On("GetCoverageReport", mock.Anything, localities.CoverageFilters{LocalityId: "9"}).
+			Return([]localities.LocalityCoverage{}, web.ResponseCode{
+				Code: http.StatusNotFound,
+				Err:  localities.GetErrLocalityNotFound("9"),
+			})
+
+		r := routerSellers()
+		r.GET(localitiesDefaultURL+"reportCoverage", localityController.GetCoverageReport())
+
+		req, err := http.NewRequest(http.MethodGet, localitiesDefaultURL+"reportCoverage?id=9", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestLocalityCoordinates(t *testing.T) {
+	t.Run("Latitude without longitude on create locality", func(t *testing.T) {
+		_, localityController := newLocalitiesController()
+
+		r := routerSellers()
+		r.POST(localitiesDefaultURL, localityController.CreateLocality())
+
+		req, err := http.NewRequest(http.MethodPost, localitiesDefaultURL, bytes.NewBuffer([]byte(
+			`{"id": "7", "locality_name": "Osasco", "province_name": "SP", "country_name": "BR", "latitude": -23.53}`,
+		)))
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		var bodyResponse ObjectErrorResponse
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, "latitude and longitude must be informed together", bodyResponse.Error)
+	})
+
+	t.Run("Latitude out of range on update locality", func(t *testing.T) {
+		_, localityController := newLocalitiesController()
+
+		r := routerSellers()
+		r.PATCH(localitiesDefaultURL+":id", localityController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, localitiesDefaultURL+"1",
+			bytes.NewBuffer([]byte(`{"latitude": 91, "longitude": 10}`)))
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		var bodyResponse ObjectErrorResponse
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, "latitude must be between -90 and 90", bodyResponse.Error)
+	})
+
+	t.Run("Successfully on update coordinates", func(t *testing.T) {
+		mockedService, localityController := newLocalitiesController()
+		mockedService.On("Update", mock.Anything, "1", map[string]interface{}{"latitude": -23.53, "longitude": -46.79}).
+			Return(localities.Locality{Id: "1"}, web.ResponseCode{Code: http.StatusOK})
+
+		r := routerSellers()
+		r.PATCH(localitiesDefaultURL+":id", localityController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, localitiesDefaultURL+"1",
+			bytes.NewBuffer([]byte(`{"latitude": -23.53, "longitude": -46.79}`)))
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	order_status "github.com/emidioreb/mercado-fresco-lerigophers/internal/orderStatus"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type OrderStatusController struct {
+	service order_status.Service
+}
+
+type reqOrderStatus struct {
+	Description       string `json:"description"`
+	IsTerminal        bool   `json:"is_terminal"`
+	CountsAsFulfilled bool   `json:"counts_as_fulfilled"`
+	ReleasesStock     bool   `json:"releases_stock"`
+}
+
+func NewOrderStatus(s order_status.Service) *OrderStatusController {
+	return &OrderStatusController{
+		service: s,
+	}
+}
+
+func NewOrderStatusHandler(r *gin.Engine, os order_status.Service) {
+	orderStatusController := NewOrderStatus(os)
+	orderStatusGroup := r.Group("/api/v1/orderStatus")
+	{
+		orderStatusGroup.GET("/:id", orderStatusController.GetOne())
+		orderStatusGroup.GET("/", orderStatusController.GetAll())
+		orderStatusGroup.POST("/", orderStatusController.Create())
+		orderStatusGroup.PATCH("/:id", orderStatusController.Update())
+		orderStatusGroup.DELETE("/:id", orderStatusController.Delete())
+	}
+}
+
+func (s *OrderStatusController) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var requestData reqOrderStatus
+
+		if err := c.ShouldBindJSON(&requestData); err != nil {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("invalid request input"))
+			return
+		}
+
+		description := strings.TrimSpace(requestData.Description)
+		if description == "" {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("description must be informed"))
+			return
+		}
+
+		orderStatus, resp := s.service.Create(
+			c.Request.Context(),
+			description,
+			requestData.IsTerminal,
+			requestData.CountsAsFulfilled,
+			requestData.ReleasesStock,
+		)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(orderStatus))
+	}
+}
+
+func (s *OrderStatusController) GetOne() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parsedId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		orderStatus, resp := s.service.GetOne(c.Request.Context(), parsedId)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, web.NewResponse(orderStatus))
+	}
+}
+
+func (s *OrderStatusController) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allOrderStatus, resp := s.service.GetAll(c.Request.Context())
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, web.NewResponse(allOrderStatus))
+	}
+}
+
+func (s *OrderStatusController) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var requestValidatorType reqOrderStatus
+		requestData := make(map[string]interface{})
+
+		parsedId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		if err := c.ShouldBindBodyWith(&requestData, binding.JSON); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, web.DecodeError("invalid request data"))
+			return
+		}
+
+		if len(requestData) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, web.DecodeError("invalid request data - body needed"))
+			return
+		}
+
+		if err := c.ShouldBindBodyWith(&requestValidatorType, binding.JSON); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, web.DecodeError("invalid type of data"))
+			return
+		}
+
+		for _, flag := range []string{"is_terminal", "counts_as_fulfilled", "releases_stock"} {
+			if value, ok := requestData[flag]; ok && value == nil {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError(flag+" must be a boolean"))
+				return
+			}
+		}
+
+		if _, ok := requestData["description"]; ok {
+			description := strings.TrimSpace(requestValidatorType.Description)
+			if description == "" {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("description must be informed"))
+				return
+			}
+			requestData["description"] = description
+		}
+
+		orderStatus, resp := s.service.Update(c.Request.Context(), parsedId, requestData)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(orderStatus))
+	}
+}
+
+func (s *OrderStatusController) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		parsedId, err := strconv.Atoi(id)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		resp := s.service.Delete(c.Request.Context(), parsedId)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse("order_status with id "+id+" was deleted"))
+	}
+}
@@ -0,0 +1,347 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	controllers "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/orderStatus"
+	order_status "github.com/emidioreb/mercado-fresco-lerigophers/internal/orderStatus"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/orderStatus/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type ObjectResponseArr struct {
+	Data []order_status.OrderStatus
+}
+
+type ObjectResponse struct {
+	Data order_status.OrderStatus
+}
+
+type ObjectErrorResponse struct {
+	Error string `json:"error"`
+}
+
+const (
+	defaultURL = "/api/v1/orderStatus/"
+	idURL      = "/api/v1/orderStatus/:id"
+)
+
+func routerOrderStatus() *gin.Engine {
+	return gin.Default()
+}
+
+func newOrderStatusController() (*mocks.Service, *controllers.OrderStatusController) {
+	mockedService := new(mocks.Service)
+	orderStatusController := controllers.NewOrderStatus(mockedService)
+	return mockedService, orderStatusController
+}
+
+var fakeOrderStatus = []order_status.OrderStatus{
+	{Id: 1, Description: "ok", IsTerminal: true, CountsAsFulfilled: true},
+	{Id: 2, Description: "in progress"},
+	{Id: 3, Description: "canceled", IsTerminal: true, ReleasesStock: true},
+}
+
+func TestCreateOrderStatus(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		mockedService, orderStatusController := newOrderStatusController()
+		mockedService.On("Create", mock.Anything, "canceled", true, false, true).
+			Return(fakeOrderStatus[2], web.ResponseCode{Code: http.StatusCreated})
+
+		r := routerOrderStatus()
+		r.POST(defaultURL, orderStatusController.Create())
+
+		body := []byte(`{"description": "canceled", "is_terminal": true, "releases_stock": true}`)
+		req, err := http.NewRequest(http.MethodPost, defaultURL, bytes.NewBuffer(body))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, fakeOrderStatus[2], currentResponse.Data)
+	})
+
+	t.Run("Invalid request input", func(t *testing.T) {
+		_, orderStatusController := newOrderStatusController()
+
+		r := routerOrderStatus()
+		r.POST(defaultURL, orderStatusController.Create())
+
+		for _, body := range []string{`{"description": "ok", "is_terminal": "yes"}`, `{"description": " "}`} {
+			req, err := http.NewRequest(http.MethodPost, defaultURL, bytes.NewBuffer([]byte(body)))
+			assert.Nil(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		}
+	})
+
+	t.Run("Service error", func(t *testing.T) {
+		mockedService, orderStatusController := newOrderStatusController()
+		mockedService.On("Create", mock.Anything, "delivered", false, true, false).Return(order_status.OrderStatus{}, web.ResponseCode{
+			Code: http.StatusUnprocessableEntity,
+			Err:  errors.New("an order_status that counts as fulfilled must be terminal"),
+		})
+
+		r := routerOrderStatus()
+		r.POST(defaultURL, orderStatusController.Create())
+
+		body := []byte(`{"description": "delivered", "counts_as_fulfilled": true}`)
+		req, err := http.NewRequest(http.MethodPost, defaultURL, bytes.NewBuffer(body))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, "an order_status that counts as fulfilled must be terminal", currentResponse.Error)
+	})
+}
+
+func TestGetOrderStatus(t *testing.T) {
+	t.Run("Get all", func(t *testing.T) {
+		mockedService, orderStatusController := newOrderStatusController()
+		mockedService.On("GetAll", mock.Anything).Return(fakeOrderStatus, web.ResponseCode{Code: http.StatusOK})
+
+		r := routerOrderStatus()
+		r.GET(defaultURL, orderStatusController.GetAll())
+
+		req, err := http.NewRequest(http.MethodGet, defaultURL, nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectResponseArr
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, fakeOrderStatus, currentResponse.Data)
+	})
+
+	t.Run("Get all error", func(t *testing.T) {
+		mockedService, orderStatusController := newOrderStatusController()
+		mockedService.On("GetAll", mock.Anything).Return([]order_status.OrderStatus{}, web.ResponseCode{
+			Code: http.StatusInternalServerError,
+			Err:  errors.New("couldn't get order_status"),
+		})
+
+		r := routerOrderStatus()
+		r.GET(defaultURL, orderStatusController.GetAll())
+
+		req, err := http.NewRequest(http.MethodGet, defaultURL, nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("Get one", func(t *testing.T) {
+		mockedService, orderStatusController := newOrderStatusController()
+		mockedService.On("GetOne", mock.Anything, 1).Return(fakeOrderStatus[0], web.ResponseCode{Code: http.StatusOK})
+
+		r := routerOrderStatus()
+		r.GET(idURL, orderStatusController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, defaultURL+"1", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, fakeOrderStatus[0], currentResponse.Data)
+	})
+
+	t.Run("Get one not found", func(t *testing.T) {
+		mockedService, orderStatusController := newOrderStatusController()
+		mockedService.On("GetOne", mock.Anything, 9).Return(order_status.OrderStatus{}, web.ResponseCode{
+			Code: http.StatusNotFound,
+			Err:  order_status.GetErrOrderStatusNotFound(9),
+		})
+
+		r := routerOrderStatus()
+		r.GET(idURL, orderStatusController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, defaultURL+"9", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Id must be a number", func(t *testing.T) {
+		_, orderStatusController := newOrderStatusController()
+
+		r := routerOrderStatus()
+		r.GET(idURL, orderStatusController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, defaultURL+"abc", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		mockedService, orderStatusController := newOrderStatusController()
+		expected := fakeOrderStatus[2]
+		expected.ReleasesStock = false
+		mockedService.On("Update", mock.Anything, 3, map[string]interface{}{"releases_stock": false}).
+			Return(expected, web.ResponseCode{Code: http.StatusOK})
+
+		r := routerOrderStatus()
+		r.PATCH(idURL, orderStatusController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, defaultURL+"3", bytes.NewBuffer([]byte(`{"releases_stock": false}`)))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.False(t, currentResponse.Data.ReleasesStock)
+	})
+
+	t.Run("Bad requests", func(t *testing.T) {
+		_, orderStatusController := newOrderStatusController()
+
+		r := routerOrderStatus()
+		r.PATCH(idURL, orderStatusController.Update())
+
+		cases := []struct {
+			url  string
+			body string
+			code int
+		}{
+			{defaultURL + "abc", `{"description": "ok"}`, http.StatusBadRequest},
+			{defaultURL + "1", `{`, http.StatusBadRequest},
+			{defaultURL + "1", `{}`, http.StatusBadRequest},
+			{defaultURL + "1", `{"is_terminal": "no"}`, http.StatusBadRequest},
+			{defaultURL + "1", `{"is_terminal": null}`, http.StatusUnprocessableEntity},
+			{defaultURL + "1", `{"description": ""}`, http.StatusUnprocessableEntity},
+		}
+
+		for _, currCase := range cases {
+			req, err := http.NewRequest(http.MethodPatch, currCase.url, bytes.NewBuffer([]byte(currCase.body)))
+			assert.Nil(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, currCase.code, rec.Code, currCase.body)
+		}
+	})
+
+	t.Run("Service error", func(t *testing.T) {
+		mockedService, orderStatusController := newOrderStatusController()
+		mockedService.On("Update", mock.Anything, 1, mock.Anything).Return(order_status.OrderStatus{}, web.ResponseCode{
+			Code: http.StatusUnprocessableEntity,
+			Err:  errors.New("an order_status that counts as fulfilled must be terminal"),
+		})
+
+		r := routerOrderStatus()
+		r.PATCH(idURL, orderStatusController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, defaultURL+"1", bytes.NewBuffer([]byte(`{"is_terminal": false}`)))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
+
+func TestDeleteOrderStatus(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		mockedService, orderStatusController := newOrderStatusController()
+		mockedService.On("Delete", mock.Anything, 3).Return(web.ResponseCode{Code: http.StatusNoContent})
+
+		r := routerOrderStatus()
+		r.DELETE(idURL, orderStatusController.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, defaultURL+"3", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("Conflict when used by purchase_orders", func(t *testing.T) {
+		mockedService, orderStatusController := newOrderStatusController()
+		mockedService.On("Delete", mock.Anything, 1).Return(web.ResponseCode{
+			Code: http.StatusConflict,
+			Err:  errors.New("order_status with id 1 is used by 4 purchase_orders"),
+		})
+
+		r := routerOrderStatus()
+		r.DELETE(idURL, orderStatusController.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, defaultURL+"1", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, "order_status with id 1 is used by 4 purchase_orders", currentResponse.Error)
+	})
+
+	t.Run("Id must be a number", func(t *testing.T) {
+		_, orderStatusController := newOrderStatusController()
+
+		r := routerOrderStatus()
+		r.DELETE(idURL, orderStatusController.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, defaultURL+"abc", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
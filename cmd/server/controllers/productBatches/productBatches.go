@@ -27,6 +27,11 @@ type ReqProductBatch struct {
 	ManufacturingDate  string `json:"manufacturing_date" binding:"required"`
 }
 
+type ReqTemperatureReading struct {
+	Temperature *float64 `json:"temperature"`
+	RecordedAt  string   `json:"recorded_at"`
+}
+
 func NewProductBatch(s product_batches.Service) *ProductBatchController {
 	return &ProductBatchController{
 		service: s,
@@ -39,6 +44,8 @@ func NewProductBatchHandler(r *gin.Engine, pb product_batches.Service) {
 	{
 		ProductBatchesGroup.POST("/", controllerProductBatches.CreateProductBatch())
 		ProductBatchesGroup.GET("/reportProducts", controllerProductBatches.GetReportSection())
+		ProductBatchesGroup.GET("/:batchNumber", controllerProductBatches.GetOne())
+		ProductBatchesGroup.POST("/:batchNumber/temperatures", controllerProductBatches.CreateTemperatureReading())
 	}
 }
 
@@ -92,6 +99,7 @@ func (s *ProductBatchController) CreateProductBatch() gin.HandlerFunc {
 		}
 
 		productBatch, resp := s.service.CreateProductBatch(
+			c.Request.Context(),
 			requestData.BatchNumber,
 			requestData.CurrentQuantity,
 			requestData.CurrentTemperature,
@@ -128,7 +136,7 @@ func (s *ProductBatchController) GetReportSection() gin.HandlerFunc {
 				return
 			}
 
-			reportSections, resp := s.service.GetReportSection(parsedId)
+			reportSections, resp := s.service.GetReportSection(c.Request.Context(), parsedId)
 			if resp.Err != nil {
 				c.JSON(
 					http.StatusNotFound,
@@ -142,7 +150,7 @@ func (s *ProductBatchController) GetReportSection() gin.HandlerFunc {
 				web.NewResponse(reportSections),
 			)
 		} else {
-			reportSections, resp := s.service.GetReportSection(0)
+			reportSections, resp := s.service.GetReportSection(c.Request.Context(), 0)
 			if resp.Err != nil {
 				c.JSON(
 					http.StatusNotFound,
@@ -160,3 +168,54 @@ func (s *ProductBatchController) GetReportSection() gin.HandlerFunc {
 	}
 
 }
+
+func (s *ProductBatchController) GetOne() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		batchNumber, err := strconv.Atoi(c.Param("batchNumber"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("batch_number must be a number"))
+			return
+		}
+
+		productBatch, resp := s.service.GetOne(c.Request.Context(), batchNumber)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(productBatch))
+	}
+}
+
+func (s *ProductBatchController) CreateTemperatureReading() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		batchNumber, err := strconv.Atoi(c.Param("batchNumber"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("batch_number must be a number"))
+			return
+		}
+
+		var requestData ReqTemperatureReading
+		if err := c.ShouldBindJSON(&requestData); err != nil || requestData.Temperature == nil {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("invalid request input"))
+			return
+		}
+
+		recordedAt := time.Now().Truncate(time.Second)
+		if requestData.RecordedAt != "" {
+			recordedAt, err = time.Parse(time.RFC3339, requestData.RecordedAt)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("recorded_at format incorrect, model: YYYY-MM-DDThh:mm:ssZ"))
+				return
+			}
+		}
+
+		reading, resp := s.service.CreateTemperatureReading(c.Request.Context(), batchNumber, *requestData.Temperature, recordedAt)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(reading))
+	}
+}
@@ -187,6 +187,7 @@ func TestCreateProductBatch(t *testing.T) {
 		mockedService, ProductBatchController := newProductBatcheController()
 
 		mockedService.On("CreateProductBatch",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -223,6 +224,7 @@ func TestCreateProductBatch(t *testing.T) {
 	t.Run("Fail on create product_batch", func(t *testing.T) {
 		mockedService, ProductBatchController := newProductBatcheController()
 		mockedService.On("CreateProductBatch",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -439,6 +441,7 @@ func TestGetReportSections(t *testing.T) {
 		mockedService, ProductBatchController := newProductBatcheController()
 		mockedService.On(
 			"GetReportSection",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return([]product_batches.ProductsQuantity{}, web.ResponseCode{
 			Code: http.StatusInternalServerError,
@@ -469,6 +472,7 @@ func TestGetReportSections(t *testing.T) {
 		mockedService, ProductBatchController := newProductBatcheController()
 		mockedService.On(
 			"GetReportSection",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return([]product_batches.ProductsQuantity{}, web.ResponseCode{
 			Code: http.StatusInternalServerError,
@@ -499,6 +503,7 @@ func TestGetReportSections(t *testing.T) {
 		mockedService, ProductBatchController := newProductBatcheController()
 		mockedService.On(
 			"GetReportSection",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(
 			[]product_batches.ProductsQuantity{fakeReports[0]},
@@ -524,3 +529,138 @@ func TestGetReportSections(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 }
+
+type ObjectResponseDetail struct {
+	Data product_batches.ProductBatchDetail
+}
+
+type ObjectResponseReading struct {
+	Data product_batches.TemperatureReading
+}
+
+func TestGetOneProductBatch(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		mockedService, productBatchesController := newProductBatcheController()
+		remaining := 120.0
+		detail := product_batches.ProductBatchDetail{
+			ProductBatches: product_batches.ProductBatches{Id: 1, BatchNumber: 10, DueDate: date, ManufacturingDate: date},
+		}
+		detail.ShelfLife.ShelfLifeUsedPercent = 50
+		detail.ShelfLife.RemainingHours = &remaining
+		detail.ShelfLife.SpoilsBeforeDueDate = true
+		mockedService.On("GetOne", mock.Anything, 10).Return(detail, web.NewCodeResponse(http.StatusOK, nil))
+
+		r := router()
+		r.GET("/api/v1/productBatches/:batchNumber", productBatchesController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/productBatches/10", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var bodyResponse ObjectResponseDetail
+		err = json.Unmarshal(rec.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, detail, bodyResponse.Data)
+	})
+
+	t.Run("Invalid batch number", func(t *testing.T) {
+		_, productBatchesController := newProductBatcheController()
+
+		r := router()
+		r.GET("/api/v1/productBatches/:batchNumber", productBatchesController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/productBatches/abc", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Not found case", func(t *testing.T) {
+		mockedService, productBatchesController := newProductBatcheController()
+		mockedService.On("GetOne", mock.Anything, 10).Return(product_batches.ProductBatchDetail{},
+			web.NewCodeResponse(http.StatusNotFound, product_batches.GetErrProductBatchNotFound(10)))
+
+		r := router()
+		r.GET("/api/v1/productBatches/:batchNumber", productBatchesController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/productBatches/10", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var bodyResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "product_batch with batch_number 10 not found", bodyResponse.Error)
+	})
+}
+
+func TestCreateTemperatureReading(t *testing.T) {
+	recordedAt := time.Date(2022, 6, 1, 10, 30, 0, 0, time.UTC)
+
+	t.Run("Success case", func(t *testing.T) {
+		mockedService, productBatchesController := newProductBatcheController()
+		reading := product_batches.TemperatureReading{Id: 1, ProductBatchId: 3, Temperature: -12.5, RecordedAt: recordedAt}
+		mockedService.On("CreateTemperatureReading", mock.Anything, 10, -12.5, recordedAt).
+			Return(reading, web.NewCodeResponse(http.StatusCreated, nil))
+
+		r := router()
+		r.POST("/api/v1/productBatches/:batchNumber/temperatures", productBatchesController.CreateTemperatureReading())
+
+		body := []byte(`{"temperature": -12.5, "recorded_at": "2022-06-01T10:30:00Z"}`)
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/productBatches/10/temperatures", bytes.NewBuffer(body))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var bodyResponse ObjectResponseReading
+		err = json.Unmarshal(rec.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, reading, bodyResponse.Data)
+	})
+
+	t.Run("Missing temperature", func(t *testing.T) {
+		_, productBatchesController := newProductBatcheController()
+
+		r := router()
+		r.POST("/api/v1/productBatches/:batchNumber/temperatures", productBatchesController.CreateTemperatureReading())
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/productBatches/10/temperatures", bytes.NewBuffer([]byte(`{}`)))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("Invalid recorded_at", func(t *testing.T) {
+		_, productBatchesController := newProductBatcheController()
+
+		r := router()
+		r.POST("/api/v1/productBatches/:batchNumber/temperatures", productBatchesController.CreateTemperatureReading())
+
+		body := []byte(`{"temperature": -12.5, "recorded_at": "01/06/2022"}`)
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/productBatches/10/temperatures", bytes.NewBuffer(body))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var bodyResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, "recorded_at format incorrect, model: YYYY-MM-DDThh:mm:ssZ", bodyResponse.Error)
+	})
+}
@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	product_records "github.com/emidioreb/mercado-fresco-lerigophers/internal/productRecords"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
@@ -28,6 +30,7 @@ func NewProductRecord(s product_records.Service) *ProductRecordController {
 func NewProductRecordHandler(r *gin.Engine, cs product_records.Service) {
 	productRecordController := NewProductRecord(cs)
 	r.POST("/api/v1/productRecords", productRecordController.CreateProductRecord())
+	r.GET("/api/v1/products/:id/prices", productRecordController.GetPriceHistory())
 }
 
 func (s *ProductRecordController) CreateProductRecord() gin.HandlerFunc {
@@ -39,6 +42,7 @@ func (s *ProductRecordController) CreateProductRecord() gin.HandlerFunc {
 		}
 
 		productRecord, resp := s.service.CreateProductRecord(
+			c.Request.Context(),
 			requestData.LastUpdateDate,
 			requestData.PurchasePrice,
 			requestData.SalePrice,
@@ -59,3 +63,31 @@ func (s *ProductRecordController) CreateProductRecord() gin.HandlerFunc {
 
 	}
 }
+
+func (s *ProductRecordController) GetPriceHistory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		parsedId, err := strconv.Atoi(id)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		date := c.Query("date")
+		if date != "" {
+			if _, err := time.Parse("2006-01-02", date); err != nil {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("date format incorrect, model: YYYY-MM-DD"))
+				return
+			}
+		}
+
+		history, resp := s.service.GetPriceHistory(c.Request.Context(), parsedId, date)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, web.NewResponse(history))
+	}
+}
@@ -76,6 +76,7 @@ func TestCreateProductRecord(t *testing.T) {
 		}
 
 		mockedService.On("CreateProductRecord",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
@@ -105,6 +106,7 @@ func TestCreateProductRecord(t *testing.T) {
 		productRecordsController := controllers.NewProductRecord(mockedService)
 
 		mockedService.On("CreateProductRecord",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
@@ -132,6 +134,7 @@ func TestCreateProductRecord(t *testing.T) {
 	t.Run("Fail on create product_record", func(t *testing.T) {
 		mockedService, ProductRecordController := newProductRecordController()
 		mockedService.On("CreateProductRecord",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
@@ -162,3 +165,79 @@ func TestCreateProductRecord(t *testing.T) {
 		assert.Equal(t, http.StatusConflict, w.Code)
 	})
 }
+
+func TestGetPriceHistory(t *testing.T) {
+	const pricesURL = "/api/v1/products/:id/prices"
+
+	t.Run("Successfully get price history", func(t *testing.T) {
+		mockedService, productRecordsController := newProductRecordController()
+		mockedService.On("GetPriceHistory", mock.Anything, 2, "2022-01-10").Return(
+			product_records.ProductPriceHistory{ProductId: 2, Prices: []product_records.ProductPrice{}},
+			web.NewCodeResponse(http.StatusOK, nil),
+		)
+
+		r := router()
+		r.GET(pricesURL, productRecordsController.GetPriceHistory())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/products/2/prices?date=2022-01-10", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Invalid id", func(t *testing.T) {
+		_, productRecordsController := newProductRecordController()
+
+		r := router()
+		r.GET(pricesURL, productRecordsController.GetPriceHistory())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/products/a/prices", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid date", func(t *testing.T) {
+		_, productRecordsController := newProductRecordController()
+
+		r := router()
+		r.GET(pricesURL, productRecordsController.GetPriceHistory())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/products/2/prices?date=10-01-2022", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("Product not found", func(t *testing.T) {
+		mockedService, productRecordsController := newProductRecordController()
+		mockedService.On("GetPriceHistory", mock.Anything, 2, "").Return(
+			product_records.ProductPriceHistory{},
+			web.NewCodeResponse(http.StatusNotFound, errors.New("product with id 2 not found")),
+		)
+
+		r := router()
+		r.GET(pricesURL, productRecordsController.GetPriceHistory())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/products/2/prices", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		var currentResponse ObjectErrorResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &currentResponse))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "product with id 2 not found", currentResponse.Error)
+	})
+}
@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	product_types "github.com/emidioreb/mercado-fresco-lerigophers/internal/productTypes"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type ProductTypeController struct {
+	service product_types.Service
+}
+
+type reqProductTypes struct {
+	Name               string   `json:"name"`
+	MinimumTemperature *float64 `json:"minimum_temperature"`
+	MaximumTemperature *float64 `json:"maximum_temperature"`
+}
+
+func NewProductType(s product_types.Service) *ProductTypeController {
+	return &ProductTypeController{
+		service: s,
+	}
+}
+
+func NewProductTypeHandler(r *gin.Engine, ps product_types.Service) {
+	productTypeController := NewProductType(ps)
+	productTypeGroup := r.Group("/api/v1/productTypes")
+	{
+		productTypeGroup.GET("/:id", productTypeController.GetOne())
+		productTypeGroup.GET("/", productTypeController.GetAll())
+		productTypeGroup.POST("/", productTypeController.Create())
+		productTypeGroup.PATCH("/:id", productTypeController.Update())
+		productTypeGroup.DELETE("/:id", productTypeController.Delete())
+	}
+}
+
+func (s *ProductTypeController) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var requestData reqProductTypes
+
+		if err := c.ShouldBindJSON(&requestData); err != nil {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("invalid request input"))
+			return
+		}
+
+		name := strings.TrimSpace(requestData.Name)
+		if name == "" {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("name must be informed"))
+			return
+		}
+
+		productType, resp := s.service.Create(c.Request.Context(), name, requestData.MinimumTemperature, requestData.MaximumTemperature)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(productType))
+	}
+}
+
+func (s *ProductTypeController) GetOne() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parsedId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		productType, resp := s.service.GetOne(c.Request.Context(), parsedId)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, web.NewResponse(productType))
+	}
+}
+
+func (s *ProductTypeController) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		productTypes, resp := s.service.GetAll(c.Request.Context())
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, web.NewResponse(productTypes))
+	}
+}
+
+func (s *ProductTypeController) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var requestValidatorType reqProductTypes
+		requestData := make(map[string]interface{})
+
+		parsedId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		if err := c.ShouldBindBodyWith(&requestData, binding.JSON); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, web.DecodeError("invalid request data"))
+			return
+		}
+
+		if len(requestData) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, web.DecodeError("invalid request data - body needed"))
+			return
+		}
+
+		if err := c.ShouldBindBodyWith(&requestValidatorType, binding.JSON); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, web.DecodeError("invalid type of data"))
+			return
+		}
+
+		if _, ok := requestData["name"]; ok {
+			name := strings.TrimSpace(requestValidatorType.Name)
+			if name == "" {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("name must be informed"))
+				return
+			}
+			requestData["name"] = name
+		}
+
+		productType, resp := s.service.Update(c.Request.Context(), parsedId, requestData)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(productType))
+	}
+}
+
+func (s *ProductTypeController) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		parsedId, err := strconv.Atoi(id)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		resp := s.service.Delete(c.Request.Context(), parsedId)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse("product_type with id "+id+" was deleted"))
+	}
+}
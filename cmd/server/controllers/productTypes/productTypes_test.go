@@ -0,0 +1,346 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	controllers "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/productTypes"
+	product_types "github.com/emidioreb/mercado-fresco-lerigophers/internal/productTypes"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/productTypes/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type ObjectResponseArr struct {
+	Data []product_types.ProductType
+}
+
+type ObjectResponse struct {
+	Data product_types.ProductType
+}
+
+type ObjectErrorResponse struct {
+	Error string `json:"error"`
+}
+
+const (
+	defaultURL = "/api/v1/productTypes/"
+	idURL      = "/api/v1/productTypes/:id"
+)
+
+func routerProductTypes() *gin.Engine {
+	return gin.Default()
+}
+
+func newProductTypeController() (*mocks.Service, *controllers.ProductTypeController) {
+	mockedService := new(mocks.Service)
+	productTypeController := controllers.NewProductType(mockedService)
+	return mockedService, productTypeController
+}
+
+func temperature(value float64) *float64 {
+	return &value
+}
+
+var fakeProductTypes = []product_types.ProductType{
+	{Id: 1, Name: "electronic"},
+	{Id: 2, Name: "freezed", MinimumTemperature: temperature(-18), MaximumTemperature: temperature(-12)},
+}
+
+func TestCreateProductType(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		mockedService, productTypeController := newProductTypeController()
+		mockedService.On("Create", mock.Anything, "freezed", temperature(-18), temperature(-12)).
+			Return(fakeProductTypes[1], web.ResponseCode{Code: http.StatusCreated})
+
+		r := routerProductTypes()
+		r.POST(defaultURL, productTypeController.Create())
+
+		body := []byte(`{"name": " freezed ", "minimum_temperature": -18, "maximum_temperature": -12}`)
+		req, err := http.NewRequest(http.MethodPost, defaultURL, bytes.NewBuffer(body))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, fakeProductTypes[1], currentResponse.Data)
+	})
+
+	t.Run("Invalid request input", func(t *testing.T) {
+		_, productTypeController := newProductTypeController()
+
+		r := routerProductTypes()
+		r.POST(defaultURL, productTypeController.Create())
+
+		for _, body := range []string{`{"name": 1}`, `{"name": "   "}`} {
+			req, err := http.NewRequest(http.MethodPost, defaultURL, bytes.NewBuffer([]byte(body)))
+			assert.Nil(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		}
+	})
+
+	t.Run("Conflict case", func(t *testing.T) {
+		mockedService, productTypeController := newProductTypeController()
+		mockedService.On("Create", mock.Anything, "freezed", mock.Anything, mock.Anything).
+			Return(product_types.ProductType{}, web.ResponseCode{Code: http.StatusConflict, Err: errors.New("product_type name already exists")})
+
+		r := routerProductTypes()
+		r.POST(defaultURL, productTypeController.Create())
+
+		req, err := http.NewRequest(http.MethodPost, defaultURL, bytes.NewBuffer([]byte(`{"name": "freezed"}`)))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, "product_type name already exists", currentResponse.Error)
+	})
+}
+
+func TestGetProductTypes(t *testing.T) {
+	t.Run("Get all", func(t *testing.T) {
+		mockedService, productTypeController := newProductTypeController()
+		mockedService.On("GetAll", mock.Anything).Return(fakeProductTypes, web.ResponseCode{Code: http.StatusOK})
+
+		r := routerProductTypes()
+		r.GET(defaultURL, productTypeController.GetAll())
+
+		req, err := http.NewRequest(http.MethodGet, defaultURL, nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectResponseArr
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, fakeProductTypes, currentResponse.Data)
+	})
+
+	t.Run("Get all error", func(t *testing.T) {
+		mockedService, productTypeController := newProductTypeController()
+		mockedService.On("GetAll", mock.Anything).Return([]product_types.ProductType{}, web.ResponseCode{
+			Code: http.StatusInternalServerError,
+			Err:  errors.New("couldn't get product_types"),
+		})
+
+		r := routerProductTypes()
+		r.GET(defaultURL, productTypeController.GetAll())
+
+		req, err := http.NewRequest(http.MethodGet, defaultURL, nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("Get one", func(t *testing.T) {
+		mockedService, productTypeController := newProductTypeController()
+		mockedService.On("GetOne", mock.Anything, 2).Return(fakeProductTypes[1], web.ResponseCode{Code: http.StatusOK})
+
+		r := routerProductTypes()
+		r.GET(idURL, productTypeController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, defaultURL+"2", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, fakeProductTypes[1], currentResponse.Data)
+	})
+
+	t.Run("Get one not found", func(t *testing.T) {
+		mockedService, productTypeController := newProductTypeController()
+		mockedService.On("GetOne", mock.Anything, 9).Return(product_types.ProductType{}, web.ResponseCode{
+			Code: http.StatusNotFound,
+			Err:  product_types.GetErrProductTypeNotFound(9),
+		})
+
+		r := routerProductTypes()
+		r.GET(idURL, productTypeController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, defaultURL+"9", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Id must be a number", func(t *testing.T) {
+		_, productTypeController := newProductTypeController()
+
+		r := routerProductTypes()
+		r.GET(idURL, productTypeController.GetOne())
+
+		req, err := http.NewRequest(http.MethodGet, defaultURL+"abc", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestUpdateProductType(t *testing.T) {
+	t.Run("Rename case", func(t *testing.T) {
+		mockedService, productTypeController := newProductTypeController()
+		expected := fakeProductTypes[1]
+		expected.Name = "frozen"
+		mockedService.On("Update", mock.Anything, 2, map[string]interface{}{"name": "frozen"}).
+			Return(expected, web.ResponseCode{Code: http.StatusOK})
+
+		r := routerProductTypes()
+		r.PATCH(idURL, productTypeController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, defaultURL+"2", bytes.NewBuffer([]byte(`{"name": " frozen "}`)))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "frozen", currentResponse.Data.Name)
+	})
+
+	t.Run("Bad requests", func(t *testing.T) {
+		_, productTypeController := newProductTypeController()
+
+		r := routerProductTypes()
+		r.PATCH(idURL, productTypeController.Update())
+
+		cases := []struct {
+			url  string
+			body string
+			code int
+		}{
+			{defaultURL + "abc", `{"name": "frozen"}`, http.StatusBadRequest},
+			{defaultURL + "2", `{`, http.StatusBadRequest},
+			{defaultURL + "2", `{}`, http.StatusBadRequest},
+			{defaultURL + "2", `{"minimum_temperature": "cold"}`, http.StatusBadRequest},
+			{defaultURL + "2", `{"name": ""}`, http.StatusUnprocessableEntity},
+		}
+
+		for _, currCase := range cases {
+			req, err := http.NewRequest(http.MethodPatch, currCase.url, bytes.NewBuffer([]byte(currCase.body)))
+			assert.Nil(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, currCase.code, rec.Code, currCase.body)
+		}
+	})
+
+	t.Run("Service error", func(t *testing.T) {
+		mockedService, productTypeController := newProductTypeController()
+		mockedService.On("Update", mock.Anything, 2, mock.Anything).Return(product_types.ProductType{}, web.ResponseCode{
+			Code: http.StatusUnprocessableEntity,
+			Err:  errors.New("minimum_temperature must be lower than or equal to maximum_temperature"),
+		})
+
+		r := routerProductTypes()
+		r.PATCH(idURL, productTypeController.Update())
+
+		req, err := http.NewRequest(http.MethodPatch, defaultURL+"2", bytes.NewBuffer([]byte(`{"minimum_temperature": 0}`)))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
+
+func TestDeleteProductType(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		mockedService, productTypeController := newProductTypeController()
+		mockedService.On("Delete", mock.Anything, 1).Return(web.ResponseCode{Code: http.StatusNoContent})
+
+		r := routerProductTypes()
+		r.DELETE(idURL, productTypeController.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, defaultURL+"1", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("Conflict when in use", func(t *testing.T) {
+		mockedService, productTypeController := newProductTypeController()
+		mockedService.On("Delete", mock.Anything, 1).Return(web.ResponseCode{
+			Code: http.StatusConflict,
+			Err:  errors.New("product_type with id 1 is used by 2 products and 1 sections"),
+		})
+
+		r := routerProductTypes()
+		r.DELETE(idURL, productTypeController.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, defaultURL+"1", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, "product_type with id 1 is used by 2 products and 1 sections", currentResponse.Error)
+	})
+
+	t.Run("Id must be a number", func(t *testing.T) {
+		_, productTypeController := newProductTypeController()
+
+		r := routerProductTypes()
+		r.DELETE(idURL, productTypeController.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, defaultURL+"abc", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
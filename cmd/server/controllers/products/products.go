@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/products"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -16,18 +17,18 @@ type ProductController struct {
 }
 
 type reqProducts struct {
-	Id                             int     `json:"id"`
-	ProductCode                    string  `json:"product_code"`
-	Description                    string  `json:"description"`
-	Width                          float64 `json:"width"`
-	Height                         float64 `json:"height"`
-	Length                         float64 `json:"length"`
-	NetWeight                      float64 `json:"net_weight"`
-	ExpirationRate                 float64 `json:"expiration_rate"`
-	RecommendedFreezingTemperature float64 `json:"recommended_freezing_temperature"`
-	FreezingRate                   float64 `json:"freezing_rate"`
-	ProductTypeId                  int     `json:"product_type_id"`
-	SellerId                       int     `json:"seller_id"`
+	Id                             int      `json:"id"`
+	ProductCode                    string   `json:"product_code"`
+	Description                    string   `json:"description"`
+	Width                          float64  `json:"width"`
+	Height                         float64  `json:"height"`
+	Length                         float64  `json:"length"`
+	NetWeight                      float64  `json:"net_weight"`
+	ExpirationRate                 float64  `json:"expiration_rate"`
+	RecommendedFreezingTemperature *float64 `json:"recommended_freezing_temperature"`
+	FreezingRate                   float64  `json:"freezing_rate"`
+	ProductTypeId                  int      `json:"product_type_id"`
+	SellerId                       int      `json:"seller_id"`
 }
 
 func NewProduct(s products.Service) *ProductController {
@@ -42,6 +43,7 @@ func NewProductHandler(r *gin.Engine, cs products.Service) {
 	{
 		productGroup.GET("/:id", productController.GetOne())
 		productGroup.GET("/", productController.GetAll())
+		productGroup.GET("/search", productController.Search())
 		productGroup.POST("/", productController.Create())
 		productGroup.DELETE("/:id", productController.Delete())
 		productGroup.PATCH("/:id", productController.Update())
@@ -64,7 +66,7 @@ func (s *ProductController) Create() gin.HandlerFunc {
 			return
 		}
 
-		product, resp := s.service.Create(requestData.ProductCode, requestData.Description,
+		product, resp := s.service.Create(c.Request.Context(), requestData.ProductCode, requestData.Description,
 			requestData.Width, requestData.Height, requestData.Length, requestData.NetWeight, requestData.ExpirationRate,
 			requestData.RecommendedFreezingTemperature, requestData.FreezingRate, requestData.ProductTypeId, requestData.SellerId)
 
@@ -92,7 +94,7 @@ func (s *ProductController) GetOne() gin.HandlerFunc {
 			return
 		}
 
-		product, resp := s.service.GetOne(parsedId)
+		product, resp := s.service.GetOne(c.Request.Context(), parsedId)
 
 		if resp.Err != nil {
 			c.JSON(
@@ -111,7 +113,13 @@ func (s *ProductController) GetOne() gin.HandlerFunc {
 
 func (s *ProductController) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ProductsList, resp := s.service.GetAll()
+		opts, err := listing.Parse(c.Request.URL.Query(), products.ListFields)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, web.DecodeError(err.Error()))
+			return
+		}
+
+		ProductsList, page, resp := s.service.GetAll(c.Request.Context(), opts)
 
 		if resp.Err != nil {
 			c.JSON(
@@ -123,11 +131,73 @@ func (s *ProductController) GetAll() gin.HandlerFunc {
 
 		c.JSON(
 			http.StatusOK,
-			web.NewResponse(ProductsList),
+			web.NewPageResponse(ProductsList, page),
 		)
 	}
 }
 
+func (s *ProductController) Search() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter := products.ProductSearchFilter{
+			Text:   strings.TrimSpace(c.Query("q")),
+			Sort:   c.Query("sort"),
+			Order:  strings.ToLower(c.Query("order")),
+			Cursor: c.Query("cursor"),
+			Ranges: map[string]products.Range{},
+		}
+
+		intParams := map[string]*int{
+			"seller_id":       &filter.SellerId,
+			"product_type_id": &filter.ProductTypeId,
+			"limit":           &filter.Limit,
+		}
+		for param, target := range intParams {
+			value := c.Query(param)
+			if value == "" {
+				continue
+			}
+			parsedValue, err := strconv.Atoi(value)
+			if err != nil || parsedValue <= 0 {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError(param+" must be a positive number"))
+				return
+			}
+			*target = parsedValue
+		}
+
+		for _, column := range products.RangeColumns {
+			var currRange products.Range
+			for _, bound := range []string{"min", "max"} {
+				param := bound + "_" + column
+				value := c.Query(param)
+				if value == "" {
+					continue
+				}
+				parsedValue, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError(param+" must be a number"))
+					return
+				}
+				if bound == "min" {
+					currRange.Min = &parsedValue
+				} else {
+					currRange.Max = &parsedValue
+				}
+			}
+			if currRange.Min != nil || currRange.Max != nil {
+				filter.Ranges[column] = currRange
+			}
+		}
+
+		page, resp := s.service.Search(c.Request.Context(), filter)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, web.NewResponse(page))
+	}
+}
+
 func (s *ProductController) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
@@ -139,7 +209,7 @@ func (s *ProductController) Delete() gin.HandlerFunc {
 			return
 		}
 
-		resp := s.service.Delete(parsedId)
+		resp := s.service.Delete(c.Request.Context(), parsedId)
 		if resp.Err != nil {
 			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
 			return
@@ -183,7 +253,7 @@ func (s *ProductController) Update() gin.HandlerFunc {
 			}
 		}
 
-		product, resp := s.service.Update(parsedId, requestData)
+		product, resp := s.service.Update(c.Request.Context(), parsedId, requestData)
 
 		if resp.Err != nil {
 			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
@@ -208,9 +278,9 @@ func (s *ProductController) GetReportRecords() gin.HandlerFunc {
 				c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
 				return
 			}
-			reportRecords, resp = s.service.GetReportRecord(parsedId)
+			reportRecords, resp = s.service.GetReportRecord(c.Request.Context(), parsedId)
 		} else {
-			reportRecords, resp = s.service.GetReportRecord(0)
+			reportRecords, resp = s.service.GetReportRecord(c.Request.Context(), 0)
 		}
 		if resp.Err != nil {
 			c.JSON(
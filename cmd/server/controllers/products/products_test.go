@@ -11,6 +11,7 @@ import (
 	controllers "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/products"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/products"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/products/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -109,7 +110,7 @@ var (
 func TestGetProduct(t *testing.T) {
 	t.Run("Get all products", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("GetAll").Return(fakeProducts, web.ResponseCode{})
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(fakeProducts, listing.Page{}, web.ResponseCode{})
 
 		r := routerProducts()
 		r.GET(defaultURL, productController.GetAll())
@@ -131,7 +132,7 @@ func TestGetProduct(t *testing.T) {
 
 	t.Run("Error case", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("GetAll").Return(nil, web.ResponseCode{
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(nil, listing.Page{}, web.ResponseCode{
 			Code: http.StatusInternalServerError,
 			Err:  errServer,
 		})
@@ -156,7 +157,7 @@ func TestGetProduct(t *testing.T) {
 func TestGetOne(t *testing.T) {
 	t.Run("Success case", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(fakeProducts[0], web.ResponseCode{})
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(fakeProducts[0], web.ResponseCode{})
 
 		r := routerProducts()
 		r.GET(idRequest, productController.GetOne())
@@ -177,7 +178,7 @@ func TestGetOne(t *testing.T) {
 
 	t.Run("Not exist case", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(products.Product{}, web.ResponseCode{
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(products.Product{}, web.ResponseCode{
 			Code: http.StatusNotFound,
 			Err:  errProductNotFound,
 		})
@@ -201,7 +202,7 @@ func TestGetOne(t *testing.T) {
 
 	t.Run("Fail when ID is not a number", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(products.Product{}, web.ResponseCode{})
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(products.Product{}, web.ResponseCode{})
 
 		r := routerProducts()
 		r.GET(idRequest, productController.GetOne())
@@ -224,7 +225,7 @@ func TestGetOne(t *testing.T) {
 func TestDeleteProduct(t *testing.T) {
 	t.Run("Success case if exists", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(web.ResponseCode{
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(web.ResponseCode{
 			Code: http.StatusNoContent,
 		})
 
@@ -243,7 +244,7 @@ func TestDeleteProduct(t *testing.T) {
 
 	t.Run("Error case if not exists", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(web.ResponseCode{
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(web.ResponseCode{
 			Code: http.StatusNotFound,
 			Err:  errProductNotFound,
 		})
@@ -267,7 +268,7 @@ func TestDeleteProduct(t *testing.T) {
 
 	t.Run("Fail when ID is not a number", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(products.Product{}, web.ResponseCode{})
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(products.Product{}, web.ResponseCode{})
 
 		r := routerProducts()
 		r.DELETE(idRequest, productController.Delete())
@@ -290,7 +291,7 @@ func TestDeleteProduct(t *testing.T) {
 func TestUpdateProduct(t *testing.T) {
 	t.Run("Sucessfully case", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(fakeProducts[0], web.ResponseCode{})
 
 		parsedFakeProduct, err := json.Marshal(fakeProducts[0])
@@ -318,7 +319,7 @@ func TestUpdateProduct(t *testing.T) {
 
 	t.Run("Not found case", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(products.Product{}, web.ResponseCode{
 				Code: http.StatusNotFound,
 				Err:  errProductNotFound,
@@ -347,7 +348,7 @@ func TestUpdateProduct(t *testing.T) {
 
 	t.Run("Id must be a number", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(products.Product{}, web.ResponseCode{})
 
 		parsedFakeProduct, err := json.Marshal(fakeProducts[0])
@@ -374,7 +375,7 @@ func TestUpdateProduct(t *testing.T) {
 
 	t.Run("Invalid request data", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(products.Product{}, web.ResponseCode{})
 
 		r := routerProducts()
@@ -397,7 +398,7 @@ func TestUpdateProduct(t *testing.T) {
 
 	t.Run("Body needed", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(products.Product{}, web.ResponseCode{})
 
 		r := routerProducts()
@@ -420,7 +421,7 @@ func TestUpdateProduct(t *testing.T) {
 
 	t.Run("Product Code just with blank spaces", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(products.Product{}, web.ResponseCode{})
 
 		r := routerProducts()
@@ -443,7 +444,7 @@ func TestUpdateProduct(t *testing.T) {
 
 	t.Run("Sysntax error on body", func(t *testing.T) {
 		mockedService, productController := newProductController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(products.Product{}, web.ResponseCode{})
 
 		r := routerProducts()
@@ -469,6 +470,7 @@ func TestCreateProduct(t *testing.T) {
 	t.Run("Success on Create", func(t *testing.T) {
 		mockedService, productController := newProductController()
 		mockedService.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("float64"),
@@ -476,7 +478,7 @@ func TestCreateProduct(t *testing.T) {
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
-			mock.AnythingOfType("float64"),
+			mock.AnythingOfType("*float64"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -509,6 +511,7 @@ func TestCreateProduct(t *testing.T) {
 	t.Run("invalid request input", func(t *testing.T) {
 		mockedService, productController := newProductController()
 		mockedService.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("float64"),
@@ -516,7 +519,7 @@ func TestCreateProduct(t *testing.T) {
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
-			mock.AnythingOfType("float64"),
+			mock.AnythingOfType("*float64"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("int"),
 		).
@@ -543,6 +546,7 @@ func TestCreateProduct(t *testing.T) {
 	t.Run("Product Code with only blank spaces", func(t *testing.T) {
 		mockedService, productController := newProductController()
 		mockedService.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("float64"),
@@ -550,7 +554,7 @@ func TestCreateProduct(t *testing.T) {
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
-			mock.AnythingOfType("float64"),
+			mock.AnythingOfType("*float64"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("int"),
 		).Return(products.Product{}, web.ResponseCode{})
@@ -575,9 +579,10 @@ func TestCreateProduct(t *testing.T) {
 
 	t.Run("Conflict Product Code", func(t *testing.T) {
 		mockedService, sellerController := newProductController()
-		mockedService.On("GetAll").Return(fakeProducts, nil)
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(fakeProducts, listing.Page{}, nil)
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("float64"),
@@ -585,7 +590,7 @@ func TestCreateProduct(t *testing.T) {
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
-			mock.AnythingOfType("float64"),
+			mock.AnythingOfType("*float64"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -614,6 +619,7 @@ func TestCreateProduct(t *testing.T) {
 	t.Run("Success on Create", func(t *testing.T) {
 		mockedService, productController := newProductController()
 		mockedService.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("float64"),
@@ -621,7 +627,7 @@ func TestCreateProduct(t *testing.T) {
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
-			mock.AnythingOfType("float64"),
+			mock.AnythingOfType("*float64"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -658,6 +664,7 @@ func TestGetReportRecords(t *testing.T) {
 		mockedService, productsController := newProductController()
 		mockedService.On(
 			"GetReportRecord",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).
 			Return(
@@ -684,3 +691,132 @@ func TestGetReportRecords(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 }
+
+func TestSearchProducts(t *testing.T) {
+	const searchURL = "/api/v1/products/search"
+
+	t.Run("Search with filters", func(t *testing.T) {
+		mockedService, productController := newProductController()
+
+		minWidth, maxTemperature := 10.5, 20.0
+		mockedService.On("Search", mock.Anything, products.ProductSearchFilter{
+			Text:          "batata",
+			SellerId:      1,
+			ProductTypeId: 7,
+			Ranges: map[string]products.Range{
+				"width":                            {Min: &minWidth},
+				"recommended_freezing_temperature": {Max: &maxTemperature},
+			},
+			Sort:   "net_weight",
+			Order:  listing.SortDesc,
+			Limit:  5,
+			Cursor: "abc",
+		}).Return(products.ProductPage{Products: fakeProducts, NextCursor: "next"}, web.ResponseCode{Code: http.StatusOK})
+
+		r := routerProducts()
+		r.GET(searchURL, productController.Search())
+
+		req, err := http.NewRequest(http.MethodGet, searchURL+
+			"?q=batata&seller_id=1&product_type_id=7&min_width=10.5&max_recommended_freezing_temperature=20"+
+			"&sort=net_weight&order=DESC&limit=5&cursor=abc", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse struct {
+			Data products.ProductPage
+		}
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, fakeProducts, currentResponse.Data.Products)
+		assert.Equal(t, "next", currentResponse.Data.NextCursor)
+		mockedService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid numeric parameters", func(t *testing.T) {
+		_, productController := newProductController()
+
+		r := routerProducts()
+		r.GET(searchURL, productController.Search())
+
+		for _, query := range []string{"?seller_id=abc", "?limit=0", "?min_height=tall"} {
+			req, err := http.NewRequest(http.MethodGet, searchURL+query, nil)
+			assert.Nil(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		}
+	})
+
+	t.Run("Service error", func(t *testing.T) {
+		mockedService, productController := newProductController()
+		mockedService.On("Search", mock.Anything, mock.AnythingOfType("products.ProductSearchFilter")).
+			Return(products.ProductPage{}, web.ResponseCode{Code: http.StatusUnprocessableEntity, Err: errors.New("invalid sort column foo")})
+
+		r := routerProducts()
+		r.GET(searchURL, productController.Search())
+
+		req, err := http.NewRequest(http.MethodGet, searchURL+"?sort=foo", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, "invalid sort column foo", currentResponse.Error)
+	})
+}
+
+func TestDeleteProductDependents(t *testing.T) {
+	cases := []struct {
+		url    string
+		method string
+		args   []interface{}
+		resp   web.ResponseCode
+		code   int
+		err    string
+	}{
+		{"/api/v1/products/1", "Delete", []interface{}{mock.Anything, 1}, web.NewCodeResponse(http.StatusConflict, errors.New("product with id 1 is used by 2 product_batches and 3 product_records")), http.StatusConflict, "product with id 1 is used by 2 product_batches and 3 product_records"},
+		{"/api/v1/products/1", "Delete", []interface{}{mock.Anything, 1}, web.NewCodeResponse(http.StatusConflict, errors.New("product with id 1 is used by 3 product_records")), http.StatusConflict, "product with id 1 is used by 3 product_records"},
+		{"/api/v1/products/1?reassign_to=2", "Delete", []interface{}{mock.Anything, 1}, web.NewCodeResponse(http.StatusConflict, errors.New("product with id 1 is used by 2 product_batches and 3 product_records")), http.StatusConflict, "product with id 1 is used by 2 product_batches and 3 product_records"},
+	}
+
+	for _, currCase := range cases {
+		mockedService, controller := newProductController()
+		if currCase.method != "" {
+			mockedService.On(currCase.method, currCase.args...).Return(currCase.resp)
+		}
+
+		r := routerProducts()
+		r.DELETE(idRequest, controller.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, currCase.url, nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, currCase.code, rec.Code, currCase.url)
+		if currCase.err != "" {
+			var currentResponse ObjectErrorResponse
+			err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+			assert.Nil(t, err)
+			assert.Equal(t, currCase.err, currentResponse.Error, currCase.url)
+		}
+
+		if currCase.method != "" {
+			mockedService.AssertExpectations(t)
+		} else {
+			assert.Empty(t, mockedService.Calls)
+		}
+	}
+}
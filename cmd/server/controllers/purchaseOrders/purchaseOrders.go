@@ -18,7 +18,8 @@ type ReqPurchaseOrders struct {
 	OrderDate       string `json:"order_date" binding:"required"`
 	TrackingCode    string `json:"tracking_code" binding:"required"`
 	BuyerId         int    `json:"buyer_id" binding:"required"`
-	ProductRecordId int    `json:"product_record_id" binding:"required"`
+	ProductId       int    `json:"product_id"`
+	ProductRecordId int    `json:"product_record_id"`
 	OrderStatusId   int    `json:"order_status_id" binding:"required"`
 }
 
@@ -42,6 +43,11 @@ func (s *PurchaseOrdersController) CreatePurchaseOrder() gin.HandlerFunc {
 			return
 		}
 
+		if requestData.ProductId == 0 && requestData.ProductRecordId == 0 {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("product_id or product_record_id must be informed"))
+			return
+		}
+
 		const layout = "2006-01-02"
 		orderDate, errDate := time.Parse(layout, requestData.OrderDate)
 
@@ -51,10 +57,12 @@ func (s *PurchaseOrdersController) CreatePurchaseOrder() gin.HandlerFunc {
 		}
 
 		purchaseOrder, resp := s.service.CreatePurchaseOrders(
+			c.Request.Context(),
 			requestData.OrderNumber,
 			orderDate,
 			requestData.TrackingCode,
 			requestData.BuyerId,
+			requestData.ProductId,
 			requestData.ProductRecordId,
 			requestData.OrderStatusId,
 		)
@@ -77,12 +77,14 @@ func TestCreatePurchaseOrder(t *testing.T) {
 		mockedService, PurchaseOrderController := newPurchaseOrdersController()
 
 		mockedService.On("CreatePurchaseOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("time.Time"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
+			mock.AnythingOfType("int"),
 		).Return(successfullyResponse, web.ResponseCode{
 			Code: http.StatusCreated, Err: nil,
 		})
@@ -147,15 +149,42 @@ func TestCreatePurchaseOrder(t *testing.T) {
 		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 	})
 
+	t.Run("Unprocessable entity - product not informed", func(t *testing.T) {
+		_, PurchaseOrderController := newPurchaseOrdersController()
+
+		input := fakeInput
+		input.ProductRecordId = 0
+
+		parsedFakePurchaseOrder, err := json.Marshal(input)
+		assert.NoError(t, err)
+
+		r := router()
+		r.POST(defaultURL, PurchaseOrderController.CreatePurchaseOrder())
+
+		req, err := http.NewRequest(
+			http.MethodPost,
+			defaultURL,
+			bytes.NewBuffer(parsedFakePurchaseOrder),
+		)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
 	t.Run("Fail on create purchase_order", func(t *testing.T) {
 		mockedService, PurchaseOrderController := newPurchaseOrdersController()
 		mockedService.On("CreatePurchaseOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("time.Time"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
+			mock.AnythingOfType("int"),
 		).Return(
 			purchase_orders.PurchaseOrders{},
 			web.ResponseCode{
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sections"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -15,14 +16,14 @@ type SectionController struct {
 }
 
 type reqSections struct {
-	SectionNumber      int `json:"section_number"`
-	CurrentTemperature int `json:"current_temperature"`
-	MinimumTemperature int `json:"minimum_temperature"`
-	CurrentCapacity    int `json:"current_capacity"`
-	MininumCapacity    int `json:"minimum_capacity"`
-	MaximumCapacity    int `json:"maximum_capacity"`
-	WarehouseId        int `json:"warehouse_id"`
-	ProductTypeId      int `json:"product_type_id"`
+	SectionNumber      int  `json:"section_number"`
+	CurrentTemperature int  `json:"current_temperature"`
+	MinimumTemperature *int `json:"minimum_temperature"`
+	CurrentCapacity    int  `json:"current_capacity"`
+	MininumCapacity    int  `json:"minimum_capacity"`
+	MaximumCapacity    int  `json:"maximum_capacity"`
+	WarehouseId        int  `json:"warehouse_id"`
+	ProductTypeId      int  `json:"product_type_id"`
 }
 
 func NewSection(s sections.Service) *SectionController {
@@ -58,6 +59,7 @@ func (s *SectionController) Create() gin.HandlerFunc {
 		}
 
 		section, resp := s.service.Create(
+			c.Request.Context(),
 			requestData.SectionNumber,
 			requestData.CurrentTemperature,
 			requestData.MinimumTemperature,
@@ -92,7 +94,7 @@ func (s *SectionController) GetOne() gin.HandlerFunc {
 			return
 		}
 
-		section, resp := s.service.GetOne(parsedId)
+		section, resp := s.service.GetOne(c.Request.Context(), parsedId)
 
 		if resp.Err != nil {
 			c.JSON(
@@ -111,7 +113,13 @@ func (s *SectionController) GetOne() gin.HandlerFunc {
 
 func (s *SectionController) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		sectionsList, resp := s.service.GetAll()
+		opts, err := listing.Parse(c.Request.URL.Query(), sections.ListFields)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, web.DecodeError(err.Error()))
+			return
+		}
+
+		sectionsList, page, resp := s.service.GetAll(c.Request.Context(), opts)
 
 		if resp.Err != nil {
 			c.JSON(
@@ -123,7 +131,7 @@ func (s *SectionController) GetAll() gin.HandlerFunc {
 
 		c.JSON(
 			http.StatusOK,
-			web.NewResponse(sectionsList),
+			web.NewPageResponse(sectionsList, page),
 		)
 	}
 }
@@ -138,7 +146,14 @@ func (s *SectionController) Delete() gin.HandlerFunc {
 			return
 		}
 
-		resp := s.service.Delete(parsedId)
+		targetId, reassign, resp := web.ParseReassignTo(c, parsedId)
+		if resp.Err == nil {
+			if reassign {
+				resp = s.service.ReassignAndDelete(c.Request.Context(), parsedId, targetId)
+			} else {
+				resp = s.service.Delete(c.Request.Context(), parsedId)
+			}
+		}
 		if resp.Err != nil {
 			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
 			return
@@ -185,7 +200,7 @@ func (s *SectionController) Update() gin.HandlerFunc {
 			}
 		}
 
-		section, resp := s.service.Update(parsedId, requestData)
+		section, resp := s.service.Update(c.Request.Context(), parsedId, requestData)
 
 		if resp.Err != nil {
 			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
@@ -11,6 +11,7 @@ import (
 	controllers "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/sections"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sections"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sections/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -85,7 +86,7 @@ var (
 func TestGetSection(t *testing.T) {
 	t.Run("Get all sections", func(t *testing.T) {
 		mockedService, sectionController := newSectionController()
-		mockedService.On("GetAll").Return(fakeSections, web.ResponseCode{})
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(fakeSections, listing.Page{}, web.ResponseCode{})
 
 		r := routerSections()
 		r.GET(defaultURL, sectionController.GetAll())
@@ -107,7 +108,7 @@ func TestGetSection(t *testing.T) {
 
 	t.Run("Error case", func(t *testing.T) {
 		mockedService, sectionController := newSectionController()
-		mockedService.On("GetAll").Return(nil, web.ResponseCode{
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(nil, listing.Page{}, web.ResponseCode{
 			Code: http.StatusInternalServerError,
 			Err:  errServer,
 		})
@@ -134,7 +135,7 @@ func TestGetOneSection(t *testing.T) {
 		mockedService, sectionController := newSectionController()
 		fakeSection := fakeSections[0]
 
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(fakeSection, web.ResponseCode{})
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(fakeSection, web.ResponseCode{})
 		r := routerSections()
 		r.GET(idRequest, sectionController.GetOne())
 
@@ -156,7 +157,7 @@ func TestGetOneSection(t *testing.T) {
 		mockedService, sectionController := newSectionController()
 		expectedError := errNotFound
 
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(sections.Section{}, web.ResponseCode{
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(sections.Section{}, web.ResponseCode{
 			Code: http.StatusNotFound,
 			Err:  expectedError,
 		})
@@ -182,7 +183,7 @@ func TestGetOneSection(t *testing.T) {
 		mockedService, sectionController := newSectionController()
 		expectedError := errIdNumber
 
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(sections.Section{}, web.ResponseCode{})
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(sections.Section{}, web.ResponseCode{})
 
 		r := routerSections()
 		r.GET(idRequest, sectionController.GetOne())
@@ -206,7 +207,7 @@ func TestDeleteOneSection(t *testing.T) {
 	t.Run("OK Case if exists - 204", func(t *testing.T) {
 		mockedService, sectionController := newSectionController()
 
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(web.ResponseCode{
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(web.ResponseCode{
 			Code: http.StatusNoContent,
 		})
 
@@ -227,7 +228,7 @@ func TestDeleteOneSection(t *testing.T) {
 		mockedService, sectionController := newSectionController()
 
 		expectedError := errNotFound
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(web.ResponseCode{
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(web.ResponseCode{
 			Code: http.StatusNotFound,
 			Err:  expectedError,
 		})
@@ -253,7 +254,7 @@ func TestDeleteOneSection(t *testing.T) {
 		mockedService, sectionController := newSectionController()
 		expectedError := errIdNumber
 
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(sections.Section{}, web.ResponseCode{})
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(sections.Section{}, web.ResponseCode{})
 
 		r := routerSections()
 		r.DELETE(idRequest, sectionController.Delete())
@@ -281,7 +282,7 @@ func TestUpdateOneSection(t *testing.T) {
 		parsedFakeSection, err := json.Marshal(fakeSection)
 		assert.Nil(t, err)
 
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(fakeSection, web.ResponseCode{})
 
 		r := routerSections()
@@ -311,7 +312,7 @@ func TestUpdateOneSection(t *testing.T) {
 
 		mockedService, sectionController := newSectionController()
 
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(sections.Section{}, web.ResponseCode{
 				Code: http.StatusNotFound,
 				Err:  expectedError,
@@ -345,7 +346,7 @@ func TestUpdateOneSection(t *testing.T) {
 
 		mockedService, sectionController := newSectionController()
 
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(sections.Section{}, web.ResponseCode{})
 
 		r := routerSections()
@@ -371,7 +372,7 @@ func TestUpdateOneSection(t *testing.T) {
 		expectedError := errInvalidData
 		mockedService, sectionController := newSectionController()
 
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(sections.Section{}, web.ResponseCode{})
 
 		r := routerSections()
@@ -397,7 +398,7 @@ func TestUpdateOneSection(t *testing.T) {
 		expectedError := errInvalidDataBody
 		mockedService, sectionController := newSectionController()
 
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(sections.Section{}, web.ResponseCode{})
 
 		r := routerSections()
@@ -423,7 +424,7 @@ func TestUpdateOneSection(t *testing.T) {
 		expectedError := errGreatherThanZero
 		mockedService, sectionController := newSectionController()
 
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(sections.Section{}, web.ResponseCode{})
 
 		r := routerSections()
@@ -449,7 +450,7 @@ func TestUpdateOneSection(t *testing.T) {
 		expectedError := errInvalidTypeOfData
 		mockedService, sectionController := newSectionController()
 
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(sections.Section{}, web.ResponseCode{})
 
 		r := gin.Default()
@@ -482,9 +483,10 @@ func TestCreateSection(t *testing.T) {
 
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
-			mock.AnythingOfType("int"),
+			mock.AnythingOfType("*int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -518,6 +520,7 @@ func TestCreateSection(t *testing.T) {
 
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -550,6 +553,7 @@ func TestCreateSection(t *testing.T) {
 
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -580,13 +584,14 @@ func TestCreateSection(t *testing.T) {
 		mockedService, sectionController := newSectionController()
 		expectedError := errAlreadyExists
 
-		mockedService.On("GetAll").Return(fakeSections, nil)
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(fakeSections, listing.Page{}, nil)
 
 		mockedService.On(
 			"Create",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
-			mock.AnythingOfType("int"),
+			mock.AnythingOfType("*int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -616,3 +621,49 @@ func TestCreateSection(t *testing.T) {
 		assert.Equal(t, expectedError.Error(), bodyResponse.Error)
 	})
 }
+
+func TestDeleteSectionDependents(t *testing.T) {
+	cases := []struct {
+		url    string
+		method string
+		args   []interface{}
+		resp   web.ResponseCode
+		code   int
+		err    string
+	}{
+		{"/api/v1/sections/1", "Delete", []interface{}{mock.Anything, 1}, web.NewCodeResponse(http.StatusConflict, errors.New("section with id 1 is used by 5 product_batches")), http.StatusConflict, "section with id 1 is used by 5 product_batches"},
+		{"/api/v1/sections/1?reassign_to=2", "ReassignAndDelete", []interface{}{mock.Anything, 1, 2}, web.NewCodeResponse(http.StatusNoContent, nil), http.StatusNoContent, ""},
+		{"/api/v1/sections/1?reassign_to=abc", "", nil, web.ResponseCode{}, http.StatusBadRequest, "reassign_to must be a number"},
+		{"/api/v1/sections/1?reassign_to=1", "", nil, web.ResponseCode{}, http.StatusUnprocessableEntity, "reassign_to must be different from the deleted id"},
+	}
+
+	for _, currCase := range cases {
+		mockedService, controller := newSectionController()
+		if currCase.method != "" {
+			mockedService.On(currCase.method, currCase.args...).Return(currCase.resp)
+		}
+
+		r := routerSections()
+		r.DELETE(idRequest, controller.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, currCase.url, nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, currCase.code, rec.Code, currCase.url)
+		if currCase.err != "" {
+			var currentResponse ObjectErrorResponse
+			err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+			assert.Nil(t, err)
+			assert.Equal(t, currCase.err, currentResponse.Error, currCase.url)
+		}
+
+		if currCase.method != "" {
+			mockedService.AssertExpectations(t)
+		} else {
+			assert.Empty(t, mockedService.Calls)
+		}
+	}
+}
@@ -1,12 +1,17 @@
 package controllers
 
 import (
+	"encoding/csv"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin/binding"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
 )
@@ -15,20 +20,24 @@ type SellerController struct {
 	service sellers.Service
 }
 
+// reqSellersCreate accepts either the structured_address or, for older
+// clients, the free-text address.
 type reqSellersCreate struct {
-	Cid         int    `json:"cid" binding:"required"`
-	CompanyName string `json:"company_name" binding:"required"`
-	Address     string `json:"address" binding:"required"`
-	Telephone   string `json:"telephone" binding:"required"`
-	LocalityId  string `json:"locality_id" binding:"required"`
+	Cid               string           `json:"cid" binding:"required"`
+	CompanyName       string           `json:"company_name" binding:"required"`
+	Address           string           `json:"address"`
+	StructuredAddress *address.Address `json:"structured_address"`
+	Telephone         string           `json:"telephone" binding:"required"`
+	LocalityId        string           `json:"locality_id" binding:"required"`
 }
 
 type reqSellersUpdate struct {
-	Cid         int    `json:"cid"`
-	CompanyName string `json:"company_name"`
-	Address     string `json:"address"`
-	Telephone   string `json:"telephone"`
-	LocalityId  string `json:"locality_id"`
+	Cid               string           `json:"cid"`
+	CompanyName       string           `json:"company_name"`
+	Address           string           `json:"address"`
+	StructuredAddress *address.Address `json:"structured_address"`
+	Telephone         string           `json:"telephone"`
+	LocalityId        string           `json:"locality_id"`
 }
 
 func NewSeller(s sellers.Service) *SellerController {
@@ -43,6 +52,11 @@ func NewSellerHandler(r *gin.Engine, ss sellers.Service) {
 	{
 		sellerGroup.GET("/:id", sellerController.GetOne())
 		sellerGroup.GET("/", sellerController.GetAll())
+		sellerGroup.GET("/reportProfitability", sellerController.GetProfitabilityReport())
+		sellerGroup.GET("/:id/products", sellerController.GetProducts())
+		sellerGroup.GET("/:id/stock", sellerController.GetStock())
+		sellerGroup.GET("/:id/inbound", sellerController.GetInboundReceipts())
+		sellerGroup.GET("/:id/expirations", sellerController.GetExpirations())
 		sellerGroup.POST("/", sellerController.Create())
 		sellerGroup.DELETE("/:id", sellerController.Delete())
 		sellerGroup.PATCH("/:id", sellerController.Update())
@@ -58,6 +72,14 @@ func (s *SellerController) Create() gin.HandlerFunc {
 			return
 		}
 
+		addr := address.FromLegacy(requestData.Address)
+		if requestData.StructuredAddress != nil {
+			addr = *requestData.StructuredAddress
+		} else if addr.Street == "" {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("invalid request input"))
+			return
+		}
+
 		if len(requestData.CompanyName) > 255 {
 			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("company_name too long: max 255 characters"))
 			return
@@ -79,9 +101,10 @@ func (s *SellerController) Create() gin.HandlerFunc {
 		}
 
 		seller, resp := s.service.Create(
+			c.Request.Context(),
 			requestData.Cid,
 			requestData.CompanyName,
-			requestData.Address,
+			addr,
 			requestData.Telephone,
 			requestData.LocalityId,
 		)
@@ -110,7 +133,7 @@ func (s *SellerController) GetOne() gin.HandlerFunc {
 			return
 		}
 
-		seller, resp := s.service.GetOne(parsedId)
+		seller, resp := s.service.GetOne(c.Request.Context(), parsedId)
 
 		if resp.Err != nil {
 			c.JSON(
@@ -129,7 +152,13 @@ func (s *SellerController) GetOne() gin.HandlerFunc {
 
 func (s *SellerController) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		sellersList, resp := s.service.GetAll()
+		opts, err := listing.Parse(c.Request.URL.Query(), sellers.ListFields)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, web.DecodeError(err.Error()))
+			return
+		}
+
+		sellersList, page, resp := s.service.GetAll(c.Request.Context(), opts)
 
 		if resp.Err != nil {
 			c.JSON(
@@ -141,7 +170,7 @@ func (s *SellerController) GetAll() gin.HandlerFunc {
 
 		c.JSON(
 			http.StatusOK,
-			web.NewResponse(sellersList),
+			web.NewPageResponse(sellersList, page),
 		)
 	}
 }
@@ -157,7 +186,14 @@ func (s *SellerController) Delete() gin.HandlerFunc {
 			return
 		}
 
-		resp := s.service.Delete(parsedId)
+		targetId, reassign, resp := web.ParseReassignTo(c, parsedId)
+		if resp.Err == nil {
+			if reassign {
+				resp = s.service.ReassignAndDelete(c.Request.Context(), parsedId, targetId)
+			} else {
+				resp = s.service.Delete(c.Request.Context(), parsedId)
+			}
+		}
 		if resp.Err != nil {
 			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
 			return
@@ -194,16 +230,6 @@ func (s *SellerController) Update() gin.HandlerFunc {
 			return
 		}
 
-		if value, ok := requestData["cid"].(float64); ok {
-			if value < 1 {
-				c.AbortWithStatusJSON(
-					http.StatusUnprocessableEntity,
-					web.DecodeError("cid must be greather than 0"),
-				)
-				return
-			}
-		}
-
 		if value, ok := requestData["address"].(string); ok {
 			if len(value) > 255 {
 				c.AbortWithStatusJSON(
@@ -244,7 +270,7 @@ func (s *SellerController) Update() gin.HandlerFunc {
 			}
 		}
 
-		seller, resp := s.service.Update(parsedId, requestData)
+		seller, resp := s.service.Update(c.Request.Context(), parsedId, requestData)
 
 		if resp.Err != nil {
 			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
@@ -254,3 +280,216 @@ func (s *SellerController) Update() gin.HandlerFunc {
 		c.JSON(resp.Code, web.NewResponse(seller))
 	}
 }
+
+func (s *SellerController) GetProducts() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parsedId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		opts, err := listing.Parse(c.Request.URL.Query(), sellers.ProductListFields)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, web.DecodeError(err.Error()))
+			return
+		}
+
+		products, page, resp := s.service.GetProducts(c.Request.Context(), parsedId, opts)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewPageResponse(products, page))
+	}
+}
+
+func (s *SellerController) GetStock() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parsedId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		stock, resp := s.service.GetStock(c.Request.Context(), parsedId)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(stock))
+	}
+}
+
+func (s *SellerController) GetInboundReceipts() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parsedId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		opts, err := parseViewOptions(c.Request.URL.Query(), sellers.InboundListFields, "order_date", listing.SortDesc)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, web.DecodeError(err.Error()))
+			return
+		}
+
+		receipts, page, resp := s.service.GetInboundReceipts(c.Request.Context(), parsedId, opts)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewPageResponse(receipts, page))
+	}
+}
+
+// parseViewOptions reads the listing options of a seller view, sorted by
+// sort in order when the request does not choose a sort.
+func parseViewOptions(values url.Values, fields listing.Fields, sort, order string) (listing.Options, error) {
+	if values.Get("sort") == "" {
+		values.Set("sort", sort)
+		if values.Get("order") == "" {
+			values.Set("order", order)
+		}
+	}
+	return listing.Parse(values, fields)
+}
+
+// defaultExpirationDays is the window used when no days query is given.
+const defaultExpirationDays = 30
+
+func (s *SellerController) GetExpirations() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parsedId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+			return
+		}
+
+		values := c.Request.URL.Query()
+
+		days := defaultExpirationDays
+		if value := values.Get("days"); value != "" {
+			days, err = strconv.Atoi(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, web.DecodeError("days must be a number"))
+				return
+			}
+		}
+		values.Del("days")
+
+		opts, err := parseViewOptions(values, sellers.ExpirationListFields, "days_to_expire", listing.SortAsc)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, web.DecodeError(err.Error()))
+			return
+		}
+
+		expirations, page, resp := s.service.GetExpirations(c.Request.Context(), parsedId, days, opts)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewPageResponse(expirations, page))
+	}
+}
+
+var profitabilityCSVHeader = []string{
+	"seller_id", "company_name", "product_id", "product_code", "description", "units_sold",
+	"revenue", "cost", "gross_margin", "margin_percentage", "stock_quantity", "stock_value",
+}
+
+func (s *SellerController) GetProfitabilityReport() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sellerId := 0
+		if id := c.Query("id"); id != "" {
+			parsedId, err := strconv.Atoi(id)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, web.DecodeError("id must be a number"))
+				return
+			}
+			sellerId = parsedId
+		}
+
+		from, err := parseReportDate(c.Query("from"))
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, web.DecodeError("from format incorrect, model: YYYY-MM-DD"))
+			return
+		}
+
+		to, err := parseReportDate(c.Query("to"))
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, web.DecodeError("to format incorrect, model: YYYY-MM-DD"))
+			return
+		}
+
+		format := c.DefaultQuery("format", "json")
+		if format != "json" && format != "csv" {
+			c.JSON(http.StatusUnprocessableEntity, web.DecodeError("format must be json or csv"))
+			return
+		}
+
+		report, resp := s.service.GetProfitabilityReport(c.Request.Context(), sellerId, from, to)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		if format == "csv" {
+			writeProfitabilityCSV(c, report)
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(report))
+	}
+}
+
+func parseReportDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+// writeProfitabilityCSV writes one line per product, repeating the seller
+// columns, so the file can be pivoted by spreadsheet tools.
+func writeProfitabilityCSV(c *gin.Context, report []sellers.SellerProfitability) {
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", "attachment; filename=sellers_profitability.csv")
+	c.Status(http.StatusOK)
+
+	formatFloat := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', 2, 64)
+	}
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Write(profitabilityCSVHeader)
+	for _, seller := range report {
+		for _, product := range seller.Products {
+			writer.Write([]string{
+				strconv.Itoa(seller.SellerId),
+				seller.CompanyName,
+				strconv.Itoa(product.ProductId),
+				product.ProductCode,
+				product.Description,
+				strconv.Itoa(product.UnitsSold),
+				formatFloat(product.Revenue),
+				formatFloat(product.Cost),
+				formatFloat(product.GrossMargin),
+				formatFloat(product.MarginPercentage),
+				strconv.Itoa(product.StockQuantity),
+				formatFloat(product.StockValue),
+			})
+		}
+	}
+	writer.Flush()
+}
@@ -7,10 +7,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	controllers "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/sellers"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -42,14 +45,14 @@ func newSellerController() (*mocks.Service, *controllers.SellerController) {
 
 var fakeSellers = []sellers.Seller{{
 	Id:          1,
-	Cid:         1,
+	Cid:         "11222333000181",
 	CompanyName: "Fake Business",
 	Address:     "Fake Address",
 	Telephone:   "Fake Number",
 	LocalityId:  "12345",
 }, {
 	Id:          2,
-	Cid:         2,
+	Cid:         "20000222000111",
 	CompanyName: "Fake Business",
 	Address:     "Fake Address",
 	Telephone:   "Fake Number",
@@ -77,13 +80,13 @@ var (
 	errAddress        = errors.New("address too long: max 255 characters")
 	errTelephone      = errors.New("telephone too long: max 20 characters")
 	errLocalityId     = errors.New("locality_id too long: max 255 characters")
-	errCidZero        = errors.New("cid must be greather than 0")
+	errInvalidCid     = errors.New("cid must be a valid CNPJ or CPF")
 )
 
 func TestGetSeller(t *testing.T) {
 	t.Run("Get all sellers", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("GetAll").Return(fakeSellers, web.ResponseCode{})
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(fakeSellers, listing.Page{}, web.ResponseCode{})
 
 		r := routerSellers()
 		r.GET(defaultURL, sellerController.GetAll())
@@ -105,7 +108,7 @@ func TestGetSeller(t *testing.T) {
 
 	t.Run("Error case", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("GetAll").Return(nil, web.ResponseCode{
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(nil, listing.Page{}, web.ResponseCode{
 			Code: http.StatusInternalServerError,
 			Err:  errServer,
 		})
@@ -130,7 +133,7 @@ func TestGetSeller(t *testing.T) {
 func TestGetOne(t *testing.T) {
 	t.Run("Success case", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(fakeSellers[0], web.ResponseCode{})
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(fakeSellers[0], web.ResponseCode{})
 
 		r := routerSellers()
 		r.GET(idRequest, sellerController.GetOne())
@@ -151,7 +154,7 @@ func TestGetOne(t *testing.T) {
 
 	t.Run("Not exist case", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(sellers.Seller{}, web.ResponseCode{
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(sellers.Seller{}, web.ResponseCode{
 			Code: http.StatusNotFound,
 			Err:  errSellerNotFound,
 		})
@@ -175,7 +178,7 @@ func TestGetOne(t *testing.T) {
 
 	t.Run("Fail when ID is not a number", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(sellers.Seller{}, web.ResponseCode{})
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(sellers.Seller{}, web.ResponseCode{})
 
 		r := routerSellers()
 		r.GET(idRequest, sellerController.GetOne())
@@ -198,7 +201,7 @@ func TestGetOne(t *testing.T) {
 func TestDeleteSeller(t *testing.T) {
 	t.Run("Success case if exists", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(web.ResponseCode{
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(web.ResponseCode{
 			Code: http.StatusNoContent,
 		})
 
@@ -217,7 +220,7 @@ func TestDeleteSeller(t *testing.T) {
 
 	t.Run("Error case if not exists", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(web.ResponseCode{
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(web.ResponseCode{
 			Code: http.StatusNotFound,
 			Err:  errSellerNotFound,
 		})
@@ -241,7 +244,7 @@ func TestDeleteSeller(t *testing.T) {
 
 	t.Run("Fail when ID is not a number", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(sellers.Seller{}, web.ResponseCode{})
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(sellers.Seller{}, web.ResponseCode{})
 
 		r := routerSellers()
 		r.DELETE(idRequest, sellerController.Delete())
@@ -264,7 +267,7 @@ func TestDeleteSeller(t *testing.T) {
 func TestUpdateSeller(t *testing.T) {
 	t.Run("Sucessfully case", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(fakeSellers[0], web.ResponseCode{})
 
 		parsedFakeSeller, err := json.Marshal(fakeSellers[0])
@@ -291,7 +294,7 @@ func TestUpdateSeller(t *testing.T) {
 
 	t.Run("Not found case", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(sellers.Seller{}, web.ResponseCode{
 				Code: http.StatusNotFound,
 				Err:  errSellerNotFound,
@@ -320,7 +323,7 @@ func TestUpdateSeller(t *testing.T) {
 
 	t.Run("Id must be a number", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(sellers.Seller{}, web.ResponseCode{})
 
 		parsedFakeSeller, err := json.Marshal(fakeSellers[0])
@@ -346,7 +349,7 @@ func TestUpdateSeller(t *testing.T) {
 
 	t.Run("Invalid request data", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(sellers.Seller{}, web.ResponseCode{})
 
 		r := routerSellers()
@@ -369,7 +372,7 @@ func TestUpdateSeller(t *testing.T) {
 
 	t.Run("Body needed", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(sellers.Seller{}, web.ResponseCode{})
 
 		router := routerSellers()
@@ -392,7 +395,7 @@ func TestUpdateSeller(t *testing.T) {
 
 	t.Run("Syntax error on body", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
 			Return(sellers.Seller{}, web.ResponseCode{})
 
 		router := routerSellers()
@@ -415,7 +418,7 @@ func TestUpdateSeller(t *testing.T) {
 
 	t.Run("Unprocessable entity 1 - company_name", func(t *testing.T) {
 		fakeSeller := sellers.Seller{}
-		fakeSeller.Cid = 1
+		fakeSeller.Cid = "11222333000181"
 		fakeSeller.Address = "Nações Unidas"
 		fakeSeller.Telephone = "123"
 		fakeSeller.LocalityId = "123"
@@ -452,7 +455,7 @@ func TestUpdateSeller(t *testing.T) {
 
 	t.Run("Unprocessable entity 2 - address", func(t *testing.T) {
 		fakeSeller := sellers.Seller{}
-		fakeSeller.Cid = 1
+		fakeSeller.Cid = "11222333000181"
 		fakeSeller.CompanyName = "Nações Unidas"
 		fakeSeller.Telephone = "123"
 		fakeSeller.LocalityId = "123"
@@ -489,7 +492,7 @@ func TestUpdateSeller(t *testing.T) {
 
 	t.Run("Unprocessable entity 3 - telephone", func(t *testing.T) {
 		fakeSeller := sellers.Seller{}
-		fakeSeller.Cid = 1
+		fakeSeller.Cid = "11222333000181"
 		fakeSeller.Address = "Nações Unidas"
 		fakeSeller.CompanyName = "123"
 		fakeSeller.LocalityId = "123"
@@ -526,7 +529,7 @@ func TestUpdateSeller(t *testing.T) {
 
 	t.Run("Unprocessable entity 4 - locality_id", func(t *testing.T) {
 		fakeSeller := sellers.Seller{}
-		fakeSeller.Cid = 1
+		fakeSeller.Cid = "11222333000181"
 		fakeSeller.Address = "Nações Unidas"
 		fakeSeller.Telephone = "123"
 		fakeSeller.CompanyName = "123"
@@ -561,14 +564,16 @@ func TestUpdateSeller(t *testing.T) {
 		assert.Equal(t, errLocalityId.Error(), bodyResponse.Error)
 	})
 
-	t.Run("CID greather than zero", func(t *testing.T) {
+	t.Run("CID is not a valid document", func(t *testing.T) {
 		fakeSeller := sellers.Seller{}
-		fakeSeller.Cid = 0
+		fakeSeller.Cid = "11222333000180"
 
 		parsedSeller, err := json.Marshal(fakeSeller)
 		assert.NoError(t, err)
 
-		_, sellerController := newSellerController()
+		mockedService, sellerController := newSellerController()
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).
+			Return(sellers.Seller{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidCid))
 
 		r := routerSellers()
 		r.PATCH(idRequest, sellerController.Update())
@@ -589,7 +594,7 @@ func TestUpdateSeller(t *testing.T) {
 		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
 		assert.Nil(t, err)
 
-		assert.Equal(t, errCidZero.Error(), bodyResponse.Error)
+		assert.Equal(t, errInvalidCid.Error(), bodyResponse.Error)
 	})
 }
 
@@ -598,9 +603,10 @@ func TestCreateSeller(t *testing.T) {
 		mockedService, sellerController := newSellerController()
 		mockedService.On(
 			"Create",
-			mock.AnythingOfType("int"),
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 		).
@@ -633,9 +639,10 @@ func TestCreateSeller(t *testing.T) {
 		mockedService, sellerController := newSellerController()
 		mockedService.On(
 			"Create",
-			mock.AnythingOfType("int"),
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
 			mock.AnythingOfType("string"),
 		).
 			Return(sellers.Seller{}, web.ResponseCode{})
@@ -660,12 +667,13 @@ func TestCreateSeller(t *testing.T) {
 
 	t.Run("Conflict CID", func(t *testing.T) {
 		mockedService, sellerController := newSellerController()
-		mockedService.On("GetAll").Return(fakeSellers, nil)
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(fakeSellers, listing.Page{}, nil)
 		mockedService.On(
 			"Create",
-			mock.AnythingOfType("int"),
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 		).Return(sellers.Seller{}, web.ResponseCode{
@@ -676,7 +684,7 @@ func TestCreateSeller(t *testing.T) {
 		r := routerSellers()
 		r.POST(defaultURL, sellerController.Create())
 
-		req, err := http.NewRequest(http.MethodPost, defaultURL, bytes.NewBuffer([]byte(`{"cid":1,"address":"a","company_name":"a","locality_id":"123","telephone":"123"}`)))
+		req, err := http.NewRequest(http.MethodPost, defaultURL, bytes.NewBuffer([]byte(`{"cid":"11222333000181","address":"a","company_name":"a","locality_id":"123","telephone":"123"}`)))
 		assert.Nil(t, err)
 
 		w := httptest.NewRecorder()
@@ -692,7 +700,7 @@ func TestCreateSeller(t *testing.T) {
 
 	t.Run("Unprocessable entity 1 - company_name", func(t *testing.T) {
 		fakeSeller := sellers.Seller{}
-		fakeSeller.Cid = 1
+		fakeSeller.Cid = "11222333000181"
 		fakeSeller.Address = "Nações Unidas"
 		fakeSeller.Telephone = "123"
 		fakeSeller.LocalityId = "123"
@@ -729,7 +737,7 @@ func TestCreateSeller(t *testing.T) {
 
 	t.Run("Unprocessable entity 2 - address", func(t *testing.T) {
 		fakeSeller := sellers.Seller{}
-		fakeSeller.Cid = 1
+		fakeSeller.Cid = "11222333000181"
 		fakeSeller.CompanyName = "Meli"
 		fakeSeller.Telephone = "123"
 		fakeSeller.LocalityId = "123"
@@ -766,7 +774,7 @@ func TestCreateSeller(t *testing.T) {
 
 	t.Run("Unprocessable entity 3 - telephone", func(t *testing.T) {
 		fakeSeller := sellers.Seller{}
-		fakeSeller.Cid = 1
+		fakeSeller.Cid = "11222333000181"
 		fakeSeller.CompanyName = "Meli"
 		fakeSeller.Address = "Nações Unidas"
 		fakeSeller.LocalityId = "123"
@@ -803,7 +811,7 @@ func TestCreateSeller(t *testing.T) {
 
 	t.Run("Unprocessable entity 4 - locality_id", func(t *testing.T) {
 		fakeSeller := sellers.Seller{}
-		fakeSeller.Cid = 1
+		fakeSeller.Cid = "11222333000181"
 		fakeSeller.CompanyName = "Meli"
 		fakeSeller.Address = "Nações Unidas"
 		fakeSeller.Telephone = "123"
@@ -837,5 +845,430 @@ func TestCreateSeller(t *testing.T) {
 
 		assert.Equal(t, errLocalityId.Error(), bodyResponse.Error)
 	})
+	t.Run("Create with structured_address", func(t *testing.T) {
+		mockedService, sellerController := newSellerController()
+		structured := address.Address{Street: "Av. Paulista", Number: "1000", Cep: "01310-100", State: "SP"}
+		mockedService.On("Create", mock.Anything, "11222333000181", "Fresco", structured, "3003", "6700").
+			Return(sellers.Seller{Id: 1, StructuredAddress: structured}, web.NewCodeResponse(http.StatusCreated, nil)).Once()
+
+		r := routerSellers()
+		r.POST(defaultURL, sellerController.Create())
+
+		body := `{"cid":"11222333000181","company_name":"Fresco","telephone":"3003","locality_id":"6700",` +
+			`"structured_address":{"street":"Av. Paulista","number":"1000","cep":"01310-100","state":"SP"}}`
+		req, err := http.NewRequest(http.MethodPost, defaultURL, bytes.NewBufferString(body))
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockedService.AssertExpectations(t)
+	})
+
+	t.Run("Create without any address", func(t *testing.T) {
+		_, sellerController := newSellerController()
+
+		r := routerSellers()
+		r.POST(defaultURL, sellerController.Create())
+
+		body := `{"cid":"11222333000181","company_name":"Fresco","telephone":"3003","locality_id":"6700"}`
+		req, err := http.NewRequest(http.MethodPost, defaultURL, bytes.NewBufferString(body))
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		var bodyResponse ObjectErrorResponse
+		err = json.Unmarshal(w.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, errInvalidInput.Error(), bodyResponse.Error)
+	})
+
+}
+
+func TestDeleteSellerDependents(t *testing.T) {
+	cases := []struct {
+		url    string
+		method string
+		args   []interface{}
+		resp   web.ResponseCode
+		code   int
+		err    string
+	}{
+		{"/api/v1/sellers/1", "Delete", []interface{}{mock.Anything, 1}, web.NewCodeResponse(http.StatusConflict, errors.New("seller with id 1 is used by 4 products")), http.StatusConflict, "seller with id 1 is used by 4 products"},
+		{"/api/v1/sellers/1?reassign_to=2", "ReassignAndDelete", []interface{}{mock.Anything, 1, 2}, web.NewCodeResponse(http.StatusNoContent, nil), http.StatusNoContent, ""},
+		{"/api/v1/sellers/1?reassign_to=abc", "", nil, web.ResponseCode{}, http.StatusBadRequest, "reassign_to must be a number"},
+		{"/api/v1/sellers/1?reassign_to=1", "", nil, web.ResponseCode{}, http.StatusUnprocessableEntity, "reassign_to must be different from the deleted id"},
+	}
+
+	for _, currCase := range cases {
+		mockedService, controller := newSellerController()
+		if currCase.method != "" {
+			mockedService.On(currCase.method, currCase.args...).Return(currCase.resp)
+		}
+
+		r := routerSellers()
+		r.DELETE(idRequest, controller.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, currCase.url, nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, currCase.code, rec.Code, currCase.url)
+		if currCase.err != "" {
+			var currentResponse ObjectErrorResponse
+			err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+			assert.Nil(t, err)
+			assert.Equal(t, currCase.err, currentResponse.Error, currCase.url)
+		}
+
+		if currCase.method != "" {
+			mockedService.AssertExpectations(t)
+		} else {
+			assert.Empty(t, mockedService.Calls)
+		}
+	}
+}
+
+type ObjectResponseProfitability struct {
+	Data []sellers.SellerProfitability
+}
+
+var fakeProfitability = []sellers.SellerProfitability{{
+	SellerId:         1,
+	CompanyName:      "Fresco",
+	UnitsSold:        3,
+	Revenue:          30,
+	Cost:             18,
+	GrossMargin:      12,
+	MarginPercentage: 40,
+	StockValue:       12,
+	Products: []sellers.ProductProfitability{{
+		ProductId:        10,
+		ProductCode:      "P10",
+		Description:      "Milk",
+		UnitsSold:        3,
+		Revenue:          30,
+		Cost:             18,
+		GrossMargin:      12,
+		MarginPercentage: 40,
+		StockQuantity:    2,
+		StockValue:       12,
+	}},
+}}
+
+const profitabilityURL = "/api/v1/sellers/reportProfitability"
+
+func TestGetProfitabilityReport(t *testing.T) {
+	t.Run("Success case with date range", func(t *testing.T) {
+		mockedService, sellerController := newSellerController()
+		from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC)
+		mockedService.On("GetProfitabilityReport", mock.Anything, 1, &from, &to).Return(fakeProfitability, web.NewCodeResponse(http.StatusOK, nil))
+
+		r := routerSellers()
+		r.GET(profitabilityURL, sellerController.GetProfitabilityReport())
+
+		req, err := http.NewRequest(http.MethodGet, profitabilityURL+"?id=1&from=2022-01-01&to=2022-01-31", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectResponseProfitability
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, fakeProfitability, currentResponse.Data)
+	})
+
+	t.Run("Success case exporting csv", func(t *testing.T) {
+		mockedService, sellerController := newSellerController()
+		mockedService.On("GetProfitabilityReport", mock.Anything, 0, (*time.Time)(nil), (*time.Time)(nil)).Return(fakeProfitability, web.NewCodeResponse(http.StatusOK, nil))
+
+		r := routerSellers()
+		r.GET(profitabilityURL, sellerController.GetProfitabilityReport())
+
+		req, err := http.NewRequest(http.MethodGet, profitabilityURL+"?format=csv", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+		assert.Equal(t,
+			"seller_id,company_name,product_id,product_code,description,units_sold,revenue,cost,gross_margin,margin_percentage,stock_quantity,stock_value\n"+
+				"1,Fresco,10,P10,Milk,3,30.00,18.00,12.00,40.00,2,12.00\n",
+			rec.Body.String())
+	})
+
+	t.Run("Fail when date is invalid", func(t *testing.T) {
+		_, sellerController := newSellerController()
+
+		r := routerSellers()
+		r.GET(profitabilityURL, sellerController.GetProfitabilityReport())
+
+		req, err := http.NewRequest(http.MethodGet, profitabilityURL+"?from=01/01/2022", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, "from format incorrect, model: YYYY-MM-DD", currentResponse.Error)
+	})
+
+	t.Run("Fail when id is not a number", func(t *testing.T) {
+		_, sellerController := newSellerController()
+
+		r := routerSellers()
+		r.GET(profitabilityURL, sellerController.GetProfitabilityReport())
+
+		req, err := http.NewRequest(http.MethodGet, profitabilityURL+"?id=abc", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Fail when seller do not exists", func(t *testing.T) {
+		mockedService, sellerController := newSellerController()
+		mockedService.On("GetProfitabilityReport", mock.Anything, 9, (*time.Time)(nil), (*time.Time)(nil)).
+			Return([]sellers.SellerProfitability{}, web.NewCodeResponse(http.StatusNotFound, errors.New("seller with id 9 not found")))
+
+		r := routerSellers()
+		r.GET(profitabilityURL, sellerController.GetProfitabilityReport())
+
+		req, err := http.NewRequest(http.MethodGet, profitabilityURL+"?id=9&format=csv", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "seller with id 9 not found", currentResponse.Error)
+	})
+}
+
+type ObjectResponseSellerProduct struct {
+	Data []sellers.SellerProduct
+}
+
+type ObjectResponseSellerStock struct {
+	Data []sellers.SellerStock
+}
+
+type ObjectResponseSellerInboundReceipt struct {
+	Data []sellers.SellerInboundReceipt
+}
+
+type ObjectResponseSellerExpiration struct {
+	Data []sellers.SellerExpiration
+}
+
+func TestGetSellerViews(t *testing.T) {
+	t.Run("Success case listing products", func(t *testing.T) {
+		mockedService, sellerController := newSellerController()
+		products := []sellers.SellerProduct{{Id: 10, ProductCode: "P10", Description: "Milk", ProductTypeId: 1}}
+		opts := listing.Options{Limit: listing.DefaultLimit, Sort: "id", Order: listing.SortAsc}
+		mockedService.On("GetProducts", mock.Anything, 1, opts).Return(products, listing.Page{}, web.NewCodeResponse(http.StatusOK, nil))
+
+		r := routerSellers()
+		r.GET("/api/v1/sellers/:id/products", sellerController.GetProducts())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/sellers/1/products", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectResponseSellerProduct
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, products, currentResponse.Data)
+	})
+
+	t.Run("Success case listing stock", func(t *testing.T) {
+		mockedService, sellerController := newSellerController()
+		stock := []sellers.SellerStock{{ProductId: 10, ProductCode: "P10", WarehouseId: 1, WarehouseCode: "W1", BatchesCount: 2, Quantity: 40}}
+		mockedService.On("GetStock", mock.Anything, 1).Return(stock, web.NewCodeResponse(http.StatusOK, nil))
+
+		r := routerSellers()
+		r.GET("/api/v1/sellers/:id/stock", sellerController.GetStock())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/sellers/1/stock", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectResponseSellerStock
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, stock, currentResponse.Data)
+	})
+
+	t.Run("Success case listing inbound receipts", func(t *testing.T) {
+		mockedService, sellerController := newSellerController()
+		receipts := []sellers.SellerInboundReceipt{{OrderNumber: "IO-1", OrderDate: "2022-01-10", ProductId: 10, Quantity: 50}}
+		opts := listing.Options{Limit: listing.DefaultLimit, Sort: "order_date", Order: listing.SortDesc}
+		mockedService.On("GetInboundReceipts", mock.Anything, 1, opts).Return(receipts, listing.Page{}, web.NewCodeResponse(http.StatusOK, nil))
+
+		r := routerSellers()
+		r.GET("/api/v1/sellers/:id/inbound", sellerController.GetInboundReceipts())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/sellers/1/inbound", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectResponseSellerInboundReceipt
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, receipts, currentResponse.Data)
+	})
+
+	t.Run("Success case listing expirations with default days", func(t *testing.T) {
+		mockedService, sellerController := newSellerController()
+		opts := listing.Options{Limit: listing.DefaultLimit, Sort: "days_to_expire", Order: listing.SortAsc}
+		mockedService.On("GetExpirations", mock.Anything, 1, 30, opts).Return([]sellers.SellerExpiration{}, listing.Page{}, web.NewCodeResponse(http.StatusOK, nil))
+
+		r := routerSellers()
+		r.GET("/api/v1/sellers/:id/expirations", sellerController.GetExpirations())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/sellers/1/expirations", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockedService.AssertNumberOfCalls(t, "GetExpirations", 1)
+	})
+
+	t.Run("Success case listing expirations within days", func(t *testing.T) {
+		mockedService, sellerController := newSellerController()
+		expirations := []sellers.SellerExpiration{{BatchNumber: 100, ProductId: 10, DaysToExpire: 5}}
+		opts := listing.Options{Limit: 5, Sort: "days_to_expire", Order: listing.SortAsc}
+		mockedService.On("GetExpirations", mock.Anything, 1, 7, opts).Return(expirations, listing.Page{}, web.NewCodeResponse(http.StatusOK, nil))
+
+		r := routerSellers()
+		r.GET("/api/v1/sellers/:id/expirations", sellerController.GetExpirations())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/sellers/1/expirations?days=7&limit=5", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectResponseSellerExpiration
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, expirations[0].BatchNumber, currentResponse.Data[0].BatchNumber)
+	})
+
+	t.Run("Fail when days is not a number", func(t *testing.T) {
+		_, sellerController := newSellerController()
 
+		r := routerSellers()
+		r.GET("/api/v1/sellers/:id/expirations", sellerController.GetExpirations())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/sellers/1/expirations?days=abc", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "days must be a number", currentResponse.Error)
+	})
+
+	t.Run("Fail when the listing options are invalid", func(t *testing.T) {
+		mockedService, sellerController := newSellerController()
+
+		r := routerSellers()
+		r.GET("/api/v1/sellers/:id/inbound", sellerController.GetInboundReceipts())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/sellers/1/inbound?sort=quantity", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, "invalid sort field quantity", currentResponse.Error)
+		assert.Empty(t, mockedService.Calls)
+	})
+
+	t.Run("Fail when id is not a number", func(t *testing.T) {
+		_, sellerController := newSellerController()
+
+		r := routerSellers()
+		r.GET("/api/v1/sellers/:id/stock", sellerController.GetStock())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/sellers/abc/stock", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Fail when seller does not exist", func(t *testing.T) {
+		mockedService, sellerController := newSellerController()
+		expectedError := sellers.GetErrSellerNotFound(9)
+		mockedService.On("GetProducts", mock.Anything, 9, mock.Anything).Return([]sellers.SellerProduct{}, listing.Page{}, web.NewCodeResponse(http.StatusNotFound, expectedError))
+
+		r := routerSellers()
+		r.GET("/api/v1/sellers/:id/products", sellerController.GetProducts())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/sellers/9/products", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var currentResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, expectedError.Error(), currentResponse.Error)
+	})
 }
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -15,12 +17,16 @@ type WarehouseController struct {
 	service warehouses.Service
 }
 
+// reqWarehouses accepts either the structured_address or, for older
+// clients, the free-text address.
 type reqWarehouses struct {
-	WarehouseCode      string `json:"warehouse_code"`
-	Address            string `json:"address"`
-	Telephone          string `json:"telephone"`
-	MinimumCapacity    int    `json:"minimum_capacity"`
-	MinimumTemperature int    `json:"minimum_temperature"`
+	WarehouseCode      string           `json:"warehouse_code"`
+	Address            string           `json:"address"`
+	StructuredAddress  *address.Address `json:"structured_address"`
+	Telephone          string           `json:"telephone"`
+	MinimumCapacity    int              `json:"minimum_capacity"`
+	MinimumTemperature int              `json:"minimum_temperature"`
+	LocalityId         *string          `json:"locality_id"`
 }
 
 func NewWarehouse(s warehouses.Service) *WarehouseController {
@@ -33,6 +39,7 @@ func NewWarehouseHandler(r *gin.Engine, wh warehouses.Service) {
 	controllerWarehouse := NewWarehouse(wh)
 	warehousesGroup := r.Group("/api/v1/warehouses")
 	{
+		warehousesGroup.GET("/nearest", controllerWarehouse.GetNearest())
 		warehousesGroup.GET("/:id", controllerWarehouse.GetOne())
 		warehousesGroup.GET("/", controllerWarehouse.GetAll())
 		warehousesGroup.POST("/", controllerWarehouse.Create())
@@ -70,7 +77,17 @@ func (s *WarehouseController) Create() gin.HandlerFunc {
 			return
 		}
 
-		warehouse, resp := s.service.Create(requestData.WarehouseCode, requestData.Address, requestData.Telephone, requestData.MinimumCapacity, requestData.MinimumTemperature)
+		if requestData.LocalityId != nil && len(*requestData.LocalityId) > 255 {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, web.DecodeError("locality_id too long: max 255 characters"))
+			return
+		}
+
+		addr := address.FromLegacy(requestData.Address)
+		if requestData.StructuredAddress != nil {
+			addr = *requestData.StructuredAddress
+		}
+
+		warehouse, resp := s.service.Create(c.Request.Context(), requestData.WarehouseCode, addr, requestData.Telephone, requestData.MinimumCapacity, requestData.MinimumTemperature, requestData.LocalityId)
 
 		if resp.Err != nil {
 			c.JSON(resp.Code, gin.H{
@@ -93,7 +110,7 @@ func (s *WarehouseController) GetOne() gin.HandlerFunc {
 			return
 		}
 
-		warehouse, resp := s.service.GetOne(parsedId)
+		warehouse, resp := s.service.GetOne(c.Request.Context(), parsedId)
 
 		if resp.Err != nil {
 			c.JSON(
@@ -109,7 +126,13 @@ func (s *WarehouseController) GetOne() gin.HandlerFunc {
 
 func (s *WarehouseController) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		warehousesList, resp := s.service.GetAll()
+		opts, err := listing.Parse(c.Request.URL.Query(), warehouses.ListFields)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, web.DecodeError(err.Error()))
+			return
+		}
+
+		warehousesList, page, resp := s.service.GetAll(c.Request.Context(), opts)
 
 		if resp.Err != nil {
 			c.JSON(
@@ -121,7 +144,7 @@ func (s *WarehouseController) GetAll() gin.HandlerFunc {
 
 		c.JSON(
 			http.StatusOK,
-			web.NewResponse(warehousesList),
+			web.NewPageResponse(warehousesList, page),
 		)
 	}
 }
@@ -137,7 +160,14 @@ func (s *WarehouseController) Delete() gin.HandlerFunc {
 			return
 		}
 
-		resp := s.service.Delete(parsedId)
+		targetId, reassign, resp := web.ParseReassignTo(c, parsedId)
+		if resp.Err == nil {
+			if reassign {
+				resp = s.service.ReassignAndDelete(c.Request.Context(), parsedId, targetId)
+			} else {
+				resp = s.service.Delete(c.Request.Context(), parsedId)
+			}
+		}
 		if resp.Err != nil {
 			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
 			return
@@ -221,7 +251,17 @@ func (s *WarehouseController) Update() gin.HandlerFunc {
 			}
 		}
 
-		warehouse, resp := s.service.Update(parsedId, requestData)
+		if value, ok := requestData["locality_id"].(string); ok {
+			if len(value) > 255 {
+				c.AbortWithStatusJSON(
+					http.StatusUnprocessableEntity,
+					web.DecodeError("locality_id too long: max 255 characters"),
+				)
+				return
+			}
+		}
+
+		warehouse, resp := s.service.Update(c.Request.Context(), parsedId, requestData)
 		if resp.Err != nil {
 			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
 			return
@@ -230,3 +270,21 @@ func (s *WarehouseController) Update() gin.HandlerFunc {
 		c.JSON(resp.Code, web.NewResponse(warehouse))
 	}
 }
+
+func (s *WarehouseController) GetNearest() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		localityId := c.Query("locality_id")
+		if localityId == "" {
+			c.JSON(http.StatusBadRequest, web.DecodeError("locality_id is required"))
+			return
+		}
+
+		nearest, resp := s.service.GetNearest(c.Request.Context(), localityId)
+		if resp.Err != nil {
+			c.JSON(resp.Code, web.DecodeError(resp.Err.Error()))
+			return
+		}
+
+		c.JSON(resp.Code, web.NewResponse(nearest))
+	}
+}
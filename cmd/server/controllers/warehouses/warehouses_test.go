@@ -11,6 +11,8 @@ import (
 	controllers "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/warehouses"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -64,11 +66,13 @@ func TestControllerWarehouseCreate(t *testing.T) {
 		expectedError := errors.New("warehouse_code already exists")
 
 		mockedService.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
+			mock.AnythingOfType("*string"),
 		).Return(warehouses.Warehouse{}, web.NewCodeResponse(http.StatusConflict, expectedError))
 
 		router := gin.Default()
@@ -216,11 +220,13 @@ func TestControllerWarehouseCreate(t *testing.T) {
 		}
 
 		mockedService.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
+			mock.AnythingOfType("*string"),
 		).Return(expectedReturnData, web.NewCodeResponse(http.StatusCreated, nil))
 
 		router := gin.Default()
@@ -239,6 +245,29 @@ func TestControllerWarehouseCreate(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, expectedReturnData, currentResponse.Data)
 	})
+
+	t.Run("passes the structured_address to the service", func(t *testing.T) {
+		mockedService := new(mocks.Service)
+		warehouseController := controllers.NewWarehouse(mockedService)
+
+		structured := address.Address{Street: "Av. Paulista", Cep: "01310-100", State: "SP", LocalityId: "6700"}
+		mockedService.On("Create", mock.Anything, "W1", structured, "0", 10, 5, (*string)(nil)).
+			Return(warehouses.Warehouse{Id: 1}, web.NewCodeResponse(http.StatusCreated, nil)).Once()
+
+		router := gin.Default()
+		router.POST("/api/v1/warehouses", warehouseController.Create())
+
+		body := `{"warehouse_code":"W1","telephone":"0","minimum_capacity":10,"minimum_temperature":5,` +
+			`"structured_address":{"street":"Av. Paulista","cep":"01310-100","state":"SP","locality_id":"6700"}}`
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/warehouses", bytes.NewBufferString(body))
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		mockedService.AssertExpectations(t)
+	})
 }
 
 func TestControllerWarehouseGetAll(t *testing.T) {
@@ -246,7 +275,7 @@ func TestControllerWarehouseGetAll(t *testing.T) {
 		mockedService := new(mocks.Service)
 		warehouseController := controllers.NewWarehouse(mockedService)
 
-		mockedService.On("GetAll").Return(fakeWarehouse, web.NewCodeResponse(http.StatusOK, nil))
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return(fakeWarehouse, listing.Page{}, web.NewCodeResponse(http.StatusOK, nil))
 
 		router := gin.Default()
 		router.GET("/api/v1/warehouses", warehouseController.GetAll())
@@ -271,7 +300,7 @@ func TestControllerWarehouseGetAll(t *testing.T) {
 
 		expectedError := errors.New("internal server error")
 
-		mockedService.On("GetAll").Return([]warehouses.Warehouse{}, web.NewCodeResponse(http.StatusInternalServerError, expectedError))
+		mockedService.On("GetAll", mock.Anything, mock.AnythingOfType("listing.Options")).Return([]warehouses.Warehouse{}, listing.Page{}, web.NewCodeResponse(http.StatusInternalServerError, expectedError))
 
 		router := gin.Default()
 		router.GET("/api/v1/warehouses", warehouseController.GetAll())
@@ -296,7 +325,7 @@ func TestControllerWarehouseGetOne(t *testing.T) {
 		mockedService := new(mocks.Service)
 		warehouseController := controllers.NewWarehouse(mockedService)
 
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(fakeWarehouse[0], web.ResponseCode{Code: 200, Err: nil})
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(fakeWarehouse[0], web.ResponseCode{Code: 200, Err: nil})
 
 		router := gin.Default()
 		router.GET("/api/v1/warehouses/:id", warehouseController.GetOne())
@@ -321,7 +350,7 @@ func TestControllerWarehouseGetOne(t *testing.T) {
 
 		expectedError := errors.New("id must be a number")
 
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, web.ResponseCode{Code: 400, Err: expectedError})
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, web.ResponseCode{Code: 400, Err: expectedError})
 
 		router := gin.Default()
 		router.GET("/api/v1/warehouses/:id", warehouseController.GetOne())
@@ -345,7 +374,7 @@ func TestControllerWarehouseGetOne(t *testing.T) {
 
 		expectedError := errors.New("warehouse with id 2 not found")
 
-		mockedService.On("GetOne", mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, web.ResponseCode{Code: http.StatusNotFound, Err: expectedError})
+		mockedService.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, web.ResponseCode{Code: http.StatusNotFound, Err: expectedError})
 
 		router := gin.Default()
 		router.GET("/api/v1/warehouses/:id", warehouseController.GetOne())
@@ -370,7 +399,7 @@ func TestControllerWarehouseDelete(t *testing.T) {
 		mockedService := new(mocks.Service)
 		warehouseController := controllers.NewWarehouse(mockedService)
 
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(web.NewCodeResponse(http.StatusNoContent, nil))
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(web.NewCodeResponse(http.StatusNoContent, nil))
 
 		router := gin.Default()
 		router.DELETE("/api/v1/warehouses/:id", warehouseController.Delete())
@@ -413,7 +442,7 @@ func TestControllerWarehouseDelete(t *testing.T) {
 
 		expectedError := errors.New("warehouse with id 2 was deleted")
 
-		mockedService.On("Delete", mock.AnythingOfType("int")).Return(web.NewCodeResponse(http.StatusNotFound, expectedError))
+		mockedService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(web.NewCodeResponse(http.StatusNotFound, expectedError))
 
 		router := gin.Default()
 		router.DELETE("/api/v1/warehouses/:id", warehouseController.Delete())
@@ -438,7 +467,7 @@ func TestControllerWarehouseUpdate(t *testing.T) {
 		mockedService := new(mocks.Service)
 		WarehouseController := controllers.NewWarehouse(mockedService)
 
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).Return(fakeWarehouse[0], web.ResponseCode{Code: 200, Err: nil})
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).Return(fakeWarehouse[0], web.ResponseCode{Code: 200, Err: nil})
 
 		router := gin.Default()
 		router.PATCH("/api/v1/warehouses/:id", WarehouseController.Update())
@@ -619,7 +648,7 @@ func TestControllerWarehouseUpdate(t *testing.T) {
 
 		expectedError := errors.New("warehouse not found")
 
-		mockedService.On("Update", mock.AnythingOfType("int"), mock.Anything).Return(warehouses.Warehouse{}, web.ResponseCode{Code: http.StatusNotFound, Err: errors.New("warehouse not found")})
+		mockedService.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).Return(warehouses.Warehouse{}, web.ResponseCode{Code: http.StatusNotFound, Err: errors.New("warehouse not found")})
 
 		router := gin.Default()
 		router.PATCH("/api/v1/warehouses/:id", WarehouseController.Update())
@@ -641,3 +670,125 @@ func TestControllerWarehouseUpdate(t *testing.T) {
 		assert.Equal(t, expectedError.Error(), currentResponse.Error)
 	})
 }
+
+func TestDeleteWarehouseDependents(t *testing.T) {
+	cases := []struct {
+		url    string
+		method string
+		args   []interface{}
+		resp   web.ResponseCode
+		code   int
+		err    string
+	}{
+		{"/api/v1/warehouses/1", "Delete", []interface{}{mock.Anything, 1}, web.NewCodeResponse(http.StatusConflict, errors.New("warehouse with id 1 is used by 1 sections, 2 employees and 3 inbound_orders")), http.StatusConflict, "warehouse with id 1 is used by 1 sections, 2 employees and 3 inbound_orders"},
+		{"/api/v1/warehouses/1", "Delete", []interface{}{mock.Anything, 1}, web.NewCodeResponse(http.StatusConflict, errors.New("warehouse with id 1 is used by 2 employees")), http.StatusConflict, "warehouse with id 1 is used by 2 employees"},
+		{"/api/v1/warehouses/1?reassign_to=2", "ReassignAndDelete", []interface{}{mock.Anything, 1, 2}, web.NewCodeResponse(http.StatusNoContent, nil), http.StatusNoContent, ""},
+		{"/api/v1/warehouses/1?reassign_to=abc", "", nil, web.ResponseCode{}, http.StatusBadRequest, "reassign_to must be a number"},
+		{"/api/v1/warehouses/1?reassign_to=1", "", nil, web.ResponseCode{}, http.StatusUnprocessableEntity, "reassign_to must be different from the deleted id"},
+	}
+
+	for _, currCase := range cases {
+		mockedService := new(mocks.Service)
+		controller := controllers.NewWarehouse(mockedService)
+		if currCase.method != "" {
+			mockedService.On(currCase.method, currCase.args...).Return(currCase.resp)
+		}
+
+		r := gin.Default()
+		r.DELETE("/api/v1/warehouses/:id", controller.Delete())
+
+		req, err := http.NewRequest(http.MethodDelete, currCase.url, nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, currCase.code, rec.Code, currCase.url)
+		if currCase.err != "" {
+			var currentResponse ObjectErrorResponse
+			err = json.Unmarshal(rec.Body.Bytes(), &currentResponse)
+			assert.Nil(t, err)
+			assert.Equal(t, currCase.err, currentResponse.Error, currCase.url)
+		}
+
+		if currCase.method != "" {
+			mockedService.AssertExpectations(t)
+		} else {
+			assert.Empty(t, mockedService.Calls)
+		}
+	}
+}
+
+type ObjectResponseDistances struct {
+	Data []warehouses.WarehouseDistance
+}
+
+func TestControllerWarehouseGetNearest(t *testing.T) {
+	t.Run("return warehouses ordered by distance", func(t *testing.T) {
+		mockedService := new(mocks.Service)
+		warehouseController := controllers.NewWarehouse(mockedService)
+
+		expected := []warehouses.WarehouseDistance{
+			{Warehouse: fakeWarehouse[1], DistanceKm: 12.5},
+			{Warehouse: fakeWarehouse[0], DistanceKm: 486.31},
+		}
+		mockedService.On("GetNearest", mock.Anything, "2").Return(expected, web.NewCodeResponse(http.StatusOK, nil))
+
+		router := gin.Default()
+		router.GET("/api/v1/warehouses/nearest", warehouseController.GetNearest())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/warehouses/nearest?locality_id=2", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var bodyResponse ObjectResponseDistances
+		err = json.Unmarshal(rec.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, bodyResponse.Data)
+	})
+
+	t.Run("return bad request without locality_id", func(t *testing.T) {
+		mockedService := new(mocks.Service)
+		warehouseController := controllers.NewWarehouse(mockedService)
+
+		router := gin.Default()
+		router.GET("/api/v1/warehouses/nearest", warehouseController.GetNearest())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/warehouses/nearest", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("return the service error", func(t *testing.T) {
+		mockedService := new(mocks.Service)
+		warehouseController := controllers.NewWarehouse(mockedService)
+
+		expectedError := errors.New("locality with id 1 has no coordinates")
+		mockedService.On("GetNearest", mock.Anything, "1").
+			Return([]warehouses.WarehouseDistance{}, web.NewCodeResponse(http.StatusUnprocessableEntity, expectedError))
+
+		router := gin.Default()
+		router.GET("/api/v1/warehouses/nearest", warehouseController.GetNearest())
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/warehouses/nearest?locality_id=1", nil)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+		var bodyResponse ObjectErrorResponse
+		err = json.Unmarshal(rec.Body.Bytes(), &bodyResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedError.Error(), bodyResponse.Error)
+	})
+}
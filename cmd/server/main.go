@@ -1,27 +1,33 @@
 package main
 
 import (
-	"database/sql"
+	"context"
+	"flag"
 	"log"
+	"net/http"
 	"os"
 
 	buyersController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/buyers"
 	carriersController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/carriers"
 	employeesController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/employees"
+	importsController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/imports"
 	inboundOrdersController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/inboundOrders"
 	localitiesController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/localities"
+	orderStatusController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/orderStatus"
 	productBatchesController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/productBatches"
 	productRecordsController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/productRecords"
+	productTypesController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/productTypes"
 	productsController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/products"
 	purchaseOrdersController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/purchaseOrders"
 	sectionsController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/sections"
 	sellersController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/sellers"
 	warehousesController "github.com/emidioreb/mercado-fresco-lerigophers/cmd/server/controllers/warehouses"
-	"github.com/joho/godotenv"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/carriers"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/config"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/employees"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/imports"
 	order_status "github.com/emidioreb/mercado-fresco-lerigophers/internal/orderStatus"
 	product_records "github.com/emidioreb/mercado-fresco-lerigophers/internal/productRecords"
 	product_types "github.com/emidioreb/mercado-fresco-lerigophers/internal/productTypes"
@@ -35,42 +41,59 @@ import (
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sections"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/gin-gonic/gin"
 
 	_ "github.com/go-sql-driver/mysql"
 )
 
 func main() {
-	server := gin.Default()
-	err := godotenv.Load()
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
 	if err != nil {
-		log.Fatal("Error loading .env file")
+		log.Fatal(err)
 	}
 
-	dataSource := os.Getenv("SERVER_URI")
-	PORT := ":" + os.Getenv("PORT")
-
-	conn, _ := sql.Open("mysql", dataSource)
-	_, err = conn.Query("USE mercado_fresco")
+	conn, err := cfg.DB.Open()
 	if err != nil {
-		log.Fatal("Couldn't connect to database: mercado_fresco do not exists")
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	if args := flag.Args(); len(args) > 0 {
+		if args[0] != "migrate" {
+			log.Fatalf("unknown command %s", args[0])
+		}
+		if err := runMigrate(context.Background(), conn, args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
 
-	conn, err = sql.Open("mysql", dataSource)
-	if err != nil {
-		log.Fatal("failed to connect to mariadb")
+	if cfg.AutoMigrate {
+		migrator, err := newMigrator(conn)
+		if err != nil {
+			log.Fatal(err)
+		}
+		applied, err := migrator.Up(context.Background())
+		printMigrations("applied", applied)
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 
-	repoProductBatches := product_batches.NewMariaDbRepository(conn)
-	serviceProductBatches := product_batches.NewService(repoProductBatches)
-	productBatchesController.NewProductBatchHandler(server, serviceProductBatches)
+	gin.SetMode(cfg.GinMode)
+	server := gin.Default()
+	server.Use(web.Timeout(cfg.HTTP.Requests))
+
+	transactions := transaction.NewDB(conn)
 
 	repoLocalities := localities.NewMariaDbRepository(conn)
 	serviceLocality := localities.NewService(repoLocalities)
 	localitiesController.NewLocalityHandle(server, serviceLocality)
 
 	repoBuyer := buyers.NewMariaDbRepository(conn)
-	serviceBuyer := buyers.NewService(repoBuyer)
+	serviceBuyer := buyers.NewService(repoBuyer, repoLocalities)
 	buyersController.NewBuyerHandler(server, serviceBuyer)
 
 	repoSellers := sellers.NewMariaDbRepository(conn)
@@ -78,19 +101,25 @@ func main() {
 	sellersController.NewSellerHandler(server, service)
 
 	repoWarehouse := warehouses.NewMariaDbRepository(conn)
-	serviceWarehouse := warehouses.NewService(repoWarehouse)
+	serviceWarehouse := warehouses.NewService(repoWarehouse, repoLocalities)
 	warehousesController.NewWarehouseHandler(server, serviceWarehouse)
 
 	repoProductType := product_types.NewMariaDbRepository(conn)
+	serviceProductType := product_types.NewService(repoProductType)
+	productTypesController.NewProductTypeHandler(server, serviceProductType)
 
 	repoSection := sections.NewMariaDbRepository(conn)
-	serviceSection := sections.NewService(repoSection, repoWarehouse, repoProductType)
+	serviceSection := sections.NewService(repoSection, repoWarehouse, repoProductType, transactions)
 	sectionsController.NewSectionHandler(server, serviceSection)
 
 	repoProduct := products.NewMariaDbRepository(conn)
-	serviceProduct := products.NewService(repoProduct, repoSellers)
+	serviceProduct := products.NewService(repoProduct, repoSellers, repoProductType)
 	productsController.NewProductHandler(server, serviceProduct)
 
+	repoProductBatches := product_batches.NewMariaDbRepository(conn)
+	serviceProductBatches := product_batches.NewService(repoProductBatches, repoProduct)
+	productBatchesController.NewProductBatchHandler(server, serviceProductBatches)
+
 	repoProductRecords := product_records.NewMariaDbRepository(conn)
 	serviceProductRecords := product_records.NewService(repoProductRecords, repoProduct)
 
@@ -101,18 +130,34 @@ func main() {
 	employeesController.NewEmployeeHandler(server, serviceEmployee)
 
 	repoCarriers := carriers.NewMariaDbRepository(conn)
-	serviceCarriers := carriers.NewService(repoCarriers)
+	serviceCarriers := carriers.NewService(repoCarriers, repoLocalities)
 	carriersController.NewCarryHandler(server, serviceCarriers)
 
 	repoInbound := inboundorders.NewMariaDbRepository(conn)
-	serviceInbound := inboundorders.NewService(repoInbound, repoWarehouse, repoEmployee, repoProductBatches)
+	serviceInbound := inboundorders.NewService(repoInbound, repoWarehouse, repoEmployee, repoProductBatches, repoSection, transactions)
 	inboundOrdersController.NewInboundHandler(server, serviceInbound)
 
 	repoOrderStatus := order_status.NewMariaDbRepository(conn)
+	serviceOrderStatus := order_status.NewService(repoOrderStatus)
+	orderStatusController.NewOrderStatusHandler(server, serviceOrderStatus)
 
 	repoPurchaseOrders := purchase_orders.NewMariaDbRepository(conn)
-	servicePurchaseOrders := purchase_orders.NewService(repoPurchaseOrders, repoBuyer, repoProductRecords, repoOrderStatus)
+	servicePurchaseOrders := purchase_orders.NewService(repoPurchaseOrders, repoBuyer, repoProductRecords, repoOrderStatus, transactions)
 	purchaseOrdersController.NewPurchaseOrderHandler(server, servicePurchaseOrders)
 
-	server.Run(PORT)
+	serviceImports := imports.NewService(serviceProduct, service, serviceBuyer, transactions)
+	importsController.NewImportHandler(server, serviceImports)
+
+	httpServer := &http.Server{
+		Addr:              cfg.Addr(),
+		Handler:           server,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+	}
+
+	if err := httpServer.ListenAndServe(); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/migrations"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/migrate"
+)
+
+const migrateUsage = `usage: server migrate <command>
+
+commands:
+  up              apply every pending migration
+  down [n]        revert the last n applied migrations, 1 by default
+  to VERSION      apply or revert migrations until VERSION is the last applied one
+  status          list the migrations and whether they were applied
+  force VERSION   mark the migrations up to VERSION as applied without running them
+  seed            insert the sample data of development`
+
+func newMigrator(conn *sql.DB) (*migrate.Migrator, error) {
+	loaded, err := migrations.Load()
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(conn, loaded), nil
+}
+
+// runMigrate runs the migrate subcommand given by args, e.g. "up" or
+// "down 2".
+func runMigrate(ctx context.Context, conn *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, err := newMigrator(conn)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		printMigrations("applied", applied)
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil {
+				return fmt.Errorf("invalid number of steps %s", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		printMigrations("reverted", reverted)
+		return err
+	case "to":
+		version, err := versionArg(args)
+		if err != nil {
+			return err
+		}
+		done, err := migrator.To(ctx, version)
+		printMigrations("migrated", done)
+		return err
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range status {
+			state := "pending"
+			if s.Applied {
+				state = "applied"
+			}
+			fmt.Printf("%04d_%s\t%s\n", s.Version, s.Name, state)
+		}
+		return nil
+	case "force":
+		version, err := versionArg(args)
+		if err != nil {
+			return err
+		}
+		return migrator.Force(ctx, version)
+	case "seed":
+		return migrator.Exec(ctx, migrations.Seed)
+	}
+
+	return errors.New(migrateUsage)
+}
+
+func versionArg(args []string) (int, error) {
+	if len(args) < 2 {
+		return 0, errors.New(migrateUsage)
+	}
+
+	version, err := strconv.Atoi(args[1])
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("invalid migration version %s", args[1])
+	}
+
+	return version, nil
+}
+
+func printMigrations(action string, done []migrate.Migration) {
+	for _, migration := range done {
+		fmt.Printf("%s %04d_%s\n", action, migration.Version, migration.Name)
+	}
+}
@@ -3,7 +3,12 @@
 package mocks
 
 import (
+	context "context"
+
 	buyers "github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers"
+
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -12,20 +17,20 @@ type Repository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: cardNumberId, firstName, lastName
-func (_m *Repository) Create(cardNumberId string, firstName string, lastName string) (buyers.Buyer, error) {
-	ret := _m.Called(cardNumberId, firstName, lastName)
+// Create provides a mock function with given fields: ctx, cardNumberId, firstName, lastName, localityId
+func (_m *Repository) Create(ctx context.Context, cardNumberId string, firstName string, lastName string, localityId *string) (buyers.Buyer, error) {
+	ret := _m.Called(ctx, cardNumberId, firstName, lastName, localityId)
 
 	var r0 buyers.Buyer
-	if rf, ok := ret.Get(0).(func(string, string, string) buyers.Buyer); ok {
-		r0 = rf(cardNumberId, firstName, lastName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *string) buyers.Buyer); ok {
+		r0 = rf(ctx, cardNumberId, firstName, lastName, localityId)
 	} else {
 		r0 = ret.Get(0).(buyers.Buyer)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(cardNumberId, firstName, lastName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *string) error); ok {
+		r1 = rf(ctx, cardNumberId, firstName, lastName, localityId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,13 +38,13 @@ func (_m *Repository) Create(cardNumberId string, firstName string, lastName str
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Repository) Delete(id int) error {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Repository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,13 +52,13 @@ func (_m *Repository) Delete(id int) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *Repository) GetAll() ([]buyers.Buyer, error) {
-	ret := _m.Called()
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Repository) GetAll(ctx context.Context, opts listing.Options) ([]buyers.Buyer, error) {
+	ret := _m.Called(ctx, opts)
 
 	var r0 []buyers.Buyer
-	if rf, ok := ret.Get(0).(func() []buyers.Buyer); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []buyers.Buyer); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]buyers.Buyer)
@@ -61,8 +66,8 @@ func (_m *Repository) GetAll() ([]buyers.Buyer, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) error); ok {
+		r1 = rf(ctx, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,20 +75,20 @@ func (_m *Repository) GetAll() ([]buyers.Buyer, error) {
 	return r0, r1
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Repository) GetOne(id int) (buyers.Buyer, error) {
-	ret := _m.Called(id)
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Repository) GetOne(ctx context.Context, id int) (buyers.Buyer, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 buyers.Buyer
-	if rf, ok := ret.Get(0).(func(int) buyers.Buyer); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) buyers.Buyer); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(buyers.Buyer)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -91,13 +96,13 @@ func (_m *Repository) GetOne(id int) (buyers.Buyer, error) {
 	return r0, r1
 }
 
-// GetReportPurchaseOrders provides a mock function with given fields: BuyerId
-func (_m *Repository) GetReportPurchaseOrders(BuyerId int) ([]buyers.ReportPurchaseOrders, error) {
-	ret := _m.Called(BuyerId)
+// GetReportPurchaseOrders provides a mock function with given fields: ctx, BuyerId
+func (_m *Repository) GetReportPurchaseOrders(ctx context.Context, BuyerId int) ([]buyers.ReportPurchaseOrders, error) {
+	ret := _m.Called(ctx, BuyerId)
 
 	var r0 []buyers.ReportPurchaseOrders
-	if rf, ok := ret.Get(0).(func(int) []buyers.ReportPurchaseOrders); ok {
-		r0 = rf(BuyerId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []buyers.ReportPurchaseOrders); ok {
+		r0 = rf(ctx, BuyerId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]buyers.ReportPurchaseOrders)
@@ -105,8 +110,8 @@ func (_m *Repository) GetReportPurchaseOrders(BuyerId int) ([]buyers.ReportPurch
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(BuyerId)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, BuyerId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -114,20 +119,20 @@ func (_m *Repository) GetReportPurchaseOrders(BuyerId int) ([]buyers.ReportPurch
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, requestData
-func (_m *Repository) Update(id int, requestData map[string]interface{}) (buyers.Buyer, error) {
-	ret := _m.Called(id, requestData)
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Repository) Update(ctx context.Context, id int, requestData map[string]interface{}) (buyers.Buyer, error) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 buyers.Buyer
-	if rf, ok := ret.Get(0).(func(int, map[string]interface{}) buyers.Buyer); ok {
-		r0 = rf(id, requestData)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) buyers.Buyer); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(buyers.Buyer)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int, map[string]interface{}) error); ok {
-		r1 = rf(id, requestData)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) error); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,7 +3,12 @@
 package mocks
 
 import (
+	context "context"
+
 	buyers "github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers"
+
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+
 	mock "github.com/stretchr/testify/mock"
 
 	web "github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
@@ -14,20 +19,20 @@ type Service struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: cardNumberId, firstName, lastName
-func (_m *Service) Create(cardNumberId string, firstName string, lastName string) (buyers.Buyer, web.ResponseCode) {
-	ret := _m.Called(cardNumberId, firstName, lastName)
+// Create provides a mock function with given fields: ctx, cardNumberId, firstName, lastName, localityId
+func (_m *Service) Create(ctx context.Context, cardNumberId string, firstName string, lastName string, localityId *string) (buyers.Buyer, web.ResponseCode) {
+	ret := _m.Called(ctx, cardNumberId, firstName, lastName, localityId)
 
 	var r0 buyers.Buyer
-	if rf, ok := ret.Get(0).(func(string, string, string) buyers.Buyer); ok {
-		r0 = rf(cardNumberId, firstName, lastName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *string) buyers.Buyer); ok {
+		r0 = rf(ctx, cardNumberId, firstName, lastName, localityId)
 	} else {
 		r0 = ret.Get(0).(buyers.Buyer)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(string, string, string) web.ResponseCode); ok {
-		r1 = rf(cardNumberId, firstName, lastName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *string) web.ResponseCode); ok {
+		r1 = rf(ctx, cardNumberId, firstName, lastName, localityId)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -35,13 +40,13 @@ func (_m *Service) Create(cardNumberId string, firstName string, lastName string
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Service) Delete(id int) web.ResponseCode {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Service) Delete(ctx context.Context, id int) web.ResponseCode {
+	ret := _m.Called(ctx, id)
 
 	var r0 web.ResponseCode
-	if rf, ok := ret.Get(0).(func(int) web.ResponseCode); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) web.ResponseCode); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(web.ResponseCode)
 	}
@@ -49,43 +54,50 @@ func (_m *Service) Delete(id int) web.ResponseCode {
 	return r0
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *Service) GetAll() ([]buyers.Buyer, web.ResponseCode) {
-	ret := _m.Called()
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Service) GetAll(ctx context.Context, opts listing.Options) ([]buyers.Buyer, listing.Page, web.ResponseCode) {
+	ret := _m.Called(ctx, opts)
 
 	var r0 []buyers.Buyer
-	if rf, ok := ret.Get(0).(func() []buyers.Buyer); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []buyers.Buyer); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]buyers.Buyer)
 		}
 	}
 
-	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func() web.ResponseCode); ok {
-		r1 = rf()
+	var r1 listing.Page
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) listing.Page); ok {
+		r1 = rf(ctx, opts)
 	} else {
-		r1 = ret.Get(1).(web.ResponseCode)
+		r1 = ret.Get(1).(listing.Page)
 	}
 
-	return r0, r1
+	var r2 web.ResponseCode
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Options) web.ResponseCode); ok {
+		r2 = rf(ctx, opts)
+	} else {
+		r2 = ret.Get(2).(web.ResponseCode)
+	}
+
+	return r0, r1, r2
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Service) GetOne(id int) (buyers.Buyer, web.ResponseCode) {
-	ret := _m.Called(id)
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Service) GetOne(ctx context.Context, id int) (buyers.Buyer, web.ResponseCode) {
+	ret := _m.Called(ctx, id)
 
 	var r0 buyers.Buyer
-	if rf, ok := ret.Get(0).(func(int) buyers.Buyer); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) buyers.Buyer); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(buyers.Buyer)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int) web.ResponseCode); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -93,13 +105,13 @@ func (_m *Service) GetOne(id int) (buyers.Buyer, web.ResponseCode) {
 	return r0, r1
 }
 
-// GetReportPurchaseOrders provides a mock function with given fields: SectionId
-func (_m *Service) GetReportPurchaseOrders(SectionId int) ([]buyers.ReportPurchaseOrders, web.ResponseCode) {
-	ret := _m.Called(SectionId)
+// GetReportPurchaseOrders provides a mock function with given fields: ctx, SectionId
+func (_m *Service) GetReportPurchaseOrders(ctx context.Context, SectionId int) ([]buyers.ReportPurchaseOrders, web.ResponseCode) {
+	ret := _m.Called(ctx, SectionId)
 
 	var r0 []buyers.ReportPurchaseOrders
-	if rf, ok := ret.Get(0).(func(int) []buyers.ReportPurchaseOrders); ok {
-		r0 = rf(SectionId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []buyers.ReportPurchaseOrders); ok {
+		r0 = rf(ctx, SectionId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]buyers.ReportPurchaseOrders)
@@ -107,8 +119,8 @@ func (_m *Service) GetReportPurchaseOrders(SectionId int) ([]buyers.ReportPurcha
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int) web.ResponseCode); ok {
-		r1 = rf(SectionId)
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, SectionId)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -116,20 +128,20 @@ func (_m *Service) GetReportPurchaseOrders(SectionId int) ([]buyers.ReportPurcha
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, requestData
-func (_m *Service) Update(id int, requestData map[string]interface{}) (buyers.Buyer, web.ResponseCode) {
-	ret := _m.Called(id, requestData)
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Service) Update(ctx context.Context, id int, requestData map[string]interface{}) (buyers.Buyer, web.ResponseCode) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 buyers.Buyer
-	if rf, ok := ret.Get(0).(func(int, map[string]interface{}) buyers.Buyer); ok {
-		r0 = rf(id, requestData)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) buyers.Buyer); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(buyers.Buyer)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int, map[string]interface{}) web.ResponseCode); ok {
-		r1 = rf(id, requestData)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) web.ResponseCode); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -137,6 +149,20 @@ func (_m *Service) Update(id int, requestData map[string]interface{}) (buyers.Bu
 	return r0, r1
 }
 
+// ValidateCreate provides a mock function with given fields: ctx, cardNumberId, firstName, lastName, localityId
+func (_m *Service) ValidateCreate(ctx context.Context, cardNumberId string, firstName string, lastName string, localityId *string) web.ResponseCode {
+	ret := _m.Called(ctx, cardNumberId, firstName, lastName, localityId)
+
+	var r0 web.ResponseCode
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *string) web.ResponseCode); ok {
+		r0 = rf(ctx, cardNumberId, firstName, lastName, localityId)
+	} else {
+		r0 = ret.Get(0).(web.ResponseCode)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewService interface {
 	mock.TestingT
 	Cleanup(func())
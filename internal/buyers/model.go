@@ -1,10 +1,32 @@
 package buyers
 
+import (
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/document"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+)
+
+// AcceptedDocuments are the documents a buyer may be registered with as card_number_id.
+var AcceptedDocuments = []document.Type{document.CPF, document.CNPJ}
+
+// ListFields are the fields buyers can be filtered and sorted by.
+var ListFields = listing.Fields{
+	Columns: map[string]string{
+		"id":             "id",
+		"card_number_id": "card_number_id",
+		"first_name":     "first_name",
+		"last_name":      "last_name",
+		"locality_id":    "locality_id",
+	},
+	Sortable: []string{"card_number_id", "first_name", "last_name"},
+}
+
 type Buyer struct {
 	Id           int    `json:"id"`
 	CardNumberId string `json:"card_number_id"`
 	FirstName    string `json:"first_name"`
 	LastName     string `json:"last_name"`
+	// LocalityId is the optional locality the buyer lives in.
+	LocalityId *string `json:"locality_id"`
 }
 
 type ReportPurchaseOrders struct {
@@ -13,4 +35,6 @@ type ReportPurchaseOrders struct {
 	FirstName           string `json:"first_name"`
 	LastName            string `json:"last_name"`
 	PurchaseOrdersCount int    `json:"purchase_orders_count"`
+	FulfilledCount      int    `json:"fulfilled_orders_count"`
+	OpenCount           int    `json:"open_orders_count"`
 }
@@ -8,8 +8,11 @@ var (
 							b.card_number_id,
 							b.first_name,
 							b.last_name,
-							count(po.id) as purchase_orders_count
+							count(po.id) as purchase_orders_count,
+							COALESCE(SUM(os.counts_as_fulfilled), 0) as fulfilled_orders_count,
+							COALESCE(SUM(po.id IS NOT NULL AND NOT os.is_terminal), 0) as open_orders_count
 						FROM purchase_orders po
+						LEFT JOIN order_status os ON po.order_status_id = os.id
 						RIGHT JOIN buyers b ON po.buyer_id = b.id
 						GROUP BY 
 							b.id,
@@ -21,8 +24,11 @@ var (
 							b.card_number_id,
 							b.first_name,
 							b.last_name,
-							count(po.id) as purchase_orders_count
+							count(po.id) as purchase_orders_count,
+							COALESCE(SUM(os.counts_as_fulfilled), 0) as fulfilled_orders_count,
+							COALESCE(SUM(po.id IS NOT NULL AND NOT os.is_terminal), 0) as open_orders_count
 						FROM purchase_orders po
+						LEFT JOIN order_status os ON po.order_status_id = os.id
 						RIGHT JOIN buyers b ON po.buyer_id = b.id
 						WHERE b.id = ?
 						GROUP BY 
@@ -30,9 +36,9 @@ var (
 							b.card_number_id,
 							b.first_name,
 							b.last_name;`
-	QueryCreateBuyer = `INSERT INTO buyers(card_number_id, first_name, last_name) VALUES(?, ?, ?);`
-	QueryGetOneBuyer = `SELECT * FROM buyers WHERE id = ?`
-	QueryGetAllBuyer = `SELECT * FROM buyers`
+	QueryCreateBuyer = `INSERT INTO buyers(card_number_id, first_name, last_name, locality_id) VALUES(?, ?, ?, ?);`
+	QueryGetOneBuyer = `SELECT id, card_number_id, first_name, last_name, locality_id FROM buyers WHERE id = ?`
+	QueryGetAllBuyer = `SELECT id, card_number_id, first_name, last_name, locality_id FROM buyers`
 	QueryDeleteBuyer = "DELETE FROM buyers WHERE id = ?"
 	QueryUpdateBuyer = func(
 		requestData map[string]interface{},
@@ -47,6 +53,7 @@ var (
 			"card_number_id",
 			"first_name",
 			"last_name",
+			"locality_id",
 		}
 		for _, currField := range fields {
 			if _, ok := requestData[currField]; ok {
@@ -1,9 +1,13 @@
 package buyers
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 )
 
 var (
@@ -16,36 +20,39 @@ var (
 )
 
 type Repository interface {
-	Create(cardNumberId, firstName, lastName string) (Buyer, error)
-	GetOne(id int) (Buyer, error)
-	GetAll() ([]Buyer, error)
-	Delete(id int) error
-	Update(id int, requestData map[string]interface{}) (Buyer, error)
-	GetReportPurchaseOrders(BuyerId int) ([]ReportPurchaseOrders, error)
+	Create(ctx context.Context, cardNumberId, firstName, lastName string, localityId *string) (Buyer, error)
+	GetOne(ctx context.Context, id int) (Buyer, error)
+	GetAll(ctx context.Context, opts listing.Options) ([]Buyer, error)
+	Delete(ctx context.Context, id int) error
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Buyer, error)
+	GetReportPurchaseOrders(ctx context.Context, BuyerId int) ([]ReportPurchaseOrders, error)
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
-func (mariaDb mariaDbRepository) Create(cardNumberId, firstName, lastName string) (Buyer, error) {
+func (mariaDb mariaDbRepository) Create(ctx context.Context, cardNumberId, firstName, lastName string, localityId *string) (Buyer, error) {
 	newBuyer := Buyer{
 		CardNumberId: cardNumberId,
 		FirstName:    firstName,
 		LastName:     lastName,
+		LocalityId:   localityId,
 	}
 
-	result, err := mariaDb.db.Exec(
+	result, err := mariaDb.db.ExecContext(
+		ctx,
 		QueryCreateBuyer,
 		cardNumberId,
 		firstName,
 		lastName,
+		localityId,
 	)
 
 	if err != nil {
@@ -62,16 +69,17 @@ func (mariaDb mariaDbRepository) Create(cardNumberId, firstName, lastName string
 	return newBuyer, nil
 }
 
-func (mariaDb mariaDbRepository) GetOne(id int) (Buyer, error) {
+func (mariaDb mariaDbRepository) GetOne(ctx context.Context, id int) (Buyer, error) {
 
 	currentBuyer := Buyer{}
 
-	row := mariaDb.db.QueryRow(QueryGetOneBuyer, id)
+	row := mariaDb.db.QueryRowContext(ctx, transaction.Lock(ctx, QueryGetOneBuyer), id)
 	err := row.Scan(
 		&currentBuyer.Id,
 		&currentBuyer.CardNumberId,
 		&currentBuyer.FirstName,
 		&currentBuyer.LastName,
+		&currentBuyer.LocalityId,
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -85,10 +93,11 @@ func (mariaDb mariaDbRepository) GetOne(id int) (Buyer, error) {
 	return currentBuyer, nil
 }
 
-func (mariaDb mariaDbRepository) GetAll() ([]Buyer, error) {
+func (mariaDb mariaDbRepository) GetAll(ctx context.Context, opts listing.Options) ([]Buyer, error) {
 	buyers := []Buyer{}
 
-	rows, err := mariaDb.db.Query(QueryGetAllBuyer)
+	query, values := listing.Build(QueryGetAllBuyer, ListFields, opts)
+	rows, err := mariaDb.db.QueryContext(ctx, query, values...)
 	if err != nil {
 		return []Buyer{}, errGetBuyers
 	}
@@ -100,15 +109,20 @@ func (mariaDb mariaDbRepository) GetAll() ([]Buyer, error) {
 			&currentBuyer.CardNumberId,
 			&currentBuyer.FirstName,
 			&currentBuyer.LastName,
+			&currentBuyer.LocalityId,
 		); err != nil {
 			return []Buyer{}, errGetBuyers
 		}
 		buyers = append(buyers, currentBuyer)
 	}
+
+	if err := rows.Err(); err != nil {
+		return []Buyer{}, errGetBuyers
+	}
 	return buyers, nil
 }
-func (mariaDb mariaDbRepository) Delete(id int) error {
-	result, err := mariaDb.db.Exec(QueryDeleteBuyer, id)
+func (mariaDb mariaDbRepository) Delete(ctx context.Context, id int) error {
+	result, err := mariaDb.db.ExecContext(ctx, QueryDeleteBuyer, id)
 	if err != nil {
 		return err
 	}
@@ -125,10 +139,10 @@ func (mariaDb mariaDbRepository) Delete(id int) error {
 	return nil
 }
 
-func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface{}) (Buyer, error) {
+func (mariaDb mariaDbRepository) Update(ctx context.Context, id int, requestData map[string]interface{}) (Buyer, error) {
 	finalQuery, valuesToUse := QueryUpdateBuyer(requestData, id)
 
-	result, err := mariaDb.db.Exec(finalQuery, valuesToUse...)
+	result, err := mariaDb.db.ExecContext(ctx, finalQuery, valuesToUse...)
 	if err != nil {
 		return Buyer{}, errUpdatedBuyer
 	}
@@ -138,7 +152,7 @@ func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface
 		return Buyer{}, errUpdatedBuyer
 	}
 
-	currentbuyer, err := mariaDb.GetOne(id)
+	currentbuyer, err := mariaDb.GetOne(ctx, id)
 	if err != nil {
 		return Buyer{}, errUpdatedBuyer
 	}
@@ -146,7 +160,7 @@ func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface
 	return currentbuyer, nil
 }
 
-func (mariaDb mariaDbRepository) GetReportPurchaseOrders(BuyerId int) ([]ReportPurchaseOrders, error) {
+func (mariaDb mariaDbRepository) GetReportPurchaseOrders(ctx context.Context, BuyerId int) ([]ReportPurchaseOrders, error) {
 	reports := []ReportPurchaseOrders{}
 
 	var (
@@ -155,9 +169,9 @@ func (mariaDb mariaDbRepository) GetReportPurchaseOrders(BuyerId int) ([]ReportP
 	)
 
 	if BuyerId != 0 {
-		rows, err = mariaDb.db.Query(QueryGetReportOne, BuyerId)
+		rows, err = mariaDb.db.QueryContext(ctx, QueryGetReportOne, BuyerId)
 	} else {
-		rows, err = mariaDb.db.Query(QueryGetReportAll)
+		rows, err = mariaDb.db.QueryContext(ctx, QueryGetReportAll)
 	}
 
 	if err != nil {
@@ -172,11 +186,17 @@ func (mariaDb mariaDbRepository) GetReportPurchaseOrders(BuyerId int) ([]ReportP
 			&currentReport.FirstName,
 			&currentReport.LastName,
 			&currentReport.PurchaseOrdersCount,
+			&currentReport.FulfilledCount,
+			&currentReport.OpenCount,
 		); err != nil {
 			return []ReportPurchaseOrders{}, errReportPurchaseOrders
 		}
 		reports = append(reports, currentReport)
 	}
 
+	if err := rows.Err(); err != nil {
+		return []ReportPurchaseOrders{}, errReportPurchaseOrders
+	}
+
 	return reports, nil
 }
@@ -1,20 +1,24 @@
 package buyers
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCreate(t *testing.T) {
+	localityId := "6700"
 	mockBuyers := &Buyer{
 		CardNumberId: "402324",
 		FirstName:    "Fulano",
 		LastName:     "Beltrano",
+		LocalityId:   &localityId,
 	}
 
 	t.Run("success create_buyer_repository ", func(t *testing.T) {
@@ -27,17 +31,19 @@ func TestCreate(t *testing.T) {
 				mockBuyers.CardNumberId,
 				mockBuyers.FirstName,
 				mockBuyers.LastName,
+				localityId,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		carriersRepo := NewMariaDbRepository(db)
 
-		carryCreate, err := carriersRepo.Create(mockBuyers.CardNumberId, mockBuyers.FirstName, mockBuyers.LastName)
+		carryCreate, err := carriersRepo.Create(context.Background(), mockBuyers.CardNumberId, mockBuyers.FirstName, mockBuyers.LastName, mockBuyers.LocalityId)
 
 		assert.NoError(t, err)
 
 		expectedFirstName := "Fulano"
 
 		assert.Equal(t, expectedFirstName, carryCreate.FirstName)
+		assert.Equal(t, &localityId, carryCreate.LocalityId)
 
 	})
 
@@ -51,7 +57,7 @@ func TestCreate(t *testing.T) {
 
 		carriersRepo := NewMariaDbRepository(db)
 
-		_, err = carriersRepo.Create(mockBuyers.CardNumberId, mockBuyers.FirstName, mockBuyers.LastName)
+		_, err = carriersRepo.Create(context.Background(), mockBuyers.CardNumberId, mockBuyers.FirstName, mockBuyers.LastName, mockBuyers.LocalityId)
 
 		assert.Error(t, err)
 	})
@@ -76,8 +82,9 @@ func TestGetOne(t *testing.T) {
 			"card_number_id",
 			"first_name",
 			"last_name",
+			"locality_id",
 		}).
-			AddRow(mockBuyers.Id, mockBuyers.CardNumberId, mockBuyers.FirstName, mockBuyers.LastName)
+			AddRow(mockBuyers.Id, mockBuyers.CardNumberId, mockBuyers.FirstName, mockBuyers.LastName, "6700")
 
 		mock.ExpectQuery(regexp.QuoteMeta(QueryGetOneBuyer)).WillReturnRows(rows)
 
@@ -85,10 +92,11 @@ func TestGetOne(t *testing.T) {
 
 		expectedFirstName := "Fulano"
 
-		carryGetOne, err := carriersRepo.GetOne(1)
+		carryGetOne, err := carriersRepo.GetOne(context.Background(), 1)
 		assert.NoError(t, err)
 		assert.NotNil(t, carryGetOne)
 		assert.Equal(t, expectedFirstName, carryGetOne.FirstName)
+		assert.Equal(t, "6700", *carryGetOne.LocalityId)
 
 	})
 
@@ -103,14 +111,15 @@ func TestGetOne(t *testing.T) {
 			"card_number_id",
 			"first_name",
 			"last_name",
+			"locality_id",
 		}).
-			AddRow("", "", "", "")
+			AddRow("", "", "", "", nil)
 
 		mock.ExpectQuery(regexp.QuoteMeta(QueryGetOneBuyer)).WillReturnRows(rows)
 
 		carriersRepo := NewMariaDbRepository(db)
 
-		_, err = carriersRepo.GetOne(1)
+		_, err = carriersRepo.GetOne(context.Background(), 1)
 
 		assert.NotNil(t, err)
 	})
@@ -127,21 +136,23 @@ func TestGetAllBuyers(t *testing.T) {
 			"card_number_id",
 			"first_name",
 			"last_name",
+			"locality_id",
 		}).
-			AddRow(1, "402324", "Fulano", "Beltrano").
-			AddRow(2, "402325", "José", "Francisco").
-			AddRow(3, "402326", "João", "Emídio")
+			AddRow(1, "402324", "Fulano", "Beltrano", "6700").
+			AddRow(2, "402325", "José", "Francisco", nil).
+			AddRow(3, "402326", "João", "Emídio", nil)
 		mock.ExpectQuery(regexp.QuoteMeta(QueryGetAllBuyer)).WillReturnRows(rows)
 
 		buyersRepo := NewMariaDbRepository(db)
 
-		buyerGetAll, err := buyersRepo.GetAll()
+		buyerGetAll, err := buyersRepo.GetAll(context.Background(), listing.Options{})
 		assert.NoError(t, err)
 
 		assert.Len(t, buyerGetAll, 3)
 		assert.Equal(t, "Fulano", buyerGetAll[0].FirstName)
 		assert.Equal(t, "Francisco", buyerGetAll[1].LastName)
 		assert.Equal(t, "402326", buyerGetAll[2].CardNumberId)
+		assert.Nil(t, buyerGetAll[1].LocalityId)
 	})
 
 	t.Run("DB Error", func(t *testing.T) {
@@ -154,7 +165,7 @@ func TestGetAllBuyers(t *testing.T) {
 
 		buyersRepo := NewMariaDbRepository(db)
 
-		_, err = buyersRepo.GetAll()
+		_, err = buyersRepo.GetAll(context.Background(), listing.Options{})
 		assert.Error(t, err)
 	})
 }
@@ -170,7 +181,7 @@ func TestDeleteBuyers(t *testing.T) {
 			WillReturnResult(affectedRowsResult)
 
 		buyersRepo := NewMariaDbRepository(db)
-		err = buyersRepo.Delete(1)
+		err = buyersRepo.Delete(context.Background(), 1)
 		assert.NoError(t, err)
 	})
 
@@ -184,7 +195,7 @@ func TestDeleteBuyers(t *testing.T) {
 			WillReturnResult(affectedRowsResult)
 
 		productsRepo := NewMariaDbRepository(db)
-		err = productsRepo.Delete(1)
+		err = productsRepo.Delete(context.Background(), 1)
 		assert.NotNil(t, err)
 		assert.Equal(t, "buyer with id 1 not found", err.Error())
 	})
@@ -198,7 +209,7 @@ func TestDeleteBuyers(t *testing.T) {
 			WillReturnError(errors.New("any error"))
 
 		productsRepo := NewMariaDbRepository(db)
-		err = productsRepo.Delete(1)
+		err = productsRepo.Delete(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "any error", err.Error())
 	})
@@ -231,15 +242,16 @@ func TestUpdateBuyers(t *testing.T) {
 				"id",
 				"card_number_id",
 				"first_name",
-				"last_name"}).
-			AddRow(1, "402325", "João", "Emídio")
+				"last_name",
+				"locality_id"}).
+			AddRow(1, "402325", "João", "Emídio", nil)
 
 		mock.ExpectQuery(regexp.QuoteMeta(QueryGetOneBuyer)).
 			WithArgs(1).WillReturnRows(newRow)
 
 		buyersRepo := NewMariaDbRepository(db)
 
-		buyer, err := buyersRepo.Update(1, requestData)
+		buyer, err := buyersRepo.Update(context.Background(), 1, requestData)
 		assert.Nil(t, err)
 
 		assert.Equal(t, "João", buyer.FirstName)
@@ -255,7 +267,7 @@ func TestUpdateBuyers(t *testing.T) {
 
 		sellersRepo := NewMariaDbRepository(db)
 
-		_, err = sellersRepo.Update(1, requestData)
+		_, err = sellersRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errors.New("ocurred an error while updating the buyer"), err)
 	})
@@ -273,7 +285,7 @@ func TestUpdateBuyers(t *testing.T) {
 
 		buyersRepo := NewMariaDbRepository(db)
 
-		_, err = buyersRepo.Update(1, requestData)
+		_, err = buyersRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errors.New("ocurred an error while updating the buyer"), err)
 	})
@@ -293,7 +305,7 @@ func TestUpdateBuyers(t *testing.T) {
 
 		buyersRepo := NewMariaDbRepository(db)
 
-		_, err = buyersRepo.Update(1, requestData)
+		_, err = buyersRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errors.New("ocurred an error while updating the buyer"), err)
 	})
@@ -313,21 +325,25 @@ func TestGetReportPurchaseOrders(t *testing.T) {
 			"first_name",
 			"last_name",
 			"purchase_orders_count",
+			"fulfilled_orders_count",
+			"open_orders_count",
 		}).
-		AddRow(1, "402324", "Fulano", "Beltrano",123).
-		AddRow(2, "402325", "José", "Francisco",124).
-		AddRow(3, "402326", "João", "Emídio",125)
+		AddRow(1, "402324", "Fulano", "Beltrano",123, 100, 20).
+		AddRow(2, "402325", "José", "Francisco",124, 0, 124).
+		AddRow(3, "402326", "João", "Emídio",125, 125, 0)
 
 		mock.ExpectQuery(regexp.QuoteMeta(QueryGetReportAll)).WillReturnRows(rows)
 
 		buyerRepo := NewMariaDbRepository(db)
 
-		purchaseOrders, err := buyerRepo.GetReportPurchaseOrders(0)
+		purchaseOrders, err := buyerRepo.GetReportPurchaseOrders(context.Background(), 0)
 		assert.Nil(t, err)
 
 		assert.Len(t, purchaseOrders, 3)
 		assert.Equal(t, purchaseOrders[0].FirstName, "Fulano")
 		assert.Equal(t, purchaseOrders[1].FirstName, "José")
 		assert.Equal(t, purchaseOrders[2].FirstName, "João")
+		assert.Equal(t, 100, purchaseOrders[0].FulfilledCount)
+		assert.Equal(t, 20, purchaseOrders[0].OpenCount)
 	})
 }
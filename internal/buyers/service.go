@@ -1,47 +1,80 @@
 package buyers
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/localities"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/document"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 )
 
+var errInvalidCardNumberId = fmt.Errorf("card_number_id must be a valid %s", document.Describe(AcceptedDocuments))
+
 type Service interface {
-	Create(cardNumberId string, firstName, lastName string) (Buyer, web.ResponseCode)
-	GetOne(id int) (Buyer, web.ResponseCode)
-	GetAll() ([]Buyer, web.ResponseCode)
-	Delete(id int) web.ResponseCode
-	Update(id int, requestData map[string]interface{}) (Buyer, web.ResponseCode)
-	GetReportPurchaseOrders(SectionId int) ([]ReportPurchaseOrders, web.ResponseCode)
+	Create(ctx context.Context, cardNumberId string, firstName, lastName string, localityId *string) (Buyer, web.ResponseCode)
+	ValidateCreate(ctx context.Context, cardNumberId string, firstName, lastName string, localityId *string) web.ResponseCode
+	GetOne(ctx context.Context, id int) (Buyer, web.ResponseCode)
+	GetAll(ctx context.Context, opts listing.Options) ([]Buyer, listing.Page, web.ResponseCode)
+	Delete(ctx context.Context, id int) web.ResponseCode
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Buyer, web.ResponseCode)
+	GetReportPurchaseOrders(ctx context.Context, SectionId int) ([]ReportPurchaseOrders, web.ResponseCode)
 }
 
 type service struct {
-	repository Repository
+	repository         Repository
+	localityRepository localities.Repository
 }
 
-func NewService(r Repository) Service {
+func NewService(r Repository, lr localities.Repository) Service {
 	return &service{
-		repository: r,
+		repository:         r,
+		localityRepository: lr,
 	}
 }
 
-func (s service) Create(cardNumberId string, firstName string, lastName string) (Buyer, web.ResponseCode) {
-	allBuyers, _ := s.GetAll()
+// ValidateCreate applies the rules of Create without persisting the buyer.
+func (s service) ValidateCreate(ctx context.Context, cardNumberId string, firstName string, lastName string, localityId *string) web.ResponseCode {
+	if strings.ReplaceAll(cardNumberId, " ", "") == "" {
+		return web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("empty card_number_id not allowed"))
+	}
+
+	doc, err := document.Parse(cardNumberId, AcceptedDocuments...)
+	if err != nil {
+		return web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidCardNumberId)
+	}
+
+	allBuyers, _ := s.repository.GetAll(ctx, listing.Options{})
 
 	for _, buyer := range allBuyers {
-		if buyer.CardNumberId == cardNumberId {
-			return Buyer{}, web.NewCodeResponse(http.StatusConflict, errors.New("CardNumberId already exists"))
+		if buyer.CardNumberId == doc.Number {
+			return web.NewCodeResponse(http.StatusConflict, errors.New("CardNumberId already exists"))
 		}
 	}
 
-	Buyer, _ := s.repository.Create(cardNumberId, firstName, lastName)
+	if localityId != nil {
+		return s.checkLocality(ctx, *localityId)
+	}
+
+	return web.ResponseCode{}
+}
+
+func (s service) Create(ctx context.Context, cardNumberId string, firstName string, lastName string, localityId *string) (Buyer, web.ResponseCode) {
+	if resp := s.ValidateCreate(ctx, cardNumberId, firstName, lastName, localityId); resp.Err != nil {
+		return Buyer{}, resp
+	}
+
+	Buyer, _ := s.repository.Create(ctx, document.Normalize(cardNumberId), firstName, lastName, localityId)
 
 	return Buyer, web.NewCodeResponse(http.StatusCreated, nil)
 }
 
-func (s service) GetOne(id int) (Buyer, web.ResponseCode) {
-	buyer, err := s.repository.GetOne(id)
+func (s service) GetOne(ctx context.Context, id int) (Buyer, web.ResponseCode) {
+	buyer, err := s.repository.GetOne(ctx, id)
 
 	if err != nil {
 		return Buyer{}, web.NewCodeResponse(http.StatusNotFound, err)
@@ -49,13 +82,14 @@ func (s service) GetOne(id int) (Buyer, web.ResponseCode) {
 	return buyer, web.NewCodeResponse(http.StatusNotFound, nil)
 }
 
-func (s service) GetAll() ([]Buyer, web.ResponseCode) {
-	buyers, err := s.repository.GetAll()
-	return buyers, web.NewCodeResponse(http.StatusOK, err)
+func (s service) GetAll(ctx context.Context, opts listing.Options) ([]Buyer, listing.Page, web.ResponseCode) {
+	buyers, err := s.repository.GetAll(ctx, opts)
+	buyers, page := listing.Paginate(buyers, opts)
+	return buyers, page, web.NewCodeResponse(http.StatusOK, err)
 }
 
-func (s service) Delete(id int) web.ResponseCode {
-	err := s.repository.Delete(id)
+func (s service) Delete(ctx context.Context, id int) web.ResponseCode {
+	err := s.repository.Delete(ctx, id)
 
 	if err != nil {
 		return web.NewCodeResponse(http.StatusNotFound, err)
@@ -63,32 +97,61 @@ func (s service) Delete(id int) web.ResponseCode {
 	return web.NewCodeResponse(http.StatusNoContent, nil)
 }
 
-func (s service) Update(id int, requestData map[string]interface{}) (Buyer, web.ResponseCode) {
-	_, responseCode := s.GetOne(id)
-	allBuyers, _ := s.GetAll()
+func (s service) Update(ctx context.Context, id int, requestData map[string]interface{}) (Buyer, web.ResponseCode) {
+	_, responseCode := s.GetOne(ctx, id)
+	allBuyers, _ := s.repository.GetAll(ctx, listing.Options{})
 	buyerNumberReqData := requestData["card_number_id"]
 
 	if responseCode.Err != nil {
 		return Buyer{}, web.NewCodeResponse(http.StatusNotFound, errors.New("buyer not found"))
 	}
 
+	if cardNumberId, ok := buyerNumberReqData.(string); ok {
+		doc, err := document.Parse(cardNumberId, AcceptedDocuments...)
+		if err != nil {
+			return Buyer{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidCardNumberId)
+		}
+		buyerNumberReqData = doc.Number
+		requestData["card_number_id"] = doc.Number
+	}
+
 	for _, buyer := range allBuyers {
 		if buyer.CardNumberId == buyerNumberReqData && buyer.Id != id {
 			return Buyer{}, web.NewCodeResponse(http.StatusConflict, errors.New("buyer number already exists"))
 		}
 	}
 
-	buyer, _ := s.repository.Update(id, requestData)
+	if localityId, ok := requestData["locality_id"].(string); ok {
+		if resp := s.checkLocality(ctx, localityId); resp.Err != nil {
+			return Buyer{}, resp
+		}
+	}
+
+	buyer, _ := s.repository.Update(ctx, id, requestData)
 
 	return buyer, web.ResponseCode{Code: http.StatusOK, Err: nil}
 }
 
-func (s service) GetReportPurchaseOrders(BuyerId int) ([]ReportPurchaseOrders, web.ResponseCode) {
-	if _, err := s.repository.GetOne(BuyerId); err != nil && BuyerId != 0 {
+// checkLocality answers 409 when the locality does not exist and 500 when
+// it could not be verified.
+func (s service) checkLocality(ctx context.Context, localityId string) web.ResponseCode {
+	_, err := s.localityRepository.GetOne(ctx, localityId)
+	if err == nil {
+		return web.ResponseCode{}
+	}
+
+	if err.Error() == localities.GetErrLocalityNotFound(localityId).Error() {
+		return web.NewCodeResponse(http.StatusConflict, err)
+	}
+	return web.NewCodeResponse(http.StatusInternalServerError, err)
+}
+
+func (s service) GetReportPurchaseOrders(ctx context.Context, BuyerId int) ([]ReportPurchaseOrders, web.ResponseCode) {
+	if _, err := s.repository.GetOne(ctx, BuyerId); err != nil && BuyerId != 0 {
 		return []ReportPurchaseOrders{}, web.NewCodeResponse(http.StatusNotFound, err)
 	}
 
-	report, err := s.repository.GetReportPurchaseOrders(BuyerId)
+	report, err := s.repository.GetReportPurchaseOrders(ctx, BuyerId)
 	if err != nil {
 		return []ReportPurchaseOrders{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
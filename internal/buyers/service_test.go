@@ -1,12 +1,16 @@
 package buyers_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/localities"
+	mockLocalityRepository "github.com/emidioreb/mercado-fresco-lerigophers/internal/localities/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -17,19 +21,21 @@ func TestServiceCreate(t *testing.T) {
 
 		input := buyers.Buyer{
 			Id:           1,
-			CardNumberId: "12345",
+			CardNumberId: "52998224725",
 			FirstName:    "José",
 			LastName:     "Silva",
 		}
-		mockedRepository.On("GetAll").Return([]buyers.Buyer{}, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]buyers.Buyer{}, nil)
 		mockedRepository.On("Create",
+			mock.Anything,
+			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string")).Return(input, nil)
+			mock.Anything).Return(input, nil)
 
-		service := buyers.NewService(mockedRepository)
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
 
-		result, err := service.Create(input.CardNumberId, input.FirstName, input.LastName)
+		result, err := service.Create(context.Background(), input.CardNumberId, input.FirstName, input.LastName, nil)
 
 		assert.Nil(t, err.Err)
 		assert.Equal(t, result, input)
@@ -45,7 +51,7 @@ func TestServiceCreate(t *testing.T) {
 
 		input := buyers.Buyer{
 			Id:           1,
-			CardNumberId: "12345",
+			CardNumberId: "52998224725",
 			FirstName:    "José",
 			LastName:     "Silva",
 		}
@@ -53,14 +59,16 @@ func TestServiceCreate(t *testing.T) {
 		listBuyers := []buyers.Buyer{}
 		listBuyers = append(listBuyers, input)
 
-		mockedRepository.On("GetAll").Return(listBuyers, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return(listBuyers, nil)
 		mockedRepository.On("Create",
+			mock.Anything,
+			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string")).Return(buyers.Buyer{}, expectedError)
+			mock.Anything).Return(buyers.Buyer{}, expectedError)
 
-		service := buyers.NewService(mockedRepository)
-		_, err := service.Create(input.CardNumberId, input.FirstName, input.LastName)
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, err := service.Create(context.Background(), input.CardNumberId, input.FirstName, input.LastName, nil)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, err.Err.Error(), expectedError.Error())
@@ -69,6 +77,90 @@ func TestServiceCreate(t *testing.T) {
 
 }
 
+func TestServiceValidateCreate(t *testing.T) {
+	t.Run("valid buyer should not return error", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]buyers.Buyer{}, nil)
+
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		resp := service.ValidateCreate(context.Background(), "52998224725", "José", "Silva", nil)
+
+		assert.Nil(t, resp.Err)
+		mockedRepository.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("empty CardNumberId should return error", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		resp := service.ValidateCreate(context.Background(), " ", "José", "Silva", nil)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "empty card_number_id not allowed", resp.Err.Error())
+	})
+
+	t.Run("invalid document should return error", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		resp := service.ValidateCreate(context.Background(), "529.982.247-00", "José", "Silva", nil)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "card_number_id must be a valid CPF or CNPJ", resp.Err.Error())
+	})
+
+	t.Run("formatted document should match the canonical one", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]buyers.Buyer{{Id: 1, CardNumberId: "52998224725"}}, nil)
+
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		resp := service.ValidateCreate(context.Background(), "529.982.247-25", "José", "Silva", nil)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
+	t.Run("existing locality should not return error", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocalityRepository := new(mockLocalityRepository.Repository)
+		localityId := "6700"
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]buyers.Buyer{}, nil)
+		mockedLocalityRepository.On("GetOne", mock.Anything, localityId).Return(localities.Locality{Id: localityId}, nil)
+
+		service := buyers.NewService(mockedRepository, mockedLocalityRepository)
+		resp := service.ValidateCreate(context.Background(), "52998224725", "José", "Silva", &localityId)
+
+		assert.Nil(t, resp.Err)
+		mockedLocalityRepository.AssertExpectations(t)
+	})
+
+	t.Run("unknown locality should return conflict", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocalityRepository := new(mockLocalityRepository.Repository)
+		localityId := "6700"
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]buyers.Buyer{}, nil)
+		mockedLocalityRepository.On("GetOne", mock.Anything, localityId).Return(localities.Locality{}, localities.GetErrLocalityNotFound(localityId))
+
+		service := buyers.NewService(mockedRepository, mockedLocalityRepository)
+		resp := service.ValidateCreate(context.Background(), "52998224725", "José", "Silva", &localityId)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, localities.GetErrLocalityNotFound(localityId), resp.Err)
+	})
+
+	t.Run("locality lookup failure should return internal error", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocalityRepository := new(mockLocalityRepository.Repository)
+		localityId := "6700"
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]buyers.Buyer{}, nil)
+		mockedLocalityRepository.On("GetOne", mock.Anything, localityId).Return(localities.Locality{}, errors.New("error to find locality"))
+
+		service := buyers.NewService(mockedRepository, mockedLocalityRepository)
+		resp := service.ValidateCreate(context.Background(), "52998224725", "José", "Silva", &localityId)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
 func TestServiceGetAll(t *testing.T) {
 	t.Run("should return buyers list", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
@@ -76,23 +168,23 @@ func TestServiceGetAll(t *testing.T) {
 		input := []buyers.Buyer{
 			{
 				Id:           1,
-				CardNumberId: "12345",
+				CardNumberId: "52998224725",
 				FirstName:    "José",
 				LastName:     "Silva",
 			},
 			{
 				Id:           2,
-				CardNumberId: "54321",
+				CardNumberId: "12345779179",
 				FirstName:    "Maria",
 				LastName:     "Pereira",
 			},
 		}
 
-		mockedRepository.On("GetAll").Return(input, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return(input, nil)
 
-		service := buyers.NewService(mockedRepository)
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
 
-		result, err := service.GetAll()
+		result, _, err := service.GetAll(context.Background(), listing.Options{})
 
 		assert.Nil(t, err.Err)
 		assert.Len(t, result, 2)
@@ -108,16 +200,16 @@ func TestServiceGetOne(t *testing.T) {
 
 		input := buyers.Buyer{
 			Id:           1,
-			CardNumberId: "12345",
+			CardNumberId: "52998224725",
 			FirstName:    "José",
 			LastName:     "Silva",
 		}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(input, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(input, nil)
 
-		service := buyers.NewService(mockedRepository)
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
 
-		result, err := service.GetOne(1)
+		result, err := service.GetOne(context.Background(), 1)
 
 		assert.Nil(t, err.Err)
 		assert.Equal(t, result, input)
@@ -130,10 +222,10 @@ func TestServiceGetOne(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		expectedError := errors.New("Buyer with id 1 not found")
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(buyers.Buyer{}, expectedError)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, expectedError)
 
-		service := buyers.NewService(mockedRepository)
-		_, err := service.GetOne(1)
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, err := service.GetOne(context.Background(), 1)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, err.Code, http.StatusNotFound)
@@ -146,10 +238,10 @@ func TestServiceDelete(t *testing.T) {
 	t.Run("Verify if the buyer was deleted", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 
-		mockedRepository.On("Delete", mock.AnythingOfType("int")).Return(nil)
-		service := buyers.NewService(mockedRepository)
+		mockedRepository.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
 
-		result := service.Delete(1)
+		result := service.Delete(context.Background(), 1)
 
 		assert.Equal(t, result.Code, http.StatusNoContent)
 
@@ -161,10 +253,10 @@ func TestServiceDelete(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		expectedError := errors.New("Buyer with id 1 not found")
 
-		mockedRepository.On("Delete", mock.AnythingOfType("int")).Return(expectedError)
+		mockedRepository.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(expectedError)
 
-		service := buyers.NewService(mockedRepository)
-		result := service.Delete(1)
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.Delete(context.Background(), 1)
 		assert.NotNil(t, result.Err)
 
 		assert.Equal(t, result.Code, http.StatusNotFound)
@@ -178,61 +270,82 @@ func TestServiceUpdate(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 
 		requestData := map[string]interface{}{
-			"card_number_id": "12345",
+			"card_number_id": "52998224725",
 			"first_name":     "Fulano",
 			"last_name":      "Beltrano",
 		}
 
 		expectedBuyer := buyers.Buyer{
 			Id:           1,
-			CardNumberId: "12345",
+			CardNumberId: "52998224725",
 			FirstName:    "Fulano",
 			LastName:     "Beltrano",
 		}
 
 		input := buyers.Buyer{
 			Id:           1,
-			CardNumberId: "12345",
+			CardNumberId: "52998224725",
 			FirstName:    "José",
 			LastName:     "Silva",
 		}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).
 			Return(input, nil)
 
-		mockedRepository.On("GetAll").
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).
 			Return([]buyers.Buyer{}, nil)
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(expectedBuyer, nil)
 
-		service := buyers.NewService(mockedRepository)
-		result, err := service.Update(1, requestData)
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result, err := service.Update(context.Background(), 1, requestData)
 
 		assert.Nil(t, err.Err)
 		assert.Equal(t, expectedBuyer, result)
 		mockedRepository.AssertExpectations(t)
 	})
 
+	t.Run("return conflict when locality_id does not exist", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocalityRepository := new(mockLocalityRepository.Repository)
+		requestData := map[string]interface{}{
+			"locality_id": "6700",
+		}
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(buyers.Buyer{Id: 1}, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]buyers.Buyer{}, nil)
+		mockedLocalityRepository.On("GetOne", mock.Anything, "6700").Return(localities.Locality{}, localities.GetErrLocalityNotFound("6700"))
+
+		service := buyers.NewService(mockedRepository, mockedLocalityRepository)
+		_, err := service.Update(context.Background(), 1, requestData)
+
+		assert.Equal(t, http.StatusConflict, err.Code)
+		assert.Equal(t, localities.GetErrLocalityNotFound("6700"), err.Err)
+		mockedRepository.AssertNumberOfCalls(t, "Update", 0)
+	})
+
 	t.Run("Return null when buyer id do not exists", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		expectedError := errors.New("buyer not found")
 		requestData := map[string]interface{}{}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(buyers.Buyer{}, expectedError).Once()
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, expectedError).Once()
 
-		mockedRepository.On("GetAll").
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).
 			Return([]buyers.Buyer{}, expectedError).Once()
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(buyers.Buyer{}, nil).Once()
 
-		service := buyers.NewService(mockedRepository)
-		_, err := service.Update(1, requestData)
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, err := service.Update(context.Background(), 1, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, err.Code, http.StatusNotFound)
@@ -242,32 +355,45 @@ func TestServiceUpdate(t *testing.T) {
 	t.Run("return error when card_number_id already exists and id doesn't match", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		requestData := map[string]interface{}{
-			"card_number_id": "12345",
+			"card_number_id": "52998224725",
 		}
 		expectedError := errors.New("buyer number already exists")
 		input := []buyers.Buyer{
 			{
 				Id:           1,
-				CardNumberId: "12345",
+				CardNumberId: "52998224725",
 				FirstName:    "José",
 				LastName:     "Silva",
 			},
 			{
 				Id:           2,
-				CardNumberId: "12344",
+				CardNumberId: "12345880210",
 				FirstName:    "José",
 				LastName:     "Silva",
 			},
 		}
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).
 			Return(input[1], nil).Once()
-		mockedRepository.On("GetAll").
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).
 			Return(input, nil).Once()
-		mockedRepository.On("Update", mock.AnythingOfType("int"), mock.Anything).Return(buyers.Buyer{}, nil).Once()
-		service := buyers.NewService(mockedRepository)
-		_, err := service.Update(2, requestData)
+		mockedRepository.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).Return(buyers.Buyer{}, nil).Once()
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, err := service.Update(context.Background(), 2, requestData)
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, expectedError.Error(), err.Err.Error())
 		assert.Equal(t, http.StatusConflict, err.Code)
 	})
+
+	t.Run("return error when card_number_id is not a valid document", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(buyers.Buyer{Id: 1}, nil).Once()
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]buyers.Buyer{}, nil).Once()
+
+		service := buyers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, err := service.Update(context.Background(), 1, map[string]interface{}{"card_number_id": "Card#1"})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, err.Code)
+		assert.Equal(t, "card_number_id must be a valid CPF or CNPJ", err.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Update", 0)
+	})
 }
@@ -4,7 +4,15 @@ package mocks
 
 import (
 	carriers "github.com/emidioreb/mercado-fresco-lerigophers/internal/carriers"
+	address "github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+
+	context "context"
+
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+
 	mock "github.com/stretchr/testify/mock"
+
+	phone "github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -12,20 +20,180 @@ type Repository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: cid, companyName, address, telephone, localityId
-func (_m *Repository) Create(cid string, companyName string, address string, telephone string, localityId string) (carriers.Carry, error) {
-	ret := _m.Called(cid, companyName, address, telephone, localityId)
+// Create provides a mock function with given fields: ctx, cid, companyName, addr, telephone, localityId
+func (_m *Repository) Create(ctx context.Context, cid string, companyName string, addr address.Address, telephone phone.Number, localityId string) (carriers.Carry, error) {
+	ret := _m.Called(ctx, cid, companyName, addr, telephone, localityId)
+
+	var r0 carriers.Carry
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, address.Address, phone.Number, string) carriers.Carry); ok {
+		r0 = rf(ctx, cid, companyName, addr, telephone, localityId)
+	} else {
+		r0 = ret.Get(0).(carriers.Carry)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, address.Address, phone.Number, string) error); ok {
+		r1 = rf(ctx, cid, companyName, addr, telephone, localityId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateServiceArea provides a mock function with given fields: ctx, area
+func (_m *Repository) CreateServiceArea(ctx context.Context, area carriers.ServiceArea) (carriers.ServiceArea, error) {
+	ret := _m.Called(ctx, area)
+
+	var r0 carriers.ServiceArea
+	if rf, ok := ret.Get(0).(func(context.Context, carriers.ServiceArea) carriers.ServiceArea); ok {
+		r0 = rf(ctx, area)
+	} else {
+		r0 = ret.Get(0).(carriers.ServiceArea)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, carriers.ServiceArea) error); ok {
+		r1 = rf(ctx, area)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Repository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteServiceArea provides a mock function with given fields: ctx, carrierId, id
+func (_m *Repository) DeleteServiceArea(ctx context.Context, carrierId int, id int) error {
+	ret := _m.Called(ctx, carrierId, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, carrierId, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Repository) GetAll(ctx context.Context, opts listing.Options) ([]carriers.Carry, error) {
+	ret := _m.Called(ctx, opts)
+
+	var r0 []carriers.Carry
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []carriers.Carry); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]carriers.Carry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByCid provides a mock function with given fields: ctx, cid
+func (_m *Repository) GetByCid(ctx context.Context, cid string) (carriers.Carry, error) {
+	ret := _m.Called(ctx, cid)
+
+	var r0 carriers.Carry
+	if rf, ok := ret.Get(0).(func(context.Context, string) carriers.Carry); ok {
+		r0 = rf(ctx, cid)
+	} else {
+		r0 = ret.Get(0).(carriers.Carry)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, cid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, fromLocalityId, toLocalityId
+func (_m *Repository) GetDeliveries(ctx context.Context, fromLocalityId string, toLocalityId string) ([]carriers.Delivery, error) {
+	ret := _m.Called(ctx, fromLocalityId, toLocalityId)
+
+	var r0 []carriers.Delivery
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []carriers.Delivery); ok {
+		r0 = rf(ctx, fromLocalityId, toLocalityId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]carriers.Delivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, fromLocalityId, toLocalityId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Repository) GetOne(ctx context.Context, id int) (carriers.Carry, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 carriers.Carry
-	if rf, ok := ret.Get(0).(func(string, string, string, string, string) carriers.Carry); ok {
-		r0 = rf(cid, companyName, address, telephone, localityId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) carriers.Carry); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(carriers.Carry)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, string, string) error); ok {
-		r1 = rf(cid, companyName, address, telephone, localityId)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceAreas provides a mock function with given fields: ctx, carrierId
+func (_m *Repository) GetServiceAreas(ctx context.Context, carrierId int) ([]carriers.ServiceArea, error) {
+	ret := _m.Called(ctx, carrierId)
+
+	var r0 []carriers.ServiceArea
+	if rf, ok := ret.Get(0).(func(context.Context, int) []carriers.ServiceArea); ok {
+		r0 = rf(ctx, carrierId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]carriers.ServiceArea)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, carrierId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,20 +201,20 @@ func (_m *Repository) Create(cid string, companyName string, address string, tel
 	return r0, r1
 }
 
-// GetOne provides a mock function with given fields: cid
-func (_m *Repository) GetOne(cid string) (carriers.Carry, error) {
-	ret := _m.Called(cid)
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Repository) Update(ctx context.Context, id int, requestData map[string]interface{}) (carriers.Carry, error) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 carriers.Carry
-	if rf, ok := ret.Get(0).(func(string) carriers.Carry); ok {
-		r0 = rf(cid)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) carriers.Carry); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(carriers.Carry)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(cid)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) error); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Error(1)
 	}
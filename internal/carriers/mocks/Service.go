@@ -4,6 +4,12 @@ package mocks
 
 import (
 	carriers "github.com/emidioreb/mercado-fresco-lerigophers/internal/carriers"
+	address "github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+
+	context "context"
+
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+
 	mock "github.com/stretchr/testify/mock"
 
 	web "github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
@@ -14,20 +20,208 @@ type Service struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: cid, companyName, address, telephone, localityId
-func (_m *Service) Create(cid string, companyName string, address string, telephone string, localityId string) (carriers.Carry, web.ResponseCode) {
-	ret := _m.Called(cid, companyName, address, telephone, localityId)
+// Create provides a mock function with given fields: ctx, cid, companyName, addr, telephone, localityId
+func (_m *Service) Create(ctx context.Context, cid string, companyName string, addr address.Address, telephone string, localityId string) (carriers.Carry, web.ResponseCode) {
+	ret := _m.Called(ctx, cid, companyName, addr, telephone, localityId)
+
+	var r0 carriers.Carry
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, address.Address, string, string) carriers.Carry); ok {
+		r0 = rf(ctx, cid, companyName, addr, telephone, localityId)
+	} else {
+		r0 = ret.Get(0).(carriers.Carry)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, address.Address, string, string) web.ResponseCode); ok {
+		r1 = rf(ctx, cid, companyName, addr, telephone, localityId)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// CreateServiceArea provides a mock function with given fields: ctx, carrierId, area
+func (_m *Service) CreateServiceArea(ctx context.Context, carrierId int, area carriers.ServiceArea) (carriers.ServiceArea, web.ResponseCode) {
+	ret := _m.Called(ctx, carrierId, area)
+
+	var r0 carriers.ServiceArea
+	if rf, ok := ret.Get(0).(func(context.Context, int, carriers.ServiceArea) carriers.ServiceArea); ok {
+		r0 = rf(ctx, carrierId, area)
+	} else {
+		r0 = ret.Get(0).(carriers.ServiceArea)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, int, carriers.ServiceArea) web.ResponseCode); ok {
+		r1 = rf(ctx, carrierId, area)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Service) Delete(ctx context.Context, id int) web.ResponseCode {
+	ret := _m.Called(ctx, id)
+
+	var r0 web.ResponseCode
+	if rf, ok := ret.Get(0).(func(context.Context, int) web.ResponseCode); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(web.ResponseCode)
+	}
+
+	return r0
+}
+
+// DeleteServiceArea provides a mock function with given fields: ctx, carrierId, id
+func (_m *Service) DeleteServiceArea(ctx context.Context, carrierId int, id int) web.ResponseCode {
+	ret := _m.Called(ctx, carrierId, id)
+
+	var r0 web.ResponseCode
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) web.ResponseCode); ok {
+		r0 = rf(ctx, carrierId, id)
+	} else {
+		r0 = ret.Get(0).(web.ResponseCode)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Service) GetAll(ctx context.Context, opts listing.Options) ([]carriers.Carry, listing.Page, web.ResponseCode) {
+	ret := _m.Called(ctx, opts)
+
+	var r0 []carriers.Carry
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []carriers.Carry); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]carriers.Carry)
+		}
+	}
+
+	var r1 listing.Page
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) listing.Page); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Get(1).(listing.Page)
+	}
+
+	var r2 web.ResponseCode
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Options) web.ResponseCode); ok {
+		r2 = rf(ctx, opts)
+	} else {
+		r2 = ret.Get(2).(web.ResponseCode)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByCid provides a mock function with given fields: ctx, cid
+func (_m *Service) GetByCid(ctx context.Context, cid string) (carriers.Carry, web.ResponseCode) {
+	ret := _m.Called(ctx, cid)
+
+	var r0 carriers.Carry
+	if rf, ok := ret.Get(0).(func(context.Context, string) carriers.Carry); ok {
+		r0 = rf(ctx, cid)
+	} else {
+		r0 = ret.Get(0).(carriers.Carry)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, string) web.ResponseCode); ok {
+		r1 = rf(ctx, cid)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, fromLocalityId, toLocalityId
+func (_m *Service) GetDeliveries(ctx context.Context, fromLocalityId string, toLocalityId string) ([]carriers.Delivery, web.ResponseCode) {
+	ret := _m.Called(ctx, fromLocalityId, toLocalityId)
+
+	var r0 []carriers.Delivery
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []carriers.Delivery); ok {
+		r0 = rf(ctx, fromLocalityId, toLocalityId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]carriers.Delivery)
+		}
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) web.ResponseCode); ok {
+		r1 = rf(ctx, fromLocalityId, toLocalityId)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Service) GetOne(ctx context.Context, id int) (carriers.Carry, web.ResponseCode) {
+	ret := _m.Called(ctx, id)
+
+	var r0 carriers.Carry
+	if rf, ok := ret.Get(0).(func(context.Context, int) carriers.Carry); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(carriers.Carry)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetServiceAreas provides a mock function with given fields: ctx, carrierId
+func (_m *Service) GetServiceAreas(ctx context.Context, carrierId int) ([]carriers.ServiceArea, web.ResponseCode) {
+	ret := _m.Called(ctx, carrierId)
+
+	var r0 []carriers.ServiceArea
+	if rf, ok := ret.Get(0).(func(context.Context, int) []carriers.ServiceArea); ok {
+		r0 = rf(ctx, carrierId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]carriers.ServiceArea)
+		}
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, carrierId)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Service) Update(ctx context.Context, id int, requestData map[string]interface{}) (carriers.Carry, web.ResponseCode) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 carriers.Carry
-	if rf, ok := ret.Get(0).(func(string, string, string, string, string) carriers.Carry); ok {
-		r0 = rf(cid, companyName, address, telephone, localityId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) carriers.Carry); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(carriers.Carry)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(string, string, string, string, string) web.ResponseCode); ok {
-		r1 = rf(cid, companyName, address, telephone, localityId)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) web.ResponseCode); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
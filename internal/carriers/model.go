@@ -1,10 +1,54 @@
 package carriers
 
+import (
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/document"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+)
+
+// AcceptedDocuments are the documents a carry may be registered with as cid.
+var AcceptedDocuments = []document.Type{document.CNPJ}
+
+// ListFields are the fields carriers can be filtered and sorted by.
+var ListFields = listing.Fields{
+	Columns: map[string]string{
+		"id":           "id",
+		"cid":          "cid",
+		"company_name": "company_name",
+		"telephone":    "telephone",
+		"locality_id":  "locality_id",
+	},
+	Sortable: []string{"cid", "company_name"},
+}
+
 type Carry struct {
-	Id          int    `json:"id"`
-	Cid         string `json:"cid"`
-	CompanyName string `json:"company_name"`
-	Address     string `json:"address"`
-	Telephone   string `json:"telephone"`
-	LocalityId  string `json:"locality_id"`
+	Id                int             `json:"id"`
+	Cid               string          `json:"cid"`
+	CompanyName       string          `json:"company_name"`
+	Address           string          `json:"address"`
+	StructuredAddress address.Address `json:"structured_address"`
+	Telephone         string          `json:"telephone"`
+	TelephoneDisplay  string          `json:"telephone_display"`
+	LocalityId        string          `json:"locality_id"`
+}
+
+// ServiceArea is a locality, or a whole province when LocalityId is nil,
+// served by a carry besides the locality of its headquarters.
+type ServiceArea struct {
+	Id           int     `json:"id"`
+	CarrierId    int     `json:"carrier_id"`
+	LocalityId   *string `json:"locality_id"`
+	ProvinceName *string `json:"province_name"`
+	CountryName  *string `json:"country_name"`
+	LeadTimeDays *int    `json:"lead_time_days"`
+}
+
+// Delivery is a carry serving both ends of a delivery. The lead times are
+// nil when the locality is only covered by the headquarters of the carry
+// or by areas without a lead time.
+type Delivery struct {
+	Carry
+	OriginLeadTimeDays      *int `json:"origin_lead_time_days"`
+	DestinationLeadTimeDays *int `json:"destination_lead_time_days"`
+	LeadTimeDays            *int `json:"lead_time_days"`
 }
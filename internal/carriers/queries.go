@@ -1,6 +1,65 @@
 package carriers
 
+import (
+	"fmt"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+)
+
 var (
-	queryCreateCarry  = "INSERT INTO carriers (cid, company_name, address, telephone,locality_id) VALUES (?, ?, ?, ?,?)"
-	queryGetOneCarry  = "SELECT * FROM carriers WHERE cid=?"
-)
\ No newline at end of file
+	queryCarryColumns = "id, cid, company_name, address, street, number, complement, neighborhood, cep, state, telephone, telephone_display, locality_id"
+
+	queryCreateCarry = `INSERT INTO carriers (cid, company_name, address, street, number, complement, neighborhood, cep, state, telephone, telephone_display, locality_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	queryGetCarryByCid = "SELECT " + queryCarryColumns + " FROM carriers WHERE cid=?"
+	queryGetOneCarry   = "SELECT " + queryCarryColumns + " FROM carriers WHERE id=?"
+	queryGetAllCarries = "SELECT " + queryCarryColumns + " FROM carriers"
+	queryDeleteCarry   = "DELETE FROM carriers WHERE id = ?"
+
+	queryServiceAreaColumns = "id, carrier_id, locality_id, province_name, country_name, lead_time_days"
+	queryCreateServiceArea  = `INSERT INTO carrier_service_areas (carrier_id, locality_id, province_name, country_name, lead_time_days) VALUES (?, ?, ?, ?, ?)`
+	queryGetServiceAreas    = "SELECT " + queryServiceAreaColumns + " FROM carrier_service_areas WHERE carrier_id = ? ORDER BY id"
+	queryDeleteServiceArea  = "DELETE FROM carrier_service_areas WHERE id = ? AND carrier_id = ?"
+
+	// queryGetDeliveries lists the carriers covering both localities with the
+	// shortest lead time declared for each of them.
+	queryGetDeliveries = `SELECT c.id, c.cid, c.company_name, c.address, c.street, c.number, c.complement, c.neighborhood, c.cep, c.state,
+	c.telephone, c.telephone_display, c.locality_id, o.lead_time_days, d.lead_time_days
+	FROM carriers c
+	JOIN (SELECT carrier_id, MIN(lead_time_days) AS lead_time_days FROM carrier_coverage WHERE locality_id = ? GROUP BY carrier_id) o ON o.carrier_id = c.id
+	JOIN (SELECT carrier_id, MIN(lead_time_days) AS lead_time_days FROM carrier_coverage WHERE locality_id = ? GROUP BY carrier_id) d ON d.carrier_id = c.id
+	ORDER BY c.id`
+	queryUpdateCarry = func(
+		requestData map[string]interface{},
+		id int) (
+		finalQuery string,
+		valuesToUse []interface{}) {
+		prefixQuery := "UPDATE carriers SET"
+		fieldsToUpdate := []string{}
+		whereCase := "WHERE id = ?"
+
+		var fields = []string{"cid", "company_name", "address", "telephone", "locality_id"}
+		fields = append(fields, address.UpdatedColumns(requestData)...)
+		fields = append(fields, phone.UpdatedColumns(requestData)...)
+		for _, currField := range fields {
+			if _, ok := requestData[currField]; ok {
+				fieldsToUpdate = append(fieldsToUpdate, fmt.Sprintf(" %s = ?", currField))
+				valuesToUse = append(valuesToUse, requestData[currField])
+			}
+		}
+
+		valuesToUse = append(valuesToUse, id)
+		finalQuery += prefixQuery
+		for index, field := range fieldsToUpdate {
+			if index+1 == len(fieldsToUpdate) {
+				finalQuery += field + " "
+			} else {
+				finalQuery += field + ", "
+			}
+		}
+		finalQuery += whereCase
+
+		return finalQuery, valuesToUse
+	}
+)
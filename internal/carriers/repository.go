@@ -1,46 +1,93 @@
 package carriers
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
+	"github.com/go-sql-driver/mysql"
 )
 
+// mysqlErrRowIsReferenced is the MySQL error number returned when a delete
+// would break a foreign key pointing at the row.
+const mysqlErrRowIsReferenced = 1451
+
 var (
-	errCreateCarry = errors.New("ocurred an error to create seller")
+	errCreateCarry     = errors.New("ocurred an error to create seller")
+	errGetCarries      = errors.New("couldn't get carries")
+	errUpdateCarry     = errors.New("ocurred an error while updating the carry")
+	errDeleteCarry     = errors.New("ocurred an error to delete the carry")
+	errCarryReferenced = errors.New("carry is used by shipments and cannot be removed")
+	errGetServiceAreas = errors.New("couldn't get the service areas of the carry")
+	errCreateArea      = errors.New("ocurred an error to create the service area")
+	errDeleteArea      = errors.New("ocurred an error to delete the service area")
+	errGetDeliveries   = errors.New("couldn't get the carriers for the delivery")
 )
 
+func GetErrCarryNotFound(id int) error {
+	return fmt.Errorf("carry with id %d not found", id)
+}
+
+func GetErrCarryCidNotFound(cid string) error {
+	return fmt.Errorf("Carry with cid %s not found", cid)
+}
+
+func GetErrServiceAreaNotFound(id int) error {
+	return fmt.Errorf("service area with id %d not found", id)
+}
+
 type Repository interface {
-	Create(cid, companyName, address, telephone, localityId string) (Carry, error)
-	GetOne(cid string) (Carry, error)
+	Create(ctx context.Context, cid, companyName string, addr address.Address, telephone phone.Number, localityId string) (Carry, error)
+	GetOne(ctx context.Context, id int) (Carry, error)
+	GetByCid(ctx context.Context, cid string) (Carry, error)
+	GetAll(ctx context.Context, opts listing.Options) ([]Carry, error)
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Carry, error)
+	Delete(ctx context.Context, id int) error
+	GetServiceAreas(ctx context.Context, carrierId int) ([]ServiceArea, error)
+	CreateServiceArea(ctx context.Context, area ServiceArea) (ServiceArea, error)
+	DeleteServiceArea(ctx context.Context, carrierId, id int) error
+	GetDeliveries(ctx context.Context, fromLocalityId, toLocalityId string) ([]Delivery, error)
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
-func (mariaDb mariaDbRepository) GetOne(cid string) (Carry, error) {
+func (mariaDb mariaDbRepository) GetOne(ctx context.Context, id int) (Carry, error) {
 	currentCarry := Carry{}
 
-	row := mariaDb.db.QueryRow(queryGetOneCarry, cid)
+	row := mariaDb.db.QueryRowContext(ctx, queryGetOneCarry, id)
 
 	err := row.Scan(
 		&currentCarry.Id,
 		&currentCarry.Cid,
 		&currentCarry.CompanyName,
 		&currentCarry.Address,
+		&currentCarry.StructuredAddress.Street,
+		&currentCarry.StructuredAddress.Number,
+		&currentCarry.StructuredAddress.Complement,
+		&currentCarry.StructuredAddress.Neighborhood,
+		&currentCarry.StructuredAddress.Cep,
+		&currentCarry.StructuredAddress.State,
 		&currentCarry.Telephone,
+		&currentCarry.TelephoneDisplay,
 		&currentCarry.LocalityId,
 	)
+	currentCarry.StructuredAddress.LocalityId = currentCarry.LocalityId
 
 	if errors.Is(err, sql.ErrNoRows) {
-		return Carry{}, fmt.Errorf("Carry with cid %s not found", cid)
+		return Carry{}, GetErrCarryNotFound(id)
 	}
 
 	if err != nil {
@@ -50,23 +97,92 @@ func (mariaDb mariaDbRepository) GetOne(cid string) (Carry, error) {
 	return currentCarry, nil
 }
 
-func (mariaDb mariaDbRepository) Create(cid, companyName, address, telephone, localityId string) (Carry, error) {
-	newCarry := Carry{
-		Cid:         cid,
-		CompanyName: companyName,
-		Address:     address,
-		Telephone:   telephone,
-		LocalityId:  localityId,
-	}
-
-	result, err := mariaDb.db.Exec(
-		queryCreateCarry ,
-		cid,
-		companyName,
-		address,
-		telephone,
-		localityId,
+func (mariaDb mariaDbRepository) GetByCid(ctx context.Context, cid string) (Carry, error) {
+	currentCarry := Carry{}
+
+	row := mariaDb.db.QueryRowContext(ctx, queryGetCarryByCid, cid)
+
+	err := row.Scan(
+		&currentCarry.Id,
+		&currentCarry.Cid,
+		&currentCarry.CompanyName,
+		&currentCarry.Address,
+		&currentCarry.StructuredAddress.Street,
+		&currentCarry.StructuredAddress.Number,
+		&currentCarry.StructuredAddress.Complement,
+		&currentCarry.StructuredAddress.Neighborhood,
+		&currentCarry.StructuredAddress.Cep,
+		&currentCarry.StructuredAddress.State,
+		&currentCarry.Telephone,
+		&currentCarry.TelephoneDisplay,
+		&currentCarry.LocalityId,
 	)
+	currentCarry.StructuredAddress.LocalityId = currentCarry.LocalityId
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return Carry{}, GetErrCarryCidNotFound(cid)
+	}
+
+	if err != nil {
+		return Carry{}, errors.New("error to find Carry")
+	}
+
+	return currentCarry, nil
+}
+
+func (mariaDb mariaDbRepository) GetAll(ctx context.Context, opts listing.Options) ([]Carry, error) {
+	carries := []Carry{}
+
+	query, values := listing.Build(queryGetAllCarries, ListFields, opts)
+	rows, err := mariaDb.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return []Carry{}, errGetCarries
+	}
+
+	for rows.Next() {
+		var currentCarry Carry
+		if err := rows.Scan(
+			&currentCarry.Id,
+			&currentCarry.Cid,
+			&currentCarry.CompanyName,
+			&currentCarry.Address,
+			&currentCarry.StructuredAddress.Street,
+			&currentCarry.StructuredAddress.Number,
+			&currentCarry.StructuredAddress.Complement,
+			&currentCarry.StructuredAddress.Neighborhood,
+			&currentCarry.StructuredAddress.Cep,
+			&currentCarry.StructuredAddress.State,
+			&currentCarry.Telephone,
+			&currentCarry.TelephoneDisplay,
+			&currentCarry.LocalityId,
+		); err != nil {
+			return []Carry{}, errGetCarries
+		}
+		currentCarry.StructuredAddress.LocalityId = currentCarry.LocalityId
+		carries = append(carries, currentCarry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []Carry{}, errGetCarries
+	}
+	return carries, nil
+}
+
+func (mariaDb mariaDbRepository) Create(ctx context.Context, cid, companyName string, addr address.Address, telephone phone.Number, localityId string) (Carry, error) {
+	newCarry := Carry{
+		Cid:               cid,
+		CompanyName:       companyName,
+		Address:           addr.String(),
+		StructuredAddress: addr,
+		Telephone:         telephone.E164,
+		TelephoneDisplay:  telephone.Display,
+		LocalityId:        localityId,
+	}
+
+	values := append([]interface{}{cid, companyName}, addr.Values()...)
+	values = append(values, telephone.E164, telephone.Display, localityId)
+
+	result, err := mariaDb.db.ExecContext(ctx, queryCreateCarry, values...)
 
 	if err != nil {
 		return Carry{}, errCreateCarry
@@ -81,3 +197,146 @@ func (mariaDb mariaDbRepository) Create(cid, companyName, address, telephone, lo
 
 	return newCarry, nil
 }
+
+func (mariaDb mariaDbRepository) Update(ctx context.Context, id int, requestData map[string]interface{}) (Carry, error) {
+	finalQuery, valuesToUse := queryUpdateCarry(requestData, id)
+
+	_, err := mariaDb.db.ExecContext(ctx, finalQuery, valuesToUse...)
+	if err != nil {
+		return Carry{}, errUpdateCarry
+	}
+
+	currentCarry, err := mariaDb.GetOne(ctx, id)
+	if err != nil {
+		return Carry{}, errUpdateCarry
+	}
+
+	return currentCarry, nil
+}
+
+func (mariaDb mariaDbRepository) Delete(ctx context.Context, id int) error {
+	_, err := mariaDb.db.ExecContext(ctx, queryDeleteCarry, id)
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrRowIsReferenced {
+		return errCarryReferenced
+	}
+
+	if err != nil {
+		return errDeleteCarry
+	}
+
+	return nil
+}
+
+func (mariaDb mariaDbRepository) GetServiceAreas(ctx context.Context, carrierId int) ([]ServiceArea, error) {
+	areas := []ServiceArea{}
+
+	rows, err := mariaDb.db.QueryContext(ctx, queryGetServiceAreas, carrierId)
+	if err != nil {
+		return []ServiceArea{}, errGetServiceAreas
+	}
+
+	for rows.Next() {
+		var currentArea ServiceArea
+		if err := rows.Scan(
+			&currentArea.Id,
+			&currentArea.CarrierId,
+			&currentArea.LocalityId,
+			&currentArea.ProvinceName,
+			&currentArea.CountryName,
+			&currentArea.LeadTimeDays,
+		); err != nil {
+			return []ServiceArea{}, errGetServiceAreas
+		}
+		areas = append(areas, currentArea)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []ServiceArea{}, errGetServiceAreas
+	}
+
+	return areas, nil
+}
+
+func (mariaDb mariaDbRepository) CreateServiceArea(ctx context.Context, area ServiceArea) (ServiceArea, error) {
+	result, err := mariaDb.db.ExecContext(
+		ctx,
+		queryCreateServiceArea,
+		area.CarrierId,
+		area.LocalityId,
+		area.ProvinceName,
+		area.CountryName,
+		area.LeadTimeDays,
+	)
+	if err != nil {
+		return ServiceArea{}, errCreateArea
+	}
+
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		return ServiceArea{}, errCreateArea
+	}
+
+	area.Id = int(lastId)
+
+	return area, nil
+}
+
+func (mariaDb mariaDbRepository) DeleteServiceArea(ctx context.Context, carrierId, id int) error {
+	result, err := mariaDb.db.ExecContext(ctx, queryDeleteServiceArea, id, carrierId)
+	if err != nil {
+		return errDeleteArea
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return errDeleteArea
+	}
+
+	if affectedRows == 0 {
+		return GetErrServiceAreaNotFound(id)
+	}
+
+	return nil
+}
+
+func (mariaDb mariaDbRepository) GetDeliveries(ctx context.Context, fromLocalityId, toLocalityId string) ([]Delivery, error) {
+	deliveries := []Delivery{}
+
+	rows, err := mariaDb.db.QueryContext(ctx, queryGetDeliveries, fromLocalityId, toLocalityId)
+	if err != nil {
+		return []Delivery{}, errGetDeliveries
+	}
+
+	for rows.Next() {
+		var currentDelivery Delivery
+		if err := rows.Scan(
+			&currentDelivery.Id,
+			&currentDelivery.Cid,
+			&currentDelivery.CompanyName,
+			&currentDelivery.Address,
+			&currentDelivery.StructuredAddress.Street,
+			&currentDelivery.StructuredAddress.Number,
+			&currentDelivery.StructuredAddress.Complement,
+			&currentDelivery.StructuredAddress.Neighborhood,
+			&currentDelivery.StructuredAddress.Cep,
+			&currentDelivery.StructuredAddress.State,
+			&currentDelivery.Telephone,
+			&currentDelivery.TelephoneDisplay,
+			&currentDelivery.LocalityId,
+			&currentDelivery.OriginLeadTimeDays,
+			&currentDelivery.DestinationLeadTimeDays,
+		); err != nil {
+			return []Delivery{}, errGetDeliveries
+		}
+		currentDelivery.StructuredAddress.LocalityId = currentDelivery.LocalityId
+		deliveries = append(deliveries, currentDelivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []Delivery{}, errGetDeliveries
+	}
+
+	return deliveries, nil
+}
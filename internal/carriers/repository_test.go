@@ -1,9 +1,18 @@
 package carriers
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"regexp"
 	"testing"
+	"time"
+
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -12,9 +21,11 @@ func TestCreate(t *testing.T) {
 		Cid:         "CID#25vA",
 		CompanyName: "some name",
 		Address:     "corrientes 800",
-		Telephone:   "4567-4567",
+		Telephone:   "+554832221100",
 		LocalityId:  "456",
 	}
+	telephone := phone.Number{E164: mockCarriers.Telephone, Display: "(48) 3222-1100"}
+
 	t.Run("success create_carry_repository ", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.NoError(t, err)
@@ -25,13 +36,20 @@ func TestCreate(t *testing.T) {
 				mockCarriers.Cid,
 				mockCarriers.CompanyName,
 				mockCarriers.Address,
+				mockCarriers.Address,
+				"",
+				"",
+				"",
+				"",
+				"",
 				mockCarriers.Telephone,
+				"(48) 3222-1100",
 				mockCarriers.LocalityId,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		carriersRepo := NewMariaDbRepository(db)
 
-		carryCreate, err := carriersRepo.Create(mockCarriers.Cid, mockCarriers.CompanyName, mockCarriers.Address, mockCarriers.Telephone, mockCarriers.LocalityId)
+		carryCreate, err := carriersRepo.Create(context.Background(), mockCarriers.Cid, mockCarriers.CompanyName, address.FromLegacy(mockCarriers.Address), telephone, mockCarriers.LocalityId)
 
 		assert.NoError(t, err)
 
@@ -51,15 +69,15 @@ func TestCreate(t *testing.T) {
 
 		carriersRepo := NewMariaDbRepository(db)
 
-		_, err = carriersRepo.Create(mockCarriers.Cid, mockCarriers.CompanyName, mockCarriers.Address, mockCarriers.Telephone, mockCarriers.LocalityId)
+		_, err = carriersRepo.Create(context.Background(), mockCarriers.Cid, mockCarriers.CompanyName, address.FromLegacy(mockCarriers.Address), telephone, mockCarriers.LocalityId)
 
 		assert.Error(t, err)
 	})
 }
 
-func TestGetOne(t *testing.T) {
+func TestGetByCid(t *testing.T) {
 	mockCarriers := &Carry{
-		Id: 1,
+		Id:          1,
 		Cid:         "CID#1",
 		CompanyName: "some name",
 		Address:     "corrientes 800",
@@ -67,37 +85,43 @@ func TestGetOne(t *testing.T) {
 		LocalityId:  "456",
 	}
 
-	t.Run("success getOne_carry_repository", func(t *testing.T) {
+	t.Run("success getByCid_carry_repository", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
 
 		assert.NoError(t, err)
 		defer db.Close()
 
-
 		rows := sqlmock.NewRows([]string{
 			"id",
 			"cid",
 			"company_name",
 			"adress",
+			"street",
+			"number",
+			"complement",
+			"neighborhood",
+			"cep",
+			"state",
 			"telephone",
+			"telephone_display",
 			"locality_id",
 		}).
-			AddRow(mockCarriers.Id, mockCarriers.Cid, mockCarriers.CompanyName, mockCarriers.Address, mockCarriers.Telephone, mockCarriers.LocalityId)
+			AddRow(mockCarriers.Id, mockCarriers.Cid, mockCarriers.CompanyName, mockCarriers.Address, "", "", "", "", "", "", mockCarriers.Telephone, "", mockCarriers.LocalityId)
 
-		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneCarry)).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetCarryByCid)).WillReturnRows(rows)
 
 		carriersRepo := NewMariaDbRepository(db)
 
 		expectedCompanyName := "some name"
 
-		carryGetOne, err := carriersRepo.GetOne("CID#1")
+		carryGetOne, err := carriersRepo.GetByCid(context.Background(), "CID#1")
 		assert.NoError(t, err)
 		assert.NotNil(t, carryGetOne)
-		assert.Equal(t,expectedCompanyName, carryGetOne.CompanyName)
+		assert.Equal(t, expectedCompanyName, carryGetOne.CompanyName)
 
 	})
 
-	t.Run("failed getOne_carry_repository", func(t *testing.T) {
+	t.Run("failed getByCid_carry_repository", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
 
 		assert.NoError(t, err)
@@ -108,17 +132,328 @@ func TestGetOne(t *testing.T) {
 			"cid",
 			"company_name",
 			"adress",
+			"street",
+			"number",
+			"complement",
+			"neighborhood",
+			"cep",
+			"state",
 			"telephone",
+			"telephone_display",
 			"locality_id",
 		}).
-			AddRow("", "", "", "", "", "")
+			AddRow("", "", "", "", "", "", "", "", "", "", "", "", "")
 
-		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneCarry)).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetCarryByCid)).WillReturnRows(rows)
 
 		carriersRepo := NewMariaDbRepository(db)
 
-		_, err = carriersRepo.GetOne("CID#1")
+		_, err = carriersRepo.GetByCid(context.Background(), "CID#1")
 
 		assert.NotNil(t, err)
 	})
 }
+
+var carryColumns = []string{"id", "cid", "company_name", "address", "street", "number", "complement", "neighborhood", "cep", "state", "telephone", "telephone_display", "locality_id"}
+
+func TestGetOne(t *testing.T) {
+	t.Run("success getOne_carry_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(carryColumns).
+			AddRow(1, "CID#1", "some name", "corrientes 800", "", "", "", "", "", "", "4567-4567", "", "456")
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneCarry)).WithArgs(1).WillReturnRows(rows)
+
+		carriersRepo := NewMariaDbRepository(db)
+		carry, err := carriersRepo.GetOne(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "CID#1", carry.Cid)
+	})
+
+	t.Run("not found getOne_carry_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneCarry)).WithArgs(1).WillReturnError(sql.ErrNoRows)
+
+		carriersRepo := NewMariaDbRepository(db)
+		_, err = carriersRepo.GetOne(context.Background(), 1)
+
+		assert.Equal(t, GetErrCarryNotFound(1), err)
+	})
+}
+
+func TestGetAll(t *testing.T) {
+	t.Run("success getAll_carry_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(carryColumns).
+			AddRow(1, "CID#1", "some name", "corrientes 800", "", "", "", "", "", "", "4567-4567", "", "456").
+			AddRow(2, "CID#2", "other name", "corrientes 900", "", "", "", "", "", "", "4567-4568", "", "456")
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetAllCarries)).WillReturnRows(rows)
+
+		carriersRepo := NewMariaDbRepository(db)
+		carries, err := carriersRepo.GetAll(context.Background(), listing.Options{})
+
+		assert.NoError(t, err)
+		assert.Len(t, carries, 2)
+	})
+
+	t.Run("success getAll_carry_repository filtered by locality", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(carryColumns).
+			AddRow(1, "CID#1", "some name", "corrientes 800", "", "", "", "", "", "", "4567-4567", "", "456")
+		opts := listing.Options{Limit: 10, Sort: "id", Order: listing.SortAsc, Filters: []listing.Filter{{Field: "locality_id", Value: "456"}}}
+		query, _ := listing.Build(queryGetAllCarries, ListFields, opts)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("456", 11, 0).WillReturnRows(rows)
+
+		carriersRepo := NewMariaDbRepository(db)
+		carries, err := carriersRepo.GetAll(context.Background(), opts)
+
+		assert.NoError(t, err)
+		assert.Len(t, carries, 1)
+	})
+
+	t.Run("failed getAll_carry_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetAllCarries)).WillReturnError(errors.New("some error"))
+
+		carriersRepo := NewMariaDbRepository(db)
+		_, err = carriersRepo.GetAll(context.Background(), listing.Options{})
+
+		assert.Equal(t, errGetCarries, err)
+	})
+
+	t.Run("failed getAll_carry_repository with a cancelled context", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetAllCarries)).WillDelayFor(time.Second).WillReturnRows(sqlmock.NewRows(carryColumns))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		carriersRepo := NewMariaDbRepository(db)
+		_, err = carriersRepo.GetAll(ctx, listing.Options{})
+
+		assert.Equal(t, errGetCarries, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	requestData := map[string]interface{}{"cid": "CID#2", "telephone": "1234-1234"}
+
+	t.Run("success update_carry_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		finalQuery, _ := queryUpdateCarry(requestData, 1)
+		mock.ExpectExec(regexp.QuoteMeta(finalQuery)).
+			WithArgs("CID#2", "1234-1234", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		rows := sqlmock.NewRows(carryColumns).
+			AddRow(1, "CID#2", "some name", "corrientes 800", "", "", "", "", "", "", "1234-1234", "", "456")
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneCarry)).WithArgs(1).WillReturnRows(rows)
+
+		carriersRepo := NewMariaDbRepository(db)
+		carry, err := carriersRepo.Update(context.Background(), 1, requestData)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "CID#2", carry.Cid)
+		assert.Equal(t, "1234-1234", carry.Telephone)
+	})
+
+	t.Run("address columns are only written along with the address", func(t *testing.T) {
+		finalQuery, values := queryUpdateCarry(map[string]interface{}{"company_name": "some name", "cep": "01310100"}, 1)
+
+		assert.Equal(t, "UPDATE carriers SET company_name = ? WHERE id = ?", finalQuery)
+		assert.Equal(t, []interface{}{"some name", 1}, values)
+	})
+
+	t.Run("telephone_display is only written along with the telephone", func(t *testing.T) {
+		finalQuery, values := queryUpdateCarry(map[string]interface{}{"company_name": "some name", "telephone_display": "1234-1234"}, 1)
+
+		assert.Equal(t, "UPDATE carriers SET company_name = ? WHERE id = ?", finalQuery)
+		assert.Equal(t, []interface{}{"some name", 1}, values)
+	})
+
+	t.Run("failed update_carry_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		finalQuery, _ := queryUpdateCarry(requestData, 1)
+		mock.ExpectExec(regexp.QuoteMeta(finalQuery)).WillReturnError(errors.New("some error"))
+
+		carriersRepo := NewMariaDbRepository(db)
+		_, err = carriersRepo.Update(context.Background(), 1, requestData)
+
+		assert.Equal(t, errUpdateCarry, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("success delete_carry_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteCarry)).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		carriersRepo := NewMariaDbRepository(db)
+		assert.NoError(t, carriersRepo.Delete(context.Background(), 1))
+	})
+
+	t.Run("referenced delete_carry_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteCarry)).WithArgs(1).
+			WillReturnError(&mysql.MySQLError{Number: mysqlErrRowIsReferenced})
+
+		carriersRepo := NewMariaDbRepository(db)
+		assert.Equal(t, errCarryReferenced, carriersRepo.Delete(context.Background(), 1))
+	})
+
+	t.Run("failed delete_carry_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteCarry)).WithArgs(1).WillReturnError(errors.New("some error"))
+
+		carriersRepo := NewMariaDbRepository(db)
+		assert.Equal(t, errDeleteCarry, carriersRepo.Delete(context.Background(), 1))
+	})
+}
+
+func TestServiceAreas(t *testing.T) {
+	localityId, leadTime := "6", 2
+	area := ServiceArea{CarrierId: 1, LocalityId: &localityId, LeadTimeDays: &leadTime}
+	columns := []string{"id", "carrier_id", "locality_id", "province_name", "country_name", "lead_time_days"}
+
+	t.Run("success get_service_areas_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, 1, "6", nil, nil, 2).
+			AddRow(2, 1, nil, "SP", "BR", nil)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetServiceAreas)).WithArgs(1).WillReturnRows(rows)
+
+		areas, err := NewMariaDbRepository(db).GetServiceAreas(context.Background(), 1)
+		assert.NoError(t, err)
+
+		assert.Len(t, areas, 2)
+		assert.Equal(t, "6", *areas[0].LocalityId)
+		assert.Nil(t, areas[1].LocalityId)
+		assert.Equal(t, "SP", *areas[1].ProvinceName)
+		assert.Nil(t, areas[1].LeadTimeDays)
+	})
+
+	t.Run("failed get_service_areas_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetServiceAreas)).WillReturnError(errors.New("some error"))
+
+		_, err = NewMariaDbRepository(db).GetServiceAreas(context.Background(), 1)
+		assert.Equal(t, errGetServiceAreas, err)
+	})
+
+	t.Run("success create_service_area_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryCreateServiceArea)).
+			WithArgs(1, &localityId, nil, nil, &leadTime).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+
+		result, err := NewMariaDbRepository(db).CreateServiceArea(context.Background(), area)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Id)
+	})
+
+	t.Run("failed create_service_area_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryCreateServiceArea)).WillReturnError(errors.New("some error"))
+
+		_, err = NewMariaDbRepository(db).CreateServiceArea(context.Background(), area)
+		assert.Equal(t, errCreateArea, err)
+	})
+
+	t.Run("success delete_service_area_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteServiceArea)).WithArgs(3, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, NewMariaDbRepository(db).DeleteServiceArea(context.Background(), 1, 3))
+	})
+
+	t.Run("not found delete_service_area_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteServiceArea)).WithArgs(3, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.Equal(t, GetErrServiceAreaNotFound(3), NewMariaDbRepository(db).DeleteServiceArea(context.Background(), 1, 3))
+	})
+}
+
+func TestGetDeliveries(t *testing.T) {
+	t.Run("success get_deliveries_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{
+			"id", "cid", "company_name", "address", "street", "number", "complement", "neighborhood", "cep", "state",
+			"telephone", "telephone_display", "locality_id", "lead_time_days", "lead_time_days",
+		}).AddRow(1, "11222333000181", "some name", "Rua A", "Rua A", "", "", "", "", "SP", "+554832221100", "(48) 3222-1100", "2", nil, 3)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetDeliveries)).WithArgs("2", "6").WillReturnRows(rows)
+
+		deliveries, err := NewMariaDbRepository(db).GetDeliveries(context.Background(), "2", "6")
+		assert.NoError(t, err)
+
+		assert.Len(t, deliveries, 1)
+		assert.Equal(t, "2", deliveries[0].StructuredAddress.LocalityId)
+		assert.Nil(t, deliveries[0].OriginLeadTimeDays)
+		assert.Equal(t, 3, *deliveries[0].DestinationLeadTimeDays)
+	})
+
+	t.Run("failed get_deliveries_repository", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetDeliveries)).WillReturnError(errors.New("some error"))
+
+		_, err = NewMariaDbRepository(db).GetDeliveries(context.Background(), "2", "6")
+		assert.Equal(t, errGetDeliveries, err)
+	})
+}
@@ -1,35 +1,84 @@
 package carriers
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/localities"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/document"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 )
 
+var (
+	errInvalidCid      = fmt.Errorf("cid must be a valid %s", document.Describe(AcceptedDocuments))
+	errInvalidArea     = errors.New("service area must have either a locality_id or a province_name and a country_name")
+	errInvalidLeadTime = errors.New("lead_time_days must not be negative")
+)
+
 type Service interface {
-	Create(cid, companyName, address, telephone, localityId string) (Carry, web.ResponseCode)
+	Create(ctx context.Context, cid, companyName string, addr address.Address, telephone, localityId string) (Carry, web.ResponseCode)
+	GetOne(ctx context.Context, id int) (Carry, web.ResponseCode)
+	GetByCid(ctx context.Context, cid string) (Carry, web.ResponseCode)
+	GetAll(ctx context.Context, opts listing.Options) ([]Carry, listing.Page, web.ResponseCode)
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Carry, web.ResponseCode)
+	Delete(ctx context.Context, id int) web.ResponseCode
+	GetServiceAreas(ctx context.Context, carrierId int) ([]ServiceArea, web.ResponseCode)
+	CreateServiceArea(ctx context.Context, carrierId int, area ServiceArea) (ServiceArea, web.ResponseCode)
+	DeleteServiceArea(ctx context.Context, carrierId, id int) web.ResponseCode
+	GetDeliveries(ctx context.Context, fromLocalityId, toLocalityId string) ([]Delivery, web.ResponseCode)
 }
 
 type service struct {
-	repository Repository
+	repository         Repository
+	localityRepository localities.Repository
 }
 
-func NewService(r Repository) Service {
+func NewService(r Repository, lr localities.Repository) Service {
 	return &service{
-		repository: r,
+		repository:         r,
+		localityRepository: lr,
 	}
 }
 
-func (s service) Create(cid, companyName, address, telephone, localityId string) (Carry, web.ResponseCode) {
+func (s service) Create(ctx context.Context, cid, companyName string, addr address.Address, telephone, localityId string) (Carry, web.ResponseCode) {
+	doc, err := document.Parse(cid, AcceptedDocuments...)
+	if err != nil {
+		return Carry{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidCid)
+	}
+	cid = doc.Number
 
-	_, err := s.repository.GetOne(cid)
+	_, err = s.repository.GetByCid(ctx, cid)
 
 	if err == nil {
 		return Carry{}, web.NewCodeResponse(http.StatusConflict, errors.New("CID already exists"))
 	}
 
-	CarryResult, err := s.repository.Create(cid, companyName, address, telephone, localityId)
+	if err.Error() != GetErrCarryCidNotFound(cid).Error() {
+		return Carry{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	number, err := phone.Parse(telephone)
+	if err != nil {
+		return Carry{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+	}
+
+	addr, err = address.Link(addr, localityId)
+	if err != nil {
+		return Carry{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+	}
+
+	if resp := s.checkLocality(ctx, addr, localityId); resp.Err != nil {
+		return Carry{}, resp
+	}
+
+	CarryResult, err := s.repository.Create(ctx, cid, companyName, addr, number, localityId)
 	if err != nil {
 		return Carry{}, web.NewCodeResponse(
 			http.StatusInternalServerError,
@@ -39,3 +88,306 @@ func (s service) Create(cid, companyName, address, telephone, localityId string)
 
 	return CarryResult, web.NewCodeResponse(http.StatusCreated, nil)
 }
+
+func (s service) GetOne(ctx context.Context, id int) (Carry, web.ResponseCode) {
+	carry, err := s.repository.GetOne(ctx, id)
+
+	if err != nil && err.Error() == GetErrCarryNotFound(id).Error() {
+		return Carry{}, web.NewCodeResponse(http.StatusNotFound, err)
+	}
+
+	if err != nil {
+		return Carry{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return carry, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) GetByCid(ctx context.Context, cid string) (Carry, web.ResponseCode) {
+	cid = document.Normalize(cid)
+	carry, err := s.repository.GetByCid(ctx, cid)
+
+	if err != nil && err.Error() == GetErrCarryCidNotFound(cid).Error() {
+		return Carry{}, web.NewCodeResponse(http.StatusNotFound, err)
+	}
+
+	if err != nil {
+		return Carry{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return carry, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) GetAll(ctx context.Context, opts listing.Options) ([]Carry, listing.Page, web.ResponseCode) {
+	carries, err := s.repository.GetAll(ctx, opts)
+	if err != nil {
+		return []Carry{}, listing.Page{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	carries, page := listing.Paginate(carries, opts)
+	return carries, page, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) Update(ctx context.Context, id int, requestData map[string]interface{}) (Carry, web.ResponseCode) {
+	current, resp := s.GetOne(ctx, id)
+	if resp.Err != nil {
+		return Carry{}, resp
+	}
+
+	if currCid, ok := requestData["cid"].(string); ok {
+		doc, err := document.Parse(currCid, AcceptedDocuments...)
+		if err != nil {
+			return Carry{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidCid)
+		}
+		requestData["cid"] = doc.Number
+
+		carry, err := s.repository.GetByCid(ctx, doc.Number)
+		if err == nil && carry.Id != id {
+			return Carry{}, web.NewCodeResponse(http.StatusConflict, errors.New("CID already exists"))
+		}
+
+		if err != nil && err.Error() != GetErrCarryCidNotFound(doc.Number).Error() {
+			return Carry{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+		}
+	}
+
+	if value, ok := requestData["telephone"].(string); ok {
+		number, err := phone.Parse(value)
+		if err != nil {
+			return Carry{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+		}
+		number.ToRequestData(requestData)
+	}
+
+	localityId := current.LocalityId
+	if value, ok := requestData["locality_id"].(string); ok {
+		localityId = value
+	}
+
+	addr, changed, err := address.FromRequestData(requestData)
+	if err != nil {
+		return Carry{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+	}
+
+	if !changed {
+		addr = current.StructuredAddress
+	} else {
+		if addr, err = address.Link(addr, localityId); err != nil {
+			return Carry{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+		}
+		addr.ToRequestData(requestData)
+	}
+
+	if localityId != current.LocalityId || addr.State != current.StructuredAddress.State {
+		addr.LocalityId = localityId
+		if resp := s.checkLocality(ctx, addr, localityId); resp.Err != nil {
+			return Carry{}, resp
+		}
+	}
+
+	carry, err := s.repository.Update(ctx, id, requestData)
+	if err != nil {
+		return Carry{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return carry, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) Delete(ctx context.Context, id int) web.ResponseCode {
+	if _, resp := s.GetOne(ctx, id); resp.Err != nil {
+		return resp
+	}
+
+	err := s.repository.Delete(ctx, id)
+
+	if err != nil && err.Error() == errCarryReferenced.Error() {
+		return web.NewCodeResponse(http.StatusConflict, err)
+	}
+
+	if err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return web.NewCodeResponse(http.StatusNoContent, nil)
+}
+
+// checkLocality answers 409 when the locality does not exist and 422 when
+// the state of the address is not the province of the locality.
+func (s service) checkLocality(ctx context.Context, addr address.Address, localityId string) web.ResponseCode {
+	locality, err := s.localityRepository.GetOne(ctx, localityId)
+	if err != nil && err.Error() == localities.GetErrLocalityNotFound(localityId).Error() {
+		return web.NewCodeResponse(http.StatusConflict, err)
+	}
+
+	if err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	if err := address.CheckState(addr, locality.ProvinceName); err != nil {
+		return web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+	}
+
+	return web.ResponseCode{}
+}
+
+func (s service) GetServiceAreas(ctx context.Context, carrierId int) ([]ServiceArea, web.ResponseCode) {
+	if _, resp := s.GetOne(ctx, carrierId); resp.Err != nil {
+		return []ServiceArea{}, resp
+	}
+
+	areas, err := s.repository.GetServiceAreas(ctx, carrierId)
+	if err != nil {
+		return []ServiceArea{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return areas, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+// CreateServiceArea declares a locality or a whole province served by the
+// carry. Declaring the same area twice answers 409.
+func (s service) CreateServiceArea(ctx context.Context, carrierId int, area ServiceArea) (ServiceArea, web.ResponseCode) {
+	area.CarrierId = carrierId
+	area.LocalityId = trimmed(area.LocalityId)
+	area.ProvinceName = trimmed(area.ProvinceName)
+	area.CountryName = trimmed(area.CountryName)
+
+	byLocality := area.LocalityId != nil
+	byProvince := area.ProvinceName != nil || area.CountryName != nil
+	if byLocality == byProvince || (byProvince && (area.ProvinceName == nil || area.CountryName == nil)) {
+		return ServiceArea{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidArea)
+	}
+
+	if area.LeadTimeDays != nil && *area.LeadTimeDays < 0 {
+		return ServiceArea{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidLeadTime)
+	}
+
+	areas, resp := s.GetServiceAreas(ctx, carrierId)
+	if resp.Err != nil {
+		return ServiceArea{}, resp
+	}
+
+	if byLocality {
+		_, err := s.localityRepository.GetOne(ctx, *area.LocalityId)
+		if err != nil && err.Error() == localities.GetErrLocalityNotFound(*area.LocalityId).Error() {
+			return ServiceArea{}, web.NewCodeResponse(http.StatusConflict, err)
+		}
+
+		if err != nil {
+			return ServiceArea{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+		}
+	} else {
+		provinceLocalities, err := s.localityRepository.GetLocalities(ctx, *area.CountryName, *area.ProvinceName)
+		if err != nil {
+			return ServiceArea{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+		}
+
+		if len(provinceLocalities) == 0 {
+			return ServiceArea{}, web.NewCodeResponse(
+				http.StatusConflict,
+				fmt.Errorf("province %s not found in country %s", *area.ProvinceName, *area.CountryName),
+			)
+		}
+	}
+
+	for _, current := range areas {
+		if sameArea(current, area) {
+			return ServiceArea{}, web.NewCodeResponse(
+				http.StatusConflict,
+				fmt.Errorf("carry with id %d already serves this area in service area %d", carrierId, current.Id),
+			)
+		}
+	}
+
+	newArea, err := s.repository.CreateServiceArea(ctx, area)
+	if err != nil {
+		return ServiceArea{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return newArea, web.NewCodeResponse(http.StatusCreated, nil)
+}
+
+func (s service) DeleteServiceArea(ctx context.Context, carrierId, id int) web.ResponseCode {
+	if _, resp := s.GetOne(ctx, carrierId); resp.Err != nil {
+		return resp
+	}
+
+	err := s.repository.DeleteServiceArea(ctx, carrierId, id)
+
+	if err != nil && err.Error() == GetErrServiceAreaNotFound(id).Error() {
+		return web.NewCodeResponse(http.StatusNotFound, err)
+	}
+
+	if err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return web.NewCodeResponse(http.StatusNoContent, nil)
+}
+
+// GetDeliveries lists the carriers covering both localities, the fastest
+// first. The lead time of a delivery is the longest of its two ends;
+// carriers without any declared lead time come last.
+func (s service) GetDeliveries(ctx context.Context, fromLocalityId, toLocalityId string) ([]Delivery, web.ResponseCode) {
+	for _, localityId := range []string{fromLocalityId, toLocalityId} {
+		_, err := s.localityRepository.GetOne(ctx, localityId)
+		if err != nil && err.Error() == localities.GetErrLocalityNotFound(localityId).Error() {
+			return []Delivery{}, web.NewCodeResponse(http.StatusNotFound, err)
+		}
+
+		if err != nil {
+			return []Delivery{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+		}
+	}
+
+	deliveries, err := s.repository.GetDeliveries(ctx, fromLocalityId, toLocalityId)
+	if err != nil {
+		return []Delivery{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	for i := range deliveries {
+		deliveries[i].LeadTimeDays = longest(deliveries[i].OriginLeadTimeDays, deliveries[i].DestinationLeadTimeDays)
+	}
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		a, b := deliveries[i].LeadTimeDays, deliveries[j].LeadTimeDays
+		return a != nil && (b == nil || *a < *b)
+	})
+
+	return deliveries, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func sameArea(a, b ServiceArea) bool {
+	if a.LocalityId != nil || b.LocalityId != nil {
+		return a.LocalityId != nil && b.LocalityId != nil && *a.LocalityId == *b.LocalityId
+	}
+
+	return strings.EqualFold(valueOf(a.ProvinceName), valueOf(b.ProvinceName)) &&
+		strings.EqualFold(valueOf(a.CountryName), valueOf(b.CountryName))
+}
+
+func longest(a, b *int) *int {
+	if a == nil || (b != nil && *b > *a) {
+		return b
+	}
+
+	return a
+}
+
+// trimmed drops the surrounding spaces of value, turning blank values into
+// nil.
+func trimmed(value *string) *string {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil
+	}
+
+	result := strings.TrimSpace(*value)
+	return &result
+}
+
+func valueOf(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
@@ -1,41 +1,54 @@
 package carriers_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/carriers"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/carriers/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/localities"
+	mockLocalityRepository "github.com/emidioreb/mercado-fresco-lerigophers/internal/localities/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func TestServiceCreate(t *testing.T) {
 	input := carriers.Carry{
-		Cid:         "CID#1",
+		Cid:         "11222333000181",
 		CompanyName: "some name",
 		Address:     "corrientes 800",
-		Telephone:   "4567-4567",
+		Telephone:   "(48) 3222-1100",
 		LocalityId:  "456",
 	}
+	addr := address.FromLegacy(input.Address)
+	telephone := phone.Number{E164: "+554832221100", Display: "(48) 3222-1100"}
+	locality := localities.Locality{Id: "456", ProvinceName: "SP"}
 
 	t.Run("should return create Carry", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedLocality.On("GetOne", mock.Anything, "456").Return(locality, nil)
 
-		mockedRepository.On("GetOne",
-			mock.AnythingOfType("string")).Return(carriers.Carry{}, errors.New(""))
+		mockedRepository.On("GetByCid",
+			mock.Anything,
+			mock.AnythingOfType("string")).Return(carriers.Carry{}, carriers.GetErrCarryCidNotFound("11222333000181"))
 
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
+			mock.AnythingOfType("phone.Number"),
 			mock.AnythingOfType("string")).Return(input, nil)
 
-		service := carriers.NewService(mockedRepository)
+		service := carriers.NewService(mockedRepository, mockedLocality)
 
-		result, err := service.Create(input.Cid, input.CompanyName, input.Address, input.Telephone, input.LocalityId)
+		result, err := service.Create(context.Background(), input.Cid, input.CompanyName, addr, input.Telephone, input.LocalityId)
 
 		assert.Nil(t, err.Err)
 		assert.Equal(t, result, input)
@@ -46,20 +59,24 @@ func TestServiceCreate(t *testing.T) {
 
 	t.Run("CID already exists", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedLocality.On("GetOne", mock.Anything, "456").Return(locality, nil)
 
-		mockedRepository.On("GetOne",
+		mockedRepository.On("GetByCid",
+			mock.Anything,
 			mock.AnythingOfType("string")).Return(carriers.Carry{}, nil)
 
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
+			mock.AnythingOfType("phone.Number"),
 			mock.AnythingOfType("string")).Return(input, errors.New("CID already exists"))
 
-		service := carriers.NewService(mockedRepository)
+		service := carriers.NewService(mockedRepository, mockedLocality)
 
-		_, resp := service.Create(input.Cid, input.CompanyName, input.Address, input.Telephone, input.LocalityId)
+		_, resp := service.Create(context.Background(), input.Cid, input.CompanyName, addr, input.Telephone, input.LocalityId)
 
 		assert.NotNil(t, resp.Err)
 		assert.Equal(t, resp.Code, http.StatusConflict)
@@ -68,21 +85,572 @@ func TestServiceCreate(t *testing.T) {
 
 	t.Run("CarryResult should return error", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedLocality.On("GetOne", mock.Anything, "456").Return(locality, nil)
 
-		mockedRepository.On("GetOne",
-			mock.AnythingOfType("string")).Return(carriers.Carry{}, errors.New(""))
+		mockedRepository.On("GetByCid",
+			mock.Anything,
+			mock.AnythingOfType("string")).Return(carriers.Carry{}, carriers.GetErrCarryCidNotFound("11222333000181"))
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
+			mock.AnythingOfType("phone.Number"),
 			mock.AnythingOfType("string")).Return(carriers.Carry{}, errors.New("StatusInternalServerError"))
 
-		service := carriers.NewService(mockedRepository)
-		_, resp := service.Create(input.Cid, input.CompanyName, input.Address, input.Telephone, input.LocalityId)
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		_, resp := service.Create(context.Background(), input.Cid, input.CompanyName, addr, input.Telephone, input.LocalityId)
 
 		assert.NotNil(t, resp.Err)
 		assert.Equal(t, resp.Code, http.StatusInternalServerError)
 	})
 
+	t.Run("should store the cid in canonical form", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedLocality.On("GetOne", mock.Anything, "456").Return(locality, nil)
+		mockedRepository.On("GetByCid", mock.Anything, "11222333000181").Return(carriers.Carry{}, carriers.GetErrCarryCidNotFound("11222333000181")).Once()
+		mockedRepository.On("Create", mock.Anything, "11222333000181", input.CompanyName, address.Address{Street: "corrientes 800", LocalityId: "456"}, telephone, input.LocalityId).
+			Return(input, nil).Once()
+
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		_, resp := service.Create(context.Background(), "11.222.333/0001-81", input.CompanyName, addr, input.Telephone, input.LocalityId)
+
+		assert.Nil(t, resp.Err)
+		mockedRepository.AssertExpectations(t)
+	})
+
+	t.Run("should return internal error when the cid lookup fails", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetByCid", mock.Anything, "11222333000181").Return(carriers.Carry{}, errors.New("error to find Carry"))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.Create(context.Background(), input.Cid, input.CompanyName, addr, input.Telephone, input.LocalityId)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		assert.Equal(t, "error to find Carry", resp.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("should reject a cid that is not a valid CNPJ", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedLocality.On("GetOne", mock.Anything, "456").Return(locality, nil)
+		mockedRepository.On("GetByCid", mock.Anything, mock.AnythingOfType("string")).Return(carriers.Carry{}, carriers.GetErrCarryCidNotFound("11222333000181"))
+
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		_, resp := service.Create(context.Background(), "CID#1", input.CompanyName, addr, input.Telephone, input.LocalityId)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "cid must be a valid CNPJ", resp.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "GetByCid", 0)
+	})
+
+	t.Run("should return conflict when the locality does not exist", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedRepository.On("GetByCid", mock.Anything, mock.AnythingOfType("string")).Return(carriers.Carry{}, carriers.GetErrCarryCidNotFound("11222333000181"))
+		mockedLocality.On("GetOne", mock.Anything, "456").Return(localities.Locality{}, localities.GetErrLocalityNotFound("456"))
+
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		_, resp := service.Create(context.Background(), input.Cid, input.CompanyName, addr, input.Telephone, input.LocalityId)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		mockedRepository.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("should reject a state out of the locality province", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedRepository.On("GetByCid", mock.Anything, mock.AnythingOfType("string")).Return(carriers.Carry{}, carriers.GetErrCarryCidNotFound("11222333000181"))
+		mockedLocality.On("GetOne", mock.Anything, "456").Return(locality, nil)
+
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		_, resp := service.Create(context.Background(), input.Cid, input.CompanyName, address.Address{Street: "Rua A", State: "RJ"}, input.Telephone, input.LocalityId)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "state RJ does not match the province SP of locality 456", resp.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("should reject a telephone with an unknown area code", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetByCid", mock.Anything, mock.AnythingOfType("string")).Return(carriers.Carry{}, carriers.GetErrCarryCidNotFound("11222333000181"))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.Create(context.Background(), input.Cid, input.CompanyName, addr, "(20) 3222-1100", input.LocalityId)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, phone.ErrInvalidArea, resp.Err)
+		mockedRepository.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("should reject a malformed cep", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetByCid", mock.Anything, mock.AnythingOfType("string")).Return(carriers.Carry{}, carriers.GetErrCarryCidNotFound("11222333000181"))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.Create(context.Background(), input.Cid, input.CompanyName, address.Address{Street: "Rua A", Cep: "123"}, input.Telephone, input.LocalityId)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, address.ErrInvalidCep, resp.Err)
+	})
+}
+
+func TestServiceGetOne(t *testing.T) {
+	t.Run("should return the carry", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result, resp := service.GetOne(context.Background(), 1)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, 1, result.Id)
+	})
+
+	t.Run("should return not found", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{}, carriers.GetErrCarryNotFound(1))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.GetOne(context.Background(), 1)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("should return internal server error", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{}, errors.New("error to find Carry"))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.GetOne(context.Background(), 1)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestServiceGetByCid(t *testing.T) {
+	t.Run("should return the carry", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetByCid", mock.Anything, "11222333000181").Return(carriers.Carry{Id: 1, Cid: "11222333000181"}, nil)
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result, resp := service.GetByCid(context.Background(), "11222333000181")
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, "11222333000181", result.Cid)
+	})
+
+	t.Run("should return not found", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetByCid", mock.Anything, "11222333000181").Return(carriers.Carry{}, errors.New("Carry with cid 11222333000181 not found"))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.GetByCid(context.Background(), "11222333000181")
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("should look up the formatted cid by its canonical form", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetByCid", mock.Anything, "12ABC34501DE35").Return(carriers.Carry{Id: 1, Cid: "12ABC34501DE35"}, nil).Once()
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.GetByCid(context.Background(), "12.abc.345/01de-35")
+
+		assert.Nil(t, resp.Err)
+		mockedRepository.AssertExpectations(t)
+	})
+}
+
+func TestServiceGetAll(t *testing.T) {
+	t.Run("should return the carries of a locality", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		opts := listing.Options{Filters: []listing.Filter{{Field: "locality_id", Value: "456"}}}
+		mockedRepository.On("GetAll", mock.Anything, opts).Return([]carriers.Carry{{Id: 1, LocalityId: "456"}}, nil)
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result, _, resp := service.GetAll(context.Background(), opts)
+
+		assert.Nil(t, resp.Err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("should return a page and the next cursor", func(t *testing.T) {
+		opts := listing.Options{Limit: 1, Sort: "cid", Order: listing.SortAsc}
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything, opts).Return([]carriers.Carry{{Id: 1, Cid: "CID#1"}, {Id: 2, Cid: "CID#2"}}, nil)
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result, page, resp := service.GetAll(context.Background(), opts)
+
+		assert.Nil(t, resp.Err)
+		assert.Len(t, result, 1)
+		assert.True(t, page.HasMore)
+		assert.Equal(t, 1, *page.NextOffset)
+
+		cursor, err := listing.DecodeCursor(page.NextCursor, "cid", listing.SortAsc)
+		assert.NoError(t, err)
+		assert.Equal(t, "CID#1", cursor.Value)
+		assert.Equal(t, 1, cursor.Id)
+	})
+
+	t.Run("should return internal server error", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]carriers.Carry{}, errors.New("couldn't get carries"))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, _, resp := service.GetAll(context.Background(), listing.Options{})
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestServiceUpdate(t *testing.T) {
+	requestData := map[string]interface{}{"cid": "12ABC34501DE35"}
+
+	t.Run("should update the carry", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+		mockedRepository.On("GetByCid", mock.Anything, "12ABC34501DE35").Return(carriers.Carry{}, errors.New("Carry with cid 12ABC34501DE35 not found"))
+		mockedRepository.On("Update", mock.Anything, 1, requestData).Return(carriers.Carry{Id: 1, Cid: "12ABC34501DE35"}, nil)
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result, resp := service.Update(context.Background(), 1, requestData)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, "12ABC34501DE35", result.Cid)
+	})
+
+	t.Run("should allow keeping its own cid", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+		mockedRepository.On("GetByCid", mock.Anything, "12ABC34501DE35").Return(carriers.Carry{Id: 1, Cid: "12ABC34501DE35"}, nil)
+		mockedRepository.On("Update", mock.Anything, 1, requestData).Return(carriers.Carry{Id: 1, Cid: "12ABC34501DE35"}, nil)
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.Update(context.Background(), 1, requestData)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("should return conflict when cid belongs to another carry", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+		mockedRepository.On("GetByCid", mock.Anything, "12ABC34501DE35").Return(carriers.Carry{Id: 2, Cid: "12ABC34501DE35"}, nil)
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.Update(context.Background(), 1, requestData)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		mockedRepository.AssertNumberOfCalls(t, "Update", 0)
+	})
+
+	t.Run("should return internal error when the cid lookup fails", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+		mockedRepository.On("GetByCid", mock.Anything, "12ABC34501DE35").Return(carriers.Carry{}, errors.New("error to find Carry"))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.Update(context.Background(), 1, requestData)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		assert.Equal(t, "error to find Carry", resp.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Update", 0)
+	})
+
+	t.Run("should reject a cid that is not a valid CNPJ", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.Update(context.Background(), 1, map[string]interface{}{"cid": "52998224725"})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "cid must be a valid CNPJ", resp.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Update", 0)
+	})
+
+	t.Run("should store the telephone in E.164 with its display format", func(t *testing.T) {
+		requestData := map[string]interface{}{"telephone": "48 3222 1100"}
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+		mockedRepository.On("Update", mock.Anything, 1, map[string]interface{}{
+			"telephone":         "+554832221100",
+			"telephone_display": "(48) 3222-1100",
+		}).Return(carriers.Carry{Id: 1}, nil).Once()
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.Update(context.Background(), 1, requestData)
+
+		assert.Nil(t, resp.Err)
+		mockedRepository.AssertExpectations(t)
+	})
+
+	t.Run("should reject an invalid telephone", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.Update(context.Background(), 1, map[string]interface{}{"telephone": "4567-4567"})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		mockedRepository.AssertNumberOfCalls(t, "Update", 0)
+	})
+
+	t.Run("should return not found", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{}, carriers.GetErrCarryNotFound(1))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.Update(context.Background(), 1, requestData)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("should return internal server error", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+		mockedRepository.On("GetByCid", mock.Anything, "12ABC34501DE35").Return(carriers.Carry{}, errors.New("Carry with cid 12ABC34501DE35 not found"))
+		mockedRepository.On("Update", mock.Anything, 1, requestData).Return(carriers.Carry{}, errors.New("ocurred an error while updating the carry"))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.Update(context.Background(), 1, requestData)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestServiceDelete(t *testing.T) {
+	t.Run("should delete the carry", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+		mockedRepository.On("Delete", mock.Anything, 1).Return(nil)
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		resp := service.Delete(context.Background(), 1)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+	})
+
+	t.Run("should return not found", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{}, carriers.GetErrCarryNotFound(1))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		resp := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("should return conflict when used by shipments", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+		mockedRepository.On("Delete", mock.Anything, 1).Return(errors.New("carry is used by shipments and cannot be removed"))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		resp := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
+	t.Run("should return internal server error", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+		mockedRepository.On("Delete", mock.Anything, 1).Return(errors.New("ocurred an error to delete the carry"))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		resp := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestServiceCreateServiceArea(t *testing.T) {
+	carry := carriers.Carry{Id: 1, LocalityId: "456"}
+	localityId, province, country, leadTime := "6", "SP", "BR", 2
+
+	newMocks := func(areas []carriers.ServiceArea) (*mocks.Repository, *mockLocalityRepository.Repository) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carry, nil)
+		mockedRepository.On("GetServiceAreas", mock.Anything, 1).Return(areas, nil)
+		return mockedRepository, mockedLocality
+	}
+
+	t.Run("should create an area for a locality", func(t *testing.T) {
+		blank := " 6 "
+		mockedRepository, mockedLocality := newMocks([]carriers.ServiceArea{})
+		mockedLocality.On("GetOne", mock.Anything, "6").Return(localities.Locality{Id: "6"}, nil)
+		mockedRepository.On("CreateServiceArea", mock.Anything, carriers.ServiceArea{CarrierId: 1, LocalityId: &localityId, LeadTimeDays: &leadTime}).
+			Return(carriers.ServiceArea{Id: 1, CarrierId: 1, LocalityId: &localityId, LeadTimeDays: &leadTime}, nil)
+
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		result, resp := service.CreateServiceArea(context.Background(), 1, carriers.ServiceArea{LocalityId: &blank, LeadTimeDays: &leadTime})
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, 1, result.Id)
+	})
+
+	t.Run("should create an area for a province", func(t *testing.T) {
+		mockedRepository, mockedLocality := newMocks([]carriers.ServiceArea{})
+		mockedLocality.On("GetLocalities", mock.Anything, "BR", "SP").Return([]localities.LocalityDetail{{}}, nil)
+		mockedRepository.On("CreateServiceArea", mock.Anything, mock.AnythingOfType("carriers.ServiceArea")).
+			Return(carriers.ServiceArea{Id: 2, CarrierId: 1, ProvinceName: &province, CountryName: &country}, nil)
+
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		_, resp := service.CreateServiceArea(context.Background(), 1, carriers.ServiceArea{ProvinceName: &province, CountryName: &country})
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+	})
+
+	t.Run("should reject an area with a locality and a province", func(t *testing.T) {
+		for _, area := range []carriers.ServiceArea{
+			{LocalityId: &localityId, ProvinceName: &province, CountryName: &country},
+			{ProvinceName: &province},
+			{},
+		} {
+			mockedRepository, mockedLocality := newMocks([]carriers.ServiceArea{})
+
+			service := carriers.NewService(mockedRepository, mockedLocality)
+			_, resp := service.CreateServiceArea(context.Background(), 1, area)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		}
+	})
+
+	t.Run("should reject a negative lead time", func(t *testing.T) {
+		negative := -1
+		mockedRepository, mockedLocality := newMocks([]carriers.ServiceArea{})
+
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		_, resp := service.CreateServiceArea(context.Background(), 1, carriers.ServiceArea{LocalityId: &localityId, LeadTimeDays: &negative})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+
+	t.Run("should return conflict when the locality does not exist", func(t *testing.T) {
+		mockedRepository, mockedLocality := newMocks([]carriers.ServiceArea{})
+		mockedLocality.On("GetOne", mock.Anything, "6").Return(localities.Locality{}, localities.GetErrLocalityNotFound("6"))
+
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		_, resp := service.CreateServiceArea(context.Background(), 1, carriers.ServiceArea{LocalityId: &localityId})
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
+	t.Run("should return conflict when the province does not exist", func(t *testing.T) {
+		mockedRepository, mockedLocality := newMocks([]carriers.ServiceArea{})
+		mockedLocality.On("GetLocalities", mock.Anything, "BR", "SP").Return([]localities.LocalityDetail{}, nil)
+
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		_, resp := service.CreateServiceArea(context.Background(), 1, carriers.ServiceArea{ProvinceName: &province, CountryName: &country})
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.EqualError(t, resp.Err, "province SP not found in country BR")
+	})
+
+	t.Run("should return conflict when the area is already served", func(t *testing.T) {
+		lower := "sp"
+		mockedRepository, mockedLocality := newMocks([]carriers.ServiceArea{
+			{Id: 4, CarrierId: 1, ProvinceName: &lower, CountryName: &country},
+		})
+		mockedLocality.On("GetLocalities", mock.Anything, "BR", "SP").Return([]localities.LocalityDetail{{}}, nil)
+
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		_, resp := service.CreateServiceArea(context.Background(), 1, carriers.ServiceArea{ProvinceName: &province, CountryName: &country})
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.EqualError(t, resp.Err, "carry with id 1 already serves this area in service area 4")
+	})
+
+	t.Run("should return not found for an unknown carry", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{}, carriers.GetErrCarryNotFound(1))
+
+		service := carriers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.CreateServiceArea(context.Background(), 1, carriers.ServiceArea{LocalityId: &localityId})
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+func TestServiceDeleteServiceArea(t *testing.T) {
+	t.Run("should delete the area", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+		mockedRepository.On("DeleteServiceArea", mock.Anything, 1, 3).Return(nil)
+
+		resp := carriers.NewService(mockedRepository, nil).DeleteServiceArea(context.Background(), 1, 3)
+
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+	})
+
+	t.Run("should return not found for an unknown area", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(carriers.Carry{Id: 1}, nil)
+		mockedRepository.On("DeleteServiceArea", mock.Anything, 1, 3).Return(carriers.GetErrServiceAreaNotFound(3))
+
+		resp := carriers.NewService(mockedRepository, nil).DeleteServiceArea(context.Background(), 1, 3)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+func TestServiceGetDeliveries(t *testing.T) {
+	one, two, five := 1, 2, 5
+
+	t.Run("should sort the carriers by lead time", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedLocality.On("GetOne", mock.Anything, mock.AnythingOfType("string")).Return(localities.Locality{}, nil)
+		mockedRepository.On("GetDeliveries", mock.Anything, "2", "6").Return([]carriers.Delivery{
+			{Carry: carriers.Carry{Id: 1}},
+			{Carry: carriers.Carry{Id: 2}, OriginLeadTimeDays: &one, DestinationLeadTimeDays: &five},
+			{Carry: carriers.Carry{Id: 3}, DestinationLeadTimeDays: &two},
+		}, nil)
+
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		result, resp := service.GetDeliveries(context.Background(), "2", "6")
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, 3, result[0].Id)
+		assert.Equal(t, 2, *result[0].LeadTimeDays)
+		assert.Equal(t, 2, result[1].Id)
+		assert.Equal(t, 5, *result[1].LeadTimeDays)
+		assert.Equal(t, 1, result[2].Id)
+		assert.Nil(t, result[2].LeadTimeDays)
+	})
+
+	t.Run("should return not found for an unknown locality", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedLocality.On("GetOne", mock.Anything, "2").Return(localities.Locality{}, nil)
+		mockedLocality.On("GetOne", mock.Anything, "6").Return(localities.Locality{}, localities.GetErrLocalityNotFound("6"))
+
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		_, resp := service.GetDeliveries(context.Background(), "2", "6")
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("should return error when the query fails", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedLocality.On("GetOne", mock.Anything, mock.AnythingOfType("string")).Return(localities.Locality{}, nil)
+		mockedRepository.On("GetDeliveries", mock.Anything, "2", "6").Return([]carriers.Delivery{}, errors.New("couldn't get the carriers for the delivery"))
+
+		service := carriers.NewService(mockedRepository, mockedLocality)
+		_, resp := service.GetDeliveries(context.Background(), "2", "6")
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
 }
@@ -0,0 +1,293 @@
+// Package config reads the settings of the server and its commands.
+//
+// Each setting is taken from, in increasing order of precedence, its
+// default, the .env file, the environment and the command line flags.
+package config
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+	"github.com/joho/godotenv"
+)
+
+const defaultEnvFile = ".env"
+
+type Config struct {
+	Port        int
+	GinMode     string
+	AutoMigrate bool
+	DB          DB
+	HTTP        HTTP
+}
+
+type DB struct {
+	DataSource      string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	ConnectTimeout  time.Duration
+}
+
+// HTTP holds the timeouts of the http.Server and, in Requests, the ones
+// applied to each request by web.Timeout. A zero server timeout means no
+// timeout.
+type HTTP struct {
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	Requests          web.Timeouts
+}
+
+type setting struct {
+	env   string
+	flag  string
+	value string
+	usage string
+	set   func(c *Config, value string) error
+}
+
+var settings = []setting{
+	{"SERVER_URI", "server-uri", "", "MySQL data source name, e.g. user:pass@tcp(localhost:3306)/mercado_fresco", func(c *Config, v string) error {
+		c.DB.DataSource = v
+		return nil
+	}},
+	{"PORT", "port", "8080", "port the server listens on", func(c *Config, v string) error {
+		return parseInt(&c.Port, "PORT", v)
+	}},
+	{"GIN_MODE", "gin-mode", gin.DebugMode, "gin mode: debug, release or test", func(c *Config, v string) error {
+		c.GinMode = v
+		return nil
+	}},
+	{"AUTO_MIGRATE", "auto-migrate", "false", "apply the pending migrations before starting the server", func(c *Config, v string) error {
+		autoMigrate, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid AUTO_MIGRATE %q: must be true or false", v)
+		}
+		c.AutoMigrate = autoMigrate
+		return nil
+	}},
+	{"DB_MAX_OPEN_CONNS", "db-max-open-conns", "25", "maximum number of open database connections, 0 for no limit", func(c *Config, v string) error {
+		return parseInt(&c.DB.MaxOpenConns, "DB_MAX_OPEN_CONNS", v)
+	}},
+	{"DB_MAX_IDLE_CONNS", "db-max-idle-conns", "5", "maximum number of idle database connections", func(c *Config, v string) error {
+		return parseInt(&c.DB.MaxIdleConns, "DB_MAX_IDLE_CONNS", v)
+	}},
+	{"DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "5m", "maximum time a database connection is reused, 0 for no limit", func(c *Config, v string) error {
+		return parseDuration(&c.DB.ConnMaxLifetime, "DB_CONN_MAX_LIFETIME", v)
+	}},
+	{"DB_CONN_MAX_IDLE_TIME", "db-conn-max-idle-time", "1m", "maximum time a database connection stays idle, 0 for no limit", func(c *Config, v string) error {
+		return parseDuration(&c.DB.ConnMaxIdleTime, "DB_CONN_MAX_IDLE_TIME", v)
+	}},
+	{"DB_CONNECT_TIMEOUT", "db-connect-timeout", "10s", "time to wait for the database on startup", func(c *Config, v string) error {
+		return parseDuration(&c.DB.ConnectTimeout, "DB_CONNECT_TIMEOUT", v)
+	}},
+	{"HTTP_READ_TIMEOUT", "http-read-timeout", "30s", "maximum time to read a request, body included", func(c *Config, v string) error {
+		return parseDuration(&c.HTTP.ReadTimeout, "HTTP_READ_TIMEOUT", v)
+	}},
+	{"HTTP_READ_HEADER_TIMEOUT", "http-read-header-timeout", "10s", "maximum time to read the headers of a request", func(c *Config, v string) error {
+		return parseDuration(&c.HTTP.ReadHeaderTimeout, "HTTP_READ_HEADER_TIMEOUT", v)
+	}},
+	{"HTTP_WRITE_TIMEOUT", "http-write-timeout", "0", "maximum time to write a response, 0 to rely on the request timeouts", func(c *Config, v string) error {
+		return parseDuration(&c.HTTP.WriteTimeout, "HTTP_WRITE_TIMEOUT", v)
+	}},
+	{"HTTP_IDLE_TIMEOUT", "http-idle-timeout", "2m", "maximum time a keep-alive connection stays idle", func(c *Config, v string) error {
+		return parseDuration(&c.HTTP.IdleTimeout, "HTTP_IDLE_TIMEOUT", v)
+	}},
+	{"REQUEST_TIMEOUT", "request-timeout", web.DefaultRequestTimeout.String(), "default timeout of each request, 0 for none", func(c *Config, v string) error {
+		timeouts, err := web.ParseTimeouts(v, "")
+		c.HTTP.Requests.Default = timeouts.Default
+		return err
+	}},
+	{"ROUTE_TIMEOUTS", "route-timeouts", "", `per-route request timeouts, e.g. "POST /api/v1/imports/:entity=5m"`, func(c *Config, v string) error {
+		timeouts, err := web.ParseTimeouts("", v)
+		c.HTTP.Requests.Routes = timeouts.Routes
+		return err
+	}},
+}
+
+// Load registers the flags of the settings in flags, parses args with it
+// and returns the validated settings. The arguments left after the flags
+// are available from flags.Args().
+//
+// The .env file is optional unless another one is given with ENV_FILE or
+// -env-file. Its variables never override the environment.
+func Load(flags *flag.FlagSet, args []string) (Config, error) {
+	envFile := flags.String("env-file", "", "file to read the settings from (default .env)")
+	values := make([]*string, len(settings))
+	for i, s := range settings {
+		values[i] = flags.String(s.flag, s.value, s.usage+" ($"+s.env+")")
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	explicit := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	if !explicit["env-file"] {
+		*envFile = os.Getenv("ENV_FILE")
+	}
+	dotenv, err := readEnvFile(*envFile)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var c Config
+	for i, s := range settings {
+		value := s.value
+		if v, ok := dotenv[s.env]; ok {
+			value = v
+		}
+		if v, ok := os.LookupEnv(s.env); ok {
+			value = v
+		}
+		if explicit[s.flag] {
+			value = *values[i]
+		}
+
+		if err := s.set(&c, value); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := c.validate(); err != nil {
+		return Config{}, err
+	}
+
+	return c, nil
+}
+
+func readEnvFile(name string) (map[string]string, error) {
+	if name == "" {
+		dotenv, err := godotenv.Read(defaultEnvFile)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid env file %s: %w", defaultEnvFile, err)
+		}
+		return dotenv, nil
+	}
+
+	dotenv, err := godotenv.Read(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid env file %s: %w", name, err)
+	}
+	return dotenv, nil
+}
+
+func (c Config) validate() error {
+	if c.DB.DataSource == "" {
+		return errors.New("SERVER_URI is required, e.g. user:pass@tcp(localhost:3306)/mercado_fresco")
+	}
+	dsn, err := mysql.ParseDSN(c.DB.DataSource)
+	if err != nil {
+		return fmt.Errorf("invalid SERVER_URI: %w", err)
+	}
+	if dsn.DBName == "" {
+		return errors.New("invalid SERVER_URI: it must name the database, e.g. user:pass@tcp(localhost:3306)/mercado_fresco")
+	}
+
+	if c.Port < 1 || c.Port > 65535 {
+		return fmt.Errorf("invalid PORT %d: must be between 1 and 65535", c.Port)
+	}
+
+	switch c.GinMode {
+	case gin.DebugMode, gin.ReleaseMode, gin.TestMode:
+	default:
+		return fmt.Errorf("invalid GIN_MODE %q: must be debug, release or test", c.GinMode)
+	}
+
+	if c.DB.MaxOpenConns < 0 {
+		return errors.New("invalid DB_MAX_OPEN_CONNS: must not be negative")
+	}
+	if c.DB.MaxIdleConns < 0 {
+		return errors.New("invalid DB_MAX_IDLE_CONNS: must not be negative")
+	}
+	if c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		return fmt.Errorf("invalid DB_MAX_IDLE_CONNS %d: must not exceed DB_MAX_OPEN_CONNS %d", c.DB.MaxIdleConns, c.DB.MaxOpenConns)
+	}
+	if c.DB.ConnectTimeout <= 0 {
+		return errors.New("invalid DB_CONNECT_TIMEOUT: must be greater than 0")
+	}
+
+	if c.HTTP.WriteTimeout > 0 {
+		longest := c.HTTP.Requests.Default
+		for _, timeout := range c.HTTP.Requests.Routes {
+			if timeout == 0 || longest == 0 {
+				longest = 0
+				break
+			}
+			if timeout > longest {
+				longest = timeout
+			}
+		}
+		if longest == 0 || c.HTTP.WriteTimeout <= longest {
+			return fmt.Errorf("invalid HTTP_WRITE_TIMEOUT %s: must be longer than every request timeout, or 0", c.HTTP.WriteTimeout)
+		}
+	}
+
+	return nil
+}
+
+// Addr is the address the server listens on.
+func (c Config) Addr() string {
+	return ":" + strconv.Itoa(c.Port)
+}
+
+// Open opens the database with the pool limits of c and checks that it can
+// be reached.
+func (c DB) Open() (*sql.DB, error) {
+	conn, err := sql.Open("mysql", c.DataSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	conn.SetMaxOpenConns(c.MaxOpenConns)
+	conn.SetMaxIdleConns(c.MaxIdleConns)
+	conn.SetConnMaxLifetime(c.ConnMaxLifetime)
+	conn.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.ConnectTimeout)
+	defer cancel()
+
+	if err := conn.PingContext(ctx); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return conn, nil
+}
+
+func parseInt(dst *int, name, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: must be an integer", name, value)
+	}
+	*dst = n
+	return nil
+}
+
+func parseDuration(dst *time.Duration, name, value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return fmt.Errorf("invalid %s %q: must be a duration such as 30s", name, value)
+	}
+	*dst = d
+	return nil
+}
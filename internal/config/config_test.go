@@ -0,0 +1,111 @@
+package config_test
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const dataSource = "root:root@tcp(localhost:3306)/mercado_fresco"
+
+func load(args ...string) (config.Config, error) {
+	cfg, _, err := loadArgs(args...)
+	return cfg, err
+}
+
+func loadArgs(args ...string) (config.Config, []string, error) {
+	flags := flag.NewFlagSet("server", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	cfg, err := config.Load(flags, args)
+	return cfg, flags.Args(), err
+}
+
+// chdir runs the test in an empty directory, so no .env file is found.
+func chdir(t *testing.T) string {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(wd) })
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("defaults without .env file", func(t *testing.T) {
+		chdir(t)
+		t.Setenv("SERVER_URI", dataSource)
+
+		cfg, err := load()
+
+		assert.NoError(t, err)
+		assert.Equal(t, dataSource, cfg.DB.DataSource)
+		assert.Equal(t, ":8080", cfg.Addr())
+		assert.Equal(t, "debug", cfg.GinMode)
+		assert.False(t, cfg.AutoMigrate)
+		assert.Equal(t, 25, cfg.DB.MaxOpenConns)
+		assert.Equal(t, 5, cfg.DB.MaxIdleConns)
+		assert.Equal(t, 5*time.Minute, cfg.DB.ConnMaxLifetime)
+		assert.Equal(t, 30*time.Second, cfg.HTTP.Requests.Default)
+		assert.Empty(t, cfg.HTTP.Requests.Routes)
+	})
+
+	t.Run("environment overrides .env and flags override environment", func(t *testing.T) {
+		dir := chdir(t)
+		err := os.WriteFile(filepath.Join(dir, ".env"), []byte("SERVER_URI="+dataSource+"\nPORT=8081\nGIN_MODE=release\nDB_MAX_IDLE_CONNS=2\n"), 0o600)
+		assert.NoError(t, err)
+		t.Setenv("PORT", "8082")
+		t.Setenv("DB_MAX_IDLE_CONNS", "3")
+
+		cfg, args, err := loadArgs("-db-max-idle-conns", "4", "-route-timeouts", "POST /api/v1/imports/:entity=5m", "migrate", "up")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"migrate", "up"}, args)
+		assert.Equal(t, dataSource, cfg.DB.DataSource)
+		assert.Equal(t, "release", cfg.GinMode)
+		assert.Equal(t, 8082, cfg.Port)
+		assert.Equal(t, 4, cfg.DB.MaxIdleConns)
+		assert.Equal(t, 5*time.Minute, cfg.HTTP.Requests.For("POST", "/api/v1/imports/:entity"))
+	})
+
+	t.Run("a missing env file fails when it is given", func(t *testing.T) {
+		chdir(t)
+		t.Setenv("SERVER_URI", dataSource)
+
+		_, err := load("-env-file", "prod.env")
+
+		assert.ErrorContains(t, err, "invalid env file prod.env")
+	})
+
+	t.Run("rejects invalid settings", func(t *testing.T) {
+		chdir(t)
+
+		for env, expected := range map[string]string{
+			"SERVER_URI":                           "SERVER_URI is required, e.g. user:pass@tcp(localhost:3306)/mercado_fresco",
+			"SERVER_URI=root@tcp(localhost:3306)/": "invalid SERVER_URI: it must name the database, e.g. user:pass@tcp(localhost:3306)/mercado_fresco",
+			"PORT=http":                            `invalid PORT "http": must be an integer`,
+			"PORT=70000":                           "invalid PORT 70000: must be between 1 and 65535",
+			"GIN_MODE=prod":                        `invalid GIN_MODE "prod": must be debug, release or test`,
+			"AUTO_MIGRATE=yes":                     `invalid AUTO_MIGRATE "yes": must be true or false`,
+			"DB_MAX_IDLE_CONNS=30":                 "invalid DB_MAX_IDLE_CONNS 30: must not exceed DB_MAX_OPEN_CONNS 25",
+			"DB_CONN_MAX_LIFETIME=-1s":             `invalid DB_CONN_MAX_LIFETIME "-1s": must be a duration such as 30s`,
+			"REQUEST_TIMEOUT=soon":                 "invalid request timeout soon",
+			"HTTP_WRITE_TIMEOUT=10s":               "invalid HTTP_WRITE_TIMEOUT 10s: must be longer than every request timeout, or 0",
+		} {
+			t.Run(env, func(t *testing.T) {
+				t.Setenv("SERVER_URI", dataSource)
+				name, value, _ := strings.Cut(env, "=")
+				t.Setenv(name, value)
+
+				_, err := load()
+				assert.EqualError(t, err, expected)
+			})
+		}
+	})
+}
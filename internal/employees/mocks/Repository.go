@@ -3,7 +3,11 @@
 package mocks
 
 import (
+	context "context"
+
 	employees "github.com/emidioreb/mercado-fresco-lerigophers/internal/employees"
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -12,20 +16,20 @@ type Repository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: cardNumber, firstName, lastName, warehouseId
-func (_m *Repository) Create(cardNumber string, firstName string, lastName string, warehouseId int) (employees.Employee, error) {
-	ret := _m.Called(cardNumber, firstName, lastName, warehouseId)
+// Create provides a mock function with given fields: ctx, cardNumber, firstName, lastName, warehouseId
+func (_m *Repository) Create(ctx context.Context, cardNumber string, firstName string, lastName string, warehouseId int) (employees.Employee, error) {
+	ret := _m.Called(ctx, cardNumber, firstName, lastName, warehouseId)
 
 	var r0 employees.Employee
-	if rf, ok := ret.Get(0).(func(string, string, string, int) employees.Employee); ok {
-		r0 = rf(cardNumber, firstName, lastName, warehouseId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int) employees.Employee); ok {
+		r0 = rf(ctx, cardNumber, firstName, lastName, warehouseId)
 	} else {
 		r0 = ret.Get(0).(employees.Employee)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, int) error); ok {
-		r1 = rf(cardNumber, firstName, lastName, warehouseId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int) error); ok {
+		r1 = rf(ctx, cardNumber, firstName, lastName, warehouseId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,13 +37,13 @@ func (_m *Repository) Create(cardNumber string, firstName string, lastName strin
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Repository) Delete(id int) error {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Repository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,13 +51,13 @@ func (_m *Repository) Delete(id int) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *Repository) GetAll() ([]employees.Employee, error) {
-	ret := _m.Called()
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Repository) GetAll(ctx context.Context, opts listing.Options) ([]employees.Employee, error) {
+	ret := _m.Called(ctx, opts)
 
 	var r0 []employees.Employee
-	if rf, ok := ret.Get(0).(func() []employees.Employee); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []employees.Employee); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]employees.Employee)
@@ -61,8 +65,8 @@ func (_m *Repository) GetAll() ([]employees.Employee, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) error); ok {
+		r1 = rf(ctx, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,20 +74,20 @@ func (_m *Repository) GetAll() ([]employees.Employee, error) {
 	return r0, r1
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Repository) GetOne(id int) (employees.Employee, error) {
-	ret := _m.Called(id)
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Repository) GetOne(ctx context.Context, id int) (employees.Employee, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 employees.Employee
-	if rf, ok := ret.Get(0).(func(int) employees.Employee); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) employees.Employee); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(employees.Employee)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -91,13 +95,13 @@ func (_m *Repository) GetOne(id int) (employees.Employee, error) {
 	return r0, r1
 }
 
-// GetOneByCardNumber provides a mock function with given fields: id, cardNumber
-func (_m *Repository) GetOneByCardNumber(id int, cardNumber string) error {
-	ret := _m.Called(id, cardNumber)
+// GetOneByCardNumber provides a mock function with given fields: ctx, id, cardNumber
+func (_m *Repository) GetOneByCardNumber(ctx context.Context, id int, cardNumber string) error {
+	ret := _m.Called(ctx, id, cardNumber)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string) error); ok {
-		r0 = rf(id, cardNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, id, cardNumber)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -105,20 +109,20 @@ func (_m *Repository) GetOneByCardNumber(id int, cardNumber string) error {
 	return r0
 }
 
-// Update provides a mock function with given fields: id, requestData
-func (_m *Repository) Update(id int, requestData map[string]interface{}) (employees.Employee, error) {
-	ret := _m.Called(id, requestData)
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Repository) Update(ctx context.Context, id int, requestData map[string]interface{}) (employees.Employee, error) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 employees.Employee
-	if rf, ok := ret.Get(0).(func(int, map[string]interface{}) employees.Employee); ok {
-		r0 = rf(id, requestData)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) employees.Employee); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(employees.Employee)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int, map[string]interface{}) error); ok {
-		r1 = rf(id, requestData)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) error); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,7 +3,11 @@
 package mocks
 
 import (
+	context "context"
+
 	employees "github.com/emidioreb/mercado-fresco-lerigophers/internal/employees"
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+
 	mock "github.com/stretchr/testify/mock"
 
 	web "github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
@@ -14,20 +18,20 @@ type Service struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: cardNumber, firstName, lastName, warehouseId
-func (_m *Service) Create(cardNumber string, firstName string, lastName string, warehouseId int) (employees.Employee, web.ResponseCode) {
-	ret := _m.Called(cardNumber, firstName, lastName, warehouseId)
+// Create provides a mock function with given fields: ctx, cardNumber, firstName, lastName, warehouseId
+func (_m *Service) Create(ctx context.Context, cardNumber string, firstName string, lastName string, warehouseId int) (employees.Employee, web.ResponseCode) {
+	ret := _m.Called(ctx, cardNumber, firstName, lastName, warehouseId)
 
 	var r0 employees.Employee
-	if rf, ok := ret.Get(0).(func(string, string, string, int) employees.Employee); ok {
-		r0 = rf(cardNumber, firstName, lastName, warehouseId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int) employees.Employee); ok {
+		r0 = rf(ctx, cardNumber, firstName, lastName, warehouseId)
 	} else {
 		r0 = ret.Get(0).(employees.Employee)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(string, string, string, int) web.ResponseCode); ok {
-		r1 = rf(cardNumber, firstName, lastName, warehouseId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int) web.ResponseCode); ok {
+		r1 = rf(ctx, cardNumber, firstName, lastName, warehouseId)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -35,13 +39,13 @@ func (_m *Service) Create(cardNumber string, firstName string, lastName string,
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Service) Delete(id int) web.ResponseCode {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Service) Delete(ctx context.Context, id int) web.ResponseCode {
+	ret := _m.Called(ctx, id)
 
 	var r0 web.ResponseCode
-	if rf, ok := ret.Get(0).(func(int) web.ResponseCode); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) web.ResponseCode); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(web.ResponseCode)
 	}
@@ -49,43 +53,50 @@ func (_m *Service) Delete(id int) web.ResponseCode {
 	return r0
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *Service) GetAll() ([]employees.Employee, web.ResponseCode) {
-	ret := _m.Called()
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Service) GetAll(ctx context.Context, opts listing.Options) ([]employees.Employee, listing.Page, web.ResponseCode) {
+	ret := _m.Called(ctx, opts)
 
 	var r0 []employees.Employee
-	if rf, ok := ret.Get(0).(func() []employees.Employee); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []employees.Employee); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]employees.Employee)
 		}
 	}
 
-	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func() web.ResponseCode); ok {
-		r1 = rf()
+	var r1 listing.Page
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) listing.Page); ok {
+		r1 = rf(ctx, opts)
 	} else {
-		r1 = ret.Get(1).(web.ResponseCode)
+		r1 = ret.Get(1).(listing.Page)
 	}
 
-	return r0, r1
+	var r2 web.ResponseCode
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Options) web.ResponseCode); ok {
+		r2 = rf(ctx, opts)
+	} else {
+		r2 = ret.Get(2).(web.ResponseCode)
+	}
+
+	return r0, r1, r2
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Service) GetOne(id int) (employees.Employee, web.ResponseCode) {
-	ret := _m.Called(id)
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Service) GetOne(ctx context.Context, id int) (employees.Employee, web.ResponseCode) {
+	ret := _m.Called(ctx, id)
 
 	var r0 employees.Employee
-	if rf, ok := ret.Get(0).(func(int) employees.Employee); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) employees.Employee); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(employees.Employee)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int) web.ResponseCode); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -93,20 +104,20 @@ func (_m *Service) GetOne(id int) (employees.Employee, web.ResponseCode) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, requestData
-func (_m *Service) Update(id int, requestData map[string]interface{}) (employees.Employee, web.ResponseCode) {
-	ret := _m.Called(id, requestData)
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Service) Update(ctx context.Context, id int, requestData map[string]interface{}) (employees.Employee, web.ResponseCode) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 employees.Employee
-	if rf, ok := ret.Get(0).(func(int, map[string]interface{}) employees.Employee); ok {
-		r0 = rf(id, requestData)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) employees.Employee); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(employees.Employee)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int, map[string]interface{}) web.ResponseCode); ok {
-		r1 = rf(id, requestData)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) web.ResponseCode); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
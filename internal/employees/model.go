@@ -1,5 +1,25 @@
 package employees
 
+import (
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/document"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+)
+
+// AcceptedDocuments are the documents an employee may be registered with as card_number_id.
+var AcceptedDocuments = []document.Type{document.CPF}
+
+// ListFields are the fields employees can be filtered and sorted by.
+var ListFields = listing.Fields{
+	Columns: map[string]string{
+		"id":             "id",
+		"card_number_id": "card_number_id",
+		"first_name":     "first_name",
+		"last_name":      "last_name",
+		"warehouse_id":   "warehouse_id",
+	},
+	Sortable: []string{"card_number_id", "first_name", "last_name", "warehouse_id"},
+}
+
 type Employee struct {
 	Id           int    `json:"id"`
 	CardNumberId string `json:"card_number_id"`
@@ -1,9 +1,13 @@
 package employees
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 )
 
 var (
@@ -15,25 +19,25 @@ var (
 )
 
 type Repository interface {
-	Create(cardNumber, firstName, lastName string, warehouseId int) (Employee, error)
-	GetOne(id int) (Employee, error)
-	GetAll() ([]Employee, error)
-	Delete(id int) error
-	Update(id int, requestData map[string]interface{}) (Employee, error)
-	GetOneByCardNumber(id int, cardNumber string) error
+	Create(ctx context.Context, cardNumber, firstName, lastName string, warehouseId int) (Employee, error)
+	GetOne(ctx context.Context, id int) (Employee, error)
+	GetAll(ctx context.Context, opts listing.Options) ([]Employee, error)
+	Delete(ctx context.Context, id int) error
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Employee, error)
+	GetOneByCardNumber(ctx context.Context, id int, cardNumber string) error
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
-func (mariaDb mariaDbRepository) Create(cardNumber, firstName, lastName string, warehouseId int) (Employee, error) {
+func (mariaDb mariaDbRepository) Create(ctx context.Context, cardNumber, firstName, lastName string, warehouseId int) (Employee, error) {
 
 	newEmployee := Employee{
 		CardNumberId: cardNumber,
@@ -42,7 +46,8 @@ func (mariaDb mariaDbRepository) Create(cardNumber, firstName, lastName string,
 		WarehouseId:  warehouseId,
 	}
 
-	result, err := mariaDb.db.Exec(
+	result, err := mariaDb.db.ExecContext(
+		ctx,
 		queryCreate,
 		cardNumber,
 		firstName,
@@ -64,11 +69,11 @@ func (mariaDb mariaDbRepository) Create(cardNumber, firstName, lastName string,
 	return newEmployee, nil
 }
 
-func (mariaDb mariaDbRepository) GetOne(id int) (Employee, error) {
+func (mariaDb mariaDbRepository) GetOne(ctx context.Context, id int) (Employee, error) {
 
 	currentEmployee := Employee{}
 
-	row := mariaDb.db.QueryRow(queryGetOne, id)
+	row := mariaDb.db.QueryRowContext(ctx, transaction.Lock(ctx, queryGetOne), id)
 	err := row.Scan(
 		&currentEmployee.Id,
 		&currentEmployee.CardNumberId,
@@ -88,17 +93,17 @@ func (mariaDb mariaDbRepository) GetOne(id int) (Employee, error) {
 	return currentEmployee, nil
 }
 
-func (mariaDb mariaDbRepository) GetOneByCardNumber(id int, cardNumber string) error {
+func (mariaDb mariaDbRepository) GetOneByCardNumber(ctx context.Context, id int, cardNumber string) error {
 	var err error
 	var currentEmployeeId int
 
 	if id != 0 {
-		row := mariaDb.db.QueryRow(queryByCardNumberUpdate, cardNumber, id)
+		row := mariaDb.db.QueryRowContext(ctx, queryByCardNumberUpdate, cardNumber, id)
 		err = row.Scan(
 			&currentEmployeeId,
 		)
 	} else {
-		row := mariaDb.db.QueryRow(queryByCardNumberCreate, cardNumber)
+		row := mariaDb.db.QueryRowContext(ctx, queryByCardNumberCreate, cardNumber)
 		err = row.Scan(
 			&currentEmployeeId,
 		)
@@ -119,11 +124,12 @@ func (mariaDb mariaDbRepository) GetOneByCardNumber(id int, cardNumber string) e
 	return nil
 }
 
-func (mariaDb mariaDbRepository) GetAll() ([]Employee, error) {
+func (mariaDb mariaDbRepository) GetAll(ctx context.Context, opts listing.Options) ([]Employee, error) {
 
 	employees := []Employee{}
 
-	rows, err := mariaDb.db.Query(queryGetAll)
+	query, values := listing.Build(queryGetAll, ListFields, opts)
+	rows, err := mariaDb.db.QueryContext(ctx, query, values...)
 	if err != nil {
 		return []Employee{}, errGetEmployees
 	}
@@ -141,12 +147,16 @@ func (mariaDb mariaDbRepository) GetAll() ([]Employee, error) {
 		}
 		employees = append(employees, currentEmployee)
 	}
+
+	if err := rows.Err(); err != nil {
+		return []Employee{}, errGetEmployees
+	}
 	return employees, nil
 }
 
-func (mariaDb mariaDbRepository) Delete(id int) error {
+func (mariaDb mariaDbRepository) Delete(ctx context.Context, id int) error {
 
-	result, err := mariaDb.db.Exec(queryDelete, id)
+	result, err := mariaDb.db.ExecContext(ctx, queryDelete, id)
 	if err != nil {
 		return errDeleteEmployee
 	}
@@ -163,11 +173,11 @@ func (mariaDb mariaDbRepository) Delete(id int) error {
 	return nil
 }
 
-func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface{}) (Employee, error) {
+func (mariaDb mariaDbRepository) Update(ctx context.Context, id int, requestData map[string]interface{}) (Employee, error) {
 
 	finalQuery, valuesToUse := queryUpdate(requestData, id)
 
-	result, err := mariaDb.db.Exec(finalQuery, valuesToUse...)
+	result, err := mariaDb.db.ExecContext(ctx, finalQuery, valuesToUse...)
 	if err != nil {
 		return Employee{}, errUpdatedEmployee
 	}
@@ -177,7 +187,7 @@ func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface
 		return Employee{}, errUpdatedEmployee
 	}
 
-	currentEmployee, err := mariaDb.GetOne(id)
+	currentEmployee, err := mariaDb.GetOne(ctx, id)
 	if err != nil {
 		return Employee{}, errUpdatedEmployee
 	}
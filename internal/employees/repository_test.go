@@ -1,6 +1,7 @@
 package employees
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"regexp"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 )
 
 func TestRepositoryCreate(t *testing.T) {
@@ -27,6 +30,7 @@ func TestRepositoryCreate(t *testing.T) {
 		employeesRepo := NewMariaDbRepository(db)
 
 		employee, err := employeesRepo.Create(
+			context.Background(),
 			"365",
 			"Iuri",
 			"Caliman",
@@ -45,7 +49,7 @@ func TestRepositoryCreate(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryCreate)).WillReturnError(errors.New("ocurred an error to create employee"))
 		employeesRepo := NewMariaDbRepository(db)
 
-		_, err = employeesRepo.Create("", "", "", 0)
+		_, err = employeesRepo.Create(context.Background(), "", "", "", 0)
 		assert.Error(t, err)
 	})
 
@@ -60,7 +64,7 @@ func TestRepositoryCreate(t *testing.T) {
 			WillReturnResult(sqlDriverResultErr)
 
 		employeesRepo := NewMariaDbRepository(db)
-		_, err = employeesRepo.Create("", "", "", 0)
+		_, err = employeesRepo.Create(context.Background(), "", "", "", 0)
 
 		assert.Error(t, err)
 		assert.Equal(t, "ocurred an error to create employee", err.Error())
@@ -85,7 +89,7 @@ func TestRepositoryGetOne(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOne)).WillReturnRows(rows)
 
 		employeesRepo := NewMariaDbRepository(db)
-		employee, err := employeesRepo.GetOne(1)
+		employee, err := employeesRepo.GetOne(context.Background(), 1)
 		assert.NoError(t, err)
 
 		assert.Equal(t, Employee{1, "465", "Iuri", "Caliman", 1}, employee)
@@ -99,7 +103,7 @@ func TestRepositoryGetOne(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOne)).WillReturnError(sql.ErrNoRows)
 
 		employeesRepo := NewMariaDbRepository(db)
-		_, err = employeesRepo.GetOne(1)
+		_, err = employeesRepo.GetOne(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "employee with id 1 not found", err.Error())
 	})
@@ -113,7 +117,7 @@ func TestRepositoryGetOne(t *testing.T) {
 			WillReturnError(errors.New("unexpected error to get employee"))
 
 		employeesRepo := NewMariaDbRepository(db)
-		_, err = employeesRepo.GetOne(1)
+		_, err = employeesRepo.GetOne(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "unexpected error to get employee", err.Error())
 	})
@@ -139,7 +143,7 @@ func TestRepositoryGetAll(t *testing.T) {
 
 		employeesRepo := NewMariaDbRepository(db)
 
-		employeesReports, err := employeesRepo.GetAll()
+		employeesReports, err := employeesRepo.GetAll(context.Background(), listing.Options{})
 		assert.NoError(t, err)
 
 		assert.Len(t, employeesReports, 3)
@@ -166,7 +170,7 @@ func TestRepositoryGetAll(t *testing.T) {
 
 		employeesRepo := NewMariaDbRepository(db)
 
-		_, err = employeesRepo.GetAll()
+		_, err = employeesRepo.GetAll(context.Background(), listing.Options{})
 		assert.Error(t, err)
 		assert.Equal(t, "couldn't get employees", err.Error())
 	})
@@ -181,7 +185,7 @@ func TestRepositoryGetAll(t *testing.T) {
 
 		employeesRepo := NewMariaDbRepository(db)
 
-		_, err = employeesRepo.GetAll()
+		_, err = employeesRepo.GetAll(context.Background(), listing.Options{})
 		assert.Error(t, err)
 	})
 }
@@ -197,7 +201,7 @@ func TestRepositoryDelete(t *testing.T) {
 			WillReturnResult(affectedRowsResult)
 
 		sellersRepo := NewMariaDbRepository(db)
-		err = sellersRepo.Delete(1)
+		err = sellersRepo.Delete(context.Background(), 1)
 		assert.NoError(t, err)
 	})
 
@@ -211,7 +215,7 @@ func TestRepositoryDelete(t *testing.T) {
 			WillReturnResult(affectedRowsResult)
 
 		employeesRepo := NewMariaDbRepository(db)
-		err = employeesRepo.Delete(1)
+		err = employeesRepo.Delete(context.Background(), 1)
 		assert.NotNil(t, err)
 		assert.Equal(t, "employee with id 1 not found", err.Error())
 	})
@@ -225,7 +229,7 @@ func TestRepositoryDelete(t *testing.T) {
 			WillReturnError(errors.New("unexpected error to delete employee"))
 
 		employeesRepo := NewMariaDbRepository(db)
-		err = employeesRepo.Delete(1)
+		err = employeesRepo.Delete(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "unexpected error to delete employee", err.Error())
 	})
@@ -242,7 +246,7 @@ func TestRepositoryDelete(t *testing.T) {
 			WillReturnResult(resultErr)
 
 		sellersRepo := NewMariaDbRepository(db)
-		err = sellersRepo.Delete(1)
+		err = sellersRepo.Delete(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "unexpected error to delete employee", err.Error())
 	})
@@ -262,7 +266,7 @@ func TestRepositoryGetOneByCardNumber(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryByCardNumberCreate)).WillReturnRows(rows)
 
 		employeesRepo := NewMariaDbRepository(db)
-		errGetOne := employeesRepo.GetOneByCardNumber(0, "465")
+		errGetOne := employeesRepo.GetOneByCardNumber(context.Background(), 0, "465")
 		assert.NoError(t, errGetOne)
 	})
 
@@ -279,7 +283,7 @@ func TestRepositoryGetOneByCardNumber(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryByCardNumberCreate)).WillReturnRows(rows)
 
 		employeesRepo := NewMariaDbRepository(db)
-		errGetOne := employeesRepo.GetOneByCardNumber(1, "465")
+		errGetOne := employeesRepo.GetOneByCardNumber(context.Background(), 1, "465")
 		assert.NoError(t, errGetOne)
 	})
 
@@ -291,7 +295,7 @@ func TestRepositoryGetOneByCardNumber(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryByCardNumberCreate)).WillReturnError(errors.New("unexpected error to get employee"))
 
 		employeesRepo := NewMariaDbRepository(db)
-		errGetOne := employeesRepo.GetOneByCardNumber(1, "465")
+		errGetOne := employeesRepo.GetOneByCardNumber(context.Background(), 1, "465")
 		assert.Error(t, errGetOne)
 		assert.Equal(t, errors.New("unexpected error to get employee"), errGetOne)
 	})
@@ -309,7 +313,7 @@ func TestRepositoryGetOneByCardNumber(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryByCardNumberCreate)).WillReturnRows(rows)
 
 		employeesRepo := NewMariaDbRepository(db)
-		errGetOne := employeesRepo.GetOneByCardNumber(1, "465")
+		errGetOne := employeesRepo.GetOneByCardNumber(context.Background(), 1, "465")
 		assert.Error(t, errGetOne)
 		assert.Equal(t, errors.New("card_number_id already exists"), errGetOne)
 	})
@@ -359,7 +363,7 @@ func TestRepositoryUpdate(t *testing.T) {
 
 		employeesRepo := NewMariaDbRepository(db)
 
-		employee, err := employeesRepo.Update(1, requestData)
+		employee, err := employeesRepo.Update(context.Background(), 1, requestData)
 		assert.Nil(t, err)
 
 		assert.Equal(t, Employee{1, "456", "Iuri", "Caliman", 1}, employee)
@@ -375,7 +379,7 @@ func TestRepositoryUpdate(t *testing.T) {
 
 		employeesRepo := NewMariaDbRepository(db)
 
-		_, err = employeesRepo.Update(1, requestData)
+		_, err = employeesRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errUpdatedEmployee, err)
 	})
@@ -393,7 +397,7 @@ func TestRepositoryUpdate(t *testing.T) {
 
 		employeesRepo := NewMariaDbRepository(db)
 
-		_, err = employeesRepo.Update(1, requestData)
+		_, err = employeesRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errUpdatedEmployee, err)
 	})
@@ -413,7 +417,7 @@ func TestRepositoryUpdate(t *testing.T) {
 
 		sellersRepo := NewMariaDbRepository(db)
 
-		_, err = sellersRepo.Update(1, requestData)
+		_, err = sellersRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errUpdatedEmployee, err)
 	})
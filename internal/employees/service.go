@@ -1,20 +1,25 @@
 package employees
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/document"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 )
 
+var errInvalidCardNumberId = fmt.Errorf("card_number_id must be a valid %s", document.Describe(AcceptedDocuments))
+
 type Service interface {
-	Create(cardNumber, firstName, lastName string, warehouseId int) (Employee, web.ResponseCode)
-	GetOne(id int) (Employee, web.ResponseCode)
-	GetAll() ([]Employee, web.ResponseCode)
-	Delete(id int) web.ResponseCode
-	Update(id int, requestData map[string]interface{}) (Employee, web.ResponseCode)
+	Create(ctx context.Context, cardNumber, firstName, lastName string, warehouseId int) (Employee, web.ResponseCode)
+	GetOne(ctx context.Context, id int) (Employee, web.ResponseCode)
+	GetAll(ctx context.Context, opts listing.Options) ([]Employee, listing.Page, web.ResponseCode)
+	Delete(ctx context.Context, id int) web.ResponseCode
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Employee, web.ResponseCode)
 }
 
 type service struct {
@@ -29,9 +34,14 @@ func NewService(r Repository, w warehouses.Repository) Service {
 	}
 }
 
-func (s service) Create(cardNumber string, firstName, lastName string, warehouseId int) (Employee, web.ResponseCode) {
+func (s service) Create(ctx context.Context, cardNumber string, firstName, lastName string, warehouseId int) (Employee, web.ResponseCode) {
+	doc, err := document.Parse(cardNumber, AcceptedDocuments...)
+	if err != nil {
+		return Employee{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidCardNumberId)
+	}
+	cardNumber = doc.Number
 
-	errGetByCardNumber := s.repository.GetOneByCardNumber(0, cardNumber)
+	errGetByCardNumber := s.repository.GetOneByCardNumber(ctx, 0, cardNumber)
 
 	if errGetByCardNumber != nil {
 		if errGetByCardNumber.Error() == "card_number_id already exists" {
@@ -40,7 +50,7 @@ func (s service) Create(cardNumber string, firstName, lastName string, warehouse
 		return Employee{}, web.NewCodeResponse(http.StatusInternalServerError, errGetByCardNumber)
 	}
 
-	_, warehouseErr := s.warehouseRepo.GetOne(warehouseId)
+	_, warehouseErr := s.warehouseRepo.GetOne(ctx, warehouseId)
 	if warehouseErr != nil {
 		if warehouseErr.Error() == fmt.Sprintf("warehouse with id %d not found", warehouseId) {
 			return Employee{}, web.NewCodeResponse(http.StatusConflict, warehouseErr)
@@ -48,7 +58,7 @@ func (s service) Create(cardNumber string, firstName, lastName string, warehouse
 		return Employee{}, web.NewCodeResponse(http.StatusInternalServerError, warehouseErr)
 	}
 
-	employee, errCreate := s.repository.Create(cardNumber, firstName, lastName, warehouseId)
+	employee, errCreate := s.repository.Create(ctx, cardNumber, firstName, lastName, warehouseId)
 
 	if errCreate != nil {
 		return Employee{}, web.NewCodeResponse(http.StatusInternalServerError, errCreate)
@@ -57,8 +67,8 @@ func (s service) Create(cardNumber string, firstName, lastName string, warehouse
 	return employee, web.NewCodeResponse(http.StatusCreated, nil)
 }
 
-func (s service) GetOne(id int) (Employee, web.ResponseCode) {
-	employee, err := s.repository.GetOne(id)
+func (s service) GetOne(ctx context.Context, id int) (Employee, web.ResponseCode) {
+	employee, err := s.repository.GetOne(ctx, id)
 
 	if err != nil {
 		if err.Error() == fmt.Sprintf("employee with id %d not found", id) {
@@ -69,18 +79,19 @@ func (s service) GetOne(id int) (Employee, web.ResponseCode) {
 	return employee, web.NewCodeResponse(http.StatusOK, nil)
 }
 
-func (s service) GetAll() ([]Employee, web.ResponseCode) {
-	employees, err := s.repository.GetAll()
+func (s service) GetAll(ctx context.Context, opts listing.Options) ([]Employee, listing.Page, web.ResponseCode) {
+	employees, err := s.repository.GetAll(ctx, opts)
 
 	if err != nil {
-		return []Employee{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+		return []Employee{}, listing.Page{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
 
-	return employees, web.NewCodeResponse(http.StatusOK, err)
+	employees, page := listing.Paginate(employees, opts)
+	return employees, page, web.NewCodeResponse(http.StatusOK, err)
 }
 
-func (s service) Delete(id int) web.ResponseCode {
-	err := s.repository.Delete(id)
+func (s service) Delete(ctx context.Context, id int) web.ResponseCode {
+	err := s.repository.Delete(ctx, id)
 
 	if err != nil {
 		if err.Error() == fmt.Sprintf("employee with id %d not found", id) {
@@ -91,8 +102,8 @@ func (s service) Delete(id int) web.ResponseCode {
 	return web.NewCodeResponse(http.StatusNoContent, nil)
 }
 
-func (s service) Update(id int, requestData map[string]interface{}) (Employee, web.ResponseCode) {
-	_, responseCode := s.GetOne(id)
+func (s service) Update(ctx context.Context, id int, requestData map[string]interface{}) (Employee, web.ResponseCode) {
+	_, responseCode := s.GetOne(ctx, id)
 
 	cardNumberReqData := requestData["card_number_id"]
 
@@ -101,7 +112,13 @@ func (s service) Update(id int, requestData map[string]interface{}) (Employee, w
 	}
 
 	if cardNumberReqData != nil {
-		errGetByCardNumber := s.repository.GetOneByCardNumber(id, cardNumberReqData.(string))
+		doc, err := document.Parse(cardNumberReqData.(string), AcceptedDocuments...)
+		if err != nil {
+			return Employee{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidCardNumberId)
+		}
+		requestData["card_number_id"] = doc.Number
+
+		errGetByCardNumber := s.repository.GetOneByCardNumber(ctx, id, doc.Number)
 		if errGetByCardNumber != nil {
 			if errGetByCardNumber.Error() == "card_number_id already exists" {
 				return Employee{}, web.NewCodeResponse(http.StatusConflict, errGetByCardNumber)
@@ -112,7 +129,7 @@ func (s service) Update(id int, requestData map[string]interface{}) (Employee, w
 
 	if requestData["warehouse_id"] != nil {
 		if int(requestData["warehouse_id"].(float64)) != 0 {
-			_, warehouseErr := s.warehouseRepo.GetOne(int(requestData["warehouse_id"].(float64)))
+			_, warehouseErr := s.warehouseRepo.GetOne(ctx, int(requestData["warehouse_id"].(float64)))
 			if warehouseErr != nil {
 				if warehouseErr.Error() == fmt.Sprintf("warehouse with id %d not found", int(requestData["warehouse_id"].(float64))) {
 					return Employee{}, web.NewCodeResponse(http.StatusNotFound, warehouseErr)
@@ -122,7 +139,7 @@ func (s service) Update(id int, requestData map[string]interface{}) (Employee, w
 		}
 	}
 
-	employee, err := s.repository.Update(id, requestData)
+	employee, err := s.repository.Update(ctx, id, requestData)
 	if err != nil {
 		return Employee{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
@@ -1,6 +1,7 @@
 package employees_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/employees/mocks"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses"
 	mockWarehouseRepository "github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -21,15 +23,16 @@ func TestServiceCreate(t *testing.T) {
 
 		input := employees.Employee{
 			Id:           1,
-			CardNumberId: "123",
+			CardNumberId: "52998224725",
 			FirstName:    "",
 			LastName:     "",
 			WarehouseId:  1,
 		}
 
-		mockedRepository.On("GetOneByCardNumber", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
-		mockedWarehouse.On("GetOne", mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, nil).Once()
+		mockedRepository.On("GetOneByCardNumber", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
+		mockedWarehouse.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, nil).Once()
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -38,7 +41,7 @@ func TestServiceCreate(t *testing.T) {
 
 		service := employees.NewService(mockedRepository, mockedWarehouse)
 
-		result, err := service.Create(input.CardNumberId, input.FirstName, input.LastName, input.WarehouseId)
+		result, err := service.Create(context.Background(), input.CardNumberId, input.FirstName, input.LastName, input.WarehouseId)
 		assert.Nil(t, err.Err)
 
 		assert.Equal(t, input, result)
@@ -51,7 +54,7 @@ func TestServiceCreate(t *testing.T) {
 
 		input := employees.Employee{
 			Id:           1,
-			CardNumberId: "123",
+			CardNumberId: "52998224725",
 			FirstName:    "",
 			LastName:     "",
 			WarehouseId:  1,
@@ -59,8 +62,9 @@ func TestServiceCreate(t *testing.T) {
 
 		errEmployeeCarId := errors.New("card_number_id already exists")
 
-		mockedRepository.On("GetOneByCardNumber", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(errEmployeeCarId)
+		mockedRepository.On("GetOneByCardNumber", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(errEmployeeCarId)
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -69,7 +73,7 @@ func TestServiceCreate(t *testing.T) {
 
 		service := employees.NewService(mockedRepository, mockedWarehouse)
 
-		_, err := service.Create(input.CardNumberId, input.FirstName, input.LastName, input.WarehouseId)
+		_, err := service.Create(context.Background(), input.CardNumberId, input.FirstName, input.LastName, input.WarehouseId)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, err.Err.Error(), errEmployeeCarId.Error())
@@ -81,7 +85,7 @@ func TestServiceCreate(t *testing.T) {
 
 		input := employees.Employee{
 			Id:           1,
-			CardNumberId: "123",
+			CardNumberId: "52998224725",
 			FirstName:    "",
 			LastName:     "",
 			WarehouseId:  1,
@@ -89,8 +93,9 @@ func TestServiceCreate(t *testing.T) {
 
 		errEmployeeCarId := errors.New("any error")
 
-		mockedRepository.On("GetOneByCardNumber", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(errEmployeeCarId)
+		mockedRepository.On("GetOneByCardNumber", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(errEmployeeCarId)
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -99,7 +104,7 @@ func TestServiceCreate(t *testing.T) {
 
 		service := employees.NewService(mockedRepository, mockedWarehouse)
 
-		_, err := service.Create(input.CardNumberId, input.FirstName, input.LastName, input.WarehouseId)
+		_, err := service.Create(context.Background(), input.CardNumberId, input.FirstName, input.LastName, input.WarehouseId)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, err.Err.Error(), errEmployeeCarId.Error())
@@ -111,7 +116,7 @@ func TestServiceCreate(t *testing.T) {
 
 		input := employees.Employee{
 			Id:           1,
-			CardNumberId: "123",
+			CardNumberId: "52998224725",
 			FirstName:    "",
 			LastName:     "",
 			WarehouseId:  1,
@@ -119,9 +124,10 @@ func TestServiceCreate(t *testing.T) {
 
 		errWarehouseId := errors.New("any error")
 
-		mockedRepository.On("GetOneByCardNumber", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
-		mockedWarehouse.On("GetOne", mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, errWarehouseId).Once()
+		mockedRepository.On("GetOneByCardNumber", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
+		mockedWarehouse.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, errWarehouseId).Once()
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -130,7 +136,7 @@ func TestServiceCreate(t *testing.T) {
 
 		service := employees.NewService(mockedRepository, mockedWarehouse)
 
-		_, err := service.Create(input.CardNumberId, input.FirstName, input.LastName, input.WarehouseId)
+		_, err := service.Create(context.Background(), input.CardNumberId, input.FirstName, input.LastName, input.WarehouseId)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, err.Err.Error(), errWarehouseId.Error())
@@ -142,7 +148,7 @@ func TestServiceCreate(t *testing.T) {
 
 		input := employees.Employee{
 			Id:           1,
-			CardNumberId: "123",
+			CardNumberId: "52998224725",
 			FirstName:    "",
 			LastName:     "",
 			WarehouseId:  1,
@@ -150,9 +156,10 @@ func TestServiceCreate(t *testing.T) {
 
 		errWarehouseId := errors.New("warehouse with id 1 not found")
 
-		mockedRepository.On("GetOneByCardNumber", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
-		mockedWarehouse.On("GetOne", mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, errWarehouseId).Once()
+		mockedRepository.On("GetOneByCardNumber", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
+		mockedWarehouse.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, errWarehouseId).Once()
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -161,7 +168,7 @@ func TestServiceCreate(t *testing.T) {
 
 		service := employees.NewService(mockedRepository, mockedWarehouse)
 
-		_, err := service.Create(input.CardNumberId, input.FirstName, input.LastName, input.WarehouseId)
+		_, err := service.Create(context.Background(), input.CardNumberId, input.FirstName, input.LastName, input.WarehouseId)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, err.Err.Error(), errWarehouseId.Error())
@@ -173,7 +180,7 @@ func TestServiceCreate(t *testing.T) {
 
 		input := employees.Employee{
 			Id:           1,
-			CardNumberId: "123",
+			CardNumberId: "52998224725",
 			FirstName:    "",
 			LastName:     "",
 			WarehouseId:  1,
@@ -181,9 +188,10 @@ func TestServiceCreate(t *testing.T) {
 
 		errCreate := errors.New("ocurred an error to create employee")
 
-		mockedRepository.On("GetOneByCardNumber", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
-		mockedWarehouse.On("GetOne", mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, nil).Once()
+		mockedRepository.On("GetOneByCardNumber", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
+		mockedWarehouse.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, nil).Once()
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -192,7 +200,7 @@ func TestServiceCreate(t *testing.T) {
 
 		service := employees.NewService(mockedRepository, mockedWarehouse)
 
-		_, err := service.Create(input.CardNumberId, input.FirstName, input.LastName, input.WarehouseId)
+		_, err := service.Create(context.Background(), input.CardNumberId, input.FirstName, input.LastName, input.WarehouseId)
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, err.Err.Error(), errCreate.Error())
 	})
@@ -204,7 +212,7 @@ func TestServiceGetAll(t *testing.T) {
 
 		input := employees.Employee{
 			Id:           1,
-			CardNumberId: "123",
+			CardNumberId: "52998224725",
 			FirstName:    "",
 			LastName:     "",
 			WarehouseId:  1,
@@ -212,11 +220,11 @@ func TestServiceGetAll(t *testing.T) {
 		listaEmployees := []employees.Employee{}
 		listaEmployees = append(listaEmployees, input)
 
-		mockedRepository.On("GetAll").Return(listaEmployees, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return(listaEmployees, nil)
 
 		service := employees.NewService(mockedRepository, nil)
 
-		result, _ := service.GetAll()
+		result, _, _ := service.GetAll(context.Background(), listing.Options{})
 
 		assert.Equal(t, listaEmployees, result)
 	})
@@ -226,11 +234,11 @@ func TestServiceGetAll(t *testing.T) {
 
 		expectedErr := errors.New("any error")
 
-		mockedRepository.On("GetAll").Return([]employees.Employee{}, expectedErr)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]employees.Employee{}, expectedErr)
 
 		service := employees.NewService(mockedRepository, nil)
 
-		_, err := service.GetAll()
+		_, _, err := service.GetAll(context.Background(), listing.Options{})
 
 		assert.NotNil(t, err)
 		assert.Equal(t, expectedErr.Error(), err.Err.Error())
@@ -243,17 +251,17 @@ func TestServiceGetOne(t *testing.T) {
 
 		input := employees.Employee{
 			Id:           1,
-			CardNumberId: "123",
+			CardNumberId: "52998224725",
 			FirstName:    "",
 			LastName:     "",
 			WarehouseId:  1,
 		}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(input, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(input, nil)
 
 		service := employees.NewService(mockedRepository, nil)
 
-		result, err := service.GetOne(1)
+		result, err := service.GetOne(context.Background(), 1)
 		assert.Nil(t, err.Err)
 
 		assert.Equal(t, input, result)
@@ -265,11 +273,11 @@ func TestServiceGetOne(t *testing.T) {
 
 		expectedError := errors.New("employee with id 1 not found")
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(employees.Employee{}, expectedError)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(employees.Employee{}, expectedError)
 
 		service := employees.NewService(mockedRepository, nil)
 
-		_, err := service.GetOne(1)
+		_, err := service.GetOne(context.Background(), 1)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, http.StatusNotFound, err.Code)
@@ -283,11 +291,11 @@ func TestServiceGetOne(t *testing.T) {
 
 		expectedError := errors.New("unexpected error to get employee")
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(employees.Employee{}, expectedError)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(employees.Employee{}, expectedError)
 
 		service := employees.NewService(mockedRepository, nil)
 
-		_, err := service.GetOne(1)
+		_, err := service.GetOne(context.Background(), 1)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, expectedError.Error(), err.Err.Error())
@@ -307,23 +315,24 @@ func TestServiceUpdate(t *testing.T) {
 
 		expectedEmployee := employees.Employee{
 			Id:           1,
-			CardNumberId: "1",
+			CardNumberId: "12345779179",
 			FirstName:    "Now",
 			LastName:     "Are",
 			WarehouseId:  1,
 		}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(employees.Employee{}, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(employees.Employee{}, nil)
 
-		mockedRepository.On("GetOneByCardNumber", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
+		mockedRepository.On("GetOneByCardNumber", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(expectedEmployee, nil)
 
 		service := employees.NewService(mockedRepository, nil)
-		result, err := service.Update(1, requestData)
+		result, err := service.Update(context.Background(), 1, requestData)
 
 		assert.Nil(t, err.Err)
 		assert.Equal(t, expectedEmployee, result)
@@ -334,19 +343,20 @@ func TestServiceUpdate(t *testing.T) {
 		expectedError := errors.New("employee with id 1 not found")
 		requestData := map[string]interface{}{}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).
 			Return(employees.Employee{}, expectedError).Once()
 
-		mockedRepository.On("GetAll").
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).
 			Return([]employees.Employee{}, nil).Once()
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(employees.Employee{}, nil).Once()
 
 		service := employees.NewService(mockedRepository, nil)
-		_, err := service.Update(1, requestData)
+		_, err := service.Update(context.Background(), 1, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, http.StatusNotFound, err.Code)
@@ -357,22 +367,23 @@ func TestServiceUpdate(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 
 		requestData := map[string]interface{}{
-			"card_number_id": "1",
+			"card_number_id": "123.457.791-79",
 		}
 
 		expectedError := errors.New("card_number_id already exists")
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(employees.Employee{}, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(employees.Employee{}, nil)
 
-		mockedRepository.On("GetOneByCardNumber", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(expectedError)
+		mockedRepository.On("GetOneByCardNumber", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(expectedError)
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(employees.Employee{}, expectedError)
 
 		service := employees.NewService(mockedRepository, nil)
-		_, err := service.Update(1, requestData)
+		_, err := service.Update(context.Background(), 1, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, http.StatusConflict, err.Code)
@@ -383,22 +394,23 @@ func TestServiceUpdate(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 
 		requestData := map[string]interface{}{
-			"card_number_id": "1",
+			"card_number_id": "123.457.791-79",
 		}
 
 		expectedError := errors.New("unexpected error to get employee")
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(employees.Employee{}, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(employees.Employee{}, nil)
 
-		mockedRepository.On("GetOneByCardNumber", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(expectedError)
+		mockedRepository.On("GetOneByCardNumber", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(expectedError)
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(employees.Employee{}, expectedError)
 
 		service := employees.NewService(mockedRepository, nil)
-		_, err := service.Update(1, requestData)
+		_, err := service.Update(context.Background(), 1, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, http.StatusInternalServerError, err.Code)
@@ -415,17 +427,18 @@ func TestServiceUpdate(t *testing.T) {
 
 		errUpdate := errors.New("ocurred an error while updating the employee")
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(employees.Employee{}, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(employees.Employee{}, nil)
 
-		mockedRepository.On("GetOneByCardNumber", mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
+		mockedRepository.On("GetOneByCardNumber", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(employees.Employee{}, errUpdate)
 
 		service := employees.NewService(mockedRepository, nil)
-		_, err := service.Update(1, requestData)
+		_, err := service.Update(context.Background(), 1, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, errUpdate, err.Err)
@@ -441,17 +454,18 @@ func TestServiceUpdate(t *testing.T) {
 
 		expectedError := errors.New("unexpected error to get warehouse")
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(employees.Employee{}, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(employees.Employee{}, nil)
 
-		mockedWarehouseRepo.On("GetOne", mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, expectedError)
+		mockedWarehouseRepo.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, expectedError)
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(employees.Employee{}, expectedError)
 
 		service := employees.NewService(mockedRepository, mockedWarehouseRepo)
-		_, err := service.Update(1, requestData)
+		_, err := service.Update(context.Background(), 1, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, http.StatusInternalServerError, err.Code)
@@ -468,17 +482,18 @@ func TestServiceUpdate(t *testing.T) {
 
 		expectedError := errors.New("warehouse with id 1 not found")
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(employees.Employee{}, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(employees.Employee{}, nil)
 
-		mockedWarehouseRepo.On("GetOne", mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, expectedError)
+		mockedWarehouseRepo.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, expectedError)
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(employees.Employee{}, expectedError)
 
 		service := employees.NewService(mockedRepository, mockedWarehouseRepo)
-		_, err := service.Update(1, requestData)
+		_, err := service.Update(context.Background(), 1, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, http.StatusNotFound, err.Code)
@@ -490,10 +505,10 @@ func TestServiceDelete(t *testing.T) {
 	t.Run("Verify the successfully case if the employee is deleted", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 
-		mockedRepository.On("Delete", mock.AnythingOfType("int")).Return(nil)
+		mockedRepository.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 
 		service := employees.NewService(mockedRepository, nil)
-		result := service.Delete(1)
+		result := service.Delete(context.Background(), 1)
 		assert.Nil(t, result.Err)
 
 		assert.Equal(t, result.Code, http.StatusNoContent)
@@ -504,10 +519,10 @@ func TestServiceDelete(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		expectedError := errors.New("employee with id 1 not found")
 
-		mockedRepository.On("Delete", mock.AnythingOfType("int")).Return(expectedError)
+		mockedRepository.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(expectedError)
 
 		service := employees.NewService(mockedRepository, nil)
-		result := service.Delete(1)
+		result := service.Delete(context.Background(), 1)
 		assert.NotNil(t, result.Err)
 
 		assert.Equal(t, result.Code, http.StatusNotFound)
@@ -519,10 +534,10 @@ func TestServiceDelete(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		expectedError := errors.New("unexpected error to delete employee")
 
-		mockedRepository.On("Delete", mock.AnythingOfType("int")).Return(expectedError)
+		mockedRepository.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(expectedError)
 
 		service := employees.NewService(mockedRepository, nil)
-		result := service.Delete(1)
+		result := service.Delete(context.Background(), 1)
 		assert.NotNil(t, result.Err)
 
 		assert.Equal(t, result.Code, http.StatusInternalServerError)
@@ -530,3 +545,45 @@ func TestServiceDelete(t *testing.T) {
 		mockedRepository.AssertExpectations(t)
 	})
 }
+
+func TestServiceCardNumberDocument(t *testing.T) {
+	t.Run("Test error case if card_number_id is not a valid CPF on create", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOneByCardNumber", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(nil)
+
+		service := employees.NewService(mockedRepository, nil)
+		_, resp := service.Create(context.Background(), "11222333000181", "José", "Silva", 1)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "card_number_id must be a valid CPF", resp.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "GetOneByCardNumber", 0)
+	})
+
+	t.Run("Test if card_number_id is stored in the canonical form on update", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		requestData := map[string]interface{}{"card_number_id": "529.982.247-25"}
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(employees.Employee{Id: 1}, nil)
+		mockedRepository.On("GetOneByCardNumber", mock.Anything, 1, "52998224725").Return(nil).Once()
+		mockedRepository.On("Update", mock.Anything, 1, map[string]interface{}{"card_number_id": "52998224725"}).
+			Return(employees.Employee{Id: 1, CardNumberId: "52998224725"}, nil).Once()
+
+		service := employees.NewService(mockedRepository, nil)
+		result, resp := service.Update(context.Background(), 1, requestData)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, "52998224725", result.CardNumberId)
+		mockedRepository.AssertExpectations(t)
+	})
+
+	t.Run("Test error case if card_number_id is not a valid CPF on update", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(employees.Employee{Id: 1}, nil)
+
+		service := employees.NewService(mockedRepository, nil)
+		_, resp := service.Update(context.Background(), 1, map[string]interface{}{"card_number_id": "123"})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "card_number_id must be a valid CPF", resp.Err.Error())
+	})
+}
@@ -0,0 +1,253 @@
+package imports
+
+import (
+	"context"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/products"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/document"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+)
+
+// Importer turns parsed rows into records of one entity, validating and
+// creating them through the entity service so the same rules of the
+// single-record endpoints apply.
+type Importer interface {
+	Decode(row Row) (interface{}, error)
+	Key(record interface{}) string
+	Validate(ctx context.Context, record interface{}) web.ResponseCode
+	Create(ctx context.Context, record interface{}) (int, web.ResponseCode)
+}
+
+type productRecord struct {
+	ProductCode                    string
+	Description                    string
+	Width                          float64
+	Height                         float64
+	Length                         float64
+	NetWeight                      float64
+	ExpirationRate                 float64
+	RecommendedFreezingTemperature *float64
+	FreezingRate                   float64
+	ProductTypeId                  int
+	SellerId                       int
+}
+
+type productImporter struct {
+	service products.Service
+}
+
+func NewProductImporter(s products.Service) Importer {
+	return &productImporter{service: s}
+}
+
+func (i productImporter) Decode(row Row) (interface{}, error) {
+	var (
+		record productRecord
+		err    error
+	)
+
+	if record.ProductCode, err = row.String("product_code"); err != nil {
+		return nil, err
+	}
+	if record.Description, err = row.String("description"); err != nil {
+		return nil, err
+	}
+
+	floatFields := []struct {
+		name   string
+		target *float64
+	}{
+		{"width", &record.Width},
+		{"height", &record.Height},
+		{"length", &record.Length},
+		{"net_weight", &record.NetWeight},
+		{"expiration_rate", &record.ExpirationRate},
+		{"freezing_rate", &record.FreezingRate},
+	}
+	for _, field := range floatFields {
+		if *field.target, err = row.Float(field.name); err != nil {
+			return nil, err
+		}
+	}
+
+	if record.RecommendedFreezingTemperature, err = row.OptionalFloat("recommended_freezing_temperature"); err != nil {
+		return nil, err
+	}
+	if record.ProductTypeId, err = row.Int("product_type_id"); err != nil {
+		return nil, err
+	}
+	if record.SellerId, err = row.Int("seller_id"); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (i productImporter) Key(record interface{}) string {
+	return record.(productRecord).ProductCode
+}
+
+func (i productImporter) Validate(ctx context.Context, record interface{}) web.ResponseCode {
+	product := record.(productRecord)
+	return i.service.ValidateCreate(ctx, product.ProductCode, product.RecommendedFreezingTemperature, product.ProductTypeId, product.SellerId)
+}
+
+func (i productImporter) Create(ctx context.Context, record interface{}) (int, web.ResponseCode) {
+	product := record.(productRecord)
+	created, resp := i.service.Create(
+		ctx,
+		product.ProductCode,
+		product.Description,
+		product.Width,
+		product.Height,
+		product.Length,
+		product.NetWeight,
+		product.ExpirationRate,
+		product.RecommendedFreezingTemperature,
+		product.FreezingRate,
+		product.ProductTypeId,
+		product.SellerId,
+	)
+	return created.Id, resp
+}
+
+type sellerRecord struct {
+	Cid         string
+	CompanyName string
+	Address     address.Address
+	Telephone   string
+	LocalityId  string
+}
+
+type sellerImporter struct {
+	service sellers.Service
+}
+
+func NewSellerImporter(s sellers.Service) Importer {
+	return &sellerImporter{service: s}
+}
+
+func (i sellerImporter) Decode(row Row) (interface{}, error) {
+	var (
+		record sellerRecord
+		err    error
+	)
+
+	if record.Cid, err = row.String("cid"); err != nil {
+		return nil, err
+	}
+	if record.CompanyName, err = row.String("company_name"); err != nil {
+		return nil, err
+	}
+	if record.Address, err = decodeAddress(row); err != nil {
+		return nil, err
+	}
+	if record.Telephone, err = row.String("telephone"); err != nil {
+		return nil, err
+	}
+	if record.LocalityId, err = row.String("locality_id"); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (i sellerImporter) Key(record interface{}) string {
+	return document.Normalize(record.(sellerRecord).Cid)
+}
+
+func (i sellerImporter) Validate(ctx context.Context, record interface{}) web.ResponseCode {
+	seller := record.(sellerRecord)
+	return i.service.ValidateCreate(ctx, seller.Cid, seller.CompanyName, seller.Address, seller.Telephone, seller.LocalityId)
+}
+
+func (i sellerImporter) Create(ctx context.Context, record interface{}) (int, web.ResponseCode) {
+	seller := record.(sellerRecord)
+	created, resp := i.service.Create(ctx, seller.Cid, seller.CompanyName, seller.Address, seller.Telephone, seller.LocalityId)
+	return created.Id, resp
+}
+
+// decodeAddress reads the structured address columns, falling back to the
+// free-text address column of older files.
+func decodeAddress(row Row) (address.Address, error) {
+	var (
+		addr address.Address
+		err  error
+	)
+
+	fields := []*string{&addr.Street, &addr.Number, &addr.Complement, &addr.Neighborhood, &addr.Cep, &addr.State}
+	for i, column := range address.Columns {
+		if *fields[i], err = row.String(column); err != nil {
+			return address.Address{}, err
+		}
+	}
+
+	if addr.Street == "" {
+		legacy, err := row.String("address")
+		if err != nil {
+			return address.Address{}, err
+		}
+		addr.Street = legacy
+	}
+
+	return addr, nil
+}
+
+type buyerRecord struct {
+	CardNumberId string
+	FirstName    string
+	LastName     string
+	LocalityId   *string
+}
+
+type buyerImporter struct {
+	service buyers.Service
+}
+
+func NewBuyerImporter(s buyers.Service) Importer {
+	return &buyerImporter{service: s}
+}
+
+func (i buyerImporter) Decode(row Row) (interface{}, error) {
+	var (
+		record buyerRecord
+		err    error
+	)
+
+	if record.CardNumberId, err = row.String("card_number_id"); err != nil {
+		return nil, err
+	}
+	if record.FirstName, err = row.String("first_name"); err != nil {
+		return nil, err
+	}
+	if record.LastName, err = row.String("last_name"); err != nil {
+		return nil, err
+	}
+	localityId, err := row.String("locality_id")
+	if err != nil {
+		return nil, err
+	}
+	if localityId != "" {
+		record.LocalityId = &localityId
+	}
+
+	return record, nil
+}
+
+func (i buyerImporter) Key(record interface{}) string {
+	return document.Normalize(record.(buyerRecord).CardNumberId)
+}
+
+func (i buyerImporter) Validate(ctx context.Context, record interface{}) web.ResponseCode {
+	buyer := record.(buyerRecord)
+	return i.service.ValidateCreate(ctx, buyer.CardNumberId, buyer.FirstName, buyer.LastName, buyer.LocalityId)
+}
+
+func (i buyerImporter) Create(ctx context.Context, record interface{}) (int, web.ResponseCode) {
+	buyer := record.(buyerRecord)
+	created, resp := i.service.Create(ctx, buyer.CardNumberId, buyer.FirstName, buyer.LastName, buyer.LocalityId)
+	return created.Id, resp
+}
@@ -0,0 +1,55 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	imports "github.com/emidioreb/mercado-fresco-lerigophers/internal/imports"
+
+	mock "github.com/stretchr/testify/mock"
+
+	web "github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// Import provides a mock function with given fields: ctx, entity, format, mode, dryRun, body
+func (_m *Service) Import(ctx context.Context, entity string, format string, mode string, dryRun bool, body io.Reader) (imports.Result, web.ResponseCode) {
+	ret := _m.Called(ctx, entity, format, mode, dryRun, body)
+
+	var r0 imports.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool, io.Reader) imports.Result); ok {
+		r0 = rf(ctx, entity, format, mode, dryRun, body)
+	} else {
+		r0 = ret.Get(0).(imports.Result)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, bool, io.Reader) web.ResponseCode); ok {
+		r1 = rf(ctx, entity, format, mode, dryRun, body)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewService(t mockConstructorTestingTNewService) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,32 @@
+package imports
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+
+	ModeAtomic     = "atomic"
+	ModeBestEffort = "best_effort"
+
+	StatusValid    = "valid"
+	StatusImported = "imported"
+	StatusFailed   = "failed"
+	StatusSkipped  = "skipped"
+)
+
+type RowResult struct {
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	Id     int    `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Result struct {
+	Entity   string      `json:"entity"`
+	Mode     string      `json:"mode"`
+	DryRun   bool        `json:"dry_run"`
+	Total    int         `json:"total"`
+	Valid    int         `json:"valid"`
+	Imported int         `json:"imported"`
+	Failed   int         `json:"failed"`
+	Rows     []RowResult `json:"rows"`
+}
@@ -0,0 +1,174 @@
+package imports
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	errEmptyCSV    = errors.New("csv header is missing")
+	errUnknownType = errors.New("unknown import format")
+)
+
+// Row is a single record of the imported file. Err is set when the line
+// could not be parsed, so it can be reported along with the other rows.
+type Row struct {
+	Line   int
+	Fields map[string]interface{}
+	Err    error
+}
+
+func ParseRows(format string, body io.Reader) ([]Row, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(body)
+	case FormatJSONL:
+		return parseJSONL(body)
+	}
+	return nil, errUnknownType
+}
+
+func parseCSV(body io.Reader) ([]Row, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errEmptyCSV
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv header: %s", err.Error())
+	}
+
+	for index, column := range header {
+		header[index] = strings.ToLower(strings.TrimSpace(column))
+	}
+
+	rows := []Row{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, Row{Line: parseErr.StartLine, Err: fmt.Errorf("invalid csv line: %s", parseErr.Err.Error())})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(record) != len(header) {
+			rows = append(rows, Row{Line: line, Err: fmt.Errorf("expected %d columns, got %d", len(header), len(record))})
+			continue
+		}
+
+		fields := map[string]interface{}{}
+		for index, value := range record {
+			if value = strings.TrimSpace(value); value != "" {
+				fields[header[index]] = value
+			}
+		}
+		rows = append(rows, Row{Line: line, Fields: fields})
+	}
+
+	return rows, nil
+}
+
+func parseJSONL(body io.Reader) ([]Row, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	rows := []Row{}
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(text), &fields); err != nil {
+			rows = append(rows, Row{Line: line, Err: errors.New("invalid json object")})
+			continue
+		}
+
+		for name, value := range fields {
+			if value == nil {
+				delete(fields, name)
+			}
+		}
+		rows = append(rows, Row{Line: line, Fields: fields})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r Row) String(name string) (string, error) {
+	value, ok := r.Fields[name]
+	if !ok {
+		return "", nil
+	}
+
+	switch typed := value.(type) {
+	case string:
+		return typed, nil
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("%s must be a string", name)
+}
+
+func (r Row) Float(name string) (float64, error) {
+	value, err := r.OptionalFloat(name)
+	if err != nil || value == nil {
+		return 0, err
+	}
+	return *value, nil
+}
+
+func (r Row) OptionalFloat(name string) (*float64, error) {
+	value, ok := r.Fields[name]
+	if !ok {
+		return nil, nil
+	}
+
+	switch typed := value.(type) {
+	case float64:
+		return &typed, nil
+	case string:
+		parsed, err := strconv.ParseFloat(typed, 64)
+		if err == nil {
+			return &parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("%s must be a number", name)
+}
+
+func (r Row) Int(name string) (int, error) {
+	value, err := r.OptionalFloat(name)
+	if err != nil {
+		return 0, err
+	}
+	if value == nil {
+		return 0, nil
+	}
+	if *value != math.Trunc(*value) {
+		return 0, fmt.Errorf("%s must be an integer", name)
+	}
+	return int(*value), nil
+}
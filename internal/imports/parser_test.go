@@ -0,0 +1,111 @@
+package imports_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/imports"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRowsCSV(t *testing.T) {
+	t.Run("Test if parse csv rows with line numbers", func(t *testing.T) {
+		body := "card_number_id,first_name,last_name\n" +
+			"402,Ana,Souza\n" +
+			"\n" +
+			"403,Bruno,\n"
+
+		rows, err := imports.ParseRows(imports.FormatCSV, strings.NewReader(body))
+		assert.Nil(t, err)
+		assert.Len(t, rows, 2)
+
+		assert.Equal(t, 2, rows[0].Line)
+		assert.Equal(t, "Ana", rows[0].Fields["first_name"])
+
+		assert.Equal(t, 4, rows[1].Line)
+		_, ok := rows[1].Fields["last_name"]
+		assert.False(t, ok)
+	})
+
+	t.Run("Test if report rows with a wrong number of columns", func(t *testing.T) {
+		body := "card_number_id,first_name,last_name\n402,Ana\n"
+
+		rows, err := imports.ParseRows(imports.FormatCSV, strings.NewReader(body))
+		assert.Nil(t, err)
+		assert.Len(t, rows, 1)
+		assert.Equal(t, 2, rows[0].Line)
+		assert.Equal(t, "expected 3 columns, got 2", rows[0].Err.Error())
+	})
+
+	t.Run("Test if report rows with invalid quotes", func(t *testing.T) {
+		body := "card_number_id,first_name,last_name\n402,\"Ana,Souza\n"
+
+		rows, err := imports.ParseRows(imports.FormatCSV, strings.NewReader(body))
+		assert.Nil(t, err)
+		assert.Len(t, rows, 1)
+		assert.NotNil(t, rows[0].Err)
+	})
+
+	t.Run("Test error when the header is missing", func(t *testing.T) {
+		_, err := imports.ParseRows(imports.FormatCSV, strings.NewReader(""))
+		assert.Equal(t, "csv header is missing", err.Error())
+	})
+}
+
+func TestParseRowsJSONL(t *testing.T) {
+	t.Run("Test if parse json lines skipping blank lines", func(t *testing.T) {
+		body := `{"cid": 10, "company_name": "Fresco"}` + "\n\n" +
+			`{"cid": 11, "company_name": null}` + "\n" +
+			`not json` + "\n"
+
+		rows, err := imports.ParseRows(imports.FormatJSONL, strings.NewReader(body))
+		assert.Nil(t, err)
+		assert.Len(t, rows, 3)
+
+		assert.Equal(t, 1, rows[0].Line)
+		assert.Equal(t, "Fresco", rows[0].Fields["company_name"])
+
+		assert.Equal(t, 3, rows[1].Line)
+		_, ok := rows[1].Fields["company_name"]
+		assert.False(t, ok)
+
+		assert.Equal(t, 4, rows[2].Line)
+		assert.Equal(t, "invalid json object", rows[2].Err.Error())
+	})
+
+	t.Run("Test error with unknown format", func(t *testing.T) {
+		_, err := imports.ParseRows("xml", strings.NewReader(""))
+		assert.Equal(t, "unknown import format", err.Error())
+	})
+}
+
+func TestRowValues(t *testing.T) {
+	row := imports.Row{Fields: map[string]interface{}{
+		"number": "12.5",
+		"int":    float64(3),
+		"text":   "abc",
+		"half":   1.5,
+	}}
+
+	value, err := row.Float("number")
+	assert.Nil(t, err)
+	assert.Equal(t, 12.5, value)
+
+	integer, err := row.Int("int")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, integer)
+
+	_, err = row.Int("half")
+	assert.Equal(t, "half must be an integer", err.Error())
+
+	_, err = row.Float("text")
+	assert.Equal(t, "text must be a number", err.Error())
+
+	optional, err := row.OptionalFloat("missing")
+	assert.Nil(t, err)
+	assert.Nil(t, optional)
+
+	text, err := row.String("int")
+	assert.Nil(t, err)
+	assert.Equal(t, "3", text)
+}
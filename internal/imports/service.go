@@ -0,0 +1,196 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/products"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+)
+
+var (
+	errInvalidMode = errors.New("mode must be atomic or best_effort")
+	errNoRows      = errors.New("no rows to import")
+	errAtomic      = errors.New("import aborted: some rows are invalid, no row was imported")
+)
+
+type Service interface {
+	Import(ctx context.Context, entity, format, mode string, dryRun bool, body io.Reader) (Result, web.ResponseCode)
+}
+
+type service struct {
+	importers    map[string]Importer
+	transactions transaction.Manager
+}
+
+func NewService(ps products.Service, ss sellers.Service, bs buyers.Service, tm transaction.Manager) Service {
+	return &service{
+		importers: map[string]Importer{
+			"products": NewProductImporter(ps),
+			"sellers":  NewSellerImporter(ss),
+			"buyers":   NewBuyerImporter(bs),
+		},
+		transactions: tm,
+	}
+}
+
+type validatedRow struct {
+	result *RowResult
+	record interface{}
+}
+
+// Import validates every row before creating any of them. In atomic mode a
+// single invalid row aborts the whole import and the rows are created in one
+// transaction, so a row that fails on create rolls back the ones before it;
+// in best_effort mode the valid rows are created and the invalid ones
+// reported.
+func (s service) Import(ctx context.Context, entity, format, mode string, dryRun bool, body io.Reader) (Result, web.ResponseCode) {
+	importer, ok := s.importers[entity]
+	if !ok {
+		return Result{}, web.NewCodeResponse(http.StatusNotFound, fmt.Errorf("unknown import entity %s", entity))
+	}
+
+	if mode != ModeAtomic && mode != ModeBestEffort {
+		return Result{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidMode)
+	}
+
+	rows, err := ParseRows(format, body)
+	if err != nil {
+		return Result{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+	}
+
+	if len(rows) == 0 {
+		return Result{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errNoRows)
+	}
+
+	result := Result{
+		Entity: entity,
+		Mode:   mode,
+		DryRun: dryRun,
+		Total:  len(rows),
+		Rows:   make([]RowResult, len(rows)),
+	}
+
+	valid := []validatedRow{}
+	seenKeys := map[string]int{}
+
+	for index, row := range rows {
+		rowResult := &result.Rows[index]
+		rowResult.Line = row.Line
+
+		record, err := decodeRow(importer, row)
+		if err != nil {
+			fail(&result, rowResult, err)
+			continue
+		}
+
+		key := importer.Key(record)
+		if line, ok := seenKeys[key]; ok {
+			fail(&result, rowResult, fmt.Errorf("duplicated %s %s, already informed in line %d", entity, key, line))
+			continue
+		}
+		seenKeys[key] = row.Line
+
+		if resp := importer.Validate(ctx, record); resp.Err != nil {
+			fail(&result, rowResult, resp.Err)
+			continue
+		}
+
+		rowResult.Status = StatusValid
+		result.Valid++
+		valid = append(valid, validatedRow{result: rowResult, record: record})
+	}
+
+	if dryRun {
+		return result, web.NewCodeResponse(http.StatusOK, nil)
+	}
+
+	if mode == ModeAtomic && result.Failed > 0 {
+		for _, row := range valid {
+			row.result.Status = StatusSkipped
+		}
+		return result, web.NewCodeResponse(http.StatusUnprocessableEntity, errAtomic)
+	}
+
+	if mode == ModeAtomic {
+		return s.createAtomic(ctx, importer, &result, valid)
+	}
+
+	for _, row := range valid {
+		id, resp := importer.Create(ctx, row.record)
+		if resp.Err != nil {
+			fail(&result, row.result, resp.Err)
+			continue
+		}
+
+		row.result.Status = StatusImported
+		row.result.Id = id
+		result.Imported++
+	}
+
+	if result.Imported == 0 {
+		return result, web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("no row was imported"))
+	}
+
+	if result.Failed > 0 {
+		return result, web.NewCodeResponse(http.StatusOK, nil)
+	}
+
+	return result, web.NewCodeResponse(http.StatusCreated, nil)
+}
+
+func (s service) createAtomic(ctx context.Context, importer Importer, result *Result, valid []validatedRow) (Result, web.ResponseCode) {
+	var createErr error
+
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		for _, row := range valid {
+			id, resp := importer.Create(ctx, row.record)
+			if resp.Err != nil {
+				fail(result, row.result, resp.Err)
+				createErr = fmt.Errorf("import aborted: line %d failed on create, no row was imported", row.result.Line)
+				return createErr
+			}
+
+			row.result.Status = StatusImported
+			row.result.Id = id
+			result.Imported++
+		}
+		return nil
+	})
+
+	if err != nil {
+		for _, row := range valid {
+			if row.result.Status != StatusFailed {
+				row.result.Status = StatusSkipped
+				row.result.Id = 0
+			}
+		}
+		result.Imported = 0
+
+		if createErr != nil {
+			return *result, web.NewCodeResponse(http.StatusUnprocessableEntity, createErr)
+		}
+		return *result, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return *result, web.NewCodeResponse(http.StatusCreated, nil)
+}
+
+func decodeRow(importer Importer, row Row) (interface{}, error) {
+	if row.Err != nil {
+		return nil, row.Err
+	}
+	return importer.Decode(row)
+}
+
+func fail(result *Result, rowResult *RowResult, err error) {
+	rowResult.Status = StatusFailed
+	rowResult.Error = err.Error()
+	result.Failed++
+}
@@ -0,0 +1,202 @@
+package imports_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers"
+	mockBuyerService "github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/imports"
+	mockProductService "github.com/emidioreb/mercado-fresco-lerigophers/internal/products/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers"
+	mockSellerService "github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const buyersCSV = "card_number_id,first_name,last_name\n" +
+	"402,Ana,Souza\n" +
+	"403,Bruno,Lima\n"
+
+type serviceMocks struct {
+	products *mockProductService.Service
+	sellers  *mockSellerService.Service
+	buyers   *mockBuyerService.Service
+}
+
+func newServiceMocks() (serviceMocks, imports.Service) {
+	return newServiceMocksWith(transaction.Nop)
+}
+
+func newServiceMocksWith(tm transaction.Manager) (serviceMocks, imports.Service) {
+	mocks := serviceMocks{
+		products: new(mockProductService.Service),
+		sellers:  new(mockSellerService.Service),
+		buyers:   new(mockBuyerService.Service),
+	}
+	return mocks, imports.NewService(mocks.products, mocks.sellers, mocks.buyers, tm)
+}
+
+func okResponse() web.ResponseCode {
+	return web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func TestServiceImportAtomic(t *testing.T) {
+	t.Run("Test if import every row", func(t *testing.T) {
+		mocks, service := newServiceMocks()
+		mocks.buyers.On("ValidateCreate", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), (*string)(nil)).
+			Return(okResponse()).Twice()
+		mocks.buyers.On("Create", mock.Anything, "402", "Ana", "Souza", (*string)(nil)).
+			Return(buyers.Buyer{Id: 1}, web.NewCodeResponse(http.StatusCreated, nil)).Once()
+		mocks.buyers.On("Create", mock.Anything, "403", "Bruno", "Lima", (*string)(nil)).
+			Return(buyers.Buyer{Id: 2}, web.NewCodeResponse(http.StatusCreated, nil)).Once()
+
+		result, resp := service.Import(context.Background(), "buyers", imports.FormatCSV, imports.ModeAtomic, false, strings.NewReader(buyersCSV))
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, 2, result.Total)
+		assert.Equal(t, 2, result.Imported)
+		assert.Equal(t, imports.RowResult{Line: 2, Status: imports.StatusImported, Id: 1}, result.Rows[0])
+		assert.Equal(t, imports.RowResult{Line: 3, Status: imports.StatusImported, Id: 2}, result.Rows[1])
+		mocks.buyers.AssertExpectations(t)
+	})
+
+	t.Run("Test if nothing is imported when a row is invalid", func(t *testing.T) {
+		mocks, service := newServiceMocks()
+		mocks.buyers.On("ValidateCreate", mock.Anything, "402", "Ana", "Souza", (*string)(nil)).Return(okResponse()).Once()
+		mocks.buyers.On("ValidateCreate", mock.Anything, "403", "Bruno", "Lima", (*string)(nil)).
+			Return(web.NewCodeResponse(http.StatusConflict, errors.New("buyer with card_number_id 403 already exists"))).Once()
+
+		result, resp := service.Import(context.Background(), "buyers", imports.FormatCSV, imports.ModeAtomic, false, strings.NewReader(buyersCSV))
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "import aborted: some rows are invalid, no row was imported", resp.Err.Error())
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, imports.StatusSkipped, result.Rows[0].Status)
+		assert.Equal(t, imports.RowResult{Line: 3, Status: imports.StatusFailed, Error: "buyer with card_number_id 403 already exists"}, result.Rows[1])
+		mocks.buyers.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("Test if a row that fails on create rolls back the rows before it", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		mocks, service := newServiceMocksWith(transaction.NewDB(db))
+		mocks.buyers.On("ValidateCreate", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), (*string)(nil)).
+			Return(okResponse()).Twice()
+		mocks.buyers.On("Create", mock.MatchedBy(transaction.InTx), "402", "Ana", "Souza", (*string)(nil)).
+			Return(buyers.Buyer{Id: 1}, web.NewCodeResponse(http.StatusCreated, nil)).Once()
+		mocks.buyers.On("Create", mock.MatchedBy(transaction.InTx), "403", "Bruno", "Lima", (*string)(nil)).
+			Return(buyers.Buyer{}, web.NewCodeResponse(http.StatusConflict, errors.New("buyer with card_number_id 403 already exists"))).Once()
+
+		result, resp := service.Import(context.Background(), "buyers", imports.FormatCSV, imports.ModeAtomic, false, strings.NewReader(buyersCSV))
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "import aborted: line 3 failed on create, no row was imported", resp.Err.Error())
+		assert.Equal(t, 0, result.Imported)
+		assert.Equal(t, imports.RowResult{Line: 2, Status: imports.StatusSkipped}, result.Rows[0])
+		assert.Equal(t, imports.RowResult{Line: 3, Status: imports.StatusFailed, Error: "buyer with card_number_id 403 already exists"}, result.Rows[1])
+		mocks.buyers.AssertExpectations(t)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Test if report duplicated keys inside the file", func(t *testing.T) {
+		mocks, service := newServiceMocks()
+		mocks.buyers.On("ValidateCreate", mock.Anything, "402", "Ana", "Souza", (*string)(nil)).Return(okResponse()).Once()
+		mocks.buyers.On("ValidateCreate", mock.Anything, "403", "Bruno", "Lima", (*string)(nil)).Return(okResponse()).Once()
+		body := buyersCSV + "402,Carla,Dias\n"
+
+		result, resp := service.Import(context.Background(), "buyers", imports.FormatCSV, imports.ModeAtomic, false, strings.NewReader(body))
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "duplicated buyers 402, already informed in line 2", result.Rows[2].Error)
+	})
+}
+
+func TestServiceImportBestEffort(t *testing.T) {
+	t.Run("Test if import only the valid rows", func(t *testing.T) {
+		mocks, service := newServiceMocks()
+		mocks.sellers.On("ValidateCreate", mock.Anything, "11222333000181", "Fresco", address.FromLegacy("Rua A"), "3003", "6700").Return(okResponse()).Once()
+		mocks.sellers.On("Create", mock.Anything, "11222333000181", "Fresco", address.FromLegacy("Rua A"), "3003", "6700").
+			Return(sellers.Seller{Id: 7}, web.NewCodeResponse(http.StatusCreated, nil)).Once()
+		body := `{"cid": "11222333000181", "company_name": "Fresco", "address": "Rua A", "telephone": "3003", "locality_id": "6700"}` + "\n" +
+			`{"cid": true}` + "\n"
+
+		result, resp := service.Import(context.Background(), "sellers", imports.FormatJSONL, imports.ModeBestEffort, false, strings.NewReader(body))
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, 1, result.Imported)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, imports.RowResult{Line: 1, Status: imports.StatusImported, Id: 7}, result.Rows[0])
+		assert.Equal(t, imports.RowResult{Line: 2, Status: imports.StatusFailed, Error: "cid must be a string"}, result.Rows[1])
+		mocks.sellers.AssertExpectations(t)
+	})
+
+	t.Run("Test if report rows that failed on create", func(t *testing.T) {
+		mocks, service := newServiceMocks()
+		mocks.buyers.On("ValidateCreate", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), (*string)(nil)).
+			Return(okResponse()).Twice()
+		mocks.buyers.On("Create", mock.Anything, "402", "Ana", "Souza", (*string)(nil)).
+			Return(buyers.Buyer{Id: 1}, web.NewCodeResponse(http.StatusCreated, nil)).Once()
+		mocks.buyers.On("Create", mock.Anything, "403", "Bruno", "Lima", (*string)(nil)).
+			Return(buyers.Buyer{}, web.NewCodeResponse(http.StatusInternalServerError, errors.New("couldn't create a buyer"))).Once()
+
+		result, resp := service.Import(context.Background(), "buyers", imports.FormatCSV, imports.ModeBestEffort, false, strings.NewReader(buyersCSV))
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "couldn't create a buyer", result.Rows[1].Error)
+	})
+
+	t.Run("Test error when no row is imported", func(t *testing.T) {
+		_, service := newServiceMocks()
+		body := "card_number_id,first_name,last_name\n402,Ana\n"
+
+		result, resp := service.Import(context.Background(), "buyers", imports.FormatCSV, imports.ModeBestEffort, false, strings.NewReader(body))
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, 1, result.Failed)
+	})
+}
+
+func TestServiceImportDryRun(t *testing.T) {
+	t.Run("Test if validate products without creating them", func(t *testing.T) {
+		mocks, service := newServiceMocks()
+		temperature := -18.0
+		mocks.products.On("ValidateCreate", mock.Anything, "P1", &temperature, 1, 2).Return(okResponse()).Once()
+		body := "product_code,description,width,height,length,net_weight,expiration_rate,recommended_freezing_temperature,freezing_rate,product_type_id,seller_id\n" +
+			"P1,Milk,1,2,3,4,5,-18,1,1,2\n"
+
+		result, resp := service.Import(context.Background(), "products", imports.FormatCSV, imports.ModeAtomic, true, strings.NewReader(body))
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 1, result.Valid)
+		assert.Equal(t, imports.StatusValid, result.Rows[0].Status)
+		mocks.products.AssertNumberOfCalls(t, "Create", 0)
+		mocks.products.AssertExpectations(t)
+	})
+}
+
+func TestServiceImportErrors(t *testing.T) {
+	_, service := newServiceMocks()
+
+	_, resp := service.Import(context.Background(), "warehouses", imports.FormatCSV, imports.ModeAtomic, false, strings.NewReader(buyersCSV))
+	assert.Equal(t, web.NewCodeResponse(http.StatusNotFound, errors.New("unknown import entity warehouses")), resp)
+
+	_, resp = service.Import(context.Background(), "buyers", imports.FormatCSV, "partial", false, strings.NewReader(buyersCSV))
+	assert.Equal(t, web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("mode must be atomic or best_effort")), resp)
+
+	_, resp = service.Import(context.Background(), "buyers", "xml", imports.ModeAtomic, false, strings.NewReader(buyersCSV))
+	assert.Equal(t, web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("unknown import format")), resp)
+
+	_, resp = service.Import(context.Background(), "buyers", imports.FormatCSV, imports.ModeAtomic, false, strings.NewReader("card_number_id\n"))
+	assert.Equal(t, web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("no rows to import")), resp)
+
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	inboundorders "github.com/emidioreb/mercado-fresco-lerigophers/internal/inboundOrders"
 	mock "github.com/stretchr/testify/mock"
 
@@ -14,20 +16,20 @@ type Service struct {
 	mock.Mock
 }
 
-// CreateInboundOrders provides a mock function with given fields: orderNumber, orderDate, employeeId, productBatchId, warehouseId
-func (_m *Service) CreateInboundOrders(orderNumber string, orderDate string, employeeId int, productBatchId int, warehouseId int) (inboundorders.InboundOrder, web.ResponseCode) {
-	ret := _m.Called(orderNumber, orderDate, employeeId, productBatchId, warehouseId)
+// CreateInboundOrders provides a mock function with given fields: ctx, orderNumber, orderDate, employeeId, productBatchId, warehouseId
+func (_m *Service) CreateInboundOrders(ctx context.Context, orderNumber string, orderDate string, employeeId int, productBatchId int, warehouseId int) (inboundorders.InboundOrder, web.ResponseCode) {
+	ret := _m.Called(ctx, orderNumber, orderDate, employeeId, productBatchId, warehouseId)
 
 	var r0 inboundorders.InboundOrder
-	if rf, ok := ret.Get(0).(func(string, string, int, int, int) inboundorders.InboundOrder); ok {
-		r0 = rf(orderNumber, orderDate, employeeId, productBatchId, warehouseId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, int, int) inboundorders.InboundOrder); ok {
+		r0 = rf(ctx, orderNumber, orderDate, employeeId, productBatchId, warehouseId)
 	} else {
 		r0 = ret.Get(0).(inboundorders.InboundOrder)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(string, string, int, int, int) web.ResponseCode); ok {
-		r1 = rf(orderNumber, orderDate, employeeId, productBatchId, warehouseId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, int, int) web.ResponseCode); ok {
+		r1 = rf(ctx, orderNumber, orderDate, employeeId, productBatchId, warehouseId)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -35,13 +37,13 @@ func (_m *Service) CreateInboundOrders(orderNumber string, orderDate string, emp
 	return r0, r1
 }
 
-// GetReportInboundOrders provides a mock function with given fields: employeeId
-func (_m *Service) GetReportInboundOrders(employeeId string) ([]inboundorders.ReportInboundOrder, web.ResponseCode) {
-	ret := _m.Called(employeeId)
+// GetReportInboundOrders provides a mock function with given fields: ctx, employeeId
+func (_m *Service) GetReportInboundOrders(ctx context.Context, employeeId string) ([]inboundorders.ReportInboundOrder, web.ResponseCode) {
+	ret := _m.Called(ctx, employeeId)
 
 	var r0 []inboundorders.ReportInboundOrder
-	if rf, ok := ret.Get(0).(func(string) []inboundorders.ReportInboundOrder); ok {
-		r0 = rf(employeeId)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []inboundorders.ReportInboundOrder); ok {
+		r0 = rf(ctx, employeeId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]inboundorders.ReportInboundOrder)
@@ -49,8 +51,31 @@ func (_m *Service) GetReportInboundOrders(employeeId string) ([]inboundorders.Re
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(string) web.ResponseCode); ok {
-		r1 = rf(employeeId)
+	if rf, ok := ret.Get(1).(func(context.Context, string) web.ResponseCode); ok {
+		r1 = rf(ctx, employeeId)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetReportProductivity provides a mock function with given fields: ctx, filter
+func (_m *Service) GetReportProductivity(ctx context.Context, filter inboundorders.ReportProductivityFilter) ([]inboundorders.ReportProductivity, web.ResponseCode) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []inboundorders.ReportProductivity
+	if rf, ok := ret.Get(0).(func(context.Context, inboundorders.ReportProductivityFilter) []inboundorders.ReportProductivity); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]inboundorders.ReportProductivity)
+		}
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, inboundorders.ReportProductivityFilter) web.ResponseCode); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -17,3 +17,28 @@ type ReportInboundOrder struct {
 	WarehouseId        int    `json:"warehouse_id"`
 	InboundOrdersCount int    `json:"inbound_orders_count"`
 }
+
+type ReportProductivityFilter struct {
+	StartDate   string
+	EndDate     string
+	WarehouseId int
+	GroupBy     string
+}
+
+type ProductivityPeriod struct {
+	Period             string `json:"period"`
+	InboundOrdersCount int    `json:"inbound_orders_count"`
+	UnitsReceived      int    `json:"units_received"`
+}
+
+type ReportProductivity struct {
+	Id                 int                  `json:"id"`
+	CardNumberId       string               `json:"card_number_id"`
+	FirstName          string               `json:"first_name"`
+	LastName           string               `json:"last_name"`
+	WarehouseId        int                  `json:"warehouse_id"`
+	InboundOrdersCount int                  `json:"inbound_orders_count"`
+	UnitsReceived      int                  `json:"units_received"`
+	WarehouseRank      int                  `json:"warehouse_rank"`
+	Periods            []ProductivityPeriod `json:"periods"`
+}
@@ -1,14 +1,50 @@
 package inboundorders
 
+const (
+	GroupByDay  = "day"
+	GroupByWeek = "week"
+)
+
 var (
 	QueryCreate = `INSERT INTO inbound_orders(order_number, order_date, employee_id, product_batch_id, warehouse_id)
 	VALUES(?, ?, ?, ?, ?)`
 
-	QueryReportGetAll = `SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, count(*) as inbound_orders_count FROM inbound_orders i
-	JOIN employees e ON i.employee_id = e.id
+	QueryReportGetAll = `SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, count(i.id) as inbound_orders_count FROM inbound_orders i
+	RIGHT JOIN employees e ON i.employee_id = e.id
 	GROUP BY e.id, e.card_number_id`
 
-	QueryReportGetOne = `SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, count(*) as inbound_orders_count FROM inbound_orders i
-	JOIN employees e ON i.employee_id = e.id WHERE e.id = ?
+	QueryReportGetOne = `SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, count(i.id) as inbound_orders_count FROM inbound_orders i
+	RIGHT JOIN employees e ON i.employee_id = e.id WHERE e.id = ?
 	GROUP BY e.id, e.card_number_id`
+
+	QueryReportProductivity = `SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id,
+		count(i.id) as inbound_orders_count,
+		COALESCE(SUM(pb.initial_quantity), 0) as units_received
+	FROM employees e
+	LEFT JOIN inbound_orders i ON i.employee_id = e.id AND i.order_date BETWEEN ? AND ?
+	LEFT JOIN product_batches pb ON i.product_batch_id = pb.id
+	WHERE (? = 0 OR e.warehouse_id = ?)
+	GROUP BY e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id`
+
+	QueryReportProductivityPeriods = map[string]string{
+		GroupByDay: `SELECT i.employee_id, DATE_FORMAT(i.order_date, '%Y-%m-%d') as period,
+		count(i.id) as inbound_orders_count,
+		COALESCE(SUM(pb.initial_quantity), 0) as units_received
+	FROM inbound_orders i
+	JOIN employees e ON i.employee_id = e.id
+	LEFT JOIN product_batches pb ON i.product_batch_id = pb.id
+	WHERE i.order_date BETWEEN ? AND ? AND (? = 0 OR e.warehouse_id = ?)
+	GROUP BY i.employee_id, period
+	ORDER BY i.employee_id, period`,
+
+		GroupByWeek: `SELECT i.employee_id, DATE_FORMAT(i.order_date, '%x-W%v') as period,
+		count(i.id) as inbound_orders_count,
+		COALESCE(SUM(pb.initial_quantity), 0) as units_received
+	FROM inbound_orders i
+	JOIN employees e ON i.employee_id = e.id
+	LEFT JOIN product_batches pb ON i.product_batch_id = pb.id
+	WHERE i.order_date BETWEEN ? AND ? AND (? = 0 OR e.warehouse_id = ?)
+	GROUP BY i.employee_id, period
+	ORDER BY i.employee_id, period`,
+	}
 )
@@ -1,26 +1,34 @@
 package inboundorders
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
+)
+
+var (
+	errReportProductivity = errors.New("error to report productivity by employee")
 )
 
 type Repository interface {
-	CreateInboundOrders(orderNumber, orderDate string, employeeId, productBatchId, warehouseId int) (InboundOrder, error)
-	GetReportInboundOrders(employeeId string) ([]ReportInboundOrder, error)
+	CreateInboundOrders(ctx context.Context, orderNumber, orderDate string, employeeId, productBatchId, warehouseId int) (InboundOrder, error)
+	GetReportInboundOrders(ctx context.Context, employeeId string) ([]ReportInboundOrder, error)
+	GetReportProductivity(ctx context.Context, filter ReportProductivityFilter) ([]ReportProductivity, error)
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
-func (mariaDb mariaDbRepository) CreateInboundOrders(orderNumber, orderDate string, employeeId, productBatchId, warehouseId int) (InboundOrder, error) {
+func (mariaDb mariaDbRepository) CreateInboundOrders(ctx context.Context, orderNumber, orderDate string, employeeId, productBatchId, warehouseId int) (InboundOrder, error) {
 
 	newInbound := InboundOrder{
 		OrderNumber:    orderNumber,
@@ -30,7 +38,8 @@ func (mariaDb mariaDbRepository) CreateInboundOrders(orderNumber, orderDate stri
 		WarehouseId:    warehouseId,
 	}
 
-	result, err := mariaDb.db.Exec(
+	result, err := mariaDb.db.ExecContext(
+		ctx,
 		QueryCreate,
 		orderNumber,
 		orderDate,
@@ -52,7 +61,7 @@ func (mariaDb mariaDbRepository) CreateInboundOrders(orderNumber, orderDate stri
 	return newInbound, nil
 }
 
-func (mariaDb mariaDbRepository) GetReportInboundOrders(employeeId string) ([]ReportInboundOrder, error) {
+func (mariaDb mariaDbRepository) GetReportInboundOrders(ctx context.Context, employeeId string) ([]ReportInboundOrder, error) {
 	reports := []ReportInboundOrder{}
 
 	var (
@@ -61,9 +70,9 @@ func (mariaDb mariaDbRepository) GetReportInboundOrders(employeeId string) ([]Re
 	)
 
 	if employeeId != "" {
-		rows, err = mariaDb.db.Query(QueryReportGetOne, employeeId)
+		rows, err = mariaDb.db.QueryContext(ctx, QueryReportGetOne, employeeId)
 	} else {
-		rows, err = mariaDb.db.Query(QueryReportGetAll)
+		rows, err = mariaDb.db.QueryContext(ctx, QueryReportGetAll)
 	}
 
 	if err != nil {
@@ -85,5 +94,89 @@ func (mariaDb mariaDbRepository) GetReportInboundOrders(employeeId string) ([]Re
 		reports = append(reports, currentReport)
 	}
 
+	if err := rows.Err(); err != nil {
+		return []ReportInboundOrder{}, errors.New("error to report inbound_orders by employee")
+	}
+
+	return reports, nil
+}
+
+func (mariaDb mariaDbRepository) GetReportProductivity(ctx context.Context, filter ReportProductivityFilter) ([]ReportProductivity, error) {
+	reports := []ReportProductivity{}
+	indexByEmployee := map[int]int{}
+
+	rows, err := mariaDb.db.QueryContext(
+		ctx,
+		QueryReportProductivity,
+		filter.StartDate,
+		filter.EndDate,
+		filter.WarehouseId,
+		filter.WarehouseId,
+	)
+	if err != nil {
+		return []ReportProductivity{}, errReportProductivity
+	}
+
+	for rows.Next() {
+		currentReport := ReportProductivity{Periods: []ProductivityPeriod{}}
+		if err := rows.Scan(
+			&currentReport.Id,
+			&currentReport.CardNumberId,
+			&currentReport.FirstName,
+			&currentReport.LastName,
+			&currentReport.WarehouseId,
+			&currentReport.InboundOrdersCount,
+			&currentReport.UnitsReceived,
+		); err != nil {
+			return []ReportProductivity{}, errReportProductivity
+		}
+		indexByEmployee[currentReport.Id] = len(reports)
+		reports = append(reports, currentReport)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []ReportProductivity{}, errReportProductivity
+	}
+
+	queryPeriods, ok := QueryReportProductivityPeriods[filter.GroupBy]
+	if !ok {
+		return reports, nil
+	}
+
+	rows, err = mariaDb.db.QueryContext(
+		ctx,
+		queryPeriods,
+		filter.StartDate,
+		filter.EndDate,
+		filter.WarehouseId,
+		filter.WarehouseId,
+	)
+	if err != nil {
+		return []ReportProductivity{}, errReportProductivity
+	}
+
+	for rows.Next() {
+		var (
+			employeeId    int
+			currentPeriod ProductivityPeriod
+		)
+		if err := rows.Scan(
+			&employeeId,
+			&currentPeriod.Period,
+			&currentPeriod.InboundOrdersCount,
+			&currentPeriod.UnitsReceived,
+		); err != nil {
+			return []ReportProductivity{}, errReportProductivity
+		}
+
+		if index, ok := indexByEmployee[employeeId]; ok {
+			reports[index].Periods = append(reports[index].Periods, currentPeriod)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return []ReportProductivity{}, errReportProductivity
+	}
+
 	return reports, nil
 }
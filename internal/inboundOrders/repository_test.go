@@ -1,6 +1,7 @@
 package inboundorders_test
 
 import (
+	"context"
 	"errors"
 	"regexp"
 	"testing"
@@ -28,6 +29,7 @@ func TestDBCreateLocality(t *testing.T) {
 		inboundOrderRepo := inboundorders.NewMariaDbRepository(db)
 
 		inboundOrder, err := inboundOrderRepo.CreateInboundOrders(
+			context.Background(),
 			"123",
 			"123",
 			123,
@@ -47,7 +49,7 @@ func TestDBCreateLocality(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(inboundorders.QueryCreate)).WillReturnError(errors.New("any"))
 		inboundRepo := inboundorders.NewMariaDbRepository(db)
 
-		inbound, err := inboundRepo.CreateInboundOrders("", "", 1, 1, 1)
+		inbound, err := inboundRepo.CreateInboundOrders(context.Background(), "", "", 1, 1, 1)
 		assert.NotNil(t, err)
 		assert.Equal(t, "", inbound.OrderNumber)
 	})
@@ -63,7 +65,7 @@ func TestDBCreateLocality(t *testing.T) {
 			WillReturnResult(sqlDriverResultErr)
 
 		inboundRepo := inboundorders.NewMariaDbRepository(db)
-		_, errLastInsert := inboundRepo.CreateInboundOrders("", "", 0, 0, 0)
+		_, errLastInsert := inboundRepo.CreateInboundOrders(context.Background(), "", "", 0, 0, 0)
 
 		assert.Error(t, errLastInsert)
 		assert.Equal(t, "couldn't load the inbound order created", errLastInsert.Error())
@@ -93,7 +95,7 @@ func TestDBGetReportSellers(t *testing.T) {
 
 		inboundRepo := inboundorders.NewMariaDbRepository(db)
 
-		inboundReports, err := inboundRepo.GetReportInboundOrders("")
+		inboundReports, err := inboundRepo.GetReportInboundOrders(context.Background(), "")
 		assert.Nil(t, err)
 
 		assert.Len(t, inboundReports, 3)
@@ -122,7 +124,7 @@ func TestDBGetReportSellers(t *testing.T) {
 
 		inboundRepo := inboundorders.NewMariaDbRepository(db)
 
-		inboundReports, err := inboundRepo.GetReportInboundOrders("1")
+		inboundReports, err := inboundRepo.GetReportInboundOrders(context.Background(), "1")
 		assert.Nil(t, err)
 
 		assert.Len(t, inboundReports, 1)
@@ -141,7 +143,7 @@ func TestDBGetReportSellers(t *testing.T) {
 
 		inboundRepo := inboundorders.NewMariaDbRepository(db)
 
-		_, err = inboundRepo.GetReportInboundOrders("1")
+		_, err = inboundRepo.GetReportInboundOrders(context.Background(), "1")
 		assert.NotNil(t, err)
 		assert.Equal(t, "error to report inbound_orders by employee", err.Error())
 	})
@@ -166,7 +168,173 @@ func TestDBGetReportSellers(t *testing.T) {
 
 		inboundRepo := inboundorders.NewMariaDbRepository(db)
 
-		_, err = inboundRepo.GetReportInboundOrders("1")
+		_, err = inboundRepo.GetReportInboundOrders(context.Background(), "1")
+		assert.Error(t, err)
+	})
+}
+
+func TestDBGetReportProductivity(t *testing.T) {
+	filter := inboundorders.ReportProductivityFilter{
+		StartDate:   "2022-01-01",
+		EndDate:     "2022-01-31",
+		WarehouseId: 1,
+		GroupBy:     inboundorders.GroupByDay,
+	}
+
+	employeeColumns := []string{
+		"id",
+		"card_number_id",
+		"first_name",
+		"last_name",
+		"warehouse_id",
+		"inbound_orders_count",
+		"units_received",
+	}
+
+	periodColumns := []string{
+		"employee_id",
+		"period",
+		"inbound_orders_count",
+		"units_received",
+	}
+
+	t.Run("Get report grouped by day", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(employeeColumns).
+			AddRow(1, "765", "Iu", "ri", 1, 2, 300).
+			AddRow(2, "7656", "Iuzin", "rizin", 1, 0, 0)
+
+		periodRows := sqlmock.NewRows(periodColumns).
+			AddRow(1, "2022-01-03", 1, 100).
+			AddRow(1, "2022-01-04", 1, 200)
+
+		mock.ExpectQuery(regexp.QuoteMeta(inboundorders.QueryReportProductivity)).
+			WithArgs("2022-01-01", "2022-01-31", 1, 1).
+			WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(inboundorders.QueryReportProductivityPeriods[inboundorders.GroupByDay])).
+			WithArgs("2022-01-01", "2022-01-31", 1, 1).
+			WillReturnRows(periodRows)
+
+		inboundRepo := inboundorders.NewMariaDbRepository(db)
+
+		report, err := inboundRepo.GetReportProductivity(context.Background(), filter)
+		assert.Nil(t, err)
+
+		assert.Len(t, report, 2)
+		assert.Equal(t, 300, report[0].UnitsReceived)
+		assert.Len(t, report[0].Periods, 2)
+		assert.Equal(t, "2022-01-04", report[0].Periods[1].Period)
+		assert.Equal(t, 0, report[1].InboundOrdersCount)
+		assert.Len(t, report[1].Periods, 0)
+	})
+
+	t.Run("Get report without grouping", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(employeeColumns).
+			AddRow(1, "765", "Iu", "ri", 1, 2, 300)
+
+		mock.ExpectQuery(regexp.QuoteMeta(inboundorders.QueryReportProductivity)).WillReturnRows(rows)
+
+		inboundRepo := inboundorders.NewMariaDbRepository(db)
+
+		report, err := inboundRepo.GetReportProductivity(context.Background(), inboundorders.ReportProductivityFilter{})
+		assert.Nil(t, err)
+		assert.Len(t, report, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error to get report - case query", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(inboundorders.QueryReportProductivity)).WillReturnError(errors.New(""))
+
+		inboundRepo := inboundorders.NewMariaDbRepository(db)
+
+		_, err = inboundRepo.GetReportProductivity(context.Background(), filter)
+		assert.NotNil(t, err)
+		assert.Equal(t, "error to report productivity by employee", err.Error())
+	})
+
+	t.Run("Error to get report - case scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(employeeColumns).
+			AddRow("", "", "", "", "", "", "")
+
+		mock.ExpectQuery(regexp.QuoteMeta(inboundorders.QueryReportProductivity)).WillReturnRows(rows)
+
+		inboundRepo := inboundorders.NewMariaDbRepository(db)
+
+		_, err = inboundRepo.GetReportProductivity(context.Background(), filter)
+		assert.Error(t, err)
+	})
+
+	t.Run("Error to get report - case rows", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(employeeColumns).
+			AddRow(1, "765", "Iu", "ri", 1, 2, 300).
+			AddRow(2, "766", "Lu", "ca", 1, 1, 100).
+			RowError(1, errors.New("connection reset"))
+
+		mock.ExpectQuery(regexp.QuoteMeta(inboundorders.QueryReportProductivity)).WillReturnRows(rows)
+
+		inboundRepo := inboundorders.NewMariaDbRepository(db)
+
+		_, err = inboundRepo.GetReportProductivity(context.Background(), filter)
+		assert.Error(t, err)
+		assert.Equal(t, "error to report productivity by employee", err.Error())
+	})
+
+	t.Run("Error to get report - case periods query", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(employeeColumns).
+			AddRow(1, "765", "Iu", "ri", 1, 2, 300)
+
+		mock.ExpectQuery(regexp.QuoteMeta(inboundorders.QueryReportProductivity)).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(inboundorders.QueryReportProductivityPeriods[inboundorders.GroupByDay])).
+			WillReturnError(errors.New(""))
+
+		inboundRepo := inboundorders.NewMariaDbRepository(db)
+
+		_, err = inboundRepo.GetReportProductivity(context.Background(), filter)
+		assert.Error(t, err)
+		assert.Equal(t, "error to report productivity by employee", err.Error())
+	})
+
+	t.Run("Error to get report - case periods scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(employeeColumns).
+			AddRow(1, "765", "Iu", "ri", 1, 2, 300)
+
+		periodRows := sqlmock.NewRows(periodColumns).
+			AddRow("", "", "", "")
+
+		mock.ExpectQuery(regexp.QuoteMeta(inboundorders.QueryReportProductivity)).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(inboundorders.QueryReportProductivityPeriods[inboundorders.GroupByDay])).
+			WillReturnRows(periodRows)
+
+		inboundRepo := inboundorders.NewMariaDbRepository(db)
+
+		_, err = inboundRepo.GetReportProductivity(context.Background(), filter)
 		assert.Error(t, err)
 	})
 }
@@ -0,0 +1,105 @@
+package inboundorders
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/employees"
+	product_batches "github.com/emidioreb/mercado-fresco-lerigophers/internal/productBatches"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sections"
+)
+
+const (
+	RuleEmployeeWarehouse = "employee_warehouse"
+	RuleSectionWarehouse  = "section_warehouse"
+)
+
+// InboundOrderGraph groups the inbound order with every entity it references,
+// so consistency rules can compare them with each other.
+type InboundOrderGraph struct {
+	Order        InboundOrder
+	Employee     employees.Employee
+	ProductBatch product_batches.ProductBatches
+	Section      sections.Section
+}
+
+type ConsistencyRule struct {
+	Name  string
+	Check func(graph InboundOrderGraph) string
+}
+
+type RuleViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+type ConsistencyError struct {
+	Violations []RuleViolation
+}
+
+func (e ConsistencyError) Error() string {
+	messages := []string{}
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+	return "inconsistent inbound order: " + strings.Join(messages, "; ")
+}
+
+var (
+	EmployeeWarehouseRule = ConsistencyRule{
+		Name: RuleEmployeeWarehouse,
+		Check: func(graph InboundOrderGraph) string {
+			if graph.Employee.WarehouseId == graph.Order.WarehouseId {
+				return ""
+			}
+			return fmt.Sprintf(
+				"employee with id %d belongs to warehouse %d, not to warehouse %d",
+				graph.Employee.Id,
+				graph.Employee.WarehouseId,
+				graph.Order.WarehouseId,
+			)
+		},
+	}
+
+	SectionWarehouseRule = ConsistencyRule{
+		Name: RuleSectionWarehouse,
+		Check: func(graph InboundOrderGraph) string {
+			if graph.Section.WarehouseId == graph.Order.WarehouseId {
+				return ""
+			}
+			return fmt.Sprintf(
+				"product_batch %d is stored in section %d of warehouse %d, not of warehouse %d",
+				graph.ProductBatch.BatchNumber,
+				graph.Section.Id,
+				graph.Section.WarehouseId,
+				graph.Order.WarehouseId,
+			)
+		},
+	}
+
+	DefaultConsistencyRules = []ConsistencyRule{
+		EmployeeWarehouseRule,
+		SectionWarehouseRule,
+	}
+)
+
+// ValidateConsistency runs every rule against the graph and returns all
+// violations found, or nil when the graph is consistent.
+func ValidateConsistency(graph InboundOrderGraph, rules []ConsistencyRule) error {
+	violations := []RuleViolation{}
+
+	for _, rule := range rules {
+		if message := rule.Check(graph); message != "" {
+			violations = append(violations, RuleViolation{
+				Rule:    rule.Name,
+				Message: message,
+			})
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return ConsistencyError{Violations: violations}
+}
@@ -1,38 +1,77 @@
 package inboundorders
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/employees"
 	product_batches "github.com/emidioreb/mercado-fresco-lerigophers/internal/productBatches"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sections"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 )
 
 type Service interface {
-	CreateInboundOrders(orderNumber, orderDate string, employeeId, productBatchId, warehouseId int) (InboundOrder, web.ResponseCode)
-	GetReportInboundOrders(employeeId string) ([]ReportInboundOrder, web.ResponseCode)
+	CreateInboundOrders(ctx context.Context, orderNumber, orderDate string, employeeId, productBatchId, warehouseId int) (InboundOrder, web.ResponseCode)
+	GetReportInboundOrders(ctx context.Context, employeeId string) ([]ReportInboundOrder, web.ResponseCode)
+	GetReportProductivity(ctx context.Context, filter ReportProductivityFilter) ([]ReportProductivity, web.ResponseCode)
 }
 
+const (
+	minReportDate = "1000-01-01"
+	maxReportDate = "9999-12-31"
+)
+
 type service struct {
 	repository               Repository
 	warehouseRepository      warehouses.Repository
 	employeeRepository       employees.Repository
 	productBatchesRepository product_batches.Repository
+	sectionRepository        sections.Repository
+	transactions             transaction.Manager
+	consistencyRules         []ConsistencyRule
 }
 
-func NewService(r Repository, w warehouses.Repository, e employees.Repository, pb product_batches.Repository) Service {
+// NewService builds the inbound orders service. When no consistency rules
+// are informed, DefaultConsistencyRules are applied on creation.
+func NewService(r Repository, w warehouses.Repository, e employees.Repository, pb product_batches.Repository, sr sections.Repository, tm transaction.Manager, rules ...ConsistencyRule) Service {
+	if len(rules) == 0 {
+		rules = DefaultConsistencyRules
+	}
+
 	return &service{
 		repository:               r,
 		warehouseRepository:      w,
 		employeeRepository:       e,
 		productBatchesRepository: pb,
+		sectionRepository:        sr,
+		transactions:             tm,
+		consistencyRules:         rules,
+	}
+}
+
+// CreateInboundOrders reads the employee, warehouse, product batch and
+// section of the order, checks them against the consistency rules and
+// inserts the order in one transaction, so none of them changes in between.
+func (s service) CreateInboundOrders(ctx context.Context, orderNumber, orderDate string, employeeId, productBatchId, warehouseId int) (InboundOrder, web.ResponseCode) {
+	var inboundOrder InboundOrder
+	var resp web.ResponseCode
+
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		inboundOrder, resp = s.createInboundOrders(ctx, orderNumber, orderDate, employeeId, productBatchId, warehouseId)
+		return resp.Err
+	})
+	if err != nil && resp.Err == nil {
+		return InboundOrder{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
+
+	return inboundOrder, resp
 }
 
-func (s service) CreateInboundOrders(orderNumber, orderDate string, employeeId, productBatchId, warehouseId int) (InboundOrder, web.ResponseCode) {
-	_, errEmployee := s.employeeRepository.GetOne(employeeId)
+func (s service) createInboundOrders(ctx context.Context, orderNumber, orderDate string, employeeId, productBatchId, warehouseId int) (InboundOrder, web.ResponseCode) {
+	employee, errEmployee := s.employeeRepository.GetOne(ctx, employeeId)
 	if errEmployee != nil {
 		if errEmployee.Error() == fmt.Sprintf("employee with id %d not found", employeeId) {
 			return InboundOrder{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errEmployee)
@@ -40,7 +79,7 @@ func (s service) CreateInboundOrders(orderNumber, orderDate string, employeeId,
 		return InboundOrder{}, web.NewCodeResponse(http.StatusInternalServerError, errEmployee)
 	}
 
-	_, errWarehouse := s.warehouseRepository.GetOne(warehouseId)
+	_, errWarehouse := s.warehouseRepository.GetOne(ctx, warehouseId)
 	if errWarehouse != nil {
 		if errWarehouse.Error() == fmt.Sprintf("warehouse with id %d not found", warehouseId) {
 			return InboundOrder{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errWarehouse)
@@ -48,7 +87,7 @@ func (s service) CreateInboundOrders(orderNumber, orderDate string, employeeId,
 		return InboundOrder{}, web.NewCodeResponse(http.StatusInternalServerError, errWarehouse)
 	}
 
-	_, errProductBat := s.productBatchesRepository.GetOne(productBatchId)
+	productBatch, errProductBat := s.productBatchesRepository.GetOne(ctx, productBatchId)
 	if errProductBat != nil {
 		if errProductBat.Error() == fmt.Sprintf("product_batch with batch_number %d not found", productBatchId) {
 			return InboundOrder{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errProductBat)
@@ -56,7 +95,32 @@ func (s service) CreateInboundOrders(orderNumber, orderDate string, employeeId,
 		return InboundOrder{}, web.NewCodeResponse(http.StatusInternalServerError, errProductBat)
 	}
 
-	result, err := s.repository.CreateInboundOrders(orderNumber, orderDate, employeeId, productBatchId, warehouseId)
+	section, errSection := s.sectionRepository.GetOne(ctx, productBatch.SectionId)
+	if errSection != nil {
+		if errSection.Error() == sections.GetErrSectionNotFound(productBatch.SectionId).Error() {
+			return InboundOrder{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errSection)
+		}
+		return InboundOrder{}, web.NewCodeResponse(http.StatusInternalServerError, errSection)
+	}
+
+	graph := InboundOrderGraph{
+		Order: InboundOrder{
+			OrderNumber:    orderNumber,
+			OrderDate:      orderDate,
+			EmployeeId:     employeeId,
+			ProductBatchId: productBatchId,
+			WarehouseId:    warehouseId,
+		},
+		Employee:     employee,
+		ProductBatch: productBatch,
+		Section:      section,
+	}
+
+	if err := ValidateConsistency(graph, s.consistencyRules); err != nil {
+		return InboundOrder{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+	}
+
+	result, err := s.repository.CreateInboundOrders(ctx, orderNumber, orderDate, employeeId, productBatchId, warehouseId)
 	if err != nil {
 		return InboundOrder{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
@@ -64,8 +128,8 @@ func (s service) CreateInboundOrders(orderNumber, orderDate string, employeeId,
 	return result, web.NewCodeResponse(http.StatusCreated, nil)
 }
 
-func (s service) GetReportInboundOrders(employeeId string) ([]ReportInboundOrder, web.ResponseCode) {
-	report, err := s.repository.GetReportInboundOrders(employeeId)
+func (s service) GetReportInboundOrders(ctx context.Context, employeeId string) ([]ReportInboundOrder, web.ResponseCode) {
+	report, err := s.repository.GetReportInboundOrders(ctx, employeeId)
 
 	if err != nil {
 		return []ReportInboundOrder{}, web.NewCodeResponse(http.StatusInternalServerError, err)
@@ -73,3 +137,46 @@ func (s service) GetReportInboundOrders(employeeId string) ([]ReportInboundOrder
 
 	return report, web.NewCodeResponse(http.StatusOK, nil)
 }
+
+func (s service) GetReportProductivity(ctx context.Context, filter ReportProductivityFilter) ([]ReportProductivity, web.ResponseCode) {
+	if filter.WarehouseId != 0 {
+		if _, err := s.warehouseRepository.GetOne(ctx, filter.WarehouseId); err != nil {
+			if err.Error() == fmt.Sprintf("warehouse with id %d not found", filter.WarehouseId) {
+				return []ReportProductivity{}, web.NewCodeResponse(http.StatusNotFound, err)
+			}
+			return []ReportProductivity{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+		}
+	}
+
+	if filter.StartDate == "" {
+		filter.StartDate = minReportDate
+	}
+
+	if filter.EndDate == "" {
+		filter.EndDate = maxReportDate
+	}
+
+	report, err := s.repository.GetReportProductivity(ctx, filter)
+	if err != nil {
+		return []ReportProductivity{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	rankByWarehouse(report)
+
+	return report, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+// rankByWarehouse sets WarehouseRank comparing inbound orders count between
+// employees of the same warehouse. Ties share the same position.
+func rankByWarehouse(report []ReportProductivity) {
+	for i := range report {
+		rank := 1
+		for j := range report {
+			if report[j].WarehouseId == report[i].WarehouseId &&
+				report[j].InboundOrdersCount > report[i].InboundOrdersCount {
+				rank++
+			}
+		}
+		report[i].WarehouseRank = rank
+	}
+}
@@ -1,6 +1,7 @@
 package inboundorders_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -10,8 +11,11 @@ import (
 	inboundOrdersMock "github.com/emidioreb/mercado-fresco-lerigophers/internal/inboundOrders/mocks"
 	product_batches "github.com/emidioreb/mercado-fresco-lerigophers/internal/productBatches"
 	productBatchesRepository "github.com/emidioreb/mercado-fresco-lerigophers/internal/productBatches/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sections"
+	sectionRepository "github.com/emidioreb/mercado-fresco-lerigophers/internal/sections/mocks"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses"
 	warehouseRepository "github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -60,24 +64,35 @@ func TestServiceCreate(t *testing.T) {
 		employeeRepo := new(employeeRepository.Repository)
 		warehouseRepo := new(warehouseRepository.Repository)
 		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
 
 		employeeRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
-		).Return(employees.Employee{}, nil)
+		).Return(employees.Employee{WarehouseId: 1}, nil)
 
 		warehouseRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(warehouses.Warehouse{}, nil)
 
 		productBatcheRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
-		).Return(product_batches.ProductBatches{}, nil)
+		).Return(product_batches.ProductBatches{SectionId: 1}, nil)
+
+		sectionRepo.On(
+			"GetOne",
+			mock.Anything,
+			mock.AnythingOfType("int"),
+		).Return(sections.Section{Id: 1, WarehouseId: 1}, nil)
 
 		mockedRepository.On(
 			"CreateInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
@@ -85,8 +100,9 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("int"),
 		).Return(fakeInbounds[0], nil)
 
-		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo)
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
 		result, err := service.CreateInboundOrders(
+			context.Background(),
 			fakeInbounds[0].OrderNumber,
 			fakeInbounds[0].OrderDate,
 			fakeInbounds[0].EmployeeId,
@@ -104,24 +120,29 @@ func TestServiceCreate(t *testing.T) {
 		employeeRepo := new(employeeRepository.Repository)
 		warehouseRepo := new(warehouseRepository.Repository)
 		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
 
 		employeeRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(employees.Employee{}, errors.New("employee with id 1 not found"))
 
 		warehouseRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(warehouses.Warehouse{}, nil)
 
 		productBatcheRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(product_batches.ProductBatches{}, nil)
 
 		mockedRepository.On(
 			"CreateInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
@@ -129,8 +150,9 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("int"),
 		).Return(inboundOrdersInternal.InboundOrder{}, nil)
 
-		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo)
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
 		_, err := service.CreateInboundOrders(
+			context.Background(),
 			fakeInbounds[0].OrderNumber,
 			fakeInbounds[0].OrderDate,
 			fakeInbounds[0].EmployeeId,
@@ -148,24 +170,29 @@ func TestServiceCreate(t *testing.T) {
 		employeeRepo := new(employeeRepository.Repository)
 		warehouseRepo := new(warehouseRepository.Repository)
 		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
 
 		employeeRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(employees.Employee{}, errors.New("unexpected error to get employee"))
 
 		warehouseRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(warehouses.Warehouse{}, nil)
 
 		productBatcheRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(product_batches.ProductBatches{}, nil)
 
 		mockedRepository.On(
 			"CreateInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
@@ -173,8 +200,9 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("int"),
 		).Return(inboundOrdersInternal.InboundOrder{}, nil)
 
-		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo)
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
 		_, err := service.CreateInboundOrders(
+			context.Background(),
 			fakeInbounds[0].OrderNumber,
 			fakeInbounds[0].OrderDate,
 			fakeInbounds[0].EmployeeId,
@@ -192,24 +220,29 @@ func TestServiceCreate(t *testing.T) {
 		employeeRepo := new(employeeRepository.Repository)
 		warehouseRepo := new(warehouseRepository.Repository)
 		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
 
 		employeeRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(employees.Employee{}, nil)
 
 		warehouseRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(warehouses.Warehouse{}, errors.New("warehouse with id 1 not found"))
 
 		productBatcheRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(product_batches.ProductBatches{}, nil)
 
 		mockedRepository.On(
 			"CreateInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
@@ -217,8 +250,9 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("int"),
 		).Return(inboundOrdersInternal.InboundOrder{}, nil)
 
-		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo)
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
 		_, err := service.CreateInboundOrders(
+			context.Background(),
 			fakeInbounds[0].OrderNumber,
 			fakeInbounds[0].OrderDate,
 			fakeInbounds[0].EmployeeId,
@@ -236,24 +270,29 @@ func TestServiceCreate(t *testing.T) {
 		employeeRepo := new(employeeRepository.Repository)
 		warehouseRepo := new(warehouseRepository.Repository)
 		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
 
 		employeeRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(employees.Employee{}, nil)
 
 		warehouseRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(warehouses.Warehouse{}, errors.New("error"))
 
 		productBatcheRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(product_batches.ProductBatches{}, nil)
 
 		mockedRepository.On(
 			"CreateInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
@@ -261,8 +300,9 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("int"),
 		).Return(inboundOrdersInternal.InboundOrder{}, nil)
 
-		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo)
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
 		_, err := service.CreateInboundOrders(
+			context.Background(),
 			fakeInbounds[0].OrderNumber,
 			fakeInbounds[0].OrderDate,
 			fakeInbounds[0].EmployeeId,
@@ -280,24 +320,29 @@ func TestServiceCreate(t *testing.T) {
 		employeeRepo := new(employeeRepository.Repository)
 		warehouseRepo := new(warehouseRepository.Repository)
 		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
 
 		employeeRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(employees.Employee{}, nil)
 
 		warehouseRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(warehouses.Warehouse{}, nil)
 
 		productBatcheRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(product_batches.ProductBatches{}, errors.New("product_batch with batch_number 1 not found"))
 
 		mockedRepository.On(
 			"CreateInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
@@ -305,8 +350,9 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("int"),
 		).Return(inboundOrdersInternal.InboundOrder{}, nil)
 
-		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo)
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
 		_, err := service.CreateInboundOrders(
+			context.Background(),
 			fakeInbounds[0].OrderNumber,
 			fakeInbounds[0].OrderDate,
 			fakeInbounds[0].EmployeeId,
@@ -324,24 +370,29 @@ func TestServiceCreate(t *testing.T) {
 		employeeRepo := new(employeeRepository.Repository)
 		warehouseRepo := new(warehouseRepository.Repository)
 		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
 
 		employeeRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(employees.Employee{}, nil)
 
 		warehouseRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(warehouses.Warehouse{}, nil)
 
 		productBatcheRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(product_batches.ProductBatches{}, errors.New("error"))
 
 		mockedRepository.On(
 			"CreateInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
@@ -349,8 +400,9 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("int"),
 		).Return(inboundOrdersInternal.InboundOrder{}, nil)
 
-		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo)
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
 		_, err := service.CreateInboundOrders(
+			context.Background(),
 			fakeInbounds[0].OrderNumber,
 			fakeInbounds[0].OrderDate,
 			fakeInbounds[0].EmployeeId,
@@ -368,24 +420,35 @@ func TestServiceCreate(t *testing.T) {
 		employeeRepo := new(employeeRepository.Repository)
 		warehouseRepo := new(warehouseRepository.Repository)
 		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
 
 		employeeRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
-		).Return(employees.Employee{}, nil)
+		).Return(employees.Employee{WarehouseId: 1}, nil)
 
 		warehouseRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 		).Return(warehouses.Warehouse{}, nil)
 
 		productBatcheRepo.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int"),
-		).Return(product_batches.ProductBatches{}, nil)
+		).Return(product_batches.ProductBatches{SectionId: 1}, nil)
+
+		sectionRepo.On(
+			"GetOne",
+			mock.Anything,
+			mock.AnythingOfType("int"),
+		).Return(sections.Section{Id: 1, WarehouseId: 1}, nil)
 
 		mockedRepository.On(
 			"CreateInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("int"),
@@ -393,8 +456,9 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("int"),
 		).Return(inboundOrdersInternal.InboundOrder{}, errors.New("error"))
 
-		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo)
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
 		_, err := service.CreateInboundOrders(
+			context.Background(),
 			fakeInbounds[0].OrderNumber,
 			fakeInbounds[0].OrderDate,
 			fakeInbounds[0].EmployeeId,
@@ -408,20 +472,130 @@ func TestServiceCreate(t *testing.T) {
 	})
 }
 
+func TestServiceCreateConsistency(t *testing.T) {
+	newMocks := func(employee employees.Employee, section sections.Section, sectionErr error) (
+		*inboundOrdersMock.Repository,
+		*warehouseRepository.Repository,
+		*employeeRepository.Repository,
+		*productBatchesRepository.Repository,
+		*sectionRepository.Repository,
+	) {
+		mockedRepository := new(inboundOrdersMock.Repository)
+		employeeRepo := new(employeeRepository.Repository)
+		warehouseRepo := new(warehouseRepository.Repository)
+		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
+
+		employeeRepo.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(employee, nil)
+		warehouseRepo.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, nil)
+		productBatcheRepo.On("GetOne", mock.Anything, mock.AnythingOfType("int")).
+			Return(product_batches.ProductBatches{BatchNumber: 1, SectionId: 7}, nil)
+		sectionRepo.On("GetOne", mock.Anything, 7).Return(section, sectionErr)
+
+		return mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo
+	}
+
+	t.Run("Test if all violations are reported together", func(t *testing.T) {
+		mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo := newMocks(
+			employees.Employee{Id: 1, WarehouseId: 2},
+			sections.Section{Id: 7, WarehouseId: 3},
+			nil,
+		)
+
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
+		_, resp := service.CreateInboundOrders(context.Background(), "43", "2006-01-02", 1, 1, 1)
+
+		var consistencyErr inboundOrdersInternal.ConsistencyError
+		assert.True(t, errors.As(resp.Err, &consistencyErr))
+		assert.Equal(t, 422, resp.Code)
+		assert.Equal(t, []inboundOrdersInternal.RuleViolation{
+			{
+				Rule:    inboundOrdersInternal.RuleEmployeeWarehouse,
+				Message: "employee with id 1 belongs to warehouse 2, not to warehouse 1",
+			},
+			{
+				Rule:    inboundOrdersInternal.RuleSectionWarehouse,
+				Message: "product_batch 1 is stored in section 7 of warehouse 3, not of warehouse 1",
+			},
+		}, consistencyErr.Violations)
+	})
+
+	t.Run("Test if only configured rules are applied", func(t *testing.T) {
+		mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo := newMocks(
+			employees.Employee{Id: 1, WarehouseId: 2},
+			sections.Section{Id: 7, WarehouseId: 1},
+			nil,
+		)
+
+		mockedRepository.On(
+			"CreateInboundOrders",
+			mock.Anything,
+			mock.AnythingOfType("string"),
+			mock.AnythingOfType("string"),
+			mock.AnythingOfType("int"),
+			mock.AnythingOfType("int"),
+			mock.AnythingOfType("int"),
+		).Return(fakeInbounds[0], nil)
+
+		service := inboundOrdersInternal.NewService(
+			mockedRepository,
+			warehouseRepo,
+			employeeRepo,
+			productBatcheRepo,
+			sectionRepo,
+			transaction.Nop,
+			inboundOrdersInternal.SectionWarehouseRule,
+		)
+		_, resp := service.CreateInboundOrders(context.Background(), "43", "2006-01-02", 1, 1, 1)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, 201, resp.Code)
+	})
+
+	t.Run("Test if section dont exists", func(t *testing.T) {
+		mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo := newMocks(
+			employees.Employee{Id: 1, WarehouseId: 1},
+			sections.Section{},
+			sections.GetErrSectionNotFound(7),
+		)
+
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
+		_, resp := service.CreateInboundOrders(context.Background(), "43", "2006-01-02", 1, 1, 1)
+
+		assert.Equal(t, 422, resp.Code)
+		assert.Equal(t, "section with id 7 not found", resp.Err.Error())
+	})
+
+	t.Run("Test if section error", func(t *testing.T) {
+		mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo := newMocks(
+			employees.Employee{Id: 1, WarehouseId: 1},
+			sections.Section{},
+			errors.New("error"),
+		)
+
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
+		_, resp := service.CreateInboundOrders(context.Background(), "43", "2006-01-02", 1, 1, 1)
+
+		assert.Equal(t, 500, resp.Code)
+	})
+}
+
 func TestServiceGet(t *testing.T) {
 	t.Run("Test if getreport success", func(t *testing.T) {
 		mockedRepository := new(inboundOrdersMock.Repository)
 		employeeRepo := new(employeeRepository.Repository)
 		warehouseRepo := new(warehouseRepository.Repository)
 		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
 
 		mockedRepository.On(
 			"GetReportInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 		).Return(fakeReports, nil)
 
-		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo)
-		result, err := service.GetReportInboundOrders("1")
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
+		result, err := service.GetReportInboundOrders(context.Background(), "1")
 
 		assert.Nil(t, err.Err)
 
@@ -433,17 +607,123 @@ func TestServiceGet(t *testing.T) {
 		employeeRepo := new(employeeRepository.Repository)
 		warehouseRepo := new(warehouseRepository.Repository)
 		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
 
 		mockedRepository.On(
 			"GetReportInboundOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 		).Return([]inboundOrdersInternal.ReportInboundOrder{}, errors.New("error"))
 
-		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo)
-		_, err := service.GetReportInboundOrders("1")
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
+		_, err := service.GetReportInboundOrders(context.Background(), "1")
 
 		assert.NotNil(t, err.Err)
 
 		assert.Equal(t, err.Err, errors.New("error"))
 	})
 }
+
+func TestServiceGetReportProductivity(t *testing.T) {
+	fakeProductivity := []inboundOrdersInternal.ReportProductivity{
+		{Id: 1, WarehouseId: 1, InboundOrdersCount: 3},
+		{Id: 2, WarehouseId: 1, InboundOrdersCount: 5},
+		{Id: 3, WarehouseId: 2, InboundOrdersCount: 0},
+		{Id: 4, WarehouseId: 1, InboundOrdersCount: 3},
+	}
+
+	t.Run("Test if ranks employees by warehouse", func(t *testing.T) {
+		mockedRepository := new(inboundOrdersMock.Repository)
+		employeeRepo := new(employeeRepository.Repository)
+		warehouseRepo := new(warehouseRepository.Repository)
+		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
+
+		report := make([]inboundOrdersInternal.ReportProductivity, len(fakeProductivity))
+		copy(report, fakeProductivity)
+
+		mockedRepository.On(
+			"GetReportProductivity",
+			mock.Anything,
+			inboundOrdersInternal.ReportProductivityFilter{
+				StartDate: "1000-01-01",
+				EndDate:   "9999-12-31",
+			},
+		).Return(report, nil)
+
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
+		result, resp := service.GetReportProductivity(context.Background(), inboundOrdersInternal.ReportProductivityFilter{})
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, 200, resp.Code)
+		assert.Equal(t, 2, result[0].WarehouseRank)
+		assert.Equal(t, 1, result[1].WarehouseRank)
+		assert.Equal(t, 1, result[2].WarehouseRank)
+		assert.Equal(t, 2, result[3].WarehouseRank)
+	})
+
+	t.Run("Test if warehouse dont exists", func(t *testing.T) {
+		mockedRepository := new(inboundOrdersMock.Repository)
+		employeeRepo := new(employeeRepository.Repository)
+		warehouseRepo := new(warehouseRepository.Repository)
+		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
+
+		warehouseRepo.On(
+			"GetOne",
+			mock.Anything,
+			mock.AnythingOfType("int"),
+		).Return(warehouses.Warehouse{}, errors.New("warehouse with id 1 not found"))
+
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
+		_, resp := service.GetReportProductivity(context.Background(), inboundOrdersInternal.ReportProductivityFilter{WarehouseId: 1})
+
+		assert.Equal(t, 404, resp.Code)
+		assert.Equal(t, errors.New("warehouse with id 1 not found"), resp.Err)
+	})
+
+	t.Run("Test if warehouse error", func(t *testing.T) {
+		mockedRepository := new(inboundOrdersMock.Repository)
+		employeeRepo := new(employeeRepository.Repository)
+		warehouseRepo := new(warehouseRepository.Repository)
+		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
+
+		warehouseRepo.On(
+			"GetOne",
+			mock.Anything,
+			mock.AnythingOfType("int"),
+		).Return(warehouses.Warehouse{}, errors.New("error"))
+
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
+		_, resp := service.GetReportProductivity(context.Background(), inboundOrdersInternal.ReportProductivityFilter{WarehouseId: 1})
+
+		assert.Equal(t, 500, resp.Code)
+	})
+
+	t.Run("Test if getreport error", func(t *testing.T) {
+		mockedRepository := new(inboundOrdersMock.Repository)
+		employeeRepo := new(employeeRepository.Repository)
+		warehouseRepo := new(warehouseRepository.Repository)
+		productBatcheRepo := new(productBatchesRepository.Repository)
+		sectionRepo := new(sectionRepository.Repository)
+
+		warehouseRepo.On(
+			"GetOne",
+			mock.Anything,
+			mock.AnythingOfType("int"),
+		).Return(warehouses.Warehouse{}, nil)
+
+		mockedRepository.On(
+			"GetReportProductivity",
+			mock.Anything,
+			mock.Anything,
+		).Return([]inboundOrdersInternal.ReportProductivity{}, errors.New("error"))
+
+		service := inboundOrdersInternal.NewService(mockedRepository, warehouseRepo, employeeRepo, productBatcheRepo, sectionRepo, transaction.Nop)
+		_, resp := service.GetReportProductivity(context.Background(), inboundOrdersInternal.ReportProductivityFilter{WarehouseId: 1})
+
+		assert.Equal(t, 500, resp.Code)
+		assert.Equal(t, errors.New("error"), resp.Err)
+	})
+}
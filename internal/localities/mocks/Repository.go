@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	localities "github.com/emidioreb/mercado-fresco-lerigophers/internal/localities"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,20 +14,20 @@ type Repository struct {
 	mock.Mock
 }
 
-// CreateLocality provides a mock function with given fields: id, localityName, provinceName, countryName
-func (_m *Repository) CreateLocality(id string, localityName string, provinceName string, countryName string) (localities.Locality, error) {
-	ret := _m.Called(id, localityName, provinceName, countryName)
+// CreateLocality provides a mock function with given fields: ctx, id, localityName, provinceName, countryName, latitude, longitude
+func (_m *Repository) CreateLocality(ctx context.Context, id string, localityName string, provinceName string, countryName string, latitude *float64, longitude *float64) (localities.Locality, error) {
+	ret := _m.Called(ctx, id, localityName, provinceName, countryName, latitude, longitude)
 
 	var r0 localities.Locality
-	if rf, ok := ret.Get(0).(func(string, string, string, string) localities.Locality); ok {
-		r0 = rf(id, localityName, provinceName, countryName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, *float64, *float64) localities.Locality); ok {
+		r0 = rf(ctx, id, localityName, provinceName, countryName, latitude, longitude)
 	} else {
 		r0 = ret.Get(0).(localities.Locality)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, string) error); ok {
-		r1 = rf(id, localityName, provinceName, countryName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, *float64, *float64) error); ok {
+		r1 = rf(ctx, id, localityName, provinceName, countryName, latitude, longitude)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,20 +35,126 @@ func (_m *Repository) CreateLocality(id string, localityName string, provinceNam
 	return r0, r1
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Repository) GetOne(id string) (localities.Locality, error) {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Repository) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCountries provides a mock function with given fields: ctx
+func (_m *Repository) GetCountries(ctx context.Context) ([]localities.Country, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []localities.Country
+	if rf, ok := ret.Get(0).(func(context.Context) []localities.Country); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]localities.Country)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCoverageReport provides a mock function with given fields: ctx, filters
+func (_m *Repository) GetCoverageReport(ctx context.Context, filters localities.CoverageFilters) ([]localities.LocalityCoverage, error) {
+	ret := _m.Called(ctx, filters)
+
+	var r0 []localities.LocalityCoverage
+	if rf, ok := ret.Get(0).(func(context.Context, localities.CoverageFilters) []localities.LocalityCoverage); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]localities.LocalityCoverage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, localities.CoverageFilters) error); ok {
+		r1 = rf(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLocalities provides a mock function with given fields: ctx, countryName, provinceName
+func (_m *Repository) GetLocalities(ctx context.Context, countryName string, provinceName string) ([]localities.LocalityDetail, error) {
+	ret := _m.Called(ctx, countryName, provinceName)
+
+	var r0 []localities.LocalityDetail
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []localities.LocalityDetail); ok {
+		r0 = rf(ctx, countryName, provinceName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]localities.LocalityDetail)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, countryName, provinceName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Repository) GetOne(ctx context.Context, id string) (localities.Locality, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 localities.Locality
-	if rf, ok := ret.Get(0).(func(string) localities.Locality); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) localities.Locality); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(localities.Locality)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProvinces provides a mock function with given fields: ctx, countryName
+func (_m *Repository) GetProvinces(ctx context.Context, countryName string) ([]localities.Province, error) {
+	ret := _m.Called(ctx, countryName)
+
+	var r0 []localities.Province
+	if rf, ok := ret.Get(0).(func(context.Context, string) []localities.Province); ok {
+		r0 = rf(ctx, countryName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]localities.Province)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, countryName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -54,13 +162,13 @@ func (_m *Repository) GetOne(id string) (localities.Locality, error) {
 	return r0, r1
 }
 
-// GetReportCarriers provides a mock function with given fields: localityId
-func (_m *Repository) GetReportCarriers(localityId string) ([]localities.ReportCarriers, error) {
-	ret := _m.Called(localityId)
+// GetReportCarriers provides a mock function with given fields: ctx, localityId
+func (_m *Repository) GetReportCarriers(ctx context.Context, localityId string) ([]localities.ReportCarriers, error) {
+	ret := _m.Called(ctx, localityId)
 
 	var r0 []localities.ReportCarriers
-	if rf, ok := ret.Get(0).(func(string) []localities.ReportCarriers); ok {
-		r0 = rf(localityId)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []localities.ReportCarriers); ok {
+		r0 = rf(ctx, localityId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]localities.ReportCarriers)
@@ -68,8 +176,8 @@ func (_m *Repository) GetReportCarriers(localityId string) ([]localities.ReportC
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(localityId)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, localityId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -77,13 +185,13 @@ func (_m *Repository) GetReportCarriers(localityId string) ([]localities.ReportC
 	return r0, r1
 }
 
-// GetReportSellers provides a mock function with given fields: localityId
-func (_m *Repository) GetReportSellers(localityId string) ([]localities.ReportSellers, error) {
-	ret := _m.Called(localityId)
+// GetReportSellers provides a mock function with given fields: ctx, localityId
+func (_m *Repository) GetReportSellers(ctx context.Context, localityId string) ([]localities.ReportSellers, error) {
+	ret := _m.Called(ctx, localityId)
 
 	var r0 []localities.ReportSellers
-	if rf, ok := ret.Get(0).(func(string) []localities.ReportSellers); ok {
-		r0 = rf(localityId)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []localities.ReportSellers); ok {
+		r0 = rf(ctx, localityId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]localities.ReportSellers)
@@ -91,8 +199,50 @@ func (_m *Repository) GetReportSellers(localityId string) ([]localities.ReportSe
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(localityId)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, localityId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsage provides a mock function with given fields: ctx, id
+func (_m *Repository) GetUsage(ctx context.Context, id string) (localities.LocalityUsage, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 localities.LocalityUsage
+	if rf, ok := ret.Get(0).(func(context.Context, string) localities.LocalityUsage); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(localities.LocalityUsage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Repository) Update(ctx context.Context, id string, requestData map[string]interface{}) (localities.Locality, error) {
+	ret := _m.Called(ctx, id, requestData)
+
+	var r0 localities.Locality
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]interface{}) localities.Locality); ok {
+		r0 = rf(ctx, id, requestData)
+	} else {
+		r0 = ret.Get(0).(localities.Locality)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, map[string]interface{}) error); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Error(1)
 	}
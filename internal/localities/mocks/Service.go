@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	localities "github.com/emidioreb/mercado-fresco-lerigophers/internal/localities"
 	mock "github.com/stretchr/testify/mock"
 
@@ -14,20 +16,20 @@ type Service struct {
 	mock.Mock
 }
 
-// CreateLocality provides a mock function with given fields: id, localityName, provinceName, countryName
-func (_m *Service) CreateLocality(id string, localityName string, provinceName string, countryName string) (localities.Locality, web.ResponseCode) {
-	ret := _m.Called(id, localityName, provinceName, countryName)
+// CreateLocality provides a mock function with given fields: ctx, id, localityName, provinceName, countryName, latitude, longitude
+func (_m *Service) CreateLocality(ctx context.Context, id string, localityName string, provinceName string, countryName string, latitude *float64, longitude *float64) (localities.Locality, web.ResponseCode) {
+	ret := _m.Called(ctx, id, localityName, provinceName, countryName, latitude, longitude)
 
 	var r0 localities.Locality
-	if rf, ok := ret.Get(0).(func(string, string, string, string) localities.Locality); ok {
-		r0 = rf(id, localityName, provinceName, countryName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, *float64, *float64) localities.Locality); ok {
+		r0 = rf(ctx, id, localityName, provinceName, countryName, latitude, longitude)
 	} else {
 		r0 = ret.Get(0).(localities.Locality)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(string, string, string, string) web.ResponseCode); ok {
-		r1 = rf(id, localityName, provinceName, countryName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, *float64, *float64) web.ResponseCode); ok {
+		r1 = rf(ctx, id, localityName, provinceName, countryName, latitude, longitude)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -35,13 +37,27 @@ func (_m *Service) CreateLocality(id string, localityName string, provinceName s
 	return r0, r1
 }
 
-// GetAllReportSellers provides a mock function with given fields:
-func (_m *Service) GetAllReportSellers() ([]localities.ReportSellers, web.ResponseCode) {
-	ret := _m.Called()
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Service) Delete(ctx context.Context, id string) web.ResponseCode {
+	ret := _m.Called(ctx, id)
+
+	var r0 web.ResponseCode
+	if rf, ok := ret.Get(0).(func(context.Context, string) web.ResponseCode); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(web.ResponseCode)
+	}
+
+	return r0
+}
+
+// GetAllReportSellers provides a mock function with given fields: ctx
+func (_m *Service) GetAllReportSellers(ctx context.Context) ([]localities.ReportSellers, web.ResponseCode) {
+	ret := _m.Called(ctx)
 
 	var r0 []localities.ReportSellers
-	if rf, ok := ret.Get(0).(func() []localities.ReportSellers); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []localities.ReportSellers); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]localities.ReportSellers)
@@ -49,8 +65,54 @@ func (_m *Service) GetAllReportSellers() ([]localities.ReportSellers, web.Respon
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func() web.ResponseCode); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) web.ResponseCode); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetCountries provides a mock function with given fields: ctx
+func (_m *Service) GetCountries(ctx context.Context) ([]localities.Country, web.ResponseCode) {
+	ret := _m.Called(ctx)
+
+	var r0 []localities.Country
+	if rf, ok := ret.Get(0).(func(context.Context) []localities.Country); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]localities.Country)
+		}
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context) web.ResponseCode); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetCoverageReport provides a mock function with given fields: ctx, filters
+func (_m *Service) GetCoverageReport(ctx context.Context, filters localities.CoverageFilters) ([]localities.LocalityCoverage, web.ResponseCode) {
+	ret := _m.Called(ctx, filters)
+
+	var r0 []localities.LocalityCoverage
+	if rf, ok := ret.Get(0).(func(context.Context, localities.CoverageFilters) []localities.LocalityCoverage); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]localities.LocalityCoverage)
+		}
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, localities.CoverageFilters) web.ResponseCode); ok {
+		r1 = rf(ctx, filters)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -58,13 +120,80 @@ func (_m *Service) GetAllReportSellers() ([]localities.ReportSellers, web.Respon
 	return r0, r1
 }
 
-// GetReportCarriers provides a mock function with given fields: localityId
-func (_m *Service) GetReportCarriers(localityId string) ([]localities.ReportCarriers, web.ResponseCode) {
-	ret := _m.Called(localityId)
+// GetLocalities provides a mock function with given fields: ctx, countryName, provinceName
+func (_m *Service) GetLocalities(ctx context.Context, countryName string, provinceName string) ([]localities.LocalityDetail, web.ResponseCode) {
+	ret := _m.Called(ctx, countryName, provinceName)
+
+	var r0 []localities.LocalityDetail
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []localities.LocalityDetail); ok {
+		r0 = rf(ctx, countryName, provinceName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]localities.LocalityDetail)
+		}
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) web.ResponseCode); ok {
+		r1 = rf(ctx, countryName, provinceName)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Service) GetOne(ctx context.Context, id string) (localities.Locality, web.ResponseCode) {
+	ret := _m.Called(ctx, id)
+
+	var r0 localities.Locality
+	if rf, ok := ret.Get(0).(func(context.Context, string) localities.Locality); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(localities.Locality)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, string) web.ResponseCode); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetProvinces provides a mock function with given fields: ctx, countryName
+func (_m *Service) GetProvinces(ctx context.Context, countryName string) ([]localities.Province, web.ResponseCode) {
+	ret := _m.Called(ctx, countryName)
+
+	var r0 []localities.Province
+	if rf, ok := ret.Get(0).(func(context.Context, string) []localities.Province); ok {
+		r0 = rf(ctx, countryName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]localities.Province)
+		}
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, string) web.ResponseCode); ok {
+		r1 = rf(ctx, countryName)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetReportCarriers provides a mock function with given fields: ctx, localityId
+func (_m *Service) GetReportCarriers(ctx context.Context, localityId string) ([]localities.ReportCarriers, web.ResponseCode) {
+	ret := _m.Called(ctx, localityId)
 
 	var r0 []localities.ReportCarriers
-	if rf, ok := ret.Get(0).(func(string) []localities.ReportCarriers); ok {
-		r0 = rf(localityId)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []localities.ReportCarriers); ok {
+		r0 = rf(ctx, localityId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]localities.ReportCarriers)
@@ -72,8 +201,8 @@ func (_m *Service) GetReportCarriers(localityId string) ([]localities.ReportCarr
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(string) web.ResponseCode); ok {
-		r1 = rf(localityId)
+	if rf, ok := ret.Get(1).(func(context.Context, string) web.ResponseCode); ok {
+		r1 = rf(ctx, localityId)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -81,13 +210,13 @@ func (_m *Service) GetReportCarriers(localityId string) ([]localities.ReportCarr
 	return r0, r1
 }
 
-// GetReportOneSeller provides a mock function with given fields: localityId
-func (_m *Service) GetReportOneSeller(localityId string) ([]localities.ReportSellers, web.ResponseCode) {
-	ret := _m.Called(localityId)
+// GetReportOneSeller provides a mock function with given fields: ctx, localityId
+func (_m *Service) GetReportOneSeller(ctx context.Context, localityId string) ([]localities.ReportSellers, web.ResponseCode) {
+	ret := _m.Called(ctx, localityId)
 
 	var r0 []localities.ReportSellers
-	if rf, ok := ret.Get(0).(func(string) []localities.ReportSellers); ok {
-		r0 = rf(localityId)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []localities.ReportSellers); ok {
+		r0 = rf(ctx, localityId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]localities.ReportSellers)
@@ -95,8 +224,29 @@ func (_m *Service) GetReportOneSeller(localityId string) ([]localities.ReportSel
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(string) web.ResponseCode); ok {
-		r1 = rf(localityId)
+	if rf, ok := ret.Get(1).(func(context.Context, string) web.ResponseCode); ok {
+		r1 = rf(ctx, localityId)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Service) Update(ctx context.Context, id string, requestData map[string]interface{}) (localities.Locality, web.ResponseCode) {
+	ret := _m.Called(ctx, id, requestData)
+
+	var r0 localities.Locality
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]interface{}) localities.Locality); ok {
+		r0 = rf(ctx, id, requestData)
+	} else {
+		r0 = ret.Get(0).(localities.Locality)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, string, map[string]interface{}) web.ResponseCode); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
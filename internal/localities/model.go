@@ -5,6 +5,10 @@ type Locality struct {
 	LocalityName string `json:"locality_name"`
 	ProvinceName string `json:"province_name"`
 	CountryName  string `json:"country_name"`
+	// Latitude and Longitude are optional decimal degrees used for distance
+	// calculations.
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
 }
 
 type ReportSellers struct {
@@ -13,8 +17,61 @@ type ReportSellers struct {
 	SellersCount int    `json:"sellers_count"`
 }
 
+// ReportCarriers counts the carriers serving a locality; HeadquartersCount
+// is the part of them based in it.
 type ReportCarriers struct {
-	LocalityId   string `json:"locality_id"`
-	LocalityName string `json:"locality_name"`
-	CarriersCount int    `json:"carriers_count"`
+	LocalityId        string `json:"locality_id"`
+	LocalityName      string `json:"locality_name"`
+	CarriersCount     int    `json:"carriers_count"`
+	HeadquartersCount int    `json:"headquarters_count"`
+}
+
+type LocalityUsage struct {
+	Sellers    int `json:"sellers"`
+	Carriers   int `json:"carriers"`
+	Warehouses int `json:"warehouses"`
+	Buyers     int `json:"buyers"`
+}
+
+type LocationCounts struct {
+	SellersCount    int `json:"sellers_count"`
+	CarriersCount   int `json:"carriers_count"`
+	WarehousesCount int `json:"warehouses_count"`
+}
+
+type Country struct {
+	CountryName     string `json:"country_name"`
+	ProvincesCount  int    `json:"provinces_count"`
+	LocalitiesCount int    `json:"localities_count"`
+	LocationCounts
+}
+
+type Province struct {
+	CountryName     string `json:"country_name"`
+	ProvinceName    string `json:"province_name"`
+	LocalitiesCount int    `json:"localities_count"`
+	LocationCounts
+}
+
+type LocalityDetail struct {
+	Locality
+	LocationCounts
+}
+
+type CoverageFilters struct {
+	LocalityId   string
+	ProvinceName string
+	CountryName  string
+}
+
+type LocalityCoverage struct {
+	Locality
+	LocationCounts
+	BuyersCount int `json:"buyers_count"`
+	// CoveringCarriersCount counts the carriers serving the locality, based
+	// in it or not.
+	CoveringCarriersCount int `json:"covering_carriers_count"`
+	// WithoutCarrierCoverage is set when the locality has sellers but no
+	// carrier serving it.
+	WithoutCarrierCoverage bool `json:"without_carrier_coverage"`
 }
@@ -1,5 +1,10 @@
 package localities
 
+import (
+	"fmt"
+	"strings"
+)
+
 var (
 	queryGetReportAll = `SELECT l.id as locality_id, l.locality_name, count(s.id) as sellers_count
 	FROM sellers s
@@ -9,18 +14,107 @@ var (
 	FROM sellers s
 	RIGHT JOIN localities l ON s.locality_id = l.id WHERE l.id = ? GROUP BY l.id, l.locality_name;`
 
-	queryCreateLocality = `INSERT INTO localities (id, locality_name, province_name, country_name) VALUES (?, ?, ?, ?)`
-	queryGetOneLocality = `SELECT * FROM localities WHERE id = ?`
+	queryCreateLocality = `INSERT INTO localities (id, locality_name, province_name, country_name, latitude, longitude) VALUES (?, ?, ?, ?, ?, ?)`
+	queryGetOneLocality = `SELECT id, locality_name, province_name, country_name, latitude, longitude FROM localities WHERE id = ?`
+
+	// The carriers report counts every carrier serving the locality, through
+	// its headquarters or its service areas, besides the ones based in it.
+	queryGetReportCarriersAll = `SELECT l.id as locality_id, l.locality_name, count(DISTINCT cc.carrier_id) as carriers_count, count(DISTINCT c.id) as headquarters_count
+	FROM localities l
+	LEFT JOIN carrier_coverage cc ON cc.locality_id = l.id
+	LEFT JOIN carriers c ON c.locality_id = l.id GROUP BY l.id, l.locality_name;`
+
+	queryGetReportCarriersOne = `SELECT l.id as locality_id, l.locality_name, count(DISTINCT cc.carrier_id) as carriers_count, count(DISTINCT c.id) as headquarters_count
+	FROM localities l
+	LEFT JOIN carrier_coverage cc ON cc.locality_id = l.id
+	LEFT JOIN carriers c ON c.locality_id = l.id WHERE l.id = ? GROUP BY l.id, l.locality_name;`
+
+	queryGetLocalityUsage = `SELECT
+		(SELECT COUNT(*) FROM sellers WHERE locality_id = ?),
+		(SELECT COUNT(*) FROM carriers WHERE locality_id = ?),
+		(SELECT COUNT(*) FROM warehouses WHERE locality_id = ?),
+		(SELECT COUNT(*) FROM buyers WHERE locality_id = ?)`
+
+	queryDeleteLocality = `DELETE FROM localities WHERE id = ?`
+
+	// locationCountsJoins attaches the number of sellers, carriers and
+	// warehouses of each locality, keeping localities without any of them.
+	locationCountsJoins = `FROM localities l
+	LEFT JOIN (SELECT locality_id, COUNT(*) AS total FROM sellers GROUP BY locality_id) s ON s.locality_id = l.id
+	LEFT JOIN (SELECT locality_id, COUNT(*) AS total FROM carriers GROUP BY locality_id) c ON c.locality_id = l.id
+	LEFT JOIN (SELECT locality_id, COUNT(*) AS total FROM warehouses GROUP BY locality_id) w ON w.locality_id = l.id`
+
+	queryGetCountries = `SELECT l.country_name, COUNT(DISTINCT l.province_name), COUNT(l.id),
+	COALESCE(SUM(s.total), 0), COALESCE(SUM(c.total), 0), COALESCE(SUM(w.total), 0)
+	` + locationCountsJoins + `
+	GROUP BY l.country_name
+	ORDER BY l.country_name`
+
+	queryGetProvinces = `SELECT l.country_name, l.province_name, COUNT(l.id),
+	COALESCE(SUM(s.total), 0), COALESCE(SUM(c.total), 0), COALESCE(SUM(w.total), 0)
+	` + locationCountsJoins + `
+	WHERE l.country_name = ?
+	GROUP BY l.country_name, l.province_name
+	ORDER BY l.province_name`
+
+	queryGetLocalitiesByProvince = `SELECT l.id, l.locality_name, l.province_name, l.country_name, l.latitude, l.longitude,
+	COALESCE(s.total, 0), COALESCE(c.total, 0), COALESCE(w.total, 0)
+	` + locationCountsJoins + `
+	WHERE l.country_name = ? AND l.province_name = ?
+	ORDER BY l.locality_name`
+
+	// queryGetCoverageReport lists every locality matching the non-empty
+	// filters with its sellers, carriers, warehouses and buyers counts and
+	// the number of carriers serving it.
+	queryGetCoverageReport = func(filters CoverageFilters) (string, []interface{}) {
+		conditions := []string{}
+		values := []interface{}{}
+
+		if filters.LocalityId != "" {
+			conditions = append(conditions, "l.id = ?")
+			values = append(values, filters.LocalityId)
+		}
+		if filters.ProvinceName != "" {
+			conditions = append(conditions, "l.province_name = ?")
+			values = append(values, filters.ProvinceName)
+		}
+		if filters.CountryName != "" {
+			conditions = append(conditions, "l.country_name = ?")
+			values = append(values, filters.CountryName)
+		}
+
+		query := `SELECT l.id, l.locality_name, l.province_name, l.country_name, l.latitude, l.longitude,
+	COALESCE(s.total, 0), COALESCE(c.total, 0), COALESCE(w.total, 0), COALESCE(b.total, 0), COALESCE(cc.total, 0)
+	` + locationCountsJoins + `
+	LEFT JOIN (SELECT locality_id, COUNT(*) AS total FROM buyers GROUP BY locality_id) b ON b.locality_id = l.id
+	LEFT JOIN (SELECT locality_id, COUNT(DISTINCT carrier_id) AS total FROM carrier_coverage GROUP BY locality_id) cc ON cc.locality_id = l.id`
+
+		if len(conditions) > 0 {
+			query += "\n\tWHERE " + strings.Join(conditions, " AND ")
+		}
+
+		return query + "\n\tORDER BY l.country_name, l.province_name, l.locality_name", values
+	}
+
+	updatableFields = []string{"locality_name", "province_name", "country_name", "latitude", "longitude"}
+
+	queryUpdateLocality = func(
+		requestData map[string]interface{},
+		id string) (
+		finalQuery string,
+		valuesToUse []interface{}) {
+		fieldsToUpdate := []string{}
+
+		for _, currField := range updatableFields {
+			if _, ok := requestData[currField]; ok {
+				fieldsToUpdate = append(fieldsToUpdate, fmt.Sprintf("%s = ?", currField))
+				valuesToUse = append(valuesToUse, requestData[currField])
+			}
+		}
 
-	queryGetReportCarriersAll = `SELECT l.id as locality_id, l.locality_name, count(*) as carriers_count 
-	FROM localities as l
-	INNER JOIN carriers as c
-	ON l.id = c.locality_id 
-	GROUP BY l.id, l.locality_name;`
+		valuesToUse = append(valuesToUse, id)
+		finalQuery = "UPDATE localities SET " + strings.Join(fieldsToUpdate, ", ") + " WHERE id = ?"
 
-	queryGetReportCarriersOne = `SELECT l.id as locality_id, l.locality_name, count(*) as carriers_count
-	FROM carriers c
-	JOIN localities l ON c.locality_id = l.id
-	WHERE l.id = ?
-	GROUP BY l.id, l.locality_name;`
+		return finalQuery, valuesToUse
+	}
 )
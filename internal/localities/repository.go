@@ -1,41 +1,67 @@
 package localities
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
+)
+
+var (
+	errUpdateLocality   = errors.New("couldn't update the locality")
+	errDeleteLocality   = errors.New("couldn't delete the locality")
+	errGetLocalityUsage = errors.New("couldn't check the locality usage")
+	errGetCountries     = errors.New("couldn't get countries")
+	errGetProvinces     = errors.New("couldn't get provinces")
+	errGetLocalities    = errors.New("couldn't get localities")
+	errGetCoverage      = errors.New("error to report coverage by locality")
 )
 
+func GetErrLocalityNotFound(id string) error {
+	return fmt.Errorf("locality with id %s not found", id)
+}
+
 type Repository interface {
-	CreateLocality(id, localityName, provinceName, countryName string) (Locality, error)
-	GetReportSellers(localityId string) ([]ReportSellers, error)
-	GetReportCarriers(localityId string) ([]ReportCarriers, error)
-	GetOne(id string) (Locality, error)
+	CreateLocality(ctx context.Context, id, localityName, provinceName, countryName string, latitude, longitude *float64) (Locality, error)
+	GetReportSellers(ctx context.Context, localityId string) ([]ReportSellers, error)
+	GetReportCarriers(ctx context.Context, localityId string) ([]ReportCarriers, error)
+	GetOne(ctx context.Context, id string) (Locality, error)
+	Update(ctx context.Context, id string, requestData map[string]interface{}) (Locality, error)
+	Delete(ctx context.Context, id string) error
+	GetUsage(ctx context.Context, id string) (LocalityUsage, error)
+	GetCountries(ctx context.Context) ([]Country, error)
+	GetProvinces(ctx context.Context, countryName string) ([]Province, error)
+	GetLocalities(ctx context.Context, countryName, provinceName string) ([]LocalityDetail, error)
+	GetCoverageReport(ctx context.Context, filters CoverageFilters) ([]LocalityCoverage, error)
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
-func (mariaDb mariaDbRepository) GetOne(id string) (Locality, error) {
+func (mariaDb mariaDbRepository) GetOne(ctx context.Context, id string) (Locality, error) {
 	currentLocality := Locality{}
 
-	row := mariaDb.db.QueryRow(queryGetOneLocality, id)
+	row := mariaDb.db.QueryRowContext(ctx, queryGetOneLocality, id)
 	err := row.Scan(
 		&currentLocality.Id,
 		&currentLocality.LocalityName,
 		&currentLocality.ProvinceName,
 		&currentLocality.CountryName,
+		&currentLocality.Latitude,
+		&currentLocality.Longitude,
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
-		return Locality{}, fmt.Errorf("locality with id %s not found", id)
+		return Locality{}, GetErrLocalityNotFound(id)
 	}
 
 	if err != nil {
@@ -45,19 +71,24 @@ func (mariaDb mariaDbRepository) GetOne(id string) (Locality, error) {
 	return currentLocality, nil
 }
 
-func (mariaDb mariaDbRepository) CreateLocality(
+func (mariaDb mariaDbRepository) CreateLocality(ctx context.Context,
 	id,
 	localityName,
 	provinceName,
 	countryName string,
+	latitude,
+	longitude *float64,
 ) (Locality, error) {
 
-	_, err := mariaDb.db.Exec(
+	_, err := mariaDb.db.ExecContext(
+		ctx,
 		queryCreateLocality,
 		id,
 		localityName,
 		provinceName,
 		countryName,
+		latitude,
+		longitude,
 	)
 
 	if err != nil {
@@ -69,12 +100,14 @@ func (mariaDb mariaDbRepository) CreateLocality(
 		LocalityName: localityName,
 		ProvinceName: provinceName,
 		CountryName:  countryName,
+		Latitude:     latitude,
+		Longitude:    longitude,
 	}
 
 	return newLocality, nil
 }
 
-func (mariaDb mariaDbRepository) GetReportSellers(localityId string) ([]ReportSellers, error) {
+func (mariaDb mariaDbRepository) GetReportSellers(ctx context.Context, localityId string) ([]ReportSellers, error) {
 	reports := []ReportSellers{}
 
 	var (
@@ -83,9 +116,9 @@ func (mariaDb mariaDbRepository) GetReportSellers(localityId string) ([]ReportSe
 	)
 
 	if localityId != "" {
-		rows, err = mariaDb.db.Query(queryGetReportOne, localityId)
+		rows, err = mariaDb.db.QueryContext(ctx, queryGetReportOne, localityId)
 	} else {
-		rows, err = mariaDb.db.Query(queryGetReportAll)
+		rows, err = mariaDb.db.QueryContext(ctx, queryGetReportAll)
 	}
 
 	if err != nil {
@@ -104,10 +137,14 @@ func (mariaDb mariaDbRepository) GetReportSellers(localityId string) ([]ReportSe
 		reports = append(reports, currentReport)
 	}
 
+	if err := rows.Err(); err != nil {
+		return []ReportSellers{}, errors.New("error to report sellers by locality")
+	}
+
 	return reports, nil
 }
 
-func (mariaDb mariaDbRepository) GetReportCarriers(localityId string) ([]ReportCarriers, error) {
+func (mariaDb mariaDbRepository) GetReportCarriers(ctx context.Context, localityId string) ([]ReportCarriers, error) {
 	reports := []ReportCarriers{}
 
 	var (
@@ -116,9 +153,9 @@ func (mariaDb mariaDbRepository) GetReportCarriers(localityId string) ([]ReportC
 	)
 
 	if localityId != "" {
-		rows, err = mariaDb.db.Query(queryGetReportCarriersOne, localityId)
+		rows, err = mariaDb.db.QueryContext(ctx, queryGetReportCarriersOne, localityId)
 	} else {
-		rows, err = mariaDb.db.Query(queryGetReportCarriersAll)
+		rows, err = mariaDb.db.QueryContext(ctx, queryGetReportCarriersAll)
 	}
 
 	if err != nil {
@@ -131,11 +168,179 @@ func (mariaDb mariaDbRepository) GetReportCarriers(localityId string) ([]ReportC
 			&currentReport.LocalityId,
 			&currentReport.LocalityName,
 			&currentReport.CarriersCount,
+			&currentReport.HeadquartersCount,
 		); err != nil {
 			return []ReportCarriers{}, errors.New("error to report carriers by locality")
 		}
 		reports = append(reports, currentReport)
 	}
 
+	if err := rows.Err(); err != nil {
+		return []ReportCarriers{}, errors.New("error to report carriers by locality")
+	}
+
+	return reports, nil
+}
+
+func (mariaDb mariaDbRepository) Update(ctx context.Context, id string, requestData map[string]interface{}) (Locality, error) {
+	finalQuery, valuesToUse := queryUpdateLocality(requestData, id)
+
+	if _, err := mariaDb.db.ExecContext(ctx, finalQuery, valuesToUse...); err != nil {
+		return Locality{}, errUpdateLocality
+	}
+
+	currentLocality, err := mariaDb.GetOne(ctx, id)
+	if err != nil {
+		return Locality{}, errUpdateLocality
+	}
+
+	return currentLocality, nil
+}
+
+func (mariaDb mariaDbRepository) Delete(ctx context.Context, id string) error {
+	if _, err := mariaDb.db.ExecContext(ctx, queryDeleteLocality, id); err != nil {
+		return errDeleteLocality
+	}
+
+	return nil
+}
+
+func (mariaDb mariaDbRepository) GetUsage(ctx context.Context, id string) (LocalityUsage, error) {
+	var usage LocalityUsage
+
+	row := mariaDb.db.QueryRowContext(ctx, queryGetLocalityUsage, id, id, id, id)
+	if err := row.Scan(&usage.Sellers, &usage.Carriers, &usage.Warehouses, &usage.Buyers); err != nil {
+		return LocalityUsage{}, errGetLocalityUsage
+	}
+
+	return usage, nil
+}
+
+func (mariaDb mariaDbRepository) GetCountries(ctx context.Context) ([]Country, error) {
+	countries := []Country{}
+
+	rows, err := mariaDb.db.QueryContext(ctx, queryGetCountries)
+	if err != nil {
+		return []Country{}, errGetCountries
+	}
+
+	for rows.Next() {
+		var currentCountry Country
+		if err := rows.Scan(
+			&currentCountry.CountryName,
+			&currentCountry.ProvincesCount,
+			&currentCountry.LocalitiesCount,
+			&currentCountry.SellersCount,
+			&currentCountry.CarriersCount,
+			&currentCountry.WarehousesCount,
+		); err != nil {
+			return []Country{}, errGetCountries
+		}
+		countries = append(countries, currentCountry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []Country{}, errGetCountries
+	}
+
+	return countries, nil
+}
+
+func (mariaDb mariaDbRepository) GetProvinces(ctx context.Context, countryName string) ([]Province, error) {
+	provinces := []Province{}
+
+	rows, err := mariaDb.db.QueryContext(ctx, queryGetProvinces, countryName)
+	if err != nil {
+		return []Province{}, errGetProvinces
+	}
+
+	for rows.Next() {
+		var currentProvince Province
+		if err := rows.Scan(
+			&currentProvince.CountryName,
+			&currentProvince.ProvinceName,
+			&currentProvince.LocalitiesCount,
+			&currentProvince.SellersCount,
+			&currentProvince.CarriersCount,
+			&currentProvince.WarehousesCount,
+		); err != nil {
+			return []Province{}, errGetProvinces
+		}
+		provinces = append(provinces, currentProvince)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []Province{}, errGetProvinces
+	}
+
+	return provinces, nil
+}
+
+func (mariaDb mariaDbRepository) GetLocalities(ctx context.Context, countryName, provinceName string) ([]LocalityDetail, error) {
+	localities := []LocalityDetail{}
+
+	rows, err := mariaDb.db.QueryContext(ctx, queryGetLocalitiesByProvince, countryName, provinceName)
+	if err != nil {
+		return []LocalityDetail{}, errGetLocalities
+	}
+
+	for rows.Next() {
+		var currentLocality LocalityDetail
+		if err := rows.Scan(
+			&currentLocality.Id,
+			&currentLocality.LocalityName,
+			&currentLocality.ProvinceName,
+			&currentLocality.CountryName,
+			&currentLocality.Latitude,
+			&currentLocality.Longitude,
+			&currentLocality.SellersCount,
+			&currentLocality.CarriersCount,
+			&currentLocality.WarehousesCount,
+		); err != nil {
+			return []LocalityDetail{}, errGetLocalities
+		}
+		localities = append(localities, currentLocality)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []LocalityDetail{}, errGetLocalities
+	}
+
+	return localities, nil
+}
+
+func (mariaDb mariaDbRepository) GetCoverageReport(ctx context.Context, filters CoverageFilters) ([]LocalityCoverage, error) {
+	reports := []LocalityCoverage{}
+
+	query, values := queryGetCoverageReport(filters)
+	rows, err := mariaDb.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return []LocalityCoverage{}, errGetCoverage
+	}
+
+	for rows.Next() {
+		var currentReport LocalityCoverage
+		if err := rows.Scan(
+			&currentReport.Id,
+			&currentReport.LocalityName,
+			&currentReport.ProvinceName,
+			&currentReport.CountryName,
+			&currentReport.Latitude,
+			&currentReport.Longitude,
+			&currentReport.SellersCount,
+			&currentReport.CarriersCount,
+			&currentReport.WarehousesCount,
+			&currentReport.BuyersCount,
+			&currentReport.CoveringCarriersCount,
+		); err != nil {
+			return []LocalityCoverage{}, errGetCoverage
+		}
+		reports = append(reports, currentReport)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []LocalityCoverage{}, errGetCoverage
+	}
+
 	return reports, nil
 }
@@ -1,6 +1,7 @@
 package localities
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"regexp"
@@ -22,15 +23,20 @@ func TestDBCreateLocality(t *testing.T) {
 				"123",
 				"123",
 				"123",
+				nil,
+				nil,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		localitiesRepo := NewMariaDbRepository(db)
 
 		locality, err := localitiesRepo.CreateLocality(
+			context.Background(),
 			"123",
 			"123",
 			"123",
 			"123",
+			nil,
+			nil,
 		)
 		assert.Nil(t, err)
 
@@ -46,17 +52,21 @@ func TestDBCreateLocality(t *testing.T) {
 			"locality_name",
 			"province_name",
 			"country_name",
+			"latitude",
+			"longitude",
 		}).
 			AddRow(
 				"123",
 				"123",
 				"123",
 				"123",
+				nil,
+				nil,
 			)
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneLocality)).WillReturnRows(rows)
 		localitiesRepo := NewMariaDbRepository(db)
 
-		locality, err := localitiesRepo.GetOne("123")
+		locality, err := localitiesRepo.GetOne(context.Background(), "123")
 		assert.NoError(t, err)
 		assert.Equal(t, "123", locality.Id)
 	})
@@ -69,7 +79,7 @@ func TestDBCreateLocality(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(queryCreateLocality)).WillReturnError(errors.New(""))
 		localitiesRepo := NewMariaDbRepository(db)
 
-		emptyLocality, err := localitiesRepo.CreateLocality("123", "Presidente Dutra", "MA", "BR")
+		emptyLocality, err := localitiesRepo.CreateLocality(context.Background(), "123", "Presidente Dutra", "MA", "BR", nil, nil)
 		assert.NotNil(t, err)
 		assert.Equal(t, "", emptyLocality.CountryName)
 	})
@@ -87,20 +97,26 @@ func TestDBGetOneLocality(t *testing.T) {
 			"locality_name",
 			"province_name",
 			"country_name",
+			"latitude",
+			"longitude",
 		}).AddRow("123",
 			"Presidente Dutra",
 			"MA",
 			"BR",
+			-5.2897,
+			-44.495,
 		)
 
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneLocality)).WillReturnRows(rows)
 
 		localitiesRepo := NewMariaDbRepository(db)
-		locality, err := localitiesRepo.GetOne("123")
+		locality, err := localitiesRepo.GetOne(context.Background(), "123")
 		assert.Nil(t, err)
 
 		assert.Equal(t, "123", locality.Id)
 		assert.Equal(t, "Presidente Dutra", locality.LocalityName)
+		assert.Equal(t, -5.2897, *locality.Latitude)
+		assert.Equal(t, -44.495, *locality.Longitude)
 	})
 
 	t.Run("Not found case", func(t *testing.T) {
@@ -111,7 +127,7 @@ func TestDBGetOneLocality(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneLocality)).WillReturnError(sql.ErrNoRows)
 
 		localitiesRepo := NewMariaDbRepository(db)
-		_, err = localitiesRepo.GetOne("123")
+		_, err = localitiesRepo.GetOne(context.Background(), "123")
 		assert.NotNil(t, err)
 		assert.Equal(t, "locality with id 123 not found", err.Error())
 
@@ -124,7 +140,7 @@ func TestDBGetOneLocality(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneLocality)).WillReturnError(errors.New(""))
 
 		localitiesRepo := NewMariaDbRepository(db)
-		_, err = localitiesRepo.GetOne("123")
+		_, err = localitiesRepo.GetOne(context.Background(), "123")
 		assert.NotNil(t, err)
 		assert.Equal(t, "error to find locality", err.Error())
 	})
@@ -150,7 +166,7 @@ func TestDBGetReportSellers(t *testing.T) {
 
 		localityRepo := NewMariaDbRepository(db)
 
-		sellerReports, err := localityRepo.GetReportSellers("")
+		sellerReports, err := localityRepo.GetReportSellers(context.Background(), "")
 		assert.Nil(t, err)
 
 		assert.Len(t, sellerReports, 3)
@@ -176,7 +192,7 @@ func TestDBGetReportSellers(t *testing.T) {
 
 		localityRepo := NewMariaDbRepository(db)
 
-		sellerReports, err := localityRepo.GetReportSellers("456")
+		sellerReports, err := localityRepo.GetReportSellers(context.Background(), "456")
 		assert.Nil(t, err)
 
 		assert.Len(t, sellerReports, 1)
@@ -195,7 +211,7 @@ func TestDBGetReportSellers(t *testing.T) {
 
 		localityRepo := NewMariaDbRepository(db)
 
-		_, err = localityRepo.GetReportSellers("456")
+		_, err = localityRepo.GetReportSellers(context.Background(), "456")
 		assert.NotNil(t, err)
 		assert.Equal(t, "error to report sellers by locality", err.Error())
 	})
@@ -216,7 +232,7 @@ func TestDBGetReportSellers(t *testing.T) {
 
 		localityRepo := NewMariaDbRepository(db)
 
-		_, err = localityRepo.GetReportSellers("123")
+		_, err = localityRepo.GetReportSellers(context.Background(), "123")
 		assert.Error(t, err)
 	})
 }
@@ -232,23 +248,25 @@ func TestDBGetReportCarriers(t *testing.T) {
 			"locality_id",
 			"locality_name",
 			"carriers_count",
+			"headquarters_count",
 		}).
-			AddRow("123", "Pres. Dutra", 200).
-			AddRow("456", "Osasco", 150).
-			AddRow("789", "São Luís", 250)
+			AddRow("123", "Pres. Dutra", 200, 1).
+			AddRow("456", "Osasco", 150, 0).
+			AddRow("789", "São Luís", 250, 2)
 
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetReportCarriersAll)).
 			WillReturnRows(rows)
 
 		localityRepo := NewMariaDbRepository(db)
 
-		carriersReports, err := localityRepo.GetReportCarriers("")
+		carriersReports, err := localityRepo.GetReportCarriers(context.Background(), "")
 		assert.Nil(t, err)
 
 		assert.Len(t, carriersReports, 3)
 		assert.Equal(t, carriersReports[0].LocalityId, "123")
 		assert.Equal(t, carriersReports[1].LocalityId, "456")
 		assert.Equal(t, carriersReports[2].LocalityId, "789")
+		assert.Equal(t, 2, carriersReports[2].HeadquartersCount)
 	})
 
 	t.Run("Get one report", func(t *testing.T) {
@@ -261,15 +279,16 @@ func TestDBGetReportCarriers(t *testing.T) {
 			"locality_id",
 			"locality_name",
 			"carriers_count",
+			"headquarters_count",
 		}).
-			AddRow("123", "Pres. Dutra", 200)
+			AddRow("123", "Pres. Dutra", 200, 1)
 
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetReportCarriersOne)).
 			WillReturnRows(rows)
 
 		localityRepo := NewMariaDbRepository(db)
 
-		carriersReports, err := localityRepo.GetReportCarriers("123")
+		carriersReports, err := localityRepo.GetReportCarriers(context.Background(), "123")
 		assert.Nil(t, err)
 
 		assert.Len(t, carriersReports, 1)
@@ -287,7 +306,7 @@ func TestDBGetReportCarriers(t *testing.T) {
 
 		localityRepo := NewMariaDbRepository(db)
 
-		_, err = localityRepo.GetReportCarriers("123")
+		_, err = localityRepo.GetReportCarriers(context.Background(), "123")
 		assert.Error(t, err)
 		assert.Equal(t, "error to report carriers by locality", err.Error())
 	})
@@ -310,10 +329,259 @@ func TestDBGetReportCarriers(t *testing.T) {
 
 		localityRepo := NewMariaDbRepository(db)
 
-		carriersReport, err := localityRepo.GetReportCarriers("")
+		carriersReport, err := localityRepo.GetReportCarriers(context.Background(), "")
 		assert.Error(t, err)
 		assert.Equal(t, "error to report carriers by locality", err.Error())
 
 		assert.Equal(t, []ReportCarriers{}, carriersReport)
 	})
 }
+
+func TestDBUpdateLocality(t *testing.T) {
+	requestData := map[string]interface{}{"locality_name": "Osasco", "province_name": "SP"}
+
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		finalQuery, _ := queryUpdateLocality(requestData, "123")
+		assert.Equal(t, "UPDATE localities SET locality_name = ?, province_name = ? WHERE id = ?", finalQuery)
+
+		mock.ExpectExec(regexp.QuoteMeta(finalQuery)).
+			WithArgs("Osasco", "SP", "123").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		rows := sqlmock.NewRows([]string{"id", "locality_name", "province_name", "country_name", "latitude", "longitude"}).
+			AddRow("123", "Osasco", "SP", "BR", nil, nil)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneLocality)).WithArgs("123").WillReturnRows(rows)
+
+		localitiesRepo := NewMariaDbRepository(db)
+		locality, err := localitiesRepo.Update(context.Background(), "123", requestData)
+		assert.Nil(t, err)
+		assert.Equal(t, "Osasco", locality.LocalityName)
+		assert.Equal(t, "SP", locality.ProvinceName)
+	})
+
+	t.Run("Error case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		finalQuery, _ := queryUpdateLocality(requestData, "123")
+		mock.ExpectExec(regexp.QuoteMeta(finalQuery)).WillReturnError(errors.New(""))
+
+		localitiesRepo := NewMariaDbRepository(db)
+		_, err = localitiesRepo.Update(context.Background(), "123", requestData)
+		assert.Equal(t, errUpdateLocality, err)
+	})
+}
+
+func TestDBDeleteLocality(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteLocality)).WithArgs("123").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		localitiesRepo := NewMariaDbRepository(db)
+		assert.Nil(t, localitiesRepo.Delete(context.Background(), "123"))
+	})
+
+	t.Run("Error case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteLocality)).WithArgs("123").WillReturnError(errors.New(""))
+
+		localitiesRepo := NewMariaDbRepository(db)
+		assert.Equal(t, errDeleteLocality, localitiesRepo.Delete(context.Background(), "123"))
+	})
+}
+
+func TestDBGetLocalityUsage(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"sellers", "carriers", "warehouses", "buyers"}).AddRow(2, 1, 3, 4)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetLocalityUsage)).WithArgs("123", "123", "123", "123").WillReturnRows(rows)
+
+		localitiesRepo := NewMariaDbRepository(db)
+		usage, err := localitiesRepo.GetUsage(context.Background(), "123")
+		assert.Nil(t, err)
+		assert.Equal(t, LocalityUsage{Sellers: 2, Carriers: 1, Warehouses: 3, Buyers: 4}, usage)
+	})
+
+	t.Run("Error case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetLocalityUsage)).WillReturnError(errors.New(""))
+
+		localitiesRepo := NewMariaDbRepository(db)
+		_, err = localitiesRepo.GetUsage(context.Background(), "123")
+		assert.Equal(t, errGetLocalityUsage, err)
+	})
+}
+
+func TestDBGetCountries(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{
+			"country_name", "provinces_count", "localities_count",
+			"sellers_count", "carriers_count", "warehouses_count",
+		}).AddRow("BR", 4, 6, 6, 2, 1)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetCountries)).WillReturnRows(rows)
+
+		localitiesRepo := NewMariaDbRepository(db)
+		countries, err := localitiesRepo.GetCountries(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, []Country{{
+			CountryName:     "BR",
+			ProvincesCount:  4,
+			LocalitiesCount: 6,
+			LocationCounts:  LocationCounts{SellersCount: 6, CarriersCount: 2, WarehousesCount: 1},
+		}}, countries)
+	})
+
+	t.Run("Error case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetCountries)).WillReturnError(errors.New(""))
+
+		localitiesRepo := NewMariaDbRepository(db)
+		_, err = localitiesRepo.GetCountries(context.Background())
+		assert.Equal(t, errGetCountries, err)
+	})
+}
+
+func TestDBGetProvinces(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{
+			"country_name", "province_name", "localities_count",
+			"sellers_count", "carriers_count", "warehouses_count",
+		}).AddRow("BR", "MA", 3, 3, 1, 0).AddRow("BR", "SP", 1, 1, 0, 1)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetProvinces)).WithArgs("BR").WillReturnRows(rows)
+
+		localitiesRepo := NewMariaDbRepository(db)
+		provinces, err := localitiesRepo.GetProvinces(context.Background(), "BR")
+		assert.Nil(t, err)
+		assert.Len(t, provinces, 2)
+		assert.Equal(t, "MA", provinces[0].ProvinceName)
+		assert.Equal(t, 3, provinces[0].LocalitiesCount)
+		assert.Equal(t, 1, provinces[1].WarehousesCount)
+	})
+
+	t.Run("Error case - scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"country_name"}).AddRow("BR")
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetProvinces)).WithArgs("BR").WillReturnRows(rows)
+
+		localitiesRepo := NewMariaDbRepository(db)
+		_, err = localitiesRepo.GetProvinces(context.Background(), "BR")
+		assert.Equal(t, errGetProvinces, err)
+	})
+}
+
+func TestDBGetLocalities(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{
+			"id", "locality_name", "province_name", "country_name", "latitude", "longitude",
+			"sellers_count", "carriers_count", "warehouses_count",
+		}).AddRow("1", "Presidente Dutra", "MA", "BR", nil, nil, 1, 0, 0)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetLocalitiesByProvince)).WithArgs("BR", "MA").WillReturnRows(rows)
+
+		localitiesRepo := NewMariaDbRepository(db)
+		localitiesList, err := localitiesRepo.GetLocalities(context.Background(), "BR", "MA")
+		assert.Nil(t, err)
+		assert.Equal(t, []LocalityDetail{{
+			Locality:       Locality{Id: "1", LocalityName: "Presidente Dutra", ProvinceName: "MA", CountryName: "BR"},
+			LocationCounts: LocationCounts{SellersCount: 1},
+		}}, localitiesList)
+	})
+
+	t.Run("Error case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetLocalitiesByProvince)).WillReturnError(errors.New(""))
+
+		localitiesRepo := NewMariaDbRepository(db)
+		_, err = localitiesRepo.GetLocalities(context.Background(), "BR", "MA")
+		assert.Equal(t, errGetLocalities, err)
+	})
+}
+
+func TestDBGetCoverageReport(t *testing.T) {
+	columns := []string{
+		"id", "locality_name", "province_name", "country_name", "latitude", "longitude",
+		"sellers_count", "carriers_count", "warehouses_count", "buyers_count", "covering_carriers_count",
+	}
+
+	t.Run("Filter query", func(t *testing.T) {
+		query, values := queryGetCoverageReport(CoverageFilters{ProvinceName: "MA", CountryName: "BR"})
+		assert.Contains(t, query, "WHERE l.province_name = ? AND l.country_name = ?")
+		assert.Equal(t, []interface{}{"MA", "BR"}, values)
+
+		query, values = queryGetCoverageReport(CoverageFilters{})
+		assert.NotContains(t, query, "WHERE")
+		assert.Empty(t, values)
+	})
+
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		filters := CoverageFilters{CountryName: "BR"}
+		query, _ := queryGetCoverageReport(filters)
+		rows := sqlmock.NewRows(columns).
+			AddRow("1", "Presidente Dutra", "MA", "BR", nil, nil, 1, 0, 0, 2, 3).
+			AddRow("2", "Osasco", "SP", "BR", nil, nil, 0, 0, 0, 0, 0)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("BR").WillReturnRows(rows)
+
+		localitiesRepo := NewMariaDbRepository(db)
+		report, err := localitiesRepo.GetCoverageReport(context.Background(), filters)
+		assert.Nil(t, err)
+		assert.Len(t, report, 2)
+		assert.Equal(t, 2, report[0].BuyersCount)
+		assert.Equal(t, 3, report[0].CoveringCarriersCount)
+		assert.Equal(t, "Osasco", report[1].LocalityName)
+		assert.Equal(t, 0, report[1].SellersCount)
+	})
+
+	t.Run("Error case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		query, _ := queryGetCoverageReport(CoverageFilters{})
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New(""))
+
+		localitiesRepo := NewMariaDbRepository(db)
+		_, err = localitiesRepo.GetCoverageReport(context.Background(), CoverageFilters{})
+		assert.Equal(t, errGetCoverage, err)
+	})
+}
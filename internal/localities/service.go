@@ -1,22 +1,35 @@
 package localities
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 )
 
+var errNothingToUpdate = errors.New("at least one of locality_name, province_name, country_name, latitude or longitude must be informed")
+
 type Service interface {
-	CreateLocality(
+	CreateLocality(ctx context.Context,
 		id,
 		localityName,
 		provinceName,
 		countryName string,
+		latitude,
+		longitude *float64,
 	) (Locality, web.ResponseCode)
-	GetAllReportSellers() ([]ReportSellers, web.ResponseCode)
-	GetReportOneSeller(localityId string) ([]ReportSellers, web.ResponseCode)
-	GetReportCarriers(localityId string) ([]ReportCarriers, web.ResponseCode) 
+	GetAllReportSellers(ctx context.Context) ([]ReportSellers, web.ResponseCode)
+	GetReportOneSeller(ctx context.Context, localityId string) ([]ReportSellers, web.ResponseCode)
+	GetReportCarriers(ctx context.Context, localityId string) ([]ReportCarriers, web.ResponseCode)
+	GetOne(ctx context.Context, id string) (Locality, web.ResponseCode)
+	Update(ctx context.Context, id string, requestData map[string]interface{}) (Locality, web.ResponseCode)
+	Delete(ctx context.Context, id string) web.ResponseCode
+	GetCountries(ctx context.Context) ([]Country, web.ResponseCode)
+	GetProvinces(ctx context.Context, countryName string) ([]Province, web.ResponseCode)
+	GetLocalities(ctx context.Context, countryName, provinceName string) ([]LocalityDetail, web.ResponseCode)
+	GetCoverageReport(ctx context.Context, filters CoverageFilters) ([]LocalityCoverage, web.ResponseCode)
 }
 
 type service struct {
@@ -29,13 +42,13 @@ func NewService(r Repository) Service {
 	}
 }
 
-func (s service) CreateLocality(id, localityName, provinceName, countryName string) (Locality, web.ResponseCode) {
-	_, err := s.repository.GetOne(id)
+func (s service) CreateLocality(ctx context.Context, id, localityName, provinceName, countryName string, latitude, longitude *float64) (Locality, web.ResponseCode) {
+	_, err := s.repository.GetOne(ctx, id)
 	if err == nil {
 		return Locality{}, web.NewCodeResponse(http.StatusConflict, errors.New("locality already exists"))
 	}
 
-	result, err := s.repository.CreateLocality(id, localityName, provinceName, countryName)
+	result, err := s.repository.CreateLocality(ctx, id, localityName, provinceName, countryName, latitude, longitude)
 	if err != nil {
 		return Locality{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
@@ -43,8 +56,8 @@ func (s service) CreateLocality(id, localityName, provinceName, countryName stri
 	return result, web.NewCodeResponse(http.StatusCreated, nil)
 }
 
-func (s service) GetAllReportSellers() ([]ReportSellers, web.ResponseCode) {
-	report, err := s.repository.GetReportSellers("")
+func (s service) GetAllReportSellers(ctx context.Context) ([]ReportSellers, web.ResponseCode) {
+	report, err := s.repository.GetReportSellers(ctx, "")
 	if err != nil {
 		return []ReportSellers{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
@@ -52,12 +65,12 @@ func (s service) GetAllReportSellers() ([]ReportSellers, web.ResponseCode) {
 	return report, web.NewCodeResponse(http.StatusOK, nil)
 }
 
-func (s service) GetReportOneSeller(localityId string) ([]ReportSellers, web.ResponseCode) {
-	if _, err := s.repository.GetOne(localityId); err != nil {
+func (s service) GetReportOneSeller(ctx context.Context, localityId string) ([]ReportSellers, web.ResponseCode) {
+	if _, err := s.repository.GetOne(ctx, localityId); err != nil {
 		return []ReportSellers{}, web.NewCodeResponse(http.StatusNotFound, err)
 	}
 
-	report, err := s.repository.GetReportSellers(localityId)
+	report, err := s.repository.GetReportSellers(ctx, localityId)
 	if err != nil {
 		return []ReportSellers{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
@@ -65,8 +78,8 @@ func (s service) GetReportOneSeller(localityId string) ([]ReportSellers, web.Res
 	return report, web.NewCodeResponse(http.StatusOK, nil)
 }
 
-func (s service) GetReportCarriers(localityId string) ([]ReportCarriers, web.ResponseCode) {
-	report, err := s.repository.GetReportCarriers(localityId)
+func (s service) GetReportCarriers(ctx context.Context, localityId string) ([]ReportCarriers, web.ResponseCode) {
+	report, err := s.repository.GetReportCarriers(ctx, localityId)
 
 	if err != nil {
 		return []ReportCarriers{}, web.NewCodeResponse(http.StatusInternalServerError, err)
@@ -75,4 +88,128 @@ func (s service) GetReportCarriers(localityId string) ([]ReportCarriers, web.Res
 	return report, web.NewCodeResponse(http.StatusOK, nil)
 }
 
+func (s service) GetOne(ctx context.Context, id string) (Locality, web.ResponseCode) {
+	locality, err := s.repository.GetOne(ctx, id)
+
+	if err != nil && err.Error() == GetErrLocalityNotFound(id).Error() {
+		return Locality{}, web.NewCodeResponse(http.StatusNotFound, err)
+	}
+
+	if err != nil {
+		return Locality{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return locality, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) Update(ctx context.Context, id string, requestData map[string]interface{}) (Locality, web.ResponseCode) {
+	if !hasUpdatableField(requestData) {
+		return Locality{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errNothingToUpdate)
+	}
+
+	if _, resp := s.GetOne(ctx, id); resp.Err != nil {
+		return Locality{}, resp
+	}
 
+	locality, err := s.repository.Update(ctx, id, requestData)
+	if err != nil {
+		return Locality{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return locality, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) Delete(ctx context.Context, id string) web.ResponseCode {
+	if _, resp := s.GetOne(ctx, id); resp.Err != nil {
+		return resp
+	}
+
+	usage, err := s.repository.GetUsage(ctx, id)
+	if err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	if resp := web.ReferencedBy("locality", id,
+		web.Reference{Table: "sellers", Count: usage.Sellers},
+		web.Reference{Table: "carriers", Count: usage.Carriers},
+		web.Reference{Table: "warehouses", Count: usage.Warehouses},
+		web.Reference{Table: "buyers", Count: usage.Buyers},
+	); resp.Err != nil {
+		return resp
+	}
+
+	if err := s.repository.Delete(ctx, id); err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return web.NewCodeResponse(http.StatusNoContent, nil)
+}
+
+func (s service) GetCountries(ctx context.Context) ([]Country, web.ResponseCode) {
+	countries, err := s.repository.GetCountries(ctx)
+	if err != nil {
+		return []Country{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return countries, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) GetProvinces(ctx context.Context, countryName string) ([]Province, web.ResponseCode) {
+	provinces, err := s.repository.GetProvinces(ctx, countryName)
+	if err != nil {
+		return []Province{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	if len(provinces) == 0 {
+		return []Province{}, web.NewCodeResponse(
+			http.StatusNotFound,
+			fmt.Errorf("country %s not found", countryName),
+		)
+	}
+
+	return provinces, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) GetLocalities(ctx context.Context, countryName, provinceName string) ([]LocalityDetail, web.ResponseCode) {
+	localities, err := s.repository.GetLocalities(ctx, countryName, provinceName)
+	if err != nil {
+		return []LocalityDetail{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	if len(localities) == 0 {
+		return []LocalityDetail{}, web.NewCodeResponse(
+			http.StatusNotFound,
+			fmt.Errorf("province %s not found in country %s", provinceName, countryName),
+		)
+	}
+
+	return localities, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) GetCoverageReport(ctx context.Context, filters CoverageFilters) ([]LocalityCoverage, web.ResponseCode) {
+	if filters.LocalityId != "" {
+		if _, resp := s.GetOne(ctx, filters.LocalityId); resp.Err != nil {
+			return []LocalityCoverage{}, resp
+		}
+	}
+
+	report, err := s.repository.GetCoverageReport(ctx, filters)
+	if err != nil {
+		return []LocalityCoverage{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	for i := range report {
+		report[i].WithoutCarrierCoverage = report[i].SellersCount > 0 && report[i].CoveringCarriersCount == 0
+	}
+
+	return report, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func hasUpdatableField(requestData map[string]interface{}) bool {
+	for _, field := range updatableFields {
+		if _, ok := requestData[field]; ok {
+			return true
+		}
+	}
+	return false
+}
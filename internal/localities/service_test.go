@@ -1,6 +1,7 @@
 package localities_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
@@ -57,23 +58,30 @@ func TestCreateLocality(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		mockedRepository.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 		).Return(localities.Locality{}, errors.New(""))
 
 		mockedRepository.On(
 			"CreateLocality",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
+			mock.AnythingOfType("*float64"),
+			mock.AnythingOfType("*float64"),
 		).Return(fakeLocalities[0], nil)
 
 		service := localities.NewService(mockedRepository)
 		result, err := service.CreateLocality(
+			context.Background(),
 			fakeLocalities[0].Id,
 			fakeLocalities[0].LocalityName,
 			fakeLocalities[0].ProvinceName,
 			fakeLocalities[0].CountryName,
+			fakeLocalities[0].Latitude,
+			fakeLocalities[0].Longitude,
 		)
 
 		assert.Nil(t, err.Err)
@@ -84,23 +92,29 @@ func TestCreateLocality(t *testing.T) {
 
 	t.Run("Internal server error case", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		mockedRepository.On("GetOne", mock.AnythingOfType("string")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("string")).
 			Return(localities.Locality{}, errors.New(""))
 
 		mockedRepository.On(
 			"CreateLocality",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
+			mock.AnythingOfType("*float64"),
+			mock.AnythingOfType("*float64"),
 		).Return(localities.Locality{}, errors.New("any error"))
 
 		service := localities.NewService(mockedRepository)
 		_, err := service.CreateLocality(
+			context.Background(),
 			fakeLocalities[0].Id,
 			fakeLocalities[0].LocalityName,
 			fakeLocalities[0].ProvinceName,
 			fakeLocalities[0].CountryName,
+			fakeLocalities[0].Latitude,
+			fakeLocalities[0].Longitude,
 		)
 
 		assert.Error(t, err.Err)
@@ -110,15 +124,18 @@ func TestCreateLocality(t *testing.T) {
 
 	t.Run("Conflict locality", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		mockedRepository.On("GetOne", mock.AnythingOfType("string")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("string")).
 			Return(localities.Locality{}, nil)
 
 		service := localities.NewService(mockedRepository)
 		_, err := service.CreateLocality(
+			context.Background(),
 			fakeLocalities[0].Id,
 			fakeLocalities[0].LocalityName,
 			fakeLocalities[0].ProvinceName,
 			fakeLocalities[0].CountryName,
+			fakeLocalities[0].Latitude,
+			fakeLocalities[0].Longitude,
 		)
 
 		assert.Error(t, err.Err)
@@ -130,14 +147,14 @@ func TestCreateLocality(t *testing.T) {
 func TestGetOneReportSeller(t *testing.T) {
 	t.Run("Test if get successfully", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		mockedRepository.On("GetOne", mock.AnythingOfType("string")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("string")).
 			Return(localities.Locality{}, nil)
 
-		mockedRepository.On("GetReportSellers", mock.AnythingOfType("string")).
+		mockedRepository.On("GetReportSellers", mock.Anything, mock.AnythingOfType("string")).
 			Return(fakeReports, nil)
 
 		service := localities.NewService(mockedRepository)
-		result, err := service.GetReportOneSeller("")
+		result, err := service.GetReportOneSeller(context.Background(), "")
 
 		assert.Nil(t, err.Err)
 		assert.Len(t, result, 2)
@@ -147,14 +164,14 @@ func TestGetOneReportSeller(t *testing.T) {
 
 	t.Run("Test fail case", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		mockedRepository.On("GetOne", mock.AnythingOfType("string")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("string")).
 			Return(localities.Locality{}, nil)
 
-		mockedRepository.On("GetReportSellers", mock.AnythingOfType("string")).
+		mockedRepository.On("GetReportSellers", mock.Anything, mock.AnythingOfType("string")).
 			Return([]localities.ReportSellers{}, errors.New("any error"))
 
 		service := localities.NewService(mockedRepository)
-		result, err := service.GetReportOneSeller("")
+		result, err := service.GetReportOneSeller(context.Background(), "")
 
 		assert.Error(t, err.Err)
 		assert.Len(t, result, 0)
@@ -164,11 +181,11 @@ func TestGetOneReportSeller(t *testing.T) {
 
 	t.Run("Test fail case", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		mockedRepository.On("GetOne", mock.AnythingOfType("string")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("string")).
 			Return(localities.Locality{}, errors.New("locality with id 1 not found"))
 
 		service := localities.NewService(mockedRepository)
-		result, err := service.GetReportOneSeller("1")
+		result, err := service.GetReportOneSeller(context.Background(), "1")
 
 		assert.Error(t, err.Err)
 		assert.Len(t, result, 0)
@@ -181,11 +198,11 @@ func TestGetAllReportSellers(t *testing.T) {
 	t.Run("Test if get successfully", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 
-		mockedRepository.On("GetReportSellers", mock.AnythingOfType("string")).
+		mockedRepository.On("GetReportSellers", mock.Anything, mock.AnythingOfType("string")).
 			Return(fakeReports, nil)
 
 		service := localities.NewService(mockedRepository)
-		result, err := service.GetAllReportSellers()
+		result, err := service.GetAllReportSellers(context.Background())
 
 		assert.Nil(t, err.Err)
 		assert.Len(t, result, 2)
@@ -195,11 +212,11 @@ func TestGetAllReportSellers(t *testing.T) {
 
 	t.Run("Test fail case", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		mockedRepository.On("GetReportSellers", mock.AnythingOfType("string")).
+		mockedRepository.On("GetReportSellers", mock.Anything, mock.AnythingOfType("string")).
 			Return([]localities.ReportSellers{}, errors.New("any error"))
 
 		service := localities.NewService(mockedRepository)
-		result, err := service.GetAllReportSellers()
+		result, err := service.GetAllReportSellers(context.Background())
 
 		assert.Error(t, err.Err)
 		assert.Len(t, result, 0)
@@ -212,11 +229,11 @@ func TestGetAllReportCarriers(t *testing.T) {
 	t.Run("Test if get successfully", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 
-		mockedRepository.On("GetReportCarriers", mock.AnythingOfType("string")).
+		mockedRepository.On("GetReportCarriers", mock.Anything, mock.AnythingOfType("string")).
 			Return(fakeCarriersReports, nil)
 
 		service := localities.NewService(mockedRepository)
-		result, err := service.GetReportCarriers("")
+		result, err := service.GetReportCarriers(context.Background(), "")
 
 		assert.Nil(t, err.Err)
 		assert.Len(t, result, 2)
@@ -226,11 +243,11 @@ func TestGetAllReportCarriers(t *testing.T) {
 
 	t.Run("Test fail case", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		mockedRepository.On("GetReportCarriers", mock.AnythingOfType("string")).
+		mockedRepository.On("GetReportCarriers", mock.Anything, mock.AnythingOfType("string")).
 			Return([]localities.ReportCarriers{}, errors.New("any error"))
 
 		service := localities.NewService(mockedRepository)
-		result, err := service.GetReportCarriers("")
+		result, err := service.GetReportCarriers(context.Background(), "")
 
 		assert.Error(t, err.Err)
 		assert.Len(t, result, 0)
@@ -238,3 +255,302 @@ func TestGetAllReportCarriers(t *testing.T) {
 		mockedRepository.AssertExpectations(t)
 	})
 }
+
+func TestGetOneLocality(t *testing.T) {
+	t.Run("Test if get successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, "65760000").Return(fakeLocalities[0], nil)
+
+		service := localities.NewService(mockedRepository)
+		result, resp := service.GetOne(context.Background(), "65760000")
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, fakeLocalities[0], result)
+	})
+
+	t.Run("Test not found case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, "1").Return(localities.Locality{}, localities.GetErrLocalityNotFound("1"))
+
+		service := localities.NewService(mockedRepository)
+		_, resp := service.GetOne(context.Background(), "1")
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("Test internal error case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, "1").Return(localities.Locality{}, errors.New("error to find locality"))
+
+		service := localities.NewService(mockedRepository)
+		_, resp := service.GetOne(context.Background(), "1")
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestUpdateLocality(t *testing.T) {
+	requestData := map[string]interface{}{"locality_name": "Osasco"}
+
+	t.Run("Test if update successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, "1").Return(fakeLocalities[0], nil)
+		mockedRepository.On("Update", mock.Anything, "1", requestData).
+			Return(localities.Locality{Id: "1", LocalityName: "Osasco"}, nil)
+
+		service := localities.NewService(mockedRepository)
+		result, resp := service.Update(context.Background(), "1", requestData)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "Osasco", result.LocalityName)
+	})
+
+	t.Run("Test not found case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, "1").Return(localities.Locality{}, localities.GetErrLocalityNotFound("1"))
+
+		service := localities.NewService(mockedRepository)
+		_, resp := service.Update(context.Background(), "1", requestData)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		mockedRepository.AssertNumberOfCalls(t, "Update", 0)
+	})
+
+	t.Run("Test update without updatable fields case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		service := localities.NewService(mockedRepository)
+		_, resp := service.Update(context.Background(), "1", map[string]interface{}{"id": "2"})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "at least one of locality_name, province_name, country_name, latitude or longitude must be informed", resp.Err.Error())
+		assert.Empty(t, mockedRepository.Calls)
+	})
+
+	t.Run("Test internal error case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, "1").Return(fakeLocalities[0], nil)
+		mockedRepository.On("Update", mock.Anything, "1", requestData).
+			Return(localities.Locality{}, errors.New("couldn't update the locality"))
+
+		service := localities.NewService(mockedRepository)
+		_, resp := service.Update(context.Background(), "1", requestData)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestDeleteLocality(t *testing.T) {
+	t.Run("Test if delete successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, "1").Return(fakeLocalities[0], nil)
+		mockedRepository.On("GetUsage", mock.Anything, "1").Return(localities.LocalityUsage{}, nil)
+		mockedRepository.On("Delete", mock.Anything, "1").Return(nil)
+
+		service := localities.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), "1")
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+	})
+
+	t.Run("Test not found case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, "1").Return(localities.Locality{}, localities.GetErrLocalityNotFound("1"))
+
+		service := localities.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), "1")
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("Test referenced locality case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, "1").Return(fakeLocalities[0], nil)
+		mockedRepository.On("GetUsage", mock.Anything, "1").Return(localities.LocalityUsage{Sellers: 2, Carriers: 1}, nil)
+
+		service := localities.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), "1")
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, "locality with id 1 is used by 2 sellers and 1 carriers", resp.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("Test locality referenced only by warehouses and buyers case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, "1").Return(fakeLocalities[0], nil)
+		mockedRepository.On("GetUsage", mock.Anything, "1").Return(localities.LocalityUsage{Warehouses: 1, Buyers: 3}, nil)
+
+		service := localities.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), "1")
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, "locality with id 1 is used by 1 warehouses and 3 buyers", resp.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("Test usage error case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, "1").Return(fakeLocalities[0], nil)
+		mockedRepository.On("GetUsage", mock.Anything, "1").Return(localities.LocalityUsage{}, errors.New("couldn't check the locality usage"))
+
+		service := localities.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), "1")
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+
+	t.Run("Test delete error case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, "1").Return(fakeLocalities[0], nil)
+		mockedRepository.On("GetUsage", mock.Anything, "1").Return(localities.LocalityUsage{}, nil)
+		mockedRepository.On("Delete", mock.Anything, "1").Return(errors.New("couldn't delete the locality"))
+
+		service := localities.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), "1")
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestGetCountries(t *testing.T) {
+	t.Run("Test if get successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetCountries", mock.Anything).Return([]localities.Country{{CountryName: "BR"}}, nil)
+
+		service := localities.NewService(mockedRepository)
+		result, resp := service.GetCountries(context.Background())
+
+		assert.Nil(t, resp.Err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("Test fail case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetCountries", mock.Anything).Return([]localities.Country{}, errors.New("couldn't get countries"))
+
+		service := localities.NewService(mockedRepository)
+		_, resp := service.GetCountries(context.Background())
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestGetProvinces(t *testing.T) {
+	t.Run("Test if get successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetProvinces", mock.Anything, "BR").
+			Return([]localities.Province{{CountryName: "BR", ProvinceName: "MA"}}, nil)
+
+		service := localities.NewService(mockedRepository)
+		result, resp := service.GetProvinces(context.Background(), "BR")
+
+		assert.Nil(t, resp.Err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("Test unknown country case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetProvinces", mock.Anything, "AR").Return([]localities.Province{}, nil)
+
+		service := localities.NewService(mockedRepository)
+		_, resp := service.GetProvinces(context.Background(), "AR")
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.Equal(t, "country AR not found", resp.Err.Error())
+	})
+
+	t.Run("Test fail case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetProvinces", mock.Anything, "BR").Return([]localities.Province{}, errors.New("couldn't get provinces"))
+
+		service := localities.NewService(mockedRepository)
+		_, resp := service.GetProvinces(context.Background(), "BR")
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestGetLocalities(t *testing.T) {
+	t.Run("Test if get successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetLocalities", mock.Anything, "BR", "MA").
+			Return([]localities.LocalityDetail{{Locality: fakeLocalities[0]}}, nil)
+
+		service := localities.NewService(mockedRepository)
+		result, resp := service.GetLocalities(context.Background(), "BR", "MA")
+
+		assert.Nil(t, resp.Err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("Test unknown province case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetLocalities", mock.Anything, "BR", "XX").Return([]localities.LocalityDetail{}, nil)
+
+		service := localities.NewService(mockedRepository)
+		_, resp := service.GetLocalities(context.Background(), "BR", "XX")
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.Equal(t, "province XX not found in country BR", resp.Err.Error())
+	})
+
+	t.Run("Test fail case", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetLocalities", mock.Anything, "BR", "MA").
+			Return([]localities.LocalityDetail{}, errors.New("couldn't get localities"))
+
+		service := localities.NewService(mockedRepository)
+		_, resp := service.GetLocalities(context.Background(), "BR", "MA")
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestGetCoverageReport(t *testing.T) {
+	t.Run("Test if flags localities without carriers", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetCoverageReport", mock.Anything, localities.CoverageFilters{}).Return([]localities.LocalityCoverage{
+			{Locality: fakeLocalities[0], LocationCounts: localities.LocationCounts{SellersCount: 1}},
+			{Locality: fakeLocalities[1], LocationCounts: localities.LocationCounts{SellersCount: 1}, CoveringCarriersCount: 1},
+			{Locality: localities.Locality{Id: "3"}},
+		}, nil)
+
+		service := localities.NewService(mockedRepository)
+		result, resp := service.GetCoverageReport(context.Background(), localities.CoverageFilters{})
+
+		assert.Nil(t, resp.Err)
+		assert.Len(t, result, 3)
+		assert.True(t, result[0].WithoutCarrierCoverage)
+		assert.False(t, result[1].WithoutCarrierCoverage)
+		assert.False(t, result[2].WithoutCarrierCoverage)
+		mockedRepository.AssertNumberOfCalls(t, "GetOne", 0)
+	})
+
+	t.Run("Test unknown locality case", func(t *testing.T) {
+		filters := localities.CoverageFilters{LocalityId: "1"}
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, "1").Return(localities.Locality{}, localities.GetErrLocalityNotFound("1"))
+
+		service := localities.NewService(mockedRepository)
+		_, resp := service.GetCoverageReport(context.Background(), filters)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		mockedRepository.AssertNumberOfCalls(t, "GetCoverageReport", 0)
+	})
+
+	t.Run("Test fail case", func(t *testing.T) {
+		filters := localities.CoverageFilters{ProvinceName: "MA"}
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetCoverageReport", mock.Anything, filters).
+			Return([]localities.LocalityCoverage{}, errors.New("error to report coverage by locality"))
+
+		service := localities.NewService(mockedRepository)
+		_, resp := service.GetCoverageReport(context.Background(), filters)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
@@ -0,0 +1,19 @@
+SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0;
+
+DROP TABLE IF EXISTS `inbound_orders`;
+DROP TABLE IF EXISTS `product_batch_temperatures`;
+DROP TABLE IF EXISTS `product_batches`;
+DROP TABLE IF EXISTS `purchase_orders`;
+DROP TABLE IF EXISTS `product_records`;
+DROP TABLE IF EXISTS `order_status`;
+DROP TABLE IF EXISTS `carriers`;
+DROP TABLE IF EXISTS `sections`;
+DROP TABLE IF EXISTS `products`;
+DROP TABLE IF EXISTS `sellers`;
+DROP TABLE IF EXISTS `localities`;
+DROP TABLE IF EXISTS `product_type`;
+DROP TABLE IF EXISTS `employees`;
+DROP TABLE IF EXISTS `warehouses`;
+DROP TABLE IF EXISTS `buyers`;
+
+SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS;
@@ -0,0 +1,23 @@
+ALTER TABLE `sellers`
+  DROP COLUMN `street`,
+  DROP COLUMN `number`,
+  DROP COLUMN `complement`,
+  DROP COLUMN `neighborhood`,
+  DROP COLUMN `cep`,
+  DROP COLUMN `state`;
+
+ALTER TABLE `carriers`
+  DROP COLUMN `street`,
+  DROP COLUMN `number`,
+  DROP COLUMN `complement`,
+  DROP COLUMN `neighborhood`,
+  DROP COLUMN `cep`,
+  DROP COLUMN `state`;
+
+ALTER TABLE `warehouses`
+  DROP COLUMN `street`,
+  DROP COLUMN `number`,
+  DROP COLUMN `complement`,
+  DROP COLUMN `neighborhood`,
+  DROP COLUMN `cep`,
+  DROP COLUMN `state`;
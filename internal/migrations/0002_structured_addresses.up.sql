@@ -0,0 +1,34 @@
+-- -----------------------------------------------------
+-- Structured addresses for sellers, carriers and warehouses.
+--
+-- The free-text `address` column is kept for older clients and is now
+-- rendered from the structured columns. Existing rows keep their text as
+-- the street until they are updated with a structured_address.
+-- -----------------------------------------------------
+ALTER TABLE `sellers`
+  ADD COLUMN `street` VARCHAR(255) NOT NULL DEFAULT '' AFTER `address`,
+  ADD COLUMN `number` VARCHAR(255) NOT NULL DEFAULT '' AFTER `street`,
+  ADD COLUMN `complement` VARCHAR(255) NOT NULL DEFAULT '' AFTER `number`,
+  ADD COLUMN `neighborhood` VARCHAR(255) NOT NULL DEFAULT '' AFTER `complement`,
+  ADD COLUMN `cep` VARCHAR(8) NOT NULL DEFAULT '' AFTER `neighborhood`,
+  ADD COLUMN `state` VARCHAR(2) NOT NULL DEFAULT '' AFTER `cep`;
+
+ALTER TABLE `carriers`
+  ADD COLUMN `street` VARCHAR(255) NOT NULL DEFAULT '' AFTER `address`,
+  ADD COLUMN `number` VARCHAR(255) NOT NULL DEFAULT '' AFTER `street`,
+  ADD COLUMN `complement` VARCHAR(255) NOT NULL DEFAULT '' AFTER `number`,
+  ADD COLUMN `neighborhood` VARCHAR(255) NOT NULL DEFAULT '' AFTER `complement`,
+  ADD COLUMN `cep` VARCHAR(8) NOT NULL DEFAULT '' AFTER `neighborhood`,
+  ADD COLUMN `state` VARCHAR(2) NOT NULL DEFAULT '' AFTER `cep`;
+
+ALTER TABLE `warehouses`
+  ADD COLUMN `street` VARCHAR(255) NOT NULL DEFAULT '' AFTER `address`,
+  ADD COLUMN `number` VARCHAR(255) NOT NULL DEFAULT '' AFTER `street`,
+  ADD COLUMN `complement` VARCHAR(255) NOT NULL DEFAULT '' AFTER `number`,
+  ADD COLUMN `neighborhood` VARCHAR(255) NOT NULL DEFAULT '' AFTER `complement`,
+  ADD COLUMN `cep` VARCHAR(8) NOT NULL DEFAULT '' AFTER `neighborhood`,
+  ADD COLUMN `state` VARCHAR(2) NOT NULL DEFAULT '' AFTER `cep`;
+
+UPDATE `sellers` SET `street` = `address` WHERE `street` = '' AND `address` IS NOT NULL;
+UPDATE `carriers` SET `street` = `address` WHERE `street` = '' AND `address` IS NOT NULL;
+UPDATE `warehouses` SET `street` = `address` WHERE `street` = '' AND `address` IS NOT NULL;
@@ -0,0 +1,8 @@
+ALTER TABLE `sellers`
+  DROP COLUMN `telephone_display`;
+
+ALTER TABLE `carriers`
+  DROP COLUMN `telephone_display`;
+
+ALTER TABLE `warehouses`
+  DROP COLUMN `telephone_display`;
@@ -0,0 +1,17 @@
+-- -----------------------------------------------------
+-- Telephones of sellers, carriers and warehouses are stored in E.164
+-- (e.g. +5511999990000) with a separate display format.
+--
+-- After adding the column, rewrite the existing telephones with:
+--   go run ./cmd/fixphones          (dry run, lists what would change)
+--   go run ./cmd/fixphones -apply
+-- Numbers that can not be parsed are listed and left untouched.
+-- -----------------------------------------------------
+ALTER TABLE `sellers`
+  ADD COLUMN `telephone_display` VARCHAR(255) NOT NULL DEFAULT '' AFTER `telephone`;
+
+ALTER TABLE `carriers`
+  ADD COLUMN `telephone_display` VARCHAR(255) NOT NULL DEFAULT '' AFTER `telephone`;
+
+ALTER TABLE `warehouses`
+  ADD COLUMN `telephone_display` VARCHAR(255) NOT NULL DEFAULT '' AFTER `telephone`;
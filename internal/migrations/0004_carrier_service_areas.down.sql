@@ -0,0 +1,3 @@
+DROP VIEW IF EXISTS `carrier_coverage`;
+
+DROP TABLE IF EXISTS `carrier_service_areas`;
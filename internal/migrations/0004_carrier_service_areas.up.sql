@@ -0,0 +1,45 @@
+-- -----------------------------------------------------
+-- Carrier service areas.
+--
+-- A carrier keeps its headquarters in `carriers.locality_id` and may also
+-- serve other localities or whole provinces, each with an optional lead
+-- time. `carrier_coverage` lists every locality a carrier serves.
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `carrier_service_areas` (
+  `id` INT UNSIGNED NOT NULL AUTO_INCREMENT,
+  `carrier_id` INT UNSIGNED NOT NULL,
+  `locality_id` VARCHAR(255) NULL DEFAULT NULL,
+  `province_name` VARCHAR(255) NULL DEFAULT NULL,
+  `country_name` VARCHAR(255) NULL DEFAULT NULL,
+  `lead_time_days` INT UNSIGNED NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `id_UNIQUE` (`id` ASC) VISIBLE,
+  INDEX `fk_carrier_service_areas_carriers_idx` (`carrier_id` ASC) VISIBLE,
+  INDEX `fk_carrier_service_areas_localities_idx` (`locality_id` ASC) VISIBLE,
+  INDEX `carrier_service_areas_province_idx` (`country_name` ASC, `province_name` ASC) VISIBLE,
+  CONSTRAINT `fk_carrier_service_areas_carriers`
+    FOREIGN KEY (`carrier_id`)
+    REFERENCES `carriers` (`id`)
+    ON DELETE CASCADE
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_carrier_service_areas_localities`
+    FOREIGN KEY (`locality_id`)
+    REFERENCES `localities` (`id`)
+    ON DELETE CASCADE
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+DEFAULT CHARACTER SET = utf8mb3;
+
+CREATE OR REPLACE VIEW `carrier_coverage` AS
+  SELECT c.id AS carrier_id, c.locality_id, NULL AS lead_time_days
+  FROM `carriers` c
+  WHERE c.locality_id IS NOT NULL
+  UNION ALL
+  SELECT a.carrier_id, a.locality_id, a.lead_time_days
+  FROM `carrier_service_areas` a
+  WHERE a.locality_id IS NOT NULL
+  UNION ALL
+  SELECT a.carrier_id, l.id, a.lead_time_days
+  FROM `carrier_service_areas` a
+  JOIN `localities` l ON l.province_name = a.province_name AND l.country_name = a.country_name
+  WHERE a.locality_id IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE `products`
+  ADD INDEX `products_description_idx` (`description` ASC) VISIBLE;
@@ -0,0 +1,6 @@
+-- -----------------------------------------------------
+-- The product search matches description with LIKE '%text%', which can not
+-- use a B-tree index, so the index only slowed down the writes.
+-- -----------------------------------------------------
+ALTER TABLE `products`
+  DROP INDEX `products_description_idx`;
@@ -0,0 +1,20 @@
+// Package migrations holds the versioned schema of mercado_fresco and the
+// sample data used in development, embedded in the server binary.
+package migrations
+
+import (
+	"embed"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/migrate"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+//go:embed seed/seed.sql
+var Seed string
+
+// Load returns the migrations of the schema, sorted by version.
+func Load() ([]migrate.Migration, error) {
+	return migrate.Load(files)
+}
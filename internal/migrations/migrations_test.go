@@ -0,0 +1,23 @@
+package migrations_test
+
+import (
+	"testing"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/migrations"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/migrate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	loaded, err := migrations.Load()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, loaded)
+
+	for i, migration := range loaded {
+		assert.Equal(t, i+1, migration.Version, migration.Name)
+		assert.NotEmpty(t, migrate.Statements(migration.Up), migration.Name)
+		assert.NotEmpty(t, migrate.Statements(migration.Down), migration.Name)
+	}
+
+	assert.NotEmpty(t, migrate.Statements(migrations.Seed))
+}
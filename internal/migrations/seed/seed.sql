@@ -0,0 +1,55 @@
+-- -----------------------------------------------------
+-- Sample data for development, applied with "migrate seed" after the
+-- migrations. Rows that already exist are skipped, so it can run again.
+-- -----------------------------------------------------
+
+-- Order status
+INSERT IGNORE INTO `order_status` (description, is_terminal, counts_as_fulfilled, releases_stock) VALUES ("ok", 1, 1, 0);
+INSERT IGNORE INTO `order_status` (description, is_terminal, counts_as_fulfilled, releases_stock) VALUES ("in progress", 0, 0, 0);
+INSERT IGNORE INTO `order_status` (description, is_terminal, counts_as_fulfilled, releases_stock) VALUES ("canceled", 1, 0, 1);
+
+-- Product types
+INSERT IGNORE INTO `product_type` (name) VALUES ("electronic");
+INSERT IGNORE INTO `product_type` (name, minimum_temperature, maximum_temperature) VALUES ("freezed", -18, -12);
+INSERT IGNORE INTO `product_type` (name) VALUES ("food");
+INSERT IGNORE INTO `product_type` (name) VALUES ("data storage");
+INSERT IGNORE INTO `product_type` (name) VALUES ("test");
+
+-- Localities
+INSERT IGNORE INTO `localities` (`id`, `locality_name`, `province_name`, `country_name`, `latitude`, `longitude`) VALUES ("1", "Presidente Dutra", "MA", "BR", -5.289700, -44.495000);
+INSERT IGNORE INTO `localities` (`id`, `locality_name`, `province_name`, `country_name`, `latitude`, `longitude`) VALUES ("2", "Osasco", "SP", "BR", -23.532900, -46.791700);
+INSERT IGNORE INTO `localities` (`id`, `locality_name`, `province_name`, `country_name`, `latitude`, `longitude`) VALUES ("3", "Aparecida de Goiânia", "GO", "BR", -16.819800, -49.246900);
+INSERT IGNORE INTO `localities` (`id`, `locality_name`, `province_name`, `country_name`, `latitude`, `longitude`) VALUES ("4", "Tuntum", "MA", "BR", -5.257800, -44.648900);
+INSERT IGNORE INTO `localities` (`id`, `locality_name`, `province_name`, `country_name`, `latitude`, `longitude`) VALUES ("5", "Barra do Corda", "MA", "BR", -5.505800, -45.243300);
+INSERT IGNORE INTO `localities` (`id`, `locality_name`, `province_name`, `country_name`, `latitude`, `longitude`) VALUES ("6", "Florianópolis", "SC", "BR", -27.595400, -48.548000);
+
+-- Sellers
+INSERT IGNORE INTO `sellers` (`id`, `cid`, `company_name`, `address`, `street`, `state`, `telephone`, `telephone_display`, `locality_id`) VALUES ('1', '10000111000151', 'Mercado Livre', 'Av. Tancredo Neves - MA', 'Av. Tancredo Neves', 'MA', '+559832221100', '(98) 3222-1100', '1');
+INSERT IGNORE INTO `sellers` (`id`, `cid`, `company_name`, `address`, `street`, `state`, `telephone`, `telephone_display`, `locality_id`) VALUES ('2', '20000222000111', 'Mercado Livre', 'Av. Das Nações Unidas - SP', 'Av. Das Nações Unidas', 'SP', '+551132221100', '(11) 3222-1100', '2');
+INSERT IGNORE INTO `sellers` (`id`, `cid`, `company_name`, `address`, `street`, `state`, `telephone`, `telephone_display`, `locality_id`) VALUES ('3', '30000333000181', 'Mercado Livre', 'Av. Olavo Sampaio - GO', 'Av. Olavo Sampaio', 'GO', '+556232221100', '(62) 3222-1100', '3');
+INSERT IGNORE INTO `sellers` (`id`, `cid`, `company_name`, `address`, `street`, `state`, `telephone`, `telephone_display`, `locality_id`) VALUES ('4', '40000444000141', 'Mercado Livre', 'Av. Paulista - MA', 'Av. Paulista', 'MA', '+559832221100', '(98) 3222-1100', '4');
+INSERT IGNORE INTO `sellers` (`id`, `cid`, `company_name`, `address`, `street`, `state`, `telephone`, `telephone_display`, `locality_id`) VALUES ('5', '50000555000101', 'Mercado Livre', 'Av. Das Flores - MA', 'Av. Das Flores', 'MA', '+559832221100', '(98) 3222-1100', '5');
+INSERT IGNORE INTO `sellers` (`id`, `cid`, `company_name`, `address`, `street`, `state`, `telephone`, `telephone_display`, `locality_id`) VALUES ('6', '60000666000171', 'Mercado Livre', 'Av. Campo Dantas - SC', 'Av. Campo Dantas', 'SC', '+554832221100', '(48) 3222-1100', '6');
+
+-- Products
+INSERT IGNORE INTO `products` (`id`, `product_code`, `description`, `width`, `height`, `length`, `net_weight`, `expiration_rate`, `recommended_freezing_temperature`, `freezing_rate`, `product_type_id`, `seller_id`) VALUES ('1', '1', 'Tomato', '250', '250', '250', '10', '50', '34', '50', '1', '1');
+INSERT IGNORE INTO `products` (`id`, `product_code`, `description`, `width`, `height`, `length`, `net_weight`, `expiration_rate`, `recommended_freezing_temperature`, `freezing_rate`, `product_type_id`, `seller_id`) VALUES ('2', '2', 'Purple Onion', '250', '250', '250', '10', '60', '34', '50', '2', '2');
+INSERT IGNORE INTO `products` (`id`, `product_code`, `description`, `width`, `height`, `length`, `net_weight`, `expiration_rate`, `recommended_freezing_temperature`, `freezing_rate`, `product_type_id`, `seller_id`) VALUES ('3', '3', 'RAM 16GB DDR5', '260', '250', '250', '10', '70', '34', '50', '3', '3');
+INSERT IGNORE INTO `products` (`id`, `product_code`, `description`, `width`, `height`, `length`, `net_weight`, `expiration_rate`, `recommended_freezing_temperature`, `freezing_rate`, `product_type_id`, `seller_id`) VALUES ('4', '4', 'RTX 3080 16GB', '250', '250', '250', '10', '90', '34', '50', '4', '4');
+INSERT IGNORE INTO `products` (`id`, `product_code`, `description`, `width`, `height`, `length`, `net_weight`, `expiration_rate`, `recommended_freezing_temperature`, `freezing_rate`, `product_type_id`, `seller_id`) VALUES ('5', '5', 'Intel Core i9', '250', '250', '250', '10', '80', '34', '50', '5', '5');
+
+-- Warehouses
+INSERT IGNORE INTO `warehouses`(`warehouse_code`,`address`,`street`,`state`,`telephone`,`telephone_display`,`minimum_temperature`,`minimum_capacity`,`locality_id`)VALUES("Cod#1","Rua Hercílio Luz - SC","Rua Hercílio Luz","SC","+5548999001122","(48) 99900-1122",10,10,"6");
+INSERT IGNORE INTO `warehouses`(`warehouse_code`,`address`,`street`,`state`,`telephone`,`telephone_display`,`minimum_temperature`,`minimum_capacity`,`locality_id`)VALUES("Cod#2","Avenida Paulista - SP","Avenida Paulista","SP","+5511999001133","(11) 99900-1133",5,20,"2");
+
+-- Buyers
+INSERT IGNORE INTO `buyers`(`card_number_id`,`first_name`,`last_name`)VALUES("12345779179","Vitor","Souza");
+INSERT IGNORE INTO `buyers`(`card_number_id`,`first_name`,`last_name`)VALUES("12345880210","Lucas","Bulhões");
+
+-- Product Records
+INSERT IGNORE INTO `product_records`(`id`,`last_update_date`,`purchase_price`,`sale_price`,`product_id`)VALUES(1,"2022-01-01",5,25,2);
+INSERT IGNORE INTO `product_records`(`id`,`last_update_date`,`purchase_price`,`sale_price`,`product_id`)VALUES(2,"2022-01-05",6,23,2);
+
+-- Sections
+INSERT IGNORE INTO `sections`(`section_number`,`current_temperature`,`minimum_temperature`,`current_capacity`,`minimum_capacity`,`maximum_capacity`,`warehouse_id`,`product_type_id`)VALUES(1,25,5,200,5,800,1,1);
+INSERT IGNORE INTO `sections`(`section_number`,`current_temperature`,`minimum_temperature`,`current_capacity`,`minimum_capacity`,`maximum_capacity`,`warehouse_id`,`product_type_id`)VALUES(13,25,5,200,5,800,2,2);
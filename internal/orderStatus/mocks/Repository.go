@@ -2,20 +2,67 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	order_status "github.com/emidioreb/mercado-fresco-lerigophers/internal/orderStatus"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Repository is an autogenerated mock type for the Repository type
 type Repository struct {
 	mock.Mock
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Repository) GetOne(id int) error {
-	ret := _m.Called(id)
+// CountPurchaseOrders provides a mock function with given fields: ctx, id
+func (_m *Repository) CountPurchaseOrders(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, description, isTerminal, countsAsFulfilled, releasesStock
+func (_m *Repository) Create(ctx context.Context, description string, isTerminal bool, countsAsFulfilled bool, releasesStock bool) (order_status.OrderStatus, error) {
+	ret := _m.Called(ctx, description, isTerminal, countsAsFulfilled, releasesStock)
+
+	var r0 order_status.OrderStatus
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, bool, bool) order_status.OrderStatus); ok {
+		r0 = rf(ctx, description, isTerminal, countsAsFulfilled, releasesStock)
+	} else {
+		r0 = ret.Get(0).(order_status.OrderStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, bool, bool) error); ok {
+		r1 = rf(ctx, description, isTerminal, countsAsFulfilled, releasesStock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Repository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -23,6 +70,71 @@ func (_m *Repository) GetOne(id int) error {
 	return r0
 }
 
+// GetAll provides a mock function with given fields: ctx
+func (_m *Repository) GetAll(ctx context.Context) ([]order_status.OrderStatus, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []order_status.OrderStatus
+	if rf, ok := ret.Get(0).(func(context.Context) []order_status.OrderStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order_status.OrderStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Repository) GetOne(ctx context.Context, id int) (order_status.OrderStatus, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 order_status.OrderStatus
+	if rf, ok := ret.Get(0).(func(context.Context, int) order_status.OrderStatus); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(order_status.OrderStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Repository) Update(ctx context.Context, id int, requestData map[string]interface{}) (order_status.OrderStatus, error) {
+	ret := _m.Called(ctx, id, requestData)
+
+	var r0 order_status.OrderStatus
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) order_status.OrderStatus); ok {
+		r0 = rf(ctx, id, requestData)
+	} else {
+		r0 = ret.Get(0).(order_status.OrderStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) error); ok {
+		r1 = rf(ctx, id, requestData)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewRepository interface {
 	mock.TestingT
 	Cleanup(func())
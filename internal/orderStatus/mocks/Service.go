@@ -0,0 +1,132 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	order_status "github.com/emidioreb/mercado-fresco-lerigophers/internal/orderStatus"
+	mock "github.com/stretchr/testify/mock"
+
+	web "github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, description, isTerminal, countsAsFulfilled, releasesStock
+func (_m *Service) Create(ctx context.Context, description string, isTerminal bool, countsAsFulfilled bool, releasesStock bool) (order_status.OrderStatus, web.ResponseCode) {
+	ret := _m.Called(ctx, description, isTerminal, countsAsFulfilled, releasesStock)
+
+	var r0 order_status.OrderStatus
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, bool, bool) order_status.OrderStatus); ok {
+		r0 = rf(ctx, description, isTerminal, countsAsFulfilled, releasesStock)
+	} else {
+		r0 = ret.Get(0).(order_status.OrderStatus)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, bool, bool) web.ResponseCode); ok {
+		r1 = rf(ctx, description, isTerminal, countsAsFulfilled, releasesStock)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Service) Delete(ctx context.Context, id int) web.ResponseCode {
+	ret := _m.Called(ctx, id)
+
+	var r0 web.ResponseCode
+	if rf, ok := ret.Get(0).(func(context.Context, int) web.ResponseCode); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(web.ResponseCode)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *Service) GetAll(ctx context.Context) ([]order_status.OrderStatus, web.ResponseCode) {
+	ret := _m.Called(ctx)
+
+	var r0 []order_status.OrderStatus
+	if rf, ok := ret.Get(0).(func(context.Context) []order_status.OrderStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order_status.OrderStatus)
+		}
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context) web.ResponseCode); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Service) GetOne(ctx context.Context, id int) (order_status.OrderStatus, web.ResponseCode) {
+	ret := _m.Called(ctx, id)
+
+	var r0 order_status.OrderStatus
+	if rf, ok := ret.Get(0).(func(context.Context, int) order_status.OrderStatus); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(order_status.OrderStatus)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Service) Update(ctx context.Context, id int, requestData map[string]interface{}) (order_status.OrderStatus, web.ResponseCode) {
+	ret := _m.Called(ctx, id, requestData)
+
+	var r0 order_status.OrderStatus
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) order_status.OrderStatus); ok {
+		r0 = rf(ctx, id, requestData)
+	} else {
+		r0 = ret.Get(0).(order_status.OrderStatus)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) web.ResponseCode); ok {
+		r1 = rf(ctx, id, requestData)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewService(t mockConstructorTestingTNewService) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,9 @@
+package order_status
+
+type OrderStatus struct {
+	Id                int    `json:"id"`
+	Description       string `json:"description"`
+	IsTerminal        bool   `json:"is_terminal"`
+	CountsAsFulfilled bool   `json:"counts_as_fulfilled"`
+	ReleasesStock     bool   `json:"releases_stock"`
+}
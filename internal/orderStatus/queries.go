@@ -0,0 +1,41 @@
+package order_status
+
+import "fmt"
+
+var (
+	queryCreateOrderStatus = `INSERT INTO order_status (description, is_terminal, counts_as_fulfilled, releases_stock)
+		VALUES (?, ?, ?, ?)`
+	queryGetOneOrderStatus = `SELECT id, description, is_terminal, counts_as_fulfilled, releases_stock
+		FROM order_status WHERE id = ?`
+	queryGetAllOrderStatus = `SELECT id, description, is_terminal, counts_as_fulfilled, releases_stock
+		FROM order_status`
+	queryDeleteOrderStatus   = "DELETE FROM order_status WHERE id = ?"
+	queryCountPurchaseOrders = "SELECT count(*) FROM purchase_orders WHERE order_status_id = ?"
+
+	queryUpdateOrderStatus = func(requestData map[string]interface{}, id int) (finalQuery string, valuesToUse []interface{}) {
+		prefixQuery := "UPDATE order_status SET"
+		fieldsToUpdate := []string{}
+		whereCase := "WHERE id = ?"
+
+		var fields = []string{"description", "is_terminal", "counts_as_fulfilled", "releases_stock"}
+		for _, currField := range fields {
+			if value, ok := requestData[currField]; ok {
+				fieldsToUpdate = append(fieldsToUpdate, fmt.Sprintf(" %s = ?", currField))
+				valuesToUse = append(valuesToUse, value)
+			}
+		}
+
+		valuesToUse = append(valuesToUse, id)
+		finalQuery += prefixQuery
+		for index, field := range fieldsToUpdate {
+			if index+1 == len(fieldsToUpdate) {
+				finalQuery += field + " "
+			} else {
+				finalQuery += field + ", "
+			}
+		}
+		finalQuery += whereCase
+
+		return finalQuery, valuesToUse
+	}
+)
@@ -1,39 +1,159 @@
 package order_status
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
+)
+
+var (
+	errCreateOrderStatus   = errors.New("ocurred an error to create order_status")
+	errGetAllOrderStatus   = errors.New("couldn't get order_status")
+	errGetOneOrderStatus   = errors.New("unexpected error to verify order_status")
+	errUpdateOrderStatus   = errors.New("ocurred an error while updating the order_status")
+	errDeleteOrderStatus   = errors.New("unexpected error to delete order_status")
+	errCountPurchaseOrders = errors.New("unexpected error to verify order_status usage")
 )
 
+func GetErrOrderStatusNotFound(id int) error {
+	return fmt.Errorf("order_status with id %d not found", id)
+}
+
 type Repository interface {
-	GetOne(id int) error
+	Create(ctx context.Context, description string, isTerminal, countsAsFulfilled, releasesStock bool) (OrderStatus, error)
+	GetOne(ctx context.Context, id int) (OrderStatus, error)
+	GetAll(ctx context.Context) ([]OrderStatus, error)
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (OrderStatus, error)
+	Delete(ctx context.Context, id int) error
+	CountPurchaseOrders(ctx context.Context, id int) (int, error)
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
-func (mariaDb mariaDbRepository) GetOne(id int) error {
-	queryGetOne := "SELECT id FROM order_status WHERE id = ?"
-	var idSelected int
+func (mariaDb mariaDbRepository) Create(ctx context.Context, description string, isTerminal, countsAsFulfilled, releasesStock bool) (OrderStatus, error) {
+	result, err := mariaDb.db.ExecContext(ctx, queryCreateOrderStatus, description, isTerminal, countsAsFulfilled, releasesStock)
+	if err != nil {
+		return OrderStatus{}, errCreateOrderStatus
+	}
+
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		return OrderStatus{}, errCreateOrderStatus
+	}
+
+	return OrderStatus{
+		Id:                int(lastId),
+		Description:       description,
+		IsTerminal:        isTerminal,
+		CountsAsFulfilled: countsAsFulfilled,
+		ReleasesStock:     releasesStock,
+	}, nil
+}
 
-	row := mariaDb.db.QueryRow(queryGetOne, id)
-	err := row.Scan(&idSelected)
+func (mariaDb mariaDbRepository) GetOne(ctx context.Context, id int) (OrderStatus, error) {
+	var orderStatus OrderStatus
+
+	row := mariaDb.db.QueryRowContext(ctx, transaction.Lock(ctx, queryGetOneOrderStatus), id)
+	err := row.Scan(
+		&orderStatus.Id,
+		&orderStatus.Description,
+		&orderStatus.IsTerminal,
+		&orderStatus.CountsAsFulfilled,
+		&orderStatus.ReleasesStock,
+	)
 
 	if errors.Is(err, sql.ErrNoRows) {
-		return fmt.Errorf("order_status with id %d not found", id)
+		return OrderStatus{}, GetErrOrderStatusNotFound(id)
+	}
+
+	if err != nil {
+		return OrderStatus{}, errGetOneOrderStatus
+	}
+
+	return orderStatus, nil
+}
+
+func (mariaDb mariaDbRepository) GetAll(ctx context.Context) ([]OrderStatus, error) {
+	allOrderStatus := []OrderStatus{}
+
+	rows, err := mariaDb.db.QueryContext(ctx, queryGetAllOrderStatus)
+	if err != nil {
+		return []OrderStatus{}, errGetAllOrderStatus
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderStatus OrderStatus
+		if err := rows.Scan(
+			&orderStatus.Id,
+			&orderStatus.Description,
+			&orderStatus.IsTerminal,
+			&orderStatus.CountsAsFulfilled,
+			&orderStatus.ReleasesStock,
+		); err != nil {
+			return []OrderStatus{}, errGetAllOrderStatus
+		}
+		allOrderStatus = append(allOrderStatus, orderStatus)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []OrderStatus{}, errGetAllOrderStatus
+	}
+
+	return allOrderStatus, nil
+}
+
+func (mariaDb mariaDbRepository) Update(ctx context.Context, id int, requestData map[string]interface{}) (OrderStatus, error) {
+	finalQuery, valuesToUse := queryUpdateOrderStatus(requestData, id)
+
+	if _, err := mariaDb.db.ExecContext(ctx, finalQuery, valuesToUse...); err != nil {
+		return OrderStatus{}, errUpdateOrderStatus
 	}
 
+	orderStatus, err := mariaDb.GetOne(ctx, id)
 	if err != nil {
-		return errors.New("unexpected error to verify order_status")
+		return OrderStatus{}, errUpdateOrderStatus
+	}
+
+	return orderStatus, nil
+}
+
+func (mariaDb mariaDbRepository) Delete(ctx context.Context, id int) error {
+	result, err := mariaDb.db.ExecContext(ctx, queryDeleteOrderStatus, id)
+	if err != nil {
+		return errDeleteOrderStatus
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return errDeleteOrderStatus
+	}
+
+	if affectedRows == 0 {
+		return GetErrOrderStatusNotFound(id)
 	}
 
 	return nil
 }
+
+func (mariaDb mariaDbRepository) CountPurchaseOrders(ctx context.Context, id int) (int, error) {
+	var count int
+
+	row := mariaDb.db.QueryRowContext(ctx, queryCountPurchaseOrders, id)
+	if err := row.Scan(&count); err != nil {
+		return 0, errCountPurchaseOrders
+	}
+
+	return count, nil
+}
@@ -1,6 +1,8 @@
 package order_status
 
 import (
+	"context"
+	"errors"
 	"regexp"
 	"testing"
 
@@ -8,18 +10,248 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var orderStatusColumns = []string{"id", "description", "is_terminal", "counts_as_fulfilled", "releases_stock"}
+
+func TestDBCreateOrderStatus(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryCreateOrderStatus)).
+			WithArgs("delivered", true, true, false).
+			WillReturnResult(sqlmock.NewResult(4, 1))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		orderStatus, err := orderStatusRepo.Create(context.Background(), "delivered", true, true, false)
+		assert.NoError(t, err)
+		assert.Equal(t, OrderStatus{Id: 4, Description: "delivered", IsTerminal: true, CountsAsFulfilled: true}, orderStatus)
+	})
+
+	t.Run("Exec error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryCreateOrderStatus)).WillReturnError(errors.New("internal db error"))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		_, err = orderStatusRepo.Create(context.Background(), "delivered", true, true, false)
+		assert.Equal(t, errCreateOrderStatus, err)
+	})
+
+	t.Run("LastInsertId error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryCreateOrderStatus)).
+			WillReturnResult(sqlmock.NewErrorResult(errors.New("last insert id error")))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		_, err = orderStatusRepo.Create(context.Background(), "delivered", true, true, false)
+		assert.Equal(t, errCreateOrderStatus, err)
+	})
+}
+
 func TestDBGetOneOrderStatus(t *testing.T) {
 	t.Run("Success case", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.NoError(t, err)
 		defer db.Close()
 
-		rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+		rows := sqlmock.NewRows(orderStatusColumns).AddRow(3, "canceled", true, false, true)
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM order_status WHERE id = ?")).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneOrderStatus)).WithArgs(3).WillReturnRows(rows)
 
 		orderStatusRepo := NewMariaDbRepository(db)
-		err = orderStatusRepo.GetOne(1)
+		orderStatus, err := orderStatusRepo.GetOne(context.Background(), 3)
 		assert.NoError(t, err)
+		assert.True(t, orderStatus.IsTerminal)
+		assert.False(t, orderStatus.CountsAsFulfilled)
+		assert.True(t, orderStatus.ReleasesStock)
+	})
+
+	t.Run("Not found case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneOrderStatus)).WithArgs(9).
+			WillReturnRows(sqlmock.NewRows(orderStatusColumns))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		_, err = orderStatusRepo.GetOne(context.Background(), 9)
+		assert.Equal(t, "order_status with id 9 not found", err.Error())
+	})
+
+	t.Run("Unexpected error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneOrderStatus)).WillReturnError(errors.New("internal db error"))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		_, err = orderStatusRepo.GetOne(context.Background(), 1)
+		assert.Equal(t, errGetOneOrderStatus, err)
+	})
+}
+
+func TestDBGetAllOrderStatus(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(orderStatusColumns).
+			AddRow(1, "ok", true, true, false).
+			AddRow(2, "in progress", false, false, false)
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetAllOrderStatus)).WillReturnRows(rows)
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		allOrderStatus, err := orderStatusRepo.GetAll(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, allOrderStatus, 2)
+		assert.Equal(t, "in progress", allOrderStatus[1].Description)
+	})
+
+	t.Run("Query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetAllOrderStatus)).WillReturnError(errors.New("internal db error"))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		_, err = orderStatusRepo.GetAll(context.Background())
+		assert.Equal(t, errGetAllOrderStatus, err)
+	})
+
+	t.Run("Scan error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(orderStatusColumns).AddRow("", "", "", "", "")
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetAllOrderStatus)).WillReturnRows(rows)
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		_, err = orderStatusRepo.GetAll(context.Background())
+		assert.Equal(t, errGetAllOrderStatus, err)
+	})
+}
+
+func TestDBUpdateOrderStatus(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		requestData := map[string]interface{}{"description": "done", "is_terminal": true}
+		finalQuery, _ := queryUpdateOrderStatus(requestData, 1)
+
+		mock.ExpectExec(regexp.QuoteMeta(finalQuery)).
+			WithArgs("done", true, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneOrderStatus)).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(orderStatusColumns).AddRow(1, "done", true, true, false))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		orderStatus, err := orderStatusRepo.Update(context.Background(), 1, requestData)
+		assert.NoError(t, err)
+		assert.Equal(t, "done", orderStatus.Description)
+	})
+
+	t.Run("Exec error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE order_status").WillReturnError(errors.New("internal db error"))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		_, err = orderStatusRepo.Update(context.Background(), 1, map[string]interface{}{"description": "done"})
+		assert.Equal(t, errUpdateOrderStatus, err)
+	})
+
+	t.Run("GetOne error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE order_status").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneOrderStatus)).WillReturnError(errors.New("internal db error"))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		_, err = orderStatusRepo.Update(context.Background(), 1, map[string]interface{}{"description": "done"})
+		assert.Equal(t, errUpdateOrderStatus, err)
+	})
+}
+
+func TestDBDeleteOrderStatus(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteOrderStatus)).WithArgs(4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		assert.NoError(t, orderStatusRepo.Delete(context.Background(), 4))
+	})
+
+	t.Run("Not found case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteOrderStatus)).WithArgs(4).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		err = orderStatusRepo.Delete(context.Background(), 4)
+		assert.Equal(t, "order_status with id 4 not found", err.Error())
+	})
+
+	t.Run("Exec error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteOrderStatus)).WillReturnError(errors.New("internal db error"))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		assert.Equal(t, errDeleteOrderStatus, orderStatusRepo.Delete(context.Background(), 4))
+	})
+}
+
+func TestDBCountPurchaseOrders(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryCountPurchaseOrders)).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		count, err := orderStatusRepo.CountPurchaseOrders(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 7, count)
+	})
+
+	t.Run("Query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryCountPurchaseOrders)).WillReturnError(errors.New("internal db error"))
+
+		orderStatusRepo := NewMariaDbRepository(db)
+		_, err = orderStatusRepo.CountPurchaseOrders(context.Background(), 1)
+		assert.Equal(t, errCountPurchaseOrders, err)
 	})
 }
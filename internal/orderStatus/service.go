@@ -0,0 +1,140 @@
+package order_status
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+)
+
+var (
+	errFulfilledNotTerminal = errors.New("an order_status that counts as fulfilled must be terminal")
+	errDescriptionExists    = errors.New("order_status description already exists")
+)
+
+type Service interface {
+	Create(ctx context.Context, description string, isTerminal, countsAsFulfilled, releasesStock bool) (OrderStatus, web.ResponseCode)
+	GetOne(ctx context.Context, id int) (OrderStatus, web.ResponseCode)
+	GetAll(ctx context.Context) ([]OrderStatus, web.ResponseCode)
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (OrderStatus, web.ResponseCode)
+	Delete(ctx context.Context, id int) web.ResponseCode
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{
+		repository: r,
+	}
+}
+
+func (s service) Create(ctx context.Context, description string, isTerminal, countsAsFulfilled, releasesStock bool) (OrderStatus, web.ResponseCode) {
+	if countsAsFulfilled && !isTerminal {
+		return OrderStatus{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errFulfilledNotTerminal)
+	}
+
+	if resp := s.checkDescriptionAvailable(ctx, description, 0); resp.Err != nil {
+		return OrderStatus{}, resp
+	}
+
+	orderStatus, err := s.repository.Create(ctx, description, isTerminal, countsAsFulfilled, releasesStock)
+	if err != nil {
+		return OrderStatus{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return orderStatus, web.NewCodeResponse(http.StatusCreated, nil)
+}
+
+func (s service) GetOne(ctx context.Context, id int) (OrderStatus, web.ResponseCode) {
+	orderStatus, err := s.repository.GetOne(ctx, id)
+	if err != nil {
+		if err.Error() == GetErrOrderStatusNotFound(id).Error() {
+			return OrderStatus{}, web.NewCodeResponse(http.StatusNotFound, err)
+		}
+		return OrderStatus{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return orderStatus, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) GetAll(ctx context.Context) ([]OrderStatus, web.ResponseCode) {
+	allOrderStatus, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return []OrderStatus{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return allOrderStatus, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) Update(ctx context.Context, id int, requestData map[string]interface{}) (OrderStatus, web.ResponseCode) {
+	current, resp := s.GetOne(ctx, id)
+	if resp.Err != nil {
+		return OrderStatus{}, resp
+	}
+
+	if description, ok := requestData["description"].(string); ok {
+		if resp := s.checkDescriptionAvailable(ctx, description, id); resp.Err != nil {
+			return OrderStatus{}, resp
+		}
+	}
+
+	if value, ok := requestData["is_terminal"].(bool); ok {
+		current.IsTerminal = value
+	}
+	if value, ok := requestData["counts_as_fulfilled"].(bool); ok {
+		current.CountsAsFulfilled = value
+	}
+
+	if current.CountsAsFulfilled && !current.IsTerminal {
+		return OrderStatus{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errFulfilledNotTerminal)
+	}
+
+	orderStatus, err := s.repository.Update(ctx, id, requestData)
+	if err != nil {
+		return OrderStatus{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return orderStatus, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) Delete(ctx context.Context, id int) web.ResponseCode {
+	if _, resp := s.GetOne(ctx, id); resp.Err != nil {
+		return resp
+	}
+
+	count, err := s.repository.CountPurchaseOrders(ctx, id)
+	if err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	if resp := web.ReferencedBy("order_status", id,
+		web.Reference{Table: "purchase_orders", Count: count},
+	); resp.Err != nil {
+		return resp
+	}
+
+	if err := s.repository.Delete(ctx, id); err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return web.NewCodeResponse(http.StatusNoContent, nil)
+}
+
+func (s service) checkDescriptionAvailable(ctx context.Context, description string, id int) web.ResponseCode {
+	allOrderStatus, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	for _, orderStatus := range allOrderStatus {
+		if strings.EqualFold(orderStatus.Description, description) && orderStatus.Id != id {
+			return web.NewCodeResponse(http.StatusConflict, errDescriptionExists)
+		}
+	}
+
+	return web.ResponseCode{}
+}
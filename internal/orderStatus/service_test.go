@@ -0,0 +1,243 @@
+package order_status_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	order_status "github.com/emidioreb/mercado-fresco-lerigophers/internal/orderStatus"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/orderStatus/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var fakeOrderStatus = []order_status.OrderStatus{
+	{Id: 1, Description: "ok", IsTerminal: true, CountsAsFulfilled: true},
+	{Id: 2, Description: "in progress"},
+	{Id: 3, Description: "canceled", IsTerminal: true, ReleasesStock: true},
+}
+
+func TestServiceCreate(t *testing.T) {
+	t.Run("Test if create successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expected := order_status.OrderStatus{Id: 4, Description: "returned", IsTerminal: true, ReleasesStock: true}
+
+		mockedRepository.On("GetAll", mock.Anything).Return(fakeOrderStatus, nil)
+		mockedRepository.On("Create", mock.Anything, "returned", true, false, true).Return(expected, nil)
+
+		service := order_status.NewService(mockedRepository)
+		result, resp := service.Create(context.Background(), "returned", true, false, true)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("Test fulfilled status must be terminal", func(t *testing.T) {
+		service := order_status.NewService(new(mocks.Repository))
+		_, resp := service.Create(context.Background(), "delivered", false, true, false)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+
+	t.Run("Test conflict if description already exists", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything).Return(fakeOrderStatus, nil)
+
+		service := order_status.NewService(mockedRepository)
+		_, resp := service.Create(context.Background(), "Canceled", true, false, true)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
+	t.Run("Test error on create", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything).Return(fakeOrderStatus, nil)
+		mockedRepository.On("Create", mock.Anything, "returned", true, false, true).
+			Return(order_status.OrderStatus{}, errors.New("ocurred an error to create order_status"))
+
+		service := order_status.NewService(mockedRepository)
+		_, resp := service.Create(context.Background(), "returned", true, false, true)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestServiceGet(t *testing.T) {
+	t.Run("Test get one", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 3).Return(fakeOrderStatus[2], nil)
+
+		service := order_status.NewService(mockedRepository)
+		result, resp := service.GetOne(context.Background(), 3)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, fakeOrderStatus[2], result)
+	})
+
+	t.Run("Test get one not found", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 9).Return(order_status.OrderStatus{}, order_status.GetErrOrderStatusNotFound(9))
+
+		service := order_status.NewService(mockedRepository)
+		_, resp := service.GetOne(context.Background(), 9)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("Test get one unexpected error", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 9).Return(order_status.OrderStatus{}, errors.New("unexpected error to verify order_status"))
+
+		service := order_status.NewService(mockedRepository)
+		_, resp := service.GetOne(context.Background(), 9)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+
+	t.Run("Test get all", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything).Return(fakeOrderStatus, nil)
+
+		service := order_status.NewService(mockedRepository)
+		result, resp := service.GetAll(context.Background())
+
+		assert.Nil(t, resp.Err)
+		assert.Len(t, result, 3)
+	})
+
+	t.Run("Test get all error", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything).Return([]order_status.OrderStatus{}, errors.New("couldn't get order_status"))
+
+		service := order_status.NewService(mockedRepository)
+		_, resp := service.GetAll(context.Background())
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestServiceUpdate(t *testing.T) {
+	t.Run("Test if update flags successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		requestData := map[string]interface{}{"releases_stock": false}
+		expected := fakeOrderStatus[2]
+		expected.ReleasesStock = false
+
+		mockedRepository.On("GetOne", mock.Anything, 3).Return(fakeOrderStatus[2], nil)
+		mockedRepository.On("Update", mock.Anything, 3, requestData).Return(expected, nil)
+
+		service := order_status.NewService(mockedRepository)
+		result, resp := service.Update(context.Background(), 3, requestData)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("Test fulfilled status must stay terminal", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeOrderStatus[0], nil)
+
+		service := order_status.NewService(mockedRepository)
+		_, resp := service.Update(context.Background(), 1, map[string]interface{}{"is_terminal": false})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+
+	t.Run("Test conflict on description", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(fakeOrderStatus[1], nil)
+		mockedRepository.On("GetAll", mock.Anything).Return(fakeOrderStatus, nil)
+
+		service := order_status.NewService(mockedRepository)
+		_, resp := service.Update(context.Background(), 2, map[string]interface{}{"description": "ok"})
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
+	t.Run("Test not found", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 9).Return(order_status.OrderStatus{}, order_status.GetErrOrderStatusNotFound(9))
+
+		service := order_status.NewService(mockedRepository)
+		_, resp := service.Update(context.Background(), 9, map[string]interface{}{"description": "done"})
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("Test error on update", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		requestData := map[string]interface{}{"releases_stock": true}
+
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(fakeOrderStatus[1], nil)
+		mockedRepository.On("Update", mock.Anything, 2, requestData).
+			Return(order_status.OrderStatus{}, errors.New("ocurred an error while updating the order_status"))
+
+		service := order_status.NewService(mockedRepository)
+		_, resp := service.Update(context.Background(), 2, requestData)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestServiceDelete(t *testing.T) {
+	t.Run("Test if delete successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 3).Return(fakeOrderStatus[2], nil)
+		mockedRepository.On("CountPurchaseOrders", mock.Anything, 3).Return(0, nil)
+		mockedRepository.On("Delete", mock.Anything, 3).Return(nil)
+
+		service := order_status.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), 3)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+	})
+
+	t.Run("Test conflict if used by purchase_orders", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeOrderStatus[0], nil)
+		mockedRepository.On("CountPurchaseOrders", mock.Anything, 1).Return(4, nil)
+
+		service := order_status.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, "order_status with id 1 is used by 4 purchase_orders", resp.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("Test not found", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 9).Return(order_status.OrderStatus{}, order_status.GetErrOrderStatusNotFound(9))
+
+		service := order_status.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), 9)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("Test error to count purchase_orders", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeOrderStatus[0], nil)
+		mockedRepository.On("CountPurchaseOrders", mock.Anything, 1).Return(0, errors.New("unexpected error to verify order_status usage"))
+
+		service := order_status.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+
+	t.Run("Test error on delete", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 3).Return(fakeOrderStatus[2], nil)
+		mockedRepository.On("CountPurchaseOrders", mock.Anything, 3).Return(0, nil)
+		mockedRepository.On("Delete", mock.Anything, 3).Return(errors.New("unexpected error to delete order_status"))
+
+		service := order_status.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), 3)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
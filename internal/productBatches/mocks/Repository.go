@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	time "time"
 
 	product_batches "github.com/emidioreb/mercado-fresco-lerigophers/internal/productBatches"
@@ -14,20 +15,20 @@ type Repository struct {
 	mock.Mock
 }
 
-// CreateProductBatch provides a mock function with given fields: BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate
-func (_m *Repository) CreateProductBatch(BatchNumber int, CurrentQuantity int, CurrentTemperature int, InitialQuantity int, ManufacturingHour int, MinimumTemperature int, ProductId int, SectionId int, DueDate time.Time, ManufacturingDate time.Time) (product_batches.ProductBatches, error) {
-	ret := _m.Called(BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
+// CreateProductBatch provides a mock function with given fields: ctx, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate
+func (_m *Repository) CreateProductBatch(ctx context.Context, BatchNumber int, CurrentQuantity int, CurrentTemperature int, InitialQuantity int, ManufacturingHour int, MinimumTemperature int, ProductId int, SectionId int, DueDate time.Time, ManufacturingDate time.Time) (product_batches.ProductBatches, error) {
+	ret := _m.Called(ctx, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
 
 	var r0 product_batches.ProductBatches
-	if rf, ok := ret.Get(0).(func(int, int, int, int, int, int, int, int, time.Time, time.Time) product_batches.ProductBatches); ok {
-		r0 = rf(BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int, int, int, int, int, time.Time, time.Time) product_batches.ProductBatches); ok {
+		r0 = rf(ctx, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
 	} else {
 		r0 = ret.Get(0).(product_batches.ProductBatches)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int, int, int, int, int, int, int, int, time.Time, time.Time) error); ok {
-		r1 = rf(BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, int, int, int, int, int, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -35,20 +36,41 @@ func (_m *Repository) CreateProductBatch(BatchNumber int, CurrentQuantity int, C
 	return r0, r1
 }
 
-// GetOne provides a mock function with given fields: BatchNumber
-func (_m *Repository) GetOne(BatchNumber int) (product_batches.ProductBatches, error) {
-	ret := _m.Called(BatchNumber)
+// CreateTemperatureReading provides a mock function with given fields: ctx, productBatchId, temperature, recordedAt
+func (_m *Repository) CreateTemperatureReading(ctx context.Context, productBatchId int, temperature float64, recordedAt time.Time) (product_batches.TemperatureReading, error) {
+	ret := _m.Called(ctx, productBatchId, temperature, recordedAt)
+
+	var r0 product_batches.TemperatureReading
+	if rf, ok := ret.Get(0).(func(context.Context, int, float64, time.Time) product_batches.TemperatureReading); ok {
+		r0 = rf(ctx, productBatchId, temperature, recordedAt)
+	} else {
+		r0 = ret.Get(0).(product_batches.TemperatureReading)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, float64, time.Time) error); ok {
+		r1 = rf(ctx, productBatchId, temperature, recordedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOne provides a mock function with given fields: ctx, BatchNumber
+func (_m *Repository) GetOne(ctx context.Context, BatchNumber int) (product_batches.ProductBatches, error) {
+	ret := _m.Called(ctx, BatchNumber)
 
 	var r0 product_batches.ProductBatches
-	if rf, ok := ret.Get(0).(func(int) product_batches.ProductBatches); ok {
-		r0 = rf(BatchNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, int) product_batches.ProductBatches); ok {
+		r0 = rf(ctx, BatchNumber)
 	} else {
 		r0 = ret.Get(0).(product_batches.ProductBatches)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(BatchNumber)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, BatchNumber)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,13 +78,13 @@ func (_m *Repository) GetOne(BatchNumber int) (product_batches.ProductBatches, e
 	return r0, r1
 }
 
-// GetReportSection provides a mock function with given fields: SectionId
-func (_m *Repository) GetReportSection(SectionId int) ([]product_batches.ProductsQuantity, error) {
-	ret := _m.Called(SectionId)
+// GetReportSection provides a mock function with given fields: ctx, SectionId
+func (_m *Repository) GetReportSection(ctx context.Context, SectionId int) ([]product_batches.ProductsQuantity, error) {
+	ret := _m.Called(ctx, SectionId)
 
 	var r0 []product_batches.ProductsQuantity
-	if rf, ok := ret.Get(0).(func(int) []product_batches.ProductsQuantity); ok {
-		r0 = rf(SectionId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []product_batches.ProductsQuantity); ok {
+		r0 = rf(ctx, SectionId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]product_batches.ProductsQuantity)
@@ -70,8 +92,31 @@ func (_m *Repository) GetReportSection(SectionId int) ([]product_batches.Product
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(SectionId)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, SectionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTemperatureHistory provides a mock function with given fields: ctx, productBatchId
+func (_m *Repository) GetTemperatureHistory(ctx context.Context, productBatchId int) ([]product_batches.TemperatureReading, error) {
+	ret := _m.Called(ctx, productBatchId)
+
+	var r0 []product_batches.TemperatureReading
+	if rf, ok := ret.Get(0).(func(context.Context, int) []product_batches.TemperatureReading); ok {
+		r0 = rf(ctx, productBatchId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product_batches.TemperatureReading)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, productBatchId)
 	} else {
 		r1 = ret.Error(1)
 	}
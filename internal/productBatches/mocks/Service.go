@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	time "time"
 
 	product_batches "github.com/emidioreb/mercado-fresco-lerigophers/internal/productBatches"
@@ -16,20 +17,20 @@ type Service struct {
 	mock.Mock
 }
 
-// CreateProductBatch provides a mock function with given fields: BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate
-func (_m *Service) CreateProductBatch(BatchNumber int, CurrentQuantity int, CurrentTemperature int, InitialQuantity int, ManufacturingHour int, MinimumTemperature int, ProductId int, SectionId int, DueDate time.Time, ManufacturingDate time.Time) (product_batches.ProductBatches, web.ResponseCode) {
-	ret := _m.Called(BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
+// CreateProductBatch provides a mock function with given fields: ctx, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate
+func (_m *Service) CreateProductBatch(ctx context.Context, BatchNumber int, CurrentQuantity int, CurrentTemperature int, InitialQuantity int, ManufacturingHour int, MinimumTemperature int, ProductId int, SectionId int, DueDate time.Time, ManufacturingDate time.Time) (product_batches.ProductBatches, web.ResponseCode) {
+	ret := _m.Called(ctx, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
 
 	var r0 product_batches.ProductBatches
-	if rf, ok := ret.Get(0).(func(int, int, int, int, int, int, int, int, time.Time, time.Time) product_batches.ProductBatches); ok {
-		r0 = rf(BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int, int, int, int, int, time.Time, time.Time) product_batches.ProductBatches); ok {
+		r0 = rf(ctx, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
 	} else {
 		r0 = ret.Get(0).(product_batches.ProductBatches)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int, int, int, int, int, int, int, int, time.Time, time.Time) web.ResponseCode); ok {
-		r1 = rf(BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, int, int, int, int, int, time.Time, time.Time) web.ResponseCode); ok {
+		r1 = rf(ctx, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -37,13 +38,55 @@ func (_m *Service) CreateProductBatch(BatchNumber int, CurrentQuantity int, Curr
 	return r0, r1
 }
 
-// GetReportSection provides a mock function with given fields: SectionId
-func (_m *Service) GetReportSection(SectionId int) ([]product_batches.ProductsQuantity, web.ResponseCode) {
-	ret := _m.Called(SectionId)
+// CreateTemperatureReading provides a mock function with given fields: ctx, BatchNumber, temperature, recordedAt
+func (_m *Service) CreateTemperatureReading(ctx context.Context, BatchNumber int, temperature float64, recordedAt time.Time) (product_batches.TemperatureReading, web.ResponseCode) {
+	ret := _m.Called(ctx, BatchNumber, temperature, recordedAt)
+
+	var r0 product_batches.TemperatureReading
+	if rf, ok := ret.Get(0).(func(context.Context, int, float64, time.Time) product_batches.TemperatureReading); ok {
+		r0 = rf(ctx, BatchNumber, temperature, recordedAt)
+	} else {
+		r0 = ret.Get(0).(product_batches.TemperatureReading)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, int, float64, time.Time) web.ResponseCode); ok {
+		r1 = rf(ctx, BatchNumber, temperature, recordedAt)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetOne provides a mock function with given fields: ctx, BatchNumber
+func (_m *Service) GetOne(ctx context.Context, BatchNumber int) (product_batches.ProductBatchDetail, web.ResponseCode) {
+	ret := _m.Called(ctx, BatchNumber)
+
+	var r0 product_batches.ProductBatchDetail
+	if rf, ok := ret.Get(0).(func(context.Context, int) product_batches.ProductBatchDetail); ok {
+		r0 = rf(ctx, BatchNumber)
+	} else {
+		r0 = ret.Get(0).(product_batches.ProductBatchDetail)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, BatchNumber)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetReportSection provides a mock function with given fields: ctx, SectionId
+func (_m *Service) GetReportSection(ctx context.Context, SectionId int) ([]product_batches.ProductsQuantity, web.ResponseCode) {
+	ret := _m.Called(ctx, SectionId)
 
 	var r0 []product_batches.ProductsQuantity
-	if rf, ok := ret.Get(0).(func(int) []product_batches.ProductsQuantity); ok {
-		r0 = rf(SectionId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []product_batches.ProductsQuantity); ok {
+		r0 = rf(ctx, SectionId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]product_batches.ProductsQuantity)
@@ -51,8 +94,8 @@ func (_m *Service) GetReportSection(SectionId int) ([]product_batches.ProductsQu
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int) web.ResponseCode); ok {
-		r1 = rf(SectionId)
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, SectionId)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
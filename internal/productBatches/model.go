@@ -1,6 +1,10 @@
 package product_batches
 
-import "time"
+import (
+	"time"
+
+	shelf_life "github.com/emidioreb/mercado-fresco-lerigophers/internal/shelfLife"
+)
 
 // ProductBatches
 type ProductBatches struct {
@@ -22,3 +26,16 @@ type ProductsQuantity struct {
 	SectionNumber int `json:"section_number"`
 	ProductsCount int `json:"products_count"`
 }
+
+type TemperatureReading struct {
+	Id             int       `json:"id"`
+	ProductBatchId int       `json:"product_batch_id"`
+	Temperature    float64   `json:"temperature"`
+	RecordedAt     time.Time `json:"recorded_at"`
+}
+
+// ProductBatchDetail is a batch along with its shelf life estimate.
+type ProductBatchDetail struct {
+	ProductBatches
+	ShelfLife shelf_life.Estimate `json:"shelf_life"`
+}
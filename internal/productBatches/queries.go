@@ -10,5 +10,8 @@ var (
 	JOIN product_batches pb ON s.id = pb.section_id WHERE s.id = ? GROUP BY s.id, s.section_number;`
 
 	QueryCreateProductBatch = `INSERT INTO product_batches (batch_number, current_quatity, current_temperature, initial_quantity, manufacturing_hour, minimum_temperature, product_id, section_id, due_date, manufacturing_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
-	QueryGetOneProductBatch = `SELECT * FROM product_batches WHERE batch_number = ?;`
+	QueryGetOneProductBatch = `SELECT id, batch_number, current_quatity, current_temperature, initial_quantity, manufacturing_hour, minimum_temperature, product_id, section_id, due_date, manufacturing_date FROM product_batches WHERE batch_number = ?;`
+
+	QueryCreateTemperatureReading = `INSERT INTO product_batch_temperatures (product_batch_id, temperature, recorded_at) VALUES (?, ?, ?);`
+	QueryGetTemperatureHistory    = `SELECT id, product_batch_id, temperature, recorded_at FROM product_batch_temperatures WHERE product_batch_id = ? ORDER BY recorded_at;`
 )
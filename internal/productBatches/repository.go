@@ -1,32 +1,41 @@
 package product_batches
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"time"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 )
 
+func GetErrProductBatchNotFound(BatchNumber int) error {
+	return fmt.Errorf("product_batch with batch_number %d not found", BatchNumber)
+}
+
 type Repository interface {
-	CreateProductBatch(BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId int, DueDate, ManufacturingDate time.Time) (ProductBatches, error)
-	GetReportSection(SectionId int) ([]ProductsQuantity, error)
-	GetOne(BatchNumber int) (ProductBatches, error)
+	CreateProductBatch(ctx context.Context, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId int, DueDate, ManufacturingDate time.Time) (ProductBatches, error)
+	GetReportSection(ctx context.Context, SectionId int) ([]ProductsQuantity, error)
+	GetOne(ctx context.Context, BatchNumber int) (ProductBatches, error)
+	CreateTemperatureReading(ctx context.Context, productBatchId int, temperature float64, recordedAt time.Time) (TemperatureReading, error)
+	GetTemperatureHistory(ctx context.Context, productBatchId int) ([]TemperatureReading, error)
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
-func (mariaDb mariaDbRepository) GetOne(BatchNumber int) (ProductBatches, error) {
+func (mariaDb mariaDbRepository) GetOne(ctx context.Context, BatchNumber int) (ProductBatches, error) {
 	currentProductBatch := ProductBatches{}
 
-	row := mariaDb.db.QueryRow(QueryGetOneProductBatch, BatchNumber)
+	row := mariaDb.db.QueryRowContext(ctx, transaction.Lock(ctx, QueryGetOneProductBatch), BatchNumber)
 	err := row.Scan(
 		&currentProductBatch.Id,
 		&currentProductBatch.BatchNumber,
@@ -42,7 +51,7 @@ func (mariaDb mariaDbRepository) GetOne(BatchNumber int) (ProductBatches, error)
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
-		return ProductBatches{}, fmt.Errorf("product_batch with batch_number %d not found", BatchNumber)
+		return ProductBatches{}, GetErrProductBatchNotFound(BatchNumber)
 	}
 
 	if err != nil {
@@ -52,7 +61,7 @@ func (mariaDb mariaDbRepository) GetOne(BatchNumber int) (ProductBatches, error)
 	return currentProductBatch, nil
 }
 
-func (mariaDb mariaDbRepository) CreateProductBatch(BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId int, DueDate, ManufacturingDate time.Time) (ProductBatches, error) {
+func (mariaDb mariaDbRepository) CreateProductBatch(ctx context.Context, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId int, DueDate, ManufacturingDate time.Time) (ProductBatches, error) {
 	newProductBatch := ProductBatches{
 		BatchNumber:        BatchNumber,
 		CurrentQuantity:    CurrentQuantity,
@@ -66,7 +75,7 @@ func (mariaDb mariaDbRepository) CreateProductBatch(BatchNumber, CurrentQuantity
 		ManufacturingDate:  ManufacturingDate,
 	}
 
-	result, err := mariaDb.db.Exec(QueryCreateProductBatch, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
+	result, err := mariaDb.db.ExecContext(ctx, QueryCreateProductBatch, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
 	if err != nil {
 		return ProductBatches{}, errors.New("couldn't create a product_batch")
 	}
@@ -81,7 +90,7 @@ func (mariaDb mariaDbRepository) CreateProductBatch(BatchNumber, CurrentQuantity
 	return newProductBatch, nil
 }
 
-func (mariaDb mariaDbRepository) GetReportSection(SectionId int) ([]ProductsQuantity, error) {
+func (mariaDb mariaDbRepository) GetReportSection(ctx context.Context, SectionId int) ([]ProductsQuantity, error) {
 	reports := []ProductsQuantity{}
 
 	var (
@@ -90,9 +99,9 @@ func (mariaDb mariaDbRepository) GetReportSection(SectionId int) ([]ProductsQuan
 	)
 
 	if SectionId != 0 {
-		rows, err = mariaDb.db.Query(QueryGetReportOne, SectionId)
+		rows, err = mariaDb.db.QueryContext(ctx, QueryGetReportOne, SectionId)
 	} else {
-		rows, err = mariaDb.db.Query(QueryGetReportAll)
+		rows, err = mariaDb.db.QueryContext(ctx, QueryGetReportAll)
 	}
 
 	if err != nil {
@@ -111,5 +120,56 @@ func (mariaDb mariaDbRepository) GetReportSection(SectionId int) ([]ProductsQuan
 		reports = append(reports, currentReport)
 	}
 
+	if err := rows.Err(); err != nil {
+		return []ProductsQuantity{}, errors.New("error to report sections by product_batches")
+	}
+
 	return reports, nil
 }
+
+func (mariaDb mariaDbRepository) CreateTemperatureReading(ctx context.Context, productBatchId int, temperature float64, recordedAt time.Time) (TemperatureReading, error) {
+	result, err := mariaDb.db.ExecContext(ctx, QueryCreateTemperatureReading, productBatchId, temperature, recordedAt)
+	if err != nil {
+		return TemperatureReading{}, errors.New("couldn't create a temperature reading")
+	}
+
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		return TemperatureReading{}, err
+	}
+
+	return TemperatureReading{
+		Id:             int(lastId),
+		ProductBatchId: productBatchId,
+		Temperature:    temperature,
+		RecordedAt:     recordedAt,
+	}, nil
+}
+
+func (mariaDb mariaDbRepository) GetTemperatureHistory(ctx context.Context, productBatchId int) ([]TemperatureReading, error) {
+	readings := []TemperatureReading{}
+
+	rows, err := mariaDb.db.QueryContext(ctx, QueryGetTemperatureHistory, productBatchId)
+	if err != nil {
+		return []TemperatureReading{}, errors.New("error to get temperature history")
+	}
+
+	for rows.Next() {
+		var reading TemperatureReading
+		if err := rows.Scan(
+			&reading.Id,
+			&reading.ProductBatchId,
+			&reading.Temperature,
+			&reading.RecordedAt,
+		); err != nil {
+			return []TemperatureReading{}, errors.New("error to get temperature history")
+		}
+		readings = append(readings, reading)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []TemperatureReading{}, errors.New("error to get temperature history")
+	}
+
+	return readings, nil
+}
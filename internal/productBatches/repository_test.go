@@ -1,6 +1,7 @@
 package product_batches_test
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"regexp"
@@ -55,6 +56,7 @@ func TestCreate(t *testing.T) {
 		productBatchRepo := product_batches.NewMariaDbRepository(db)
 
 		pb, err := productBatchRepo.CreateProductBatch(
+			context.Background(),
 			mockProductBatch.BatchNumber,
 			mockProductBatch.CurrentQuantity,
 			mockProductBatch.CurrentTemperature,
@@ -83,6 +85,7 @@ func TestCreate(t *testing.T) {
 
 		productBatchRepo := product_batches.NewMariaDbRepository(db)
 		_, err = productBatchRepo.CreateProductBatch(
+			context.Background(),
 			mockProductBatch.BatchNumber,
 			mockProductBatch.CurrentQuantity,
 			mockProductBatch.CurrentTemperature,
@@ -122,7 +125,7 @@ func TestGetOne(t *testing.T) {
 
 		productBatchRepo := product_batches.NewMariaDbRepository(db)
 
-		pb, err := productBatchRepo.GetOne(1)
+		pb, err := productBatchRepo.GetOne(context.Background(), 1)
 		assert.Nil(t, err)
 
 		assert.Equal(t, 1, pb.Id)
@@ -137,7 +140,7 @@ func TestGetOne(t *testing.T) {
 
 		productBatchRepo := product_batches.NewMariaDbRepository(db)
 
-		_, err = productBatchRepo.GetOne(1)
+		_, err = productBatchRepo.GetOne(context.Background(), 1)
 		assert.NotNil(t, err)
 
 		assert.Equal(t, "product_batch with batch_number 1 not found", err.Error())
@@ -151,7 +154,7 @@ func TestGetOne(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(product_batches.QueryGetOneProductBatch)).WillReturnError(errors.New(""))
 
 		productBatchRepo := product_batches.NewMariaDbRepository(db)
-		_, err = productBatchRepo.GetOne(1)
+		_, err = productBatchRepo.GetOne(context.Background(), 1)
 		assert.NotNil(t, err)
 		assert.Equal(t, "error to find product_batch", err.Error())
 	})
@@ -176,7 +179,7 @@ func TestDBGetReportSections(t *testing.T) {
 
 		productBatchRepo := product_batches.NewMariaDbRepository(db)
 
-		sectionReports, err := productBatchRepo.GetReportSection(0)
+		sectionReports, err := productBatchRepo.GetReportSection(context.Background(), 0)
 		assert.Nil(t, err)
 
 		assert.Len(t, sectionReports, 3)
@@ -201,7 +204,7 @@ func TestDBGetReportSections(t *testing.T) {
 
 		productBatchRepo := product_batches.NewMariaDbRepository(db)
 
-		sectionReports, err := productBatchRepo.GetReportSection(1)
+		sectionReports, err := productBatchRepo.GetReportSection(context.Background(), 1)
 		assert.Nil(t, err)
 
 		assert.Len(t, sectionReports, 1)
@@ -217,7 +220,7 @@ func TestDBGetReportSections(t *testing.T) {
 
 		sectionReports := product_batches.NewMariaDbRepository(db)
 
-		_, err = sectionReports.GetReportSection(1)
+		_, err = sectionReports.GetReportSection(context.Background(), 1)
 		assert.NotNil(t, err)
 		assert.Equal(t, "error to report sections by product_batches", err.Error())
 	})
@@ -239,9 +242,89 @@ func TestDBGetReportSections(t *testing.T) {
 
 		ProductBatches := product_batches.NewMariaDbRepository(db)
 
-		_, err = ProductBatches.GetReportSection(1)
+		_, err = ProductBatches.GetReportSection(context.Background(), 1)
 		assert.NotNil(t, err)
 
 		assert.Equal(t, "error to report sections by product_batches", err.Error())
 	})
 }
+
+func TestDBCreateTemperatureReading(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(product_batches.QueryCreateTemperatureReading)).
+			WithArgs(3, -12.5, date).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+
+		productBatchRepo := product_batches.NewMariaDbRepository(db)
+
+		reading, err := productBatchRepo.CreateTemperatureReading(context.Background(), 3, -12.5, date)
+		assert.Nil(t, err)
+		assert.Equal(t, product_batches.TemperatureReading{Id: 7, ProductBatchId: 3, Temperature: -12.5, RecordedAt: date}, reading)
+	})
+
+	t.Run("fail to exec", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(product_batches.QueryCreateTemperatureReading)).WillReturnError(errors.New(""))
+
+		productBatchRepo := product_batches.NewMariaDbRepository(db)
+
+		_, err = productBatchRepo.CreateTemperatureReading(context.Background(), 3, -12.5, date)
+		assert.Equal(t, "couldn't create a temperature reading", err.Error())
+	})
+}
+
+func TestDBGetTemperatureHistory(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "product_batch_id", "temperature", "recorded_at"}).
+			AddRow(1, 3, -18, date).
+			AddRow(2, 3, -10.5, date.Add(time.Hour))
+
+		mock.ExpectQuery(regexp.QuoteMeta(product_batches.QueryGetTemperatureHistory)).WithArgs(3).WillReturnRows(rows)
+
+		productBatchRepo := product_batches.NewMariaDbRepository(db)
+
+		readings, err := productBatchRepo.GetTemperatureHistory(context.Background(), 3)
+		assert.Nil(t, err)
+		assert.Len(t, readings, 2)
+		assert.Equal(t, -10.5, readings[1].Temperature)
+	})
+
+	t.Run("fail to query", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(product_batches.QueryGetTemperatureHistory)).WillReturnError(errors.New(""))
+
+		productBatchRepo := product_batches.NewMariaDbRepository(db)
+
+		_, err = productBatchRepo.GetTemperatureHistory(context.Background(), 3)
+		assert.Equal(t, "error to get temperature history", err.Error())
+	})
+
+	t.Run("fail to scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "product_batch_id", "temperature", "recorded_at"}).
+			AddRow("", 3, -18, date)
+		mock.ExpectQuery(regexp.QuoteMeta(product_batches.QueryGetTemperatureHistory)).WillReturnRows(rows)
+
+		productBatchRepo := product_batches.NewMariaDbRepository(db)
+
+		_, err = productBatchRepo.GetTemperatureHistory(context.Background(), 3)
+		assert.Equal(t, "error to get temperature history", err.Error())
+	})
+}
@@ -1,35 +1,42 @@
 package product_batches
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"time"
 
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/products"
+	shelf_life "github.com/emidioreb/mercado-fresco-lerigophers/internal/shelfLife"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 )
 
 type Service interface {
-	CreateProductBatch(BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId int, DueDate, ManufacturingDate time.Time) (ProductBatches, web.ResponseCode)
-	GetReportSection(SectionId int) ([]ProductsQuantity, web.ResponseCode)
+	CreateProductBatch(ctx context.Context, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId int, DueDate, ManufacturingDate time.Time) (ProductBatches, web.ResponseCode)
+	GetReportSection(ctx context.Context, SectionId int) ([]ProductsQuantity, web.ResponseCode)
+	GetOne(ctx context.Context, BatchNumber int) (ProductBatchDetail, web.ResponseCode)
+	CreateTemperatureReading(ctx context.Context, BatchNumber int, temperature float64, recordedAt time.Time) (TemperatureReading, web.ResponseCode)
 }
 
 type service struct {
-	repository Repository
+	repository        Repository
+	productRepository products.Repository
 }
 
-func NewService(r Repository) Service {
+func NewService(r Repository, pr products.Repository) Service {
 	return &service{
-		repository: r,
+		repository:        r,
+		productRepository: pr,
 	}
 }
 
-func (s service) CreateProductBatch(BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId int, DueDate, ManufacturingDate time.Time) (ProductBatches, web.ResponseCode) {
-	_, err := s.repository.GetOne(BatchNumber)
+func (s service) CreateProductBatch(ctx context.Context, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId int, DueDate, ManufacturingDate time.Time) (ProductBatches, web.ResponseCode) {
+	_, err := s.repository.GetOne(ctx, BatchNumber)
 	if err == nil {
 		return ProductBatches{}, web.NewCodeResponse(http.StatusConflict, errors.New("product_batch already exists"))
 	}
 
-	result, err := s.repository.CreateProductBatch(BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
+	result, err := s.repository.CreateProductBatch(ctx, BatchNumber, CurrentQuantity, CurrentTemperature, InitialQuantity, ManufacturingHour, MinimumTemperature, ProductId, SectionId, DueDate, ManufacturingDate)
 	if err != nil {
 		return ProductBatches{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
@@ -37,8 +44,8 @@ func (s service) CreateProductBatch(BatchNumber, CurrentQuantity, CurrentTempera
 	return result, web.NewCodeResponse(http.StatusCreated, nil)
 }
 
-func (s service) GetReportSection(SectionId int) ([]ProductsQuantity, web.ResponseCode) {
-	report, err := s.repository.GetReportSection(SectionId)
+func (s service) GetReportSection(ctx context.Context, SectionId int) ([]ProductsQuantity, web.ResponseCode) {
+	report, err := s.repository.GetReportSection(ctx, SectionId)
 
 	if err != nil {
 		return []ProductsQuantity{}, web.NewCodeResponse(http.StatusInternalServerError, err)
@@ -46,3 +53,69 @@ func (s service) GetReportSection(SectionId int) ([]ProductsQuantity, web.Respon
 
 	return report, web.NewCodeResponse(http.StatusOK, nil)
 }
+
+// GetOne returns the batch with the shelf life estimated from the product
+// rates and the temperature history of the batch.
+func (s service) GetOne(ctx context.Context, BatchNumber int) (ProductBatchDetail, web.ResponseCode) {
+	productBatch, resp := s.getProductBatch(ctx, BatchNumber)
+	if resp.Err != nil {
+		return ProductBatchDetail{}, resp
+	}
+
+	product, err := s.productRepository.GetOne(ctx, productBatch.ProductId)
+	if err != nil {
+		return ProductBatchDetail{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	history, err := s.repository.GetTemperatureHistory(ctx, productBatch.Id)
+	if err != nil {
+		return ProductBatchDetail{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	readings := make([]shelf_life.Reading, 0, len(history))
+	for _, reading := range history {
+		readings = append(readings, shelf_life.Reading{Temperature: reading.Temperature, RecordedAt: reading.RecordedAt})
+	}
+
+	estimate := shelf_life.Calculate(shelf_life.Input{
+		ProducedAt:             productBatch.ManufacturingDate.Add(time.Duration(productBatch.ManufacturingHour) * time.Hour),
+		DueDate:                productBatch.DueDate,
+		CurrentTemperature:     float64(productBatch.CurrentTemperature),
+		Readings:               readings,
+		ExpirationRate:         product.ExpirationRate,
+		FreezingRate:           product.FreezingRate,
+		RecommendedTemperature: product.RecommendedFreezingTemperature,
+	}, time.Now())
+
+	return ProductBatchDetail{ProductBatches: productBatch, ShelfLife: estimate}, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) CreateTemperatureReading(ctx context.Context, BatchNumber int, temperature float64, recordedAt time.Time) (TemperatureReading, web.ResponseCode) {
+	productBatch, resp := s.getProductBatch(ctx, BatchNumber)
+	if resp.Err != nil {
+		return TemperatureReading{}, resp
+	}
+
+	if recordedAt.Before(productBatch.ManufacturingDate) {
+		return TemperatureReading{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("recorded_at must not be before manufacturing_date"))
+	}
+
+	reading, err := s.repository.CreateTemperatureReading(ctx, productBatch.Id, temperature, recordedAt)
+	if err != nil {
+		return TemperatureReading{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return reading, web.NewCodeResponse(http.StatusCreated, nil)
+}
+
+func (s service) getProductBatch(ctx context.Context, BatchNumber int) (ProductBatches, web.ResponseCode) {
+	productBatch, err := s.repository.GetOne(ctx, BatchNumber)
+	if err != nil {
+		if err.Error() == GetErrProductBatchNotFound(BatchNumber).Error() {
+			return ProductBatches{}, web.NewCodeResponse(http.StatusNotFound, err)
+		}
+		return ProductBatches{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return productBatch, web.ResponseCode{}
+}
@@ -1,12 +1,17 @@
 package product_batches_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	product_batches "github.com/emidioreb/mercado-fresco-lerigophers/internal/productBatches"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/productBatches/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/products"
+	mockedProducts "github.com/emidioreb/mercado-fresco-lerigophers/internal/products/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -39,8 +44,9 @@ func TestServiceCreate(t *testing.T) {
 	t.Run("Test if create successfully", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(product_batches.ProductBatches{}, errors.New(""))
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(product_batches.ProductBatches{}, errors.New(""))
 		mockedRepository.On("CreateProductBatch",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -52,9 +58,10 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("time.Time"),
 			mock.AnythingOfType("time.Time")).Return(fakeProductBatches[0], nil)
 
-		service := product_batches.NewService(mockedRepository)
+		service := product_batches.NewService(mockedRepository, &mockedProducts.Repository{})
 
 		result, err := service.CreateProductBatch(
+			context.Background(),
 			fakeProductBatches[0].BatchNumber,
 			fakeProductBatches[0].CurrentQuantity,
 			fakeProductBatches[0].CurrentTemperature,
@@ -73,11 +80,12 @@ func TestServiceCreate(t *testing.T) {
 	t.Run("product_batch already exists", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(product_batches.ProductBatches{}, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(product_batches.ProductBatches{}, nil)
 
-		service := product_batches.NewService(mockedRepository)
+		service := product_batches.NewService(mockedRepository, &mockedProducts.Repository{})
 
 		_, err := service.CreateProductBatch(
+			context.Background(),
 			fakeProductBatches[0].BatchNumber,
 			fakeProductBatches[0].CurrentQuantity,
 			fakeProductBatches[0].CurrentTemperature,
@@ -96,8 +104,9 @@ func TestServiceCreate(t *testing.T) {
 	t.Run("ProductBatchResult should return error", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(product_batches.ProductBatches{}, errors.New(""))
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(product_batches.ProductBatches{}, errors.New(""))
 		mockedRepository.On("CreateProductBatch",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -109,8 +118,9 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("time.Time"),
 			mock.AnythingOfType("time.Time")).Return(product_batches.ProductBatches{}, errors.New("couldn't create a product_batch"))
 
-		service := product_batches.NewService(mockedRepository)
+		service := product_batches.NewService(mockedRepository, &mockedProducts.Repository{})
 		_, err := service.CreateProductBatch(
+			context.Background(),
 			fakeProductBatches[0].BatchNumber,
 			fakeProductBatches[0].CurrentQuantity,
 			fakeProductBatches[0].CurrentTemperature,
@@ -129,10 +139,10 @@ func TestServiceCreate(t *testing.T) {
 func TestServiceGetReport(t *testing.T) {
 	t.Run("get report - success case", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		mockedRepository.On("GetReportSection", mock.AnythingOfType("int")).Return([]product_batches.ProductsQuantity{}, nil)
-		service := product_batches.NewService(mockedRepository)
+		mockedRepository.On("GetReportSection", mock.Anything, mock.AnythingOfType("int")).Return([]product_batches.ProductsQuantity{}, nil)
+		service := product_batches.NewService(mockedRepository, &mockedProducts.Repository{})
 
-		result, err := service.GetReportSection(0)
+		result, err := service.GetReportSection(context.Background(), 0)
 		assert.NoError(t, err.Err)
 
 		assert.Equal(t, result, []product_batches.ProductsQuantity{})
@@ -141,10 +151,10 @@ func TestServiceGetReport(t *testing.T) {
 
 	t.Run("get report - error case", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		mockedRepository.On("GetReportSection", mock.AnythingOfType("int")).Return([]product_batches.ProductsQuantity{}, errors.New("error to report sections by product_batches"))
-		service := product_batches.NewService(mockedRepository)
+		mockedRepository.On("GetReportSection", mock.Anything, mock.AnythingOfType("int")).Return([]product_batches.ProductsQuantity{}, errors.New("error to report sections by product_batches"))
+		service := product_batches.NewService(mockedRepository, &mockedProducts.Repository{})
 
-		result, err := service.GetReportSection(0)
+		result, err := service.GetReportSection(context.Background(), 0)
 		assert.NotNil(t, err.Err)
 
 		assert.Equal(t, result, []product_batches.ProductsQuantity{})
@@ -152,3 +162,100 @@ func TestServiceGetReport(t *testing.T) {
 		assert.Equal(t, "error to report sections by product_batches", err.Err.Error())
 	})
 }
+
+func TestServiceGetOne(t *testing.T) {
+	manufacturingDate := time.Now().AddDate(0, 0, -5)
+	productBatch := product_batches.ProductBatches{
+		Id:                 3,
+		BatchNumber:        10,
+		CurrentTemperature: -18,
+		ProductId:          23,
+		DueDate:            manufacturingDate.AddDate(0, 0, 30),
+		ManufacturingDate:  manufacturingDate,
+	}
+
+	t.Run("Test if return the batch with the shelf life estimate", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedProductRepository := new(mockedProducts.Repository)
+
+		mockedRepository.On("GetOne", mock.Anything, 10).Return(productBatch, nil).Once()
+		mockedRepository.On("GetTemperatureHistory", mock.Anything, 3).Return([]product_batches.TemperatureReading{
+			{Id: 1, ProductBatchId: 3, Temperature: -18, RecordedAt: manufacturingDate.AddDate(0, 0, 1)},
+		}, nil).Once()
+		mockedProductRepository.On("GetOne", mock.Anything, 23).Return(products.Product{
+			Id:                             23,
+			ExpirationRate:                 10,
+			FreezingRate:                   2,
+			RecommendedFreezingTemperature: -18,
+		}, nil).Once()
+
+		service := product_batches.NewService(mockedRepository, mockedProductRepository)
+		result, resp := service.GetOne(context.Background(), 10)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, productBatch, result.ProductBatches)
+		assert.InDelta(t, 50, result.ShelfLife.ShelfLifeUsedPercent, 1)
+		assert.True(t, result.ShelfLife.SpoilsBeforeDueDate)
+		assert.Equal(t, 0.0, *result.ShelfLife.FreezingTimeHours)
+		mockedRepository.AssertExpectations(t)
+		mockedProductRepository.AssertExpectations(t)
+	})
+
+	t.Run("Test not found batch", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := product_batches.GetErrProductBatchNotFound(10)
+		mockedRepository.On("GetOne", mock.Anything, 10).Return(product_batches.ProductBatches{}, expectedError).Once()
+
+		service := product_batches.NewService(mockedRepository, &mockedProducts.Repository{})
+		_, resp := service.GetOne(context.Background(), 10)
+
+		assert.Equal(t, web.NewCodeResponse(http.StatusNotFound, expectedError), resp)
+	})
+
+	t.Run("Test internal server error when get temperature history", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedProductRepository := new(mockedProducts.Repository)
+		expectedError := errors.New("error to get temperature history")
+
+		mockedRepository.On("GetOne", mock.Anything, 10).Return(productBatch, nil).Once()
+		mockedProductRepository.On("GetOne", mock.Anything, 23).Return(products.Product{}, nil).Once()
+		mockedRepository.On("GetTemperatureHistory", mock.Anything, 3).Return([]product_batches.TemperatureReading{}, expectedError).Once()
+
+		service := product_batches.NewService(mockedRepository, mockedProductRepository)
+		_, resp := service.GetOne(context.Background(), 10)
+
+		assert.Equal(t, web.NewCodeResponse(http.StatusInternalServerError, expectedError), resp)
+	})
+}
+
+func TestServiceCreateTemperatureReading(t *testing.T) {
+	productBatch := product_batches.ProductBatches{Id: 3, BatchNumber: 10, ManufacturingDate: date}
+
+	t.Run("Test if create successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		reading := product_batches.TemperatureReading{Id: 1, ProductBatchId: 3, Temperature: -12.5, RecordedAt: date.Add(time.Hour)}
+
+		mockedRepository.On("GetOne", mock.Anything, 10).Return(productBatch, nil).Once()
+		mockedRepository.On("CreateTemperatureReading", mock.Anything, 3, -12.5, date.Add(time.Hour)).Return(reading, nil).Once()
+
+		service := product_batches.NewService(mockedRepository, &mockedProducts.Repository{})
+		result, resp := service.CreateTemperatureReading(context.Background(), 10, -12.5, date.Add(time.Hour))
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, reading, result)
+	})
+
+	t.Run("Test error case if reading is before manufacturing", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 10).Return(productBatch, nil).Once()
+
+		service := product_batches.NewService(mockedRepository, &mockedProducts.Repository{})
+		_, resp := service.CreateTemperatureReading(context.Background(), 10, -12.5, date.Add(-time.Hour))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "recorded_at must not be before manufacturing_date", resp.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "CreateTemperatureReading", 0)
+	})
+}
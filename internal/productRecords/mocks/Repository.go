@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	product_records "github.com/emidioreb/mercado-fresco-lerigophers/internal/productRecords"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,20 +14,43 @@ type Repository struct {
 	mock.Mock
 }
 
-// CreateProductRecord provides a mock function with given fields: LastUpdateDate, PurchasePrice, SalePrice, ProductId
-func (_m *Repository) CreateProductRecord(LastUpdateDate string, PurchasePrice float64, SalePrice float64, ProductId int) (product_records.ProductRecords, error) {
-	ret := _m.Called(LastUpdateDate, PurchasePrice, SalePrice, ProductId)
+// CreateProductRecord provides a mock function with given fields: ctx, LastUpdateDate, PurchasePrice, SalePrice, ProductId
+func (_m *Repository) CreateProductRecord(ctx context.Context, LastUpdateDate string, PurchasePrice float64, SalePrice float64, ProductId int) (product_records.ProductRecords, error) {
+	ret := _m.Called(ctx, LastUpdateDate, PurchasePrice, SalePrice, ProductId)
 
 	var r0 product_records.ProductRecords
-	if rf, ok := ret.Get(0).(func(string, float64, float64, int) product_records.ProductRecords); ok {
-		r0 = rf(LastUpdateDate, PurchasePrice, SalePrice, ProductId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, float64, int) product_records.ProductRecords); ok {
+		r0 = rf(ctx, LastUpdateDate, PurchasePrice, SalePrice, ProductId)
 	} else {
 		r0 = ret.Get(0).(product_records.ProductRecords)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, float64, float64, int) error); ok {
-		r1 = rf(LastUpdateDate, PurchasePrice, SalePrice, ProductId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64, float64, int) error); ok {
+		r1 = rf(ctx, LastUpdateDate, PurchasePrice, SalePrice, ProductId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByProductId provides a mock function with given fields: ctx, productId
+func (_m *Repository) GetByProductId(ctx context.Context, productId int) ([]product_records.ProductRecords, error) {
+	ret := _m.Called(ctx, productId)
+
+	var r0 []product_records.ProductRecords
+	if rf, ok := ret.Get(0).(func(context.Context, int) []product_records.ProductRecords); ok {
+		r0 = rf(ctx, productId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product_records.ProductRecords)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, productId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,18 +58,46 @@ func (_m *Repository) CreateProductRecord(LastUpdateDate string, PurchasePrice f
 	return r0, r1
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Repository) GetOne(id int) error {
-	ret := _m.Called(id)
+// GetEffective provides a mock function with given fields: ctx, productId, date
+func (_m *Repository) GetEffective(ctx context.Context, productId int, date string) (product_records.ProductRecords, error) {
+	ret := _m.Called(ctx, productId, date)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(id)
+	var r0 product_records.ProductRecords
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) product_records.ProductRecords); ok {
+		r0 = rf(ctx, productId, date)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(product_records.ProductRecords)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, productId, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Repository) GetOne(ctx context.Context, id int) (product_records.ProductRecords, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 product_records.ProductRecords
+	if rf, ok := ret.Get(0).(func(context.Context, int) product_records.ProductRecords); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(product_records.ProductRecords)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 type mockConstructorTestingTNewRepository interface {
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	product_records "github.com/emidioreb/mercado-fresco-lerigophers/internal/productRecords"
 	web "github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	mock "github.com/stretchr/testify/mock"
@@ -13,20 +15,41 @@ type Service struct {
 	mock.Mock
 }
 
-// CreateProductRecord provides a mock function with given fields: LastUpdateDate, PurchasePrice, SalePrice, ProductId
-func (_m *Service) CreateProductRecord(LastUpdateDate string, PurchasePrice float64, SalePrice float64, ProductId int) (product_records.ProductRecords, web.ResponseCode) {
-	ret := _m.Called(LastUpdateDate, PurchasePrice, SalePrice, ProductId)
+// CreateProductRecord provides a mock function with given fields: ctx, LastUpdateDate, PurchasePrice, SalePrice, ProductId
+func (_m *Service) CreateProductRecord(ctx context.Context, LastUpdateDate string, PurchasePrice float64, SalePrice float64, ProductId int) (product_records.ProductRecords, web.ResponseCode) {
+	ret := _m.Called(ctx, LastUpdateDate, PurchasePrice, SalePrice, ProductId)
 
 	var r0 product_records.ProductRecords
-	if rf, ok := ret.Get(0).(func(string, float64, float64, int) product_records.ProductRecords); ok {
-		r0 = rf(LastUpdateDate, PurchasePrice, SalePrice, ProductId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, float64, int) product_records.ProductRecords); ok {
+		r0 = rf(ctx, LastUpdateDate, PurchasePrice, SalePrice, ProductId)
 	} else {
 		r0 = ret.Get(0).(product_records.ProductRecords)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(string, float64, float64, int) web.ResponseCode); ok {
-		r1 = rf(LastUpdateDate, PurchasePrice, SalePrice, ProductId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64, float64, int) web.ResponseCode); ok {
+		r1 = rf(ctx, LastUpdateDate, PurchasePrice, SalePrice, ProductId)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetPriceHistory provides a mock function with given fields: ctx, ProductId, date
+func (_m *Service) GetPriceHistory(ctx context.Context, ProductId int, date string) (product_records.ProductPriceHistory, web.ResponseCode) {
+	ret := _m.Called(ctx, ProductId, date)
+
+	var r0 product_records.ProductPriceHistory
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) product_records.ProductPriceHistory); ok {
+		r0 = rf(ctx, ProductId, date)
+	} else {
+		r0 = ret.Get(0).(product_records.ProductPriceHistory)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) web.ResponseCode); ok {
+		r1 = rf(ctx, ProductId, date)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
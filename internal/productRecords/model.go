@@ -7,3 +7,20 @@ type ProductRecords struct {
 	SalePrice      float64 `json:"sale_price"`
 	ProductId      int     `json:"product_id"`
 }
+
+type ProductPrice struct {
+	Id               int     `json:"id"`
+	LastUpdateDate   string  `json:"last_update_date"`
+	PurchasePrice    float64 `json:"purchase_price"`
+	SalePrice        float64 `json:"sale_price"`
+	MarginPercentage float64 `json:"margin_percentage"`
+	MarkupPercentage float64 `json:"markup_percentage"`
+}
+
+type ProductPriceHistory struct {
+	ProductId    int            `json:"product_id"`
+	Description  string         `json:"description"`
+	Date         string         `json:"date"`
+	CurrentPrice *ProductPrice  `json:"current_price"`
+	Prices       []ProductPrice `json:"prices"`
+}
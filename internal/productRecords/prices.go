@@ -0,0 +1,31 @@
+package product_records
+
+import "math"
+
+// NewProductPrice derives margin and markup percentages from a product record.
+// Margin is the profit over the sale price, markup is the profit over the
+// purchase price. Both are zero when the base price is zero.
+func NewProductPrice(record ProductRecords) ProductPrice {
+	price := ProductPrice{
+		Id:             record.Id,
+		LastUpdateDate: record.LastUpdateDate,
+		PurchasePrice:  record.PurchasePrice,
+		SalePrice:      record.SalePrice,
+	}
+
+	profit := record.SalePrice - record.PurchasePrice
+
+	if record.SalePrice != 0 {
+		price.MarginPercentage = roundPercentage(profit / record.SalePrice * 100)
+	}
+
+	if record.PurchasePrice != 0 {
+		price.MarkupPercentage = roundPercentage(profit / record.PurchasePrice * 100)
+	}
+
+	return price
+}
+
+func roundPercentage(value float64) float64 {
+	return math.Round(value*100) / 100
+}
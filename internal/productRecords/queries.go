@@ -5,5 +5,18 @@ var (
 	(last_update_date, purchase_price, sale_price, product_id) 
 	VALUES (?, ?, ?, ?)`
 
-	queryGetOneProductRecord = "SELECT id FROM product_records WHERE id = ?"
+	queryGetOneProductRecord = `SELECT id, DATE_FORMAT(last_update_date, '%Y-%m-%d'), purchase_price, sale_price, product_id
+	FROM product_records
+	WHERE id = ?`
+
+	queryGetProductRecordsByProduct = `SELECT id, DATE_FORMAT(last_update_date, '%Y-%m-%d'), purchase_price, sale_price, product_id
+	FROM product_records
+	WHERE product_id = ?
+	ORDER BY last_update_date, id`
+
+	queryGetEffectiveProductRecord = `SELECT id, DATE_FORMAT(last_update_date, '%Y-%m-%d'), purchase_price, sale_price, product_id
+	FROM product_records
+	WHERE product_id = ? AND last_update_date <= ?
+	ORDER BY last_update_date DESC, id DESC
+	LIMIT 1`
 )
@@ -1,28 +1,37 @@
 package product_records
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"time"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 )
 
 type Repository interface {
-	CreateProductRecord(LastUpdateDate string, PurchasePrice float64, SalePrice float64, ProductId int) (ProductRecords, error)
-	GetOne(id int) error
+	CreateProductRecord(ctx context.Context, LastUpdateDate string, PurchasePrice float64, SalePrice float64, ProductId int) (ProductRecords, error)
+	GetOne(ctx context.Context, id int) (ProductRecords, error)
+	GetByProductId(ctx context.Context, productId int) ([]ProductRecords, error)
+	GetEffective(ctx context.Context, productId int, date string) (ProductRecords, error)
+}
+
+func GetErrNoEffectiveProductRecord(productId int, date string) error {
+	return fmt.Errorf("product with id %d has no product_records effective at %s", productId, date)
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
-func (mariaDb mariaDbRepository) CreateProductRecord(LastUpdateDate string, PurchasePrice float64, SalePrice float64, ProductId int) (ProductRecords, error) {
+func (mariaDb mariaDbRepository) CreateProductRecord(ctx context.Context, LastUpdateDate string, PurchasePrice float64, SalePrice float64, ProductId int) (ProductRecords, error) {
 	layout := "2006-01-02"
 	lastUpdateDate, err := time.Parse(layout, LastUpdateDate)
 
@@ -30,7 +39,7 @@ func (mariaDb mariaDbRepository) CreateProductRecord(LastUpdateDate string, Purc
 		return ProductRecords{}, errors.New("invalid date input")
 	}
 
-	result, err := mariaDb.db.Exec(queryCreateProductRecord, lastUpdateDate, PurchasePrice, SalePrice, ProductId)
+	result, err := mariaDb.db.ExecContext(ctx, queryCreateProductRecord, lastUpdateDate, PurchasePrice, SalePrice, ProductId)
 	if err != nil {
 		return ProductRecords{}, errors.New("couldn't create a product_record")
 	}
@@ -52,19 +61,77 @@ func (mariaDb mariaDbRepository) CreateProductRecord(LastUpdateDate string, Purc
 	return newProductRecord, nil
 }
 
-func (mariaDb mariaDbRepository) GetOne(id int) error {
-	var currentProductRecordId int
+func (mariaDb mariaDbRepository) GetOne(ctx context.Context, id int) (ProductRecords, error) {
+	var currentProductRecord ProductRecords
 
-	row := mariaDb.db.QueryRow(queryGetOneProductRecord, id)
-	err := row.Scan(&currentProductRecordId)
+	row := mariaDb.db.QueryRowContext(ctx, transaction.Lock(ctx, queryGetOneProductRecord), id)
+	err := row.Scan(
+		&currentProductRecord.Id,
+		&currentProductRecord.LastUpdateDate,
+		&currentProductRecord.PurchasePrice,
+		&currentProductRecord.SalePrice,
+		&currentProductRecord.ProductId,
+	)
 
 	if errors.Is(err, sql.ErrNoRows) {
-		return fmt.Errorf("product_records with id %d not found", id)
+		return ProductRecords{}, fmt.Errorf("product_records with id %d not found", id)
 	}
 
 	if err != nil {
-		return errors.New("unexpected error to verify product_records")
+		return ProductRecords{}, errors.New("unexpected error to verify product_records")
+	}
+
+	return currentProductRecord, nil
+}
+
+func (mariaDb mariaDbRepository) GetEffective(ctx context.Context, productId int, date string) (ProductRecords, error) {
+	var currentProductRecord ProductRecords
+
+	row := mariaDb.db.QueryRowContext(ctx, transaction.Lock(ctx, queryGetEffectiveProductRecord), productId, date)
+	err := row.Scan(
+		&currentProductRecord.Id,
+		&currentProductRecord.LastUpdateDate,
+		&currentProductRecord.PurchasePrice,
+		&currentProductRecord.SalePrice,
+		&currentProductRecord.ProductId,
+	)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return ProductRecords{}, GetErrNoEffectiveProductRecord(productId, date)
+	}
+
+	if err != nil {
+		return ProductRecords{}, errors.New("unexpected error to get effective product_records")
+	}
+
+	return currentProductRecord, nil
+}
+
+func (mariaDb mariaDbRepository) GetByProductId(ctx context.Context, productId int) ([]ProductRecords, error) {
+	productRecords := []ProductRecords{}
+
+	rows, err := mariaDb.db.QueryContext(ctx, queryGetProductRecordsByProduct, productId)
+	if err != nil {
+		return []ProductRecords{}, errors.New("couldn't get product_records")
+	}
+
+	for rows.Next() {
+		var currentProductRecord ProductRecords
+		if err := rows.Scan(
+			&currentProductRecord.Id,
+			&currentProductRecord.LastUpdateDate,
+			&currentProductRecord.PurchasePrice,
+			&currentProductRecord.SalePrice,
+			&currentProductRecord.ProductId,
+		); err != nil {
+			return []ProductRecords{}, errors.New("couldn't get product_records")
+		}
+		productRecords = append(productRecords, currentProductRecord)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []ProductRecords{}, errors.New("couldn't get product_records")
 	}
 
-	return nil
+	return productRecords, nil
 }
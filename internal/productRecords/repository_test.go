@@ -1,6 +1,8 @@
 package product_records
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
@@ -30,6 +32,7 @@ func TestCreateProductRecord(t *testing.T) {
 		productRepo := NewMariaDbRepository(db)
 
 		productsRecords, err := productRepo.CreateProductRecord(
+			context.Background(),
 			"2022-02-07",
 			3.0,
 			4.0,
@@ -59,6 +62,7 @@ func TestCreateProductRecord(t *testing.T) {
 		productRepo := NewMariaDbRepository(db)
 
 		_, err = productRepo.CreateProductRecord(
+			context.Background(),
 			"2022-30-07",
 			3.0,
 			4.0,
@@ -84,6 +88,7 @@ func TestCreateProductRecord(t *testing.T) {
 		productRepo := NewMariaDbRepository(db)
 
 		_, err = productRepo.CreateProductRecord(
+			context.Background(),
 			"2022-02-07",
 			3.0,
 			4.0,
@@ -104,7 +109,7 @@ func TestCreateProductRecord(t *testing.T) {
 			WillReturnResult(sqlDriverResultErr)
 
 		productRepo := NewMariaDbRepository(db)
-		_, err = productRepo.CreateProductRecord("2022-02-07", 0.0, 0.0, 0)
+		_, err = productRepo.CreateProductRecord(context.Background(), "2022-02-07", 0.0, 0.0, 0)
 
 		assert.Error(t, err)
 		assert.Equal(t, "ocurred an error to create product record", err.Error())
@@ -117,12 +122,137 @@ func TestDBGetOneProductRecord(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
-		rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+		rows := sqlmock.NewRows([]string{"id", "last_update_date", "purchase_price", "sale_price", "product_id"}).
+			AddRow(1, "2022-01-01", 5.0, 25.0, 2)
 
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneProductRecord)).WillReturnRows(rows)
 
 		productRepo := NewMariaDbRepository(db)
-		err = productRepo.GetOne(1)
+		productRecord, err := productRepo.GetOne(context.Background(), 1)
 		assert.NoError(t, err)
+		assert.Equal(t, ProductRecords{1, "2022-01-01", 5.0, 25.0, 2}, productRecord)
+	})
+
+	t.Run("Not found case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneProductRecord)).WillReturnError(sql.ErrNoRows)
+
+		productRepo := NewMariaDbRepository(db)
+		_, err = productRepo.GetOne(context.Background(), 1)
+		assert.Error(t, err)
+		assert.Equal(t, "product_records with id 1 not found", err.Error())
+	})
+
+	t.Run("DB error case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneProductRecord)).WillReturnError(errors.New("any"))
+
+		productRepo := NewMariaDbRepository(db)
+		_, err = productRepo.GetOne(context.Background(), 1)
+		assert.Error(t, err)
+		assert.Equal(t, "unexpected error to verify product_records", err.Error())
+	})
+}
+
+func TestDBGetEffective(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "last_update_date", "purchase_price", "sale_price", "product_id"}).
+			AddRow(2, "2022-01-05", 6.0, 23.0, 2)
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetEffectiveProductRecord)).
+			WithArgs(2, "2022-01-10").
+			WillReturnRows(rows)
+
+		productRepo := NewMariaDbRepository(db)
+		productRecord, err := productRepo.GetEffective(context.Background(), 2, "2022-01-10")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, productRecord.Id)
+	})
+
+	t.Run("Not found case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetEffectiveProductRecord)).WillReturnError(sql.ErrNoRows)
+
+		productRepo := NewMariaDbRepository(db)
+		_, err = productRepo.GetEffective(context.Background(), 2, "2021-01-10")
+		assert.Error(t, err)
+		assert.Equal(t, "product with id 2 has no product_records effective at 2021-01-10", err.Error())
+	})
+
+	t.Run("DB error case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetEffectiveProductRecord)).WillReturnError(errors.New("any"))
+
+		productRepo := NewMariaDbRepository(db)
+		_, err = productRepo.GetEffective(context.Background(), 2, "2022-01-10")
+		assert.Error(t, err)
+		assert.Equal(t, "unexpected error to get effective product_records", err.Error())
+	})
+}
+
+func TestDBGetByProductId(t *testing.T) {
+	columns := []string{"id", "last_update_date", "purchase_price", "sale_price", "product_id"}
+
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, "2022-01-01", 5.0, 25.0, 2).
+			AddRow(2, "2022-01-05", 6.0, 23.0, 2)
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetProductRecordsByProduct)).WithArgs(2).WillReturnRows(rows)
+
+		productRepo := NewMariaDbRepository(db)
+		records, err := productRepo.GetByProductId(context.Background(), 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+		assert.Equal(t, ProductRecords{2, "2022-01-05", 6.0, 23.0, 2}, records[1])
+	})
+
+	t.Run("Query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetProductRecordsByProduct)).WillReturnError(errors.New("any"))
+
+		productRepo := NewMariaDbRepository(db)
+		_, err = productRepo.GetByProductId(context.Background(), 2)
+
+		assert.Error(t, err)
+		assert.Equal(t, "couldn't get product_records", err.Error())
+	})
+
+	t.Run("Scan error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).AddRow("", "", "", "", "")
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetProductRecordsByProduct)).WillReturnRows(rows)
+
+		productRepo := NewMariaDbRepository(db)
+		_, err = productRepo.GetByProductId(context.Background(), 2)
+
+		assert.Error(t, err)
 	})
 }
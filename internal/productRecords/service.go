@@ -1,15 +1,18 @@
 package product_records
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/products"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 )
 
 type Service interface {
-	CreateProductRecord(LastUpdateDate string, PurchasePrice float64, SalePrice float64, ProductId int) (ProductRecords, web.ResponseCode)
+	CreateProductRecord(ctx context.Context, LastUpdateDate string, PurchasePrice float64, SalePrice float64, ProductId int) (ProductRecords, web.ResponseCode)
+	GetPriceHistory(ctx context.Context, ProductId int, date string) (ProductPriceHistory, web.ResponseCode)
 }
 
 type service struct {
@@ -24,16 +27,58 @@ func NewService(r Repository, pr products.Repository) Service {
 	}
 }
 
-func (s service) CreateProductRecord(LastUpdateDate string, PurchasePrice float64, SalePrice float64, ProductId int) (ProductRecords, web.ResponseCode) {
-	_, err := s.productRepository.GetOne(ProductId)
+func (s service) CreateProductRecord(ctx context.Context, LastUpdateDate string, PurchasePrice float64, SalePrice float64, ProductId int) (ProductRecords, web.ResponseCode) {
+	_, err := s.productRepository.GetOne(ctx, ProductId)
 	if err != nil {
-		return ProductRecords{}, web.NewCodeResponse(http.StatusConflict, errors.New("product_id don`t exists"))
+		if err.Error() == products.GetErrProductNotFound(ProductId).Error() {
+			return ProductRecords{}, web.NewCodeResponse(http.StatusConflict, errors.New("product_id don`t exists"))
+		}
+		return ProductRecords{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
 
-	result, err := s.repository.CreateProductRecord(LastUpdateDate, PurchasePrice, SalePrice, ProductId)
+	result, err := s.repository.CreateProductRecord(ctx, LastUpdateDate, PurchasePrice, SalePrice, ProductId)
 	if err != nil {
 		return ProductRecords{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
 
 	return result, web.NewCodeResponse(http.StatusCreated, nil)
 }
+
+func (s service) GetPriceHistory(ctx context.Context, ProductId int, date string) (ProductPriceHistory, web.ResponseCode) {
+	product, err := s.productRepository.GetOne(ctx, ProductId)
+	if err != nil {
+		if err.Error() == products.GetErrProductNotFound(ProductId).Error() {
+			return ProductPriceHistory{}, web.NewCodeResponse(http.StatusNotFound, err)
+		}
+		return ProductPriceHistory{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+
+	records, err := s.repository.GetByProductId(ctx, ProductId)
+	if err != nil {
+		return ProductPriceHistory{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	history := ProductPriceHistory{
+		ProductId:   ProductId,
+		Description: product.Description,
+		Date:        date,
+		Prices:      []ProductPrice{},
+	}
+
+	for _, record := range records {
+		price := NewProductPrice(record)
+		history.Prices = append(history.Prices, price)
+
+		// records come ordered by date, so the last one not after the date is the effective price
+		if record.LastUpdateDate <= date {
+			effectivePrice := price
+			history.CurrentPrice = &effectivePrice
+		}
+	}
+
+	return history, web.NewCodeResponse(http.StatusOK, nil)
+}
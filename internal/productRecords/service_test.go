@@ -1,6 +1,7 @@
 package product_records_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -24,8 +25,9 @@ func TestServiceCreate(t *testing.T) {
 			ProductId:      1,
 		}
 
-		mockedProdutsRepository.On("GetOne", mock.AnythingOfType("int")).Return(products.Product{}, nil)
+		mockedProdutsRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(products.Product{}, nil)
 		mockedRepository.On("CreateProductRecord",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
@@ -34,7 +36,7 @@ func TestServiceCreate(t *testing.T) {
 
 		service := product_records.NewService(mockedRepository, mockedProdutsRepository)
 
-		result, err := service.CreateProductRecord(input.LastUpdateDate,
+		result, err := service.CreateProductRecord(context.Background(), input.LastUpdateDate,
 			input.PurchasePrice, input.SalePrice, input.ProductId)
 
 		assert.Nil(t, err.Err)
@@ -54,8 +56,9 @@ func TestServiceCreate(t *testing.T) {
 			ProductId:      1,
 		}
 
-		mockedProdutsRepository.On("GetOne", mock.AnythingOfType("int")).Return(products.Product{}, nil)
+		mockedProdutsRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(products.Product{}, nil)
 		mockedRepository.On("CreateProductRecord",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("float64"),
 			mock.AnythingOfType("float64"),
@@ -64,9 +67,95 @@ func TestServiceCreate(t *testing.T) {
 
 		service := product_records.NewService(mockedRepository, mockedProdutsRepository)
 
-		_, err := service.CreateProductRecord(input.LastUpdateDate,
+		_, err := service.CreateProductRecord(context.Background(), input.LastUpdateDate,
 			input.PurchasePrice, input.SalePrice, 2)
 
 		assert.NotNil(t, err.Err)
 	})
 }
+
+func TestServiceGetPriceHistory(t *testing.T) {
+	records := []product_records.ProductRecords{
+		{Id: 1, LastUpdateDate: "2022-01-01", PurchasePrice: 5, SalePrice: 25, ProductId: 2},
+		{Id: 2, LastUpdateDate: "2022-01-05", PurchasePrice: 6, SalePrice: 24, ProductId: 2},
+		{Id: 3, LastUpdateDate: "2022-02-01", PurchasePrice: 0, SalePrice: 0, ProductId: 2},
+	}
+
+	t.Run("Test if returns timeline and effective price", func(t *testing.T) {
+		mockedRepository := new(mockedProductRecords.Repository)
+		mockedProdutsRepository := new(mockedProducts.Repository)
+
+		mockedProdutsRepository.On("GetOne", mock.Anything, 2).Return(products.Product{Id: 2, Description: "Purple Onion"}, nil)
+		mockedRepository.On("GetByProductId", mock.Anything, 2).Return(records, nil)
+
+		service := product_records.NewService(mockedRepository, mockedProdutsRepository)
+		history, resp := service.GetPriceHistory(context.Background(), 2, "2022-01-10")
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, "Purple Onion", history.Description)
+		assert.Len(t, history.Prices, 3)
+		assert.Equal(t, product_records.ProductPrice{
+			Id:               2,
+			LastUpdateDate:   "2022-01-05",
+			PurchasePrice:    6,
+			SalePrice:        24,
+			MarginPercentage: 75,
+			MarkupPercentage: 300,
+		}, *history.CurrentPrice)
+		assert.Equal(t, 0.0, history.Prices[2].MarginPercentage)
+		assert.Equal(t, 0.0, history.Prices[2].MarkupPercentage)
+	})
+
+	t.Run("Test if there is no effective price before the first record", func(t *testing.T) {
+		mockedRepository := new(mockedProductRecords.Repository)
+		mockedProdutsRepository := new(mockedProducts.Repository)
+
+		mockedProdutsRepository.On("GetOne", mock.Anything, 2).Return(products.Product{Id: 2}, nil)
+		mockedRepository.On("GetByProductId", mock.Anything, 2).Return(records, nil)
+
+		service := product_records.NewService(mockedRepository, mockedProdutsRepository)
+		history, resp := service.GetPriceHistory(context.Background(), 2, "2021-12-31")
+
+		assert.Nil(t, resp.Err)
+		assert.Nil(t, history.CurrentPrice)
+	})
+
+	t.Run("Test if product dont exists", func(t *testing.T) {
+		mockedRepository := new(mockedProductRecords.Repository)
+		mockedProdutsRepository := new(mockedProducts.Repository)
+
+		mockedProdutsRepository.On("GetOne", mock.Anything, 2).Return(products.Product{}, products.GetErrProductNotFound(2))
+
+		service := product_records.NewService(mockedRepository, mockedProdutsRepository)
+		_, resp := service.GetPriceHistory(context.Background(), 2, "")
+
+		assert.Equal(t, 404, resp.Code)
+		assert.Equal(t, "product with id 2 not found", resp.Err.Error())
+	})
+
+	t.Run("Test if product lookup fails", func(t *testing.T) {
+		mockedRepository := new(mockedProductRecords.Repository)
+		mockedProdutsRepository := new(mockedProducts.Repository)
+
+		mockedProdutsRepository.On("GetOne", mock.Anything, 2).Return(products.Product{}, errors.New("unexpected error to get product"))
+
+		service := product_records.NewService(mockedRepository, mockedProdutsRepository)
+		_, resp := service.GetPriceHistory(context.Background(), 2, "")
+
+		assert.Equal(t, 500, resp.Code)
+		assert.Empty(t, mockedRepository.Calls)
+	})
+
+	t.Run("Test if records error", func(t *testing.T) {
+		mockedRepository := new(mockedProductRecords.Repository)
+		mockedProdutsRepository := new(mockedProducts.Repository)
+
+		mockedProdutsRepository.On("GetOne", mock.Anything, 2).Return(products.Product{Id: 2}, nil)
+		mockedRepository.On("GetByProductId", mock.Anything, 2).Return([]product_records.ProductRecords{}, errors.New("couldn't get product_records"))
+
+		service := product_records.NewService(mockedRepository, mockedProdutsRepository)
+		_, resp := service.GetPriceHistory(context.Background(), 2, "")
+
+		assert.Equal(t, 500, resp.Code)
+	})
+}
@@ -2,20 +2,46 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	producttypes "github.com/emidioreb/mercado-fresco-lerigophers/internal/productTypes"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Repository is an autogenerated mock type for the Repository type
 type Repository struct {
 	mock.Mock
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Repository) GetOne(id int) error {
-	ret := _m.Called(id)
+// Create provides a mock function with given fields: ctx, name, minimumTemperature, maximumTemperature
+func (_m *Repository) Create(ctx context.Context, name string, minimumTemperature *float64, maximumTemperature *float64) (producttypes.ProductType, error) {
+	ret := _m.Called(ctx, name, minimumTemperature, maximumTemperature)
+
+	var r0 producttypes.ProductType
+	if rf, ok := ret.Get(0).(func(context.Context, string, *float64, *float64) producttypes.ProductType); ok {
+		r0 = rf(ctx, name, minimumTemperature, maximumTemperature)
+	} else {
+		r0 = ret.Get(0).(producttypes.ProductType)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *float64, *float64) error); ok {
+		r1 = rf(ctx, name, minimumTemperature, maximumTemperature)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Repository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -23,6 +49,92 @@ func (_m *Repository) GetOne(id int) error {
 	return r0
 }
 
+// GetAll provides a mock function with given fields: ctx
+func (_m *Repository) GetAll(ctx context.Context) ([]producttypes.ProductType, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []producttypes.ProductType
+	if rf, ok := ret.Get(0).(func(context.Context) []producttypes.ProductType); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]producttypes.ProductType)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Repository) GetOne(ctx context.Context, id int) (producttypes.ProductType, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 producttypes.ProductType
+	if rf, ok := ret.Get(0).(func(context.Context, int) producttypes.ProductType); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(producttypes.ProductType)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsage provides a mock function with given fields: ctx, id
+func (_m *Repository) GetUsage(ctx context.Context, id int) (producttypes.ProductTypeUsage, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 producttypes.ProductTypeUsage
+	if rf, ok := ret.Get(0).(func(context.Context, int) producttypes.ProductTypeUsage); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(producttypes.ProductTypeUsage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Repository) Update(ctx context.Context, id int, requestData map[string]interface{}) (producttypes.ProductType, error) {
+	ret := _m.Called(ctx, id, requestData)
+
+	var r0 producttypes.ProductType
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) producttypes.ProductType); ok {
+		r0 = rf(ctx, id, requestData)
+	} else {
+		r0 = ret.Get(0).(producttypes.ProductType)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) error); ok {
+		r1 = rf(ctx, id, requestData)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewRepository interface {
 	mock.TestingT
 	Cleanup(func())
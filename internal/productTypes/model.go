@@ -0,0 +1,13 @@
+package producttypes
+
+type ProductType struct {
+	Id                 int      `json:"id"`
+	Name               string   `json:"name"`
+	MinimumTemperature *float64 `json:"minimum_temperature"`
+	MaximumTemperature *float64 `json:"maximum_temperature"`
+}
+
+type ProductTypeUsage struct {
+	Products int `json:"products"`
+	Sections int `json:"sections"`
+}
@@ -0,0 +1,41 @@
+package producttypes
+
+import "fmt"
+
+var (
+	queryCreateProductType   = "INSERT INTO product_type (name, minimum_temperature, maximum_temperature) VALUES (?, ?, ?)"
+	queryGetOneProductType   = "SELECT id, name, minimum_temperature, maximum_temperature FROM product_type WHERE id = ?"
+	queryGetAllProductTypes  = "SELECT id, name, minimum_temperature, maximum_temperature FROM product_type"
+	queryDeleteProductType   = "DELETE FROM product_type WHERE id = ?"
+	queryGetProductTypeUsage = `SELECT
+		(SELECT count(*) FROM products WHERE product_type_id = ?) AS products_count,
+		(SELECT count(*) FROM sections WHERE product_type_id = ?) AS sections_count`
+
+	updatableFields = []string{"name", "minimum_temperature", "maximum_temperature"}
+
+	queryUpdateProductType = func(requestData map[string]interface{}, id int) (finalQuery string, valuesToUse []interface{}) {
+		prefixQuery := "UPDATE product_type SET"
+		fieldsToUpdate := []string{}
+		whereCase := "WHERE id = ?"
+
+		for _, currField := range updatableFields {
+			if value, ok := requestData[currField]; ok {
+				fieldsToUpdate = append(fieldsToUpdate, fmt.Sprintf(" %s = ?", currField))
+				valuesToUse = append(valuesToUse, value)
+			}
+		}
+
+		valuesToUse = append(valuesToUse, id)
+		finalQuery += prefixQuery
+		for index, field := range fieldsToUpdate {
+			if index+1 == len(fieldsToUpdate) {
+				finalQuery += field + " "
+			} else {
+				finalQuery += field + ", "
+			}
+		}
+		finalQuery += whereCase
+
+		return finalQuery, valuesToUse
+	}
+)
@@ -1,41 +1,171 @@
 package producttypes
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
+)
+
+var (
+	errCreateProductType   = errors.New("ocurred an error to create product_type")
+	errGetProductTypes     = errors.New("couldn't get product_types")
+	errGetOneProductType   = errors.New("unexpected error to verify product_type")
+	errUpdateProductType   = errors.New("ocurred an error while updating the product_type")
+	errDeleteProductType   = errors.New("unexpected error to delete product_type")
+	errGetProductTypeUsage = errors.New("unexpected error to verify product_type usage")
 )
 
+func GetErrProductTypeNotFound(id int) error {
+	return fmt.Errorf("product_type with id %d not found", id)
+}
+
 type Repository interface {
-	GetOne(id int) error
+	Create(ctx context.Context, name string, minimumTemperature, maximumTemperature *float64) (ProductType, error)
+	GetOne(ctx context.Context, id int) (ProductType, error)
+	GetAll(ctx context.Context) ([]ProductType, error)
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (ProductType, error)
+	Delete(ctx context.Context, id int) error
+	GetUsage(ctx context.Context, id int) (ProductTypeUsage, error)
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
-func (mariaDb mariaDbRepository) GetOne(id int) error {
-	queryGetOne := "SELECT id FROM product_type WHERE id = ?"
-	var idSelected int
+func (mariaDb mariaDbRepository) Create(ctx context.Context, name string, minimumTemperature, maximumTemperature *float64) (ProductType, error) {
+	result, err := mariaDb.db.ExecContext(ctx, queryCreateProductType, name, minimumTemperature, maximumTemperature)
+	if err != nil {
+		return ProductType{}, errCreateProductType
+	}
 
-	row := mariaDb.db.QueryRow(queryGetOne, id)
-	err := row.Scan(
-		&idSelected,
-	)
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		return ProductType{}, errCreateProductType
+	}
+
+	return ProductType{
+		Id:                 int(lastId),
+		Name:               name,
+		MinimumTemperature: minimumTemperature,
+		MaximumTemperature: maximumTemperature,
+	}, nil
+}
 
+func (mariaDb mariaDbRepository) GetOne(ctx context.Context, id int) (ProductType, error) {
+	row := mariaDb.db.QueryRowContext(ctx, transaction.Lock(ctx, queryGetOneProductType), id)
+
+	productType, err := scanProductType(row)
 	if errors.Is(err, sql.ErrNoRows) {
-		return fmt.Errorf("product_type with id %d not found", id)
+		return ProductType{}, GetErrProductTypeNotFound(id)
 	}
 
 	if err != nil {
-		return errors.New("unexpected error to verify product_type")
+		return ProductType{}, errGetOneProductType
+	}
+
+	return productType, nil
+}
+
+func (mariaDb mariaDbRepository) GetAll(ctx context.Context) ([]ProductType, error) {
+	productTypes := []ProductType{}
+
+	rows, err := mariaDb.db.QueryContext(ctx, queryGetAllProductTypes)
+	if err != nil {
+		return []ProductType{}, errGetProductTypes
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		productType, err := scanProductType(rows)
+		if err != nil {
+			return []ProductType{}, errGetProductTypes
+		}
+		productTypes = append(productTypes, productType)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []ProductType{}, errGetProductTypes
+	}
+
+	return productTypes, nil
+}
+
+func (mariaDb mariaDbRepository) Update(ctx context.Context, id int, requestData map[string]interface{}) (ProductType, error) {
+	finalQuery, valuesToUse := queryUpdateProductType(requestData, id)
+
+	if _, err := mariaDb.db.ExecContext(ctx, finalQuery, valuesToUse...); err != nil {
+		return ProductType{}, errUpdateProductType
+	}
+
+	productType, err := mariaDb.GetOne(ctx, id)
+	if err != nil {
+		return ProductType{}, errUpdateProductType
+	}
+
+	return productType, nil
+}
+
+func (mariaDb mariaDbRepository) Delete(ctx context.Context, id int) error {
+	result, err := mariaDb.db.ExecContext(ctx, queryDeleteProductType, id)
+	if err != nil {
+		return errDeleteProductType
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return errDeleteProductType
+	}
+
+	if affectedRows == 0 {
+		return GetErrProductTypeNotFound(id)
 	}
 
 	return nil
 }
+
+func (mariaDb mariaDbRepository) GetUsage(ctx context.Context, id int) (ProductTypeUsage, error) {
+	var usage ProductTypeUsage
+
+	row := mariaDb.db.QueryRowContext(ctx, queryGetProductTypeUsage, id, id)
+	if err := row.Scan(&usage.Products, &usage.Sections); err != nil {
+		return ProductTypeUsage{}, errGetProductTypeUsage
+	}
+
+	return usage, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProductType(row scanner) (ProductType, error) {
+	var (
+		productType        ProductType
+		name               sql.NullString
+		minimumTemperature sql.NullFloat64
+		maximumTemperature sql.NullFloat64
+	)
+
+	if err := row.Scan(&productType.Id, &name, &minimumTemperature, &maximumTemperature); err != nil {
+		return ProductType{}, err
+	}
+
+	productType.Name = name.String
+	if minimumTemperature.Valid {
+		productType.MinimumTemperature = &minimumTemperature.Float64
+	}
+	if maximumTemperature.Valid {
+		productType.MaximumTemperature = &maximumTemperature.Float64
+	}
+
+	return productType, nil
+}
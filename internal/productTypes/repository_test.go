@@ -1,6 +1,8 @@
 package producttypes
 
 import (
+	"context"
+	"errors"
 	"regexp"
 	"testing"
 
@@ -8,18 +10,254 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var productTypeColumns = []string{"id", "name", "minimum_temperature", "maximum_temperature"}
+
+func TestDBCreateProductType(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		minimumTemperature, maximumTemperature := -18.0, -12.0
+		mock.ExpectExec(regexp.QuoteMeta(queryCreateProductType)).
+			WithArgs("freezed", &minimumTemperature, &maximumTemperature).
+			WillReturnResult(sqlmock.NewResult(6, 1))
+
+		productTypeRepo := NewMariaDbRepository(db)
+		productType, err := productTypeRepo.Create(context.Background(), "freezed", &minimumTemperature, &maximumTemperature)
+		assert.NoError(t, err)
+		assert.Equal(t, 6, productType.Id)
+		assert.Equal(t, -18.0, *productType.MinimumTemperature)
+	})
+
+	t.Run("Exec error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryCreateProductType)).WillReturnError(errors.New("internal db error"))
+
+		productTypeRepo := NewMariaDbRepository(db)
+		_, err = productTypeRepo.Create(context.Background(), "freezed", nil, nil)
+		assert.Equal(t, errCreateProductType, err)
+	})
+
+	t.Run("LastInsertId error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryCreateProductType)).
+			WillReturnResult(sqlmock.NewErrorResult(errors.New("last insert id error")))
+
+		productTypeRepo := NewMariaDbRepository(db)
+		_, err = productTypeRepo.Create(context.Background(), "freezed", nil, nil)
+		assert.Equal(t, errCreateProductType, err)
+	})
+}
+
 func TestDBGetOneProductType(t *testing.T) {
 	t.Run("Success case", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.NoError(t, err)
 		defer db.Close()
 
-		rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+		rows := sqlmock.NewRows(productTypeColumns).AddRow(1, "freezed", -18.0, nil)
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneProductType)).WithArgs(1).WillReturnRows(rows)
+
+		productTypeRepo := NewMariaDbRepository(db)
+		productType, err := productTypeRepo.GetOne(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "freezed", productType.Name)
+		assert.Equal(t, -18.0, *productType.MinimumTemperature)
+		assert.Nil(t, productType.MaximumTemperature)
+	})
+
+	t.Run("Not found case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneProductType)).WithArgs(9).
+			WillReturnRows(sqlmock.NewRows(productTypeColumns))
+
+		productTypeRepo := NewMariaDbRepository(db)
+		_, err = productTypeRepo.GetOne(context.Background(), 9)
+		assert.Equal(t, "product_type with id 9 not found", err.Error())
+	})
+
+	t.Run("Unexpected error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneProductType)).WillReturnError(errors.New("internal db error"))
+
+		productTypeRepo := NewMariaDbRepository(db)
+		_, err = productTypeRepo.GetOne(context.Background(), 1)
+		assert.Equal(t, errGetOneProductType, err)
+	})
+}
+
+func TestDBGetAllProductTypes(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(productTypeColumns).
+			AddRow(1, "electronic", nil, nil).
+			AddRow(2, "freezed", -18.0, -12.0)
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetAllProductTypes)).WillReturnRows(rows)
+
+		productTypeRepo := NewMariaDbRepository(db)
+		productTypes, err := productTypeRepo.GetAll(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, productTypes, 2)
+		assert.Nil(t, productTypes[0].MinimumTemperature)
+		assert.Equal(t, -12.0, *productTypes[1].MaximumTemperature)
+	})
+
+	t.Run("Query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetAllProductTypes)).WillReturnError(errors.New("internal db error"))
+
+		productTypeRepo := NewMariaDbRepository(db)
+		_, err = productTypeRepo.GetAll(context.Background())
+		assert.Equal(t, errGetProductTypes, err)
+	})
+
+	t.Run("Scan error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(productTypeColumns).AddRow("", "", "", "")
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetAllProductTypes)).WillReturnRows(rows)
+
+		productTypeRepo := NewMariaDbRepository(db)
+		_, err = productTypeRepo.GetAll(context.Background())
+		assert.Equal(t, errGetProductTypes, err)
+	})
+}
+
+func TestDBUpdateProductType(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		requestData := map[string]interface{}{"name": "frozen", "maximum_temperature": nil}
+		finalQuery, _ := queryUpdateProductType(requestData, 2)
+		assert.Equal(t, "UPDATE product_type SET name = ?,  maximum_temperature = ? WHERE id = ?", finalQuery)
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM product_type WHERE id = ?")).WillReturnRows(rows)
+		mock.ExpectExec(regexp.QuoteMeta(finalQuery)).
+			WithArgs("frozen", nil, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneProductType)).WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(productTypeColumns).AddRow(2, "frozen", -18.0, nil))
 
 		productTypeRepo := NewMariaDbRepository(db)
-		err = productTypeRepo.GetOne(1)
+		productType, err := productTypeRepo.Update(context.Background(), 2, requestData)
+		assert.NoError(t, err)
+		assert.Equal(t, "frozen", productType.Name)
+	})
+
+	t.Run("Exec error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
 		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE product_type").WillReturnError(errors.New("internal db error"))
+
+		productTypeRepo := NewMariaDbRepository(db)
+		_, err = productTypeRepo.Update(context.Background(), 2, map[string]interface{}{"name": "frozen"})
+		assert.Equal(t, errUpdateProductType, err)
+	})
+
+	t.Run("GetOne error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE product_type").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneProductType)).WillReturnError(errors.New("internal db error"))
+
+		productTypeRepo := NewMariaDbRepository(db)
+		_, err = productTypeRepo.Update(context.Background(), 2, map[string]interface{}{"name": "frozen"})
+		assert.Equal(t, errUpdateProductType, err)
+	})
+}
+
+func TestDBDeleteProductType(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteProductType)).WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		productTypeRepo := NewMariaDbRepository(db)
+		err = productTypeRepo.Delete(context.Background(), 5)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Not found case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteProductType)).WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		productTypeRepo := NewMariaDbRepository(db)
+		err = productTypeRepo.Delete(context.Background(), 5)
+		assert.Equal(t, "product_type with id 5 not found", err.Error())
+	})
+
+	t.Run("Exec error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteProductType)).WillReturnError(errors.New("internal db error"))
+
+		productTypeRepo := NewMariaDbRepository(db)
+		err = productTypeRepo.Delete(context.Background(), 5)
+		assert.Equal(t, errDeleteProductType, err)
+	})
+}
+
+func TestDBGetProductTypeUsage(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetProductTypeUsage)).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"products_count", "sections_count"}).AddRow(3, 1))
+
+		productTypeRepo := NewMariaDbRepository(db)
+		usage, err := productTypeRepo.GetUsage(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, ProductTypeUsage{Products: 3, Sections: 1}, usage)
+	})
+
+	t.Run("Query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetProductTypeUsage)).WillReturnError(errors.New("internal db error"))
+
+		productTypeRepo := NewMariaDbRepository(db)
+		_, err = productTypeRepo.GetUsage(context.Background(), 1)
+		assert.Equal(t, errGetProductTypeUsage, err)
 	})
 }
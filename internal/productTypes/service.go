@@ -0,0 +1,170 @@
+package producttypes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+)
+
+var (
+	errInvalidTemperatureRange = errors.New("minimum_temperature must be lower than or equal to maximum_temperature")
+	errNothingToUpdate         = errors.New("at least one of name, minimum_temperature or maximum_temperature must be informed")
+)
+
+type Service interface {
+	Create(ctx context.Context, name string, minimumTemperature, maximumTemperature *float64) (ProductType, web.ResponseCode)
+	GetOne(ctx context.Context, id int) (ProductType, web.ResponseCode)
+	GetAll(ctx context.Context) ([]ProductType, web.ResponseCode)
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (ProductType, web.ResponseCode)
+	Delete(ctx context.Context, id int) web.ResponseCode
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{
+		repository: r,
+	}
+}
+
+func (s service) Create(ctx context.Context, name string, minimumTemperature, maximumTemperature *float64) (ProductType, web.ResponseCode) {
+	if !validRange(minimumTemperature, maximumTemperature) {
+		return ProductType{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidTemperatureRange)
+	}
+
+	if resp := s.checkNameAvailable(ctx, name, 0); resp.Err != nil {
+		return ProductType{}, resp
+	}
+
+	productType, err := s.repository.Create(ctx, name, minimumTemperature, maximumTemperature)
+	if err != nil {
+		return ProductType{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return productType, web.NewCodeResponse(http.StatusCreated, nil)
+}
+
+func (s service) GetOne(ctx context.Context, id int) (ProductType, web.ResponseCode) {
+	productType, err := s.repository.GetOne(ctx, id)
+	if err != nil {
+		if err.Error() == GetErrProductTypeNotFound(id).Error() {
+			return ProductType{}, web.NewCodeResponse(http.StatusNotFound, err)
+		}
+		return ProductType{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return productType, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) GetAll(ctx context.Context) ([]ProductType, web.ResponseCode) {
+	productTypes, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return []ProductType{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return productTypes, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) Update(ctx context.Context, id int, requestData map[string]interface{}) (ProductType, web.ResponseCode) {
+	if !hasUpdatableField(requestData) {
+		return ProductType{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errNothingToUpdate)
+	}
+
+	current, resp := s.GetOne(ctx, id)
+	if resp.Err != nil {
+		return ProductType{}, resp
+	}
+
+	if name, ok := requestData["name"].(string); ok {
+		if resp := s.checkNameAvailable(ctx, name, id); resp.Err != nil {
+			return ProductType{}, resp
+		}
+	}
+
+	minimumTemperature, maximumTemperature := current.MinimumTemperature, current.MaximumTemperature
+	if value, ok := requestData["minimum_temperature"]; ok {
+		minimumTemperature = toTemperature(value)
+	}
+	if value, ok := requestData["maximum_temperature"]; ok {
+		maximumTemperature = toTemperature(value)
+	}
+
+	if !validRange(minimumTemperature, maximumTemperature) {
+		return ProductType{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidTemperatureRange)
+	}
+
+	productType, err := s.repository.Update(ctx, id, requestData)
+	if err != nil {
+		return ProductType{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return productType, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) Delete(ctx context.Context, id int) web.ResponseCode {
+	if _, resp := s.GetOne(ctx, id); resp.Err != nil {
+		return resp
+	}
+
+	usage, err := s.repository.GetUsage(ctx, id)
+	if err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	if resp := web.ReferencedBy("product_type", id,
+		web.Reference{Table: "products", Count: usage.Products},
+		web.Reference{Table: "sections", Count: usage.Sections},
+	); resp.Err != nil {
+		return resp
+	}
+
+	if err := s.repository.Delete(ctx, id); err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return web.NewCodeResponse(http.StatusNoContent, nil)
+}
+
+func (s service) checkNameAvailable(ctx context.Context, name string, id int) web.ResponseCode {
+	productTypes, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	for _, productType := range productTypes {
+		if strings.EqualFold(productType.Name, name) && productType.Id != id {
+			return web.NewCodeResponse(http.StatusConflict, errors.New("product_type name already exists"))
+		}
+	}
+
+	return web.ResponseCode{}
+}
+
+func validRange(minimumTemperature, maximumTemperature *float64) bool {
+	if minimumTemperature == nil || maximumTemperature == nil {
+		return true
+	}
+	return *minimumTemperature <= *maximumTemperature
+}
+
+func toTemperature(value interface{}) *float64 {
+	temperature, ok := value.(float64)
+	if !ok {
+		return nil
+	}
+	return &temperature
+}
+
+func hasUpdatableField(requestData map[string]interface{}) bool {
+	for _, field := range updatableFields {
+		if _, ok := requestData[field]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,276 @@
+package producttypes_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	product_types "github.com/emidioreb/mercado-fresco-lerigophers/internal/productTypes"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/productTypes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func temperature(value float64) *float64 {
+	return &value
+}
+
+var fakeProductTypes = []product_types.ProductType{
+	{Id: 1, Name: "electronic"},
+	{Id: 2, Name: "freezed", MinimumTemperature: temperature(-18), MaximumTemperature: temperature(-12)},
+}
+
+func TestServiceCreate(t *testing.T) {
+	t.Run("Test if create successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expected := product_types.ProductType{Id: 3, Name: "chilled", MinimumTemperature: temperature(0), MaximumTemperature: temperature(4)}
+
+		mockedRepository.On("GetAll", mock.Anything).Return(fakeProductTypes, nil)
+		mockedRepository.On("Create", mock.Anything, "chilled", expected.MinimumTemperature, expected.MaximumTemperature).Return(expected, nil)
+
+		service := product_types.NewService(mockedRepository)
+		result, resp := service.Create(context.Background(), "chilled", expected.MinimumTemperature, expected.MaximumTemperature)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("Test conflict if name already exists", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything).Return(fakeProductTypes, nil)
+
+		service := product_types.NewService(mockedRepository)
+		_, resp := service.Create(context.Background(), "Freezed", nil, nil)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, "product_type name already exists", resp.Err.Error())
+	})
+
+	t.Run("Test invalid temperature range", func(t *testing.T) {
+		service := product_types.NewService(new(mocks.Repository))
+		_, resp := service.Create(context.Background(), "chilled", temperature(5), temperature(0))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+
+	t.Run("Test error on create", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything).Return(fakeProductTypes, nil)
+		mockedRepository.On("Create", mock.Anything, "chilled", mock.Anything, mock.Anything).
+			Return(product_types.ProductType{}, errors.New("ocurred an error to create product_type"))
+
+		service := product_types.NewService(mockedRepository)
+		_, resp := service.Create(context.Background(), "chilled", nil, nil)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestServiceGetOne(t *testing.T) {
+	t.Run("Test if get successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(fakeProductTypes[1], nil)
+
+		service := product_types.NewService(mockedRepository)
+		result, resp := service.GetOne(context.Background(), 2)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, fakeProductTypes[1], result)
+	})
+
+	t.Run("Test not found", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 9).Return(product_types.ProductType{}, product_types.GetErrProductTypeNotFound(9))
+
+		service := product_types.NewService(mockedRepository)
+		_, resp := service.GetOne(context.Background(), 9)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("Test unexpected error", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 9).Return(product_types.ProductType{}, errors.New("unexpected error to verify product_type"))
+
+		service := product_types.NewService(mockedRepository)
+		_, resp := service.GetOne(context.Background(), 9)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestServiceGetAll(t *testing.T) {
+	t.Run("Test if get all successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything).Return(fakeProductTypes, nil)
+
+		service := product_types.NewService(mockedRepository)
+		result, resp := service.GetAll(context.Background())
+
+		assert.Nil(t, resp.Err)
+		assert.Len(t, result, 2)
+	})
+
+	t.Run("Test error on get all", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything).Return([]product_types.ProductType{}, errors.New("couldn't get product_types"))
+
+		service := product_types.NewService(mockedRepository)
+		_, resp := service.GetAll(context.Background())
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestServiceUpdate(t *testing.T) {
+	t.Run("Test if rename successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		requestData := map[string]interface{}{"name": "frozen"}
+		expected := fakeProductTypes[1]
+		expected.Name = "frozen"
+
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(fakeProductTypes[1], nil)
+		mockedRepository.On("GetAll", mock.Anything).Return(fakeProductTypes, nil)
+		mockedRepository.On("Update", mock.Anything, 2, requestData).Return(expected, nil)
+
+		service := product_types.NewService(mockedRepository)
+		result, resp := service.Update(context.Background(), 2, requestData)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("Test if no updatable field is informed", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		service := product_types.NewService(mockedRepository)
+		_, resp := service.Update(context.Background(), 2, map[string]interface{}{"color": "blue"})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "at least one of name, minimum_temperature or maximum_temperature must be informed", resp.Err.Error())
+		assert.Empty(t, mockedRepository.Calls)
+	})
+
+	t.Run("Test if keeping its own name is allowed", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		requestData := map[string]interface{}{"name": "FREEZED"}
+
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(fakeProductTypes[1], nil)
+		mockedRepository.On("GetAll", mock.Anything).Return(fakeProductTypes, nil)
+		mockedRepository.On("Update", mock.Anything, 2, requestData).Return(fakeProductTypes[1], nil)
+
+		service := product_types.NewService(mockedRepository)
+		_, resp := service.Update(context.Background(), 2, requestData)
+
+		assert.Nil(t, resp.Err)
+	})
+
+	t.Run("Test conflict on rename", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(fakeProductTypes[1], nil)
+		mockedRepository.On("GetAll", mock.Anything).Return(fakeProductTypes, nil)
+
+		service := product_types.NewService(mockedRepository)
+		_, resp := service.Update(context.Background(), 2, map[string]interface{}{"name": "electronic"})
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
+	t.Run("Test invalid range merged with current values", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(fakeProductTypes[1], nil)
+
+		service := product_types.NewService(mockedRepository)
+		_, resp := service.Update(context.Background(), 2, map[string]interface{}{"minimum_temperature": -10.0})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+
+	t.Run("Test not found", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 9).Return(product_types.ProductType{}, product_types.GetErrProductTypeNotFound(9))
+
+		service := product_types.NewService(mockedRepository)
+		_, resp := service.Update(context.Background(), 9, map[string]interface{}{"name": "frozen"})
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("Test error on update", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		requestData := map[string]interface{}{"maximum_temperature": nil}
+
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(fakeProductTypes[1], nil)
+		mockedRepository.On("Update", mock.Anything, 2, requestData).
+			Return(product_types.ProductType{}, errors.New("ocurred an error while updating the product_type"))
+
+		service := product_types.NewService(mockedRepository)
+		_, resp := service.Update(context.Background(), 2, requestData)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestServiceDelete(t *testing.T) {
+	t.Run("Test if delete successfully", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeProductTypes[0], nil)
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(product_types.ProductTypeUsage{}, nil)
+		mockedRepository.On("Delete", mock.Anything, 1).Return(nil)
+
+		service := product_types.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), 1)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+	})
+
+	t.Run("Test conflict if product_type is in use", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeProductTypes[0], nil)
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(product_types.ProductTypeUsage{Products: 2, Sections: 1}, nil)
+
+		service := product_types.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, "product_type with id 1 is used by 2 products and 1 sections", resp.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("Test not found", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 9).Return(product_types.ProductType{}, product_types.GetErrProductTypeNotFound(9))
+
+		service := product_types.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), 9)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("Test error to verify usage", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeProductTypes[0], nil)
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(product_types.ProductTypeUsage{}, errors.New("unexpected error to verify product_type usage"))
+
+		service := product_types.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+
+	t.Run("Test error on delete", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeProductTypes[0], nil)
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(product_types.ProductTypeUsage{}, nil)
+		mockedRepository.On("Delete", mock.Anything, 1).Return(errors.New("unexpected error to delete product_type"))
+
+		service := product_types.NewService(mockedRepository)
+		resp := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
@@ -3,8 +3,12 @@
 package mocks
 
 import (
-	products "github.com/emidioreb/mercado-fresco-lerigophers/internal/products"
+	context "context"
+
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	mock "github.com/stretchr/testify/mock"
+
+	products "github.com/emidioreb/mercado-fresco-lerigophers/internal/products"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -12,20 +16,20 @@ type Repository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan, freezingRate, productTypeId, sellerId
-func (_m *Repository) Create(productCode string, description string, width float64, height float64, length float64, netWeight float64, expirationRate float64, recommendedFreezingTemperaturechan float64, freezingRate float64, productTypeId int, sellerId int) (products.Product, error) {
-	ret := _m.Called(productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan, freezingRate, productTypeId, sellerId)
+// Create provides a mock function with given fields: ctx, productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan, freezingRate, productTypeId, sellerId
+func (_m *Repository) Create(ctx context.Context, productCode string, description string, width float64, height float64, length float64, netWeight float64, expirationRate float64, recommendedFreezingTemperaturechan float64, freezingRate float64, productTypeId int, sellerId int) (products.Product, error) {
+	ret := _m.Called(ctx, productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan, freezingRate, productTypeId, sellerId)
 
 	var r0 products.Product
-	if rf, ok := ret.Get(0).(func(string, string, float64, float64, float64, float64, float64, float64, float64, int, int) products.Product); ok {
-		r0 = rf(productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan, freezingRate, productTypeId, sellerId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float64, float64, float64, float64, float64, float64, float64, int, int) products.Product); ok {
+		r0 = rf(ctx, productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan, freezingRate, productTypeId, sellerId)
 	} else {
 		r0 = ret.Get(0).(products.Product)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, float64, float64, float64, float64, float64, float64, float64, int, int) error); ok {
-		r1 = rf(productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan, freezingRate, productTypeId, sellerId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, float64, float64, float64, float64, float64, float64, float64, int, int) error); ok {
+		r1 = rf(ctx, productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan, freezingRate, productTypeId, sellerId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,13 +37,13 @@ func (_m *Repository) Create(productCode string, description string, width float
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Repository) Delete(id int) error {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Repository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,13 +51,13 @@ func (_m *Repository) Delete(id int) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *Repository) GetAll() ([]products.Product, error) {
-	ret := _m.Called()
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Repository) GetAll(ctx context.Context, opts listing.Options) ([]products.Product, error) {
+	ret := _m.Called(ctx, opts)
 
 	var r0 []products.Product
-	if rf, ok := ret.Get(0).(func() []products.Product); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []products.Product); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]products.Product)
@@ -61,8 +65,8 @@ func (_m *Repository) GetAll() ([]products.Product, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) error); ok {
+		r1 = rf(ctx, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,20 +74,20 @@ func (_m *Repository) GetAll() ([]products.Product, error) {
 	return r0, r1
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Repository) GetOne(id int) (products.Product, error) {
-	ret := _m.Called(id)
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Repository) GetOne(ctx context.Context, id int) (products.Product, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 products.Product
-	if rf, ok := ret.Get(0).(func(int) products.Product); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) products.Product); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(products.Product)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -91,13 +95,13 @@ func (_m *Repository) GetOne(id int) (products.Product, error) {
 	return r0, r1
 }
 
-// GetReportProduct provides a mock function with given fields: ProductId
-func (_m *Repository) GetReportProduct(ProductId int) ([]products.ProductRecords, error) {
-	ret := _m.Called(ProductId)
+// GetReportProduct provides a mock function with given fields: ctx, ProductId
+func (_m *Repository) GetReportProduct(ctx context.Context, ProductId int) ([]products.ProductRecords, error) {
+	ret := _m.Called(ctx, ProductId)
 
 	var r0 []products.ProductRecords
-	if rf, ok := ret.Get(0).(func(int) []products.ProductRecords); ok {
-		r0 = rf(ProductId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []products.ProductRecords); ok {
+		r0 = rf(ctx, ProductId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]products.ProductRecords)
@@ -105,28 +109,73 @@ func (_m *Repository) GetReportProduct(ProductId int) ([]products.ProductRecords
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(ProductId)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ProductId)
 	} else {
 		r1 = ret.Error(1)
 	}
+
+	return r0, r1
+}
+
+// GetUsage provides a mock function with given fields: ctx, id
+func (_m *Repository) GetUsage(ctx context.Context, id int) (products.ProductUsage, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 products.ProductUsage
+	if rf, ok := ret.Get(0).(func(context.Context, int) products.ProductUsage); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(products.ProductUsage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, filter
+func (_m *Repository) Search(ctx context.Context, filter products.ProductSearchFilter) ([]products.Product, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []products.Product
+	if rf, ok := ret.Get(0).(func(context.Context, products.ProductSearchFilter) []products.Product); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]products.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, products.ProductSearchFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, requestData
-func (_m *Repository) Update(id int, requestData map[string]interface{}) (products.Product, error) {
-	ret := _m.Called(id, requestData)
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Repository) Update(ctx context.Context, id int, requestData map[string]interface{}) (products.Product, error) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 products.Product
-	if rf, ok := ret.Get(0).(func(int, map[string]interface{}) products.Product); ok {
-		r0 = rf(id, requestData)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) products.Product); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(products.Product)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int, map[string]interface{}) error); ok {
-		r1 = rf(id, requestData)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) error); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Error(1)
 	}
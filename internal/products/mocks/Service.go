@@ -3,9 +3,14 @@
 package mocks
 
 import (
+	context "context"
+
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	mock "github.com/stretchr/testify/mock"
+
 	products "github.com/emidioreb/mercado-fresco-lerigophers/internal/products"
+
 	web "github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
-	mock "github.com/stretchr/testify/mock"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -13,20 +18,20 @@ type Service struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan, freezingRate, productTypeId, sellerId
-func (_m *Service) Create(productCode string, description string, width float64, height float64, length float64, netWeight float64, expirationRate float64, recommendedFreezingTemperaturechan float64, freezingRate float64, productTypeId int, sellerId int) (products.Product, web.ResponseCode) {
-	ret := _m.Called(productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan, freezingRate, productTypeId, sellerId)
+// Create provides a mock function with given fields: ctx, productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperature, freezingRate, productTypeId, sellerId
+func (_m *Service) Create(ctx context.Context, productCode string, description string, width float64, height float64, length float64, netWeight float64, expirationRate float64, recommendedFreezingTemperature *float64, freezingRate float64, productTypeId int, sellerId int) (products.Product, web.ResponseCode) {
+	ret := _m.Called(ctx, productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperature, freezingRate, productTypeId, sellerId)
 
 	var r0 products.Product
-	if rf, ok := ret.Get(0).(func(string, string, float64, float64, float64, float64, float64, float64, float64, int, int) products.Product); ok {
-		r0 = rf(productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan, freezingRate, productTypeId, sellerId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float64, float64, float64, float64, float64, *float64, float64, int, int) products.Product); ok {
+		r0 = rf(ctx, productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperature, freezingRate, productTypeId, sellerId)
 	} else {
 		r0 = ret.Get(0).(products.Product)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(string, string, float64, float64, float64, float64, float64, float64, float64, int, int) web.ResponseCode); ok {
-		r1 = rf(productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan, freezingRate, productTypeId, sellerId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, float64, float64, float64, float64, float64, *float64, float64, int, int) web.ResponseCode); ok {
+		r1 = rf(ctx, productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperature, freezingRate, productTypeId, sellerId)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -34,13 +39,13 @@ func (_m *Service) Create(productCode string, description string, width float64,
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Service) Delete(id int) web.ResponseCode {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Service) Delete(ctx context.Context, id int) web.ResponseCode {
+	ret := _m.Called(ctx, id)
 
 	var r0 web.ResponseCode
-	if rf, ok := ret.Get(0).(func(int) web.ResponseCode); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) web.ResponseCode); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(web.ResponseCode)
 	}
@@ -48,43 +53,50 @@ func (_m *Service) Delete(id int) web.ResponseCode {
 	return r0
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *Service) GetAll() ([]products.Product, web.ResponseCode) {
-	ret := _m.Called()
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Service) GetAll(ctx context.Context, opts listing.Options) ([]products.Product, listing.Page, web.ResponseCode) {
+	ret := _m.Called(ctx, opts)
 
 	var r0 []products.Product
-	if rf, ok := ret.Get(0).(func() []products.Product); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []products.Product); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]products.Product)
 		}
 	}
 
-	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func() web.ResponseCode); ok {
-		r1 = rf()
+	var r1 listing.Page
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) listing.Page); ok {
+		r1 = rf(ctx, opts)
 	} else {
-		r1 = ret.Get(1).(web.ResponseCode)
+		r1 = ret.Get(1).(listing.Page)
 	}
 
-	return r0, r1
+	var r2 web.ResponseCode
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Options) web.ResponseCode); ok {
+		r2 = rf(ctx, opts)
+	} else {
+		r2 = ret.Get(2).(web.ResponseCode)
+	}
+
+	return r0, r1, r2
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Service) GetOne(id int) (products.Product, web.ResponseCode) {
-	ret := _m.Called(id)
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Service) GetOne(ctx context.Context, id int) (products.Product, web.ResponseCode) {
+	ret := _m.Called(ctx, id)
 
 	var r0 products.Product
-	if rf, ok := ret.Get(0).(func(int) products.Product); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) products.Product); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(products.Product)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int) web.ResponseCode); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -92,13 +104,13 @@ func (_m *Service) GetOne(id int) (products.Product, web.ResponseCode) {
 	return r0, r1
 }
 
-// GetReportRecord provides a mock function with given fields: ProductId
-func (_m *Service) GetReportRecord(ProductId int) ([]products.ProductRecords, web.ResponseCode) {
-	ret := _m.Called(ProductId)
+// GetReportRecord provides a mock function with given fields: ctx, ProductId
+func (_m *Service) GetReportRecord(ctx context.Context, ProductId int) ([]products.ProductRecords, web.ResponseCode) {
+	ret := _m.Called(ctx, ProductId)
 
 	var r0 []products.ProductRecords
-	if rf, ok := ret.Get(0).(func(int) []products.ProductRecords); ok {
-		r0 = rf(ProductId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []products.ProductRecords); ok {
+		r0 = rf(ctx, ProductId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]products.ProductRecords)
@@ -106,8 +118,29 @@ func (_m *Service) GetReportRecord(ProductId int) ([]products.ProductRecords, we
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int) web.ResponseCode); ok {
-		r1 = rf(ProductId)
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, ProductId)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, filter
+func (_m *Service) Search(ctx context.Context, filter products.ProductSearchFilter) (products.ProductPage, web.ResponseCode) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 products.ProductPage
+	if rf, ok := ret.Get(0).(func(context.Context, products.ProductSearchFilter) products.ProductPage); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(products.ProductPage)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, products.ProductSearchFilter) web.ResponseCode); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -115,20 +148,20 @@ func (_m *Service) GetReportRecord(ProductId int) ([]products.ProductRecords, we
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, requestData
-func (_m *Service) Update(id int, requestData map[string]interface{}) (products.Product, web.ResponseCode) {
-	ret := _m.Called(id, requestData)
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Service) Update(ctx context.Context, id int, requestData map[string]interface{}) (products.Product, web.ResponseCode) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 products.Product
-	if rf, ok := ret.Get(0).(func(int, map[string]interface{}) products.Product); ok {
-		r0 = rf(id, requestData)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) products.Product); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(products.Product)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int, map[string]interface{}) web.ResponseCode); ok {
-		r1 = rf(id, requestData)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) web.ResponseCode); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -136,6 +169,20 @@ func (_m *Service) Update(id int, requestData map[string]interface{}) (products.
 	return r0, r1
 }
 
+// ValidateCreate provides a mock function with given fields: ctx, productCode, recommendedFreezingTemperature, productTypeId, sellerId
+func (_m *Service) ValidateCreate(ctx context.Context, productCode string, recommendedFreezingTemperature *float64, productTypeId int, sellerId int) web.ResponseCode {
+	ret := _m.Called(ctx, productCode, recommendedFreezingTemperature, productTypeId, sellerId)
+
+	var r0 web.ResponseCode
+	if rf, ok := ret.Get(0).(func(context.Context, string, *float64, int, int) web.ResponseCode); ok {
+		r0 = rf(ctx, productCode, recommendedFreezingTemperature, productTypeId, sellerId)
+	} else {
+		r0 = ret.Get(0).(web.ResponseCode)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewService interface {
 	mock.TestingT
 	Cleanup(func())
@@ -1,5 +1,27 @@
 package products
 
+import "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+
+// ListFields are the fields products can be filtered by on the list
+// endpoint; they can be sorted by any of the SortableColumns.
+var ListFields = listing.Fields{
+	Columns: map[string]string{
+		"id":                               "id",
+		"product_code":                     "product_code",
+		"description":                      "description",
+		"width":                            "width",
+		"height":                           "height",
+		"length":                           "length",
+		"net_weight":                       "net_weight",
+		"expiration_rate":                  "expiration_rate",
+		"recommended_freezing_temperature": "recommended_freezing_temperature",
+		"freezing_rate":                    "freezing_rate",
+		"product_type_id":                  "product_type_id",
+		"seller_id":                        "seller_id",
+	},
+	Sortable: SortableColumns,
+}
+
 type Product struct {
 	Id                             int     `json:"id"`
 	ProductCode                    string  `json:"product_code"`
@@ -15,6 +37,11 @@ type Product struct {
 	SellerId                       int     `json:"seller_id"`
 }
 
+type ProductUsage struct {
+	ProductBatches int `json:"product_batches"`
+	ProductRecords int `json:"product_records"`
+}
+
 type ProductRecords struct {
 	ProductId    int    `json:"product_id"`
 	Description  string `json:"description"`
@@ -1,6 +1,11 @@
 package products
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+)
 
 var (
 	queryGetReportOne = `SELECT p.id as product_id, p.description, count(*) as products_count
@@ -26,8 +31,16 @@ var (
 
 	queryGetOneProduct = `SELECT * FROM products WHERE id = ?`
 
+	queryGetProductUsage = `SELECT
+		(SELECT COUNT(*) FROM product_batches WHERE product_id = ?),
+		(SELECT COUNT(*) FROM product_records WHERE product_id = ?)`
+
 	queryGetAllProducts = `SELECT * FROM products`
 
+	querySearchProducts = `SELECT id, product_code, description, width, height, length, net_weight,
+		expiration_rate, recommended_freezing_temperature, freezing_rate, product_type_id, seller_id
+		FROM products`
+
 	queryDeleteProduct = "DELETE FROM products WHERE id = ?"
 
 	queryUpdateProduct = func(
@@ -77,4 +90,67 @@ var (
 
 		return finalQuery, valuesToUse
 	}
+
+	queryBuildSearchProducts = func(filter ProductSearchFilter) (
+		finalQuery string,
+		valuesToUse []interface{}) {
+		conditions := []string{}
+
+		if !IsSortableColumn(filter.Sort) {
+			filter.Sort = "id"
+		}
+
+		if filter.Text != "" {
+			pattern := "%" + escapeLike(filter.Text) + "%"
+			conditions = append(conditions, "(description LIKE ? OR product_code LIKE ?)")
+			valuesToUse = append(valuesToUse, pattern, pattern)
+		}
+
+		if filter.SellerId != 0 {
+			conditions = append(conditions, "seller_id = ?")
+			valuesToUse = append(valuesToUse, filter.SellerId)
+		}
+
+		if filter.ProductTypeId != 0 {
+			conditions = append(conditions, "product_type_id = ?")
+			valuesToUse = append(valuesToUse, filter.ProductTypeId)
+		}
+
+		for _, column := range RangeColumns {
+			currRange, ok := filter.Ranges[column]
+			if !ok {
+				continue
+			}
+			if currRange.Min != nil {
+				conditions = append(conditions, fmt.Sprintf("%s >= ?", column))
+				valuesToUse = append(valuesToUse, *currRange.Min)
+			}
+			if currRange.Max != nil {
+				conditions = append(conditions, fmt.Sprintf("%s <= ?", column))
+				valuesToUse = append(valuesToUse, *currRange.Max)
+			}
+		}
+
+		if filter.After != nil {
+			condition, afterValues := listing.After(filter.Sort, "id", filter.Order, filter.After.Value, filter.After.Id)
+			conditions = append(conditions, condition)
+			valuesToUse = append(valuesToUse, afterValues...)
+		}
+
+		finalQuery = querySearchProducts
+		if len(conditions) > 0 {
+			finalQuery += " WHERE " + strings.Join(conditions, " AND ")
+		}
+
+		finalQuery += " ORDER BY " + listing.OrderBy(filter.Sort, "id", filter.Order)
+
+		finalQuery += " LIMIT ?"
+		valuesToUse = append(valuesToUse, filter.Limit+1)
+
+		return finalQuery, valuesToUse
+	}
 )
+
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text)
+}
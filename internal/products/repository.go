@@ -1,9 +1,13 @@
 package products
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 )
 
 var (
@@ -12,29 +16,37 @@ var (
 	errGetProducts    = errors.New("couldn`t get products")
 	errGetOneProduct  = errors.New("unexpected error to get product")
 	errDeleteProduct  = errors.New("unexpected error to delete product")
+	errSearchProducts = errors.New("couldn`t search products")
+	errGetUsage       = errors.New("unexpected error to verify product usage")
 )
 
+func GetErrProductNotFound(id int) error {
+	return fmt.Errorf("product with id %d not found", id)
+}
+
 type Repository interface {
-	Create(productCode, description string, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan,
+	Create(ctx context.Context, productCode, description string, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan,
 		freezingRate float64, productTypeId, sellerId int) (Product, error)
-	GetOne(id int) (Product, error)
-	GetAll() ([]Product, error)
-	Delete(id int) error
-	Update(id int, requestData map[string]interface{}) (Product, error)
-	GetReportProduct(ProductId int) ([]ProductRecords, error)
+	GetOne(ctx context.Context, id int) (Product, error)
+	GetAll(ctx context.Context, opts listing.Options) ([]Product, error)
+	Search(ctx context.Context, filter ProductSearchFilter) ([]Product, error)
+	Delete(ctx context.Context, id int) error
+	GetUsage(ctx context.Context, id int) (ProductUsage, error)
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Product, error)
+	GetReportProduct(ctx context.Context, ProductId int) ([]ProductRecords, error)
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
-func (mariaDb mariaDbRepository) Create(productCode, description string, width, height,
+func (mariaDb mariaDbRepository) Create(ctx context.Context, productCode, description string, width, height,
 	length, netWeight, expirationRate, recommendedFreezingTemperature,
 	freezingRate float64, productTypeId, sellerId int) (Product, error) {
 
@@ -52,7 +64,8 @@ func (mariaDb mariaDbRepository) Create(productCode, description string, width,
 		SellerId:                       sellerId,
 	}
 
-	result, err := mariaDb.db.Exec(
+	result, err := mariaDb.db.ExecContext(
+		ctx,
 		queryCreateProduct,
 		productCode,
 		description,
@@ -81,10 +94,10 @@ func (mariaDb mariaDbRepository) Create(productCode, description string, width,
 	return newProduct, nil
 }
 
-func (mariaDb mariaDbRepository) GetOne(id int) (Product, error) {
+func (mariaDb mariaDbRepository) GetOne(ctx context.Context, id int) (Product, error) {
 	currentProduct := Product{}
 
-	row := mariaDb.db.QueryRow(queryGetOneProduct, id)
+	row := mariaDb.db.QueryRowContext(ctx, queryGetOneProduct, id)
 	err := row.Scan(&currentProduct.Id,
 		&currentProduct.ProductCode,
 		&currentProduct.Description,
@@ -100,7 +113,7 @@ func (mariaDb mariaDbRepository) GetOne(id int) (Product, error) {
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
-		return Product{}, fmt.Errorf("product with id %d not found", id)
+		return Product{}, GetErrProductNotFound(id)
 	}
 
 	if err != nil {
@@ -110,10 +123,11 @@ func (mariaDb mariaDbRepository) GetOne(id int) (Product, error) {
 	return currentProduct, nil
 }
 
-func (mariaDb mariaDbRepository) GetAll() ([]Product, error) {
+func (mariaDb mariaDbRepository) GetAll(ctx context.Context, opts listing.Options) ([]Product, error) {
 	products := []Product{}
 
-	rows, err := mariaDb.db.Query(queryGetAllProducts)
+	query, values := listing.Build(queryGetAllProducts, ListFields, opts)
+	rows, err := mariaDb.db.QueryContext(ctx, query, values...)
 	if err != nil {
 		return []Product{}, errGetProducts
 	}
@@ -139,11 +153,55 @@ func (mariaDb mariaDbRepository) GetAll() ([]Product, error) {
 		}
 		products = append(products, currentProduct)
 	}
+
+	if err := rows.Err(); err != nil {
+		return []Product{}, errGetProducts
+	}
+	return products, nil
+}
+
+func (mariaDb mariaDbRepository) Search(ctx context.Context, filter ProductSearchFilter) ([]Product, error) {
+	products := []Product{}
+
+	finalQuery, valuesToUse := queryBuildSearchProducts(filter)
+
+	rows, err := mariaDb.db.QueryContext(ctx, finalQuery, valuesToUse...)
+	if err != nil {
+		return []Product{}, errSearchProducts
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var currentProduct Product
+		err := rows.Scan(
+			&currentProduct.Id,
+			&currentProduct.ProductCode,
+			&currentProduct.Description,
+			&currentProduct.Width,
+			&currentProduct.Height,
+			&currentProduct.Length,
+			&currentProduct.NetWeight,
+			&currentProduct.ExpirationRate,
+			&currentProduct.RecommendedFreezingTemperature,
+			&currentProduct.FreezingRate,
+			&currentProduct.ProductTypeId,
+			&currentProduct.SellerId,
+		)
+		if err != nil {
+			return []Product{}, errSearchProducts
+		}
+		products = append(products, currentProduct)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []Product{}, errSearchProducts
+	}
+
 	return products, nil
 }
 
-func (mariaDb mariaDbRepository) Delete(id int) error {
-	result, err := mariaDb.db.Exec(queryDeleteProduct, id)
+func (mariaDb mariaDbRepository) Delete(ctx context.Context, id int) error {
+	result, err := mariaDb.db.ExecContext(ctx, queryDeleteProduct, id)
 
 	if err != nil {
 		return err
@@ -151,7 +209,7 @@ func (mariaDb mariaDbRepository) Delete(id int) error {
 
 	affectedRows, err := result.RowsAffected()
 	if affectedRows == 0 {
-		return fmt.Errorf("product with id %d not found", id)
+		return GetErrProductNotFound(id)
 	}
 
 	if err != nil {
@@ -161,10 +219,10 @@ func (mariaDb mariaDbRepository) Delete(id int) error {
 	return nil
 }
 
-func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface{}) (Product, error) {
+func (mariaDb mariaDbRepository) Update(ctx context.Context, id int, requestData map[string]interface{}) (Product, error) {
 	finalQuery, valuesToUse := queryUpdateProduct(requestData, id)
 
-	result, err := mariaDb.db.Exec(finalQuery, valuesToUse...)
+	result, err := mariaDb.db.ExecContext(ctx, finalQuery, valuesToUse...)
 	if err != nil {
 		return Product{}, errUpdatedProduct
 	}
@@ -174,7 +232,7 @@ func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface
 		return Product{}, errUpdatedProduct
 	}
 
-	currentProduct, err := mariaDb.GetOne(id)
+	currentProduct, err := mariaDb.GetOne(ctx, id)
 	if err != nil {
 		return Product{}, errUpdatedProduct
 	}
@@ -182,7 +240,7 @@ func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface
 	return currentProduct, nil
 }
 
-func (mariaDb mariaDbRepository) GetReportProduct(ProductId int) ([]ProductRecords, error) {
+func (mariaDb mariaDbRepository) GetReportProduct(ctx context.Context, ProductId int) ([]ProductRecords, error) {
 	reports := []ProductRecords{}
 
 	var (
@@ -191,9 +249,9 @@ func (mariaDb mariaDbRepository) GetReportProduct(ProductId int) ([]ProductRecor
 	)
 
 	if ProductId != 0 {
-		rows, err = mariaDb.db.Query(queryGetReportOne, ProductId)
+		rows, err = mariaDb.db.QueryContext(ctx, queryGetReportOne, ProductId)
 	} else {
-		rows, err = mariaDb.db.Query(queryGetReportAll)
+		rows, err = mariaDb.db.QueryContext(ctx, queryGetReportAll)
 	}
 
 	if err != nil {
@@ -212,5 +270,20 @@ func (mariaDb mariaDbRepository) GetReportProduct(ProductId int) ([]ProductRecor
 		reports = append(reports, currentReport)
 	}
 
+	if err := rows.Err(); err != nil {
+		return []ProductRecords{}, errors.New("error to report products by product_id")
+	}
+
 	return reports, nil
 }
+
+func (mariaDb mariaDbRepository) GetUsage(ctx context.Context, id int) (ProductUsage, error) {
+	var usage ProductUsage
+
+	row := mariaDb.db.QueryRowContext(ctx, queryGetProductUsage, id, id)
+	if err := row.Scan(&usage.ProductBatches, &usage.ProductRecords); err != nil {
+		return ProductUsage{}, errGetUsage
+	}
+
+	return usage, nil
+}
@@ -1,6 +1,7 @@
 package products
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"regexp"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 )
 
 func TestDBCreateProduct(t *testing.T) {
@@ -35,6 +38,7 @@ func TestDBCreateProduct(t *testing.T) {
 		productsRepo := NewMariaDbRepository(db)
 
 		product, err := productsRepo.Create(
+			context.Background(),
 			"ABX0001",
 			"abacaxi",
 			1.0,
@@ -59,7 +63,7 @@ func TestDBCreateProduct(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryCreateProduct)).WillReturnError(errors.New("internal db error"))
 		productsRepo := NewMariaDbRepository(db)
 
-		_, err = productsRepo.Create("0", "", 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0, 0)
+		_, err = productsRepo.Create(context.Background(), "0", "", 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0, 0)
 		assert.Error(t, err)
 	})
 
@@ -74,7 +78,7 @@ func TestDBCreateProduct(t *testing.T) {
 			WillReturnResult(sqlDriverResultErr)
 
 		productsRepo := NewMariaDbRepository(db)
-		_, err = productsRepo.Create("0", "", 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0, 0)
+		_, err = productsRepo.Create(context.Background(), "0", "", 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0, 0)
 
 		assert.Error(t, err)
 		assert.Equal(t, "ocurred an error to create product", err.Error())
@@ -105,7 +109,7 @@ func TestDBGetOneProduct(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneProduct)).WillReturnRows(rows)
 
 		productRepo := NewMariaDbRepository(db)
-		product, err := productRepo.GetOne(1)
+		product, err := productRepo.GetOne(context.Background(), 1)
 		assert.NoError(t, err)
 
 		assert.Equal(t, "ABX0001", product.ProductCode)
@@ -120,7 +124,7 @@ func TestDBGetOneProduct(t *testing.T) {
 			WillReturnError(errors.New("unexpected error to get product"))
 
 		productsRepo := NewMariaDbRepository(db)
-		_, err = productsRepo.GetOne(1)
+		_, err = productsRepo.GetOne(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "unexpected error to get product", err.Error())
 	})
@@ -134,7 +138,7 @@ func TestDBGetOneProduct(t *testing.T) {
 			WillReturnError(errors.New("unexpected error to get product"))
 
 		productsRepo := NewMariaDbRepository(db)
-		_, err = productsRepo.GetOne(1)
+		_, err = productsRepo.GetOne(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "unexpected error to get product", err.Error())
 	})
@@ -148,7 +152,7 @@ func TestDBGetOneProduct(t *testing.T) {
 			WillReturnError(sql.ErrNoRows)
 
 		productsRepo := NewMariaDbRepository(db)
-		_, err = productsRepo.GetOne(20)
+		_, err = productsRepo.GetOne(context.Background(), 20)
 		assert.Error(t, err)
 		assert.Equal(t, "product with id 20 not found", err.Error())
 	})
@@ -181,7 +185,7 @@ func TestDBgetAllProducts(t *testing.T) {
 
 		productsRepo := NewMariaDbRepository(db)
 
-		productsReports, err := productsRepo.GetAll()
+		productsReports, err := productsRepo.GetAll(context.Background(), listing.Options{})
 		assert.NoError(t, err)
 
 		assert.Len(t, productsReports, 3)
@@ -200,7 +204,7 @@ func TestDBgetAllProducts(t *testing.T) {
 
 		productsRepo := NewMariaDbRepository(db)
 
-		_, err = productsRepo.GetAll()
+		_, err = productsRepo.GetAll(context.Background(), listing.Options{})
 		assert.Error(t, err)
 	})
 
@@ -230,7 +234,7 @@ func TestDBgetAllProducts(t *testing.T) {
 
 		productsRepo := NewMariaDbRepository(db)
 
-		_, err = productsRepo.GetAll()
+		_, err = productsRepo.GetAll(context.Background(), listing.Options{})
 		assert.Error(t, err)
 	})
 }
@@ -246,7 +250,7 @@ func TestDBDeleteProduct(t *testing.T) {
 			WillReturnResult(affectedRowsResult)
 
 		productsRepo := NewMariaDbRepository(db)
-		err = productsRepo.Delete(1)
+		err = productsRepo.Delete(context.Background(), 1)
 		assert.NoError(t, err)
 	})
 
@@ -260,7 +264,7 @@ func TestDBDeleteProduct(t *testing.T) {
 			WillReturnResult(affectedRowsResult)
 
 		productsRepo := NewMariaDbRepository(db)
-		err = productsRepo.Delete(1)
+		err = productsRepo.Delete(context.Background(), 1)
 		assert.NotNil(t, err)
 		assert.Equal(t, "product with id 1 not found", err.Error())
 	})
@@ -274,7 +278,7 @@ func TestDBDeleteProduct(t *testing.T) {
 			WillReturnError(errors.New("any error"))
 
 		productsRepo := NewMariaDbRepository(db)
-		err = productsRepo.Delete(1)
+		err = productsRepo.Delete(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "any error", err.Error())
 	})
@@ -288,7 +292,7 @@ func TestDBDeleteProduct(t *testing.T) {
 			WillReturnError(errors.New("unexpected error to delete product"))
 
 		productsRepo := NewMariaDbRepository(db)
-		err = productsRepo.Delete(1)
+		err = productsRepo.Delete(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "unexpected error to delete product", err.Error())
 	})
@@ -365,7 +369,7 @@ func TestDBUpdateProduct(t *testing.T) {
 
 		productsRepo := NewMariaDbRepository(db)
 
-		products, err := productsRepo.Update(1, requestData)
+		products, err := productsRepo.Update(context.Background(), 1, requestData)
 		assert.Nil(t, err)
 
 		assert.Equal(t, "ABX0001", products.ProductCode)
@@ -381,7 +385,7 @@ func TestDBUpdateProduct(t *testing.T) {
 
 		productsRepo := NewMariaDbRepository(db)
 
-		_, err = productsRepo.Update(1, requestData)
+		_, err = productsRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errUpdatedProduct, err)
 	})
@@ -399,7 +403,7 @@ func TestDBUpdateProduct(t *testing.T) {
 
 		productsRepo := NewMariaDbRepository(db)
 
-		_, err = productsRepo.Update(1, requestData)
+		_, err = productsRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errUpdatedProduct, err)
 	})
@@ -419,7 +423,7 @@ func TestDBUpdateProduct(t *testing.T) {
 
 		productsRepo := NewMariaDbRepository(db)
 
-		_, err = productsRepo.Update(1, requestData)
+		_, err = productsRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errUpdatedProduct, err)
 	})
@@ -442,9 +446,166 @@ func TestReportProduct(t *testing.T) {
 
 		productsRepo := NewMariaDbRepository(db)
 
-		productsReports, err := productsRepo.GetReportProduct(1)
+		productsReports, err := productsRepo.GetReportProduct(context.Background(), 1)
 		assert.NoError(t, err)
 
 		assert.Len(t, productsReports, 1)
 	})
 }
+
+func TestDBSearchProducts(t *testing.T) {
+	productColumns := []string{
+		"id",
+		"product_code",
+		"description",
+		"width",
+		"height",
+		"length",
+		"net_weight",
+		"expiration_rate",
+		"recommended_freezing_temperature",
+		"freezing_rate",
+		"product_type_id",
+		"seller_id",
+	}
+
+	t.Run("Success case with every filter", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		minWidth, maxWeight := 1.0, 10.0
+		filter := ProductSearchFilter{
+			Text:          "aba_",
+			SellerId:      1,
+			ProductTypeId: 4,
+			Ranges: map[string]Range{
+				"width":      {Min: &minWidth},
+				"net_weight": {Max: &maxWeight},
+			},
+			Sort:  "description",
+			Order: listing.SortDesc,
+			Limit: 2,
+			After: &listing.Cursor{Sort: "description", Order: listing.SortDesc, Value: "melao", Id: 2},
+		}
+
+		expectedQuery := querySearchProducts +
+			" WHERE (description LIKE ? OR product_code LIKE ?) AND seller_id = ? AND product_type_id = ?" +
+			" AND width >= ? AND net_weight <= ?" +
+			" AND (description < ? OR (description = ? AND id < ?))" +
+			" ORDER BY description IS NULL DESC, description DESC, id DESC LIMIT ?"
+
+		rows := sqlmock.NewRows(productColumns).
+			AddRow(1, "ABX0001", "abacaxi", 1.0, 2.0, 3.0, 4.0, 5.0, 6.0, 7.0, 4, 1)
+		mock.ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+			WithArgs(`%aba\_%`, `%aba\_%`, 1, 4, 1.0, 10.0, "melao", "melao", 2, 3).
+			WillReturnRows(rows)
+
+		productsRepo := NewMariaDbRepository(db)
+
+		result, err := productsRepo.Search(context.Background(), filter)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "ABX0001", result[0].ProductCode)
+	})
+
+	t.Run("Success case sorted by id", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		filter := ProductSearchFilter{
+			Sort:  "id",
+			Order: listing.SortAsc,
+			Limit: 20,
+			After: &listing.Cursor{Sort: "id", Order: listing.SortAsc, Id: 5},
+		}
+
+		mock.ExpectQuery(regexp.QuoteMeta(querySearchProducts+" WHERE id > ? ORDER BY id ASC LIMIT ?")).
+			WithArgs(5, 21).
+			WillReturnRows(sqlmock.NewRows(productColumns))
+
+		productsRepo := NewMariaDbRepository(db)
+
+		result, err := productsRepo.Search(context.Background(), filter)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("Cursor on a NULL value", func(t *testing.T) {
+		finalQuery, values := queryBuildSearchProducts(ProductSearchFilter{
+			Sort:  "description",
+			Order: listing.SortAsc,
+			Limit: 20,
+			After: &listing.Cursor{Sort: "description", Order: listing.SortAsc, Id: 5},
+		})
+		assert.Equal(t, querySearchProducts+" WHERE (description IS NULL AND id > ?) ORDER BY description IS NULL ASC, description ASC, id ASC LIMIT ?", finalQuery)
+		assert.Equal(t, []interface{}{5, 21}, values)
+	})
+
+	t.Run("Unknown sort column falls back to id", func(t *testing.T) {
+		finalQuery, _ := queryBuildSearchProducts(ProductSearchFilter{Sort: "id; DROP TABLE products", Limit: 1})
+		assert.Equal(t, querySearchProducts+" ORDER BY id ASC LIMIT ?", finalQuery)
+	})
+
+	t.Run("DB Error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(querySearchProducts)).
+			WillReturnError(errors.New("internal db error"))
+
+		productsRepo := NewMariaDbRepository(db)
+
+		_, err = productsRepo.Search(context.Background(), ProductSearchFilter{Sort: "id", Limit: 20})
+		assert.Error(t, err)
+		assert.Equal(t, errSearchProducts, err)
+	})
+
+	t.Run("Scan Error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(productColumns).
+			AddRow("", "", "", "", "", "", "", "", "", "", "", "")
+		mock.ExpectQuery(regexp.QuoteMeta(querySearchProducts)).WillReturnRows(rows)
+
+		productsRepo := NewMariaDbRepository(db)
+
+		_, err = productsRepo.Search(context.Background(), ProductSearchFilter{Sort: "id", Limit: 20})
+		assert.Error(t, err)
+		assert.Equal(t, errSearchProducts, err)
+	})
+}
+
+func TestGetUsage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"product_batches", "product_records"}).AddRow(1, 2)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetProductUsage)).WithArgs(1, 1).WillReturnRows(rows)
+
+		productsRepo := NewMariaDbRepository(db)
+
+		usage, err := productsRepo.GetUsage(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, ProductUsage{ProductBatches: 1, ProductRecords: 2}, usage)
+	})
+
+	t.Run("fail to scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetProductUsage)).WillReturnError(sql.ErrConnDone)
+
+		productsRepo := NewMariaDbRepository(db)
+
+		_, err = productsRepo.GetUsage(context.Background(), 1)
+		assert.Equal(t, errGetUsage, err)
+	})
+}
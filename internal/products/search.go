@@ -0,0 +1,72 @@
+package products
+
+import "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+
+const (
+	DefaultSearchLimit = listing.DefaultLimit
+	MaxSearchLimit     = listing.MaxLimit
+)
+
+// SortableColumns lists every column a product search can be ordered by.
+var SortableColumns = []string{
+	"id",
+	"product_code",
+	"description",
+	"width",
+	"height",
+	"length",
+	"net_weight",
+	"expiration_rate",
+	"recommended_freezing_temperature",
+	"freezing_rate",
+	"product_type_id",
+	"seller_id",
+}
+
+// RangeColumns lists the columns accepted by the min/max filters.
+var RangeColumns = []string{
+	"width",
+	"height",
+	"length",
+	"net_weight",
+	"recommended_freezing_temperature",
+}
+
+type Range struct {
+	Min *float64
+	Max *float64
+}
+
+type ProductSearchFilter struct {
+	Text          string
+	SellerId      int
+	ProductTypeId int
+	Ranges        map[string]Range
+	Sort          string
+	Order         string
+	Limit         int
+	Cursor        string
+	After         *listing.Cursor
+}
+
+type ProductPage struct {
+	Products   []Product `json:"products"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+func IsSortableColumn(column string) bool {
+	return contains(SortableColumns, column)
+}
+
+func IsRangeColumn(column string) bool {
+	return contains(RangeColumns, column)
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -1,61 +1,118 @@
 package products
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
+	product_types "github.com/emidioreb/mercado-fresco-lerigophers/internal/productTypes"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 )
 
 type Service interface {
-	Create(productCode, description string, width, height, length, netWeight, expirationRate, recommendedFreezingTemperaturechan,
+	Create(ctx context.Context, productCode, description string, width, height, length, netWeight, expirationRate float64, recommendedFreezingTemperature *float64,
 		freezingRate float64, productTypeId, sellerId int) (Product, web.ResponseCode)
-	GetOne(id int) (Product, web.ResponseCode)
-	GetAll() ([]Product, web.ResponseCode)
-	Delete(id int) web.ResponseCode
-	Update(id int, requestData map[string]interface{}) (Product, web.ResponseCode)
-	GetReportRecord(ProductId int) ([]ProductRecords, web.ResponseCode)
+	ValidateCreate(ctx context.Context, productCode string, recommendedFreezingTemperature *float64, productTypeId, sellerId int) web.ResponseCode
+	GetOne(ctx context.Context, id int) (Product, web.ResponseCode)
+	GetAll(ctx context.Context, opts listing.Options) ([]Product, listing.Page, web.ResponseCode)
+	Search(ctx context.Context, filter ProductSearchFilter) (ProductPage, web.ResponseCode)
+	Delete(ctx context.Context, id int) web.ResponseCode
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Product, web.ResponseCode)
+	GetReportRecord(ctx context.Context, ProductId int) ([]ProductRecords, web.ResponseCode)
 }
 
 type service struct {
-	repository       Repository
-	sellerRepository sellers.Repository
+	repository            Repository
+	sellerRepository      sellers.Repository
+	productTypeRepository product_types.Repository
 }
 
-func NewService(r Repository, sr sellers.Repository) Service {
+func NewService(r Repository, sr sellers.Repository, pr product_types.Repository) Service {
 	return &service{
-		repository:       r,
-		sellerRepository: sr,
+		repository:            r,
+		sellerRepository:      sr,
+		productTypeRepository: pr,
 	}
 }
 
-func (s service) Create(productCode, description string, width, height, length, netWeight, expirationRate, recommendedFreezingTemperature,
+// ValidateCreate applies the rules of Create without persisting the product.
+func (s service) ValidateCreate(ctx context.Context, productCode string, recommendedFreezingTemperature *float64, productTypeId, sellerId int) web.ResponseCode {
+	if resp := s.validateCreate(ctx, productCode, sellerId); resp.Err != nil {
+		return resp
+	}
+
+	_, resp := s.freezingTemperature(ctx, recommendedFreezingTemperature, productTypeId)
+	return resp
+}
+
+// Create inherits the recommended_freezing_temperature from the product_type
+// default range when it is not informed, and refuses it when the product_type
+// has no range either.
+func (s service) Create(ctx context.Context, productCode, description string, width, height, length, netWeight, expirationRate float64, recommendedFreezingTemperature *float64,
 	freezingRate float64, productTypeId, sellerId int) (Product, web.ResponseCode) {
-	allProducts, _ := s.repository.GetAll()
+	if resp := s.validateCreate(ctx, productCode, sellerId); resp.Err != nil {
+		return Product{}, resp
+	}
+
+	temperature, resp := s.freezingTemperature(ctx, recommendedFreezingTemperature, productTypeId)
+	if resp.Err != nil {
+		return Product{}, resp
+	}
+
+	product, err := s.repository.Create(ctx, productCode, description, width, height, length, netWeight, expirationRate, temperature,
+		freezingRate, productTypeId, sellerId)
+
+	if err != nil {
+		return Product{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return product, web.NewCodeResponse(http.StatusCreated, nil)
+}
+
+func (s service) validateCreate(ctx context.Context, productCode string, sellerId int) web.ResponseCode {
+	if strings.ReplaceAll(productCode, " ", "") == "" {
+		return web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("empty product_code not allowed"))
+	}
+
+	allProducts, _ := s.repository.GetAll(ctx, listing.Options{})
 
 	for _, product := range allProducts {
 		if product.ProductCode == productCode {
-			return Product{}, web.NewCodeResponse(http.StatusConflict, errors.New("Product_code already exists"))
+			return web.NewCodeResponse(http.StatusConflict, errors.New("Product_code already exists"))
 		}
 	}
 
-	if _, err := s.sellerRepository.GetOne(sellerId); err != nil {
-		return Product{}, web.NewCodeResponse(http.StatusConflict, errors.New("informed seller_id don't exists"))
+	if _, err := s.sellerRepository.GetOne(ctx, sellerId); err != nil {
+		return web.NewCodeResponse(http.StatusConflict, errors.New("informed seller_id don't exists"))
 	}
 
-	product, err := s.repository.Create(productCode, description, width, height, length, netWeight, expirationRate, recommendedFreezingTemperature,
-		freezingRate, productTypeId, sellerId)
+	return web.ResponseCode{}
+}
 
+func (s service) freezingTemperature(ctx context.Context, recommendedFreezingTemperature *float64, productTypeId int) (float64, web.ResponseCode) {
+	if recommendedFreezingTemperature != nil {
+		return *recommendedFreezingTemperature, web.ResponseCode{}
+	}
+
+	productType, err := s.productTypeRepository.GetOne(ctx, productTypeId)
 	if err != nil {
-		return Product{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+		return 0, web.NewCodeResponse(http.StatusConflict, err)
 	}
 
-	return product, web.NewCodeResponse(http.StatusCreated, nil)
+	if productType.MinimumTemperature == nil {
+		return 0, web.NewCodeResponse(http.StatusUnprocessableEntity, fmt.Errorf(
+			"recommended_freezing_temperature is required, product_type with id %d has no temperature range", productTypeId,
+		))
+	}
+	return *productType.MinimumTemperature, web.ResponseCode{}
 }
 
-func (s service) GetOne(id int) (Product, web.ResponseCode) {
-	product, err := s.repository.GetOne(id)
+func (s service) GetOne(ctx context.Context, id int) (Product, web.ResponseCode) {
+	product, err := s.repository.GetOne(ctx, id)
 
 	if err != nil {
 		return Product{}, web.NewCodeResponse(http.StatusNotFound, err)
@@ -63,27 +120,100 @@ func (s service) GetOne(id int) (Product, web.ResponseCode) {
 	return product, web.NewCodeResponse(http.StatusNotFound, nil)
 }
 
-func (s service) GetAll() ([]Product, web.ResponseCode) {
-	products, err := s.repository.GetAll()
-	return products, web.NewCodeResponse(http.StatusOK, err)
+func (s service) GetAll(ctx context.Context, opts listing.Options) ([]Product, listing.Page, web.ResponseCode) {
+	products, err := s.repository.GetAll(ctx, opts)
+	products, page := listing.Paginate(products, opts)
+	return products, page, web.NewCodeResponse(http.StatusOK, err)
+}
+
+func (s service) Search(ctx context.Context, filter ProductSearchFilter) (ProductPage, web.ResponseCode) {
+	if filter.Sort == "" {
+		filter.Sort = "id"
+	}
+	if filter.Order == "" {
+		filter.Order = listing.SortAsc
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultSearchLimit
+	}
+
+	if !IsSortableColumn(filter.Sort) {
+		return ProductPage{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("invalid sort column "+filter.Sort))
+	}
+	if filter.Order != listing.SortAsc && filter.Order != listing.SortDesc {
+		return ProductPage{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("order must be asc or desc"))
+	}
+	if filter.Limit > MaxSearchLimit {
+		return ProductPage{}, web.NewCodeResponse(http.StatusUnprocessableEntity, fmt.Errorf("limit must be at most %d", MaxSearchLimit))
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := listing.DecodeCursor(filter.Cursor, filter.Sort, filter.Order)
+		if err != nil {
+			return ProductPage{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+		}
+		filter.After = &cursor
+	}
+
+	products, err := s.repository.Search(ctx, filter)
+	if err != nil {
+		return ProductPage{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	products, page := listing.Paginate(products, listing.Options{
+		Limit: filter.Limit,
+		Sort:  filter.Sort,
+		Order: filter.Order,
+		After: filter.After,
+	})
+
+	return ProductPage{Products: products, NextCursor: page.NextCursor}, web.NewCodeResponse(http.StatusOK, nil)
 }
 
-func (s service) Delete(id int) web.ResponseCode {
-	err := s.repository.Delete(id)
+// Delete refuses to remove a product still referenced by batches or records.
+func (s service) Delete(ctx context.Context, id int) web.ResponseCode {
+	if resp := s.exists(ctx, id, http.StatusNotFound); resp.Err != nil {
+		return resp
+	}
 
+	usage, err := s.repository.GetUsage(ctx, id)
 	if err != nil {
-		return web.NewCodeResponse(http.StatusNotFound, err)
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	if resp := web.ReferencedBy("product", id,
+		web.Reference{Table: "product_batches", Count: usage.ProductBatches},
+		web.Reference{Table: "product_records", Count: usage.ProductRecords},
+	); resp.Err != nil {
+		return resp
+	}
+
+	if err := s.repository.Delete(ctx, id); err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
+
 	return web.NewCodeResponse(http.StatusNoContent, nil)
 }
 
-func (s service) Update(id int, requestData map[string]interface{}) (Product, web.ResponseCode) {
-	_, err := s.repository.GetOne(id)
+func (s service) exists(ctx context.Context, id, notFoundCode int) web.ResponseCode {
+	_, err := s.repository.GetOne(ctx, id)
+	if err == nil {
+		return web.ResponseCode{}
+	}
+
+	if err.Error() == GetErrProductNotFound(id).Error() {
+		return web.NewCodeResponse(notFoundCode, err)
+	}
+	return web.NewCodeResponse(http.StatusInternalServerError, err)
+}
+
+func (s service) Update(ctx context.Context, id int, requestData map[string]interface{}) (Product, web.ResponseCode) {
+	_, err := s.repository.GetOne(ctx, id)
 	if err != nil {
 		return Product{}, web.NewCodeResponse(http.StatusNotFound, err)
 	}
 
-	allProducts, _ := s.GetAll()
+	allProducts, _ := s.repository.GetAll(ctx, listing.Options{})
 	productCodeReqData := requestData["product_code"]
 
 	for _, product := range allProducts {
@@ -92,13 +222,13 @@ func (s service) Update(id int, requestData map[string]interface{}) (Product, we
 		}
 	}
 
-	product, _ := s.repository.Update(id, requestData)
+	product, _ := s.repository.Update(ctx, id, requestData)
 
 	return product, web.ResponseCode{Code: http.StatusOK, Err: nil}
 }
 
-func (s service) GetReportRecord(ProductId int) ([]ProductRecords, web.ResponseCode) {
-	report, err := s.repository.GetReportProduct(ProductId)
+func (s service) GetReportRecord(ctx context.Context, ProductId int) ([]ProductRecords, web.ResponseCode) {
+	report, err := s.repository.GetReportProduct(ctx, ProductId)
 
 	if err != nil {
 		return []ProductRecords{}, web.NewCodeResponse(http.StatusInternalServerError, err)
@@ -1,14 +1,18 @@
 package products_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
 
+	product_types "github.com/emidioreb/mercado-fresco-lerigophers/internal/productTypes"
+	mockedProductType "github.com/emidioreb/mercado-fresco-lerigophers/internal/productTypes/mocks"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/products"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/products/mocks"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers"
 	mockedSeller "github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -33,9 +37,10 @@ func TestServiceCreate(t *testing.T) {
 			SellerId:                       1,
 		}
 
-		mockedRepository.On("GetAll").Return([]products.Product{}, nil)
-		mockedSellerRepository.On("GetOne", mock.AnythingOfType("int")).Return(sellers.Seller{}, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]products.Product{}, nil)
+		mockedSellerRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(sellers.Seller{}, nil)
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("float64"),
@@ -49,11 +54,11 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("int"),
 		).Return(input, nil)
 
-		service := products.NewService(mockedRepository, mockedSellerRepository)
+		service := products.NewService(mockedRepository, mockedSellerRepository, &mockedProductType.Repository{})
 
-		result, err := service.Create(input.ProductCode, input.Description,
+		result, err := service.Create(context.Background(), input.ProductCode, input.Description,
 			input.Width, input.Height, input.Length, input.NetWeight,
-			input.ExpirationRate, input.RecommendedFreezingTemperature,
+			input.ExpirationRate, &input.RecommendedFreezingTemperature,
 			input.FreezingRate, input.ProductTypeId, input.SellerId)
 		assert.Nil(t, err.Err)
 
@@ -92,8 +97,9 @@ func TestServiceCreate(t *testing.T) {
 
 		expectedError := errors.New("Product_code already exists")
 
-		mockedRepository.On("GetAll").Return(input, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return(input, nil)
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("float64"),
@@ -106,47 +112,144 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("int"),
 		).Return(products.Product{}, expectedError)
 
-		service := products.NewService(mockedRepository, &mockedSeller.Repository{})
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
 
-		_, err := service.Create(input[0].ProductCode, input[0].Description,
+		_, err := service.Create(context.Background(), input[0].ProductCode, input[0].Description,
 			input[0].Width, input[0].Height, input[0].Length, input[0].NetWeight,
-			input[0].ExpirationRate, input[0].RecommendedFreezingTemperature,
+			input[0].ExpirationRate, &input[0].RecommendedFreezingTemperature,
 			input[0].FreezingRate, input[0].ProductTypeId, input[0].SellerId)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, err.Err.Error(), expectedError.Error())
 		assert.Equal(t, err.Code, http.StatusConflict)
 	})
+
+	t.Run("Test if inherits recommended_freezing_temperature from product_type", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedSellerRepository := new(mockedSeller.Repository)
+		mockedProductTypeRepository := new(mockedProductType.Repository)
+
+		minimumTemperature := -18.0
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]products.Product{}, nil)
+		mockedSellerRepository.On("GetOne", mock.Anything, 1).Return(sellers.Seller{}, nil)
+		mockedProductTypeRepository.On("GetOne", mock.Anything, 7).Return(product_types.ProductType{Id: 7, MinimumTemperature: &minimumTemperature}, nil)
+		mockedRepository.On("Create", mock.Anything, "FK0003", "Fake Product", 1.0, 2.0, 3.0, 4.0, 5.0, -18.0, 6.0, 7, 1).
+			Return(products.Product{Id: 1, RecommendedFreezingTemperature: -18}, nil)
+
+		service := products.NewService(mockedRepository, mockedSellerRepository, mockedProductTypeRepository)
+
+		result, resp := service.Create(context.Background(), "FK0003", "Fake Product", 1, 2, 3, 4, 5, nil, 6, 7, 1)
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, -18.0, result.RecommendedFreezingTemperature)
+		mockedRepository.AssertExpectations(t)
+	})
+
+	t.Run("Test if requires recommended_freezing_temperature when product_type has no range", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedSellerRepository := new(mockedSeller.Repository)
+		mockedProductTypeRepository := new(mockedProductType.Repository)
+
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]products.Product{}, nil)
+		mockedSellerRepository.On("GetOne", mock.Anything, 1).Return(sellers.Seller{}, nil)
+		mockedProductTypeRepository.On("GetOne", mock.Anything, 7).Return(product_types.ProductType{Id: 7}, nil)
+
+		service := products.NewService(mockedRepository, mockedSellerRepository, mockedProductTypeRepository)
+
+		_, resp := service.Create(context.Background(), "FK0003", "Fake Product", 1, 2, 3, 4, 5, nil, 6, 7, 1)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "recommended_freezing_temperature is required, product_type with id 7 has no temperature range", resp.Err.Error())
+		mockedRepository.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("Test conflict if product_type to inherit from do not exist", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedSellerRepository := new(mockedSeller.Repository)
+		mockedProductTypeRepository := new(mockedProductType.Repository)
+
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]products.Product{}, nil)
+		mockedSellerRepository.On("GetOne", mock.Anything, 1).Return(sellers.Seller{}, nil)
+		mockedProductTypeRepository.On("GetOne", mock.Anything, 7).Return(product_types.ProductType{}, product_types.GetErrProductTypeNotFound(7))
+
+		service := products.NewService(mockedRepository, mockedSellerRepository, mockedProductTypeRepository)
+
+		_, resp := service.Create(context.Background(), "FK0003", "Fake Product", 1, 2, 3, 4, 5, nil, 6, 7, 1)
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, "product_type with id 7 not found", resp.Err.Error())
+	})
 }
 
 func TestServiceDelete(t *testing.T) {
-	t.Run("Verify the sucessfully case if the product is deleted", func(t *testing.T) {
+	t.Run("Verify the successfully case if the product is deleted", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 
-		mockedRepository.On("Delete", mock.AnythingOfType("int")).Return(nil)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(products.Product{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(products.ProductUsage{}, nil).Once()
+		mockedRepository.On("Delete", mock.Anything, 1).Return(nil).Once()
 
-		service := products.NewService(mockedRepository, &mockedSeller.Repository{})
-		result := service.Delete(1)
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
+		result := service.Delete(context.Background(), 1)
 		assert.Nil(t, result.Err)
 
-		assert.Equal(t, result.Code, http.StatusNoContent)
+		assert.Equal(t, http.StatusNoContent, result.Code)
 		mockedRepository.AssertExpectations(t)
 	})
 
 	t.Run("Verify the error case if product do not exists", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		expectedError := errors.New("product with id 1 not found")
+		expectedError := products.GetErrProductNotFound(1)
 
-		mockedRepository.On("Delete",
-			mock.AnythingOfType("int")).Return(expectedError)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(products.Product{}, expectedError).Once()
 
-		service := products.NewService(mockedRepository, &mockedSeller.Repository{})
-		result := service.Delete(1)
-		assert.NotNil(t, result.Err)
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
+		result := service.Delete(context.Background(), 1)
 
-		assert.Equal(t, result.Code, http.StatusNotFound)
-		assert.Equal(t, result.Err, expectedError)
-		mockedRepository.AssertExpectations(t)
+		assert.Equal(t, http.StatusNotFound, result.Code)
+		assert.Equal(t, expectedError, result.Err)
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("Verify the conflict case if product is still referenced", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(products.Product{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(products.ProductUsage{ProductBatches: 2, ProductRecords: 3}, nil).Once()
+
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
+		result := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusConflict, result.Code)
+		assert.Equal(t, "product with id 1 is used by 2 product_batches and 3 product_records", result.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("Fail when verify product usage", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := errors.New("unexpected error to verify product usage")
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(products.Product{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(products.ProductUsage{}, expectedError).Once()
+
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
+		result := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusInternalServerError, result.Code)
+		assert.Equal(t, expectedError, result.Err)
+	})
+
+	t.Run("Fail when delete product", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := errors.New("unexpected error to delete product")
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(products.Product{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(products.ProductUsage{}, nil).Once()
+		mockedRepository.On("Delete", mock.Anything, 1).Return(expectedError).Once()
+
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
+		result := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusInternalServerError, result.Code)
+		assert.Equal(t, expectedError, result.Err)
 	})
 }
 
@@ -194,11 +297,11 @@ func TestServiceGetAll(t *testing.T) {
 			},
 		}
 
-		mockedRepository.On("GetAll").Return(input, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return(input, nil)
 
-		service := products.NewService(mockedRepository, &mockedSeller.Repository{})
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
 
-		result, err := service.GetAll()
+		result, _, err := service.GetAll(context.Background(), listing.Options{})
 		assert.Nil(t, err.Err)
 
 		assert.Len(t, result, 3)
@@ -207,6 +310,34 @@ func TestServiceGetAll(t *testing.T) {
 	})
 }
 
+func TestServiceValidateCreate(t *testing.T) {
+	t.Run("Test if validate without creating the product", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedSellerRepository := new(mockedSeller.Repository)
+		mockedProductTypeRepository := new(mockedProductType.Repository)
+		minimum := -18.0
+
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]products.Product{}, nil)
+		mockedSellerRepository.On("GetOne", mock.Anything, 1).Return(sellers.Seller{}, nil)
+		mockedProductTypeRepository.On("GetOne", mock.Anything, 7).Return(product_types.ProductType{Id: 7, MinimumTemperature: &minimum}, nil)
+
+		service := products.NewService(mockedRepository, mockedSellerRepository, mockedProductTypeRepository)
+
+		resp := service.ValidateCreate(context.Background(), "FK0003", nil, 7, 1)
+		assert.Nil(t, resp.Err)
+		mockedRepository.AssertNumberOfCalls(t, "Create", 0)
+		mockedProductTypeRepository.AssertExpectations(t)
+	})
+
+	t.Run("Test error case if product_code is empty", func(t *testing.T) {
+		service := products.NewService(&mocks.Repository{}, &mockedSeller.Repository{}, &mockedProductType.Repository{})
+
+		resp := service.ValidateCreate(context.Background(), "", nil, 7, 1)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "empty product_code not allowed", resp.Err.Error())
+	})
+}
+
 func TestServiceGetOne(t *testing.T) {
 	t.Run("Test if product is returned based on valid id", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
@@ -225,11 +356,11 @@ func TestServiceGetOne(t *testing.T) {
 			ProductTypeId:                  7,
 		}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(input, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(input, nil)
 
-		service := products.NewService(mockedRepository, &mockedSeller.Repository{})
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
 
-		result, err := service.GetOne(1)
+		result, err := service.GetOne(context.Background(), 1)
 		assert.Nil(t, err.Err)
 
 		assert.Equal(t, input, result)
@@ -241,10 +372,11 @@ func TestServiceGetOne(t *testing.T) {
 		expectedError := errors.New("product with id 1 not found")
 
 		mockedRepository.On("GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int")).Return(products.Product{}, expectedError)
 
-		service := products.NewService(mockedRepository, &mockedSeller.Repository{})
-		_, err := service.GetOne(1)
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
+		_, err := service.GetOne(context.Background(), 1)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, http.StatusNotFound, err.Code)
@@ -299,18 +431,19 @@ func TestServiceUpdate(t *testing.T) {
 			ProductTypeId:                  7,
 		}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).
 			Return(input, nil)
 
-		mockedRepository.On("GetAll").Return([]products.Product{}, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]products.Product{}, nil)
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(expectedProduct, nil)
 
-		service := products.NewService(mockedRepository, &mockedSeller.Repository{})
-		result, err := service.Update(1, requestData)
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
+		result, err := service.Update(context.Background(), 1, requestData)
 
 		assert.Nil(t, err.Err)
 		assert.Equal(t, expectedProduct, result)
@@ -322,19 +455,20 @@ func TestServiceUpdate(t *testing.T) {
 		expectedError := errors.New("product with id 1 not found")
 		requestData := map[string]interface{}{}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).
 			Return(products.Product{}, expectedError).Once()
 
-		mockedRepository.On("GetAll").
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).
 			Return([]products.Product{}, expectedError).Once()
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(products.Product{}, nil).Once()
 
-		service := products.NewService(mockedRepository, &mockedSeller.Repository{})
-		_, err := service.Update(1, requestData)
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
+		_, err := service.Update(context.Background(), 1, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, http.StatusNotFound, err.Code)
@@ -378,19 +512,112 @@ func TestServiceUpdate(t *testing.T) {
 		expectedError := errors.New("product_code already exists")
 
 		mockedRepository.On("GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int")).Return(products.Product{}, nil).Once()
-		mockedRepository.On("GetAll").Return(input, nil).Once()
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return(input, nil).Once()
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(products.Product{}, expectedError).Once()
 
-		service := products.NewService(mockedRepository, &mockedSeller.Repository{})
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
 
-		_, err := service.Update(input[1].Id, requestData)
+		_, err := service.Update(context.Background(), input[1].Id, requestData)
 		assert.NotNil(t, err.Err)
 
 		assert.Equal(t, expectedError.Error(), err.Err.Error())
 		assert.Equal(t, http.StatusConflict, err.Code)
 	})
 }
+
+func TestServiceSearch(t *testing.T) {
+	fakeSearch := []products.Product{
+		{Id: 1, ProductCode: "FK0001", Description: "Abacaxi"},
+		{Id: 2, ProductCode: "FK0002", Description: "Banana"},
+		{Id: 3, ProductCode: "FK0003", Description: "Caju"},
+	}
+
+	t.Run("Test default sort, order and limit", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("Search", mock.Anything, products.ProductSearchFilter{
+			Sort:  "id",
+			Order: listing.SortAsc,
+			Limit: products.DefaultSearchLimit,
+		}).Return(fakeSearch, nil)
+
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
+
+		page, resp := service.Search(context.Background(), products.ProductSearchFilter{})
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Len(t, page.Products, 3)
+		assert.Empty(t, page.NextCursor)
+		mockedRepository.AssertExpectations(t)
+	})
+
+	t.Run("Test next cursor when there are more rows", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("Search", mock.Anything, mock.AnythingOfType("products.ProductSearchFilter")).Return(fakeSearch, nil)
+
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
+
+		page, resp := service.Search(context.Background(), products.ProductSearchFilter{Sort: "description", Order: listing.SortAsc, Limit: 2})
+		assert.Nil(t, resp.Err)
+		assert.Len(t, page.Products, 2)
+
+		cursor, err := listing.DecodeCursor(page.NextCursor, "description", listing.SortAsc)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, cursor.Id)
+		assert.Equal(t, "Banana", cursor.Value)
+	})
+
+	t.Run("Test cursor is decoded into the filter", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		encoded := listing.EncodeCursor(listing.Cursor{Sort: "id", Order: listing.SortDesc, Id: 2})
+		mockedRepository.On("Search", mock.Anything, products.ProductSearchFilter{
+			Sort:   "id",
+			Order:  listing.SortDesc,
+			Limit:  2,
+			Cursor: encoded,
+			After:  &listing.Cursor{Sort: "id", Order: listing.SortDesc, Id: 2},
+		}).Return(fakeSearch[:1], nil)
+
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
+
+		page, resp := service.Search(context.Background(), products.ProductSearchFilter{Sort: "id", Order: listing.SortDesc, Limit: 2, Cursor: encoded})
+		assert.Nil(t, resp.Err)
+		assert.Len(t, page.Products, 1)
+		mockedRepository.AssertExpectations(t)
+	})
+
+	t.Run("Test invalid parameters", func(t *testing.T) {
+		service := products.NewService(new(mocks.Repository), &mockedSeller.Repository{}, &mockedProductType.Repository{})
+
+		invalidFilters := []products.ProductSearchFilter{
+			{Sort: "unknown"},
+			{Order: "sideways"},
+			{Limit: products.MaxSearchLimit + 1},
+			{Cursor: "not-a-cursor"},
+			{Sort: "description", Cursor: listing.EncodeCursor(listing.Cursor{Sort: "id", Order: listing.SortAsc, Id: 1})},
+		}
+
+		for _, filter := range invalidFilters {
+			_, resp := service.Search(context.Background(), filter)
+			assert.Error(t, resp.Err)
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		}
+	})
+
+	t.Run("Test repository error", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("Search", mock.Anything, mock.AnythingOfType("products.ProductSearchFilter")).
+			Return([]products.Product{}, errors.New("couldn`t search products"))
+
+		service := products.NewService(mockedRepository, &mockedSeller.Repository{}, &mockedProductType.Repository{})
+
+		_, resp := service.Search(context.Background(), products.ProductSearchFilter{})
+		assert.Error(t, resp.Err)
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
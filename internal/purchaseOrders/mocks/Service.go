@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	time "time"
 
 	purchase_orders "github.com/emidioreb/mercado-fresco-lerigophers/internal/purchaseOrders"
@@ -16,20 +17,20 @@ type Service struct {
 	mock.Mock
 }
 
-// CreatePurchaseOrders provides a mock function with given fields: OrderNumber, OrderDate, TrackingCode, BuyerId, ProductRecordId, OrderStatusId
-func (_m *Service) CreatePurchaseOrders(OrderNumber string, OrderDate time.Time, TrackingCode string, BuyerId int, ProductRecordId int, OrderStatusId int) (purchase_orders.PurchaseOrders, web.ResponseCode) {
-	ret := _m.Called(OrderNumber, OrderDate, TrackingCode, BuyerId, ProductRecordId, OrderStatusId)
+// CreatePurchaseOrders provides a mock function with given fields: ctx, OrderNumber, OrderDate, TrackingCode, BuyerId, ProductId, ProductRecordId, OrderStatusId
+func (_m *Service) CreatePurchaseOrders(ctx context.Context, OrderNumber string, OrderDate time.Time, TrackingCode string, BuyerId int, ProductId int, ProductRecordId int, OrderStatusId int) (purchase_orders.PurchaseOrders, web.ResponseCode) {
+	ret := _m.Called(ctx, OrderNumber, OrderDate, TrackingCode, BuyerId, ProductId, ProductRecordId, OrderStatusId)
 
 	var r0 purchase_orders.PurchaseOrders
-	if rf, ok := ret.Get(0).(func(string, time.Time, string, int, int, int) purchase_orders.PurchaseOrders); ok {
-		r0 = rf(OrderNumber, OrderDate, TrackingCode, BuyerId, ProductRecordId, OrderStatusId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, string, int, int, int, int) purchase_orders.PurchaseOrders); ok {
+		r0 = rf(ctx, OrderNumber, OrderDate, TrackingCode, BuyerId, ProductId, ProductRecordId, OrderStatusId)
 	} else {
 		r0 = ret.Get(0).(purchase_orders.PurchaseOrders)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(string, time.Time, string, int, int, int) web.ResponseCode); ok {
-		r1 = rf(OrderNumber, OrderDate, TrackingCode, BuyerId, ProductRecordId, OrderStatusId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, string, int, int, int, int) web.ResponseCode); ok {
+		r1 = rf(ctx, OrderNumber, OrderDate, TrackingCode, BuyerId, ProductId, ProductRecordId, OrderStatusId)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
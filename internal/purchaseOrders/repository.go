@@ -1,22 +1,25 @@
 package purchase_orders
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"time"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 )
 
 type Repository interface {
-	CreatePurchaseOrders(OrderNumber string, OrderDate time.Time, TrackingCode string, BuyerId, ProductRecordId, OrderStatusId int) (PurchaseOrders, error)
+	CreatePurchaseOrders(ctx context.Context, OrderNumber string, OrderDate time.Time, TrackingCode string, BuyerId, ProductRecordId, OrderStatusId int) (PurchaseOrders, error)
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
@@ -24,8 +27,8 @@ var (
 	errCreatePurchaseOrders = errors.New("couldn't create purchase order")
 )
 
-func (mariaDb mariaDbRepository) CreatePurchaseOrders(OrderNumber string, OrderDate time.Time, TrackingCode string, BuyerId, ProductRecordId, OrderStatusId int) (PurchaseOrders, error) {
-	result, err := mariaDb.db.Exec(QueryCreatePurchaseOrder, OrderNumber, OrderDate, TrackingCode, BuyerId, ProductRecordId, OrderStatusId)
+func (mariaDb mariaDbRepository) CreatePurchaseOrders(ctx context.Context, OrderNumber string, OrderDate time.Time, TrackingCode string, BuyerId, ProductRecordId, OrderStatusId int) (PurchaseOrders, error) {
+	result, err := mariaDb.db.ExecContext(ctx, QueryCreatePurchaseOrder, OrderNumber, OrderDate, TrackingCode, BuyerId, ProductRecordId, OrderStatusId)
 	if err != nil {
 		return PurchaseOrders{}, errCreatePurchaseOrders
 	}
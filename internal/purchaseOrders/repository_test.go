@@ -1,6 +1,7 @@
 package purchase_orders_test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 	"time"
@@ -44,6 +45,7 @@ func TestCreate(t *testing.T) {
 		purchaseOrderRepo := purchase_orders.NewMariaDbRepository(db)
 
 		po, err := purchaseOrderRepo.CreatePurchaseOrders(
+			context.Background(),
 			mockPurchaseOrder.OrderNumber,
 			mockPurchaseOrder.OrderDate,
 			mockPurchaseOrder.TrackingCode,
@@ -68,6 +70,7 @@ func TestCreate(t *testing.T) {
 
 		purchaseOrderRepo := purchase_orders.NewMariaDbRepository(db)
 		_, err = purchaseOrderRepo.CreatePurchaseOrders(
+			context.Background(),
 			mockPurchaseOrder.OrderNumber,
 			mockPurchaseOrder.OrderDate,
 			mockPurchaseOrder.TrackingCode,
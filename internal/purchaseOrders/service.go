@@ -1,17 +1,21 @@
 package purchase_orders
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers"
 	order_status "github.com/emidioreb/mercado-fresco-lerigophers/internal/orderStatus"
 	product_records "github.com/emidioreb/mercado-fresco-lerigophers/internal/productRecords"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 )
 
 type Service interface {
-	CreatePurchaseOrders(OrderNumber string, OrderDate time.Time, TrackingCode string, BuyerId, ProductRecordId, OrderStatusId int) (PurchaseOrders, web.ResponseCode)
+	CreatePurchaseOrders(ctx context.Context, OrderNumber string, OrderDate time.Time, TrackingCode string, BuyerId, ProductId, ProductRecordId, OrderStatusId int) (PurchaseOrders, web.ResponseCode)
 }
 
 type service struct {
@@ -19,35 +23,94 @@ type service struct {
 	buyerRepository          buyers.Repository
 	productRecordsRepository product_records.Repository
 	orderStatusRepository    order_status.Repository
+	transactions             transaction.Manager
 }
 
-func NewService(r Repository, br buyers.Repository, prr product_records.Repository, osr order_status.Repository) Service {
+func NewService(r Repository, br buyers.Repository, prr product_records.Repository, osr order_status.Repository, tm transaction.Manager) Service {
 	return &service{
 		repository:               r,
 		buyerRepository:          br,
 		productRecordsRepository: prr,
 		orderStatusRepository:    osr,
+		transactions:             tm,
 	}
 }
 
-func (s service) CreatePurchaseOrders(OrderNumber string, OrderDate time.Time, TrackingCode string, BuyerId, ProductRecordId, OrderStatusId int) (PurchaseOrders, web.ResponseCode) {
+// CreatePurchaseOrders resolves the price of the order from the product and
+// the order date. A product_record_id informed by the client is only accepted
+// when it is the effective record at the order date. The checks and the
+// insert run in one transaction, so the price can not change in between.
+func (s service) CreatePurchaseOrders(ctx context.Context, OrderNumber string, OrderDate time.Time, TrackingCode string, BuyerId, ProductId, ProductRecordId, OrderStatusId int) (PurchaseOrders, web.ResponseCode) {
+	var purchaseOrder PurchaseOrders
+	var resp web.ResponseCode
 
-	_, err := s.buyerRepository.GetOne(BuyerId)
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		purchaseOrder, resp = s.createPurchaseOrders(ctx, OrderNumber, OrderDate, TrackingCode, BuyerId, ProductId, ProductRecordId, OrderStatusId)
+		return resp.Err
+	})
+	if err != nil && resp.Err == nil {
+		return PurchaseOrders{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return purchaseOrder, resp
+}
+
+func (s service) createPurchaseOrders(ctx context.Context, OrderNumber string, OrderDate time.Time, TrackingCode string, BuyerId, ProductId, ProductRecordId, OrderStatusId int) (PurchaseOrders, web.ResponseCode) {
+
+	_, err := s.buyerRepository.GetOne(ctx, BuyerId)
 	if err != nil {
 		return PurchaseOrders{}, web.NewCodeResponse(http.StatusConflict, err)
 	}
 
-	err = s.productRecordsRepository.GetOne(ProductRecordId)
+	var informedRecord product_records.ProductRecords
+	if ProductRecordId != 0 {
+		informedRecord, err = s.productRecordsRepository.GetOne(ctx, ProductRecordId)
+		if err != nil {
+			return PurchaseOrders{}, web.NewCodeResponse(http.StatusConflict, err)
+		}
+
+		if ProductId != 0 && informedRecord.ProductId != ProductId {
+			return PurchaseOrders{}, web.NewCodeResponse(
+				http.StatusConflict,
+				fmt.Errorf("product_record_id %d does not belong to product with id %d", ProductRecordId, ProductId),
+			)
+		}
+
+		ProductId = informedRecord.ProductId
+	}
+
+	if ProductId == 0 {
+		return PurchaseOrders{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("product_id or product_record_id must be informed"))
+	}
+
+	orderDate := OrderDate.Format("2006-01-02")
+	effectiveRecord, err := s.productRecordsRepository.GetEffective(ctx, ProductId, orderDate)
 	if err != nil {
-		return PurchaseOrders{}, web.NewCodeResponse(http.StatusConflict, err)
+		if err.Error() == product_records.GetErrNoEffectiveProductRecord(ProductId, orderDate).Error() {
+			return PurchaseOrders{}, web.NewCodeResponse(http.StatusConflict, err)
+		}
+		return PurchaseOrders{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	if ProductRecordId != 0 && ProductRecordId != effectiveRecord.Id {
+		if informedRecord.LastUpdateDate > orderDate {
+			return PurchaseOrders{}, web.NewCodeResponse(
+				http.StatusConflict,
+				fmt.Errorf("product_record_id %d is a future price for order_date %s", ProductRecordId, orderDate),
+			)
+		}
+		return PurchaseOrders{}, web.NewCodeResponse(
+			http.StatusConflict,
+			fmt.Errorf("product_record_id %d is stale, the effective one for order_date %s is %d", ProductRecordId, orderDate, effectiveRecord.Id),
+		)
 	}
 
-	err = s.orderStatusRepository.GetOne(OrderStatusId)
+	_, err = s.orderStatusRepository.GetOne(ctx, OrderStatusId)
 	if err != nil {
 		return PurchaseOrders{}, web.NewCodeResponse(http.StatusConflict, err)
 	}
 
-	result, err := s.repository.CreatePurchaseOrders(OrderNumber, OrderDate, TrackingCode, BuyerId, ProductRecordId, OrderStatusId)
+	result, err := s.repository.CreatePurchaseOrders(ctx, OrderNumber, OrderDate, TrackingCode, BuyerId, effectiveRecord.Id, OrderStatusId)
 	if err != nil {
 		return PurchaseOrders{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
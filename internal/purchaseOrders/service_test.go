@@ -1,16 +1,20 @@
 package purchase_orders_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers"
 	buyers_mock "github.com/emidioreb/mercado-fresco-lerigophers/internal/buyers/mocks"
+	order_status "github.com/emidioreb/mercado-fresco-lerigophers/internal/orderStatus"
 	order_status_mock "github.com/emidioreb/mercado-fresco-lerigophers/internal/orderStatus/mocks"
+	product_records "github.com/emidioreb/mercado-fresco-lerigophers/internal/productRecords"
 	product_records_mock "github.com/emidioreb/mercado-fresco-lerigophers/internal/productRecords/mocks"
 	purchase_orders "github.com/emidioreb/mercado-fresco-lerigophers/internal/purchaseOrders"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/purchaseOrders/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -31,18 +35,37 @@ var fakePurchaseOrders = []purchase_orders.PurchaseOrders{{
 	OrderStatusId:   2,
 }}
 
+func newServiceMocks() (
+	*mocks.Repository,
+	*buyers_mock.Repository,
+	*product_records_mock.Repository,
+	*order_status_mock.Repository,
+) {
+	return new(mocks.Repository),
+		new(buyers_mock.Repository),
+		new(product_records_mock.Repository),
+		new(order_status_mock.Repository)
+}
+
+var effectiveRecord = product_records.ProductRecords{
+	Id:             1,
+	LastUpdateDate: "2006-01-01",
+	PurchasePrice:  5,
+	SalePrice:      25,
+	ProductId:      7,
+}
+
 func TestServiceCreate(t *testing.T) {
 	t.Run("Test if create successfully", func(t *testing.T) {
-		mockedRepository := new(mocks.Repository)
-		mockedBuyersRepository := new(buyers_mock.Repository)
-		mockedProductRecordsRepository := new(product_records_mock.Repository)
-		mockedOrderStatusRepository := new(order_status_mock.Repository)
+		mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository := newServiceMocks()
 
-		mockedBuyersRepository.On("GetOne", mock.AnythingOfType("int")).Return(buyers.Buyer{}, nil)
-		mockedProductRecordsRepository.On("GetOne", mock.AnythingOfType("int")).Return(nil)
-		mockedOrderStatusRepository.On("GetOne", mock.AnythingOfType("int")).Return(nil)
+		mockedBuyersRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, nil)
+		mockedProductRecordsRepository.On("GetOne", mock.Anything, 1).Return(effectiveRecord, nil)
+		mockedProductRecordsRepository.On("GetEffective", mock.Anything, 7, "2006-01-02").Return(effectiveRecord, nil)
+		mockedOrderStatusRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(order_status.OrderStatus{}, nil)
 
 		mockedRepository.On("CreatePurchaseOrders",
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("time.Time"),
 			mock.AnythingOfType("string"),
@@ -50,13 +73,15 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(fakePurchaseOrders[0], nil)
 
-		service := purchase_orders.NewService(mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository)
+		service := purchase_orders.NewService(mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository, transaction.Nop)
 
 		result, err := service.CreatePurchaseOrders(
+			context.Background(),
 			fakePurchaseOrders[0].OrderNumber,
 			fakePurchaseOrders[0].OrderDate,
 			fakePurchaseOrders[0].TrackingCode,
 			fakePurchaseOrders[0].BuyerId,
+			0,
 			fakePurchaseOrders[0].ProductRecordId,
 			fakePurchaseOrders[0].OrderStatusId)
 		assert.Nil(t, err.Err)
@@ -64,21 +89,53 @@ func TestServiceCreate(t *testing.T) {
 		assert.Equal(t, fakePurchaseOrders[0], result)
 	})
 
+	t.Run("Test if resolves the effective record from product_id", func(t *testing.T) {
+		mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository := newServiceMocks()
+
+		mockedBuyersRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, nil)
+		mockedProductRecordsRepository.On("GetEffective", mock.Anything, 7, "2006-01-02").Return(effectiveRecord, nil)
+		mockedOrderStatusRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(order_status.OrderStatus{}, nil)
+
+		mockedRepository.On("CreatePurchaseOrders",
+			mock.Anything,
+			fakePurchaseOrders[0].OrderNumber,
+			fakePurchaseOrders[0].OrderDate,
+			fakePurchaseOrders[0].TrackingCode,
+			fakePurchaseOrders[0].BuyerId,
+			effectiveRecord.Id,
+			fakePurchaseOrders[0].OrderStatusId).Return(fakePurchaseOrders[0], nil)
+
+		service := purchase_orders.NewService(mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository, transaction.Nop)
+
+		result, resp := service.CreatePurchaseOrders(
+			context.Background(),
+			fakePurchaseOrders[0].OrderNumber,
+			fakePurchaseOrders[0].OrderDate,
+			fakePurchaseOrders[0].TrackingCode,
+			fakePurchaseOrders[0].BuyerId,
+			7,
+			0,
+			fakePurchaseOrders[0].OrderStatusId)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, effectiveRecord.Id, result.ProductRecordId)
+	})
+
 	t.Run("Test conflict if buyer_id do not exist", func(t *testing.T) {
-		mockedRepository := new(mocks.Repository)
-		mockedBuyersRepository := new(buyers_mock.Repository)
-		mockedProductRecordsRepository := new(product_records_mock.Repository)
-		mockedOrderStatusRepository := new(order_status_mock.Repository)
+		mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository := newServiceMocks()
 
 		expectedError := errors.New("some error")
-		mockedBuyersRepository.On("GetOne", mock.AnythingOfType("int")).Return(buyers.Buyer{}, expectedError)
+		mockedBuyersRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, expectedError)
 
-		service := purchase_orders.NewService(mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository)
+		service := purchase_orders.NewService(mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository, transaction.Nop)
 		_, resp := service.CreatePurchaseOrders(
+			context.Background(),
 			fakePurchaseOrders[0].OrderNumber,
 			fakePurchaseOrders[0].OrderDate,
 			fakePurchaseOrders[0].TrackingCode,
 			fakePurchaseOrders[0].BuyerId,
+			0,
 			fakePurchaseOrders[0].ProductRecordId,
 			fakePurchaseOrders[0].OrderStatusId,
 		)
@@ -89,21 +146,20 @@ func TestServiceCreate(t *testing.T) {
 	})
 
 	t.Run("Test conflict if product_records do not exist", func(t *testing.T) {
-		mockedRepository := new(mocks.Repository)
-		mockedBuyersRepository := new(buyers_mock.Repository)
-		mockedProductRecordsRepository := new(product_records_mock.Repository)
-		mockedOrderStatusRepository := new(order_status_mock.Repository)
+		mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository := newServiceMocks()
 
 		expectedError := errors.New("some error")
-		mockedBuyersRepository.On("GetOne", mock.AnythingOfType("int")).Return(buyers.Buyer{}, nil)
-		mockedProductRecordsRepository.On("GetOne", mock.AnythingOfType("int")).Return(expectedError)
+		mockedBuyersRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, nil)
+		mockedProductRecordsRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(product_records.ProductRecords{}, expectedError)
 
-		service := purchase_orders.NewService(mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository)
+		service := purchase_orders.NewService(mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository, transaction.Nop)
 		_, resp := service.CreatePurchaseOrders(
+			context.Background(),
 			fakePurchaseOrders[0].OrderNumber,
 			fakePurchaseOrders[0].OrderDate,
 			fakePurchaseOrders[0].TrackingCode,
 			fakePurchaseOrders[0].BuyerId,
+			0,
 			fakePurchaseOrders[0].ProductRecordId,
 			fakePurchaseOrders[0].OrderStatusId,
 		)
@@ -112,4 +168,91 @@ func TestServiceCreate(t *testing.T) {
 		assert.Equal(t, expectedError, resp.Err)
 		assert.Equal(t, http.StatusConflict, resp.Code)
 	})
+
+	t.Run("Test conflict if product_record belongs to another product", func(t *testing.T) {
+		mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository := newServiceMocks()
+
+		mockedBuyersRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, nil)
+		mockedProductRecordsRepository.On("GetOne", mock.Anything, 1).Return(effectiveRecord, nil)
+
+		service := purchase_orders.NewService(mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository, transaction.Nop)
+		_, resp := service.CreatePurchaseOrders(context.Background(), "#order-1", date, "A1234", 1, 8, 1, 1)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, "product_record_id 1 does not belong to product with id 8", resp.Err.Error())
+	})
+
+	t.Run("Test conflict if product_record is stale", func(t *testing.T) {
+		mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository := newServiceMocks()
+
+		staleRecord := effectiveRecord
+		staleRecord.Id = 3
+		staleRecord.LastUpdateDate = "2005-12-01"
+
+		mockedBuyersRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, nil)
+		mockedProductRecordsRepository.On("GetOne", mock.Anything, 3).Return(staleRecord, nil)
+		mockedProductRecordsRepository.On("GetEffective", mock.Anything, 7, "2006-01-02").Return(effectiveRecord, nil)
+
+		service := purchase_orders.NewService(mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository, transaction.Nop)
+		_, resp := service.CreatePurchaseOrders(context.Background(), "#order-1", date, "A1234", 1, 7, 3, 1)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, "product_record_id 3 is stale, the effective one for order_date 2006-01-02 is 1", resp.Err.Error())
+	})
+
+	t.Run("Test conflict if product_record is in the future", func(t *testing.T) {
+		mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository := newServiceMocks()
+
+		futureRecord := effectiveRecord
+		futureRecord.Id = 4
+		futureRecord.LastUpdateDate = "2006-02-01"
+
+		mockedBuyersRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, nil)
+		mockedProductRecordsRepository.On("GetOne", mock.Anything, 4).Return(futureRecord, nil)
+		mockedProductRecordsRepository.On("GetEffective", mock.Anything, 7, "2006-01-02").Return(effectiveRecord, nil)
+
+		service := purchase_orders.NewService(mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository, transaction.Nop)
+		_, resp := service.CreatePurchaseOrders(context.Background(), "#order-1", date, "A1234", 1, 0, 4, 1)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, "product_record_id 4 is a future price for order_date 2006-01-02", resp.Err.Error())
+	})
+
+	t.Run("Test conflict if there is no effective price", func(t *testing.T) {
+		mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository := newServiceMocks()
+
+		mockedBuyersRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, nil)
+		mockedProductRecordsRepository.On("GetEffective", mock.Anything, 7, "2006-01-02").
+			Return(product_records.ProductRecords{}, product_records.GetErrNoEffectiveProductRecord(7, "2006-01-02"))
+
+		service := purchase_orders.NewService(mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository, transaction.Nop)
+		_, resp := service.CreatePurchaseOrders(context.Background(), "#order-1", date, "A1234", 1, 7, 0, 1)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, "product with id 7 has no product_records effective at 2006-01-02", resp.Err.Error())
+	})
+
+	t.Run("Test error to get effective price", func(t *testing.T) {
+		mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository := newServiceMocks()
+
+		mockedBuyersRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, nil)
+		mockedProductRecordsRepository.On("GetEffective", mock.Anything, 7, "2006-01-02").
+			Return(product_records.ProductRecords{}, errors.New("unexpected error to get effective product_records"))
+
+		service := purchase_orders.NewService(mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository, transaction.Nop)
+		_, resp := service.CreatePurchaseOrders(context.Background(), "#order-1", date, "A1234", 1, 7, 0, 1)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+
+	t.Run("Test if product is required", func(t *testing.T) {
+		mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository := newServiceMocks()
+
+		mockedBuyersRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(buyers.Buyer{}, nil)
+
+		service := purchase_orders.NewService(mockedRepository, mockedBuyersRepository, mockedProductRecordsRepository, mockedOrderStatusRepository, transaction.Nop)
+		_, resp := service.CreatePurchaseOrders(context.Background(), "#order-1", date, "A1234", 1, 0, 0, 1)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
 }
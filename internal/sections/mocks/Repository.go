@@ -3,8 +3,12 @@
 package mocks
 
 import (
-	sections "github.com/emidioreb/mercado-fresco-lerigophers/internal/sections"
+	context "context"
+
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 	mock "github.com/stretchr/testify/mock"
+
+	sections "github.com/emidioreb/mercado-fresco-lerigophers/internal/sections"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -12,20 +16,20 @@ type Repository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId
-func (_m *Repository) Create(sectionNumber int, currentTemperature int, minimumTemperature int, currentCapacity int, mininumCapacity int, maximumCapacity int, warehouseId int, productTypeId int) (sections.Section, error) {
-	ret := _m.Called(sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
+// Create provides a mock function with given fields: ctx, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId
+func (_m *Repository) Create(ctx context.Context, sectionNumber int, currentTemperature int, minimumTemperature int, currentCapacity int, mininumCapacity int, maximumCapacity int, warehouseId int, productTypeId int) (sections.Section, error) {
+	ret := _m.Called(ctx, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
 
 	var r0 sections.Section
-	if rf, ok := ret.Get(0).(func(int, int, int, int, int, int, int, int) sections.Section); ok {
-		r0 = rf(sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int, int, int, int, int) sections.Section); ok {
+		r0 = rf(ctx, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
 	} else {
 		r0 = ret.Get(0).(sections.Section)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int, int, int, int, int, int, int, int) error); ok {
-		r1 = rf(sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, int, int, int, int, int) error); ok {
+		r1 = rf(ctx, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,13 +37,13 @@ func (_m *Repository) Create(sectionNumber int, currentTemperature int, minimumT
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Repository) Delete(id int) error {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Repository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,13 +51,13 @@ func (_m *Repository) Delete(id int) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *Repository) GetAll() ([]sections.Section, error) {
-	ret := _m.Called()
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Repository) GetAll(ctx context.Context, opts listing.Options) ([]sections.Section, error) {
+	ret := _m.Called(ctx, opts)
 
 	var r0 []sections.Section
-	if rf, ok := ret.Get(0).(func() []sections.Section); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []sections.Section); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]sections.Section)
@@ -61,8 +65,8 @@ func (_m *Repository) GetAll() ([]sections.Section, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) error); ok {
+		r1 = rf(ctx, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,20 +74,20 @@ func (_m *Repository) GetAll() ([]sections.Section, error) {
 	return r0, r1
 }
 
-// GetBySectionNumber provides a mock function with given fields: sectionNumber
-func (_m *Repository) GetBySectionNumber(sectionNumber int) (int, error) {
-	ret := _m.Called(sectionNumber)
+// GetBySectionNumber provides a mock function with given fields: ctx, sectionNumber
+func (_m *Repository) GetBySectionNumber(ctx context.Context, sectionNumber int) (int, error) {
+	ret := _m.Called(ctx, sectionNumber)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(int) int); ok {
-		r0 = rf(sectionNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, sectionNumber)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(sectionNumber)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, sectionNumber)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -91,20 +95,20 @@ func (_m *Repository) GetBySectionNumber(sectionNumber int) (int, error) {
 	return r0, r1
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Repository) GetOne(id int) (sections.Section, error) {
-	ret := _m.Called(id)
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Repository) GetOne(ctx context.Context, id int) (sections.Section, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 sections.Section
-	if rf, ok := ret.Get(0).(func(int) sections.Section); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) sections.Section); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(sections.Section)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -112,20 +116,55 @@ func (_m *Repository) GetOne(id int) (sections.Section, error) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, requestData
-func (_m *Repository) Update(id int, requestData map[string]interface{}) (sections.Section, error) {
-	ret := _m.Called(id, requestData)
+// GetUsage provides a mock function with given fields: ctx, id
+func (_m *Repository) GetUsage(ctx context.Context, id int) (sections.SectionUsage, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 sections.SectionUsage
+	if rf, ok := ret.Get(0).(func(context.Context, int) sections.SectionUsage); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(sections.SectionUsage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReassignAndDelete provides a mock function with given fields: ctx, id, targetId
+func (_m *Repository) ReassignAndDelete(ctx context.Context, id int, targetId int) error {
+	ret := _m.Called(ctx, id, targetId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, targetId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Repository) Update(ctx context.Context, id int, requestData map[string]interface{}) (sections.Section, error) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 sections.Section
-	if rf, ok := ret.Get(0).(func(int, map[string]interface{}) sections.Section); ok {
-		r0 = rf(id, requestData)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) sections.Section); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(sections.Section)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int, map[string]interface{}) error); ok {
-		r1 = rf(id, requestData)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) error); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Error(1)
 	}
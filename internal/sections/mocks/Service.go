@@ -3,9 +3,14 @@
 package mocks
 
 import (
+	context "context"
+
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	mock "github.com/stretchr/testify/mock"
+
 	sections "github.com/emidioreb/mercado-fresco-lerigophers/internal/sections"
+
 	web "github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
-	mock "github.com/stretchr/testify/mock"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -13,20 +18,20 @@ type Service struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId
-func (_m *Service) Create(sectionNumber int, currentTemperature int, minimumTemperature int, currentCapacity int, mininumCapacity int, maximumCapacity int, warehouseId int, productTypeId int) (sections.Section, web.ResponseCode) {
-	ret := _m.Called(sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
+// Create provides a mock function with given fields: ctx, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId
+func (_m *Service) Create(ctx context.Context, sectionNumber int, currentTemperature int, minimumTemperature *int, currentCapacity int, mininumCapacity int, maximumCapacity int, warehouseId int, productTypeId int) (sections.Section, web.ResponseCode) {
+	ret := _m.Called(ctx, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
 
 	var r0 sections.Section
-	if rf, ok := ret.Get(0).(func(int, int, int, int, int, int, int, int) sections.Section); ok {
-		r0 = rf(sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *int, int, int, int, int, int) sections.Section); ok {
+		r0 = rf(ctx, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
 	} else {
 		r0 = ret.Get(0).(sections.Section)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int, int, int, int, int, int, int, int) web.ResponseCode); ok {
-		r1 = rf(sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, *int, int, int, int, int, int) web.ResponseCode); ok {
+		r1 = rf(ctx, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -34,13 +39,13 @@ func (_m *Service) Create(sectionNumber int, currentTemperature int, minimumTemp
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Service) Delete(id int) web.ResponseCode {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Service) Delete(ctx context.Context, id int) web.ResponseCode {
+	ret := _m.Called(ctx, id)
 
 	var r0 web.ResponseCode
-	if rf, ok := ret.Get(0).(func(int) web.ResponseCode); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) web.ResponseCode); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(web.ResponseCode)
 	}
@@ -48,43 +53,50 @@ func (_m *Service) Delete(id int) web.ResponseCode {
 	return r0
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *Service) GetAll() ([]sections.Section, web.ResponseCode) {
-	ret := _m.Called()
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Service) GetAll(ctx context.Context, opts listing.Options) ([]sections.Section, listing.Page, web.ResponseCode) {
+	ret := _m.Called(ctx, opts)
 
 	var r0 []sections.Section
-	if rf, ok := ret.Get(0).(func() []sections.Section); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []sections.Section); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]sections.Section)
 		}
 	}
 
-	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func() web.ResponseCode); ok {
-		r1 = rf()
+	var r1 listing.Page
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) listing.Page); ok {
+		r1 = rf(ctx, opts)
 	} else {
-		r1 = ret.Get(1).(web.ResponseCode)
+		r1 = ret.Get(1).(listing.Page)
 	}
 
-	return r0, r1
+	var r2 web.ResponseCode
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Options) web.ResponseCode); ok {
+		r2 = rf(ctx, opts)
+	} else {
+		r2 = ret.Get(2).(web.ResponseCode)
+	}
+
+	return r0, r1, r2
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Service) GetOne(id int) (sections.Section, web.ResponseCode) {
-	ret := _m.Called(id)
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Service) GetOne(ctx context.Context, id int) (sections.Section, web.ResponseCode) {
+	ret := _m.Called(ctx, id)
 
 	var r0 sections.Section
-	if rf, ok := ret.Get(0).(func(int) sections.Section); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) sections.Section); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(sections.Section)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int) web.ResponseCode); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -92,20 +104,34 @@ func (_m *Service) GetOne(id int) (sections.Section, web.ResponseCode) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, requestData
-func (_m *Service) Update(id int, requestData map[string]interface{}) (sections.Section, web.ResponseCode) {
-	ret := _m.Called(id, requestData)
+// ReassignAndDelete provides a mock function with given fields: ctx, id, targetId
+func (_m *Service) ReassignAndDelete(ctx context.Context, id int, targetId int) web.ResponseCode {
+	ret := _m.Called(ctx, id, targetId)
+
+	var r0 web.ResponseCode
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) web.ResponseCode); ok {
+		r0 = rf(ctx, id, targetId)
+	} else {
+		r0 = ret.Get(0).(web.ResponseCode)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Service) Update(ctx context.Context, id int, requestData map[string]interface{}) (sections.Section, web.ResponseCode) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 sections.Section
-	if rf, ok := ret.Get(0).(func(int, map[string]interface{}) sections.Section); ok {
-		r0 = rf(id, requestData)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) sections.Section); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(sections.Section)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int, map[string]interface{}) web.ResponseCode); ok {
-		r1 = rf(id, requestData)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) web.ResponseCode); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
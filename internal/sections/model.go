@@ -1,5 +1,32 @@
 package sections
 
+import "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+
+// ListFields are the fields sections can be filtered and sorted by.
+var ListFields = listing.Fields{
+	Columns: map[string]string{
+		"id":                  "id",
+		"section_number":      "section_number",
+		"current_temperature": "current_temperature",
+		"minimum_temperature": "minimum_temperature",
+		"current_capacity":    "current_capacity",
+		"minimum_capacity":    "minimum_capacity",
+		"maximum_capacity":    "maximum_capacity",
+		"warehouse_id":        "warehouse_id",
+		"product_type_id":     "product_type_id",
+	},
+	Sortable: []string{
+		"section_number",
+		"current_temperature",
+		"minimum_temperature",
+		"current_capacity",
+		"minimum_capacity",
+		"maximum_capacity",
+		"warehouse_id",
+		"product_type_id",
+	},
+}
+
 type Section struct {
 	Id                 int `json:"id"`
 	SectionNumber      int `json:"section_number"`
@@ -11,3 +38,7 @@ type Section struct {
 	WarehouseId        int `json:"warehouse_id"`
 	ProductTypeId      int `json:"product_type_id"`
 }
+
+type SectionUsage struct {
+	ProductBatches int `json:"product_batches"`
+}
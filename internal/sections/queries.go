@@ -3,6 +3,10 @@ package sections
 import "fmt"
 
 var (
+	queryGetSectionUsage = `SELECT COUNT(*) FROM product_batches WHERE section_id = ?`
+
+	queryReassignSectionProductBatches = `UPDATE product_batches SET section_id = ? WHERE section_id = ?`
+
 	queryCreateSection      = "INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
 	queryGetOneSection      = "SELECT * FROM sections WHERE id = ?"
 	queryGetAllSections     = "SELECT * FROM sections"
@@ -1,9 +1,13 @@
 package sections
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 )
 
 var (
@@ -12,6 +16,8 @@ var (
 	errGetSections                = errors.New("couldn't get sections")
 	errGetOneSection              = errors.New("unexpected error to get section")
 	errDeleteSection              = errors.New("unexpected error to delete section")
+	errGetSectionUsage            = errors.New("unexpected error to verify section usage")
+	errReassignSection            = errors.New("unexpected error to reassign section dependents")
 	errVerifySectionNumber        = errors.New("failed to verify if section_number already exists")
 	errSectionNumberAlreadyExists = errors.New("section number already exists")
 )
@@ -21,25 +27,27 @@ func GetErrSectionNotFound(id int) error {
 }
 
 type Repository interface {
-	Create(sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId int) (Section, error)
-	GetOne(id int) (Section, error)
-	GetAll() ([]Section, error)
-	Delete(id int) error
-	Update(id int, requestData map[string]interface{}) (Section, error)
-	GetBySectionNumber(sectionNumber int) (int, error)
+	Create(ctx context.Context, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId int) (Section, error)
+	GetOne(ctx context.Context, id int) (Section, error)
+	GetAll(ctx context.Context, opts listing.Options) ([]Section, error)
+	Delete(ctx context.Context, id int) error
+	GetUsage(ctx context.Context, id int) (SectionUsage, error)
+	ReassignAndDelete(ctx context.Context, id, targetId int) error
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Section, error)
+	GetBySectionNumber(ctx context.Context, sectionNumber int) (int, error)
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
-func (mariaDb mariaDbRepository) Create(sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId int) (Section, error) {
+func (mariaDb mariaDbRepository) Create(ctx context.Context, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId int) (Section, error) {
 	newSection := Section{
 		SectionNumber:      sectionNumber,
 		CurrentTemperature: currentTemperature,
@@ -51,7 +59,8 @@ func (mariaDb mariaDbRepository) Create(sectionNumber, currentTemperature, minim
 		ProductTypeId:      productTypeId,
 	}
 
-	result, err := mariaDb.db.Exec(
+	result, err := mariaDb.db.ExecContext(
+		ctx,
 		queryCreateSection,
 		sectionNumber,
 		currentTemperature,
@@ -77,10 +86,10 @@ func (mariaDb mariaDbRepository) Create(sectionNumber, currentTemperature, minim
 	return newSection, nil
 }
 
-func (mariaDb mariaDbRepository) GetOne(id int) (Section, error) {
+func (mariaDb mariaDbRepository) GetOne(ctx context.Context, id int) (Section, error) {
 	currentSection := Section{}
 
-	row := mariaDb.db.QueryRow(queryGetOneSection, id)
+	row := mariaDb.db.QueryRowContext(ctx, transaction.Lock(ctx, queryGetOneSection), id)
 	err := row.Scan(
 		&currentSection.Id,
 		&currentSection.SectionNumber,
@@ -104,10 +113,11 @@ func (mariaDb mariaDbRepository) GetOne(id int) (Section, error) {
 	return currentSection, nil
 }
 
-func (mariaDb mariaDbRepository) GetAll() ([]Section, error) {
+func (mariaDb mariaDbRepository) GetAll(ctx context.Context, opts listing.Options) ([]Section, error) {
 	sections := []Section{}
 
-	rows, err := mariaDb.db.Query(queryGetAllSections)
+	query, values := listing.Build(queryGetAllSections, ListFields, opts)
+	rows, err := mariaDb.db.QueryContext(ctx, query, values...)
 	if err != nil {
 		return []Section{}, errGetSections
 	}
@@ -130,11 +140,15 @@ func (mariaDb mariaDbRepository) GetAll() ([]Section, error) {
 		sections = append(sections, currentSection)
 	}
 
+	if err := rows.Err(); err != nil {
+		return []Section{}, errGetSections
+	}
+
 	return sections, nil
 
 }
-func (mariaDb mariaDbRepository) Delete(id int) error {
-	_, err := mariaDb.db.Exec(queryDeleteSection, id)
+func (mariaDb mariaDbRepository) Delete(ctx context.Context, id int) error {
+	_, err := mariaDb.db.ExecContext(ctx, queryDeleteSection, id)
 	if err != nil {
 		return errDeleteSection
 	}
@@ -142,10 +156,10 @@ func (mariaDb mariaDbRepository) Delete(id int) error {
 	return nil
 }
 
-func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface{}) (Section, error) {
+func (mariaDb mariaDbRepository) Update(ctx context.Context, id int, requestData map[string]interface{}) (Section, error) {
 	finalQuery, valuesToUse := queryUpdateSection(requestData, id)
 
-	result, err := mariaDb.db.Exec(finalQuery, valuesToUse...)
+	result, err := mariaDb.db.ExecContext(ctx, finalQuery, valuesToUse...)
 	if err != nil {
 		return Section{}, errUpdatedSection
 	}
@@ -155,7 +169,7 @@ func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface
 		return Section{}, errUpdatedSection
 	}
 
-	currentSection, err := mariaDb.GetOne(id)
+	currentSection, err := mariaDb.GetOne(ctx, id)
 	if err != nil {
 		return Section{}, errUpdatedSection
 	}
@@ -163,10 +177,10 @@ func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface
 	return currentSection, nil
 }
 
-func (mariaDb mariaDbRepository) GetBySectionNumber(sectionNumber int) (int, error) {
+func (mariaDb mariaDbRepository) GetBySectionNumber(ctx context.Context, sectionNumber int) (int, error) {
 	var selectedSectionNumber, selectedId int
 
-	row := mariaDb.db.QueryRow(queryValidSectionNumber, sectionNumber)
+	row := mariaDb.db.QueryRowContext(ctx, transaction.Lock(ctx, queryValidSectionNumber), sectionNumber)
 	err := row.Scan(&selectedId, &selectedSectionNumber)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -179,3 +193,35 @@ func (mariaDb mariaDbRepository) GetBySectionNumber(sectionNumber int) (int, err
 
 	return selectedId, errSectionNumberAlreadyExists
 }
+
+func (mariaDb mariaDbRepository) GetUsage(ctx context.Context, id int) (SectionUsage, error) {
+	var usage SectionUsage
+
+	row := mariaDb.db.QueryRowContext(ctx, queryGetSectionUsage, id)
+	if err := row.Scan(&usage.ProductBatches); err != nil {
+		return SectionUsage{}, errGetSectionUsage
+	}
+
+	return usage, nil
+}
+
+// ReassignAndDelete moves the product_batches of a section to targetId
+// and deletes the section in a single transaction.
+func (mariaDb mariaDbRepository) ReassignAndDelete(ctx context.Context, id, targetId int) error {
+	err := mariaDb.db.Run(ctx, func(ctx context.Context) error {
+		if _, err := mariaDb.db.ExecContext(ctx, queryReassignSectionProductBatches, targetId, id); err != nil {
+			return errReassignSection
+		}
+
+		if _, err := mariaDb.db.ExecContext(ctx, queryDeleteSection, id); err != nil {
+			return errDeleteSection
+		}
+
+		return nil
+	})
+	if err != nil && err != errDeleteSection {
+		return errReassignSection
+	}
+
+	return err
+}
@@ -1,6 +1,7 @@
 package sections
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"regexp"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
 )
 
 func TestDBCreateSection(t *testing.T) {
@@ -31,6 +34,7 @@ func TestDBCreateSection(t *testing.T) {
 		sectionsRepo := NewMariaDbRepository(db)
 
 		section, err := sectionsRepo.Create(
+			context.Background(),
 			1,
 			10,
 			2,
@@ -53,7 +57,7 @@ func TestDBCreateSection(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryCreateSection)).WillReturnError(errors.New("internal db error"))
 		sectionsRepo := NewMariaDbRepository(db)
 
-		_, err = sectionsRepo.Create(0, 0, 0, 0, 0, 0, 0, 0)
+		_, err = sectionsRepo.Create(context.Background(), 0, 0, 0, 0, 0, 0, 0, 0)
 		assert.Error(t, err)
 	})
 
@@ -68,7 +72,7 @@ func TestDBCreateSection(t *testing.T) {
 			WillReturnResult(sqlDriverResultErr)
 
 		sectionsRepo := NewMariaDbRepository(db)
-		_, err = sectionsRepo.Create(0, 0, 0, 0, 0, 0, 0, 0)
+		_, err = sectionsRepo.Create(context.Background(), 0, 0, 0, 0, 0, 0, 0, 0)
 
 		assert.Error(t, err)
 		assert.Equal(t, "ocurred an error to create section", err.Error())
@@ -97,7 +101,7 @@ func TestDBGetOneSection(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneSection)).WillReturnRows(rows)
 
 		sectionsRepo := NewMariaDbRepository(db)
-		section, err := sectionsRepo.GetOne(1)
+		section, err := sectionsRepo.GetOne(context.Background(), 1)
 		assert.NoError(t, err)
 
 		assert.Equal(t, 10, section.CurrentTemperature)
@@ -111,7 +115,7 @@ func TestDBGetOneSection(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneSection)).WillReturnError(sql.ErrNoRows)
 
 		sectionsRepo := NewMariaDbRepository(db)
-		_, err = sectionsRepo.GetOne(1)
+		_, err = sectionsRepo.GetOne(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "section with id 1 not found", err.Error())
 	})
@@ -125,7 +129,7 @@ func TestDBGetOneSection(t *testing.T) {
 			WillReturnError(errors.New("unexpected error to get section"))
 
 		sectionsRepo := NewMariaDbRepository(db)
-		_, err = sectionsRepo.GetOne(1)
+		_, err = sectionsRepo.GetOne(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "unexpected error to get section", err.Error())
 	})
@@ -155,7 +159,7 @@ func TestDBGetAllSections(t *testing.T) {
 
 		sectionsRepo := NewMariaDbRepository(db)
 
-		sectionReports, err := sectionsRepo.GetAll()
+		sectionReports, err := sectionsRepo.GetAll(context.Background(), listing.Options{})
 		assert.NoError(t, err)
 
 		assert.Len(t, sectionReports, 3)
@@ -185,7 +189,7 @@ func TestDBGetAllSections(t *testing.T) {
 
 		sectionsRepo := NewMariaDbRepository(db)
 
-		_, err = sectionsRepo.GetAll()
+		_, err = sectionsRepo.GetAll(context.Background(), listing.Options{})
 		assert.Error(t, err)
 		assert.Equal(t, "couldn't get sections", err.Error())
 	})
@@ -200,7 +204,7 @@ func TestDBGetAllSections(t *testing.T) {
 
 		sectionsRepo := NewMariaDbRepository(db)
 
-		_, err = sectionsRepo.GetAll()
+		_, err = sectionsRepo.GetAll(context.Background(), listing.Options{})
 		assert.Error(t, err)
 	})
 }
@@ -216,7 +220,7 @@ func TestDBDeleteSection(t *testing.T) {
 			WillReturnResult(affectedRowsResult)
 
 		sectionsRepo := NewMariaDbRepository(db)
-		err = sectionsRepo.Delete(1)
+		err = sectionsRepo.Delete(context.Background(), 1)
 		assert.NoError(t, err)
 	})
 
@@ -229,7 +233,7 @@ func TestDBDeleteSection(t *testing.T) {
 			WillReturnError(errors.New("any error"))
 
 		sectionsRepo := NewMariaDbRepository(db)
-		err = sectionsRepo.Delete(1)
+		err = sectionsRepo.Delete(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, errDeleteSection, err)
 	})
@@ -295,7 +299,7 @@ func TestDBUpdateSection(t *testing.T) {
 
 		sectionsRepo := NewMariaDbRepository(db)
 
-		section, err := sectionsRepo.Update(1, requestData)
+		section, err := sectionsRepo.Update(context.Background(), 1, requestData)
 		assert.Nil(t, err)
 		assert.Equal(t, 15, section.CurrentTemperature)
 	})
@@ -310,8 +314,90 @@ func TestDBUpdateSection(t *testing.T) {
 
 		sectionsRepo := NewMariaDbRepository(db)
 
-		_, err = sectionsRepo.Update(1, requestData)
+		_, err = sectionsRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errUpdatedSection, err)
 	})
 }
+
+func TestGetUsage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"product_batches"}).AddRow(1)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetSectionUsage)).WithArgs(1).WillReturnRows(rows)
+
+		sectionsRepo := NewMariaDbRepository(db)
+
+		usage, err := sectionsRepo.GetUsage(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, SectionUsage{ProductBatches: 1}, usage)
+	})
+
+	t.Run("fail to scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetSectionUsage)).WillReturnError(sql.ErrConnDone)
+
+		sectionsRepo := NewMariaDbRepository(db)
+
+		_, err = sectionsRepo.GetUsage(context.Background(), 1)
+		assert.Equal(t, errGetSectionUsage, err)
+	})
+}
+
+func TestReassignAndDelete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(queryReassignSectionProductBatches)).WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteSection)).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		sectionsRepo := NewMariaDbRepository(db)
+
+		err = sectionsRepo.ReassignAndDelete(context.Background(), 1, 2)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback when reassign fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(queryReassignSectionProductBatches)).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		sectionsRepo := NewMariaDbRepository(db)
+
+		err = sectionsRepo.ReassignAndDelete(context.Background(), 1, 2)
+		assert.Equal(t, errReassignSection, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback when delete fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(queryReassignSectionProductBatches)).WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteSection)).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		sectionsRepo := NewMariaDbRepository(db)
+
+		err = sectionsRepo.ReassignAndDelete(context.Background(), 1, 2)
+		assert.Equal(t, errDeleteSection, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -1,51 +1,87 @@
 package sections
 
 import (
+	"context"
+	"fmt"
+	"math"
 	"net/http"
 
 	product_types "github.com/emidioreb/mercado-fresco-lerigophers/internal/productTypes"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 )
 
 type Service interface {
-	Create(sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId int) (Section, web.ResponseCode)
-	GetOne(id int) (Section, web.ResponseCode)
-	GetAll() ([]Section, web.ResponseCode)
-	Delete(id int) web.ResponseCode
-	Update(id int, requestData map[string]interface{}) (Section, web.ResponseCode)
+	Create(ctx context.Context, sectionNumber, currentTemperature int, minimumTemperature *int, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId int) (Section, web.ResponseCode)
+	GetOne(ctx context.Context, id int) (Section, web.ResponseCode)
+	GetAll(ctx context.Context, opts listing.Options) ([]Section, listing.Page, web.ResponseCode)
+	Delete(ctx context.Context, id int) web.ResponseCode
+	ReassignAndDelete(ctx context.Context, id, targetId int) web.ResponseCode
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Section, web.ResponseCode)
 }
 
 type service struct {
 	repository            Repository
 	warehouseRepository   warehouses.Repository
 	productTypeRepository product_types.Repository
+	transactions          transaction.Manager
 }
 
-func NewService(r Repository, wr warehouses.Repository, pr product_types.Repository) Service {
+func NewService(r Repository, wr warehouses.Repository, pr product_types.Repository, tm transaction.Manager) Service {
 	return &service{
 		repository:            r,
 		warehouseRepository:   wr,
 		productTypeRepository: pr,
+		transactions:          tm,
 	}
 }
 
-func (s service) Create(sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId int) (Section, web.ResponseCode) {
-	if _, err := s.repository.GetBySectionNumber(sectionNumber); err != nil {
+// Create inherits the minimum_temperature from the product_type default range
+// when it is not informed, and refuses it when the product_type has no range
+// either. The checks and the insert run in one transaction.
+func (s service) Create(ctx context.Context, sectionNumber, currentTemperature int, minimumTemperature *int, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId int) (Section, web.ResponseCode) {
+	var section Section
+	var resp web.ResponseCode
+
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		section, resp = s.create(ctx, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
+		return resp.Err
+	})
+	if err != nil && resp.Err == nil {
+		return Section{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return section, resp
+}
+
+func (s service) create(ctx context.Context, sectionNumber, currentTemperature int, minimumTemperature *int, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId int) (Section, web.ResponseCode) {
+	if _, err := s.repository.GetBySectionNumber(ctx, sectionNumber); err != nil {
 		return Section{}, web.NewCodeResponse(http.StatusConflict, err)
 	}
 
-	_, err := s.warehouseRepository.GetOne(warehouseId)
+	_, err := s.warehouseRepository.GetOne(ctx, warehouseId)
 	if err != nil {
 		return Section{}, web.NewCodeResponse(http.StatusConflict, err)
 	}
 
-	err = s.productTypeRepository.GetOne(productTypeId)
+	productType, err := s.productTypeRepository.GetOne(ctx, productTypeId)
 	if err != nil {
 		return Section{}, web.NewCodeResponse(http.StatusConflict, err)
 	}
 
-	section, err := s.repository.Create(sectionNumber, currentTemperature, minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
+	if minimumTemperature == nil {
+		if productType.MinimumTemperature == nil {
+			return Section{}, web.NewCodeResponse(http.StatusUnprocessableEntity, fmt.Errorf(
+				"minimum_temperature is required, product_type with id %d has no temperature range", productTypeId,
+			))
+		}
+		inherited := int(math.Round(*productType.MinimumTemperature))
+		minimumTemperature = &inherited
+	}
+
+	section, err := s.repository.Create(ctx, sectionNumber, currentTemperature, *minimumTemperature, currentCapacity, mininumCapacity, maximumCapacity, warehouseId, productTypeId)
 	if err != nil {
 		return Section{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
@@ -53,8 +89,8 @@ func (s service) Create(sectionNumber, currentTemperature, minimumTemperature, c
 	return section, web.NewCodeResponse(http.StatusCreated, nil)
 }
 
-func (s service) GetOne(id int) (Section, web.ResponseCode) {
-	section, err := s.repository.GetOne(id)
+func (s service) GetOne(ctx context.Context, id int) (Section, web.ResponseCode) {
+	section, err := s.repository.GetOne(ctx, id)
 
 	if err != nil {
 		return Section{}, web.NewCodeResponse(http.StatusNotFound, err)
@@ -63,58 +99,106 @@ func (s service) GetOne(id int) (Section, web.ResponseCode) {
 	return section, web.NewCodeResponse(http.StatusOK, nil)
 }
 
-func (s service) GetAll() ([]Section, web.ResponseCode) {
-	sections, err := s.repository.GetAll()
+func (s service) GetAll(ctx context.Context, opts listing.Options) ([]Section, listing.Page, web.ResponseCode) {
+	sections, err := s.repository.GetAll(ctx, opts)
 
 	if err != nil {
-		return []Section{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+		return []Section{}, listing.Page{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
 
-	return sections, web.NewCodeResponse(http.StatusOK, nil)
+	sections, page := listing.Paginate(sections, opts)
+	return sections, page, web.NewCodeResponse(http.StatusOK, nil)
 }
 
-func (s service) Delete(id int) web.ResponseCode {
-	_, err := s.repository.GetOne(id)
-	if err != nil {
-		return web.NewCodeResponse(http.StatusNotFound, err)
+// Delete refuses to remove a section still referenced by its product_batches.
+func (s service) Delete(ctx context.Context, id int) web.ResponseCode {
+	if _, resp := s.find(ctx, id, http.StatusNotFound); resp.Err != nil {
+		return resp
 	}
 
-	err = s.repository.Delete(id)
+	usage, err := s.repository.GetUsage(ctx, id)
 	if err != nil {
 		return web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
 
+	if resp := web.ReferencedBy("section", id,
+		web.Reference{Table: "product_batches", Count: usage.ProductBatches},
+	); resp.Err != nil {
+		return resp
+	}
+
+	if err := s.repository.Delete(ctx, id); err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
 	return web.NewCodeResponse(http.StatusNoContent, nil)
 }
 
-func (s service) Update(id int, requestData map[string]interface{}) (Section, web.ResponseCode) {
-	_, responseCode := s.GetOne(id)
+func (s service) ReassignAndDelete(ctx context.Context, id, targetId int) web.ResponseCode {
+	section, resp := s.find(ctx, id, http.StatusNotFound)
+	if resp.Err != nil {
+		return resp
+	}
+
+	target, resp := s.find(ctx, targetId, http.StatusConflict)
+	if resp.Err != nil {
+		return resp
+	}
+
+	if target.WarehouseId != section.WarehouseId {
+		return web.NewCodeResponse(http.StatusConflict, fmt.Errorf(
+			"section with id %d is not in warehouse %d of the deleted section",
+			targetId, section.WarehouseId,
+		))
+	}
+
+	if err := s.repository.ReassignAndDelete(ctx, id, targetId); err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return web.NewCodeResponse(http.StatusNoContent, nil)
+}
+
+func (s service) find(ctx context.Context, id, notFoundCode int) (Section, web.ResponseCode) {
+	section, err := s.repository.GetOne(ctx, id)
+	if err == nil {
+		return section, web.ResponseCode{}
+	}
+
+	if err.Error() == GetErrSectionNotFound(id).Error() {
+		return Section{}, web.NewCodeResponse(notFoundCode, err)
+	}
+	return Section{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+}
+
+func (s service) Update(ctx context.Context, id int, requestData map[string]interface{}) (Section, web.ResponseCode) {
+	_, responseCode := s.GetOne(ctx, id)
 
 	if responseCode.Err != nil {
 		return Section{}, responseCode
 	}
 
 	if sectionNumberReqData := requestData["section_number"]; sectionNumberReqData != nil {
-		if selectedId, err := s.repository.GetBySectionNumber(int(sectionNumberReqData.(float64))); err != nil && id != selectedId {
+		if selectedId, err := s.repository.GetBySectionNumber(ctx, int(sectionNumberReqData.(float64))); err != nil && id != selectedId {
 			return Section{}, web.NewCodeResponse(http.StatusConflict, err)
 		}
 	}
 
 	if warehouseId := requestData["warehouse_id"]; warehouseId != nil {
-		_, err := s.warehouseRepository.GetOne(int(warehouseId.(float64)))
+		_, err := s.warehouseRepository.GetOne(ctx, int(warehouseId.(float64)))
 		if err != nil {
 			return Section{}, web.NewCodeResponse(http.StatusConflict, err)
 		}
 	}
 
 	if productTypeId := requestData["product_type_id"]; productTypeId != nil {
-		err := s.productTypeRepository.GetOne(int(productTypeId.(float64)))
+		_, err := s.productTypeRepository.GetOne(ctx, int(productTypeId.(float64)))
 		if err != nil {
 			return Section{}, web.NewCodeResponse(http.StatusConflict, err)
 		}
 	}
 
-	section, err := s.repository.Update(id, requestData)
+	section, err := s.repository.Update(ctx, id, requestData)
 	if err != nil {
 		return Section{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
@@ -1,12 +1,15 @@
 package sections_test
 
 import (
+	"context"
 	"errors"
 
+	"fmt"
 	"net/http"
 
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sections"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses"
 
@@ -14,7 +17,10 @@ import (
 
 	warehouses_mock "github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses/mocks"
 
+	product_types "github.com/emidioreb/mercado-fresco-lerigophers/internal/productTypes"
 	product_types_mock "github.com/emidioreb/mercado-fresco-lerigophers/internal/productTypes/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 
 	"github.com/stretchr/testify/assert"
 
@@ -70,11 +76,12 @@ func TestServiceCreate(t *testing.T) {
 
 		input := inputSections[0]
 
-		mockedRepository.On("GetBySectionNumber", mock.AnythingOfType("int")).Return(0, nil)
-		mockedWarehouseRepository.On("GetOne", mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, nil)
-		mockedProductTypesRepository.On("GetOne", mock.AnythingOfType("int")).Return(nil)
+		mockedRepository.On("GetBySectionNumber", mock.Anything, mock.AnythingOfType("int")).Return(0, nil)
+		mockedWarehouseRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, nil)
+		mockedProductTypesRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(product_types.ProductType{}, nil)
 
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -85,9 +92,9 @@ func TestServiceCreate(t *testing.T) {
 			mock.AnythingOfType("int"),
 		).Return(input, nil)
 
-		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository)
+		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository, transaction.Nop)
 
-		result, err := service.Create(input.SectionNumber, input.CurrentTemperature, input.MinimumTemperature, input.CurrentCapacity, input.MininumCapacity, input.MaximumCapacity, input.WarehouseId, input.ProductTypeId)
+		result, err := service.Create(context.Background(), input.SectionNumber, input.CurrentTemperature, &input.MinimumTemperature, input.CurrentCapacity, input.MininumCapacity, input.MaximumCapacity, input.WarehouseId, input.ProductTypeId)
 		assert.Nil(t, err.Err)
 
 		assert.Equal(t, input, result)
@@ -95,6 +102,59 @@ func TestServiceCreate(t *testing.T) {
 		mockedRepository.AssertExpectations(t)
 	})
 
+	t.Run("Test if inherits minimum_temperature from product_type", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedWarehouseRepository := new(warehouses_mock.Repository)
+		mockedProductTypesRepository := new(product_types_mock.Repository)
+
+		input := inputSections[0]
+		minimumTemperature := -17.6
+
+		mockedRepository.On("GetBySectionNumber", mock.Anything, mock.AnythingOfType("int")).Return(0, nil)
+		mockedWarehouseRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, nil)
+		mockedProductTypesRepository.On("GetOne", mock.Anything, input.ProductTypeId).
+			Return(product_types.ProductType{Id: input.ProductTypeId, MinimumTemperature: &minimumTemperature}, nil)
+
+		mockedRepository.On("Create",
+			mock.Anything,
+			input.SectionNumber,
+			input.CurrentTemperature,
+			-18,
+			input.CurrentCapacity,
+			input.MininumCapacity,
+			input.MaximumCapacity,
+			input.WarehouseId,
+			input.ProductTypeId,
+		).Return(input, nil)
+
+		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository, transaction.Nop)
+
+		_, err := service.Create(context.Background(), input.SectionNumber, input.CurrentTemperature, nil, input.CurrentCapacity, input.MininumCapacity, input.MaximumCapacity, input.WarehouseId, input.ProductTypeId)
+		assert.Nil(t, err.Err)
+
+		mockedRepository.AssertExpectations(t)
+	})
+
+	t.Run("Test if requires minimum_temperature when product_type has no range", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedWarehouseRepository := new(warehouses_mock.Repository)
+		mockedProductTypesRepository := new(product_types_mock.Repository)
+
+		input := inputSections[0]
+
+		mockedRepository.On("GetBySectionNumber", mock.Anything, mock.AnythingOfType("int")).Return(0, nil)
+		mockedWarehouseRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, nil)
+		mockedProductTypesRepository.On("GetOne", mock.Anything, input.ProductTypeId).
+			Return(product_types.ProductType{Id: input.ProductTypeId}, nil)
+
+		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository, transaction.Nop)
+
+		_, err := service.Create(context.Background(), input.SectionNumber, input.CurrentTemperature, nil, input.CurrentCapacity, input.MininumCapacity, input.MaximumCapacity, input.WarehouseId, input.ProductTypeId)
+		assert.Equal(t, http.StatusUnprocessableEntity, err.Code)
+		assert.Equal(t, fmt.Sprintf("minimum_temperature is required, product_type with id %d has no temperature range", input.ProductTypeId), err.Err.Error())
+		mockedRepository.AssertNotCalled(t, "Create")
+	})
+
 	t.Run("Test error case if section Section Number already exists", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		mockedWarehouseRepository := new(warehouses_mock.Repository)
@@ -106,11 +166,11 @@ func TestServiceCreate(t *testing.T) {
 		listSections := []sections.Section{}
 		listSections = append(listSections, input)
 
-		mockedRepository.On("GetBySectionNumber", mock.AnythingOfType("int")).Return(0, errors.New("error GetBySectionNumber"))
+		mockedRepository.On("GetBySectionNumber", mock.Anything, mock.AnythingOfType("int")).Return(0, errors.New("error GetBySectionNumber"))
 
-		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository)
+		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository, transaction.Nop)
 
-		_, err := service.Create(input.SectionNumber, input.CurrentTemperature, input.MinimumTemperature, input.CurrentCapacity, input.MininumCapacity, input.MaximumCapacity, input.WarehouseId, input.ProductTypeId)
+		_, err := service.Create(context.Background(), input.SectionNumber, input.CurrentTemperature, &input.MinimumTemperature, input.CurrentCapacity, input.MininumCapacity, input.MaximumCapacity, input.WarehouseId, input.ProductTypeId)
 
 		assert.Error(t, err.Err)
 
@@ -119,49 +179,191 @@ func TestServiceCreate(t *testing.T) {
 		assert.Equal(t, err.Code, http.StatusConflict)
 	})
 
-}
+	t.Run("Test if create rolls back the transaction on conflict", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
 
-func TestServiceDelete(t *testing.T) {
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		mockedRepository := new(mocks.Repository)
+		input := inputSections[0]
+
+		mockedRepository.On("GetBySectionNumber", mock.Anything, input.SectionNumber).Return(0, errors.New("section with section_number 10 already exists"))
+
+		service := sections.NewService(mockedRepository, new(warehouses_mock.Repository), new(product_types_mock.Repository), transaction.NewDB(db))
+
+		_, resp := service.Create(context.Background(), input.SectionNumber, input.CurrentTemperature, &input.MinimumTemperature, input.CurrentCapacity, input.MininumCapacity, input.MaximumCapacity, input.WarehouseId, input.ProductTypeId)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Test if create fails when the transaction can not commit", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit().WillReturnError(errors.New("deadlock found"))
 
-	t.Run("Verify the successfully case if the section is deleted", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		mockedWarehouseRepository := new(warehouses_mock.Repository)
 		mockedProductTypesRepository := new(product_types_mock.Repository)
+		input := inputSections[0]
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(sections.Section{}, nil)
-		mockedRepository.On("Delete", mock.AnythingOfType("int")).Return(nil)
+		mockedRepository.On("GetBySectionNumber", mock.Anything, mock.AnythingOfType("int")).Return(0, nil)
+		mockedWarehouseRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, nil)
+		mockedProductTypesRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(product_types.ProductType{}, nil)
+		mockedRepository.On("Create", mock.Anything, input.SectionNumber, input.CurrentTemperature, input.MinimumTemperature, input.CurrentCapacity, input.MininumCapacity, input.MaximumCapacity, input.WarehouseId, input.ProductTypeId).
+			Return(input, nil)
 
-		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository)
+		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository, transaction.NewDB(db))
 
-		result := service.Delete(1)
+		result, resp := service.Create(context.Background(), input.SectionNumber, input.CurrentTemperature, &input.MinimumTemperature, input.CurrentCapacity, input.MininumCapacity, input.MaximumCapacity, input.WarehouseId, input.ProductTypeId)
 
-		assert.Nil(t, result.Err)
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		assert.EqualError(t, resp.Err, "deadlock found")
+		assert.Equal(t, sections.Section{}, result)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
 
-		assert.Equal(t, result.Code, http.StatusNoContent)
+}
 
-		mockedRepository.AssertExpectations(t)
+func TestServiceDelete(t *testing.T) {
+	t.Run("Verify the successfully case if the section is deleted", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sections.Section{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(sections.SectionUsage{}, nil).Once()
+		mockedRepository.On("Delete", mock.Anything, 1).Return(nil).Once()
+
+		service := sections.NewService(mockedRepository, new(warehouses_mock.Repository), new(product_types_mock.Repository), transaction.Nop)
+		result := service.Delete(context.Background(), 1)
+		assert.Nil(t, result.Err)
 
+		assert.Equal(t, http.StatusNoContent, result.Code)
+		mockedRepository.AssertExpectations(t)
 	})
 
 	t.Run("Verify the error case if section do not exists", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		mockedWarehouseRepository := new(warehouses_mock.Repository)
-		mockedProductTypesRepository := new(product_types_mock.Repository)
-		expectedError := errNotFound
+		expectedError := sections.GetErrSectionNotFound(1)
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(sections.Section{}, expectedError)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sections.Section{}, expectedError).Once()
 
-		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository)
+		service := sections.NewService(mockedRepository, new(warehouses_mock.Repository), new(product_types_mock.Repository), transaction.Nop)
+		result := service.Delete(context.Background(), 1)
 
-		result := service.Delete(1)
+		assert.Equal(t, http.StatusNotFound, result.Code)
+		assert.Equal(t, expectedError, result.Err)
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
 
-		assert.NotNil(t, result.Err)
+	t.Run("Verify the conflict case if section is still referenced", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sections.Section{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(sections.SectionUsage{ProductBatches: 5}, nil).Once()
+
+		service := sections.NewService(mockedRepository, new(warehouses_mock.Repository), new(product_types_mock.Repository), transaction.Nop)
+		result := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusConflict, result.Code)
+		assert.Equal(t, "section with id 1 is used by 5 product_batches", result.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("Fail when verify section usage", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := errors.New("unexpected error to verify section usage")
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sections.Section{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(sections.SectionUsage{}, expectedError).Once()
+
+		service := sections.NewService(mockedRepository, new(warehouses_mock.Repository), new(product_types_mock.Repository), transaction.Nop)
+		result := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusInternalServerError, result.Code)
+		assert.Equal(t, expectedError, result.Err)
+	})
+
+	t.Run("Fail when delete section", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := errors.New("unexpected error to delete section")
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sections.Section{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(sections.SectionUsage{}, nil).Once()
+		mockedRepository.On("Delete", mock.Anything, 1).Return(expectedError).Once()
+
+		service := sections.NewService(mockedRepository, new(warehouses_mock.Repository), new(product_types_mock.Repository), transaction.Nop)
+		result := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusInternalServerError, result.Code)
 		assert.Equal(t, expectedError, result.Err)
-		assert.Equal(t, result.Code, http.StatusNotFound)
+	})
+}
 
+func TestServiceReassignAndDelete(t *testing.T) {
+	t.Run("Verify the successfully case if the dependents are moved", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sections.Section{Id: 1, WarehouseId: 3}, nil).Once()
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(sections.Section{Id: 2, WarehouseId: 3}, nil).Once()
+		mockedRepository.On("ReassignAndDelete", mock.Anything, 1, 2).Return(nil).Once()
+
+		service := sections.NewService(mockedRepository, new(warehouses_mock.Repository), new(product_types_mock.Repository), transaction.Nop)
+		result := service.ReassignAndDelete(context.Background(), 1, 2)
+		assert.Nil(t, result.Err)
+
+		assert.Equal(t, http.StatusNoContent, result.Code)
 		mockedRepository.AssertExpectations(t)
 	})
 
+	t.Run("Verify the conflict case if target is in another warehouse", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sections.Section{Id: 1, WarehouseId: 3}, nil).Once()
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(sections.Section{Id: 2, WarehouseId: 4}, nil).Once()
+
+		service := sections.NewService(mockedRepository, new(warehouses_mock.Repository), new(product_types_mock.Repository), transaction.Nop)
+		result := service.ReassignAndDelete(context.Background(), 1, 2)
+
+		assert.Equal(t, http.StatusConflict, result.Code)
+		assert.Equal(t, "section with id 2 is not in warehouse 3 of the deleted section", result.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "ReassignAndDelete", 0)
+	})
+
+	t.Run("Verify the conflict case if target do not exists", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := sections.GetErrSectionNotFound(2)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sections.Section{Id: 1}, nil).Once()
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(sections.Section{}, expectedError).Once()
+
+		service := sections.NewService(mockedRepository, new(warehouses_mock.Repository), new(product_types_mock.Repository), transaction.Nop)
+		result := service.ReassignAndDelete(context.Background(), 1, 2)
+
+		assert.Equal(t, http.StatusConflict, result.Code)
+		assert.Equal(t, expectedError, result.Err)
+		mockedRepository.AssertNumberOfCalls(t, "ReassignAndDelete", 0)
+	})
+
+	t.Run("Fail when reassign section dependents", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := errors.New("unexpected error to reassign section dependents")
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sections.Section{Id: 1}, nil).Once()
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(sections.Section{Id: 2}, nil).Once()
+		mockedRepository.On("ReassignAndDelete", mock.Anything, 1, 2).Return(expectedError).Once()
+
+		service := sections.NewService(mockedRepository, new(warehouses_mock.Repository), new(product_types_mock.Repository), transaction.Nop)
+		result := service.ReassignAndDelete(context.Background(), 1, 2)
+
+		assert.Equal(t, http.StatusInternalServerError, result.Code)
+		assert.Equal(t, expectedError, result.Err)
+	})
 }
 
 func TestServiceGetAll(t *testing.T) {
@@ -172,11 +374,11 @@ func TestServiceGetAll(t *testing.T) {
 		mockedProductTypesRepository := new(product_types_mock.Repository)
 		input := inputSections
 
-		mockedRepository.On("GetAll").Return(input, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return(input, nil)
 
-		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository)
+		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository, transaction.Nop)
 
-		result, err := service.GetAll()
+		result, _, err := service.GetAll(context.Background(), listing.Options{})
 
 		assert.Nil(t, err.Err)
 
@@ -193,11 +395,11 @@ func TestServiceGetAll(t *testing.T) {
 		mockedProductTypesRepository := new(product_types_mock.Repository)
 
 		expectedError := errors.New("any error")
-		mockedRepository.On("GetAll").Return([]sections.Section{}, expectedError)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]sections.Section{}, expectedError)
 
-		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository)
+		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository, transaction.Nop)
 
-		_, err := service.GetAll()
+		_, _, err := service.GetAll(context.Background(), listing.Options{})
 		assert.Error(t, err.Err)
 		assert.Equal(t, expectedError, err.Err)
 		mockedRepository.AssertExpectations(t)
@@ -213,11 +415,11 @@ func TestServiceGetOne(t *testing.T) {
 		mockedProductTypesRepository := new(product_types_mock.Repository)
 		input := inputSections[0]
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(input, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(input, nil)
 
-		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository)
+		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository, transaction.Nop)
 
-		result, err := service.GetOne(1)
+		result, err := service.GetOne(context.Background(), 1)
 
 		assert.Nil(t, err.Err)
 
@@ -232,11 +434,11 @@ func TestServiceGetOne(t *testing.T) {
 		mockedProductTypesRepository := new(product_types_mock.Repository)
 		expectedError := errNotFound
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(sections.Section{}, expectedError)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(sections.Section{}, expectedError)
 
-		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository)
+		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository, transaction.Nop)
 
-		_, err := service.GetOne(1)
+		_, err := service.GetOne(context.Background(), 1)
 
 		assert.NotNil(t, err.Err)
 
@@ -282,14 +484,14 @@ func TestServiceUpdate(t *testing.T) {
 			ProductTypeId:      71,
 		}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(input, nil)
-		mockedRepository.On("GetBySectionNumber", mock.AnythingOfType("int")).Return(0, nil)
-		mockedWarehouseRepository.On("GetOne", mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, nil)
-		mockedProductTypesRepository.On("GetOne", mock.AnythingOfType("int")).Return(nil)
-		mockedRepository.On("Update", mock.AnythingOfType("int"), mock.Anything).Return(expectedSection, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(input, nil)
+		mockedRepository.On("GetBySectionNumber", mock.Anything, mock.AnythingOfType("int")).Return(0, nil)
+		mockedWarehouseRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, nil)
+		mockedProductTypesRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(product_types.ProductType{}, nil)
+		mockedRepository.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).Return(expectedSection, nil)
 
-		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository)
-		result, err := service.Update(1, requestData)
+		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository, transaction.Nop)
+		result, err := service.Update(context.Background(), 1, requestData)
 
 		assert.Nil(t, err.Err)
 		assert.Equal(t, expectedSection, result)
@@ -304,11 +506,11 @@ func TestServiceUpdate(t *testing.T) {
 
 		requestData := map[string]interface{}{}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).
 			Return(sections.Section{}, expectedError).Once()
 
-		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository)
-		_, err := service.Update(1, requestData)
+		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository, transaction.Nop)
+		_, err := service.Update(context.Background(), 1, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, http.StatusNotFound, err.Code)
@@ -327,11 +529,11 @@ func TestServiceUpdate(t *testing.T) {
 		expectedError := errAlreadyExists
 		input := inputSections
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(input[1], nil).Once()
-		mockedRepository.On("GetBySectionNumber", mock.AnythingOfType("int")).Return(10, errAlreadyExists).Once()
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(input[1], nil).Once()
+		mockedRepository.On("GetBySectionNumber", mock.Anything, mock.AnythingOfType("int")).Return(10, errAlreadyExists).Once()
 
-		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository)
-		_, err := service.Update(2, requestData)
+		service := sections.NewService(mockedRepository, mockedWarehouseRepository, mockedProductTypesRepository, transaction.Nop)
+		_, err := service.Update(context.Background(), 2, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, expectedError.Error(), err.Err.Error())
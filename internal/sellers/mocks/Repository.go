@@ -3,8 +3,19 @@
 package mocks
 
 import (
-	sellers "github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers"
+	context "context"
+
+	address "github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+
 	mock "github.com/stretchr/testify/mock"
+
+	phone "github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+
+	sellers "github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -12,20 +23,20 @@ type Repository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: cid, companyName, address, telephone, localityId
-func (_m *Repository) Create(cid int, companyName string, address string, telephone string, localityId string) (sellers.Seller, error) {
-	ret := _m.Called(cid, companyName, address, telephone, localityId)
+// Create provides a mock function with given fields: ctx, cid, companyName, addr, telephone, localityId
+func (_m *Repository) Create(ctx context.Context, cid string, companyName string, addr address.Address, telephone phone.Number, localityId string) (sellers.Seller, error) {
+	ret := _m.Called(ctx, cid, companyName, addr, telephone, localityId)
 
 	var r0 sellers.Seller
-	if rf, ok := ret.Get(0).(func(int, string, string, string, string) sellers.Seller); ok {
-		r0 = rf(cid, companyName, address, telephone, localityId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, address.Address, phone.Number, string) sellers.Seller); ok {
+		r0 = rf(ctx, cid, companyName, addr, telephone, localityId)
 	} else {
 		r0 = ret.Get(0).(sellers.Seller)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int, string, string, string, string) error); ok {
-		r1 = rf(cid, companyName, address, telephone, localityId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, address.Address, phone.Number, string) error); ok {
+		r1 = rf(ctx, cid, companyName, addr, telephone, localityId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,13 +44,13 @@ func (_m *Repository) Create(cid int, companyName string, address string, teleph
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Repository) Delete(id int) error {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Repository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,20 +58,20 @@ func (_m *Repository) Delete(id int) error {
 	return r0
 }
 
-// FindByCID provides a mock function with given fields: cid
-func (_m *Repository) FindByCID(cid int) (int, error) {
-	ret := _m.Called(cid)
+// FindByCID provides a mock function with given fields: ctx, cid
+func (_m *Repository) FindByCID(ctx context.Context, cid string) (int, error) {
+	ret := _m.Called(ctx, cid)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(int) int); ok {
-		r0 = rf(cid)
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, cid)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(cid)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, cid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -68,13 +79,13 @@ func (_m *Repository) FindByCID(cid int) (int, error) {
 	return r0, r1
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *Repository) GetAll() ([]sellers.Seller, error) {
-	ret := _m.Called()
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Repository) GetAll(ctx context.Context, opts listing.Options) ([]sellers.Seller, error) {
+	ret := _m.Called(ctx, opts)
 
 	var r0 []sellers.Seller
-	if rf, ok := ret.Get(0).(func() []sellers.Seller); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []sellers.Seller); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]sellers.Seller)
@@ -82,8 +93,54 @@ func (_m *Repository) GetAll() ([]sellers.Seller, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpirations provides a mock function with given fields: ctx, sellerId, days, opts
+func (_m *Repository) GetExpirations(ctx context.Context, sellerId int, days int, opts listing.Options) ([]sellers.SellerExpiration, error) {
+	ret := _m.Called(ctx, sellerId, days, opts)
+
+	var r0 []sellers.SellerExpiration
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, listing.Options) []sellers.SellerExpiration); ok {
+		r0 = rf(ctx, sellerId, days, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sellers.SellerExpiration)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, listing.Options) error); ok {
+		r1 = rf(ctx, sellerId, days, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInboundReceipts provides a mock function with given fields: ctx, sellerId, opts
+func (_m *Repository) GetInboundReceipts(ctx context.Context, sellerId int, opts listing.Options) ([]sellers.SellerInboundReceipt, error) {
+	ret := _m.Called(ctx, sellerId, opts)
+
+	var r0 []sellers.SellerInboundReceipt
+	if rf, ok := ret.Get(0).(func(context.Context, int, listing.Options) []sellers.SellerInboundReceipt); ok {
+		r0 = rf(ctx, sellerId, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sellers.SellerInboundReceipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, listing.Options) error); ok {
+		r1 = rf(ctx, sellerId, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -91,20 +148,43 @@ func (_m *Repository) GetAll() ([]sellers.Seller, error) {
 	return r0, r1
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Repository) GetOne(id int) (sellers.Seller, error) {
-	ret := _m.Called(id)
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Repository) GetOne(ctx context.Context, id int) (sellers.Seller, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 sellers.Seller
-	if rf, ok := ret.Get(0).(func(int) sellers.Seller); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) sellers.Seller); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(sellers.Seller)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProducts provides a mock function with given fields: ctx, sellerId, opts
+func (_m *Repository) GetProducts(ctx context.Context, sellerId int, opts listing.Options) ([]sellers.SellerProduct, error) {
+	ret := _m.Called(ctx, sellerId, opts)
+
+	var r0 []sellers.SellerProduct
+	if rf, ok := ret.Get(0).(func(context.Context, int, listing.Options) []sellers.SellerProduct); ok {
+		r0 = rf(ctx, sellerId, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sellers.SellerProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, listing.Options) error); ok {
+		r1 = rf(ctx, sellerId, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -112,20 +192,101 @@ func (_m *Repository) GetOne(id int) (sellers.Seller, error) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, requestData
-func (_m *Repository) Update(id int, requestData map[string]interface{}) (sellers.Seller, error) {
-	ret := _m.Called(id, requestData)
+// GetProfitabilityReport provides a mock function with given fields: ctx, sellerId, from, to
+func (_m *Repository) GetProfitabilityReport(ctx context.Context, sellerId int, from *time.Time, to *time.Time) ([]sellers.SellerProductProfitability, error) {
+	ret := _m.Called(ctx, sellerId, from, to)
+
+	var r0 []sellers.SellerProductProfitability
+	if rf, ok := ret.Get(0).(func(context.Context, int, *time.Time, *time.Time) []sellers.SellerProductProfitability); ok {
+		r0 = rf(ctx, sellerId, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sellers.SellerProductProfitability)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, *time.Time, *time.Time) error); ok {
+		r1 = rf(ctx, sellerId, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStock provides a mock function with given fields: ctx, sellerId
+func (_m *Repository) GetStock(ctx context.Context, sellerId int) ([]sellers.SellerStock, error) {
+	ret := _m.Called(ctx, sellerId)
+
+	var r0 []sellers.SellerStock
+	if rf, ok := ret.Get(0).(func(context.Context, int) []sellers.SellerStock); ok {
+		r0 = rf(ctx, sellerId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sellers.SellerStock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, sellerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsage provides a mock function with given fields: ctx, id
+func (_m *Repository) GetUsage(ctx context.Context, id int) (sellers.SellerUsage, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 sellers.SellerUsage
+	if rf, ok := ret.Get(0).(func(context.Context, int) sellers.SellerUsage); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(sellers.SellerUsage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReassignAndDelete provides a mock function with given fields: ctx, id, targetId
+func (_m *Repository) ReassignAndDelete(ctx context.Context, id int, targetId int) error {
+	ret := _m.Called(ctx, id, targetId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, targetId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Repository) Update(ctx context.Context, id int, requestData map[string]interface{}) (sellers.Seller, error) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 sellers.Seller
-	if rf, ok := ret.Get(0).(func(int, map[string]interface{}) sellers.Seller); ok {
-		r0 = rf(id, requestData)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) sellers.Seller); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(sellers.Seller)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int, map[string]interface{}) error); ok {
-		r1 = rf(id, requestData)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) error); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,9 +3,19 @@
 package mocks
 
 import (
+	context "context"
+
+	address "github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+
+	mock "github.com/stretchr/testify/mock"
+
 	sellers "github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers"
+
+	time "time"
+
 	web "github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
-	mock "github.com/stretchr/testify/mock"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -13,20 +23,20 @@ type Service struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: cid, companyName, address, telephone, localityId
-func (_m *Service) Create(cid int, companyName string, address string, telephone string, localityId string) (sellers.Seller, web.ResponseCode) {
-	ret := _m.Called(cid, companyName, address, telephone, localityId)
+// Create provides a mock function with given fields: ctx, cid, companyName, addr, telephone, localityId
+func (_m *Service) Create(ctx context.Context, cid string, companyName string, addr address.Address, telephone string, localityId string) (sellers.Seller, web.ResponseCode) {
+	ret := _m.Called(ctx, cid, companyName, addr, telephone, localityId)
 
 	var r0 sellers.Seller
-	if rf, ok := ret.Get(0).(func(int, string, string, string, string) sellers.Seller); ok {
-		r0 = rf(cid, companyName, address, telephone, localityId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, address.Address, string, string) sellers.Seller); ok {
+		r0 = rf(ctx, cid, companyName, addr, telephone, localityId)
 	} else {
 		r0 = ret.Get(0).(sellers.Seller)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int, string, string, string, string) web.ResponseCode); ok {
-		r1 = rf(cid, companyName, address, telephone, localityId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, address.Address, string, string) web.ResponseCode); ok {
+		r1 = rf(ctx, cid, companyName, addr, telephone, localityId)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -34,13 +44,13 @@ func (_m *Service) Create(cid int, companyName string, address string, telephone
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Service) Delete(id int) web.ResponseCode {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Service) Delete(ctx context.Context, id int) web.ResponseCode {
+	ret := _m.Called(ctx, id)
 
 	var r0 web.ResponseCode
-	if rf, ok := ret.Get(0).(func(int) web.ResponseCode); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) web.ResponseCode); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(web.ResponseCode)
 	}
@@ -48,22 +58,163 @@ func (_m *Service) Delete(id int) web.ResponseCode {
 	return r0
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *Service) GetAll() ([]sellers.Seller, web.ResponseCode) {
-	ret := _m.Called()
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Service) GetAll(ctx context.Context, opts listing.Options) ([]sellers.Seller, listing.Page, web.ResponseCode) {
+	ret := _m.Called(ctx, opts)
 
 	var r0 []sellers.Seller
-	if rf, ok := ret.Get(0).(func() []sellers.Seller); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []sellers.Seller); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]sellers.Seller)
 		}
 	}
 
+	var r1 listing.Page
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) listing.Page); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Get(1).(listing.Page)
+	}
+
+	var r2 web.ResponseCode
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Options) web.ResponseCode); ok {
+		r2 = rf(ctx, opts)
+	} else {
+		r2 = ret.Get(2).(web.ResponseCode)
+	}
+
+	return r0, r1, r2
+}
+
+// GetExpirations provides a mock function with given fields: ctx, sellerId, days, opts
+func (_m *Service) GetExpirations(ctx context.Context, sellerId int, days int, opts listing.Options) ([]sellers.SellerExpiration, listing.Page, web.ResponseCode) {
+	ret := _m.Called(ctx, sellerId, days, opts)
+
+	var r0 []sellers.SellerExpiration
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, listing.Options) []sellers.SellerExpiration); ok {
+		r0 = rf(ctx, sellerId, days, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sellers.SellerExpiration)
+		}
+	}
+
+	var r1 listing.Page
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, listing.Options) listing.Page); ok {
+		r1 = rf(ctx, sellerId, days, opts)
+	} else {
+		r1 = ret.Get(1).(listing.Page)
+	}
+
+	var r2 web.ResponseCode
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, listing.Options) web.ResponseCode); ok {
+		r2 = rf(ctx, sellerId, days, opts)
+	} else {
+		r2 = ret.Get(2).(web.ResponseCode)
+	}
+
+	return r0, r1, r2
+}
+
+// GetInboundReceipts provides a mock function with given fields: ctx, sellerId, opts
+func (_m *Service) GetInboundReceipts(ctx context.Context, sellerId int, opts listing.Options) ([]sellers.SellerInboundReceipt, listing.Page, web.ResponseCode) {
+	ret := _m.Called(ctx, sellerId, opts)
+
+	var r0 []sellers.SellerInboundReceipt
+	if rf, ok := ret.Get(0).(func(context.Context, int, listing.Options) []sellers.SellerInboundReceipt); ok {
+		r0 = rf(ctx, sellerId, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sellers.SellerInboundReceipt)
+		}
+	}
+
+	var r1 listing.Page
+	if rf, ok := ret.Get(1).(func(context.Context, int, listing.Options) listing.Page); ok {
+		r1 = rf(ctx, sellerId, opts)
+	} else {
+		r1 = ret.Get(1).(listing.Page)
+	}
+
+	var r2 web.ResponseCode
+	if rf, ok := ret.Get(2).(func(context.Context, int, listing.Options) web.ResponseCode); ok {
+		r2 = rf(ctx, sellerId, opts)
+	} else {
+		r2 = ret.Get(2).(web.ResponseCode)
+	}
+
+	return r0, r1, r2
+}
+
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Service) GetOne(ctx context.Context, id int) (sellers.Seller, web.ResponseCode) {
+	ret := _m.Called(ctx, id)
+
+	var r0 sellers.Seller
+	if rf, ok := ret.Get(0).(func(context.Context, int) sellers.Seller); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(sellers.Seller)
+	}
+
+	var r1 web.ResponseCode
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Get(1).(web.ResponseCode)
+	}
+
+	return r0, r1
+}
+
+// GetProducts provides a mock function with given fields: ctx, sellerId, opts
+func (_m *Service) GetProducts(ctx context.Context, sellerId int, opts listing.Options) ([]sellers.SellerProduct, listing.Page, web.ResponseCode) {
+	ret := _m.Called(ctx, sellerId, opts)
+
+	var r0 []sellers.SellerProduct
+	if rf, ok := ret.Get(0).(func(context.Context, int, listing.Options) []sellers.SellerProduct); ok {
+		r0 = rf(ctx, sellerId, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sellers.SellerProduct)
+		}
+	}
+
+	var r1 listing.Page
+	if rf, ok := ret.Get(1).(func(context.Context, int, listing.Options) listing.Page); ok {
+		r1 = rf(ctx, sellerId, opts)
+	} else {
+		r1 = ret.Get(1).(listing.Page)
+	}
+
+	var r2 web.ResponseCode
+	if rf, ok := ret.Get(2).(func(context.Context, int, listing.Options) web.ResponseCode); ok {
+		r2 = rf(ctx, sellerId, opts)
+	} else {
+		r2 = ret.Get(2).(web.ResponseCode)
+	}
+
+	return r0, r1, r2
+}
+
+// GetProfitabilityReport provides a mock function with given fields: ctx, sellerId, from, to
+func (_m *Service) GetProfitabilityReport(ctx context.Context, sellerId int, from *time.Time, to *time.Time) ([]sellers.SellerProfitability, web.ResponseCode) {
+	ret := _m.Called(ctx, sellerId, from, to)
+
+	var r0 []sellers.SellerProfitability
+	if rf, ok := ret.Get(0).(func(context.Context, int, *time.Time, *time.Time) []sellers.SellerProfitability); ok {
+		r0 = rf(ctx, sellerId, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sellers.SellerProfitability)
+		}
+	}
+
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func() web.ResponseCode); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, int, *time.Time, *time.Time) web.ResponseCode); ok {
+		r1 = rf(ctx, sellerId, from, to)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -71,20 +222,22 @@ func (_m *Service) GetAll() ([]sellers.Seller, web.ResponseCode) {
 	return r0, r1
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Service) GetOne(id int) (sellers.Seller, web.ResponseCode) {
-	ret := _m.Called(id)
+// GetStock provides a mock function with given fields: ctx, sellerId
+func (_m *Service) GetStock(ctx context.Context, sellerId int) ([]sellers.SellerStock, web.ResponseCode) {
+	ret := _m.Called(ctx, sellerId)
 
-	var r0 sellers.Seller
-	if rf, ok := ret.Get(0).(func(int) sellers.Seller); ok {
-		r0 = rf(id)
+	var r0 []sellers.SellerStock
+	if rf, ok := ret.Get(0).(func(context.Context, int) []sellers.SellerStock); ok {
+		r0 = rf(ctx, sellerId)
 	} else {
-		r0 = ret.Get(0).(sellers.Seller)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sellers.SellerStock)
+		}
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int) web.ResponseCode); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, sellerId)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -92,20 +245,34 @@ func (_m *Service) GetOne(id int) (sellers.Seller, web.ResponseCode) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, requestData
-func (_m *Service) Update(id int, requestData map[string]interface{}) (sellers.Seller, web.ResponseCode) {
-	ret := _m.Called(id, requestData)
+// ReassignAndDelete provides a mock function with given fields: ctx, id, targetId
+func (_m *Service) ReassignAndDelete(ctx context.Context, id int, targetId int) web.ResponseCode {
+	ret := _m.Called(ctx, id, targetId)
+
+	var r0 web.ResponseCode
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) web.ResponseCode); ok {
+		r0 = rf(ctx, id, targetId)
+	} else {
+		r0 = ret.Get(0).(web.ResponseCode)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Service) Update(ctx context.Context, id int, requestData map[string]interface{}) (sellers.Seller, web.ResponseCode) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 sellers.Seller
-	if rf, ok := ret.Get(0).(func(int, map[string]interface{}) sellers.Seller); ok {
-		r0 = rf(id, requestData)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) sellers.Seller); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(sellers.Seller)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int, map[string]interface{}) web.ResponseCode); ok {
-		r1 = rf(id, requestData)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) web.ResponseCode); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -113,6 +280,20 @@ func (_m *Service) Update(id int, requestData map[string]interface{}) (sellers.S
 	return r0, r1
 }
 
+// ValidateCreate provides a mock function with given fields: ctx, cid, companyName, addr, telephone, localityId
+func (_m *Service) ValidateCreate(ctx context.Context, cid string, companyName string, addr address.Address, telephone string, localityId string) web.ResponseCode {
+	ret := _m.Called(ctx, cid, companyName, addr, telephone, localityId)
+
+	var r0 web.ResponseCode
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, address.Address, string, string) web.ResponseCode); ok {
+		r0 = rf(ctx, cid, companyName, addr, telephone, localityId)
+	} else {
+		r0 = ret.Get(0).(web.ResponseCode)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewService interface {
 	mock.TestingT
 	Cleanup(func())
@@ -1,10 +1,151 @@
 package sellers
 
+import (
+	"time"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/document"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+)
+
+// AcceptedDocuments are the documents a seller may be registered with as cid.
+var AcceptedDocuments = []document.Type{document.CNPJ, document.CPF}
+
+// ListFields are the fields sellers can be filtered and sorted by.
+var ListFields = listing.Fields{
+	Columns: map[string]string{
+		"id":           "id",
+		"cid":          "cid",
+		"company_name": "company_name",
+		"telephone":    "telephone",
+		"locality_id":  "locality_id",
+	},
+	Sortable: []string{"cid", "company_name"},
+}
+
+// ProductListFields, InboundListFields and ExpirationListFields are the
+// fields the seller views can be filtered and sorted by. The stock view is
+// not paged: it sums the batches per product and warehouse, so its rows
+// have no id to page on.
+var (
+	ProductListFields = listing.Fields{
+		Columns: map[string]string{
+			"id":              "id",
+			"product_code":    "product_code",
+			"description":     "description",
+			"product_type_id": "product_type_id",
+		},
+		Sortable: []string{"product_code", "description"},
+	}
+
+	InboundListFields = listing.Fields{
+		Columns: map[string]string{
+			"id":           "id",
+			"order_date":   "order_date",
+			"warehouse_id": "warehouse_id",
+			"product_id":   "product_id",
+		},
+		Sortable: []string{"order_date", "product_id"},
+	}
+
+	ExpirationListFields = listing.Fields{
+		Columns: map[string]string{
+			"id":             "id",
+			"days_to_expire": "days_to_expire",
+			"warehouse_id":   "warehouse_id",
+			"section_id":     "section_id",
+			"product_id":     "product_id",
+		},
+		Sortable: []string{"days_to_expire", "product_id"},
+	}
+)
+
 type Seller struct {
-	Id          int    `json:"id"`
-	Cid         int    `json:"cid"`
-	CompanyName string `json:"company_name"`
-	Address     string `json:"address"`
-	Telephone   string `json:"telephone"`
-	LocalityId  string `json:"locality_id"`
+	Id                int             `json:"id"`
+	Cid               string          `json:"cid"`
+	CompanyName       string          `json:"company_name"`
+	Address           string          `json:"address"`
+	StructuredAddress address.Address `json:"structured_address"`
+	Telephone         string          `json:"telephone"`
+	TelephoneDisplay  string          `json:"telephone_display"`
+	LocalityId        string          `json:"locality_id"`
+}
+
+type SellerUsage struct {
+	Products int `json:"products"`
+}
+
+type ProductProfitability struct {
+	ProductId        int     `json:"product_id"`
+	ProductCode      string  `json:"product_code"`
+	Description      string  `json:"description"`
+	UnitsSold        int     `json:"units_sold"`
+	Revenue          float64 `json:"revenue"`
+	Cost             float64 `json:"cost"`
+	GrossMargin      float64 `json:"gross_margin"`
+	MarginPercentage float64 `json:"margin_percentage"`
+	StockQuantity    int     `json:"stock_quantity"`
+	StockValue       float64 `json:"stock_value"`
+}
+
+// SellerProductProfitability is a product row of the profitability report
+// along with the seller it belongs to.
+type SellerProductProfitability struct {
+	SellerId    int
+	CompanyName string
+	Product     ProductProfitability
+}
+
+type SellerProfitability struct {
+	SellerId         int                    `json:"seller_id"`
+	CompanyName      string                 `json:"company_name"`
+	UnitsSold        int                    `json:"units_sold"`
+	Revenue          float64                `json:"revenue"`
+	Cost             float64                `json:"cost"`
+	GrossMargin      float64                `json:"gross_margin"`
+	MarginPercentage float64                `json:"margin_percentage"`
+	StockValue       float64                `json:"stock_value"`
+	Products         []ProductProfitability `json:"products"`
+}
+
+type SellerProduct struct {
+	Id            int    `json:"id"`
+	ProductCode   string `json:"product_code"`
+	Description   string `json:"description"`
+	ProductTypeId int    `json:"product_type_id"`
+}
+
+type SellerStock struct {
+	ProductId     int    `json:"product_id"`
+	ProductCode   string `json:"product_code"`
+	WarehouseId   int    `json:"warehouse_id"`
+	WarehouseCode string `json:"warehouse_code"`
+	BatchesCount  int    `json:"batches_count"`
+	Quantity      int    `json:"quantity"`
+}
+
+// SellerInboundReceipt is identified by the id of its inbound order.
+type SellerInboundReceipt struct {
+	Id            int    `json:"id"`
+	OrderNumber   string `json:"order_number"`
+	OrderDate     string `json:"order_date"`
+	WarehouseId   int    `json:"warehouse_id"`
+	WarehouseCode string `json:"warehouse_code"`
+	BatchNumber   int    `json:"batch_number"`
+	ProductId     int    `json:"product_id"`
+	ProductCode   string `json:"product_code"`
+	Quantity      int    `json:"quantity"`
+}
+
+// SellerExpiration is identified by the id of its product batch.
+type SellerExpiration struct {
+	Id              int       `json:"id"`
+	BatchNumber     int       `json:"batch_number"`
+	ProductId       int       `json:"product_id"`
+	ProductCode     string    `json:"product_code"`
+	WarehouseId     int       `json:"warehouse_id"`
+	SectionId       int       `json:"section_id"`
+	CurrentQuantity int       `json:"current_quantity"`
+	DueDate         time.Time `json:"due_date"`
+	DaysToExpire    int       `json:"days_to_expire"`
 }
@@ -1,11 +1,25 @@
 package sellers
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+)
 
 var (
-	queryCreateSeller  = "INSERT INTO sellers (cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?)"
-	queryGetOneSeller  = "SELECT * FROM sellers WHERE id = ?"
-	queryGetAllSellers = "SELECT * FROM sellers"
+	queryGetSellerUsage = `SELECT COUNT(*) FROM products WHERE seller_id = ?`
+
+	queryReassignSellerProducts = `UPDATE products SET seller_id = ? WHERE seller_id = ?`
+
+	querySellerColumns = "id, cid, company_name, address, street, number, complement, neighborhood, cep, state, telephone, telephone_display, locality_id"
+
+	queryCreateSeller = `INSERT INTO sellers (cid, company_name, address, street, number, complement, neighborhood, cep, state, telephone, telephone_display, locality_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	queryGetOneSeller  = "SELECT " + querySellerColumns + " FROM sellers WHERE id = ?"
+	queryGetAllSellers = "SELECT " + querySellerColumns + " FROM sellers"
 	queryDeleteSeller  = "DELETE FROM sellers WHERE id = ?"
 	queryUpdateSeller  = func(
 		requestData map[string]interface{},
@@ -17,14 +31,12 @@ var (
 		whereCase := "WHERE id = ?"
 
 		var fields = []string{"company_name", "address", "telephone", "locality_id", "cid"}
+		fields = append(fields, address.UpdatedColumns(requestData)...)
+		fields = append(fields, phone.UpdatedColumns(requestData)...)
 		for _, currField := range fields {
 			if _, ok := requestData[currField]; ok {
 				fieldsToUpdate = append(fieldsToUpdate, fmt.Sprintf(" %s = ?", currField))
-				if currField == "cid" {
-					valuesToUse = append(valuesToUse, int(requestData[currField].(float64)))
-				} else {
-					valuesToUse = append(valuesToUse, requestData[currField])
-				}
+				valuesToUse = append(valuesToUse, requestData[currField])
 			}
 		}
 
@@ -42,5 +54,91 @@ var (
 		return finalQuery, valuesToUse
 	}
 
-	queryFindByCID = "SELECT id, cid FROM sellers WHERE cid = ?"
+	queryFindByCID = "SELECT id FROM sellers WHERE cid = ?"
+
+	// The paged seller views select from a derived table scoped to the
+	// seller, so listing.Build can append its own WHERE and ORDER BY.
+	queryGetSellerProducts = `SELECT * FROM (SELECT p.id, p.product_code,
+		COALESCE(p.description, '') AS description, COALESCE(p.product_type_id, 0) AS product_type_id
+		FROM products p
+		WHERE p.seller_id = ?) products`
+
+	// queryGetSellerStock sums the batches of the seller's products still in
+	// stock, per product and the warehouse holding their section.
+	queryGetSellerStock = `SELECT p.id, p.product_code, w.id, w.warehouse_code,
+	COUNT(pb.id), COALESCE(SUM(pb.current_quatity), 0)
+	FROM product_batches pb
+	JOIN products p ON p.id = pb.product_id
+	JOIN sections s ON s.id = pb.section_id
+	JOIN warehouses w ON w.id = s.warehouse_id
+	WHERE p.seller_id = ? AND pb.current_quatity > 0
+	GROUP BY p.id, p.product_code, w.id, w.warehouse_code
+	ORDER BY p.id, w.id`
+
+	queryGetSellerInboundReceipts = `SELECT * FROM (SELECT io.id, COALESCE(io.order_number, '') AS order_number,
+		COALESCE(io.order_date, '') AS order_date, w.id AS warehouse_id, w.warehouse_code, pb.batch_number,
+		p.id AS product_id, p.product_code, COALESCE(pb.initial_quantity, 0) AS quantity
+		FROM inbound_orders io
+		JOIN product_batches pb ON pb.id = io.product_batch_id
+		JOIN products p ON p.id = pb.product_id
+		JOIN warehouses w ON w.id = io.warehouse_id
+		WHERE p.seller_id = ?) receipts`
+
+	// queryGetSellerExpirations lists the batches still in stock whose due
+	// date falls between today and the given number of days ahead.
+	queryGetSellerExpirations = `SELECT * FROM (SELECT pb.id, pb.batch_number, p.id AS product_id, p.product_code,
+		s.warehouse_id, s.id AS section_id, pb.current_quatity, pb.due_date,
+		DATEDIFF(pb.due_date, CURDATE()) AS days_to_expire
+		FROM product_batches pb
+		JOIN products p ON p.id = pb.product_id
+		JOIN sections s ON s.id = pb.section_id
+		WHERE p.seller_id = ? AND pb.current_quatity > 0
+		AND pb.due_date BETWEEN CURDATE() AND DATE_ADD(CURDATE(), INTERVAL ? DAY)) expirations`
+
+	// queryGetProfitabilityReport sums, per product, the purchase orders whose
+	// order_status counts as fulfilled at the prices of the product_record they
+	// refer to, and values the stock on hand at the current purchase_price.
+	// Each purchase order holds one unit of the batches until its order_status
+	// releases the stock, so those units are not on hand anymore.
+	queryGetProfitabilityReport = func(sellerId int, from, to *time.Time) (string, []interface{}) {
+		salesFilters := []string{"os.counts_as_fulfilled = 1"}
+		values := []interface{}{}
+
+		if from != nil {
+			salesFilters = append(salesFilters, "po.order_date >= ?")
+			values = append(values, *from)
+		}
+		if to != nil {
+			salesFilters = append(salesFilters, "po.order_date <= ?")
+			values = append(values, *to)
+		}
+
+		query := `SELECT s.id, s.company_name, p.id, p.product_code, p.description,
+		COALESCE(sales.units_sold, 0), COALESCE(sales.revenue, 0), COALESCE(sales.cost, 0),
+		COALESCE(GREATEST(stock.quantity, 0), 0),
+		COALESCE(GREATEST(stock.quantity, 0) * (SELECT pr.purchase_price FROM product_records pr
+			WHERE pr.product_id = p.id AND pr.last_update_date <= CURDATE()
+			ORDER BY pr.last_update_date DESC, pr.id DESC LIMIT 1), 0)
+		FROM sellers s
+		JOIN products p ON p.seller_id = s.id
+		LEFT JOIN (SELECT pr.product_id, COUNT(po.id) AS units_sold, SUM(pr.sale_price) AS revenue, SUM(pr.purchase_price) AS cost
+			FROM purchase_orders po
+			JOIN product_records pr ON pr.id = po.product_record_id
+			JOIN order_status os ON os.id = po.order_status_id
+			WHERE ` + strings.Join(salesFilters, " AND ") + `
+			GROUP BY pr.product_id) sales ON sales.product_id = p.id
+		LEFT JOIN (SELECT pb.product_id, SUM(pb.current_quatity) - (SELECT COUNT(po.id)
+				FROM purchase_orders po
+				JOIN product_records pr ON pr.id = po.product_record_id
+				JOIN order_status os ON os.id = po.order_status_id
+				WHERE pr.product_id = pb.product_id AND os.releases_stock = 0) AS quantity
+			FROM product_batches pb GROUP BY pb.product_id) stock ON stock.product_id = p.id`
+
+		if sellerId != 0 {
+			query += " WHERE s.id = ?"
+			values = append(values, sellerId)
+		}
+
+		return query + " ORDER BY s.id, p.id", values
+	}
 )
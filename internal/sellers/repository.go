@@ -1,55 +1,78 @@
 package sellers
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 )
 
 var (
-	errUpdatedSeller = errors.New("ocurred an error while updating the seller")
-	errCreateSeller  = errors.New("ocurred an error to create seller")
-	errGetSellers    = errors.New("couldn't get sellers")
-	errGetOneSeller  = errors.New("unexpected error to get seller")
-	errDeleteSeller  = errors.New("unexpected error to delete seller")
+	errUpdatedSeller  = errors.New("ocurred an error while updating the seller")
+	errCreateSeller   = errors.New("ocurred an error to create seller")
+	errGetSellers     = errors.New("couldn't get sellers")
+	errGetOneSeller   = errors.New("unexpected error to get seller")
+	errDeleteSeller   = errors.New("unexpected error to delete seller")
+	errGetSellerUsage = errors.New("unexpected error to verify seller usage")
+	errReassignSeller = errors.New("unexpected error to reassign seller dependents")
+	errProfitability  = errors.New("error to report sellers profitability")
+	errSellerProducts = errors.New("couldn't get the seller products")
+	errSellerStock    = errors.New("couldn't get the seller stock")
+	errSellerInbound  = errors.New("couldn't get the seller inbound receipts")
+	errSellerExpiring = errors.New("couldn't get the seller upcoming expirations")
 )
 
+func GetErrSellerNotFound(id int) error {
+	return fmt.Errorf("seller with id %d not found", id)
+}
+
 type Repository interface {
-	Create(cid int, companyName, address, telephone, localityId string) (Seller, error)
-	GetOne(id int) (Seller, error)
-	GetAll() ([]Seller, error)
-	Delete(id int) error
-	Update(id int, requestData map[string]interface{}) (Seller, error)
-	FindByCID(cid int) (int, error)
+	Create(ctx context.Context, cid, companyName string, addr address.Address, telephone phone.Number, localityId string) (Seller, error)
+	GetOne(ctx context.Context, id int) (Seller, error)
+	GetAll(ctx context.Context, opts listing.Options) ([]Seller, error)
+	Delete(ctx context.Context, id int) error
+	GetUsage(ctx context.Context, id int) (SellerUsage, error)
+	ReassignAndDelete(ctx context.Context, id, targetId int) error
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Seller, error)
+	FindByCID(ctx context.Context, cid string) (int, error)
+	GetProfitabilityReport(ctx context.Context, sellerId int, from, to *time.Time) ([]SellerProductProfitability, error)
+	GetProducts(ctx context.Context, sellerId int, opts listing.Options) ([]SellerProduct, error)
+	GetStock(ctx context.Context, sellerId int) ([]SellerStock, error)
+	GetInboundReceipts(ctx context.Context, sellerId int, opts listing.Options) ([]SellerInboundReceipt, error)
+	GetExpirations(ctx context.Context, sellerId, days int, opts listing.Options) ([]SellerExpiration, error)
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
-func (mariaDb mariaDbRepository) Create(cid int, companyName, address, telephone, localityId string) (Seller, error) {
+func (mariaDb mariaDbRepository) Create(ctx context.Context, cid, companyName string, addr address.Address, telephone phone.Number, localityId string) (Seller, error) {
 	newSeller := Seller{
-		Cid:         cid,
-		CompanyName: companyName,
-		Address:     address,
-		Telephone:   telephone,
-		LocalityId:  localityId,
-	}
-
-	result, err := mariaDb.db.Exec(
-		queryCreateSeller,
-		cid,
-		companyName,
-		address,
-		telephone,
-		localityId,
-	)
+		Cid:               cid,
+		CompanyName:       companyName,
+		Address:           addr.String(),
+		StructuredAddress: addr,
+		Telephone:         telephone.E164,
+		TelephoneDisplay:  telephone.Display,
+		LocalityId:        localityId,
+	}
+
+	values := append([]interface{}{cid, companyName}, addr.Values()...)
+	values = append(values, telephone.E164, telephone.Display, localityId)
+
+	result, err := mariaDb.db.ExecContext(ctx, queryCreateSeller, values...)
 
 	if err != nil {
 		return Seller{}, errCreateSeller
@@ -65,21 +88,29 @@ func (mariaDb mariaDbRepository) Create(cid int, companyName, address, telephone
 	return newSeller, nil
 }
 
-func (mariaDb mariaDbRepository) GetOne(id int) (Seller, error) {
+func (mariaDb mariaDbRepository) GetOne(ctx context.Context, id int) (Seller, error) {
 	currentSeller := Seller{}
 
-	row := mariaDb.db.QueryRow(queryGetOneSeller, id)
+	row := mariaDb.db.QueryRowContext(ctx, queryGetOneSeller, id)
 	err := row.Scan(
 		&currentSeller.Id,
 		&currentSeller.Cid,
 		&currentSeller.CompanyName,
 		&currentSeller.Address,
+		&currentSeller.StructuredAddress.Street,
+		&currentSeller.StructuredAddress.Number,
+		&currentSeller.StructuredAddress.Complement,
+		&currentSeller.StructuredAddress.Neighborhood,
+		&currentSeller.StructuredAddress.Cep,
+		&currentSeller.StructuredAddress.State,
 		&currentSeller.Telephone,
+		&currentSeller.TelephoneDisplay,
 		&currentSeller.LocalityId,
 	)
+	currentSeller.StructuredAddress.LocalityId = currentSeller.LocalityId
 
 	if errors.Is(err, sql.ErrNoRows) {
-		return Seller{}, fmt.Errorf("seller with id %d not found", id)
+		return Seller{}, GetErrSellerNotFound(id)
 	}
 
 	if err != nil {
@@ -89,10 +120,11 @@ func (mariaDb mariaDbRepository) GetOne(id int) (Seller, error) {
 	return currentSeller, nil
 }
 
-func (mariaDb mariaDbRepository) GetAll() ([]Seller, error) {
+func (mariaDb mariaDbRepository) GetAll(ctx context.Context, opts listing.Options) ([]Seller, error) {
 	sellers := []Seller{}
 
-	rows, err := mariaDb.db.Query(queryGetAllSellers)
+	query, values := listing.Build(queryGetAllSellers, ListFields, opts)
+	rows, err := mariaDb.db.QueryContext(ctx, query, values...)
 	if err != nil {
 		return []Seller{}, errGetSellers
 	}
@@ -104,18 +136,30 @@ func (mariaDb mariaDbRepository) GetAll() ([]Seller, error) {
 			&currentSeller.Cid,
 			&currentSeller.CompanyName,
 			&currentSeller.Address,
+			&currentSeller.StructuredAddress.Street,
+			&currentSeller.StructuredAddress.Number,
+			&currentSeller.StructuredAddress.Complement,
+			&currentSeller.StructuredAddress.Neighborhood,
+			&currentSeller.StructuredAddress.Cep,
+			&currentSeller.StructuredAddress.State,
 			&currentSeller.Telephone,
+			&currentSeller.TelephoneDisplay,
 			&currentSeller.LocalityId,
 		); err != nil {
 			return []Seller{}, errGetSellers
 		}
+		currentSeller.StructuredAddress.LocalityId = currentSeller.LocalityId
 		sellers = append(sellers, currentSeller)
 	}
+
+	if err := rows.Err(); err != nil {
+		return []Seller{}, errGetSellers
+	}
 	return sellers, nil
 }
 
-func (mariaDb mariaDbRepository) Delete(id int) error {
-	_, err := mariaDb.db.Exec(queryDeleteSeller, id)
+func (mariaDb mariaDbRepository) Delete(ctx context.Context, id int) error {
+	_, err := mariaDb.db.ExecContext(ctx, queryDeleteSeller, id)
 
 	if err != nil {
 		return errDeleteSeller
@@ -124,10 +168,10 @@ func (mariaDb mariaDbRepository) Delete(id int) error {
 	return nil
 }
 
-func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface{}) (Seller, error) {
+func (mariaDb mariaDbRepository) Update(ctx context.Context, id int, requestData map[string]interface{}) (Seller, error) {
 	finalQuery, valuesToUse := queryUpdateSeller(requestData, id)
 
-	result, err := mariaDb.db.Exec(finalQuery, valuesToUse...)
+	result, err := mariaDb.db.ExecContext(ctx, finalQuery, valuesToUse...)
 	if err != nil {
 		return Seller{}, errUpdatedSeller
 	}
@@ -137,7 +181,7 @@ func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface
 		return Seller{}, errUpdatedSeller
 	}
 
-	currentSeller, err := mariaDb.GetOne(id)
+	currentSeller, err := mariaDb.GetOne(ctx, id)
 	if err != nil {
 		return Seller{}, errUpdatedSeller
 	}
@@ -145,12 +189,11 @@ func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface
 	return currentSeller, nil
 }
 
-func (mariaDb mariaDbRepository) FindByCID(cid int) (int, error) {
-	row := mariaDb.db.QueryRow(queryFindByCID, cid)
+func (mariaDb mariaDbRepository) FindByCID(ctx context.Context, cid string) (int, error) {
+	row := mariaDb.db.QueryRowContext(ctx, queryFindByCID, cid)
 
-	var currCID int
 	var id int
-	err := row.Scan(&id, &currCID)
+	err := row.Scan(&id)
 
 	if errors.Is(err, sql.ErrNoRows) {
 		return 0, nil
@@ -162,3 +205,202 @@ func (mariaDb mariaDbRepository) FindByCID(cid int) (int, error) {
 
 	return id, errors.New("cid already exists")
 }
+
+func (mariaDb mariaDbRepository) GetUsage(ctx context.Context, id int) (SellerUsage, error) {
+	var usage SellerUsage
+
+	row := mariaDb.db.QueryRowContext(ctx, queryGetSellerUsage, id)
+	if err := row.Scan(&usage.Products); err != nil {
+		return SellerUsage{}, errGetSellerUsage
+	}
+
+	return usage, nil
+}
+
+// ReassignAndDelete moves the products of a seller to targetId
+// and deletes the seller in a single transaction.
+func (mariaDb mariaDbRepository) ReassignAndDelete(ctx context.Context, id, targetId int) error {
+	err := mariaDb.db.Run(ctx, func(ctx context.Context) error {
+		if _, err := mariaDb.db.ExecContext(ctx, queryReassignSellerProducts, targetId, id); err != nil {
+			return errReassignSeller
+		}
+
+		if _, err := mariaDb.db.ExecContext(ctx, queryDeleteSeller, id); err != nil {
+			return errDeleteSeller
+		}
+
+		return nil
+	})
+	if err != nil && err != errDeleteSeller {
+		return errReassignSeller
+	}
+
+	return err
+}
+
+func (mariaDb mariaDbRepository) GetProfitabilityReport(ctx context.Context, sellerId int, from, to *time.Time) ([]SellerProductProfitability, error) {
+	report := []SellerProductProfitability{}
+
+	query, values := queryGetProfitabilityReport(sellerId, from, to)
+	rows, err := mariaDb.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return []SellerProductProfitability{}, errProfitability
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row SellerProductProfitability
+		if err := rows.Scan(
+			&row.SellerId,
+			&row.CompanyName,
+			&row.Product.ProductId,
+			&row.Product.ProductCode,
+			&row.Product.Description,
+			&row.Product.UnitsSold,
+			&row.Product.Revenue,
+			&row.Product.Cost,
+			&row.Product.StockQuantity,
+			&row.Product.StockValue,
+		); err != nil {
+			return []SellerProductProfitability{}, errProfitability
+		}
+		report = append(report, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []SellerProductProfitability{}, errProfitability
+	}
+
+	return report, nil
+}
+
+func (mariaDb mariaDbRepository) GetProducts(ctx context.Context, sellerId int, opts listing.Options) ([]SellerProduct, error) {
+	products := []SellerProduct{}
+
+	query, values := listing.Build(queryGetSellerProducts, ProductListFields, opts)
+	rows, err := mariaDb.db.QueryContext(ctx, query, append([]interface{}{sellerId}, values...)...)
+	if err != nil {
+		return []SellerProduct{}, errSellerProducts
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var product SellerProduct
+		if err := rows.Scan(
+			&product.Id,
+			&product.ProductCode,
+			&product.Description,
+			&product.ProductTypeId,
+		); err != nil {
+			return []SellerProduct{}, errSellerProducts
+		}
+		products = append(products, product)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []SellerProduct{}, errSellerProducts
+	}
+
+	return products, nil
+}
+
+func (mariaDb mariaDbRepository) GetStock(ctx context.Context, sellerId int) ([]SellerStock, error) {
+	stock := []SellerStock{}
+
+	rows, err := mariaDb.db.QueryContext(ctx, queryGetSellerStock, sellerId)
+	if err != nil {
+		return []SellerStock{}, errSellerStock
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row SellerStock
+		if err := rows.Scan(
+			&row.ProductId,
+			&row.ProductCode,
+			&row.WarehouseId,
+			&row.WarehouseCode,
+			&row.BatchesCount,
+			&row.Quantity,
+		); err != nil {
+			return []SellerStock{}, errSellerStock
+		}
+		stock = append(stock, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []SellerStock{}, errSellerStock
+	}
+
+	return stock, nil
+}
+
+func (mariaDb mariaDbRepository) GetInboundReceipts(ctx context.Context, sellerId int, opts listing.Options) ([]SellerInboundReceipt, error) {
+	receipts := []SellerInboundReceipt{}
+
+	query, values := listing.Build(queryGetSellerInboundReceipts, InboundListFields, opts)
+	rows, err := mariaDb.db.QueryContext(ctx, query, append([]interface{}{sellerId}, values...)...)
+	if err != nil {
+		return []SellerInboundReceipt{}, errSellerInbound
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var receipt SellerInboundReceipt
+		if err := rows.Scan(
+			&receipt.Id,
+			&receipt.OrderNumber,
+			&receipt.OrderDate,
+			&receipt.WarehouseId,
+			&receipt.WarehouseCode,
+			&receipt.BatchNumber,
+			&receipt.ProductId,
+			&receipt.ProductCode,
+			&receipt.Quantity,
+		); err != nil {
+			return []SellerInboundReceipt{}, errSellerInbound
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []SellerInboundReceipt{}, errSellerInbound
+	}
+
+	return receipts, nil
+}
+
+func (mariaDb mariaDbRepository) GetExpirations(ctx context.Context, sellerId, days int, opts listing.Options) ([]SellerExpiration, error) {
+	expirations := []SellerExpiration{}
+
+	query, values := listing.Build(queryGetSellerExpirations, ExpirationListFields, opts)
+	rows, err := mariaDb.db.QueryContext(ctx, query, append([]interface{}{sellerId, days}, values...)...)
+	if err != nil {
+		return []SellerExpiration{}, errSellerExpiring
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var expiration SellerExpiration
+		if err := rows.Scan(
+			&expiration.Id,
+			&expiration.BatchNumber,
+			&expiration.ProductId,
+			&expiration.ProductCode,
+			&expiration.WarehouseId,
+			&expiration.SectionId,
+			&expiration.CurrentQuantity,
+			&expiration.DueDate,
+			&expiration.DaysToExpire,
+		); err != nil {
+			return []SellerExpiration{}, errSellerExpiring
+		}
+		expirations = append(expirations, expiration)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []SellerExpiration{}, errSellerExpiring
+	}
+
+	return expirations, nil
+}
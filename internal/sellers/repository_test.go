@@ -1,12 +1,17 @@
 package sellers
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,20 +24,28 @@ func TestDBCreateSeller(t *testing.T) {
 
 		mock.ExpectExec(regexp.QuoteMeta(queryCreateSeller)).
 			WithArgs(
-				123,
+				"11222333000181",
 				"Mercado Libre",
-				"Nações Unidas",
+				"Av. Nações Unidas, 999 - SP - 04578-000",
+				"Av. Nações Unidas",
 				"999",
+				"",
+				"",
+				"04578000",
+				"SP",
+				"+5511999990000",
+				"(11) 99999-0000",
 				"123",
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		sellersRepo := NewMariaDbRepository(db)
 
 		seller, err := sellersRepo.Create(
-			123,
+			context.Background(),
+			"11222333000181",
 			"Mercado Libre",
-			"Nações Unidas",
-			"999",
+			address.Address{Street: "Av. Nações Unidas", Number: "999", Cep: "04578000", State: "SP"},
+			phone.Number{E164: "+5511999990000", Display: "(11) 99999-0000"},
 			"123",
 		)
 		assert.Nil(t, err)
@@ -48,7 +61,7 @@ func TestDBCreateSeller(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryCreateSeller)).WillReturnError(errors.New("internal db error"))
 		sellersRepo := NewMariaDbRepository(db)
 
-		_, err = sellersRepo.Create(0, "", "", "", "")
+		_, err = sellersRepo.Create(context.Background(), "", "", address.Address{}, phone.Number{}, "")
 		assert.Error(t, err)
 	})
 
@@ -63,7 +76,7 @@ func TestDBCreateSeller(t *testing.T) {
 			WillReturnResult(sqlDriverResultErr)
 
 		sellersRepo := NewMariaDbRepository(db)
-		_, err = sellersRepo.Create(0, "", "", "", "")
+		_, err = sellersRepo.Create(context.Background(), "", "", address.Address{}, phone.Number{}, "")
 
 		assert.Error(t, err)
 		assert.Equal(t, "ocurred an error to create seller", err.Error())
@@ -82,15 +95,22 @@ func TestDBGetOneSeller(t *testing.T) {
 			"cid",
 			"company_name",
 			"address",
+			"street",
+			"number",
+			"complement",
+			"neighborhood",
+			"cep",
+			"state",
 			"telephone",
+			"telephone_display",
 			"locality_id",
 		}).
-			AddRow(1, 1, "Mercado Libre", "Nações Unidas", "999", "1")
+			AddRow(1, 1, "Mercado Libre", "Nações Unidas", "", "", "", "", "", "", "999", "", "1")
 
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneSeller)).WillReturnRows(rows)
 
 		sellersRepo := NewMariaDbRepository(db)
-		seller, err := sellersRepo.GetOne(1)
+		seller, err := sellersRepo.GetOne(context.Background(), 1)
 		assert.NoError(t, err)
 
 		assert.Equal(t, "Mercado Libre", seller.CompanyName)
@@ -104,7 +124,7 @@ func TestDBGetOneSeller(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneSeller)).WillReturnError(sql.ErrNoRows)
 
 		sellersRepo := NewMariaDbRepository(db)
-		_, err = sellersRepo.GetOne(1)
+		_, err = sellersRepo.GetOne(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "seller with id 1 not found", err.Error())
 	})
@@ -118,7 +138,7 @@ func TestDBGetOneSeller(t *testing.T) {
 			WillReturnError(errors.New("unexpected error to get seller"))
 
 		sellersRepo := NewMariaDbRepository(db)
-		_, err = sellersRepo.GetOne(1)
+		_, err = sellersRepo.GetOne(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "unexpected error to get seller", err.Error())
 	})
@@ -135,17 +155,24 @@ func TestDBGetAllSellers(t *testing.T) {
 			"cid",
 			"company_name",
 			"address",
+			"street",
+			"number",
+			"complement",
+			"neighborhood",
+			"cep",
+			"state",
 			"telephone",
+			"telephone_display",
 			"locality_id",
 		}).
-			AddRow(1, 1, "Mercado Libre", "Nações Unidas", "123", "1").
-			AddRow(2, 2, "Mercado Solto", "Nações Desunidas", "456", "1").
-			AddRow(3, 3, "Mercado Freedom", "Nações Unificadas", "789", "1")
+			AddRow(1, 1, "Mercado Libre", "Nações Unidas", "", "", "", "", "", "", "123", "", "1").
+			AddRow(2, 2, "Mercado Solto", "Nações Desunidas", "", "", "", "", "", "", "456", "", "1").
+			AddRow(3, 3, "Mercado Freedom", "Nações Unificadas", "", "", "", "", "", "", "789", "", "1")
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetAllSellers)).WillReturnRows(rows)
 
 		sellersRepo := NewMariaDbRepository(db)
 
-		sellerReports, err := sellersRepo.GetAll()
+		sellerReports, err := sellersRepo.GetAll(context.Background(), listing.Options{})
 		assert.NoError(t, err)
 
 		assert.Len(t, sellerReports, 3)
@@ -164,15 +191,22 @@ func TestDBGetAllSellers(t *testing.T) {
 			"cid",
 			"company_name",
 			"address",
+			"street",
+			"number",
+			"complement",
+			"neighborhood",
+			"cep",
+			"state",
 			"telephone",
+			"telephone_display",
 			"locality_id",
-		}).AddRow(1, "aaa", "Mercado Libre", "Nações Unidas", "123", "1")
+		}).AddRow("aaa", "1", "Mercado Libre", "Nações Unidas", "", "", "", "", "", "", "123", "", "1")
 
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetAllSellers)).WillReturnRows(rows)
 
 		sellersRepo := NewMariaDbRepository(db)
 
-		_, err = sellersRepo.GetAll()
+		_, err = sellersRepo.GetAll(context.Background(), listing.Options{})
 		assert.Error(t, err)
 		assert.Equal(t, "couldn't get sellers", err.Error())
 	})
@@ -187,7 +221,7 @@ func TestDBGetAllSellers(t *testing.T) {
 
 		sellersRepo := NewMariaDbRepository(db)
 
-		_, err = sellersRepo.GetAll()
+		_, err = sellersRepo.GetAll(context.Background(), listing.Options{})
 		assert.Error(t, err)
 	})
 }
@@ -203,7 +237,7 @@ func TestDBDeleteSeller(t *testing.T) {
 			WillReturnResult(affectedRowsResult)
 
 		sellersRepo := NewMariaDbRepository(db)
-		err = sellersRepo.Delete(1)
+		err = sellersRepo.Delete(context.Background(), 1)
 		assert.NoError(t, err)
 	})
 
@@ -216,7 +250,7 @@ func TestDBDeleteSeller(t *testing.T) {
 			WillReturnError(errors.New("any error"))
 
 		sellersRepo := NewMariaDbRepository(db)
-		err = sellersRepo.Delete(1)
+		err = sellersRepo.Delete(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, errDeleteSeller, err)
 	})
@@ -224,7 +258,7 @@ func TestDBDeleteSeller(t *testing.T) {
 
 func TestDBUpdateSeller(t *testing.T) {
 	requestData := map[string]interface{}{
-		"cid":          1.0,
+		"cid":          "11222333000181",
 		"address":      "Nações Separadas",
 		"telephone":    "123",
 		"locality_id":  "1",
@@ -251,7 +285,7 @@ func TestDBUpdateSeller(t *testing.T) {
 				"Nações Separadas",
 				"123",
 				"1",
-				1,
+				"11222333000181",
 				1, // Seller ID to update
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -261,21 +295,64 @@ func TestDBUpdateSeller(t *testing.T) {
 				"cid",
 				"company_name",
 				"address",
+				"street",
+				"number",
+				"complement",
+				"neighborhood",
+				"cep",
+				"state",
 				"telephone",
+				"telephone_display",
 				"locality_id"}).
-			AddRow(1, 1, "Mercado Libre", "", "", "")
+			AddRow(1, 1, "Mercado Libre", "", "", "", "", "", "", "", "", "", "")
 
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneSeller)).
 			WithArgs(1).WillReturnRows(newRow)
 
 		sellersRepo := NewMariaDbRepository(db)
 
-		seller, err := sellersRepo.Update(1, requestData)
+		seller, err := sellersRepo.Update(context.Background(), 1, requestData)
 		assert.Nil(t, err)
 
 		assert.Equal(t, "Mercado Libre", seller.CompanyName)
 	})
 
+	t.Run("Address columns are only written along with the address", func(t *testing.T) {
+		finalQuery, values := queryUpdateSeller(map[string]interface{}{
+			"company_name": "Mercado Free",
+			"cep":          "01310100",
+			"state":        "SP",
+		}, 1)
+		assert.Equal(t, "UPDATE sellers SET company_name = ? WHERE id = ?", finalQuery)
+		assert.Equal(t, []interface{}{"Mercado Free", 1}, values)
+
+		_, values = queryUpdateSeller(map[string]interface{}{
+			"address":      "Av. Paulista, 1000 - Bela Vista, 01310-100, SP",
+			"street":       "Av. Paulista",
+			"number":       "1000",
+			"complement":   "",
+			"neighborhood": "Bela Vista",
+			"cep":          "01310100",
+			"state":        "SP",
+		}, 1)
+		assert.Equal(t, []interface{}{"Av. Paulista, 1000 - Bela Vista, 01310-100, SP", "Av. Paulista", "1000", "", "Bela Vista", "01310100", "SP", 1}, values)
+	})
+
+	t.Run("Telephone display is only written along with the telephone", func(t *testing.T) {
+		finalQuery, values := queryUpdateSeller(map[string]interface{}{
+			"company_name":      "Mercado Free",
+			"telephone_display": "(11) 99999-9999",
+		}, 1)
+		assert.Equal(t, "UPDATE sellers SET company_name = ? WHERE id = ?", finalQuery)
+		assert.Equal(t, []interface{}{"Mercado Free", 1}, values)
+
+		_, values = queryUpdateSeller(map[string]interface{}{
+			"telephone":         "+5511999999999",
+			"telephone_display": "(11) 99999-9999",
+		}, 1)
+		assert.Equal(t, []interface{}{"+5511999999999", "(11) 99999-9999", 1}, values)
+	})
+
 	t.Run("Exec error", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.NoError(t, err)
@@ -286,7 +363,7 @@ func TestDBUpdateSeller(t *testing.T) {
 
 		sellersRepo := NewMariaDbRepository(db)
 
-		_, err = sellersRepo.Update(1, requestData)
+		_, err = sellersRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errUpdatedSeller, err)
 	})
@@ -304,7 +381,7 @@ func TestDBUpdateSeller(t *testing.T) {
 
 		sellersRepo := NewMariaDbRepository(db)
 
-		_, err = sellersRepo.Update(1, requestData)
+		_, err = sellersRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errUpdatedSeller, err)
 	})
@@ -324,7 +401,7 @@ func TestDBUpdateSeller(t *testing.T) {
 
 		sellersRepo := NewMariaDbRepository(db)
 
-		_, err = sellersRepo.Update(1, requestData)
+		_, err = sellersRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errUpdatedSeller, err)
 	})
@@ -336,21 +413,33 @@ func TestDBFindByCID(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
-		rows := sqlmock.NewRows([]string{
-			"id",
-			"cid",
-		}).
-			AddRow(1, 1)
+		rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
 
-		mock.ExpectQuery(regexp.QuoteMeta(queryFindByCID)).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(queryFindByCID)).WithArgs("11222333000181").WillReturnRows(rows)
 
 		sellersRepo := NewMariaDbRepository(db)
-		id, err := sellersRepo.FindByCID(1)
+		id, err := sellersRepo.FindByCID(context.Background(), "11222333000181")
 		assert.Error(t, err)
 		assert.Greater(t, id, 0)
 		assert.Equal(t, "cid already exists", err.Error())
 	})
 
+	t.Run("Already exists case with an alphanumeric cid", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id"}).AddRow(3)
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryFindByCID)).WithArgs("12ABC34501DE35").WillReturnRows(rows)
+
+		sellersRepo := NewMariaDbRepository(db)
+		id, err := sellersRepo.FindByCID(context.Background(), "12ABC34501DE35")
+		assert.Equal(t, 3, id)
+		assert.Equal(t, "cid already exists", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("No rows case", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.NoError(t, err)
@@ -360,7 +449,7 @@ func TestDBFindByCID(t *testing.T) {
 			WillReturnError(sql.ErrNoRows)
 
 		sellersRepo := NewMariaDbRepository(db)
-		id, err := sellersRepo.FindByCID(1)
+		id, err := sellersRepo.FindByCID(context.Background(), "11222333000181")
 		assert.NoError(t, err)
 		assert.Equal(t, 0, id)
 	})
@@ -374,9 +463,420 @@ func TestDBFindByCID(t *testing.T) {
 			WillReturnError(errors.New("any error"))
 
 		sellersRepo := NewMariaDbRepository(db)
-		id, err := sellersRepo.FindByCID(1)
+		id, err := sellersRepo.FindByCID(context.Background(), "11222333000181")
 		assert.Error(t, err)
 		assert.Equal(t, 0, id)
 		assert.Equal(t, "failed to verify if cid already exists", err.Error())
 	})
 }
+
+func TestGetUsage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"products"}).AddRow(1)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetSellerUsage)).WithArgs(1).WillReturnRows(rows)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		usage, err := sellersRepo.GetUsage(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, SellerUsage{Products: 1}, usage)
+	})
+
+	t.Run("fail to scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetSellerUsage)).WillReturnError(sql.ErrConnDone)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		_, err = sellersRepo.GetUsage(context.Background(), 1)
+		assert.Equal(t, errGetSellerUsage, err)
+	})
+}
+
+func TestReassignAndDelete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(queryReassignSellerProducts)).WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteSeller)).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		err = sellersRepo.ReassignAndDelete(context.Background(), 1, 2)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback when reassign fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(queryReassignSellerProducts)).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		err = sellersRepo.ReassignAndDelete(context.Background(), 1, 2)
+		assert.Equal(t, errReassignSeller, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback when delete fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(queryReassignSellerProducts)).WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteSeller)).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		err = sellersRepo.ReassignAndDelete(context.Background(), 1, 2)
+		assert.Equal(t, errDeleteSeller, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetProfitabilityReport(t *testing.T) {
+	columns := []string{
+		"seller_id", "company_name", "product_id", "product_code", "description",
+		"units_sold", "revenue", "cost", "stock_quantity", "stock_value",
+	}
+
+	t.Run("success for all sellers", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, "Fresco", 10, "P10", "Milk", 3, 30, 18, 5, 30).
+			AddRow(1, "Fresco", 11, "P11", "Cheese", 0, 0, 0, 0, 0)
+
+		query, _ := queryGetProfitabilityReport(0, nil, nil)
+		assert.Contains(t, query, "os.releases_stock = 0")
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs().WillReturnRows(rows)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		report, err := sellersRepo.GetProfitabilityReport(context.Background(), 0, nil, nil)
+		assert.NoError(t, err)
+		assert.Len(t, report, 2)
+		assert.Equal(t, SellerProductProfitability{
+			SellerId:    1,
+			CompanyName: "Fresco",
+			Product: ProductProfitability{
+				ProductId:     10,
+				ProductCode:   "P10",
+				Description:   "Milk",
+				UnitsSold:     3,
+				Revenue:       30,
+				Cost:          18,
+				StockQuantity: 5,
+				StockValue:    30,
+			},
+		}, report[0])
+	})
+
+	t.Run("success filtering seller and dates", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC)
+
+		query, _ := queryGetProfitabilityReport(1, &from, &to)
+		assert.Contains(t, query, "po.order_date >= ? AND po.order_date <= ?")
+		assert.Contains(t, query, "WHERE s.id = ?")
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(from, to, 1).WillReturnRows(sqlmock.NewRows(columns))
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		report, err := sellersRepo.GetProfitabilityReport(context.Background(), 1, &from, &to)
+		assert.NoError(t, err)
+		assert.Empty(t, report)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail to query", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		query, _ := queryGetProfitabilityReport(0, nil, nil)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(sql.ErrConnDone)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		_, err = sellersRepo.GetProfitabilityReport(context.Background(), 0, nil, nil)
+		assert.Equal(t, errProfitability, err)
+	})
+
+	t.Run("fail to scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).AddRow("", "Fresco", 10, "P10", "Milk", 3, 30, 18, 5, 30)
+		query, _ := queryGetProfitabilityReport(0, nil, nil)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(rows)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		_, err = sellersRepo.GetProfitabilityReport(context.Background(), 0, nil, nil)
+		assert.Equal(t, errProfitability, err)
+	})
+}
+
+func TestGetSellerProducts(t *testing.T) {
+	columns := []string{"id", "product_code", "description", "product_type_id"}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).AddRow(10, "P10", "Milk", 1)
+		opts := listing.Options{Limit: 20, Sort: "description", Order: listing.SortAsc}
+		query := queryGetSellerProducts + " ORDER BY description IS NULL ASC, description ASC, id ASC LIMIT ? OFFSET ?"
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, 21, 0).WillReturnRows(rows)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		products, err := sellersRepo.GetProducts(context.Background(), 1, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, []SellerProduct{{Id: 10, ProductCode: "P10", Description: "Milk", ProductTypeId: 1}}, products)
+	})
+
+	t.Run("fail to query", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetSellerProducts)).WillReturnError(sql.ErrConnDone)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		_, err = sellersRepo.GetProducts(context.Background(), 1, listing.Options{})
+		assert.Equal(t, errSellerProducts, err)
+	})
+
+	t.Run("fail to scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).AddRow("", "P10", "Milk", 1)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetSellerProducts)).WillReturnRows(rows)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		_, err = sellersRepo.GetProducts(context.Background(), 1, listing.Options{})
+		assert.Equal(t, errSellerProducts, err)
+	})
+}
+
+func TestGetSellerStock(t *testing.T) {
+	columns := []string{"product_id", "product_code", "warehouse_id", "warehouse_code", "batches_count", "quantity"}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(10, "P10", 1, "W1", 2, 40).
+			AddRow(10, "P10", 2, "W2", 1, 5)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetSellerStock)).WithArgs(1).WillReturnRows(rows)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		stock, err := sellersRepo.GetStock(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Len(t, stock, 2)
+		assert.Equal(t, SellerStock{
+			ProductId:     10,
+			ProductCode:   "P10",
+			WarehouseId:   1,
+			WarehouseCode: "W1",
+			BatchesCount:  2,
+			Quantity:      40,
+		}, stock[0])
+	})
+
+	t.Run("fail to query", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetSellerStock)).WillReturnError(sql.ErrConnDone)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		_, err = sellersRepo.GetStock(context.Background(), 1)
+		assert.Equal(t, errSellerStock, err)
+	})
+
+	t.Run("fail to scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).AddRow("", "P10", 1, "W1", 2, 40)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetSellerStock)).WillReturnRows(rows)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		_, err = sellersRepo.GetStock(context.Background(), 1)
+		assert.Equal(t, errSellerStock, err)
+	})
+}
+
+func TestGetSellerInboundReceipts(t *testing.T) {
+	columns := []string{
+		"id", "order_number", "order_date", "warehouse_id", "warehouse_code",
+		"batch_number", "product_id", "product_code", "quantity",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).AddRow(7, "IO-1", "2022-01-10", 1, "W1", 100, 10, "P10", 50)
+		opts := listing.Options{
+			Limit:   20,
+			Sort:    "order_date",
+			Order:   listing.SortDesc,
+			Filters: []listing.Filter{{Field: "warehouse_id", Value: "1"}},
+		}
+		query := queryGetSellerInboundReceipts + " WHERE warehouse_id = ? ORDER BY order_date IS NULL DESC, order_date DESC, id DESC LIMIT ? OFFSET ?"
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, "1", 21, 0).WillReturnRows(rows)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		receipts, err := sellersRepo.GetInboundReceipts(context.Background(), 1, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, []SellerInboundReceipt{{
+			Id:            7,
+			OrderNumber:   "IO-1",
+			OrderDate:     "2022-01-10",
+			WarehouseId:   1,
+			WarehouseCode: "W1",
+			BatchNumber:   100,
+			ProductId:     10,
+			ProductCode:   "P10",
+			Quantity:      50,
+		}}, receipts)
+	})
+
+	t.Run("fail to query", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetSellerInboundReceipts)).WillReturnError(sql.ErrConnDone)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		_, err = sellersRepo.GetInboundReceipts(context.Background(), 1, listing.Options{})
+		assert.Equal(t, errSellerInbound, err)
+	})
+
+	t.Run("fail to scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).AddRow(7, "IO-1", "2022-01-10", "", "W1", 100, 10, "P10", 50)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetSellerInboundReceipts)).WillReturnRows(rows)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		_, err = sellersRepo.GetInboundReceipts(context.Background(), 1, listing.Options{})
+		assert.Equal(t, errSellerInbound, err)
+	})
+}
+
+func TestGetSellerExpirations(t *testing.T) {
+	columns := []string{
+		"id", "batch_number", "product_id", "product_code", "warehouse_id",
+		"section_id", "current_quantity", "due_date", "days_to_expire",
+	}
+	dueDate := time.Date(2022, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).AddRow(4, 100, 10, "P10", 1, 3, 20, dueDate, 5)
+		opts := listing.Options{
+			Limit: 20,
+			Sort:  "days_to_expire",
+			Order: listing.SortAsc,
+			After: &listing.Cursor{Sort: "days_to_expire", Order: listing.SortAsc, Value: float64(2), Id: 3},
+		}
+		query := queryGetSellerExpirations + " WHERE (days_to_expire IS NULL OR days_to_expire > ? OR (days_to_expire = ? AND id > ?))"
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, 30, float64(2), float64(2), 3, 21, 0).WillReturnRows(rows)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		expirations, err := sellersRepo.GetExpirations(context.Background(), 1, 30, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, []SellerExpiration{{
+			Id:              4,
+			BatchNumber:     100,
+			ProductId:       10,
+			ProductCode:     "P10",
+			WarehouseId:     1,
+			SectionId:       3,
+			CurrentQuantity: 20,
+			DueDate:         dueDate,
+			DaysToExpire:    5,
+		}}, expirations)
+	})
+
+	t.Run("fail to query", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetSellerExpirations)).WillReturnError(sql.ErrConnDone)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		_, err = sellersRepo.GetExpirations(context.Background(), 1, 30, listing.Options{})
+		assert.Equal(t, errSellerExpiring, err)
+	})
+
+	t.Run("fail to scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).AddRow(4, "", 10, "P10", 1, 3, 20, dueDate, 5)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetSellerExpirations)).WillReturnRows(rows)
+
+		sellersRepo := NewMariaDbRepository(db)
+
+		_, err = sellersRepo.GetExpirations(context.Background(), 1, 30, listing.Options{})
+		assert.Equal(t, errSellerExpiring, err)
+	})
+}
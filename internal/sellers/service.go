@@ -1,20 +1,38 @@
 package sellers
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"time"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/localities"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/document"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 )
 
 type Service interface {
-	Create(cid int, companyName, address, telephone, localityId string) (Seller, web.ResponseCode)
-	GetOne(id int) (Seller, web.ResponseCode)
-	GetAll() ([]Seller, web.ResponseCode)
-	Delete(id int) web.ResponseCode
-	Update(id int, requestData map[string]interface{}) (Seller, web.ResponseCode)
+	Create(ctx context.Context, cid, companyName string, addr address.Address, telephone, localityId string) (Seller, web.ResponseCode)
+	ValidateCreate(ctx context.Context, cid, companyName string, addr address.Address, telephone, localityId string) web.ResponseCode
+	GetOne(ctx context.Context, id int) (Seller, web.ResponseCode)
+	GetAll(ctx context.Context, opts listing.Options) ([]Seller, listing.Page, web.ResponseCode)
+	Delete(ctx context.Context, id int) web.ResponseCode
+	ReassignAndDelete(ctx context.Context, id, targetId int) web.ResponseCode
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Seller, web.ResponseCode)
+	GetProfitabilityReport(ctx context.Context, sellerId int, from, to *time.Time) ([]SellerProfitability, web.ResponseCode)
+	GetProducts(ctx context.Context, sellerId int, opts listing.Options) ([]SellerProduct, listing.Page, web.ResponseCode)
+	GetStock(ctx context.Context, sellerId int) ([]SellerStock, web.ResponseCode)
+	GetInboundReceipts(ctx context.Context, sellerId int, opts listing.Options) ([]SellerInboundReceipt, listing.Page, web.ResponseCode)
+	GetExpirations(ctx context.Context, sellerId, days int, opts listing.Options) ([]SellerExpiration, listing.Page, web.ResponseCode)
 }
 
+var errInvalidCid = fmt.Errorf("cid must be a valid %s", document.Describe(AcceptedDocuments))
+
 type service struct {
 	repository         Repository
 	localityRepository localities.Repository
@@ -27,19 +45,60 @@ func NewService(r Repository, lr localities.Repository) Service {
 	}
 }
 
-func (s service) Create(cid int, companyName, address, telephone, localityId string) (Seller, web.ResponseCode) {
-	if id, err := s.repository.FindByCID(cid); err != nil && id != 0 {
-		return Seller{}, web.NewCodeResponse(http.StatusConflict, err)
+// ValidateCreate applies the rules of Create without persisting the seller.
+func (s service) ValidateCreate(ctx context.Context, cid, companyName string, addr address.Address, telephone, localityId string) web.ResponseCode {
+	if cid == "" || companyName == "" || telephone == "" || localityId == "" {
+		return web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("invalid request input"))
+	}
+
+	if len(companyName) > 255 {
+		return web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("company_name too long: max 255 characters"))
+	}
+
+	addr, err := address.Link(addr, localityId)
+	if err != nil {
+		return web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+	}
+
+	if _, err := phone.Parse(telephone); err != nil {
+		return web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+	}
+
+	if len(localityId) > 255 {
+		return web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("locality_id too long: max 255 characters"))
+	}
+
+	doc, err := document.Parse(cid, AcceptedDocuments...)
+	if err != nil {
+		return web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidCid)
+	}
+
+	if id, err := s.repository.FindByCID(ctx, doc.Number); err != nil && id != 0 {
+		return web.NewCodeResponse(http.StatusConflict, err)
 	} else if id == 0 && err != nil {
-		return Seller{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
 
-	_, localityErr := s.localityRepository.GetOne(localityId)
+	locality, localityErr := s.localityRepository.GetOne(ctx, localityId)
 	if localityErr != nil {
-		return Seller{}, web.NewCodeResponse(http.StatusConflict, localityErr)
+		return web.NewCodeResponse(http.StatusConflict, localityErr)
+	}
+
+	if err := address.CheckState(addr, locality.ProvinceName); err != nil {
+		return web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+	}
+
+	return web.ResponseCode{}
+}
+
+func (s service) Create(ctx context.Context, cid, companyName string, addr address.Address, telephone, localityId string) (Seller, web.ResponseCode) {
+	if resp := s.ValidateCreate(ctx, cid, companyName, addr, telephone, localityId); resp.Err != nil {
+		return Seller{}, resp
 	}
 
-	seller, err := s.repository.Create(cid, companyName, address, telephone, localityId)
+	addr, _ = address.Link(addr, localityId)
+	number, _ := phone.Parse(telephone)
+	seller, err := s.repository.Create(ctx, document.Normalize(cid), companyName, addr, number, localityId)
 	if err != nil {
 		return Seller{}, web.NewCodeResponse(
 			http.StatusInternalServerError,
@@ -50,8 +109,8 @@ func (s service) Create(cid int, companyName, address, telephone, localityId str
 	return seller, web.NewCodeResponse(http.StatusCreated, nil)
 }
 
-func (s service) GetOne(id int) (Seller, web.ResponseCode) {
-	seller, err := s.repository.GetOne(id)
+func (s service) GetOne(ctx context.Context, id int) (Seller, web.ResponseCode) {
+	seller, err := s.repository.GetOne(ctx, id)
 
 	if err != nil {
 		return Seller{}, web.NewCodeResponse(http.StatusNotFound, err)
@@ -60,53 +119,258 @@ func (s service) GetOne(id int) (Seller, web.ResponseCode) {
 	return seller, web.NewCodeResponse(http.StatusOK, nil)
 }
 
-func (s service) GetAll() ([]Seller, web.ResponseCode) {
-	sellers, err := s.repository.GetAll()
+func (s service) GetAll(ctx context.Context, opts listing.Options) ([]Seller, listing.Page, web.ResponseCode) {
+	sellers, err := s.repository.GetAll(ctx, opts)
 
 	if err != nil {
-		return []Seller{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+		return []Seller{}, listing.Page{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
 
-	return sellers, web.NewCodeResponse(http.StatusOK, nil)
+	sellers, page := listing.Paginate(sellers, opts)
+	return sellers, page, web.NewCodeResponse(http.StatusOK, nil)
 }
 
-func (s service) Delete(id int) web.ResponseCode {
-	_, err := s.repository.GetOne(id)
-	if err != nil {
-		return web.NewCodeResponse(http.StatusNotFound, err)
+// Delete refuses to remove a seller still referenced by its products.
+func (s service) Delete(ctx context.Context, id int) web.ResponseCode {
+	if resp := s.exists(ctx, id, http.StatusNotFound); resp.Err != nil {
+		return resp
 	}
 
-	err = s.repository.Delete(id)
+	usage, err := s.repository.GetUsage(ctx, id)
 	if err != nil {
 		return web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
 
+	if resp := web.ReferencedBy("seller", id,
+		web.Reference{Table: "products", Count: usage.Products},
+	); resp.Err != nil {
+		return resp
+	}
+
+	if err := s.repository.Delete(ctx, id); err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return web.NewCodeResponse(http.StatusNoContent, nil)
+}
+
+func (s service) ReassignAndDelete(ctx context.Context, id, targetId int) web.ResponseCode {
+	if resp := s.exists(ctx, id, http.StatusNotFound); resp.Err != nil {
+		return resp
+	}
+
+	if resp := s.exists(ctx, targetId, http.StatusConflict); resp.Err != nil {
+		return resp
+	}
+
+	if err := s.repository.ReassignAndDelete(ctx, id, targetId); err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
 	return web.NewCodeResponse(http.StatusNoContent, nil)
 }
 
-func (s service) Update(id int, requestData map[string]interface{}) (Seller, web.ResponseCode) {
-	if _, err := s.repository.GetOne(id); err != nil {
+func (s service) exists(ctx context.Context, id, notFoundCode int) web.ResponseCode {
+	_, err := s.repository.GetOne(ctx, id)
+	if err == nil {
+		return web.ResponseCode{}
+	}
+
+	if err.Error() == GetErrSellerNotFound(id).Error() {
+		return web.NewCodeResponse(notFoundCode, err)
+	}
+	return web.NewCodeResponse(http.StatusInternalServerError, err)
+}
+
+func (s service) Update(ctx context.Context, id int, requestData map[string]interface{}) (Seller, web.ResponseCode) {
+	current, err := s.repository.GetOne(ctx, id)
+	if err != nil {
 		return Seller{}, web.NewCodeResponse(http.StatusNotFound, err)
 	}
 
-	if currCid := requestData["cid"]; currCid != nil {
-		selectedID, err := s.repository.FindByCID(int(currCid.(float64)))
+	if currCid, ok := requestData["cid"].(string); ok {
+		doc, err := document.Parse(currCid, AcceptedDocuments...)
+		if err != nil {
+			return Seller{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errInvalidCid)
+		}
+		requestData["cid"] = doc.Number
+
+		selectedID, err := s.repository.FindByCID(ctx, doc.Number)
 		if err != nil && id != selectedID {
 			return Seller{}, web.NewCodeResponse(http.StatusConflict, err)
 		}
 	}
 
+	if value, ok := requestData["telephone"].(string); ok {
+		number, err := phone.Parse(value)
+		if err != nil {
+			return Seller{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+		}
+		number.ToRequestData(requestData)
+	}
+
+	localityId := current.LocalityId
 	if currLocalityId := requestData["locality_id"]; currLocalityId != nil {
-		parsedLocalityId, _ := currLocalityId.(string)
-		if _, err := s.localityRepository.GetOne(parsedLocalityId); err != nil {
+		localityId, _ = currLocalityId.(string)
+	}
+
+	addr, changed, err := address.FromRequestData(requestData)
+	if err != nil {
+		return Seller{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+	}
+
+	if !changed {
+		addr = current.StructuredAddress
+	} else {
+		if addr, err = address.Link(addr, localityId); err != nil {
+			return Seller{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+		}
+		addr.ToRequestData(requestData)
+	}
+
+	if requestData["locality_id"] != nil || addr.State != current.StructuredAddress.State {
+		locality, err := s.localityRepository.GetOne(ctx, localityId)
+		if err != nil {
 			return Seller{}, web.NewCodeResponse(http.StatusConflict, err)
 		}
+		addr.LocalityId = localityId
+		if err := address.CheckState(addr, locality.ProvinceName); err != nil {
+			return Seller{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+		}
 	}
 
-	seller, err := s.repository.Update(id, requestData)
+	seller, err := s.repository.Update(ctx, id, requestData)
 	if err != nil {
 		return Seller{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
 
 	return seller, web.ResponseCode{Code: http.StatusOK, Err: nil}
 }
+
+// GetProfitabilityReport groups the product rows of the report by seller.
+// A seller informed by id is always returned, even without products.
+func (s service) GetProfitabilityReport(ctx context.Context, sellerId int, from, to *time.Time) ([]SellerProfitability, web.ResponseCode) {
+	if from != nil && to != nil && from.After(*to) {
+		return []SellerProfitability{}, web.NewCodeResponse(http.StatusUnprocessableEntity, errors.New("from must not be after to"))
+	}
+
+	var seller Seller
+	if sellerId != 0 {
+		var resp web.ResponseCode
+		if seller, resp = s.GetOne(ctx, sellerId); resp.Err != nil {
+			return []SellerProfitability{}, resp
+		}
+	}
+
+	rows, err := s.repository.GetProfitabilityReport(ctx, sellerId, from, to)
+	if err != nil {
+		return []SellerProfitability{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	report := []SellerProfitability{}
+	for _, row := range rows {
+		if len(report) == 0 || report[len(report)-1].SellerId != row.SellerId {
+			report = append(report, SellerProfitability{
+				SellerId:    row.SellerId,
+				CompanyName: row.CompanyName,
+				Products:    []ProductProfitability{},
+			})
+		}
+
+		product := row.Product
+		product.GrossMargin = product.Revenue - product.Cost
+		product.MarginPercentage = marginPercentage(product.GrossMargin, product.Revenue)
+
+		current := &report[len(report)-1]
+		current.UnitsSold += product.UnitsSold
+		current.Revenue += product.Revenue
+		current.Cost += product.Cost
+		current.StockValue += product.StockValue
+		current.Products = append(current.Products, product)
+	}
+
+	for index := range report {
+		report[index].GrossMargin = report[index].Revenue - report[index].Cost
+		report[index].MarginPercentage = marginPercentage(report[index].GrossMargin, report[index].Revenue)
+	}
+
+	if sellerId != 0 && len(report) == 0 {
+		report = append(report, SellerProfitability{
+			SellerId:    seller.Id,
+			CompanyName: seller.CompanyName,
+			Products:    []ProductProfitability{},
+		})
+	}
+
+	return report, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func marginPercentage(margin, revenue float64) float64 {
+	if revenue == 0 {
+		return 0
+	}
+	return math.Round(margin/revenue*10000) / 100
+}
+
+func (s service) GetProducts(ctx context.Context, sellerId int, opts listing.Options) ([]SellerProduct, listing.Page, web.ResponseCode) {
+	if resp := s.exists(ctx, sellerId, http.StatusNotFound); resp.Err != nil {
+		return []SellerProduct{}, listing.Page{}, resp
+	}
+
+	products, err := s.repository.GetProducts(ctx, sellerId, opts)
+	if err != nil {
+		return []SellerProduct{}, listing.Page{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	products, page := listing.Paginate(products, opts)
+	return products, page, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) GetStock(ctx context.Context, sellerId int) ([]SellerStock, web.ResponseCode) {
+	if resp := s.exists(ctx, sellerId, http.StatusNotFound); resp.Err != nil {
+		return []SellerStock{}, resp
+	}
+
+	stock, err := s.repository.GetStock(ctx, sellerId)
+	if err != nil {
+		return []SellerStock{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	return stock, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+func (s service) GetInboundReceipts(ctx context.Context, sellerId int, opts listing.Options) ([]SellerInboundReceipt, listing.Page, web.ResponseCode) {
+	if resp := s.exists(ctx, sellerId, http.StatusNotFound); resp.Err != nil {
+		return []SellerInboundReceipt{}, listing.Page{}, resp
+	}
+
+	receipts, err := s.repository.GetInboundReceipts(ctx, sellerId, opts)
+	if err != nil {
+		return []SellerInboundReceipt{}, listing.Page{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	receipts, page := listing.Paginate(receipts, opts)
+	return receipts, page, web.NewCodeResponse(http.StatusOK, nil)
+}
+
+// GetExpirations lists the seller's batches in stock expiring within days.
+func (s service) GetExpirations(ctx context.Context, sellerId, days int, opts listing.Options) ([]SellerExpiration, listing.Page, web.ResponseCode) {
+	if days < 1 {
+		return []SellerExpiration{}, listing.Page{}, web.NewCodeResponse(
+			http.StatusUnprocessableEntity,
+			errors.New("days must be greater than 0"),
+		)
+	}
+
+	if resp := s.exists(ctx, sellerId, http.StatusNotFound); resp.Err != nil {
+		return []SellerExpiration{}, listing.Page{}, resp
+	}
+
+	expirations, err := s.repository.GetExpirations(ctx, sellerId, days, opts)
+	if err != nil {
+		return []SellerExpiration{}, listing.Page{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	expirations, page := listing.Paginate(expirations, opts)
+	return expirations, page, web.NewCodeResponse(http.StatusOK, nil)
+}
@@ -1,31 +1,37 @@
 package sellers_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/localities"
 	mockLocalityRepository "github.com/emidioreb/mercado-fresco-lerigophers/internal/localities/mocks"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/sellers/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 var fakeSellers = []sellers.Seller{{
 	Id:          1,
-	Cid:         1,
+	Cid:         "11222333000181",
 	CompanyName: "Gouveia empreendimentos",
 	Address:     "Av. Nações Unidas",
-	Telephone:   "3003",
+	Telephone:   "(11) 3003-1234",
 	LocalityId:  "65760-000",
 }, {
 	Id:          2,
-	Cid:         2,
+	Cid:         "20000222000111",
 	CompanyName: "Gouveia empreendimentos",
 	Address:     "Av. Nações Unidas",
-	Telephone:   "3003",
+	Telephone:   "(11) 3003-1234",
 	LocalityId:  "65760-123",
 }}
 
@@ -33,23 +39,25 @@ func TestServiceCreate(t *testing.T) {
 	t.Run("Test if create successfully", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		mockedLocality := new(mockLocalityRepository.Repository)
-		mockedRepository.On("FindByCID", mock.AnythingOfType("int")).Return(0, nil).Once()
-		mockedLocality.On("GetOne", fakeSellers[0].LocalityId).Return(localities.Locality{}, nil).Once()
+		mockedRepository.On("FindByCID", mock.Anything, mock.AnythingOfType("string")).Return(0, nil).Once()
+		mockedLocality.On("GetOne", mock.Anything, fakeSellers[0].LocalityId).Return(localities.Locality{}, nil).Once()
 
 		mockedRepository.On("Create",
-			mock.AnythingOfType("int"),
-			mock.AnythingOfType("string"),
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
+			mock.AnythingOfType("phone.Number"),
 			mock.AnythingOfType("string"),
 		).Return(fakeSellers[0], nil).Once()
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
 
 		result, err := service.Create(
+			context.Background(),
 			fakeSellers[0].Cid,
 			fakeSellers[0].CompanyName,
-			fakeSellers[0].Address,
+			address.FromLegacy(fakeSellers[0].Address),
 			fakeSellers[0].Telephone,
 			fakeSellers[0].LocalityId)
 		assert.Nil(t, err.Err)
@@ -63,13 +71,14 @@ func TestServiceCreate(t *testing.T) {
 		mockedLocality := new(mockLocalityRepository.Repository)
 
 		expectedError := errors.New("cid already exists")
-		mockedRepository.On("FindByCID", mock.AnythingOfType("int")).Return(1, expectedError).Once()
+		mockedRepository.On("FindByCID", mock.Anything, mock.AnythingOfType("string")).Return(1, expectedError).Once()
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
 		_, resp := service.Create(
+			context.Background(),
 			fakeSellers[0].Cid,
 			fakeSellers[0].CompanyName,
-			fakeSellers[0].Address,
+			address.FromLegacy(fakeSellers[0].Address),
 			fakeSellers[0].Telephone,
 			fakeSellers[0].LocalityId)
 
@@ -84,13 +93,14 @@ func TestServiceCreate(t *testing.T) {
 		mockedLocality := new(mockLocalityRepository.Repository)
 
 		expectedError := errors.New("some error")
-		mockedRepository.On("FindByCID", mock.AnythingOfType("int")).Return(0, expectedError).Once()
+		mockedRepository.On("FindByCID", mock.Anything, mock.AnythingOfType("string")).Return(0, expectedError).Once()
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
 		_, resp := service.Create(
+			context.Background(),
 			fakeSellers[0].Cid,
 			fakeSellers[0].CompanyName,
-			fakeSellers[0].Address,
+			address.FromLegacy(fakeSellers[0].Address),
 			fakeSellers[0].Telephone,
 			fakeSellers[0].LocalityId)
 
@@ -105,14 +115,15 @@ func TestServiceCreate(t *testing.T) {
 		mockedLocality := new(mockLocalityRepository.Repository)
 
 		expectedError := errors.New("some error")
-		mockedRepository.On("FindByCID", mock.AnythingOfType("int")).Return(1, nil).Once()
-		mockedLocality.On("GetOne", mock.AnythingOfType("string")).Return(localities.Locality{}, expectedError)
+		mockedRepository.On("FindByCID", mock.Anything, mock.AnythingOfType("string")).Return(1, nil).Once()
+		mockedLocality.On("GetOne", mock.Anything, mock.AnythingOfType("string")).Return(localities.Locality{}, expectedError)
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
 		_, resp := service.Create(
+			context.Background(),
 			fakeSellers[0].Cid,
 			fakeSellers[0].CompanyName,
-			fakeSellers[0].Address,
+			address.FromLegacy(fakeSellers[0].Address),
 			fakeSellers[0].Telephone,
 			fakeSellers[0].LocalityId)
 
@@ -127,21 +138,23 @@ func TestServiceCreate(t *testing.T) {
 		mockedLocality := new(mockLocalityRepository.Repository)
 
 		expectedError := errors.New("some error")
-		mockedRepository.On("FindByCID", mock.AnythingOfType("int")).Return(1, nil).Once()
-		mockedLocality.On("GetOne", mock.AnythingOfType("string")).Return(localities.Locality{}, nil)
+		mockedRepository.On("FindByCID", mock.Anything, mock.AnythingOfType("string")).Return(1, nil).Once()
+		mockedLocality.On("GetOne", mock.Anything, mock.AnythingOfType("string")).Return(localities.Locality{}, nil)
 		mockedRepository.On("Create",
-			mock.AnythingOfType("int"),
-			mock.AnythingOfType("string"),
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
+			mock.AnythingOfType("phone.Number"),
 			mock.AnythingOfType("string"),
 		).Return(sellers.Seller{}, expectedError)
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
 		_, resp := service.Create(
+			context.Background(),
 			fakeSellers[0].Cid,
 			fakeSellers[0].CompanyName,
-			fakeSellers[0].Address,
+			address.FromLegacy(fakeSellers[0].Address),
 			fakeSellers[0].Telephone,
 			fakeSellers[0].LocalityId)
 
@@ -155,61 +168,210 @@ func TestServiceCreate(t *testing.T) {
 func TestServiceDelete(t *testing.T) {
 	t.Run("Verify the successfully case if the seller is deleted", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		mockedLocality := new(mockLocalityRepository.Repository)
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(sellers.Seller{}, nil)
-		mockedRepository.On("Delete", mock.AnythingOfType("int")).Return(nil)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sellers.Seller{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(sellers.SellerUsage{}, nil).Once()
+		mockedRepository.On("Delete", mock.Anything, 1).Return(nil).Once()
 
-		service := sellers.NewService(mockedRepository, mockedLocality)
-		result := service.Delete(1)
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.Delete(context.Background(), 1)
 		assert.Nil(t, result.Err)
 
-		assert.Equal(t, result.Code, http.StatusNoContent)
+		assert.Equal(t, http.StatusNoContent, result.Code)
 		mockedRepository.AssertExpectations(t)
 	})
 
 	t.Run("Verify the error case if seller do not exists", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		mockedLocality := new(mockLocalityRepository.Repository)
-		expectedError := errors.New("seller with id 1 not found")
+		expectedError := sellers.GetErrSellerNotFound(1)
 
-		mockedRepository.On(
-			"GetOne",
-			mock.AnythingOfType("int"),
-		).
-			Return(
-				sellers.Seller{},
-				expectedError,
-			)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sellers.Seller{}, expectedError).Once()
 
-		service := sellers.NewService(mockedRepository, mockedLocality)
-		result := service.Delete(1)
-		assert.NotNil(t, result.Err)
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.Delete(context.Background(), 1)
 
 		assert.Equal(t, http.StatusNotFound, result.Code)
 		assert.Equal(t, expectedError, result.Err)
-		mockedRepository.AssertExpectations(t)
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("Verify the conflict case if seller is still referenced", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sellers.Seller{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(sellers.SellerUsage{Products: 4}, nil).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusConflict, result.Code)
+		assert.Equal(t, "seller with id 1 is used by 4 products", result.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("Fail when verify seller usage", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := errors.New("unexpected error to verify seller usage")
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sellers.Seller{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(sellers.SellerUsage{}, expectedError).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusInternalServerError, result.Code)
+		assert.Equal(t, expectedError, result.Err)
 	})
 
 	t.Run("Fail when delete seller", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		mockedLocality := new(mockLocalityRepository.Repository)
-		expectedError := errors.New("cannot delete seller")
+		expectedError := errors.New("unexpected error to delete seller")
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).
-			Return(sellers.Seller{}, nil)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sellers.Seller{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(sellers.SellerUsage{}, nil).Once()
+		mockedRepository.On("Delete", mock.Anything, 1).Return(expectedError).Once()
 
-		mockedRepository.On("Delete", mock.AnythingOfType("int")).
-			Return(expectedError)
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.Delete(context.Background(), 1)
 
-		service := sellers.NewService(mockedRepository, mockedLocality)
-		result := service.Delete(1)
-		assert.Error(t, result.Err)
+		assert.Equal(t, http.StatusInternalServerError, result.Code)
+		assert.Equal(t, expectedError, result.Err)
+	})
+}
+
+func TestServiceReassignAndDelete(t *testing.T) {
+	t.Run("Verify the successfully case if the dependents are moved", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sellers.Seller{Id: 1}, nil).Once()
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(sellers.Seller{Id: 2}, nil).Once()
+		mockedRepository.On("ReassignAndDelete", mock.Anything, 1, 2).Return(nil).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.ReassignAndDelete(context.Background(), 1, 2)
+		assert.Nil(t, result.Err)
+
+		assert.Equal(t, http.StatusNoContent, result.Code)
+		mockedRepository.AssertExpectations(t)
+	})
+
+	t.Run("Verify the conflict case if target do not exists", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := sellers.GetErrSellerNotFound(2)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sellers.Seller{Id: 1}, nil).Once()
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(sellers.Seller{}, expectedError).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.ReassignAndDelete(context.Background(), 1, 2)
+
+		assert.Equal(t, http.StatusConflict, result.Code)
+		assert.Equal(t, expectedError, result.Err)
+		mockedRepository.AssertNumberOfCalls(t, "ReassignAndDelete", 0)
+	})
+
+	t.Run("Fail when reassign seller dependents", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := errors.New("unexpected error to reassign seller dependents")
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(sellers.Seller{Id: 1}, nil).Once()
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(sellers.Seller{Id: 2}, nil).Once()
+		mockedRepository.On("ReassignAndDelete", mock.Anything, 1, 2).Return(expectedError).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.ReassignAndDelete(context.Background(), 1, 2)
 
 		assert.Equal(t, http.StatusInternalServerError, result.Code)
 		assert.Equal(t, expectedError, result.Err)
+	})
+}
+
+func TestServiceValidateCreate(t *testing.T) {
+	t.Run("Test if validate without creating the seller", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedRepository.On("FindByCID", mock.Anything, fakeSellers[0].Cid).Return(0, nil).Once()
+		mockedLocality.On("GetOne", mock.Anything, fakeSellers[0].LocalityId).Return(localities.Locality{}, nil).Once()
+
+		service := sellers.NewService(mockedRepository, mockedLocality)
+		resp := service.ValidateCreate(
+			context.Background(),
+			fakeSellers[0].Cid,
+			fakeSellers[0].CompanyName,
+			address.FromLegacy(fakeSellers[0].Address),
+			fakeSellers[0].Telephone,
+			fakeSellers[0].LocalityId)
+
+		assert.Nil(t, resp.Err)
+		mockedRepository.AssertExpectations(t)
+		mockedRepository.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("Test error case if a field is missing", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+
+		service := sellers.NewService(mockedRepository, mockedLocality)
+		resp := service.ValidateCreate(context.Background(), fakeSellers[0].Cid, "", address.FromLegacy(fakeSellers[0].Address), fakeSellers[0].Telephone, fakeSellers[0].LocalityId)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "invalid request input", resp.Err.Error())
+	})
+
+	t.Run("Test if the cid is normalized before checking duplicates", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedRepository.On("FindByCID", mock.Anything, "12ABC34501DE35").Return(0, nil).Once()
+		mockedLocality.On("GetOne", mock.Anything, fakeSellers[0].LocalityId).Return(localities.Locality{}, nil).Once()
+
+		service := sellers.NewService(mockedRepository, mockedLocality)
+		resp := service.ValidateCreate(context.Background(), "12.abc.345/01de-35", fakeSellers[0].CompanyName, address.FromLegacy(fakeSellers[0].Address), fakeSellers[0].Telephone, fakeSellers[0].LocalityId)
+
+		assert.Nil(t, resp.Err)
 		mockedRepository.AssertExpectations(t)
 	})
+
+	t.Run("Test error case if cid is not a valid document", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+
+		service := sellers.NewService(mockedRepository, mockedLocality)
+		resp := service.ValidateCreate(context.Background(), "123", fakeSellers[0].CompanyName, address.FromLegacy(fakeSellers[0].Address), fakeSellers[0].Telephone, fakeSellers[0].LocalityId)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "cid must be a valid CNPJ or CPF", resp.Err.Error())
+	})
+
+	t.Run("Test error case if state is not the locality province", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+		mockedRepository.On("FindByCID", mock.Anything, fakeSellers[0].Cid).Return(0, nil).Once()
+		mockedLocality.On("GetOne", mock.Anything, fakeSellers[0].LocalityId).Return(localities.Locality{ProvinceName: "MA"}, nil).Once()
+
+		service := sellers.NewService(mockedRepository, mockedLocality)
+		addr := address.Address{Street: "Av. Nações Unidas", Cep: "04578-000", State: "SP"}
+		resp := service.ValidateCreate(context.Background(), fakeSellers[0].Cid, fakeSellers[0].CompanyName, addr, fakeSellers[0].Telephone, fakeSellers[0].LocalityId)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "state SP does not match the province MA of locality 65760-000", resp.Err.Error())
+	})
+
+	t.Run("Test error case if telephone is not a valid number", func(t *testing.T) {
+		service := sellers.NewService(new(mocks.Repository), new(mockLocalityRepository.Repository))
+		resp := service.ValidateCreate(context.Background(), fakeSellers[0].Cid, fakeSellers[0].CompanyName, address.FromLegacy(fakeSellers[0].Address), "123", fakeSellers[0].LocalityId)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, phone.ErrInvalid, resp.Err)
+	})
+
+	t.Run("Test error case if cep is malformed", func(t *testing.T) {
+		service := sellers.NewService(new(mocks.Repository), new(mockLocalityRepository.Repository))
+		addr := address.Address{Street: "Av. Nações Unidas", Cep: "04578"}
+		resp := service.ValidateCreate(context.Background(), fakeSellers[0].Cid, fakeSellers[0].CompanyName, addr, fakeSellers[0].Telephone, fakeSellers[0].LocalityId)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, address.ErrInvalidCep, resp.Err)
+	})
 }
 
 func TestServiceGetAll(t *testing.T) {
@@ -217,11 +379,11 @@ func TestServiceGetAll(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		mockedLocality := new(mockLocalityRepository.Repository)
 
-		mockedRepository.On("GetAll").Return(fakeSellers, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return(fakeSellers, nil)
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
 
-		result, err := service.GetAll()
+		result, _, err := service.GetAll(context.Background(), listing.Options{})
 		assert.Nil(t, err.Err)
 
 		assert.Len(t, result, 2)
@@ -234,11 +396,11 @@ func TestServiceGetAll(t *testing.T) {
 		mockedLocality := new(mockLocalityRepository.Repository)
 
 		expectedError := errors.New("any error")
-		mockedRepository.On("GetAll").Return([]sellers.Seller{}, expectedError)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]sellers.Seller{}, expectedError)
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
 
-		_, err := service.GetAll()
+		_, _, err := service.GetAll(context.Background(), listing.Options{})
 		assert.Error(t, err.Err)
 		assert.Equal(t, expectedError, err.Err)
 		mockedRepository.AssertExpectations(t)
@@ -250,11 +412,11 @@ func TestServiceGetOne(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		mockedLocality := new(mockLocalityRepository.Repository)
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(fakeSellers[0], nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(fakeSellers[0], nil)
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
 
-		result, err := service.GetOne(1)
+		result, err := service.GetOne(context.Background(), 1)
 		assert.Nil(t, err.Err)
 
 		assert.Equal(t, fakeSellers[0], result)
@@ -268,11 +430,12 @@ func TestServiceGetOne(t *testing.T) {
 
 		mockedRepository.On(
 			"GetOne",
+			mock.Anything,
 			mock.AnythingOfType("int")).
 			Return(sellers.Seller{}, expectedError)
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
-		_, err := service.GetOne(1)
+		_, err := service.GetOne(context.Background(), 1)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, http.StatusNotFound, err.Code)
@@ -288,32 +451,33 @@ func TestServiceUpdate(t *testing.T) {
 		mockedLocality := new(mockLocalityRepository.Repository)
 
 		requestData := map[string]interface{}{
-			"cid":          1.0,
+			"cid":          "11.222.333/0001-81",
 			"company_name": "Mercado Solto",
 			"address":      "Av. Fake das Dores",
-			"telephone":    "12345",
+			"telephone":    "(11) 99999-0000",
 			"locality_id":  "12345",
 		}
 
 		expectedSeller := sellers.Seller{
 			Id:          1,
-			Cid:         1,
+			Cid:         "11222333000181",
 			CompanyName: "Mercado Solto",
 			Address:     "Av. Fake das Dores",
-			Telephone:   "12345",
+			Telephone:   "+5511999990000",
 			LocalityId:  "12345",
 		}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(fakeSellers[0], nil)
-		mockedRepository.On("FindByCID", mock.AnythingOfType("int")).Return(0, nil)
-		mockedLocality.On("GetOne", mock.AnythingOfType("string")).Return(localities.Locality{}, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(fakeSellers[0], nil)
+		mockedRepository.On("FindByCID", mock.Anything, "11222333000181").Return(0, nil)
+		mockedLocality.On("GetOne", mock.Anything, mock.AnythingOfType("string")).Return(localities.Locality{}, nil)
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(expectedSeller, nil)
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
-		result, err := service.Update(1, requestData)
+		result, err := service.Update(context.Background(), 1, requestData)
 
 		assert.Nil(t, err.Err)
 		assert.Equal(t, expectedSeller, result)
@@ -326,11 +490,11 @@ func TestServiceUpdate(t *testing.T) {
 		expectedError := errors.New("seller with id 1 not found")
 		requestData := map[string]interface{}{}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).
 			Return(sellers.Seller{}, expectedError).Once()
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
-		_, err := service.Update(1, requestData)
+		_, err := service.Update(context.Background(), 1, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, http.StatusNotFound, err.Code)
@@ -341,22 +505,35 @@ func TestServiceUpdate(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		mockedLocality := new(mockLocalityRepository.Repository)
 
-		requestData := map[string]interface{}{"cid": 2.0}
+		requestData := map[string]interface{}{"cid": fakeSellers[1].Cid}
 
 		expectedError := errors.New("cid already exists")
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(sellers.Seller{}, nil)
-		mockedRepository.On("FindByCID", mock.AnythingOfType("int")).Return(1, expectedError)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(sellers.Seller{}, nil)
+		mockedRepository.On("FindByCID", mock.Anything, mock.AnythingOfType("string")).Return(1, expectedError)
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
 
-		_, resp := service.Update(2, requestData)
+		_, resp := service.Update(context.Background(), 2, requestData)
 		assert.Error(t, resp.Err)
 
 		assert.Equal(t, expectedError, resp.Err)
 		assert.Equal(t, http.StatusConflict, resp.Code)
 	})
 
+	t.Run("Test error case if seller CID is not a valid document", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeSellers[0], nil)
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+
+		_, resp := service.Update(context.Background(), 1, map[string]interface{}{"cid": "11.222.333/0001-80"})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "cid must be a valid CNPJ or CPF", resp.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "FindByCID", 0)
+	})
+
 	t.Run("Update seller when locality_id do not exists", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 		mockedLocality := new(mockLocalityRepository.Repository)
@@ -365,12 +542,12 @@ func TestServiceUpdate(t *testing.T) {
 
 		expectedError := errors.New("cid already exists")
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(sellers.Seller{}, nil)
-		mockedLocality.On("GetOne", mock.AnythingOfType("string")).Return(localities.Locality{}, expectedError)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(sellers.Seller{}, nil)
+		mockedLocality.On("GetOne", mock.Anything, mock.AnythingOfType("string")).Return(localities.Locality{}, expectedError)
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
 
-		_, resp := service.Update(1, requestData)
+		_, resp := service.Update(context.Background(), 1, requestData)
 		assert.Error(t, resp.Err)
 
 		assert.Equal(t, expectedError, resp.Err)
@@ -385,18 +562,209 @@ func TestServiceUpdate(t *testing.T) {
 
 		expectedError := errors.New("cid already exists")
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(sellers.Seller{}, nil)
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(sellers.Seller{}, nil)
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(sellers.Seller{}, expectedError)
 
 		service := sellers.NewService(mockedRepository, mockedLocality)
 
-		_, resp := service.Update(1, requestData)
+		_, resp := service.Update(context.Background(), 1, requestData)
 		assert.Error(t, resp.Err)
 
 		assert.Equal(t, expectedError, resp.Err)
 		assert.Equal(t, http.StatusInternalServerError, resp.Code)
 	})
 }
+
+func TestServiceGetProfitabilityReport(t *testing.T) {
+	rows := []sellers.SellerProductProfitability{
+		{SellerId: 1, CompanyName: "Fresco", Product: sellers.ProductProfitability{ProductId: 10, UnitsSold: 3, Revenue: 30, Cost: 18, StockValue: 12}},
+		{SellerId: 1, CompanyName: "Fresco", Product: sellers.ProductProfitability{ProductId: 11, UnitsSold: 1, Revenue: 10, Cost: 12, StockValue: 4}},
+		{SellerId: 2, CompanyName: "Horta", Product: sellers.ProductProfitability{ProductId: 12}},
+	}
+
+	t.Run("Test if group the products by seller", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetProfitabilityReport", mock.Anything, 0, (*time.Time)(nil), (*time.Time)(nil)).Return(rows, nil).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		report, resp := service.GetProfitabilityReport(context.Background(), 0, nil, nil)
+
+		assert.Nil(t, resp.Err)
+		assert.Len(t, report, 2)
+
+		assert.Equal(t, 4, report[0].UnitsSold)
+		assert.Equal(t, 40.0, report[0].Revenue)
+		assert.Equal(t, 30.0, report[0].Cost)
+		assert.Equal(t, 10.0, report[0].GrossMargin)
+		assert.Equal(t, 25.0, report[0].MarginPercentage)
+		assert.Equal(t, 16.0, report[0].StockValue)
+		assert.Equal(t, 12.0, report[0].Products[0].GrossMargin)
+		assert.Equal(t, 40.0, report[0].Products[0].MarginPercentage)
+		assert.Equal(t, -2.0, report[0].Products[1].GrossMargin)
+		assert.Equal(t, -20.0, report[0].Products[1].MarginPercentage)
+
+		assert.Equal(t, 0.0, report[1].MarginPercentage)
+		assert.Len(t, report[1].Products, 1)
+	})
+
+	t.Run("Test if return a seller without products", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeSellers[0], nil).Once()
+		mockedRepository.On("GetProfitabilityReport", mock.Anything, 1, &from, (*time.Time)(nil)).Return([]sellers.SellerProductProfitability{}, nil).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		report, resp := service.GetProfitabilityReport(context.Background(), 1, &from, nil)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, []sellers.SellerProfitability{{
+			SellerId:    fakeSellers[0].Id,
+			CompanyName: fakeSellers[0].CompanyName,
+			Products:    []sellers.ProductProfitability{},
+		}}, report)
+	})
+
+	t.Run("Test error case if seller do not exists", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := sellers.GetErrSellerNotFound(9)
+		mockedRepository.On("GetOne", mock.Anything, 9).Return(sellers.Seller{}, expectedError).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.GetProfitabilityReport(context.Background(), 9, nil, nil)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.Equal(t, expectedError, resp.Err)
+	})
+
+	t.Run("Test error case if from is after to", func(t *testing.T) {
+		from := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		service := sellers.NewService(new(mocks.Repository), new(mockLocalityRepository.Repository))
+		_, resp := service.GetProfitabilityReport(context.Background(), 0, &from, &to)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "from must not be after to", resp.Err.Error())
+	})
+
+	t.Run("Test internal server error on report", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := errors.New("error to report sellers profitability")
+		mockedRepository.On("GetProfitabilityReport", mock.Anything, 0, (*time.Time)(nil), (*time.Time)(nil)).
+			Return([]sellers.SellerProductProfitability{}, expectedError).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.GetProfitabilityReport(context.Background(), 0, nil, nil)
+
+		assert.Equal(t, web.NewCodeResponse(http.StatusInternalServerError, expectedError), resp)
+	})
+}
+
+func TestServiceGetSellerViews(t *testing.T) {
+	t.Run("Test if get the seller products", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		products := []sellers.SellerProduct{{Id: 10, ProductCode: "P10"}, {Id: 11, ProductCode: "P11"}}
+		opts := listing.Options{Limit: 1, Sort: "id", Order: listing.SortAsc}
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeSellers[0], nil).Once()
+		mockedRepository.On("GetProducts", mock.Anything, 1, opts).Return(products, nil).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result, page, resp := service.GetProducts(context.Background(), 1, opts)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, products[:1], result)
+		assert.True(t, page.HasMore)
+	})
+
+	t.Run("Test if get the seller stock", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		stock := []sellers.SellerStock{{ProductId: 10, WarehouseId: 1, Quantity: 40}}
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeSellers[0], nil).Once()
+		mockedRepository.On("GetStock", mock.Anything, 1).Return(stock, nil).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result, resp := service.GetStock(context.Background(), 1)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, stock, result)
+	})
+
+	t.Run("Test if get the seller inbound receipts", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		receipts := []sellers.SellerInboundReceipt{{OrderNumber: "IO-1", ProductId: 10}}
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeSellers[0], nil).Once()
+		mockedRepository.On("GetInboundReceipts", mock.Anything, 1, listing.Options{}).Return(receipts, nil).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result, _, resp := service.GetInboundReceipts(context.Background(), 1, listing.Options{})
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, receipts, result)
+	})
+
+	t.Run("Test if get the seller upcoming expirations", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expirations := []sellers.SellerExpiration{{BatchNumber: 100, DaysToExpire: 5}}
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeSellers[0], nil).Once()
+		mockedRepository.On("GetExpirations", mock.Anything, 1, 30, listing.Options{}).Return(expirations, nil).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result, _, resp := service.GetExpirations(context.Background(), 1, 30, listing.Options{})
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, expirations, result)
+	})
+
+	t.Run("Test error case if seller do not exists", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := sellers.GetErrSellerNotFound(9)
+		mockedRepository.On("GetOne", mock.Anything, 9).Return(sellers.Seller{}, expectedError)
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+
+		_, _, resp := service.GetProducts(context.Background(), 9, listing.Options{})
+		assert.Equal(t, web.NewCodeResponse(http.StatusNotFound, expectedError), resp)
+		_, resp = service.GetStock(context.Background(), 9)
+		assert.Equal(t, web.NewCodeResponse(http.StatusNotFound, expectedError), resp)
+		_, _, resp = service.GetInboundReceipts(context.Background(), 9, listing.Options{})
+		assert.Equal(t, web.NewCodeResponse(http.StatusNotFound, expectedError), resp)
+		_, _, resp = service.GetExpirations(context.Background(), 9, 30, listing.Options{})
+		assert.Equal(t, web.NewCodeResponse(http.StatusNotFound, expectedError), resp)
+		mockedRepository.AssertNumberOfCalls(t, "GetProducts", 0)
+	})
+
+	t.Run("Test error case if days is not positive", func(t *testing.T) {
+		service := sellers.NewService(new(mocks.Repository), new(mockLocalityRepository.Repository))
+		_, _, resp := service.GetExpirations(context.Background(), 1, 0, listing.Options{})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "days must be greater than 0", resp.Err.Error())
+	})
+
+	t.Run("Test internal server error on the seller views", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := errors.New("couldn't get the seller stock")
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(fakeSellers[0], nil)
+		mockedRepository.On("GetProducts", mock.Anything, 1, listing.Options{}).Return([]sellers.SellerProduct{}, expectedError).Once()
+		mockedRepository.On("GetStock", mock.Anything, 1).Return([]sellers.SellerStock{}, expectedError).Once()
+		mockedRepository.On("GetInboundReceipts", mock.Anything, 1, listing.Options{}).Return([]sellers.SellerInboundReceipt{}, expectedError).Once()
+		mockedRepository.On("GetExpirations", mock.Anything, 1, 30, listing.Options{}).Return([]sellers.SellerExpiration{}, expectedError).Once()
+
+		service := sellers.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+
+		_, _, resp := service.GetProducts(context.Background(), 1, listing.Options{})
+		assert.Equal(t, web.NewCodeResponse(http.StatusInternalServerError, expectedError), resp)
+		_, resp = service.GetStock(context.Background(), 1)
+		assert.Equal(t, web.NewCodeResponse(http.StatusInternalServerError, expectedError), resp)
+		_, _, resp = service.GetInboundReceipts(context.Background(), 1, listing.Options{})
+		assert.Equal(t, web.NewCodeResponse(http.StatusInternalServerError, expectedError), resp)
+		_, _, resp = service.GetExpirations(context.Background(), 1, 30, listing.Options{})
+		assert.Equal(t, web.NewCodeResponse(http.StatusInternalServerError, expectedError), resp)
+	})
+}
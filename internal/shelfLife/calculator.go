@@ -0,0 +1,118 @@
+package shelf_life
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	fullShelfLife = 100.0
+
+	// q10 is how many times faster a product spoils for every 10 degrees
+	// above its recommended temperature.
+	q10 = 2.0
+)
+
+// Calculate estimates how much of the shelf life of a batch was already
+// consumed, when it will spoil and how long it takes to reach the
+// recommended temperature. Each reading is assumed to hold until the next
+// one, the first reading also covers the time before it, and the current
+// temperature is used to project the remaining shelf life.
+func Calculate(input Input, now time.Time) Estimate {
+	estimate := Estimate{
+		FreezingTimeHours: freezingTime(input),
+	}
+
+	if input.ExpirationRate <= 0 {
+		return estimate
+	}
+
+	used, spoiledAt := consumed(input, now)
+	estimate.ShelfLifeUsedPercent = round(math.Min(used, fullShelfLife))
+
+	if spoiledAt != nil {
+		remaining := 0.0
+		estimate.Spoiled = true
+		estimate.RemainingHours = &remaining
+		estimate.EstimatedSpoilageDate = spoiledAt
+	} else {
+		remaining := (fullShelfLife - used) / hourlyRate(input, input.CurrentTemperature)
+		spoilage := now.Add(time.Duration(remaining * float64(time.Hour))).Truncate(time.Second)
+		remaining = round(remaining)
+		estimate.RemainingHours = &remaining
+		estimate.EstimatedSpoilageDate = &spoilage
+	}
+
+	estimate.SpoilsBeforeDueDate = !input.DueDate.IsZero() && estimate.EstimatedSpoilageDate.Before(input.DueDate)
+
+	return estimate
+}
+
+// consumed walks the temperature history from the production until now and
+// returns the percentage of the shelf life used, along with the moment the
+// product spoiled when it already happened.
+func consumed(input Input, now time.Time) (float64, *time.Time) {
+	readings := make([]Reading, len(input.Readings))
+	copy(readings, input.Readings)
+	sort.SliceStable(readings, func(i, j int) bool {
+		return readings[i].RecordedAt.Before(readings[j].RecordedAt)
+	})
+
+	temperature := input.CurrentTemperature
+	if len(readings) > 0 {
+		temperature = readings[0].Temperature
+	}
+
+	used := 0.0
+	start := input.ProducedAt
+
+	consume := func(end time.Time) *time.Time {
+		if !end.After(start) {
+			return nil
+		}
+
+		rate := hourlyRate(input, temperature)
+		hours := end.Sub(start).Hours()
+		if used+rate*hours >= fullShelfLife {
+			spoiledAt := start.Add(time.Duration((fullShelfLife - used) / rate * float64(time.Hour))).Truncate(time.Second)
+			used = fullShelfLife
+			return &spoiledAt
+		}
+
+		used += rate * hours
+		start = end
+		return nil
+	}
+
+	for _, reading := range readings {
+		if reading.RecordedAt.After(now) {
+			break
+		}
+		if spoiledAt := consume(reading.RecordedAt); spoiledAt != nil {
+			return used, spoiledAt
+		}
+		temperature = reading.Temperature
+	}
+
+	return used, consume(now)
+}
+
+// hourlyRate is the percentage of the shelf life consumed per hour at the
+// given temperature.
+func hourlyRate(input Input, temperature float64) float64 {
+	return input.ExpirationRate / 24 * math.Pow(q10, (temperature-input.RecommendedTemperature)/10)
+}
+
+func freezingTime(input Input) *float64 {
+	if input.FreezingRate <= 0 {
+		return nil
+	}
+
+	hours := round(math.Max(0, input.CurrentTemperature-input.RecommendedTemperature) / input.FreezingRate)
+	return &hours
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,105 @@
+package shelf_life_test
+
+import (
+	"testing"
+	"time"
+
+	shelf_life "github.com/emidioreb/mercado-fresco-lerigophers/internal/shelfLife"
+	"github.com/stretchr/testify/assert"
+)
+
+var producedAt = time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func TestCalculate(t *testing.T) {
+	t.Run("Test estimate at the recommended temperature", func(t *testing.T) {
+		input := shelf_life.Input{
+			ProducedAt:             producedAt,
+			DueDate:                producedAt.AddDate(0, 0, 20),
+			CurrentTemperature:     -18,
+			ExpirationRate:         10,
+			FreezingRate:           2,
+			RecommendedTemperature: -18,
+		}
+
+		estimate := shelf_life.Calculate(input, producedAt.AddDate(0, 0, 5))
+
+		assert.Equal(t, 50.0, estimate.ShelfLifeUsedPercent)
+		assert.Equal(t, 120.0, *estimate.RemainingHours)
+		assert.Equal(t, producedAt.AddDate(0, 0, 10), *estimate.EstimatedSpoilageDate)
+		assert.Equal(t, 0.0, *estimate.FreezingTimeHours)
+		assert.False(t, estimate.Spoiled)
+		assert.True(t, estimate.SpoilsBeforeDueDate)
+	})
+
+	t.Run("Test warmer readings speed up the spoilage", func(t *testing.T) {
+		input := shelf_life.Input{
+			ProducedAt:         producedAt,
+			DueDate:            producedAt.AddDate(0, 0, 7),
+			CurrentTemperature: -18,
+			Readings: []shelf_life.Reading{
+				{Temperature: -18, RecordedAt: producedAt.AddDate(0, 0, 2)},
+				{Temperature: -8, RecordedAt: producedAt.AddDate(0, 0, 1)},
+			},
+			ExpirationRate:         10,
+			FreezingRate:           5,
+			RecommendedTemperature: -18,
+		}
+
+		estimate := shelf_life.Calculate(input, producedAt.AddDate(0, 0, 3))
+
+		// day 1 at -8 counts the first reading before it was recorded,
+		// day 2 at -8 again and day 3 back at -18
+		assert.Equal(t, 50.0, estimate.ShelfLifeUsedPercent)
+		assert.Equal(t, 120.0, *estimate.RemainingHours)
+		assert.False(t, estimate.SpoilsBeforeDueDate)
+	})
+
+	t.Run("Test spoiled batch reports when it spoiled", func(t *testing.T) {
+		input := shelf_life.Input{
+			ProducedAt:             producedAt,
+			DueDate:                producedAt.AddDate(0, 0, 30),
+			CurrentTemperature:     2,
+			ExpirationRate:         10,
+			RecommendedTemperature: -18,
+		}
+
+		estimate := shelf_life.Calculate(input, producedAt.AddDate(0, 0, 5))
+
+		assert.True(t, estimate.Spoiled)
+		assert.Equal(t, 100.0, estimate.ShelfLifeUsedPercent)
+		assert.Equal(t, 0.0, *estimate.RemainingHours)
+		assert.Equal(t, producedAt.Add(60*time.Hour), *estimate.EstimatedSpoilageDate)
+		assert.True(t, estimate.SpoilsBeforeDueDate)
+		assert.Nil(t, estimate.FreezingTimeHours)
+	})
+
+	t.Run("Test freezing time from the current temperature", func(t *testing.T) {
+		input := shelf_life.Input{
+			ProducedAt:             producedAt,
+			CurrentTemperature:     2,
+			FreezingRate:           4,
+			RecommendedTemperature: -18,
+		}
+
+		estimate := shelf_life.Calculate(input, producedAt)
+
+		assert.Equal(t, 5.0, *estimate.FreezingTimeHours)
+		assert.Nil(t, estimate.RemainingHours)
+		assert.Nil(t, estimate.EstimatedSpoilageDate)
+		assert.False(t, estimate.SpoilsBeforeDueDate)
+	})
+
+	t.Run("Test batch not produced yet", func(t *testing.T) {
+		input := shelf_life.Input{
+			ProducedAt:             producedAt,
+			CurrentTemperature:     -18,
+			ExpirationRate:         24,
+			RecommendedTemperature: -18,
+		}
+
+		estimate := shelf_life.Calculate(input, producedAt.Add(-time.Hour))
+
+		assert.Equal(t, 0.0, estimate.ShelfLifeUsedPercent)
+		assert.Equal(t, 100.0, *estimate.RemainingHours)
+	})
+}
@@ -0,0 +1,32 @@
+package shelf_life
+
+import "time"
+
+// Reading is a temperature measured for a batch at a given moment.
+type Reading struct {
+	Temperature float64
+	RecordedAt  time.Time
+}
+
+// Input gathers the batch and product data used by Calculate.
+// ExpirationRate is the percentage of the shelf life consumed per day when
+// the product is kept at RecommendedTemperature, and FreezingRate is how many
+// degrees Celsius the product cools down per hour.
+type Input struct {
+	ProducedAt             time.Time
+	DueDate                time.Time
+	CurrentTemperature     float64
+	Readings               []Reading
+	ExpirationRate         float64
+	FreezingRate           float64
+	RecommendedTemperature float64
+}
+
+type Estimate struct {
+	ShelfLifeUsedPercent  float64    `json:"shelf_life_used_percent"`
+	RemainingHours        *float64   `json:"remaining_shelf_life_hours"`
+	EstimatedSpoilageDate *time.Time `json:"estimated_spoilage_date"`
+	FreezingTimeHours     *float64   `json:"estimated_freezing_time_hours"`
+	Spoiled               bool       `json:"spoiled"`
+	SpoilsBeforeDueDate   bool       `json:"spoils_before_due_date"`
+}
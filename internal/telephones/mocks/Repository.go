@@ -0,0 +1,69 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	phone "github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+	mock "github.com/stretchr/testify/mock"
+
+	telephones "github.com/emidioreb/mercado-fresco-lerigophers/internal/telephones"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: ctx, table
+func (_m *Repository) GetAll(ctx context.Context, table string) ([]telephones.Row, error) {
+	ret := _m.Called(ctx, table)
+
+	var r0 []telephones.Row
+	if rf, ok := ret.Get(0).(func(context.Context, string) []telephones.Row); ok {
+		r0 = rf(ctx, table)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]telephones.Row)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, table)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, table, id, number
+func (_m *Repository) Update(ctx context.Context, table string, id int, number phone.Number) error {
+	ret := _m.Called(ctx, table, id, number)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, phone.Number) error); ok {
+		r0 = rf(ctx, table, id, number)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,27 @@
+package telephones
+
+// Row is a telephone stored by one of the Tables.
+type Row struct {
+	Table            string
+	Id               int
+	Telephone        string
+	TelephoneDisplay string
+}
+
+// Change describes what was, or would be, done with a Row. Error is set when
+// the stored telephone can not be parsed and has to be fixed by hand.
+type Change struct {
+	Table            string `json:"table"`
+	Id               int    `json:"id"`
+	Telephone        string `json:"telephone"`
+	E164             string `json:"e164,omitempty"`
+	TelephoneDisplay string `json:"telephone_display,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Checked int      `json:"checked"`
+	Applied bool     `json:"applied"`
+	Fixed   []Change `json:"fixed"`
+	Invalid []Change `json:"invalid"`
+}
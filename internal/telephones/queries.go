@@ -0,0 +1,16 @@
+package telephones
+
+import "fmt"
+
+// Tables are the tables whose telephone is stored in E.164.
+var Tables = []string{"sellers", "carriers", "warehouses"}
+
+var (
+	queryGetTelephones = func(table string) string {
+		return fmt.Sprintf("SELECT id, COALESCE(telephone, ''), COALESCE(telephone_display, '') FROM %s ORDER BY id", table)
+	}
+
+	queryUpdateTelephone = func(table string) string {
+		return fmt.Sprintf("UPDATE %s SET telephone = ?, telephone_display = ? WHERE id = ?", table)
+	}
+)
@@ -0,0 +1,86 @@
+package telephones
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
+)
+
+var errUnknownTable = errors.New("unknown table")
+
+func getErrGetTelephones(table string) error {
+	return fmt.Errorf("couldn't get the telephones of %s", table)
+}
+
+func getErrUpdateTelephone(table string, id int) error {
+	return fmt.Errorf("ocurred an error while updating the telephone of %s with id %d", table, id)
+}
+
+type Repository interface {
+	GetAll(ctx context.Context, table string) ([]Row, error)
+	Update(ctx context.Context, table string, id int, number phone.Number) error
+}
+
+type mariaDbRepository struct {
+	db transaction.DB
+}
+
+func NewMariaDbRepository(db *sql.DB) Repository {
+	return &mariaDbRepository{
+		db: transaction.NewDB(db),
+	}
+}
+
+func (mariaDb mariaDbRepository) GetAll(ctx context.Context, table string) ([]Row, error) {
+	if !isTable(table) {
+		return []Row{}, errUnknownTable
+	}
+
+	rows, err := mariaDb.db.QueryContext(ctx, queryGetTelephones(table))
+	if err != nil {
+		return []Row{}, getErrGetTelephones(table)
+	}
+	defer rows.Close()
+
+	result := []Row{}
+	for rows.Next() {
+		row := Row{Table: table}
+		if err := rows.Scan(&row.Id, &row.Telephone, &row.TelephoneDisplay); err != nil {
+			return []Row{}, getErrGetTelephones(table)
+		}
+		result = append(result, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []Row{}, getErrGetTelephones(table)
+	}
+
+	return result, nil
+}
+
+func (mariaDb mariaDbRepository) Update(ctx context.Context, table string, id int, number phone.Number) error {
+	if !isTable(table) {
+		return errUnknownTable
+	}
+
+	if _, err := mariaDb.db.ExecContext(ctx, queryUpdateTelephone(table), number.E164, number.Display, id); err != nil {
+		return getErrUpdateTelephone(table, id)
+	}
+
+	return nil
+}
+
+// isTable keeps table names out of the queries unless they are one of the
+// known Tables.
+func isTable(table string) bool {
+	for _, known := range Tables {
+		if known == table {
+			return true
+		}
+	}
+	return false
+}
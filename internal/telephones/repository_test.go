@@ -0,0 +1,82 @@
+package telephones
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBGetAllTelephones(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "telephone", "telephone_display"}).
+			AddRow(1, "123", "").
+			AddRow(2, "+554832221100", "(48) 3222-1100")
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetTelephones("sellers"))).WillReturnRows(rows)
+
+		result, err := NewMariaDbRepository(db).GetAll(context.Background(), "sellers")
+		assert.NoError(t, err)
+
+		assert.Equal(t, []Row{
+			{Table: "sellers", Id: 1, Telephone: "123"},
+			{Table: "sellers", Id: 2, Telephone: "+554832221100", TelephoneDisplay: "(48) 3222-1100"},
+		}, result)
+	})
+
+	t.Run("Query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetTelephones("carriers"))).WillReturnError(errors.New("internal db error"))
+
+		_, err = NewMariaDbRepository(db).GetAll(context.Background(), "carriers")
+		assert.Equal(t, getErrGetTelephones("carriers"), err)
+	})
+
+	t.Run("Unknown table", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		_, err = NewMariaDbRepository(db).GetAll(context.Background(), "buyers; DROP TABLE buyers")
+		assert.Equal(t, errUnknownTable, err)
+	})
+}
+
+func TestDBUpdateTelephone(t *testing.T) {
+	number := phone.Number{E164: "+554832221100", Display: "(48) 3222-1100"}
+
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryUpdateTelephone("warehouses"))).
+			WithArgs(number.E164, number.Display, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = NewMariaDbRepository(db).Update(context.Background(), "warehouses", 1, number)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Exec error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryUpdateTelephone("warehouses"))).WillReturnError(errors.New("internal db error"))
+
+		err = NewMariaDbRepository(db).Update(context.Background(), "warehouses", 1, number)
+		assert.Equal(t, getErrUpdateTelephone("warehouses", 1), err)
+	})
+}
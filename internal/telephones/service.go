@@ -0,0 +1,66 @@
+package telephones
+
+import (
+	"context"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+)
+
+type Service interface {
+	Fix(ctx context.Context, apply bool) (Report, error)
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{
+		repository: r,
+	}
+}
+
+// Fix reports the stored telephones that are not in E.164 and, when apply is
+// set, rewrites the ones that can be parsed. Empty telephones are left
+// untouched.
+func (s service) Fix(ctx context.Context, apply bool) (Report, error) {
+	report := Report{Applied: apply, Fixed: []Change{}, Invalid: []Change{}}
+
+	for _, table := range Tables {
+		rows, err := s.repository.GetAll(ctx, table)
+		if err != nil {
+			return report, err
+		}
+
+		for _, row := range rows {
+			report.Checked++
+			if row.Telephone == "" {
+				continue
+			}
+
+			change := Change{Table: row.Table, Id: row.Id, Telephone: row.Telephone}
+
+			number, err := phone.Parse(row.Telephone)
+			if err != nil {
+				change.Error = err.Error()
+				report.Invalid = append(report.Invalid, change)
+				continue
+			}
+
+			if number.E164 == row.Telephone && number.Display == row.TelephoneDisplay {
+				continue
+			}
+
+			if apply {
+				if err := s.repository.Update(ctx, row.Table, row.Id, number); err != nil {
+					return report, err
+				}
+			}
+
+			change.E164, change.TelephoneDisplay = number.E164, number.Display
+			report.Fixed = append(report.Fixed, change)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,77 @@
+package telephones_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/telephones"
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/telephones/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRepository() *mocks.Repository {
+	mockedRepository := new(mocks.Repository)
+	mockedRepository.On("GetAll", mock.Anything, "sellers").Return([]telephones.Row{
+		{Table: "sellers", Id: 1, Telephone: "123"},
+		{Table: "sellers", Id: 2, Telephone: "(11) 99999-0000"},
+	}, nil)
+	mockedRepository.On("GetAll", mock.Anything, "carriers").Return([]telephones.Row{
+		{Table: "carriers", Id: 1, Telephone: "+554832221100", TelephoneDisplay: "(48) 3222-1100"},
+	}, nil)
+	mockedRepository.On("GetAll", mock.Anything, "warehouses").Return([]telephones.Row{
+		{Table: "warehouses", Id: 1, Telephone: ""},
+	}, nil)
+	return mockedRepository
+}
+
+func TestServiceFix(t *testing.T) {
+	t.Run("Test if report without updating on dry run", func(t *testing.T) {
+		mockedRepository := newRepository()
+
+		report, err := telephones.NewService(mockedRepository).Fix(context.Background(), false)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 4, report.Checked)
+		assert.Equal(t, []telephones.Change{{
+			Table: "sellers", Id: 2, Telephone: "(11) 99999-0000", E164: "+5511999990000", TelephoneDisplay: "(11) 99999-0000",
+		}}, report.Fixed)
+		assert.Equal(t, []telephones.Change{{
+			Table: "sellers", Id: 1, Telephone: "123", Error: phone.ErrInvalid.Error(),
+		}}, report.Invalid)
+		mockedRepository.AssertNumberOfCalls(t, "Update", 0)
+	})
+
+	t.Run("Test if rewrite the parsed telephones on apply", func(t *testing.T) {
+		mockedRepository := newRepository()
+		mockedRepository.On("Update", mock.Anything, "sellers", 2, phone.Number{E164: "+5511999990000", Display: "(11) 99999-0000"}).
+			Return(nil).Once()
+
+		report, err := telephones.NewService(mockedRepository).Fix(context.Background(), true)
+		assert.NoError(t, err)
+
+		assert.True(t, report.Applied)
+		assert.Len(t, report.Fixed, 1)
+		mockedRepository.AssertExpectations(t)
+	})
+
+	t.Run("Test error case when update fails", func(t *testing.T) {
+		expectedError := errors.New("ocurred an error while updating the telephone of sellers with id 2")
+		mockedRepository := newRepository()
+		mockedRepository.On("Update", mock.Anything, "sellers", 2, mock.AnythingOfType("phone.Number")).Return(expectedError)
+
+		_, err := telephones.NewService(mockedRepository).Fix(context.Background(), true)
+		assert.Equal(t, expectedError, err)
+	})
+
+	t.Run("Test error case when the telephones can not be read", func(t *testing.T) {
+		expectedError := errors.New("couldn't get the telephones of sellers")
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything, "sellers").Return([]telephones.Row{}, expectedError)
+
+		_, err := telephones.NewService(mockedRepository).Fix(context.Background(), false)
+		assert.Equal(t, expectedError, err)
+	})
+}
@@ -3,8 +3,17 @@
 package mocks
 
 import (
-	warehouses "github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses"
+	context "context"
+
+	address "github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+
 	mock "github.com/stretchr/testify/mock"
+
+	phone "github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+
+	warehouses "github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -12,20 +21,20 @@ type Repository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: warehouseCode, adress, telephone, minimumCapacity, maxmumCapacity
-func (_m *Repository) Create(warehouseCode string, adress string, telephone string, minimumCapacity int, maxmumCapacity int) (warehouses.Warehouse, error) {
-	ret := _m.Called(warehouseCode, adress, telephone, minimumCapacity, maxmumCapacity)
+// Create provides a mock function with given fields: ctx, warehouseCode, addr, telephone, minimumCapacity, minimumTemperature, localityId
+func (_m *Repository) Create(ctx context.Context, warehouseCode string, addr address.Address, telephone phone.Number, minimumCapacity int, minimumTemperature int, localityId *string) (warehouses.Warehouse, error) {
+	ret := _m.Called(ctx, warehouseCode, addr, telephone, minimumCapacity, minimumTemperature, localityId)
 
 	var r0 warehouses.Warehouse
-	if rf, ok := ret.Get(0).(func(string, string, string, int, int) warehouses.Warehouse); ok {
-		r0 = rf(warehouseCode, adress, telephone, minimumCapacity, maxmumCapacity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, address.Address, phone.Number, int, int, *string) warehouses.Warehouse); ok {
+		r0 = rf(ctx, warehouseCode, addr, telephone, minimumCapacity, minimumTemperature, localityId)
 	} else {
 		r0 = ret.Get(0).(warehouses.Warehouse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, int, int) error); ok {
-		r1 = rf(warehouseCode, adress, telephone, minimumCapacity, maxmumCapacity)
+	if rf, ok := ret.Get(1).(func(context.Context, string, address.Address, phone.Number, int, int, *string) error); ok {
+		r1 = rf(ctx, warehouseCode, addr, telephone, minimumCapacity, minimumTemperature, localityId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,13 +42,13 @@ func (_m *Repository) Create(warehouseCode string, adress string, telephone stri
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Repository) Delete(id int) error {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Repository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,13 +56,13 @@ func (_m *Repository) Delete(id int) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *Repository) GetAll() ([]warehouses.Warehouse, error) {
-	ret := _m.Called()
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Repository) GetAll(ctx context.Context, opts listing.Options) ([]warehouses.Warehouse, error) {
+	ret := _m.Called(ctx, opts)
 
 	var r0 []warehouses.Warehouse
-	if rf, ok := ret.Get(0).(func() []warehouses.Warehouse); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []warehouses.Warehouse); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]warehouses.Warehouse)
@@ -61,8 +70,31 @@ func (_m *Repository) GetAll() ([]warehouses.Warehouse, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLocations provides a mock function with given fields: ctx
+func (_m *Repository) GetLocations(ctx context.Context) ([]warehouses.WarehouseLocation, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []warehouses.WarehouseLocation
+	if rf, ok := ret.Get(0).(func(context.Context) []warehouses.WarehouseLocation); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]warehouses.WarehouseLocation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,20 +102,20 @@ func (_m *Repository) GetAll() ([]warehouses.Warehouse, error) {
 	return r0, r1
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Repository) GetOne(id int) (warehouses.Warehouse, error) {
-	ret := _m.Called(id)
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Repository) GetOne(ctx context.Context, id int) (warehouses.Warehouse, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 warehouses.Warehouse
-	if rf, ok := ret.Get(0).(func(int) warehouses.Warehouse); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) warehouses.Warehouse); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(warehouses.Warehouse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -91,20 +123,55 @@ func (_m *Repository) GetOne(id int) (warehouses.Warehouse, error) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, requestData
-func (_m *Repository) Update(id int, requestData map[string]interface{}) (warehouses.Warehouse, error) {
-	ret := _m.Called(id, requestData)
+// GetUsage provides a mock function with given fields: ctx, id
+func (_m *Repository) GetUsage(ctx context.Context, id int) (warehouses.WarehouseUsage, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 warehouses.WarehouseUsage
+	if rf, ok := ret.Get(0).(func(context.Context, int) warehouses.WarehouseUsage); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(warehouses.WarehouseUsage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReassignAndDelete provides a mock function with given fields: ctx, id, targetId
+func (_m *Repository) ReassignAndDelete(ctx context.Context, id int, targetId int) error {
+	ret := _m.Called(ctx, id, targetId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, targetId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Repository) Update(ctx context.Context, id int, requestData map[string]interface{}) (warehouses.Warehouse, error) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 warehouses.Warehouse
-	if rf, ok := ret.Get(0).(func(int, map[string]interface{}) warehouses.Warehouse); ok {
-		r0 = rf(id, requestData)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) warehouses.Warehouse); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(warehouses.Warehouse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int, map[string]interface{}) error); ok {
-		r1 = rf(id, requestData)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) error); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Error(1)
 	}
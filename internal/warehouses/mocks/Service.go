@@ -3,9 +3,17 @@
 package mocks
 
 import (
+	context "context"
+
+	address "github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+
+	listing "github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+
+	mock "github.com/stretchr/testify/mock"
+
 	warehouses "github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses"
+
 	web "github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
-	mock "github.com/stretchr/testify/mock"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -13,20 +21,20 @@ type Service struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: warehouseCode, adress, telephone, minimumCapacity, maxmumCapacity
-func (_m *Service) Create(warehouseCode string, adress string, telephone string, minimumCapacity int, maxmumCapacity int) (warehouses.Warehouse, web.ResponseCode) {
-	ret := _m.Called(warehouseCode, adress, telephone, minimumCapacity, maxmumCapacity)
+// Create provides a mock function with given fields: ctx, warehouseCode, addr, telephone, minimumCapacity, minimumTemperature, localityId
+func (_m *Service) Create(ctx context.Context, warehouseCode string, addr address.Address, telephone string, minimumCapacity int, minimumTemperature int, localityId *string) (warehouses.Warehouse, web.ResponseCode) {
+	ret := _m.Called(ctx, warehouseCode, addr, telephone, minimumCapacity, minimumTemperature, localityId)
 
 	var r0 warehouses.Warehouse
-	if rf, ok := ret.Get(0).(func(string, string, string, int, int) warehouses.Warehouse); ok {
-		r0 = rf(warehouseCode, adress, telephone, minimumCapacity, maxmumCapacity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, address.Address, string, int, int, *string) warehouses.Warehouse); ok {
+		r0 = rf(ctx, warehouseCode, addr, telephone, minimumCapacity, minimumTemperature, localityId)
 	} else {
 		r0 = ret.Get(0).(warehouses.Warehouse)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(string, string, string, int, int) web.ResponseCode); ok {
-		r1 = rf(warehouseCode, adress, telephone, minimumCapacity, maxmumCapacity)
+	if rf, ok := ret.Get(1).(func(context.Context, string, address.Address, string, int, int, *string) web.ResponseCode); ok {
+		r1 = rf(ctx, warehouseCode, addr, telephone, minimumCapacity, minimumTemperature, localityId)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -34,13 +42,13 @@ func (_m *Service) Create(warehouseCode string, adress string, telephone string,
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Service) Delete(id int) web.ResponseCode {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Service) Delete(ctx context.Context, id int) web.ResponseCode {
+	ret := _m.Called(ctx, id)
 
 	var r0 web.ResponseCode
-	if rf, ok := ret.Get(0).(func(int) web.ResponseCode); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) web.ResponseCode); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(web.ResponseCode)
 	}
@@ -48,22 +56,52 @@ func (_m *Service) Delete(id int) web.ResponseCode {
 	return r0
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *Service) GetAll() ([]warehouses.Warehouse, web.ResponseCode) {
-	ret := _m.Called()
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Service) GetAll(ctx context.Context, opts listing.Options) ([]warehouses.Warehouse, listing.Page, web.ResponseCode) {
+	ret := _m.Called(ctx, opts)
 
 	var r0 []warehouses.Warehouse
-	if rf, ok := ret.Get(0).(func() []warehouses.Warehouse); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []warehouses.Warehouse); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]warehouses.Warehouse)
 		}
 	}
 
+	var r1 listing.Page
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) listing.Page); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Get(1).(listing.Page)
+	}
+
+	var r2 web.ResponseCode
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Options) web.ResponseCode); ok {
+		r2 = rf(ctx, opts)
+	} else {
+		r2 = ret.Get(2).(web.ResponseCode)
+	}
+
+	return r0, r1, r2
+}
+
+// GetNearest provides a mock function with given fields: ctx, localityId
+func (_m *Service) GetNearest(ctx context.Context, localityId string) ([]warehouses.WarehouseDistance, web.ResponseCode) {
+	ret := _m.Called(ctx, localityId)
+
+	var r0 []warehouses.WarehouseDistance
+	if rf, ok := ret.Get(0).(func(context.Context, string) []warehouses.WarehouseDistance); ok {
+		r0 = rf(ctx, localityId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]warehouses.WarehouseDistance)
+		}
+	}
+
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func() web.ResponseCode); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, string) web.ResponseCode); ok {
+		r1 = rf(ctx, localityId)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -71,20 +109,20 @@ func (_m *Service) GetAll() ([]warehouses.Warehouse, web.ResponseCode) {
 	return r0, r1
 }
 
-// GetOne provides a mock function with given fields: id
-func (_m *Service) GetOne(id int) (warehouses.Warehouse, web.ResponseCode) {
-	ret := _m.Called(id)
+// GetOne provides a mock function with given fields: ctx, id
+func (_m *Service) GetOne(ctx context.Context, id int) (warehouses.Warehouse, web.ResponseCode) {
+	ret := _m.Called(ctx, id)
 
 	var r0 warehouses.Warehouse
-	if rf, ok := ret.Get(0).(func(int) warehouses.Warehouse); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) warehouses.Warehouse); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(warehouses.Warehouse)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int) web.ResponseCode); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) web.ResponseCode); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
@@ -92,20 +130,34 @@ func (_m *Service) GetOne(id int) (warehouses.Warehouse, web.ResponseCode) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, requestData
-func (_m *Service) Update(id int, requestData map[string]interface{}) (warehouses.Warehouse, web.ResponseCode) {
-	ret := _m.Called(id, requestData)
+// ReassignAndDelete provides a mock function with given fields: ctx, id, targetId
+func (_m *Service) ReassignAndDelete(ctx context.Context, id int, targetId int) web.ResponseCode {
+	ret := _m.Called(ctx, id, targetId)
+
+	var r0 web.ResponseCode
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) web.ResponseCode); ok {
+		r0 = rf(ctx, id, targetId)
+	} else {
+		r0 = ret.Get(0).(web.ResponseCode)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, id, requestData
+func (_m *Service) Update(ctx context.Context, id int, requestData map[string]interface{}) (warehouses.Warehouse, web.ResponseCode) {
+	ret := _m.Called(ctx, id, requestData)
 
 	var r0 warehouses.Warehouse
-	if rf, ok := ret.Get(0).(func(int, map[string]interface{}) warehouses.Warehouse); ok {
-		r0 = rf(id, requestData)
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]interface{}) warehouses.Warehouse); ok {
+		r0 = rf(ctx, id, requestData)
 	} else {
 		r0 = ret.Get(0).(warehouses.Warehouse)
 	}
 
 	var r1 web.ResponseCode
-	if rf, ok := ret.Get(1).(func(int, map[string]interface{}) web.ResponseCode); ok {
-		r1 = rf(id, requestData)
+	if rf, ok := ret.Get(1).(func(context.Context, int, map[string]interface{}) web.ResponseCode); ok {
+		r1 = rf(ctx, id, requestData)
 	} else {
 		r1 = ret.Get(1).(web.ResponseCode)
 	}
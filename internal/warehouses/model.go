@@ -1,10 +1,59 @@
 package warehouses
 
+import (
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+)
+
+// ListFields are the fields warehouses can be filtered and sorted by.
+var ListFields = listing.Fields{
+	Columns: map[string]string{
+		"id":                  "w.id",
+		"warehouse_code":      "w.warehouse_code",
+		"telephone":           "w.telephone",
+		"minimum_capacity":    "w.minimum_capacity",
+		"minimum_temperature": "w.minimum_temperature",
+		"state":               "w.state",
+		"locality_id":         "w.locality_id",
+	},
+	Sortable: []string{"warehouse_code", "minimum_capacity", "minimum_temperature"},
+}
+
+// Warehouse keeps serializing the legacy free-text address as "adress" for
+// older clients; new clients should read structured_address.
 type Warehouse struct {
-	Id                 int    `json:"id"`
-	WarehouseCode      string `json:"warehouse_code"`
-	Address            string `json:"adress"`
-	Telephone          string `json:"telephone"`
-	MinimumCapacity    int    `json:"minimum_capacity"`
-	MinimumTemperature int    `json:"minimum_temperature"`
+	Id                 int             `json:"id"`
+	WarehouseCode      string          `json:"warehouse_code"`
+	Address            string          `json:"adress"`
+	StructuredAddress  address.Address `json:"structured_address"`
+	Telephone          string          `json:"telephone"`
+	TelephoneDisplay   string          `json:"telephone_display"`
+	MinimumCapacity    int             `json:"minimum_capacity"`
+	MinimumTemperature int             `json:"minimum_temperature"`
+	LocalityId         *string         `json:"locality_id"`
+}
+
+func (w *Warehouse) linkAddress() {
+	if w.LocalityId != nil {
+		w.StructuredAddress.LocalityId = *w.LocalityId
+	}
+}
+
+type WarehouseUsage struct {
+	Sections      int `json:"sections"`
+	Employees     int `json:"employees"`
+	InboundOrders int `json:"inbound_orders"`
+}
+
+// WarehouseLocation is a warehouse together with the coordinates of its
+// locality.
+type WarehouseLocation struct {
+	Warehouse
+	Latitude  float64
+	Longitude float64
+}
+
+type WarehouseDistance struct {
+	Warehouse
+	DistanceKm float64 `json:"distance_km"`
 }
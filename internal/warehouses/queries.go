@@ -1,9 +1,26 @@
 package warehouses
 
 var (
-	queryCreateWarehouse = `INSERT INTO warehouses (warehouse_code, address, telephone, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)`
+	queryGetWarehouseUsage = `SELECT
+		(SELECT COUNT(*) FROM sections WHERE warehouse_id = ?),
+		(SELECT COUNT(*) FROM employees WHERE warehouse_id = ?),
+		(SELECT COUNT(*) FROM inbound_orders WHERE warehouse_id = ?)`
 
-	queryGetOneWarehouse  = "SELECT * FROM warehouses WHERE id = ?"
-	queryGetAllWarehouses = "SELECT * FROM warehouses"
-	queryDeleteWarehouse  = "DELETE FROM warehouses WHERE id = ?"
+	queryReassignWarehouseSections  = `UPDATE sections SET warehouse_id = ? WHERE warehouse_id = ?`
+	queryReassignWarehouseEmployees = `UPDATE employees SET warehouse_id = ? WHERE warehouse_id = ?`
+
+	queryCreateWarehouse = `INSERT INTO warehouses (warehouse_code, address, street, number, complement, neighborhood, cep, state,
+	telephone, telephone_display, minimum_capacity, minimum_temperature, locality_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	queryWarehouseColumns = `w.id, w.warehouse_code, w.address, w.street, w.number, w.complement, w.neighborhood, w.cep, w.state,
+	w.telephone, w.telephone_display, w.minimum_capacity, w.minimum_temperature, w.locality_id`
+
+	queryGetOneWarehouse  = "SELECT " + queryWarehouseColumns + " FROM warehouses w WHERE w.id = ?"
+	queryGetAllWarehouses = "SELECT " + queryWarehouseColumns + " FROM warehouses w"
+
+	queryGetWarehouseLocations = `SELECT ` + queryWarehouseColumns + `, l.latitude, l.longitude
+	FROM warehouses w
+	JOIN localities l ON l.id = w.locality_id
+	WHERE l.latitude IS NOT NULL AND l.longitude IS NOT NULL`
+	queryDeleteWarehouse = "DELETE FROM warehouses WHERE id = ?"
 )
@@ -1,54 +1,69 @@
 package warehouses
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
 )
 
 var (
-	errUpdatedWarehouse = errors.New("ocurred an error while updating the warehouse")
-	errCreateWarehouse  = errors.New("ocurred an error to create warehouse")
-	errGetWarehouses    = errors.New("couldn't get warehouses")
-	errGetOneWarehouse  = errors.New("unexpected error to get warehouse")
-	errDeleteWarehouse  = errors.New("unexpected error to delete warehouse")
+	errUpdatedWarehouse  = errors.New("ocurred an error while updating the warehouse")
+	errCreateWarehouse   = errors.New("ocurred an error to create warehouse")
+	errGetWarehouses     = errors.New("couldn't get warehouses")
+	errGetOneWarehouse   = errors.New("unexpected error to get warehouse")
+	errDeleteWarehouse   = errors.New("unexpected error to delete warehouse")
+	errGetWarehouseUsage = errors.New("unexpected error to verify warehouse usage")
+	errReassignWarehouse = errors.New("unexpected error to reassign warehouse dependents")
+	errGetLocations      = errors.New("couldn't get warehouse locations")
 )
 
+func GetErrWarehouseNotFound(id int) error {
+	return fmt.Errorf("warehouse with id %d not found", id)
+}
+
 type Repository interface {
-	Create(warehouseCode, adress, telephone string, minimumCapacity, minimumTemperature int) (Warehouse, error)
-	GetOne(id int) (Warehouse, error)
-	GetAll() ([]Warehouse, error)
-	Delete(id int) error
-	Update(id int, requestData map[string]interface{}) (Warehouse, error)
+	Create(ctx context.Context, warehouseCode string, addr address.Address, telephone phone.Number, minimumCapacity, minimumTemperature int, localityId *string) (Warehouse, error)
+	GetOne(ctx context.Context, id int) (Warehouse, error)
+	GetAll(ctx context.Context, opts listing.Options) ([]Warehouse, error)
+	Delete(ctx context.Context, id int) error
+	GetUsage(ctx context.Context, id int) (WarehouseUsage, error)
+	ReassignAndDelete(ctx context.Context, id, targetId int) error
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Warehouse, error)
+	GetLocations(ctx context.Context) ([]WarehouseLocation, error)
 }
 
 type mariaDbRepository struct {
-	db *sql.DB
+	db transaction.DB
 }
 
 func NewMariaDbRepository(db *sql.DB) Repository {
 	return &mariaDbRepository{
-		db: db,
+		db: transaction.NewDB(db),
 	}
 }
 
-func (mariaDb mariaDbRepository) Create(warehouseCode, address, telephone string, minimumCapacity, minimumTemperature int) (Warehouse, error) {
+func (mariaDb mariaDbRepository) Create(ctx context.Context, warehouseCode string, addr address.Address, telephone phone.Number, minimumCapacity, minimumTemperature int, localityId *string) (Warehouse, error) {
 	newWarehouse := Warehouse{
 		WarehouseCode:      warehouseCode,
-		Address:            address,
-		Telephone:          telephone,
+		Address:            addr.String(),
+		StructuredAddress:  addr,
+		Telephone:          telephone.E164,
+		TelephoneDisplay:   telephone.Display,
 		MinimumCapacity:    minimumCapacity,
 		MinimumTemperature: minimumTemperature,
+		LocalityId:         localityId,
 	}
 
-	result, err := mariaDb.db.Exec(
-		queryCreateWarehouse,
-		warehouseCode,
-		address,
-		telephone,
-		minimumCapacity,
-		minimumTemperature,
-	)
+	values := append([]interface{}{warehouseCode}, addr.Values()...)
+	values = append(values, telephone.E164, telephone.Display, minimumCapacity, minimumTemperature, localityId)
+
+	result, err := mariaDb.db.ExecContext(ctx, queryCreateWarehouse, values...)
 
 	if err != nil {
 		return Warehouse{}, errCreateWarehouse
@@ -64,36 +79,45 @@ func (mariaDb mariaDbRepository) Create(warehouseCode, address, telephone string
 	return newWarehouse, nil
 }
 
-func (mariaDb mariaDbRepository) GetOne(id int) (Warehouse, error) {
+func (mariaDb mariaDbRepository) GetOne(ctx context.Context, id int) (Warehouse, error) {
 	currentWarehouse := Warehouse{}
 
-	row := mariaDb.db.QueryRow(queryGetOneWarehouse, id)
+	row := mariaDb.db.QueryRowContext(ctx, transaction.Lock(ctx, queryGetOneWarehouse), id)
 
 	err := row.Scan(
 		&currentWarehouse.Id,
 		&currentWarehouse.WarehouseCode,
 		&currentWarehouse.Address,
+		&currentWarehouse.StructuredAddress.Street,
+		&currentWarehouse.StructuredAddress.Number,
+		&currentWarehouse.StructuredAddress.Complement,
+		&currentWarehouse.StructuredAddress.Neighborhood,
+		&currentWarehouse.StructuredAddress.Cep,
+		&currentWarehouse.StructuredAddress.State,
 		&currentWarehouse.Telephone,
+		&currentWarehouse.TelephoneDisplay,
 		&currentWarehouse.MinimumCapacity,
 		&currentWarehouse.MinimumTemperature,
+		&currentWarehouse.LocalityId,
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
-		return Warehouse{}, fmt.Errorf("warehouse with id %d not found", id)
+		return Warehouse{}, GetErrWarehouseNotFound(id)
 	}
 
 	if err != nil {
 		return Warehouse{}, errGetOneWarehouse
 	}
+	currentWarehouse.linkAddress()
 
 	return currentWarehouse, nil
 }
 
-func (mariaDb mariaDbRepository) GetAll() ([]Warehouse, error) {
-	query := `SELECT * FROM warehouses`
+func (mariaDb mariaDbRepository) GetAll(ctx context.Context, opts listing.Options) ([]Warehouse, error) {
 	warehouses := []Warehouse{}
 
-	rows, err := mariaDb.db.Query(query)
+	query, values := listing.Build(queryGetAllWarehouses, ListFields, opts)
+	rows, err := mariaDb.db.QueryContext(ctx, query, values...)
 	if err != nil {
 		return []Warehouse{}, errGetWarehouses
 	}
@@ -103,29 +127,42 @@ func (mariaDb mariaDbRepository) GetAll() ([]Warehouse, error) {
 			&currentWarehouse.Id,
 			&currentWarehouse.WarehouseCode,
 			&currentWarehouse.Address,
+			&currentWarehouse.StructuredAddress.Street,
+			&currentWarehouse.StructuredAddress.Number,
+			&currentWarehouse.StructuredAddress.Complement,
+			&currentWarehouse.StructuredAddress.Neighborhood,
+			&currentWarehouse.StructuredAddress.Cep,
+			&currentWarehouse.StructuredAddress.State,
 			&currentWarehouse.Telephone,
-			&currentWarehouse.MinimumTemperature,
+			&currentWarehouse.TelephoneDisplay,
 			&currentWarehouse.MinimumCapacity,
+			&currentWarehouse.MinimumTemperature,
+			&currentWarehouse.LocalityId,
 		); err != nil {
 			return []Warehouse{}, errGetWarehouses
 		}
+		currentWarehouse.linkAddress()
 		warehouses = append(warehouses, currentWarehouse)
 
 	}
+
+	if err := rows.Err(); err != nil {
+		return []Warehouse{}, errGetWarehouses
+	}
 	return warehouses, nil
 
 }
 
-func (mariaDb mariaDbRepository) Delete(id int) error {
+func (mariaDb mariaDbRepository) Delete(ctx context.Context, id int) error {
 	delete := "DELETE FROM warehouses WHERE id = ?"
-	result, err := mariaDb.db.Exec(delete, id)
+	result, err := mariaDb.db.ExecContext(ctx, delete, id)
 	if err != nil {
 		return err
 	}
 
 	affectedRows, err := result.RowsAffected()
 	if affectedRows == 0 {
-		return fmt.Errorf("warehouse with id %d not found", id)
+		return GetErrWarehouseNotFound(id)
 	}
 
 	if err != nil {
@@ -135,7 +172,7 @@ func (mariaDb mariaDbRepository) Delete(id int) error {
 	return nil
 }
 
-func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface{}) (Warehouse, error) {
+func (mariaDb mariaDbRepository) Update(ctx context.Context, id int, requestData map[string]interface{}) (Warehouse, error) {
 	prefixQuery := "UPDATE warehouses SET"
 	fieldsToUpdate := []string{}
 	valuesToUse := []interface{}{}
@@ -150,12 +187,23 @@ func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface
 		case "address":
 			fieldsToUpdate = append(fieldsToUpdate, " address = ?")
 			valuesToUse = append(valuesToUse, requestData[key])
+			for _, column := range address.UpdatedColumns(requestData) {
+				fieldsToUpdate = append(fieldsToUpdate, fmt.Sprintf(" %s = ?", column))
+				valuesToUse = append(valuesToUse, requestData[column])
+			}
 		case "telephone":
 			fieldsToUpdate = append(fieldsToUpdate, " telephone = ?")
 			valuesToUse = append(valuesToUse, requestData[key])
+			for _, column := range phone.UpdatedColumns(requestData) {
+				fieldsToUpdate = append(fieldsToUpdate, fmt.Sprintf(" %s = ?", column))
+				valuesToUse = append(valuesToUse, requestData[column])
+			}
 		case "minimum_capacity":
 			fieldsToUpdate = append(fieldsToUpdate, " minimum_capacity = ?")
 			valuesToUse = append(valuesToUse, int(requestData[key].(float64)))
+		case "locality_id":
+			fieldsToUpdate = append(fieldsToUpdate, " locality_id = ?")
+			valuesToUse = append(valuesToUse, requestData[key])
 		}
 	}
 
@@ -170,7 +218,7 @@ func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface
 	}
 	finalQuery += whereCase
 
-	result, err := mariaDb.db.Exec(finalQuery, valuesToUse...)
+	result, err := mariaDb.db.ExecContext(ctx, finalQuery, valuesToUse...)
 	if err != nil {
 		return Warehouse{}, errUpdatedWarehouse
 	}
@@ -180,10 +228,86 @@ func (mariaDb mariaDbRepository) Update(id int, requestData map[string]interface
 		return Warehouse{}, errUpdatedWarehouse
 	}
 
-	currentWarehouse, err := mariaDb.GetOne(id)
+	currentWarehouse, err := mariaDb.GetOne(ctx, id)
 	if err != nil {
 		return Warehouse{}, errUpdatedWarehouse
 	}
 
 	return currentWarehouse, nil
 }
+
+func (mariaDb mariaDbRepository) GetUsage(ctx context.Context, id int) (WarehouseUsage, error) {
+	var usage WarehouseUsage
+
+	row := mariaDb.db.QueryRowContext(ctx, queryGetWarehouseUsage, id, id, id)
+	if err := row.Scan(&usage.Sections, &usage.Employees, &usage.InboundOrders); err != nil {
+		return WarehouseUsage{}, errGetWarehouseUsage
+	}
+
+	return usage, nil
+}
+
+// ReassignAndDelete moves the sections, with their stock, and the employees
+// of a warehouse to targetId and deletes the warehouse in a single
+// transaction. Inbound orders are receipt history and are never moved.
+func (mariaDb mariaDbRepository) ReassignAndDelete(ctx context.Context, id, targetId int) error {
+	err := mariaDb.db.Run(ctx, func(ctx context.Context) error {
+		for _, query := range []string{queryReassignWarehouseSections, queryReassignWarehouseEmployees} {
+			if _, err := mariaDb.db.ExecContext(ctx, query, targetId, id); err != nil {
+				return errReassignWarehouse
+			}
+		}
+
+		if _, err := mariaDb.db.ExecContext(ctx, queryDeleteWarehouse, id); err != nil {
+			return errDeleteWarehouse
+		}
+
+		return nil
+	})
+	if err != nil && err != errDeleteWarehouse {
+		return errReassignWarehouse
+	}
+
+	return err
+}
+
+func (mariaDb mariaDbRepository) GetLocations(ctx context.Context) ([]WarehouseLocation, error) {
+	locations := []WarehouseLocation{}
+
+	rows, err := mariaDb.db.QueryContext(ctx, queryGetWarehouseLocations)
+	if err != nil {
+		return []WarehouseLocation{}, errGetLocations
+	}
+
+	for rows.Next() {
+		var currentLocation WarehouseLocation
+		if err := rows.Scan(
+			&currentLocation.Id,
+			&currentLocation.WarehouseCode,
+			&currentLocation.Address,
+			&currentLocation.StructuredAddress.Street,
+			&currentLocation.StructuredAddress.Number,
+			&currentLocation.StructuredAddress.Complement,
+			&currentLocation.StructuredAddress.Neighborhood,
+			&currentLocation.StructuredAddress.Cep,
+			&currentLocation.StructuredAddress.State,
+			&currentLocation.Telephone,
+			&currentLocation.TelephoneDisplay,
+			&currentLocation.MinimumCapacity,
+			&currentLocation.MinimumTemperature,
+			&currentLocation.LocalityId,
+			&currentLocation.Latitude,
+			&currentLocation.Longitude,
+		); err != nil {
+			return []WarehouseLocation{}, errGetLocations
+		}
+		currentLocation.linkAddress()
+		locations = append(locations, currentLocation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []WarehouseLocation{}, errGetLocations
+	}
+
+	return locations, nil
+}
@@ -1,12 +1,16 @@
 package warehouses
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,19 +24,29 @@ func TestDBCreateWarehouse(t *testing.T) {
 			WithArgs(
 				"32dsa1",
 				"Rua das pedras",
-				"8213312123",
+				"Rua das pedras",
+				"",
+				"",
+				"",
+				"",
+				"",
+				"+554832221100",
+				"(48) 3222-1100",
 				1,
 				2,
+				nil,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		warehouseRepo := NewMariaDbRepository(db)
 
 		warehouse, err := warehouseRepo.Create(
+			context.Background(),
 			"32dsa1",
-			"Rua das pedras",
-			"8213312123",
+			address.FromLegacy("Rua das pedras"),
+			phone.Number{E164: "+554832221100", Display: "(48) 3222-1100"},
 			1,
 			2,
+			nil,
 		)
 		assert.Nil(t, err)
 
@@ -47,7 +61,7 @@ func TestDBCreateWarehouse(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryCreateWarehouse)).WillReturnError(errors.New("internal db error"))
 		warehouseRepo := NewMariaDbRepository(db)
 
-		_, err = warehouseRepo.Create("", "", "", 0, 0)
+		_, err = warehouseRepo.Create(context.Background(), "", address.Address{}, phone.Number{}, 0, 0, nil)
 		assert.Error(t, err)
 	})
 
@@ -62,7 +76,7 @@ func TestDBCreateWarehouse(t *testing.T) {
 			WillReturnResult(sqlDriverResultErr)
 
 		warehouseRepo := NewMariaDbRepository(db)
-		_, err = warehouseRepo.Create("", "", "", 0, 0)
+		_, err = warehouseRepo.Create(context.Background(), "", address.Address{}, phone.Number{}, 0, 0, nil)
 		assert.Error(t, err)
 		assert.Equal(t, "ocurred an error to create warehouse", err.Error())
 	})
@@ -78,18 +92,27 @@ func TestDBGetOneWarehouse(t *testing.T) {
 			"id",
 			"warehouse_code",
 			"adress",
+			"street",
+			"number",
+			"complement",
+			"neighborhood",
+			"cep",
+			"state",
 			"telephone",
-			"minimum_temperature",
+			"telephone_display",
 			"minimum_capacity",
-		}).AddRow(1, "1", "3413412dasd", "2132312312", 2, 2)
+			"minimum_temperature",
+			"locality_id",
+		}).AddRow(1, "1", "3413412dasd", "", "", "", "", "", "", "2132312312", "", 2, 2, "6")
 
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneWarehouse)).WillReturnRows(rows)
 
 		warehouseRepo := NewMariaDbRepository(db)
-		warehouse, err := warehouseRepo.GetOne(1)
+		warehouse, err := warehouseRepo.GetOne(context.Background(), 1)
 		assert.NoError(t, err)
 
 		assert.Equal(t, 2, warehouse.MinimumTemperature)
+		assert.Equal(t, "6", *warehouse.LocalityId)
 	})
 
 	t.Run("Not found case", func(t *testing.T) {
@@ -100,7 +123,7 @@ func TestDBGetOneWarehouse(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetOneWarehouse)).WillReturnError(sql.ErrNoRows)
 
 		warehouseRepo := NewMariaDbRepository(db)
-		_, err = warehouseRepo.GetOne(1)
+		_, err = warehouseRepo.GetOne(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "warehouse with id 1 not found", err.Error())
 	})
@@ -114,7 +137,7 @@ func TestDBGetOneWarehouse(t *testing.T) {
 			WillReturnError(errors.New("unexpected error to get warehouse"))
 
 		warehouseRepo := NewMariaDbRepository(db)
-		_, err = warehouseRepo.GetOne(1)
+		_, err = warehouseRepo.GetOne(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "unexpected error to get warehouse", err.Error())
 	})
@@ -130,18 +153,26 @@ func TestDBGetAllWarehouses(t *testing.T) {
 			"id",
 			"warehouse_code",
 			"address",
+			"street",
+			"number",
+			"complement",
+			"neighborhood",
+			"cep",
+			"state",
 			"telephone",
-			"minimum_temperature",
+			"telephone_display",
 			"minimum_capacity",
+			"minimum_temperature",
+			"locality_id",
 		}).
-			AddRow(1, "aa", "aa", "13123", 1, 10).
-			AddRow(2, "bb", "bb", "123213", 1, 10).
-			AddRow(3, "cc", "cc", "1123123", 1, 10)
+			AddRow(1, "aa", "aa", "", "", "", "", "", "", "13123", "", 10, 1, nil).
+			AddRow(2, "bb", "bb", "", "", "", "", "", "", "123213", "", 10, 1, nil).
+			AddRow(3, "cc", "cc", "", "", "", "", "", "", "1123123", "", 10, 1, "2")
 		mock.ExpectQuery(regexp.QuoteMeta(queryGetAllWarehouses)).WillReturnRows(rows)
 
 		warehousesRepo := NewMariaDbRepository(db)
 
-		warehouseReports, err := warehousesRepo.GetAll()
+		warehouseReports, err := warehousesRepo.GetAll(context.Background(), listing.Options{})
 		assert.NoError(t, err)
 
 		assert.Len(t, warehouseReports, 3)
@@ -160,7 +191,7 @@ func TestDBGetAllWarehouses(t *testing.T) {
 
 		warehouseRepo := NewMariaDbRepository(db)
 
-		_, err = warehouseRepo.GetAll()
+		_, err = warehouseRepo.GetAll(context.Background(), listing.Options{})
 		assert.Error(t, err)
 	})
 }
@@ -176,7 +207,7 @@ func TestDBDeleteSection(t *testing.T) {
 			WillReturnResult(affectedRowsResult)
 
 		sectionsRepo := NewMariaDbRepository(db)
-		err = sectionsRepo.Delete(1)
+		err = sectionsRepo.Delete(context.Background(), 1)
 		assert.NoError(t, err)
 	})
 
@@ -190,7 +221,7 @@ func TestDBDeleteSection(t *testing.T) {
 			WillReturnResult(affectedRowsResult)
 
 		productsRepo := NewMariaDbRepository(db)
-		err = productsRepo.Delete(1)
+		err = productsRepo.Delete(context.Background(), 1)
 		assert.NotNil(t, err)
 		assert.Equal(t, "warehouse with id 1 not found", err.Error())
 	})
@@ -204,7 +235,7 @@ func TestDBDeleteSection(t *testing.T) {
 			WillReturnError(errors.New("any error"))
 
 		productsRepo := NewMariaDbRepository(db)
-		err = productsRepo.Delete(1)
+		err = productsRepo.Delete(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "any error", err.Error())
 	})
@@ -218,7 +249,7 @@ func TestDBDeleteSection(t *testing.T) {
 			WillReturnError(errors.New("unexpected error to delete warehouse"))
 
 		productsRepo := NewMariaDbRepository(db)
-		err = productsRepo.Delete(1)
+		err = productsRepo.Delete(context.Background(), 1)
 		assert.Error(t, err)
 		assert.Equal(t, "unexpected error to delete warehouse", err.Error())
 	})
@@ -252,8 +283,158 @@ func TestDBUpdateWarehouse(t *testing.T) {
 
 		sectionsRepo := NewMariaDbRepository(db)
 
-		_, err = sectionsRepo.Update(1, requestData)
+		_, err = sectionsRepo.Update(context.Background(), 1, requestData)
 		assert.Error(t, err)
 		assert.Equal(t, errUpdatedWarehouse, err)
 	})
+
+	t.Run("Address columns are only written along with the address", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET warehouse_code = ? WHERE id = ?")).
+			WithArgs("212", 1).
+			WillReturnError(errors.New("any error"))
+
+		warehousesRepo := NewMariaDbRepository(db)
+
+		_, err = warehousesRepo.Update(context.Background(), 1, map[string]interface{}{"warehouse_code": "212", "cep": "01310100"})
+		assert.Equal(t, errUpdatedWarehouse, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Telephone display is only written along with the telephone", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET warehouse_code = ? WHERE id = ?")).
+			WithArgs("212", 1).
+			WillReturnError(errors.New("any error"))
+
+		warehousesRepo := NewMariaDbRepository(db)
+
+		_, err = warehousesRepo.Update(context.Background(), 1, map[string]interface{}{"warehouse_code": "212", "telephone_display": "(48) 3222-1100"})
+		assert.Equal(t, errUpdatedWarehouse, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetUsage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"sections", "employees", "inbound_orders"}).AddRow(1, 2, 3)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetWarehouseUsage)).WithArgs(1, 1, 1).WillReturnRows(rows)
+
+		warehousesRepo := NewMariaDbRepository(db)
+
+		usage, err := warehousesRepo.GetUsage(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, WarehouseUsage{Sections: 1, Employees: 2, InboundOrders: 3}, usage)
+	})
+
+	t.Run("fail to scan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetWarehouseUsage)).WillReturnError(sql.ErrConnDone)
+
+		warehousesRepo := NewMariaDbRepository(db)
+
+		_, err = warehousesRepo.GetUsage(context.Background(), 1)
+		assert.Equal(t, errGetWarehouseUsage, err)
+	})
+}
+
+func TestReassignAndDelete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(queryReassignWarehouseSections)).WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(queryReassignWarehouseEmployees)).WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteWarehouse)).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		warehousesRepo := NewMariaDbRepository(db)
+
+		err = warehousesRepo.ReassignAndDelete(context.Background(), 1, 2)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback when reassign fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(queryReassignWarehouseSections)).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		warehousesRepo := NewMariaDbRepository(db)
+
+		err = warehousesRepo.ReassignAndDelete(context.Background(), 1, 2)
+		assert.Equal(t, errReassignWarehouse, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback when delete fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(queryReassignWarehouseSections)).WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(queryReassignWarehouseEmployees)).WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(queryDeleteWarehouse)).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		warehousesRepo := NewMariaDbRepository(db)
+
+		err = warehousesRepo.ReassignAndDelete(context.Background(), 1, 2)
+		assert.Equal(t, errDeleteWarehouse, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDBGetWarehouseLocations(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{
+			"id", "warehouse_code", "address", "street", "number", "complement", "neighborhood", "cep", "state", "telephone", "telephone_display", "minimum_capacity", "minimum_temperature",
+			"locality_id", "latitude", "longitude",
+		}).AddRow(1, "Cod#1", "Rua Hercílio Luz", "", "", "", "", "", "", "48999001122", "", 10, 10, "6", -27.5954, -48.548)
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetWarehouseLocations)).WillReturnRows(rows)
+
+		warehouseRepo := NewMariaDbRepository(db)
+		locations, err := warehouseRepo.GetLocations(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, locations, 1)
+		assert.Equal(t, "Cod#1", locations[0].WarehouseCode)
+		assert.Equal(t, -27.5954, locations[0].Latitude)
+		assert.Equal(t, -48.548, locations[0].Longitude)
+	})
+
+	t.Run("Query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(queryGetWarehouseLocations)).WillReturnError(errors.New("any error"))
+
+		warehouseRepo := NewMariaDbRepository(db)
+		_, err = warehouseRepo.GetLocations(context.Background())
+		assert.Equal(t, errGetLocations, err)
+	})
 }
@@ -1,32 +1,48 @@
 package warehouses
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"sort"
 
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/localities"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/geo"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
 	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
 )
 
 type Service interface {
-	Create(warehouseCode, adress, telephone string, minimumCapacity, minimumTemperature int) (Warehouse, web.ResponseCode)
-	GetOne(id int) (Warehouse, web.ResponseCode)
-	GetAll() ([]Warehouse, web.ResponseCode)
-	Delete(id int) web.ResponseCode
-	Update(id int, requestData map[string]interface{}) (Warehouse, web.ResponseCode)
+	Create(ctx context.Context, warehouseCode string, addr address.Address, telephone string, minimumCapacity, minimumTemperature int, localityId *string) (Warehouse, web.ResponseCode)
+	GetOne(ctx context.Context, id int) (Warehouse, web.ResponseCode)
+	GetAll(ctx context.Context, opts listing.Options) ([]Warehouse, listing.Page, web.ResponseCode)
+	Delete(ctx context.Context, id int) web.ResponseCode
+	ReassignAndDelete(ctx context.Context, id, targetId int) web.ResponseCode
+	Update(ctx context.Context, id int, requestData map[string]interface{}) (Warehouse, web.ResponseCode)
+	GetNearest(ctx context.Context, localityId string) ([]WarehouseDistance, web.ResponseCode)
 }
 
 type service struct {
-	repository Repository
+	repository         Repository
+	localityRepository localities.Repository
 }
 
-func NewService(r Repository) Service {
+func NewService(r Repository, lr localities.Repository) Service {
 	return &service{
-		repository: r,
+		repository:         r,
+		localityRepository: lr,
 	}
 }
 
-func (s service) Create(warehouseCode, adress, telephone string, minimumCapacity, minimumTemperature int) (Warehouse, web.ResponseCode) {
-	allWarehouses, _ := s.repository.GetAll()
+// Create keeps the address and the telephone optional, as warehouses were
+// created without them before; when given they are normalized and the state
+// is checked against the locality.
+func (s service) Create(ctx context.Context, warehouseCode string, addr address.Address, telephone string, minimumCapacity, minimumTemperature int, localityId *string) (Warehouse, web.ResponseCode) {
+	allWarehouses, _ := s.repository.GetAll(ctx, listing.Options{})
 
 	for _, warehouse := range allWarehouses {
 		if warehouse.WarehouseCode == warehouseCode {
@@ -34,7 +50,32 @@ func (s service) Create(warehouseCode, adress, telephone string, minimumCapacity
 		}
 	}
 
-	warehouse, err := s.repository.Create(warehouseCode, adress, telephone, minimumCapacity, minimumTemperature)
+	var number phone.Number
+	if telephone != "" {
+		var err error
+		if number, err = phone.Parse(telephone); err != nil {
+			return Warehouse{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+		}
+	}
+
+	if localityId == nil && addr.LocalityId != "" {
+		localityId = &addr.LocalityId
+	}
+
+	if addr != (address.Address{}) {
+		var err error
+		if addr, err = address.Link(addr, valueOf(localityId)); err != nil {
+			return Warehouse{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+		}
+	}
+
+	if localityId != nil {
+		if resp := s.checkLocality(ctx, addr, *localityId); resp.Err != nil {
+			return Warehouse{}, resp
+		}
+	}
+
+	warehouse, err := s.repository.Create(ctx, warehouseCode, addr, number, minimumCapacity, minimumTemperature, localityId)
 	if err != nil {
 		return Warehouse{}, web.NewCodeResponse(
 			http.StatusInternalServerError,
@@ -45,8 +86,8 @@ func (s service) Create(warehouseCode, adress, telephone string, minimumCapacity
 	return warehouse, web.NewCodeResponse(http.StatusCreated, nil)
 }
 
-func (s service) GetOne(id int) (Warehouse, web.ResponseCode) {
-	warehouse, err := s.repository.GetOne(id)
+func (s service) GetOne(ctx context.Context, id int) (Warehouse, web.ResponseCode) {
+	warehouse, err := s.repository.GetOne(ctx, id)
 
 	if err != nil {
 		return Warehouse{}, web.NewCodeResponse(http.StatusNotFound, err)
@@ -54,31 +95,89 @@ func (s service) GetOne(id int) (Warehouse, web.ResponseCode) {
 	return warehouse, web.NewCodeResponse(http.StatusNotFound, nil)
 }
 
-func (s service) GetAll() ([]Warehouse, web.ResponseCode) {
-	warehouse, err := s.repository.GetAll()
+func (s service) GetAll(ctx context.Context, opts listing.Options) ([]Warehouse, listing.Page, web.ResponseCode) {
+	warehouse, err := s.repository.GetAll(ctx, opts)
 	if err != nil {
-		return []Warehouse{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+		return []Warehouse{}, listing.Page{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	warehouse, page := listing.Paginate(warehouse, opts)
+	return warehouse, page, web.NewCodeResponse(http.StatusOK, err)
+}
+
+// Delete refuses to remove a warehouse still referenced by its sections, employees and inbound_orders.
+func (s service) Delete(ctx context.Context, id int) web.ResponseCode {
+	if resp := s.exists(ctx, id, http.StatusNotFound); resp.Err != nil {
+		return resp
+	}
+
+	usage, err := s.repository.GetUsage(ctx, id)
+	if err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	if resp := web.ReferencedBy("warehouse", id,
+		web.Reference{Table: "sections", Count: usage.Sections},
+		web.Reference{Table: "employees", Count: usage.Employees},
+		web.Reference{Table: "inbound_orders", Count: usage.InboundOrders},
+	); resp.Err != nil {
+		return resp
+	}
+
+	if err := s.repository.Delete(ctx, id); err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
 
-	return warehouse, web.NewCodeResponse(http.StatusOK, err)
+	return web.NewCodeResponse(http.StatusNoContent, nil)
 }
 
-func (s service) Delete(id int) web.ResponseCode {
-	err := s.repository.Delete(id)
+func (s service) ReassignAndDelete(ctx context.Context, id, targetId int) web.ResponseCode {
+	if resp := s.exists(ctx, id, http.StatusNotFound); resp.Err != nil {
+		return resp
+	}
+
+	if resp := s.exists(ctx, targetId, http.StatusConflict); resp.Err != nil {
+		return resp
+	}
 
+	usage, err := s.repository.GetUsage(ctx, id)
 	if err != nil {
-		return web.NewCodeResponse(http.StatusNotFound, err)
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
+
+	if usage.InboundOrders > 0 {
+		return web.NewCodeResponse(http.StatusConflict, fmt.Errorf(
+			"warehouse with id %d has %d inbound_orders, which are kept as receipt history",
+			id, usage.InboundOrders,
+		))
+	}
+
+	if err := s.repository.ReassignAndDelete(ctx, id, targetId); err != nil {
+		return web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
 	return web.NewCodeResponse(http.StatusNoContent, nil)
 }
 
-func (s service) Update(id int, requestData map[string]interface{}) (Warehouse, web.ResponseCode) {
-	_, err := s.repository.GetOne(id)
+func (s service) exists(ctx context.Context, id, notFoundCode int) web.ResponseCode {
+	_, err := s.repository.GetOne(ctx, id)
+	if err == nil {
+		return web.ResponseCode{}
+	}
+
+	if err.Error() == GetErrWarehouseNotFound(id).Error() {
+		return web.NewCodeResponse(notFoundCode, err)
+	}
+	return web.NewCodeResponse(http.StatusInternalServerError, err)
+}
+
+func (s service) Update(ctx context.Context, id int, requestData map[string]interface{}) (Warehouse, web.ResponseCode) {
+	current, err := s.repository.GetOne(ctx, id)
 	if err != nil {
 		return Warehouse{}, web.NewCodeResponse(http.StatusNotFound, err)
 	}
 
-	allWarehouses, _ := s.repository.GetAll()
+	allWarehouses, _ := s.repository.GetAll(ctx, listing.Options{})
 	warehouseCodeReqData := requestData["warehouse_code"]
 
 	if warehouseCodeReqData != nil {
@@ -89,10 +188,108 @@ func (s service) Update(id int, requestData map[string]interface{}) (Warehouse,
 		}
 	}
 
-	warehouse, err := s.repository.Update(id, requestData)
+	if value, ok := requestData["telephone"].(string); ok {
+		var number phone.Number
+		if value != "" {
+			if number, err = phone.Parse(value); err != nil {
+				return Warehouse{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+			}
+		}
+		number.ToRequestData(requestData)
+	}
+
+	localityId := valueOf(current.LocalityId)
+	value, localityChanged := requestData["locality_id"].(string)
+	if localityChanged {
+		localityId = value
+	}
+
+	addr, changed, err := address.FromRequestData(requestData)
+	if err != nil {
+		return Warehouse{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+	}
+
+	if !changed {
+		addr = current.StructuredAddress
+	} else {
+		if addr, err = address.Link(addr, localityId); err != nil {
+			return Warehouse{}, web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+		}
+		addr.ToRequestData(requestData)
+	}
+
+	if localityChanged || (localityId != "" && addr.State != current.StructuredAddress.State) {
+		addr.LocalityId = localityId
+		if resp := s.checkLocality(ctx, addr, localityId); resp.Err != nil {
+			return Warehouse{}, resp
+		}
+	}
+
+	warehouse, err := s.repository.Update(ctx, id, requestData)
 	if err != nil {
 		return Warehouse{}, web.NewCodeResponse(http.StatusInternalServerError, err)
 	}
 
 	return warehouse, web.ResponseCode{Code: http.StatusOK, Err: nil}
 }
+
+// checkLocality answers 409 when the locality does not exist and 422 when
+// the state of the address is not its province.
+func (s service) checkLocality(ctx context.Context, addr address.Address, localityId string) web.ResponseCode {
+	locality, err := s.localityRepository.GetOne(ctx, localityId)
+	if err != nil {
+		return web.NewCodeResponse(http.StatusConflict, err)
+	}
+
+	if err := address.CheckState(addr, locality.ProvinceName); err != nil {
+		return web.NewCodeResponse(http.StatusUnprocessableEntity, err)
+	}
+	return web.ResponseCode{}
+}
+
+func valueOf(localityId *string) string {
+	if localityId == nil {
+		return ""
+	}
+	return *localityId
+}
+
+// GetNearest lists the warehouses whose locality has coordinates, ordered by
+// their distance to the given locality.
+func (s service) GetNearest(ctx context.Context, localityId string) ([]WarehouseDistance, web.ResponseCode) {
+	origin, err := s.localityRepository.GetOne(ctx, localityId)
+	if err != nil && err.Error() == localities.GetErrLocalityNotFound(localityId).Error() {
+		return []WarehouseDistance{}, web.NewCodeResponse(http.StatusNotFound, err)
+	}
+
+	if err != nil {
+		return []WarehouseDistance{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	if origin.Latitude == nil || origin.Longitude == nil {
+		return []WarehouseDistance{}, web.NewCodeResponse(
+			http.StatusUnprocessableEntity,
+			fmt.Errorf("locality with id %s has no coordinates", localityId),
+		)
+	}
+
+	locations, err := s.repository.GetLocations(ctx)
+	if err != nil {
+		return []WarehouseDistance{}, web.NewCodeResponse(http.StatusInternalServerError, err)
+	}
+
+	nearest := make([]WarehouseDistance, 0, len(locations))
+	for _, location := range locations {
+		distance := geo.DistanceKm(*origin.Latitude, *origin.Longitude, location.Latitude, location.Longitude)
+		nearest = append(nearest, WarehouseDistance{
+			Warehouse:  location.Warehouse,
+			DistanceKm: math.Round(distance*100) / 100,
+		})
+	}
+
+	sort.SliceStable(nearest, func(i, j int) bool {
+		return nearest[i].DistanceKm < nearest[j].DistanceKm
+	})
+
+	return nearest, web.NewCodeResponse(http.StatusOK, nil)
+}
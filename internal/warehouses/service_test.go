@@ -1,12 +1,18 @@
 package warehouses_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
 
+	"github.com/emidioreb/mercado-fresco-lerigophers/internal/localities"
+	mockLocalityRepository "github.com/emidioreb/mercado-fresco-lerigophers/internal/localities/mocks"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses"
 	"github.com/emidioreb/mercado-fresco-lerigophers/internal/warehouses/mocks"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -19,23 +25,25 @@ func TestServiceCreate(t *testing.T) {
 			Id:                 1,
 			WarehouseCode:      "212",
 			Address:            "rua do bobo",
-			Telephone:          "0",
+			Telephone:          "(48) 3222-1100",
 			MinimumCapacity:    10,
 			MinimumTemperature: 30,
 		}
 
-		mockedRepository.On("GetAll").Return([]warehouses.Warehouse{}, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]warehouses.Warehouse{}, nil)
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
+			mock.AnythingOfType("phone.Number"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
+			mock.AnythingOfType("*string"),
 		).Return(input, nil)
 
-		service := warehouses.NewService(mockedRepository)
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
 
-		result, _ := service.Create(input.WarehouseCode, input.Address, input.Telephone, input.MinimumCapacity, input.MinimumTemperature)
+		result, _ := service.Create(context.Background(), input.WarehouseCode, address.FromLegacy(input.Address), input.Telephone, input.MinimumCapacity, input.MinimumTemperature, input.LocalityId)
 
 		assert.Equal(t, result, input)
 		mockedRepository.AssertExpectations(t)
@@ -48,25 +56,27 @@ func TestServiceCreate(t *testing.T) {
 			Id:                 1,
 			WarehouseCode:      "212",
 			Address:            "rua do bobo",
-			Telephone:          "0",
+			Telephone:          "(48) 3222-1100",
 			MinimumCapacity:    10,
 			MinimumTemperature: 30,
 		}
 
 		expectedError := errors.New("warehouse_code already exists")
 
-		mockedRepository.On("GetAll").Return([]warehouses.Warehouse{input}, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]warehouses.Warehouse{input}, nil)
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
+			mock.AnythingOfType("phone.Number"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
+			mock.AnythingOfType("*string"),
 		).Return(warehouses.Warehouse{}, expectedError)
 
-		service := warehouses.NewService(mockedRepository)
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
 
-		_, err := service.Create(input.WarehouseCode, input.Address, input.Telephone, input.MinimumCapacity, input.MinimumTemperature)
+		_, err := service.Create(context.Background(), input.WarehouseCode, address.FromLegacy(input.Address), input.Telephone, input.MinimumCapacity, input.MinimumTemperature, input.LocalityId)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, err.Err.Error(), expectedError.Error())
@@ -80,25 +90,27 @@ func TestServiceCreate(t *testing.T) {
 			Id:                 1,
 			WarehouseCode:      "212",
 			Address:            "rua do bobo",
-			Telephone:          "0",
+			Telephone:          "(48) 3222-1100",
 			MinimumCapacity:    10,
 			MinimumTemperature: 30,
 		}
 
 		expectedError := errors.New("ocurred an error to create warehouse")
 
-		mockedRepository.On("GetAll").Return([]warehouses.Warehouse{}, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]warehouses.Warehouse{}, nil)
 		mockedRepository.On("Create",
+			mock.Anything,
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
-			mock.AnythingOfType("string"),
+			mock.AnythingOfType("address.Address"),
+			mock.AnythingOfType("phone.Number"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
+			mock.AnythingOfType("*string"),
 		).Return(warehouses.Warehouse{}, expectedError)
 
-		service := warehouses.NewService(mockedRepository)
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
 
-		_, err := service.Create(input.WarehouseCode, input.Address, input.Telephone, input.MinimumCapacity, input.MinimumTemperature)
+		_, err := service.Create(context.Background(), input.WarehouseCode, address.FromLegacy(input.Address), input.Telephone, input.MinimumCapacity, input.MinimumTemperature, input.LocalityId)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, err.Err.Error(), expectedError.Error())
@@ -115,7 +127,7 @@ func TestServiceGetAll(t *testing.T) {
 				Id:                 1,
 				WarehouseCode:      "212",
 				Address:            "rua do bobo",
-				Telephone:          "0",
+				Telephone:          "(48) 3222-1100",
 				MinimumCapacity:    10,
 				MinimumTemperature: 30,
 			},
@@ -123,17 +135,17 @@ func TestServiceGetAll(t *testing.T) {
 				Id:                 2,
 				WarehouseCode:      "212a",
 				Address:            "rua do bobo",
-				Telephone:          "0",
+				Telephone:          "(48) 3222-1100",
 				MinimumCapacity:    10,
 				MinimumTemperature: 30,
 			},
 		}
 
-		mockedRepository.On("GetAll").Return(input, nil).Once()
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return(input, nil).Once()
 
-		service := warehouses.NewService(mockedRepository)
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
 
-		result, _ := service.GetAll()
+		result, _, _ := service.GetAll(context.Background(), listing.Options{})
 
 		for i, warehouse := range input {
 			assert.Equal(t, result[i], warehouse)
@@ -146,11 +158,11 @@ func TestServiceGetAll(t *testing.T) {
 
 		expectedError := errors.New("couldn't get warehouses")
 
-		mockedRepository.On("GetAll").Return([]warehouses.Warehouse{}, expectedError).Once()
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]warehouses.Warehouse{}, expectedError).Once()
 
-		service := warehouses.NewService(mockedRepository)
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
 
-		_, err := service.GetAll()
+		_, _, err := service.GetAll(context.Background(), listing.Options{})
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, err.Err.Error(), expectedError.Error())
@@ -166,16 +178,16 @@ func TestServiceGetOne(t *testing.T) {
 			Id:                 1,
 			WarehouseCode:      "212",
 			Address:            "rua do bobo",
-			Telephone:          "0",
+			Telephone:          "(48) 3222-1100",
 			MinimumCapacity:    10,
 			MinimumTemperature: 30,
 		}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(input, nil).Once()
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(input, nil).Once()
 
-		service := warehouses.NewService(mockedRepository)
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
 
-		result, _ := service.GetOne(123)
+		result, _ := service.GetOne(context.Background(), 123)
 
 		assert.Equal(t, result, input)
 		mockedRepository.AssertExpectations(t)
@@ -184,11 +196,11 @@ func TestServiceGetOne(t *testing.T) {
 	t.Run("must return an error when searching for an unregistered id", func(t *testing.T) {
 		expectedError := errors.New("warehouse with id 1 not found")
 
-		service := warehouses.NewService(mockedRepository)
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, expectedError).Once()
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouses.Warehouse{}, expectedError).Once()
 
-		_, err := service.GetOne(1)
+		_, err := service.GetOne(context.Background(), 1)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, err.Err.Error(), expectedError.Error())
@@ -200,30 +212,138 @@ func TestServiceDelete(t *testing.T) {
 	t.Run("Verify the successfully case if the warehouse is deleted", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 
-		mockedRepository.On("Delete", mock.AnythingOfType("int")).Return(nil)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(warehouses.Warehouse{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(warehouses.WarehouseUsage{}, nil).Once()
+		mockedRepository.On("Delete", mock.Anything, 1).Return(nil).Once()
 
-		service := warehouses.NewService(mockedRepository)
-		result := service.Delete(1)
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.Delete(context.Background(), 1)
 		assert.Nil(t, result.Err)
 
-		assert.Equal(t, result.Code, http.StatusNoContent)
+		assert.Equal(t, http.StatusNoContent, result.Code)
 		mockedRepository.AssertExpectations(t)
 	})
 
 	t.Run("Verify the error case if warehouse do not exists", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
-		expectedError := errors.New("warehouse with id 1 not found")
+		expectedError := warehouses.GetErrWarehouseNotFound(1)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(warehouses.Warehouse{}, expectedError).Once()
+
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusNotFound, result.Code)
+		assert.Equal(t, expectedError, result.Err)
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("Verify the conflict case if warehouse is still referenced", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(warehouses.Warehouse{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(warehouses.WarehouseUsage{Sections: 1, Employees: 2, InboundOrders: 3}, nil).Once()
 
-		mockedRepository.On("Delete", mock.AnythingOfType("int")).Return(expectedError)
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.Delete(context.Background(), 1)
 
-		service := warehouses.NewService(mockedRepository)
-		result := service.Delete(1)
-		assert.NotNil(t, result.Err)
+		assert.Equal(t, http.StatusConflict, result.Code)
+		assert.Equal(t, "warehouse with id 1 is used by 1 sections, 2 employees and 3 inbound_orders", result.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("Fail when verify warehouse usage", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := errors.New("unexpected error to verify warehouse usage")
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(warehouses.Warehouse{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(warehouses.WarehouseUsage{}, expectedError).Once()
+
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusInternalServerError, result.Code)
+		assert.Equal(t, expectedError, result.Err)
+	})
+
+	t.Run("Fail when delete warehouse", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := errors.New("unexpected error to delete warehouse")
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(warehouses.Warehouse{Id: 1}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(warehouses.WarehouseUsage{}, nil).Once()
+		mockedRepository.On("Delete", mock.Anything, 1).Return(expectedError).Once()
+
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.Delete(context.Background(), 1)
+
+		assert.Equal(t, http.StatusInternalServerError, result.Code)
+		assert.Equal(t, expectedError, result.Err)
+	})
+}
+
+func TestServiceReassignAndDelete(t *testing.T) {
+	t.Run("Verify the successfully case if the dependents are moved", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
 
-		assert.Equal(t, result.Code, http.StatusNotFound)
-		assert.Equal(t, result.Err, expectedError)
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(warehouses.Warehouse{Id: 1}, nil).Once()
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(warehouses.Warehouse{Id: 2}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(warehouses.WarehouseUsage{Sections: 2, Employees: 1}, nil).Once()
+		mockedRepository.On("ReassignAndDelete", mock.Anything, 1, 2).Return(nil).Once()
+
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.ReassignAndDelete(context.Background(), 1, 2)
+		assert.Nil(t, result.Err)
+
+		assert.Equal(t, http.StatusNoContent, result.Code)
 		mockedRepository.AssertExpectations(t)
 	})
+
+	t.Run("Verify the conflict case if the warehouse has inbound orders", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(warehouses.Warehouse{Id: 1}, nil).Once()
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(warehouses.Warehouse{Id: 2}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(warehouses.WarehouseUsage{Sections: 2, InboundOrders: 3}, nil).Once()
+
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.ReassignAndDelete(context.Background(), 1, 2)
+
+		assert.Equal(t, http.StatusConflict, result.Code)
+		assert.Equal(t, "warehouse with id 1 has 3 inbound_orders, which are kept as receipt history", result.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "ReassignAndDelete", 0)
+	})
+
+	t.Run("Verify the conflict case if target do not exists", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := warehouses.GetErrWarehouseNotFound(2)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(warehouses.Warehouse{Id: 1}, nil).Once()
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(warehouses.Warehouse{}, expectedError).Once()
+
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.ReassignAndDelete(context.Background(), 1, 2)
+
+		assert.Equal(t, http.StatusConflict, result.Code)
+		assert.Equal(t, expectedError, result.Err)
+		mockedRepository.AssertNumberOfCalls(t, "ReassignAndDelete", 0)
+	})
+
+	t.Run("Fail when reassign warehouse dependents", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		expectedError := errors.New("unexpected error to reassign warehouse dependents")
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(warehouses.Warehouse{Id: 1}, nil).Once()
+		mockedRepository.On("GetOne", mock.Anything, 2).Return(warehouses.Warehouse{Id: 2}, nil).Once()
+		mockedRepository.On("GetUsage", mock.Anything, 1).Return(warehouses.WarehouseUsage{}, nil).Once()
+		mockedRepository.On("ReassignAndDelete", mock.Anything, 1, 2).Return(expectedError).Once()
+
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result := service.ReassignAndDelete(context.Background(), 1, 2)
+
+		assert.Equal(t, http.StatusInternalServerError, result.Code)
+		assert.Equal(t, expectedError, result.Err)
+	})
 }
 
 func TestServiceUpdate(t *testing.T) {
@@ -233,7 +353,7 @@ func TestServiceUpdate(t *testing.T) {
 		requestData := map[string]interface{}{
 			"warehouse_code":      "212",
 			"address":             "rua do bobo",
-			"telephone":           "0",
+			"telephone":           "(48) 3222-1100",
 			"minimum_capacity":    10,
 			"minimum_temperature": 30,
 		}
@@ -242,7 +362,7 @@ func TestServiceUpdate(t *testing.T) {
 			Id:                 1,
 			WarehouseCode:      "212",
 			Address:            "rua do bobo",
-			Telephone:          "0",
+			Telephone:          "(48) 3222-1100",
 			MinimumCapacity:    10,
 			MinimumTemperature: 30,
 		}
@@ -256,25 +376,48 @@ func TestServiceUpdate(t *testing.T) {
 			MinimumTemperature: 1000,
 		}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).
 			Return(input, nil)
 
-		mockedRepository.On("GetAll").
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).
 			Return([]warehouses.Warehouse{}, nil)
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(expectedWarehouse, nil)
 
-		service := warehouses.NewService(mockedRepository)
-		result, err := service.Update(1, requestData)
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		result, err := service.Update(context.Background(), 1, requestData)
 
 		assert.Nil(t, err.Err)
 		assert.Equal(t, expectedWarehouse, result)
 		mockedRepository.AssertExpectations(t)
 	})
 
+	t.Run("clear the telephone display along with the telephone", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+
+		requestData := map[string]interface{}{
+			"telephone":         "",
+			"telephone_display": "(48) 3222-1100",
+		}
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(warehouses.Warehouse{Id: 1, Telephone: "+554832221100"}, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]warehouses.Warehouse{}, nil)
+		mockedRepository.On("Update", mock.Anything, 1, map[string]interface{}{
+			"telephone":         "",
+			"telephone_display": "",
+		}).Return(warehouses.Warehouse{Id: 1}, nil)
+
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, err := service.Update(context.Background(), 1, requestData)
+
+		assert.Nil(t, err.Err)
+		mockedRepository.AssertExpectations(t)
+	})
+
 	t.Run("return error when warehouse_code already exists and id doesn't match", func(t *testing.T) {
 		mockedRepository := new(mocks.Repository)
 
@@ -303,16 +446,16 @@ func TestServiceUpdate(t *testing.T) {
 			},
 		}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).
 			Return(input[1], nil).Once()
 
-		mockedRepository.On("GetAll").
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).
 			Return(input, nil).Once()
 
-		mockedRepository.On("Update", mock.AnythingOfType("int"), mock.Anything).Return(warehouses.Warehouse{}, nil).Once()
+		mockedRepository.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.Anything).Return(warehouses.Warehouse{}, nil).Once()
 
-		service := warehouses.NewService(mockedRepository)
-		_, err := service.Update(2, requestData)
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, err := service.Update(context.Background(), 2, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, expectedError.Error(), err.Err.Error())
@@ -324,19 +467,20 @@ func TestServiceUpdate(t *testing.T) {
 		expectedError := errors.New("warehouse not found")
 		requestData := map[string]interface{}{}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).
 			Return(warehouses.Warehouse{}, expectedError).Once()
 
-		mockedRepository.On("GetAll").
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).
 			Return([]warehouses.Warehouse{}, nil).Once()
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(warehouses.Warehouse{}, nil).Once()
 
-		service := warehouses.NewService(mockedRepository)
-		_, err := service.Update(1, requestData)
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, err := service.Update(context.Background(), 1, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, http.StatusNotFound, err.Code)
@@ -348,22 +492,187 @@ func TestServiceUpdate(t *testing.T) {
 		expectedError := errors.New("ocurred an error while updating the warehouse")
 		requestData := map[string]interface{}{}
 
-		mockedRepository.On("GetOne", mock.AnythingOfType("int")).
+		mockedRepository.On("GetOne", mock.Anything, mock.AnythingOfType("int")).
 			Return(warehouses.Warehouse{}, nil).Once()
 
-		mockedRepository.On("GetAll").
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).
 			Return([]warehouses.Warehouse{}, nil).Once()
 
 		mockedRepository.On("Update",
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.Anything,
 		).Return(warehouses.Warehouse{}, expectedError).Once()
 
-		service := warehouses.NewService(mockedRepository)
-		_, err := service.Update(1, requestData)
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, err := service.Update(context.Background(), 1, requestData)
 
 		assert.NotNil(t, err.Err)
 		assert.Equal(t, http.StatusInternalServerError, err.Code)
 		assert.Equal(t, err.Err, expectedError)
 	})
 }
+
+func TestServiceWarehouseLocality(t *testing.T) {
+	localityId := "6"
+
+	t.Run("create must fail when the locality does not exist", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]warehouses.Warehouse{}, nil)
+		mockedLocality.On("GetOne", mock.Anything, localityId).Return(localities.Locality{}, localities.GetErrLocalityNotFound(localityId))
+
+		service := warehouses.NewService(mockedRepository, mockedLocality)
+		_, resp := service.Create(context.Background(), "212", address.FromLegacy("rua do bobo"), "(48) 3222-1100", 10, 30, &localityId)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		mockedRepository.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("create must store an existing locality", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+
+		expected := warehouses.Warehouse{Id: 1, WarehouseCode: "212", LocalityId: &localityId}
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]warehouses.Warehouse{}, nil)
+		mockedLocality.On("GetOne", mock.Anything, localityId).Return(localities.Locality{Id: localityId}, nil)
+		mockedRepository.On("Create", mock.Anything, "212", address.Address{Street: "rua do bobo", LocalityId: localityId}, phone.Number{E164: "+554832221100", Display: "(48) 3222-1100"}, 10, 30, &localityId).Return(expected, nil)
+
+		service := warehouses.NewService(mockedRepository, mockedLocality)
+		result, resp := service.Create(context.Background(), "212", address.FromLegacy("rua do bobo"), "(48) 3222-1100", 10, 30, &localityId)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("update must fail when the locality does not exist", func(t *testing.T) {
+		requestData := map[string]interface{}{"locality_id": localityId}
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(warehouses.Warehouse{Id: 1}, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]warehouses.Warehouse{}, nil)
+		mockedLocality.On("GetOne", mock.Anything, localityId).Return(localities.Locality{}, localities.GetErrLocalityNotFound(localityId))
+
+		service := warehouses.NewService(mockedRepository, mockedLocality)
+		_, resp := service.Update(context.Background(), 1, requestData)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		mockedRepository.AssertNumberOfCalls(t, "Update", 0)
+	})
+
+	t.Run("create must reject a state out of the locality province", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]warehouses.Warehouse{}, nil)
+		mockedLocality.On("GetOne", mock.Anything, localityId).Return(localities.Locality{Id: localityId, ProvinceName: "SC"}, nil)
+
+		service := warehouses.NewService(mockedRepository, mockedLocality)
+		_, resp := service.Create(context.Background(), "212", address.Address{Street: "Rua A", State: "SP"}, "(48) 3222-1100", 10, 30, &localityId)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "state SP does not match the province SC of locality 6", resp.Err.Error())
+		mockedRepository.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("create must reject an invalid telephone", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]warehouses.Warehouse{}, nil)
+
+		service := warehouses.NewService(mockedRepository, new(mockLocalityRepository.Repository))
+		_, resp := service.Create(context.Background(), "212", address.FromLegacy("rua do bobo"), "(11) 89999-0000", 10, 30, nil)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, phone.ErrInvalidRange, resp.Err)
+		mockedRepository.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("update must write the structured address columns", func(t *testing.T) {
+		requestData := map[string]interface{}{
+			"structured_address": map[string]interface{}{"street": "Rua A", "cep": "88010-000", "state": "sc"},
+		}
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+
+		mockedRepository.On("GetOne", mock.Anything, 1).Return(warehouses.Warehouse{Id: 1, LocalityId: &localityId}, nil)
+		mockedRepository.On("GetAll", mock.Anything, listing.Options{}).Return([]warehouses.Warehouse{}, nil)
+		mockedLocality.On("GetOne", mock.Anything, localityId).Return(localities.Locality{Id: localityId, ProvinceName: "SC"}, nil)
+		mockedRepository.On("Update", mock.Anything, 1, requestData).Return(warehouses.Warehouse{Id: 1}, nil)
+
+		service := warehouses.NewService(mockedRepository, mockedLocality)
+		_, resp := service.Update(context.Background(), 1, requestData)
+
+		assert.Nil(t, resp.Err)
+		assert.Equal(t, "Rua A - SC - 88010-000", requestData["address"])
+		assert.Equal(t, "88010000", requestData["cep"])
+		assert.NotContains(t, requestData, "structured_address")
+	})
+}
+
+func TestServiceGetNearest(t *testing.T) {
+	latitude, longitude := -23.5329, -46.7917
+	origin := localities.Locality{Id: "2", Latitude: &latitude, Longitude: &longitude}
+
+	t.Run("must order warehouses by distance", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+
+		mockedLocality.On("GetOne", mock.Anything, "2").Return(origin, nil)
+		mockedRepository.On("GetLocations", mock.Anything).Return([]warehouses.WarehouseLocation{
+			{Warehouse: warehouses.Warehouse{Id: 1}, Latitude: -5.2897, Longitude: -44.495},
+			{Warehouse: warehouses.Warehouse{Id: 2}, Latitude: -27.5954, Longitude: -48.548},
+			{Warehouse: warehouses.Warehouse{Id: 3}, Latitude: -23.5329, Longitude: -46.7917},
+		}, nil)
+
+		service := warehouses.NewService(mockedRepository, mockedLocality)
+		result, resp := service.GetNearest(context.Background(), "2")
+
+		assert.Nil(t, resp.Err)
+		assert.Len(t, result, 3)
+		assert.Equal(t, 3, result[0].Id)
+		assert.Equal(t, 0.0, result[0].DistanceKm)
+		assert.Equal(t, 2, result[1].Id)
+		assert.Equal(t, 1, result[2].Id)
+		assert.Less(t, result[1].DistanceKm, result[2].DistanceKm)
+	})
+
+	t.Run("must return not found for an unknown locality", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+
+		mockedLocality.On("GetOne", mock.Anything, "9").Return(localities.Locality{}, localities.GetErrLocalityNotFound("9"))
+
+		service := warehouses.NewService(mockedRepository, mockedLocality)
+		_, resp := service.GetNearest(context.Background(), "9")
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("must reject a locality without coordinates", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+
+		mockedLocality.On("GetOne", mock.Anything, "1").Return(localities.Locality{Id: "1"}, nil)
+
+		service := warehouses.NewService(mockedRepository, mockedLocality)
+		_, resp := service.GetNearest(context.Background(), "1")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, "locality with id 1 has no coordinates", resp.Err.Error())
+	})
+
+	t.Run("must return internal server error when locations fail", func(t *testing.T) {
+		mockedRepository := new(mocks.Repository)
+		mockedLocality := new(mockLocalityRepository.Repository)
+
+		mockedLocality.On("GetOne", mock.Anything, "2").Return(origin, nil)
+		mockedRepository.On("GetLocations", mock.Anything).Return([]warehouses.WarehouseLocation{}, errors.New("couldn't get warehouse locations"))
+
+		service := warehouses.NewService(mockedRepository, mockedLocality)
+		_, resp := service.GetNearest(context.Background(), "2")
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
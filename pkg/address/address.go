@@ -0,0 +1,227 @@
+// Package address holds the structured postal address shared by sellers,
+// carriers and warehouses, along with the CEP rules and the legacy
+// free-text form kept for older clients.
+package address
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	cepLength   = 8
+	maxLength   = 255
+	stateLength = 2
+)
+
+var (
+	ErrInvalidCep   = errors.New("cep must have 8 digits, e.g. 01310-100")
+	ErrInvalidState = errors.New("state must be a 2 letter code, e.g. SP")
+	ErrEmptyStreet  = errors.New("street is required")
+	ErrTooLong      = errors.New("address fields are too long: max 255 characters")
+	ErrInvalidInput = errors.New("invalid structured_address")
+
+	ErrLocalityMismatch = errors.New("structured_address.locality_id must match locality_id")
+	ErrLegacyTooLong    = errors.New("address too long: max 255 characters")
+)
+
+// Columns are the table columns that store the structured fields. The
+// legacy free-text form lives in the address column.
+var Columns = []string{"street", "number", "complement", "neighborhood", "cep", "state"}
+
+type Address struct {
+	Street       string `json:"street"`
+	Number       string `json:"number"`
+	Complement   string `json:"complement"`
+	Neighborhood string `json:"neighborhood"`
+	Cep          string `json:"cep"`
+	LocalityId   string `json:"locality_id"`
+	State        string `json:"state"`
+}
+
+// FromLegacy wraps a free-text address sent by older clients. The whole text
+// is kept as the street so nothing is lost until it is structured.
+func FromLegacy(text string) Address {
+	return Address{Street: strings.TrimSpace(text)}
+}
+
+// FromMap decodes a structured_address object received in a PATCH body.
+func FromMap(value interface{}) (Address, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return Address{}, ErrInvalidInput
+	}
+
+	var a Address
+	if err := json.Unmarshal(raw, &a); err != nil {
+		return Address{}, ErrInvalidInput
+	}
+	return a, nil
+}
+
+// FromRequestData reads the address sent in an update request, either as a
+// structured_address object or as the legacy free text. ok is false when the
+// request leaves the address untouched.
+func FromRequestData(requestData map[string]interface{}) (a Address, ok bool, err error) {
+	if structured, found := requestData["structured_address"]; found {
+		a, err = FromMap(structured)
+		return a, true, err
+	}
+
+	if legacy, found := requestData["address"].(string); found {
+		return FromLegacy(legacy), true, nil
+	}
+
+	return Address{}, false, nil
+}
+
+// Normalize trims the fields, keeps only the digits of the CEP and
+// upper-cases the state, failing when any of them is malformed.
+func Normalize(a Address) (Address, error) {
+	a.Street = strings.TrimSpace(a.Street)
+	a.Number = strings.TrimSpace(a.Number)
+	a.Complement = strings.TrimSpace(a.Complement)
+	a.Neighborhood = strings.TrimSpace(a.Neighborhood)
+	a.State = strings.ToUpper(strings.TrimSpace(a.State))
+
+	if a.Street == "" {
+		return Address{}, ErrEmptyStreet
+	}
+
+	for _, field := range []string{a.Street, a.Number, a.Complement, a.Neighborhood} {
+		if len(field) > maxLength {
+			return Address{}, ErrTooLong
+		}
+	}
+
+	if a.Cep != "" {
+		cep, err := NormalizeCep(a.Cep)
+		if err != nil {
+			return Address{}, err
+		}
+		a.Cep = cep
+	}
+
+	if a.State != "" && !isState(a.State) {
+		return Address{}, ErrInvalidState
+	}
+
+	return a, nil
+}
+
+// Link normalizes the address of an entity linked to localityId, making
+// sure the legacy text still fits the address column.
+func Link(a Address, localityId string) (Address, error) {
+	if a.LocalityId != "" && a.LocalityId != localityId {
+		return Address{}, ErrLocalityMismatch
+	}
+	a.LocalityId = localityId
+
+	a, err := Normalize(a)
+	if err != nil {
+		return Address{}, err
+	}
+
+	if len(a.String()) > maxLength {
+		return Address{}, ErrLegacyTooLong
+	}
+
+	return a, nil
+}
+
+// NormalizeCep accepts a CEP with or without its dash and returns its 8
+// digits.
+func NormalizeCep(value string) (string, error) {
+	cep := strings.NewReplacer("-", "", ".", "", " ", "").Replace(value)
+	if len(cep) != cepLength {
+		return "", ErrInvalidCep
+	}
+	for _, r := range cep {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidCep
+		}
+	}
+	return cep, nil
+}
+
+// CheckState compares the state with the province_name of the linked
+// locality. An address without state is accepted.
+func CheckState(a Address, provinceName string) error {
+	if a.State == "" || strings.EqualFold(a.State, provinceName) {
+		return nil
+	}
+	return fmt.Errorf("state %s does not match the province %s of locality %s", a.State, provinceName, a.LocalityId)
+}
+
+// String renders the legacy free-text form, e.g.
+// "Av. Paulista, 1000, apto 12 - Bela Vista - SP - 01310-100".
+func (a Address) String() string {
+	text := a.Street
+	for _, part := range []string{a.Number, a.Complement} {
+		if part != "" {
+			text += ", " + part
+		}
+	}
+
+	for _, part := range []string{a.Neighborhood, a.State, formatCep(a.Cep)} {
+		if part != "" {
+			text += " - " + part
+		}
+	}
+
+	return text
+}
+
+// Values returns the column values in the order of Columns, preceded by the
+// legacy address text.
+func (a Address) Values() []interface{} {
+	return []interface{}{a.String(), a.Street, a.Number, a.Complement, a.Neighborhood, a.Cep, a.State}
+}
+
+// ToRequestData writes the address into the column keys of an update
+// request, replacing the structured_address object.
+func (a Address) ToRequestData(requestData map[string]interface{}) {
+	delete(requestData, "structured_address")
+	requestData["address"] = a.String()
+	for i, column := range Columns {
+		requestData[column] = a.Values()[i+1]
+	}
+}
+
+// UpdatedColumns are the structured columns an update request writes. They
+// are only written along with the address text, as ToRequestData sets them
+// together, so stray column keys in a request are left out.
+func UpdatedColumns(requestData map[string]interface{}) []string {
+	columns := []string{}
+	if _, ok := requestData["address"]; !ok {
+		return columns
+	}
+
+	for _, column := range Columns {
+		if _, ok := requestData[column]; ok {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+func formatCep(cep string) string {
+	if len(cep) != cepLength {
+		return cep
+	}
+	return cep[:5] + "-" + cep[5:]
+}
+
+func isState(value string) bool {
+	if len(value) != stateLength {
+		return false
+	}
+	for _, r := range value {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
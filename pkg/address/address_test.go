@@ -0,0 +1,144 @@
+package address_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/address"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Run("trims the fields and keeps the digits of the cep", func(t *testing.T) {
+		result, err := address.Normalize(address.Address{
+			Street: " Av. Paulista ",
+			Number: "1000 ",
+			Cep:    "01310-100",
+			State:  "sp",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, address.Address{Street: "Av. Paulista", Number: "1000", Cep: "01310100", State: "SP"}, result)
+	})
+
+	t.Run("accepts an address without cep and state", func(t *testing.T) {
+		result, err := address.Normalize(address.Address{Street: "Rua A"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Rua A", result.Street)
+	})
+
+	t.Run("requires the street", func(t *testing.T) {
+		_, err := address.Normalize(address.Address{Street: " ", Cep: "01310100"})
+		assert.Equal(t, address.ErrEmptyStreet, err)
+	})
+
+	t.Run("rejects a malformed cep", func(t *testing.T) {
+		for _, cep := range []string{"1310-100", "01310-1000", "0131A-100"} {
+			_, err := address.Normalize(address.Address{Street: "Rua A", Cep: cep})
+			assert.Equal(t, address.ErrInvalidCep, err, cep)
+		}
+	})
+
+	t.Run("rejects a malformed state", func(t *testing.T) {
+		for _, state := range []string{"S", "SPA", "S1"} {
+			_, err := address.Normalize(address.Address{Street: "Rua A", State: state})
+			assert.Equal(t, address.ErrInvalidState, err, state)
+		}
+	})
+}
+
+func TestLink(t *testing.T) {
+	t.Run("sets the locality of the address", func(t *testing.T) {
+		result, err := address.Link(address.Address{Street: "Rua A"}, "6700")
+		assert.NoError(t, err)
+		assert.Equal(t, "6700", result.LocalityId)
+	})
+
+	t.Run("rejects a different locality", func(t *testing.T) {
+		_, err := address.Link(address.Address{Street: "Rua A", LocalityId: "1"}, "6700")
+		assert.Equal(t, address.ErrLocalityMismatch, err)
+	})
+
+	t.Run("rejects an address whose legacy text does not fit", func(t *testing.T) {
+		long := address.Address{Street: strings.Repeat("a", 200), Neighborhood: strings.Repeat("b", 60)}
+
+		_, err := address.Link(long, "6700")
+		assert.Equal(t, address.ErrLegacyTooLong, err)
+	})
+}
+
+func TestCheckState(t *testing.T) {
+	a := address.Address{Street: "Rua A", State: "SP", LocalityId: "6700"}
+
+	assert.NoError(t, address.CheckState(a, "SP"))
+	assert.NoError(t, address.CheckState(address.Address{Street: "Rua A"}, "RJ"))
+	assert.EqualError(t, address.CheckState(a, "RJ"), "state SP does not match the province RJ of locality 6700")
+}
+
+func TestString(t *testing.T) {
+	a := address.Address{
+		Street:       "Av. Paulista",
+		Number:       "1000",
+		Complement:   "apto 12",
+		Neighborhood: "Bela Vista",
+		Cep:          "01310100",
+		State:        "SP",
+	}
+	assert.Equal(t, "Av. Paulista, 1000, apto 12 - Bela Vista - SP - 01310-100", a.String())
+	assert.Equal(t, "Rua A", address.FromLegacy(" Rua A ").String())
+}
+
+func TestRequestData(t *testing.T) {
+	t.Run("reads the structured address", func(t *testing.T) {
+		requestData := map[string]interface{}{
+			"structured_address": map[string]interface{}{"street": "Rua A", "cep": "01310-100"},
+		}
+
+		result, ok, err := address.FromRequestData(requestData)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, address.Address{Street: "Rua A", Cep: "01310-100"}, result)
+	})
+
+	t.Run("falls back to the legacy address", func(t *testing.T) {
+		result, ok, err := address.FromRequestData(map[string]interface{}{"address": "Rua A"})
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, address.FromLegacy("Rua A"), result)
+	})
+
+	t.Run("reports an untouched address", func(t *testing.T) {
+		_, ok, err := address.FromRequestData(map[string]interface{}{"telephone": "3003"})
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("rejects a structured address with wrong types", func(t *testing.T) {
+		_, _, err := address.FromRequestData(map[string]interface{}{
+			"structured_address": map[string]interface{}{"street": 10},
+		})
+		assert.Equal(t, address.ErrInvalidInput, err)
+	})
+
+	t.Run("writes the columns and the legacy text", func(t *testing.T) {
+		requestData := map[string]interface{}{"structured_address": map[string]interface{}{}}
+		address.Address{Street: "Rua A", Cep: "01310100", State: "SP"}.ToRequestData(requestData)
+
+		assert.Equal(t, map[string]interface{}{
+			"address":      "Rua A - SP - 01310-100",
+			"street":       "Rua A",
+			"number":       "",
+			"complement":   "",
+			"neighborhood": "",
+			"cep":          "01310100",
+			"state":        "SP",
+		}, requestData)
+	})
+
+	t.Run("updates the columns only along with the address", func(t *testing.T) {
+		requestData := map[string]interface{}{}
+		address.Address{Street: "Rua A"}.ToRequestData(requestData)
+		assert.Equal(t, address.Columns, address.UpdatedColumns(requestData))
+
+		assert.Empty(t, address.UpdatedColumns(map[string]interface{}{"street": "Rua A", "cep": "01310100"}))
+	})
+}
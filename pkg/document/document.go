@@ -0,0 +1,147 @@
+// Package document validates Brazilian taxpayer documents: the CPF of
+// individuals and the CNPJ of companies, in both the numeric and the
+// alphanumeric format.
+package document
+
+import (
+	"errors"
+	"strings"
+)
+
+type Type string
+
+const (
+	CPF  Type = "CPF"
+	CNPJ Type = "CNPJ"
+)
+
+const (
+	cpfLength  = 11
+	cnpjLength = 14
+)
+
+var ErrInvalid = errors.New("invalid document")
+
+// Document is a validated document in its canonical form: punctuation
+// stripped and letters upper-cased.
+type Document struct {
+	Type   Type
+	Number string
+}
+
+func (d Document) String() string {
+	return d.Number
+}
+
+// Parse normalizes value and validates it as one of the accepted types.
+// It returns ErrInvalid when the value is not a well-formed document of an
+// accepted type or when its check digits do not match.
+func Parse(value string, accepted ...Type) (Document, error) {
+	number := Normalize(value)
+
+	for _, docType := range accepted {
+		if docType == CPF && validCPF(number) {
+			return Document{Type: CPF, Number: number}, nil
+		}
+		if docType == CNPJ && validCNPJ(number) {
+			return Document{Type: CNPJ, Number: number}, nil
+		}
+	}
+
+	return Document{}, ErrInvalid
+}
+
+// Normalize removes the usual punctuation (dots, dashes, slashes and
+// spaces) and upper-cases the letters of an alphanumeric CNPJ.
+func Normalize(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '-', '/', ' ':
+			return -1
+		}
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		return r
+	}, value)
+}
+
+// Describe lists the accepted types for error messages, e.g. "CPF or CNPJ".
+func Describe(accepted []Type) string {
+	names := make([]string, len(accepted))
+	for i, docType := range accepted {
+		names[i] = string(docType)
+	}
+	return strings.Join(names, " or ")
+}
+
+func validCPF(number string) bool {
+	if len(number) != cpfLength || !isDigits(number) || repeated(number) {
+		return false
+	}
+
+	return checkDigit(number[:9], 10) == number[9] &&
+		checkDigit(number[:10], 11) == number[10]
+}
+
+// validCNPJ accepts the alphanumeric format, where the first twelve
+// positions may hold letters valued by their ASCII code minus 48 and the
+// two check digits stay numeric.
+func validCNPJ(number string) bool {
+	if len(number) != cnpjLength || repeated(number) {
+		return false
+	}
+	for _, r := range number[:12] {
+		if !(r >= '0' && r <= '9') && !(r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	if !isDigits(number[12:]) {
+		return false
+	}
+
+	return cnpjCheckDigit(number[:12]) == number[12] &&
+		cnpjCheckDigit(number[:13]) == number[13]
+}
+
+func checkDigit(base string, weight int) byte {
+	sum := 0
+	for i := range base {
+		sum += int(base[i]-'0') * (weight - i)
+	}
+	return mod11(sum)
+}
+
+func cnpjCheckDigit(base string) byte {
+	sum := 0
+	weight := len(base) - 7
+	for i := range base {
+		sum += int(base[i]-'0') * weight
+		weight--
+		if weight < 2 {
+			weight = 9
+		}
+	}
+	return mod11(sum)
+}
+
+func mod11(sum int) byte {
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - rest)
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func repeated(value string) bool {
+	return strings.Count(value, value[:1]) == len(value)
+}
@@ -0,0 +1,76 @@
+package document_test
+
+import (
+	"testing"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/document"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("valid CPF with punctuation", func(t *testing.T) {
+		doc, err := document.Parse("529.982.247-25", document.CPF)
+		assert.NoError(t, err)
+		assert.Equal(t, document.Document{Type: document.CPF, Number: "52998224725"}, doc)
+	})
+
+	t.Run("valid numeric CNPJ", func(t *testing.T) {
+		doc, err := document.Parse("11.222.333/0001-81", document.CNPJ)
+		assert.NoError(t, err)
+		assert.Equal(t, document.Document{Type: document.CNPJ, Number: "11222333000181"}, doc)
+	})
+
+	t.Run("valid alphanumeric CNPJ in lower case", func(t *testing.T) {
+		doc, err := document.Parse("12.abc.345/01de-35", document.CNPJ)
+		assert.NoError(t, err)
+		assert.Equal(t, "12ABC34501DE35", doc.String())
+	})
+
+	t.Run("picks the accepted type by length", func(t *testing.T) {
+		doc, err := document.Parse("11222333000181", document.CPF, document.CNPJ)
+		assert.NoError(t, err)
+		assert.Equal(t, document.CNPJ, doc.Type)
+	})
+
+	t.Run("rejects a type that is not accepted", func(t *testing.T) {
+		_, err := document.Parse("52998224725", document.CNPJ)
+		assert.Equal(t, document.ErrInvalid, err)
+	})
+
+	t.Run("rejects wrong check digits", func(t *testing.T) {
+		_, err := document.Parse("529.982.247-26", document.CPF)
+		assert.Equal(t, document.ErrInvalid, err)
+
+		_, err = document.Parse("11.222.333/0001-82", document.CNPJ)
+		assert.Equal(t, document.ErrInvalid, err)
+	})
+
+	t.Run("rejects repeated digits", func(t *testing.T) {
+		_, err := document.Parse("111.111.111-11", document.CPF)
+		assert.Equal(t, document.ErrInvalid, err)
+
+		_, err = document.Parse("00000000000000", document.CNPJ)
+		assert.Equal(t, document.ErrInvalid, err)
+	})
+
+	t.Run("rejects letters in CPF and in CNPJ check digits", func(t *testing.T) {
+		_, err := document.Parse("5299822472A", document.CPF)
+		assert.Equal(t, document.ErrInvalid, err)
+
+		_, err = document.Parse("12ABC34501DE3A", document.CNPJ)
+		assert.Equal(t, document.ErrInvalid, err)
+	})
+
+	t.Run("rejects empty and unknown characters", func(t *testing.T) {
+		_, err := document.Parse("", document.CPF, document.CNPJ)
+		assert.Equal(t, document.ErrInvalid, err)
+
+		_, err = document.Parse("529*982*247*25", document.CPF)
+		assert.Equal(t, document.ErrInvalid, err)
+	})
+}
+
+func TestDescribe(t *testing.T) {
+	assert.Equal(t, "CNPJ", document.Describe([]document.Type{document.CNPJ}))
+	assert.Equal(t, "CPF or CNPJ", document.Describe([]document.Type{document.CPF, document.CNPJ}))
+}
@@ -0,0 +1,24 @@
+package geo
+
+import "math"
+
+// EarthRadiusKm is the mean radius of the Earth used by DistanceKm.
+const EarthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle distance in kilometers between two
+// points given in decimal degrees, using the haversine formula.
+func DistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1 := toRadians(lat1)
+	phi2 := toRadians(lat2)
+	deltaPhi := toRadians(lat2 - lat1)
+	deltaLambda := toRadians(lon2 - lon1)
+
+	a := math.Sin(deltaPhi/2)*math.Sin(deltaPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(deltaLambda/2)*math.Sin(deltaLambda/2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
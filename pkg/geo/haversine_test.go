@@ -0,0 +1,31 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/geo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistanceKm(t *testing.T) {
+	t.Run("same point", func(t *testing.T) {
+		assert.Equal(t, 0.0, geo.DistanceKm(-23.5329, -46.7917, -23.5329, -46.7917))
+	})
+
+	t.Run("Osasco to Florianópolis", func(t *testing.T) {
+		distance := geo.DistanceKm(-23.5329, -46.7917, -27.5954, -48.548)
+		assert.InDelta(t, 486, distance, 5)
+	})
+
+	t.Run("is symmetric", func(t *testing.T) {
+		assert.InDelta(t,
+			geo.DistanceKm(-5.2897, -44.495, -27.5954, -48.548),
+			geo.DistanceKm(-27.5954, -48.548, -5.2897, -44.495),
+			1e-9,
+		)
+	})
+
+	t.Run("antipodal points", func(t *testing.T) {
+		assert.InDelta(t, 20015, geo.DistanceKm(0, 0, 0, 180), 1)
+	})
+}
@@ -0,0 +1,316 @@
+// Package listing reads the pagination, sorting and filtering options of
+// list endpoints from a query string and turns them into parameterized SQL.
+//
+// Only the fields allowlisted by each entity reach the SQL, so the values
+// of the query string are always bound as arguments.
+package listing
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var (
+	ErrInvalidLimit  = fmt.Errorf("limit must be a number between 1 and %d", MaxLimit)
+	ErrInvalidOffset = errors.New("offset must be a positive number")
+	ErrInvalidOrder  = errors.New("order must be asc or desc")
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrCursorSort    = errors.New("cursor does not match the informed sort and order")
+	ErrCursorOffset  = errors.New("cursor and offset can not be used together")
+)
+
+// reserved are the parameters that are not field filters.
+var reserved = map[string]bool{"limit": true, "offset": true, "cursor": true, "sort": true, "order": true}
+
+// Fields is the allowlist of an entity. Columns maps the json name of each
+// field accepted as an equality filter to its SQL column and must have
+// "id". Sortable lists the fields accepted by sort; they must be top level
+// fields of the entity, read as a nil pointer when their column is NULL.
+type Fields struct {
+	Columns  map[string]string
+	Sortable []string
+}
+
+type Filter struct {
+	Field string
+	Value string
+}
+
+type Options struct {
+	Limit   int
+	Offset  int
+	Sort    string
+	Order   string
+	Filters []Filter
+	After   *Cursor
+}
+
+type Cursor struct {
+	Sort  string      `json:"s"`
+	Order string      `json:"o"`
+	Value interface{} `json:"v"`
+	Id    int         `json:"i"`
+}
+
+// Page is the pagination metadata of a list response.
+type Page struct {
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	Sort       string `json:"sort"`
+	Order      string `json:"order"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	NextOffset *int   `json:"next_offset,omitempty"`
+}
+
+func (f Fields) isSortable(field string) bool {
+	for _, sortable := range f.Sortable {
+		if sortable == field {
+			return true
+		}
+	}
+	return field == "id"
+}
+
+// Parse reads limit, offset or cursor, sort, order and the field filters
+// of values. Any other parameter is rejected.
+func Parse(values url.Values, fields Fields) (Options, error) {
+	opts := Options{Limit: DefaultLimit, Sort: "id", Order: SortAsc}
+
+	if value := values.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > MaxLimit {
+			return Options{}, ErrInvalidLimit
+		}
+		opts.Limit = limit
+	}
+
+	if value := values.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return Options{}, ErrInvalidOffset
+		}
+		opts.Offset = offset
+	}
+
+	if value := values.Get("sort"); value != "" {
+		if !fields.isSortable(value) {
+			return Options{}, fmt.Errorf("invalid sort field %s", value)
+		}
+		opts.Sort = value
+	}
+
+	if value := strings.ToLower(values.Get("order")); value != "" {
+		if value != SortAsc && value != SortDesc {
+			return Options{}, ErrInvalidOrder
+		}
+		opts.Order = value
+	}
+
+	if value := values.Get("cursor"); value != "" {
+		if opts.Offset != 0 {
+			return Options{}, ErrCursorOffset
+		}
+		cursor, err := DecodeCursor(value, opts.Sort, opts.Order)
+		if err != nil {
+			return Options{}, err
+		}
+		opts.After = &cursor
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	// A stable order keeps the generated SQL the same for the same request.
+	sort.Strings(names)
+
+	for _, name := range names {
+		if reserved[name] {
+			continue
+		}
+		if _, ok := fields.Columns[name]; !ok {
+			return Options{}, fmt.Errorf("invalid filter %s", name)
+		}
+		opts.Filters = append(opts.Filters, Filter{Field: name, Value: values.Get(name)})
+	}
+
+	return opts, nil
+}
+
+// Build appends the filters, cursor, order and limit of opts to selectFrom,
+// a query without WHERE, ORDER BY or LIMIT. It fetches one row over the
+// limit so Paginate can tell whether there is a next page. The zero
+// Options lists every row.
+func Build(selectFrom string, fields Fields, opts Options) (string, []interface{}) {
+	conditions := []string{}
+	values := []interface{}{}
+
+	for _, filter := range opts.Filters {
+		column, ok := fields.Columns[filter.Field]
+		if !ok {
+			continue
+		}
+		conditions = append(conditions, column+" = ?")
+		values = append(values, filter.Value)
+	}
+
+	idColumn := fields.Columns["id"]
+	sortColumn := idColumn
+	if opts.Sort != "" && opts.Sort != "id" && fields.isSortable(opts.Sort) {
+		sortColumn = fields.Columns[opts.Sort]
+	}
+
+	if opts.After != nil {
+		condition, afterValues := After(sortColumn, idColumn, opts.Order, opts.After.Value, opts.After.Id)
+		conditions = append(conditions, condition)
+		values = append(values, afterValues...)
+	}
+
+	query := selectFrom
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY " + OrderBy(sortColumn, idColumn, opts.Order)
+
+	if opts.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		values = append(values, opts.Limit+1, opts.Offset)
+	}
+
+	return query, values
+}
+
+// OrderBy orders by column and then by idColumn, both in order. The NULLs
+// of column come after every value in ascending order and before them in
+// descending order.
+func OrderBy(column, idColumn, order string) string {
+	direction := "ASC"
+	if order == SortDesc {
+		direction = "DESC"
+	}
+
+	if column == idColumn {
+		return fmt.Sprintf("%s %s", idColumn, direction)
+	}
+	return fmt.Sprintf("%[1]s IS NULL %[2]s, %[1]s %[2]s, %[3]s %[2]s", column, direction, idColumn)
+}
+
+// After is the condition that keeps the rows OrderBy places after the row
+// whose column holds value, nil standing for NULL, and whose id is id.
+func After(column, idColumn, order string, value interface{}, id int) (string, []interface{}) {
+	desc := order == SortDesc
+
+	switch {
+	case column == idColumn && desc:
+		return fmt.Sprintf("%s < ?", idColumn), []interface{}{id}
+	case column == idColumn:
+		return fmt.Sprintf("%s > ?", idColumn), []interface{}{id}
+	case value == nil && desc:
+		return fmt.Sprintf("(%s IS NOT NULL OR %s < ?)", column, idColumn), []interface{}{id}
+	case value == nil:
+		return fmt.Sprintf("(%s IS NULL AND %s > ?)", column, idColumn), []interface{}{id}
+	case desc:
+		return fmt.Sprintf("(%[1]s < ? OR (%[1]s = ? AND %[2]s < ?))", column, idColumn), []interface{}{value, value, id}
+	}
+	return fmt.Sprintf("(%[1]s IS NULL OR %[1]s > ? OR (%[1]s = ? AND %[2]s > ?))", column, idColumn), []interface{}{value, value, id}
+}
+
+// Paginate drops the extra row fetched by Build from items and describes
+// the page. The next cursor is made of the id and the sorted field of the
+// last item, read through their json names.
+func Paginate[T any](items []T, opts Options) ([]T, Page) {
+	page := Page{Limit: opts.Limit, Offset: opts.Offset, Sort: opts.Sort, Order: opts.Order}
+
+	if opts.Limit <= 0 || len(items) <= opts.Limit {
+		return items, page
+	}
+
+	items = items[:opts.Limit]
+	last := reflect.ValueOf(items[opts.Limit-1])
+
+	cursor := Cursor{Sort: opts.Sort, Order: opts.Order}
+	if id, ok := fieldOf(last, "id").(int); ok {
+		cursor.Id = id
+	}
+	if opts.Sort != "id" {
+		cursor.Value = fieldOf(last, opts.Sort)
+	}
+
+	page.HasMore = true
+	page.NextCursor = EncodeCursor(cursor)
+	if opts.After == nil {
+		nextOffset := opts.Offset + opts.Limit
+		page.NextOffset = &nextOffset
+	}
+
+	return items, page
+}
+
+func EncodeCursor(cursor Cursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(encoded, field, order string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Id <= 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	if cursor.Sort != field || cursor.Order != order {
+		return Cursor{}, ErrCursorSort
+	}
+
+	return cursor, nil
+}
+
+// fieldOf returns the field of the struct item whose json name is name.
+func fieldOf(item reflect.Value, name string) interface{} {
+	for item.Kind() == reflect.Ptr {
+		item = item.Elem()
+	}
+
+	itemType := item.Type()
+	for i := 0; i < itemType.NumField(); i++ {
+		tag := strings.Split(itemType.Field(i).Tag.Get("json"), ",")[0]
+		if tag == name {
+			return valueOf(item.Field(i))
+		}
+	}
+
+	return nil
+}
+
+// valueOf returns the value of field, nil for a nil pointer so a NULL
+// column is kept as such in a cursor.
+func valueOf(field reflect.Value) interface{} {
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	return field.Interface()
+}
@@ -0,0 +1,200 @@
+package listing_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/listing"
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	Id     int     `json:"id"`
+	Name   string  `json:"name"`
+	CityId *string `json:"city_id"`
+}
+
+var fields = listing.Fields{
+	Columns: map[string]string{
+		"id":      "t.id",
+		"name":    "t.name",
+		"city_id": "t.city_id",
+	},
+	Sortable: []string{"name"},
+}
+
+func TestParse(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		opts, err := listing.Parse(url.Values{}, fields)
+		assert.NoError(t, err)
+		assert.Equal(t, listing.Options{Limit: listing.DefaultLimit, Sort: "id", Order: listing.SortAsc}, opts)
+	})
+
+	t.Run("limit, offset, sort, order and filters", func(t *testing.T) {
+		values, _ := url.ParseQuery("limit=5&offset=10&sort=name&order=DESC&name=x&city_id=1")
+		opts, err := listing.Parse(values, fields)
+		assert.NoError(t, err)
+		assert.Equal(t, listing.Options{
+			Limit:   5,
+			Offset:  10,
+			Sort:    "name",
+			Order:   listing.SortDesc,
+			Filters: []listing.Filter{{Field: "city_id", Value: "1"}, {Field: "name", Value: "x"}},
+		}, opts)
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		for query, expected := range map[string]string{
+			"limit=0":      listing.ErrInvalidLimit.Error(),
+			"limit=101":    listing.ErrInvalidLimit.Error(),
+			"limit=a":      listing.ErrInvalidLimit.Error(),
+			"offset=-1":    listing.ErrInvalidOffset.Error(),
+			"order=up":     listing.ErrInvalidOrder.Error(),
+			"sort=city_id": "invalid sort field city_id",
+			"password=x":   "invalid filter password",
+			"cursor=***":   listing.ErrInvalidCursor.Error(),
+		} {
+			values, _ := url.ParseQuery(query)
+			_, err := listing.Parse(values, fields)
+			assert.EqualError(t, err, expected, query)
+		}
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		cursor := listing.EncodeCursor(listing.Cursor{Sort: "name", Order: listing.SortAsc, Value: "x", Id: 3})
+
+		opts, err := listing.Parse(url.Values{"sort": {"name"}, "cursor": {cursor}}, fields)
+		assert.NoError(t, err)
+		assert.Equal(t, &listing.Cursor{Sort: "name", Order: listing.SortAsc, Value: "x", Id: 3}, opts.After)
+
+		_, err = listing.Parse(url.Values{"cursor": {cursor}}, fields)
+		assert.Equal(t, listing.ErrCursorSort, err)
+
+		_, err = listing.Parse(url.Values{"sort": {"name"}, "offset": {"2"}, "cursor": {cursor}}, fields)
+		assert.Equal(t, listing.ErrCursorOffset, err)
+
+		_, err = listing.Parse(url.Values{"cursor": {"bm90IGpzb24"}}, fields)
+		assert.Equal(t, listing.ErrInvalidCursor, err)
+	})
+}
+
+func TestBuild(t *testing.T) {
+	t.Run("zero options list every row", func(t *testing.T) {
+		query, values := listing.Build("SELECT * FROM t", fields, listing.Options{})
+		assert.Equal(t, "SELECT * FROM t ORDER BY t.id ASC", query)
+		assert.Empty(t, values)
+	})
+
+	t.Run("filters, sort and limit", func(t *testing.T) {
+		query, values := listing.Build("SELECT * FROM t", fields, listing.Options{
+			Limit:   5,
+			Offset:  10,
+			Sort:    "name",
+			Order:   listing.SortDesc,
+			Filters: []listing.Filter{{Field: "city_id", Value: "1"}},
+		})
+		assert.Equal(t, "SELECT * FROM t WHERE t.city_id = ? ORDER BY t.name IS NULL DESC, t.name DESC, t.id DESC LIMIT ? OFFSET ?", query)
+		assert.Equal(t, []interface{}{"1", 6, 10}, values)
+	})
+
+	t.Run("cursor on id", func(t *testing.T) {
+		query, values := listing.Build("SELECT * FROM t", fields, listing.Options{
+			Limit: 5,
+			Sort:  "id",
+			Order: listing.SortAsc,
+			After: &listing.Cursor{Sort: "id", Order: listing.SortAsc, Id: 7},
+		})
+		assert.Equal(t, "SELECT * FROM t WHERE t.id > ? ORDER BY t.id ASC LIMIT ? OFFSET ?", query)
+		assert.Equal(t, []interface{}{7, 6, 0}, values)
+	})
+
+	t.Run("cursor on another field", func(t *testing.T) {
+		query, values := listing.Build("SELECT * FROM t", fields, listing.Options{
+			Limit: 5,
+			Sort:  "name",
+			Order: listing.SortAsc,
+			After: &listing.Cursor{Sort: "name", Order: listing.SortAsc, Value: "x", Id: 7},
+		})
+		assert.Equal(t, "SELECT * FROM t WHERE (t.name IS NULL OR t.name > ? OR (t.name = ? AND t.id > ?)) ORDER BY t.name IS NULL ASC, t.name ASC, t.id ASC LIMIT ? OFFSET ?", query)
+		assert.Equal(t, []interface{}{"x", "x", 7, 6, 0}, values)
+	})
+
+	t.Run("cursor on another field in descending order", func(t *testing.T) {
+		query, values := listing.Build("SELECT * FROM t", fields, listing.Options{
+			Limit: 5,
+			Sort:  "name",
+			Order: listing.SortDesc,
+			After: &listing.Cursor{Sort: "name", Order: listing.SortDesc, Value: "x", Id: 7},
+		})
+		assert.Equal(t, "SELECT * FROM t WHERE (t.name < ? OR (t.name = ? AND t.id < ?)) ORDER BY t.name IS NULL DESC, t.name DESC, t.id DESC LIMIT ? OFFSET ?", query)
+		assert.Equal(t, []interface{}{"x", "x", 7, 6, 0}, values)
+	})
+
+	t.Run("cursor on a NULL value", func(t *testing.T) {
+		fields := listing.Fields{Columns: fields.Columns, Sortable: []string{"city_id"}}
+
+		query, values := listing.Build("SELECT * FROM t", fields, listing.Options{
+			Limit: 5,
+			Sort:  "city_id",
+			Order: listing.SortAsc,
+			After: &listing.Cursor{Sort: "city_id", Order: listing.SortAsc, Id: 7},
+		})
+		assert.Equal(t, "SELECT * FROM t WHERE (t.city_id IS NULL AND t.id > ?) ORDER BY t.city_id IS NULL ASC, t.city_id ASC, t.id ASC LIMIT ? OFFSET ?", query)
+		assert.Equal(t, []interface{}{7, 6, 0}, values)
+
+		query, values = listing.Build("SELECT * FROM t", fields, listing.Options{
+			Limit: 5,
+			Sort:  "city_id",
+			Order: listing.SortDesc,
+			After: &listing.Cursor{Sort: "city_id", Order: listing.SortDesc, Id: 7},
+		})
+		assert.Equal(t, "SELECT * FROM t WHERE (t.city_id IS NOT NULL OR t.id < ?) ORDER BY t.city_id IS NULL DESC, t.city_id DESC, t.id DESC LIMIT ? OFFSET ?", query)
+		assert.Equal(t, []interface{}{7, 6, 0}, values)
+	})
+}
+
+func TestPaginate(t *testing.T) {
+	items := []item{{Id: 1, Name: "a"}, {Id: 2, Name: "b"}, {Id: 3, Name: "c"}}
+
+	t.Run("last page", func(t *testing.T) {
+		result, page := listing.Paginate(items, listing.Options{Limit: 3, Sort: "id", Order: listing.SortAsc})
+		assert.Len(t, result, 3)
+		assert.False(t, page.HasMore)
+		assert.Empty(t, page.NextCursor)
+		assert.Nil(t, page.NextOffset)
+	})
+
+	t.Run("more pages", func(t *testing.T) {
+		result, page := listing.Paginate(items, listing.Options{Limit: 2, Offset: 4, Sort: "name", Order: listing.SortAsc})
+		assert.Equal(t, items[:2], result)
+		assert.True(t, page.HasMore)
+		assert.Equal(t, 6, *page.NextOffset)
+
+		cursor, err := listing.DecodeCursor(page.NextCursor, "name", listing.SortAsc)
+		assert.NoError(t, err)
+		assert.Equal(t, listing.Cursor{Sort: "name", Order: listing.SortAsc, Value: "b", Id: 2}, cursor)
+	})
+
+	t.Run("NULL sorted field", func(t *testing.T) {
+		city := "1"
+		withCity := []item{{Id: 1, CityId: &city}, {Id: 2}, {Id: 3}}
+
+		_, page := listing.Paginate(withCity, listing.Options{Limit: 1, Sort: "city_id", Order: listing.SortAsc})
+		cursor, err := listing.DecodeCursor(page.NextCursor, "city_id", listing.SortAsc)
+		assert.NoError(t, err)
+		assert.Equal(t, "1", cursor.Value)
+
+		_, page = listing.Paginate(withCity[1:], listing.Options{Limit: 1, Sort: "city_id", Order: listing.SortAsc})
+		cursor, err = listing.DecodeCursor(page.NextCursor, "city_id", listing.SortAsc)
+		assert.NoError(t, err)
+		assert.Equal(t, listing.Cursor{Sort: "city_id", Order: listing.SortAsc, Id: 2}, cursor)
+	})
+
+	t.Run("no next offset when paging by cursor", func(t *testing.T) {
+		after := &listing.Cursor{Sort: "id", Order: listing.SortAsc, Id: 1}
+		_, page := listing.Paginate(items, listing.Options{Limit: 2, Sort: "id", Order: listing.SortAsc, After: after})
+		assert.True(t, page.HasMore)
+		assert.NotEmpty(t, page.NextCursor)
+		assert.Nil(t, page.NextOffset)
+	})
+}
@@ -0,0 +1,401 @@
+// Package migrate applies numbered SQL migrations to a database and keeps
+// track of the applied ones in the schema_migrations table.
+//
+// Each migration is a pair of files named NNNN_name.up.sql and
+// NNNN_name.down.sql, where NNNN is its version.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	queryCreateTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+  version BIGINT UNSIGNED NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (version))`
+	queryGetApplied    = "SELECT version FROM schema_migrations ORDER BY version"
+	queryInsertApplied = "INSERT INTO schema_migrations (version, name) VALUES (?, ?)"
+	queryDeleteApplied = "DELETE FROM schema_migrations WHERE version = ?"
+	queryGetLock       = "SELECT GET_LOCK('schema_migrations', ?)"
+	queryReleaseLock   = "SELECT RELEASE_LOCK('schema_migrations')"
+)
+
+// lockTimeout is how long, in seconds, a migrator waits for another one
+// holding the schema_migrations lock.
+const lockTimeout = 60
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version int
+	Name    string
+	Applied bool
+}
+
+// Load reads the migrations in the root of fsys, sorted by version. Files
+// not named after a migration are ignored.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version %s", entry.Name())
+		}
+
+		script, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("duplicate migration version %d", version)
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(script)
+		} else {
+			migration.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("migration %d_%s must have an up and a down script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Statements splits script into its statements. Semicolons inside quotes,
+// backticks and comments do not end a statement.
+func Statements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	var quote byte
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+
+		switch {
+		case quote != 0:
+			current.WriteByte(c)
+			if c == '\\' && quote != '`' && i+1 < len(script) {
+				i++
+				current.WriteByte(script[i])
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			current.WriteByte(c)
+		case c == '#' || (c == '-' && strings.HasPrefix(script[i:], "-- ")):
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+			current.WriteByte('\n')
+		case c == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end + 3
+			}
+			current.WriteByte(' ')
+		case c == ';':
+			if statement := strings.TrimSpace(current.String()); statement != "" {
+				statements = append(statements, statement)
+			}
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	if statement := strings.TrimSpace(current.String()); statement != "" {
+		statements = append(statements, statement)
+	}
+
+	return statements
+}
+
+// Migrator runs migrations on a single connection, so the session variables
+// a script sets hold for all of its statements.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+func New(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// Up applies every pending migration and returns the applied ones.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	if len(m.migrations) == 0 {
+		return nil, nil
+	}
+	return m.To(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down reverts the last steps applied migrations and returns them.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, errors.New("steps must be greater than 0")
+	}
+
+	return m.run(ctx, func(applied map[int]bool) int {
+		version := 0
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if !applied[m.migrations[i].Version] {
+				continue
+			}
+			if steps == 0 {
+				version = m.migrations[i].Version
+				break
+			}
+			steps--
+		}
+		return version
+	})
+}
+
+// To applies or reverts migrations until version is the last applied one.
+// Version 0 reverts every migration.
+func (m *Migrator) To(ctx context.Context, version int) ([]Migration, error) {
+	if version != 0 && m.find(version) < 0 {
+		return nil, fmt.Errorf("unknown migration version %d", version)
+	}
+
+	return m.run(ctx, func(map[int]bool) int {
+		return version
+	})
+}
+
+// Status lists every migration and whether it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status = append(status, Status{
+			Version: migration.Version,
+			Name:    migration.Name,
+			Applied: applied[migration.Version],
+		})
+	}
+
+	return status, nil
+}
+
+// Force records the migrations up to version as applied, and the later ones
+// as pending, without running them. It adopts a database whose schema was
+// created by other means.
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	if version != 0 && m.find(version) < 0 {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := lock(ctx, conn); err != nil {
+		return err
+	}
+	defer unlock(conn)
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range m.migrations {
+		switch {
+		case migration.Version <= version && !applied[migration.Version]:
+			_, err = conn.ExecContext(ctx, queryInsertApplied, migration.Version, migration.Name)
+		case migration.Version > version && applied[migration.Version]:
+			_, err = conn.ExecContext(ctx, queryDeleteApplied, migration.Version)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Exec runs the statements of script, which is not tracked as a migration.
+func (m *Migrator) Exec(ctx context.Context, script string) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return exec(ctx, conn, script)
+}
+
+func (m *Migrator) run(ctx context.Context, target func(applied map[int]bool) int) ([]Migration, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := lock(ctx, conn); err != nil {
+		return nil, err
+	}
+	defer unlock(conn)
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	version := target(applied)
+
+	var done []Migration
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if migration.Version <= version || !applied[migration.Version] {
+			continue
+		}
+		if err := exec(ctx, conn, migration.Down); err != nil {
+			return done, fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+		}
+		if _, err := conn.ExecContext(ctx, queryDeleteApplied, migration.Version); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version > version || applied[migration.Version] {
+			continue
+		}
+		if err := exec(ctx, conn, migration.Up); err != nil {
+			return done, fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+		}
+		if _, err := conn.ExecContext(ctx, queryInsertApplied, migration.Version, migration.Name); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int]bool, error) {
+	if _, err := conn.ExecContext(ctx, queryCreateTable); err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, queryGetApplied)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]bool{}
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		if m.find(version) < 0 {
+			return nil, fmt.Errorf("applied migration %d is unknown", version)
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+func (m *Migrator) find(version int) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// lock takes the schema_migrations named lock on conn, so that concurrent
+// migrators apply the migrations one at a time. The applied set must be
+// read only once the lock is held.
+func lock(ctx context.Context, conn *sql.Conn) error {
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, queryGetLock, lockTimeout).Scan(&acquired); err != nil {
+		return err
+	}
+	if acquired.Int64 != 1 {
+		return errors.New("could not acquire the schema_migrations lock")
+	}
+	return nil
+}
+
+// unlock releases the lock taken by lock. It does not use the context of
+// the run, which may already be done.
+func unlock(conn *sql.Conn) {
+	conn.ExecContext(context.Background(), queryReleaseLock)
+}
+
+func exec(ctx context.Context, conn *sql.Conn, script string) error {
+	for _, statement := range Statements(script) {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
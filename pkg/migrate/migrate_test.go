@@ -0,0 +1,208 @@
+package migrate_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/migrate"
+	"github.com/stretchr/testify/assert"
+)
+
+var files = fstest.MapFS{
+	"0001_create_sellers.up.sql":   {Data: []byte("CREATE TABLE sellers (id INT);")},
+	"0001_create_sellers.down.sql": {Data: []byte("DROP TABLE sellers;")},
+	"0002_add_cid.up.sql":          {Data: []byte("-- cid of the seller; unique\nALTER TABLE sellers ADD cid INT;\nCREATE UNIQUE INDEX cid ON sellers (cid);")},
+	"0002_add_cid.down.sql":        {Data: []byte("ALTER TABLE sellers DROP cid;")},
+	"README.md":                    {Data: []byte("not a migration")},
+}
+
+func load(t *testing.T) []migrate.Migration {
+	migrations, err := migrate.Load(files)
+	assert.NoError(t, err)
+	return migrations
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT GET_LOCK('schema_migrations', ?)")).WithArgs(60).WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(1))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT RELEASE_LOCK('schema_migrations')")).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectApplied(mock sqlmock.Sqlmock, versions ...int) {
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version"})
+	for _, version := range versions {
+		rows.AddRow(version)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM schema_migrations")).WillReturnRows(rows)
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("sorted by version", func(t *testing.T) {
+		migrations := load(t)
+		assert.Len(t, migrations, 2)
+		assert.Equal(t, 1, migrations[0].Version)
+		assert.Equal(t, "create_sellers", migrations[0].Name)
+		assert.Equal(t, "add_cid", migrations[1].Name)
+		assert.Equal(t, "DROP TABLE sellers;", migrations[0].Down)
+	})
+
+	t.Run("rejects a migration without down script", func(t *testing.T) {
+		_, err := migrate.Load(fstest.MapFS{"0001_a.up.sql": {Data: []byte("SELECT 1;")}})
+		assert.EqualError(t, err, "migration 1_a must have an up and a down script")
+	})
+
+	t.Run("rejects duplicate versions", func(t *testing.T) {
+		_, err := migrate.Load(fstest.MapFS{
+			"0001_a.up.sql": {Data: []byte("SELECT 1;")},
+			"0001_b.up.sql": {Data: []byte("SELECT 1;")},
+		})
+		assert.EqualError(t, err, "duplicate migration version 1")
+	})
+}
+
+func TestStatements(t *testing.T) {
+	statements := migrate.Statements(`
+-- a comment; with a semicolon
+SET @OLD_SQL_MODE=@@SQL_MODE, SQL_MODE='A;B';
+/* block; comment */ INSERT INTO t (name) VALUES ("it's; fine"), ('x\';y');
+CREATE TABLE ` + "`a;b`" + ` (id INT)`)
+
+	assert.Equal(t, []string{
+		"SET @OLD_SQL_MODE=@@SQL_MODE, SQL_MODE='A;B'",
+		`INSERT INTO t (name) VALUES ("it's; fine"), ('x\';y')`,
+		"CREATE TABLE `a;b` (id INT)",
+	}, statements)
+}
+
+func TestUp(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	expectLock(mock)
+	expectApplied(mock, 1)
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE sellers ADD cid INT")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE UNIQUE INDEX cid ON sellers (cid)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).WithArgs(2, "add_cid").WillReturnResult(sqlmock.NewResult(0, 1))
+	expectUnlock(mock)
+
+	applied, err := migrate.New(db, load(t)).Up(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, 2, applied[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDown(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	expectLock(mock)
+	expectApplied(mock, 1, 2)
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE sellers DROP cid")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations")).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectUnlock(mock)
+
+	reverted, err := migrate.New(db, load(t)).Down(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, reverted, 1)
+	assert.Equal(t, 2, reverted[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTo(t *testing.T) {
+	t.Run("rejects an unknown version", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		_, err = migrate.New(db, load(t)).To(context.Background(), 3)
+		assert.EqualError(t, err, "unknown migration version 3")
+	})
+
+	t.Run("stops at the failing migration", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectLock(mock)
+		expectApplied(mock)
+		mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE sellers")).WillReturnError(assert.AnError)
+		expectUnlock(mock)
+
+		applied, err := migrate.New(db, load(t)).To(context.Background(), 2)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rejects an applied migration it does not know", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectLock(mock)
+		expectApplied(mock, 1, 2, 3)
+		expectUnlock(mock)
+
+		_, err = migrate.New(db, load(t)).To(context.Background(), 1)
+		assert.EqualError(t, err, "applied migration 3 is unknown")
+	})
+
+	t.Run("fails when another migrator holds the lock", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT GET_LOCK('schema_migrations', ?)")).WithArgs(60).WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(0))
+
+		applied, err := migrate.New(db, load(t)).To(context.Background(), 2)
+
+		assert.EqualError(t, err, "could not acquire the schema_migrations lock")
+		assert.Empty(t, applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	expectApplied(mock, 1)
+
+	status, err := migrate.New(db, load(t)).Status(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []migrate.Status{
+		{Version: 1, Name: "create_sellers", Applied: true},
+		{Version: 2, Name: "add_cid", Applied: false},
+	}, status)
+}
+
+func TestForce(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	expectLock(mock)
+	expectApplied(mock)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).WithArgs(1, "create_sellers").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).WithArgs(2, "add_cid").WillReturnResult(sqlmock.NewResult(0, 1))
+	expectUnlock(mock)
+
+	err = migrate.New(db, load(t)).Force(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
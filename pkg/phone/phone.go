@@ -0,0 +1,144 @@
+// Package phone parses Brazilian and international telephone numbers into
+// E.164, the form stored in the database, along with a display format.
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	brazilCode = "55"
+
+	// E.164 numbers have at most 15 digits, country code included.
+	maxDigits = 15
+	minDigits = 8
+)
+
+var (
+	ErrInvalid      = errors.New("telephone must be a valid number, e.g. +55 11 99999-0000")
+	ErrInvalidArea  = errors.New("telephone has an invalid area code (DDD)")
+	ErrInvalidRange = errors.New("telephone has an invalid number for its area code")
+)
+
+// areaCodes are the Brazilian DDDs in use.
+var areaCodes = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true, "16": true, "17": true, "18": true, "19": true,
+	"21": true, "22": true, "24": true, "27": true, "28": true,
+	"31": true, "32": true, "33": true, "34": true, "35": true, "37": true, "38": true,
+	"41": true, "42": true, "43": true, "44": true, "45": true, "46": true, "47": true, "48": true, "49": true,
+	"51": true, "53": true, "54": true, "55": true,
+	"61": true, "62": true, "63": true, "64": true, "65": true, "66": true, "67": true, "68": true, "69": true,
+	"71": true, "73": true, "74": true, "75": true, "77": true, "79": true,
+	"81": true, "82": true, "83": true, "84": true, "85": true, "86": true, "87": true, "88": true, "89": true,
+	"91": true, "92": true, "93": true, "94": true, "95": true, "96": true, "97": true, "98": true, "99": true,
+}
+
+type Number struct {
+	E164    string
+	Display string
+}
+
+func (n Number) String() string {
+	return n.E164
+}
+
+// Parse accepts a number with or without punctuation. Numbers starting with
+// "+" or "00" are international; any other number is Brazilian and must
+// carry its DDD, optionally preceded by the 0 trunk prefix or by 55.
+func Parse(value string) (Number, error) {
+	value = strings.TrimSpace(value)
+	international := strings.HasPrefix(value, "+")
+
+	digits, ok := digitsOf(strings.TrimPrefix(value, "+"))
+	if !ok {
+		return Number{}, ErrInvalid
+	}
+
+	if !international && strings.HasPrefix(digits, "00") {
+		international = true
+		digits = digits[2:]
+	}
+
+	if !international {
+		digits = strings.TrimPrefix(digits, "0")
+		if len(digits) > 11 && strings.HasPrefix(digits, brazilCode) {
+			digits = digits[len(brazilCode):]
+		}
+		return parseBrazilian(digits)
+	}
+
+	if strings.HasPrefix(digits, brazilCode) {
+		return parseBrazilian(digits[len(brazilCode):])
+	}
+
+	if len(digits) < minDigits || len(digits) > maxDigits || digits[0] == '0' {
+		return Number{}, ErrInvalid
+	}
+
+	return Number{E164: "+" + digits, Display: "+" + digits}, nil
+}
+
+// parseBrazilian validates a national number made of the DDD followed by 8
+// digits for landlines or 9 digits, starting with 9, for mobiles.
+func parseBrazilian(digits string) (Number, error) {
+	if len(digits) != 10 && len(digits) != 11 {
+		return Number{}, ErrInvalid
+	}
+
+	area, subscriber := digits[:2], digits[2:]
+	if !areaCodes[area] {
+		return Number{}, ErrInvalidArea
+	}
+
+	if len(subscriber) == 9 && subscriber[0] != '9' {
+		return Number{}, ErrInvalidRange
+	}
+
+	if len(subscriber) == 8 && (subscriber[0] < '2' || subscriber[0] > '5') {
+		return Number{}, ErrInvalidRange
+	}
+
+	split := len(subscriber) - 4
+	return Number{
+		E164:    "+" + brazilCode + digits,
+		Display: "(" + area + ") " + subscriber[:split] + "-" + subscriber[split:],
+	}, nil
+}
+
+// digitsOf drops the usual separators and fails on any other character.
+func digitsOf(value string) (string, bool) {
+	var digits strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", false
+		}
+	}
+	return digits.String(), digits.Len() > 0
+}
+
+// ToRequestData writes the number into the telephone keys of an update
+// request.
+func (n Number) ToRequestData(requestData map[string]interface{}) {
+	requestData["telephone"] = n.E164
+	requestData["telephone_display"] = n.Display
+}
+
+// UpdatedColumns are the columns an update request writes besides the
+// telephone. telephone_display is only written along with the telephone, as
+// ToRequestData sets them together.
+func UpdatedColumns(requestData map[string]interface{}) []string {
+	columns := []string{}
+	if _, ok := requestData["telephone"]; !ok {
+		return columns
+	}
+
+	if _, ok := requestData["telephone_display"]; ok {
+		columns = append(columns, "telephone_display")
+	}
+	return columns
+}
@@ -0,0 +1,72 @@
+package phone_test
+
+import (
+	"testing"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/phone"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("brazilian mobile with punctuation", func(t *testing.T) {
+		number, err := phone.Parse("(11) 99999-0000")
+		assert.NoError(t, err)
+		assert.Equal(t, phone.Number{E164: "+5511999990000", Display: "(11) 99999-0000"}, number)
+	})
+
+	t.Run("brazilian landline with trunk prefix", func(t *testing.T) {
+		number, err := phone.Parse("048 3222-1100")
+		assert.NoError(t, err)
+		assert.Equal(t, phone.Number{E164: "+554832221100", Display: "(48) 3222-1100"}, number)
+	})
+
+	t.Run("brazilian number with country code", func(t *testing.T) {
+		for _, value := range []string{"+55 48 99900-1122", "5548999001122", "0055 48 99900-1122"} {
+			number, err := phone.Parse(value)
+			assert.NoError(t, err, value)
+			assert.Equal(t, "+5548999001122", number.E164, value)
+		}
+	})
+
+	t.Run("international number", func(t *testing.T) {
+		number, err := phone.Parse("+1 (415) 555-2671")
+		assert.NoError(t, err)
+		assert.Equal(t, phone.Number{E164: "+14155552671", Display: "+14155552671"}, number)
+	})
+
+	t.Run("rejects numbers without area code", func(t *testing.T) {
+		for _, value := range []string{"123", "3003", "99999-0000"} {
+			_, err := phone.Parse(value)
+			assert.Equal(t, phone.ErrInvalid, err, value)
+		}
+	})
+
+	t.Run("rejects unknown area codes", func(t *testing.T) {
+		_, err := phone.Parse("(20) 99999-0000")
+		assert.Equal(t, phone.ErrInvalidArea, err)
+	})
+
+	t.Run("rejects numbers out of the mobile and landline ranges", func(t *testing.T) {
+		_, err := phone.Parse("(11) 89999-0000")
+		assert.Equal(t, phone.ErrInvalidRange, err)
+
+		_, err = phone.Parse("(11) 9999-0000")
+		assert.Equal(t, phone.ErrInvalidRange, err)
+	})
+
+	t.Run("rejects letters and oversized international numbers", func(t *testing.T) {
+		_, err := phone.Parse("11 9999A-0000")
+		assert.Equal(t, phone.ErrInvalid, err)
+
+		_, err = phone.Parse("+1234567890123456")
+		assert.Equal(t, phone.ErrInvalid, err)
+	})
+}
+
+func TestUpdatedColumns(t *testing.T) {
+	requestData := map[string]interface{}{}
+	phone.Number{E164: "+554832221100", Display: "(48) 3222-1100"}.ToRequestData(requestData)
+	assert.Equal(t, []string{"telephone_display"}, phone.UpdatedColumns(requestData))
+
+	assert.Empty(t, phone.UpdatedColumns(map[string]interface{}{"telephone_display": "(48) 3222-1100"}))
+}
@@ -0,0 +1,109 @@
+// Package transaction lets a service run the calls of several repositories
+// in one database transaction.
+//
+// The transaction travels in the context given to the repositories, so a
+// repository runs the same queries whether it is called inside Run or not.
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// DBTX is implemented by both *sql.DB and *sql.Tx.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Manager runs fn in a transaction that is committed when fn returns nil
+// and rolled back otherwise. The repositories called with the context given
+// to fn take part in the transaction; a Run inside another one joins it.
+type Manager interface {
+	Run(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+// DB is the database handle of the repositories. Its queries run in the
+// transaction of their context, if any, and straight on the database
+// otherwise.
+type DB struct {
+	conn *sql.DB
+}
+
+func NewDB(conn *sql.DB) DB {
+	return DB{conn: conn}
+}
+
+func (db DB) Run(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if InTx(ctx) {
+		return fn(ctx)
+	}
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return db.with(ctx).ExecContext(ctx, query, args...)
+}
+
+func (db DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return db.with(ctx).QueryContext(ctx, query, args...)
+}
+
+func (db DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return db.with(ctx).QueryRowContext(ctx, query, args...)
+}
+
+func (db DB) with(ctx context.Context) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db.conn
+}
+
+// InTx reports whether ctx was created by Run.
+func InTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*sql.Tx)
+	return ok
+}
+
+// Lock makes query, a SELECT, hold a shared lock on the rows it reads until
+// the transaction of ctx ends, so the checks a service makes before writing
+// still hold when it writes. Outside of a transaction query is unchanged.
+func Lock(ctx context.Context, query string) string {
+	if !InTx(ctx) {
+		return query
+	}
+	return strings.TrimSuffix(strings.TrimSpace(query), ";") + " LOCK IN SHARE MODE"
+}
+
+// Nop runs fn without a transaction. It is meant for services whose
+// repositories are mocked.
+var Nop Manager = nop{}
+
+type nop struct{}
+
+func (nop) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,154 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+const queryInsert = "INSERT INTO sections (section_number) VALUES (?)"
+
+func TestRun(t *testing.T) {
+	t.Run("commits when fn succeeds", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(queryInsert)).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(queryInsert)).WithArgs(2).WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		tdb := transaction.NewDB(db)
+		err = tdb.Run(context.Background(), func(ctx context.Context) error {
+			assert.True(t, transaction.InTx(ctx))
+			if _, err := tdb.ExecContext(ctx, queryInsert, 1); err != nil {
+				return err
+			}
+			_, err := tdb.ExecContext(ctx, queryInsert, 2)
+			return err
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back when fn fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(queryInsert)).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectRollback()
+
+		tdb := transaction.NewDB(db)
+		err = tdb.Run(context.Background(), func(ctx context.Context) error {
+			if _, err := tdb.ExecContext(ctx, queryInsert, 1); err != nil {
+				return err
+			}
+			return errors.New("section with section_number 1 already exists")
+		})
+
+		assert.EqualError(t, err, "section with section_number 1 already exists")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back and panics again when fn panics", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		assert.Panics(t, func() {
+			transaction.NewDB(db).Run(context.Background(), func(ctx context.Context) error {
+				panic("boom")
+			})
+		})
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nested runs join the outer transaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(queryInsert)).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tdb := transaction.NewDB(db)
+		err = tdb.Run(context.Background(), func(ctx context.Context) error {
+			return tdb.Run(ctx, func(ctx context.Context) error {
+				_, err := tdb.ExecContext(ctx, queryInsert, 1)
+				return err
+			})
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fails when the transaction can not begin", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin().WillReturnError(errors.New("connection refused"))
+
+		called := false
+		err = transaction.NewDB(db).Run(context.Background(), func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+
+		assert.EqualError(t, err, "connection refused")
+		assert.False(t, called)
+	})
+
+	t.Run("queries outside of Run use the database", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(queryInsert)).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		_, err = transaction.NewDB(db).ExecContext(context.Background(), queryInsert, 1)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLock(t *testing.T) {
+	query := "SELECT id FROM sections WHERE id = ?;"
+	assert.Equal(t, query, transaction.Lock(context.Background(), query))
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	transaction.NewDB(db).Run(context.Background(), func(ctx context.Context) error {
+		assert.Equal(t, "SELECT id FROM sections WHERE id = ? LOCK IN SHARE MODE", transaction.Lock(ctx, query))
+		return nil
+	})
+}
+
+func TestNop(t *testing.T) {
+	err := transaction.Nop.Run(context.Background(), func(ctx context.Context) error {
+		assert.False(t, transaction.InTx(ctx))
+		return errors.New("some error")
+	})
+
+	assert.EqualError(t, err, "some error")
+}
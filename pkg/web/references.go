@@ -0,0 +1,59 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Reference counts the rows of a table still pointing to a record.
+type Reference struct {
+	Table string
+	Count int
+}
+
+// ReferencedBy answers 409 naming the references that keep the record of
+// entity from being deleted, or an empty ResponseCode when none is left.
+func ReferencedBy(entity string, id interface{}, references ...Reference) ResponseCode {
+	blocking := []string{}
+	for _, reference := range references {
+		if reference.Count > 0 {
+			blocking = append(blocking, fmt.Sprintf("%d %s", reference.Count, reference.Table))
+		}
+	}
+
+	if len(blocking) == 0 {
+		return ResponseCode{}
+	}
+
+	list := blocking[len(blocking)-1]
+	if len(blocking) > 1 {
+		list = strings.Join(blocking[:len(blocking)-1], ", ") + " and " + list
+	}
+
+	return NewCodeResponse(http.StatusConflict, fmt.Errorf("%s with id %v is used by %s", entity, id, list))
+}
+
+// ParseReassignTo reads the optional reassign_to query parameter of the
+// delete of the record id, the record taking over its dependents.
+func ParseReassignTo(c *gin.Context, id int) (targetId int, ok bool, resp ResponseCode) {
+	reassignTo, ok := c.GetQuery("reassign_to")
+	if !ok {
+		return 0, false, ResponseCode{}
+	}
+
+	targetId, err := strconv.Atoi(reassignTo)
+	if err != nil {
+		return 0, true, NewCodeResponse(http.StatusBadRequest, errors.New("reassign_to must be a number"))
+	}
+
+	if targetId == id {
+		return 0, true, NewCodeResponse(http.StatusUnprocessableEntity, errors.New("reassign_to must be different from the deleted id"))
+	}
+
+	return targetId, true, ResponseCode{}
+}
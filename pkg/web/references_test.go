@@ -0,0 +1,67 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferencedBy(t *testing.T) {
+	t.Run("no references left", func(t *testing.T) {
+		resp := web.ReferencedBy("seller", 1, web.Reference{Table: "products", Count: 0})
+		assert.Equal(t, web.ResponseCode{}, resp)
+	})
+
+	t.Run("lists only the blocking references", func(t *testing.T) {
+		resp := web.ReferencedBy("warehouse", 1,
+			web.Reference{Table: "sections", Count: 1},
+			web.Reference{Table: "employees", Count: 0},
+			web.Reference{Table: "inbound_orders", Count: 3},
+		)
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, "warehouse with id 1 is used by 1 sections and 3 inbound_orders", resp.Err.Error())
+	})
+
+	t.Run("joins more than two references", func(t *testing.T) {
+		resp := web.ReferencedBy("locality", "6700",
+			web.Reference{Table: "sellers", Count: 2},
+			web.Reference{Table: "carriers", Count: 1},
+			web.Reference{Table: "buyers", Count: 4},
+		)
+		assert.Equal(t, "locality with id 6700 is used by 2 sellers, 1 carriers and 4 buyers", resp.Err.Error())
+	})
+}
+
+func TestParseReassignTo(t *testing.T) {
+	cases := []struct {
+		query    string
+		targetId int
+		ok       bool
+		code     int
+		err      string
+	}{
+		{"", 0, false, 0, ""},
+		{"?reassign_to=2", 2, true, 0, ""},
+		{"?reassign_to=abc", 0, true, http.StatusBadRequest, "reassign_to must be a number"},
+		{"?reassign_to=1", 0, true, http.StatusUnprocessableEntity, "reassign_to must be different from the deleted id"},
+	}
+
+	for _, currCase := range cases {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/sellers/1"+currCase.query, nil)
+
+		targetId, ok, resp := web.ParseReassignTo(c, 1)
+		assert.Equal(t, currCase.targetId, targetId, currCase.query)
+		assert.Equal(t, currCase.ok, ok, currCase.query)
+		assert.Equal(t, currCase.code, resp.Code, currCase.query)
+		if currCase.err != "" {
+			assert.Equal(t, currCase.err, resp.Err.Error(), currCase.query)
+		} else {
+			assert.Nil(t, resp.Err, currCase.query)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package web
 
 type Response struct {
-	Data  interface{} `json:"data,omitempty"`
-	Error string      `json:"error,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
 }
 
 type ResponseCode struct {
@@ -11,11 +13,21 @@ type ResponseCode struct {
 }
 
 func NewResponse(data interface{}) Response {
-	return Response{data, ""}
+	return Response{data, "", nil, nil}
+}
+
+// NewPageResponse answers one page of a list along with its pagination
+// metadata.
+func NewPageResponse(data, meta interface{}) Response {
+	return Response{data, "", nil, meta}
 }
 
 func DecodeError(err string) Response {
-	return Response{nil, err}
+	return Response{nil, err, nil, nil}
+}
+
+func DecodeErrorWithDetails(err string, details interface{}) Response {
+	return Response{nil, err, details, nil}
 }
 
 func NewCodeResponse(code int, err error) ResponseCode {
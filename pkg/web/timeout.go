@@ -0,0 +1,84 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const DefaultRequestTimeout = 30 * time.Second
+
+// Timeouts bounds how long the requests of each route may take. Routes are
+// keyed by method and gin route pattern, e.g. "GET /api/v1/sellers/:id".
+// A zero timeout leaves the requests of the route unbounded.
+type Timeouts struct {
+	Default time.Duration
+	Routes  map[string]time.Duration
+}
+
+// ParseTimeouts reads the default timeout and a comma separated list of
+// per-route overrides, e.g.
+// "GET /api/v1/localities/reportCarriers=1m,POST /api/v1/imports/:entity=5m".
+// An empty default falls back to DefaultRequestTimeout.
+func ParseTimeouts(defaultTimeout, routes string) (Timeouts, error) {
+	timeouts := Timeouts{Default: DefaultRequestTimeout, Routes: map[string]time.Duration{}}
+
+	if defaultTimeout = strings.TrimSpace(defaultTimeout); defaultTimeout != "" {
+		timeout, err := time.ParseDuration(defaultTimeout)
+		if err != nil || timeout < 0 {
+			return Timeouts{}, fmt.Errorf("invalid request timeout %s", defaultTimeout)
+		}
+		timeouts.Default = timeout
+	}
+
+	for _, route := range strings.Split(routes, ",") {
+		if route = strings.TrimSpace(route); route == "" {
+			continue
+		}
+
+		separator := strings.LastIndex(route, "=")
+		if separator < 0 {
+			return Timeouts{}, fmt.Errorf("invalid route timeout %s", route)
+		}
+
+		fields := strings.Fields(route[:separator])
+		timeout, err := time.ParseDuration(strings.TrimSpace(route[separator+1:]))
+		if len(fields) != 2 || err != nil || timeout < 0 {
+			return Timeouts{}, fmt.Errorf("invalid route timeout %s", route)
+		}
+
+		timeouts.Routes[strings.ToUpper(fields[0])+" "+fields[1]] = timeout
+	}
+
+	return timeouts, nil
+}
+
+func (t Timeouts) For(method, route string) time.Duration {
+	if timeout, ok := t.Routes[method+" "+route]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// Timeout replaces the context of each request with one that is cancelled
+// once the timeout of its route expires, so the queries run with it stop
+// along with the request. The context is also cancelled when the client
+// disconnects.
+func Timeout(timeouts Timeouts) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := timeouts.For(c.Request.Method, c.FullPath())
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
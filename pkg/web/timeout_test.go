@@ -0,0 +1,66 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/emidioreb/mercado-fresco-lerigophers/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeouts(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		timeouts, err := web.ParseTimeouts("", "")
+		assert.NoError(t, err)
+		assert.Equal(t, web.DefaultRequestTimeout, timeouts.Default)
+		assert.Empty(t, timeouts.Routes)
+	})
+
+	t.Run("default and route timeouts", func(t *testing.T) {
+		timeouts, err := web.ParseTimeouts("5s", "get /api/v1/sellers/:id=1s, POST /api/v1/imports/:entity=2m")
+		assert.NoError(t, err)
+		assert.Equal(t, 5*time.Second, timeouts.Default)
+		assert.Equal(t, time.Second, timeouts.For(http.MethodGet, "/api/v1/sellers/:id"))
+		assert.Equal(t, 2*time.Minute, timeouts.For(http.MethodPost, "/api/v1/imports/:entity"))
+		assert.Equal(t, 5*time.Second, timeouts.For(http.MethodDelete, "/api/v1/sellers/:id"))
+	})
+
+	t.Run("rejects invalid timeouts", func(t *testing.T) {
+		_, err := web.ParseTimeouts("soon", "")
+		assert.EqualError(t, err, "invalid request timeout soon")
+
+		_, err = web.ParseTimeouts("", "/api/v1/sellers=1s")
+		assert.EqualError(t, err, "invalid route timeout /api/v1/sellers=1s")
+
+		_, err = web.ParseTimeouts("", "GET /api/v1/sellers=-1s")
+		assert.EqualError(t, err, "invalid route timeout GET /api/v1/sellers=-1s")
+	})
+}
+
+func TestTimeout(t *testing.T) {
+	timeouts := web.Timeouts{
+		Default: time.Minute,
+		Routes:  map[string]time.Duration{"GET /unbounded": 0},
+	}
+
+	r := gin.New()
+	r.Use(web.Timeout(timeouts))
+
+	var deadline time.Time
+	var bounded bool
+	handler := func(c *gin.Context) {
+		deadline, bounded = c.Request.Context().Deadline()
+	}
+	r.GET("/bounded/:id", handler)
+	r.GET("/unbounded", handler)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/bounded/1", nil))
+	assert.True(t, bounded)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unbounded", nil))
+	assert.False(t, bounded)
+}